@@ -2,7 +2,9 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/spf13/cobra"
 
@@ -19,6 +21,7 @@ func newBoardCommand() *cobra.Command {
 	b.AddCommand(newBoardListCommand())
 	b.AddCommand(newBoardGetCommand())
 	b.AddCommand(newBoardCreateCommand())
+	b.AddCommand(newBoardCloneCommand())
 	b.AddCommand(newBoardUpdateCommand())
 	b.AddCommand(newBoardDeleteCommand())
 	return b
@@ -76,10 +79,38 @@ func newBoardCreateCommand() *cobra.Command {
 	cmd.Flags().String("workspace-id", "", "workspace ID")
 	cmd.Flags().String("workspace", "", "workspace name")
 	cmd.Flags().StringArray("column", nil, `column spec "Name:#RRGGBB" (can be repeated)`)
+	cmd.Flags().String("template", "", "create the board from this template (see kanji template list)")
 	cmd.Flags().Bool("set-context", false, "set board context after creation")
 	return cmd
 }
 
+func newBoardCloneCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "clone",
+		Short: "Create a copy of a board",
+		Long: `Create a board in the same workspace with the columns, colors and WIP limits
+of another. --with-tasks copies its tasks too, keeping their columns, order,
+labels and due dates; comments are not copied.`,
+		Example: `  kanji board clone --board "Sprint 12" --name "Sprint 13"
+  kanji board clone --board-id <id> --with-tasks`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns, err := ResolveNamespace()
+			if err != nil {
+				return err
+			}
+			return runBoardClone(cmd, ns)
+		},
+	}
+	cmd.Flags().String("board-id", "", "board ID")
+	cmd.Flags().String("board", "", "board name")
+	cmd.Flags().String("workspace-id", "", "workspace ID (required for name resolution)")
+	cmd.Flags().String("workspace", "", "workspace name (required for name resolution)")
+	cmd.Flags().String("name", "", `name of the copy (default: "<board> (copy)")`)
+	cmd.Flags().Bool("with-tasks", false, "copy the tasks of the board too")
+	cmd.Flags().Bool("set-context", false, "set board context to the copy")
+	return cmd
+}
+
 func newBoardUpdateCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "update",
@@ -172,10 +203,26 @@ func runBoardCreateWithStore(cmd *cobra.Command, ns Namespace, store *state.Stor
 		return NewValidation("name is required")
 	}
 
-	// Parse columns.
+	// Create from a template, custom columns, or the default columns.
 	var board domain.Board
 	columnRaws, _ := cmd.Flags().GetStringArray("column")
-	if len(columnRaws) > 0 {
+	templateName, _ := cmd.Flags().GetString("template")
+	if len(columnRaws) > 0 && templateName != "" {
+		return NewValidation("use either --template or --column")
+	}
+	if templateName != "" {
+		template, err := rt.TemplateService.GetTemplate(ctx, templateName)
+		if errors.Is(err, application.ErrTemplateNotFound) {
+			return NewNotFound("template", templateName)
+		}
+		if err != nil {
+			return err
+		}
+		board, err = rt.TemplateService.CreateBoardFromTemplate(ctx, workspaceID, name, template)
+		if err != nil {
+			return err
+		}
+	} else if len(columnRaws) > 0 {
 		specs, err := ParseColumnSpecs(columnRaws)
 		if err != nil {
 			return err
@@ -218,6 +265,72 @@ func runBoardCreateWithStore(cmd *cobra.Command, ns Namespace, store *state.Stor
 	})
 }
 
+func runBoardClone(cmd *cobra.Command, ns Namespace) error {
+	store, err := defaultStateStore()
+	if err != nil {
+		return err
+	}
+	return runBoardCloneWithStore(cmd, ns, store)
+}
+
+func runBoardCloneWithStore(cmd *cobra.Command, ns Namespace, store *state.Store) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	if err := GuardBootstrap(rt); err != nil {
+		return err
+	}
+
+	workspaceID, _, err := ResolveWorkspaceScope(cmd, rt, store, ns)
+	if err != nil {
+		return err
+	}
+	boardID, _, err := ResolveBoardScope(cmd, rt, store, ns, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	var input application.CloneBoardInput
+	input.Name, _ = cmd.Flags().GetString("name")
+	input.WithTasks, _ = cmd.Flags().GetBool("with-tasks")
+	board, copied, err := rt.TemplateService.CloneBoard(context.Background(), boardID, input)
+	if err != nil {
+		return err
+	}
+
+	setCtx, _ := cmd.Flags().GetBool("set-context")
+	if setCtx {
+		if err := store.SetCLIContext(ns.Key, state.CLIContext{
+			WorkspaceID: workspaceID,
+			BoardID:     board.ID,
+		}); err != nil {
+			return err
+		}
+	}
+
+	if cfg.JSON {
+		return RenderWriteResultJSON(cmd.OutOrStdout(), "board", map[string]interface{}{
+			"id":           board.ID,
+			"name":         board.Name,
+			"source_id":    boardID,
+			"tasks_copied": copied,
+		})
+	}
+
+	return RenderWriteResult(cmd.OutOrStdout(), "Board", board.ID, map[string]string{
+		"Name":         board.Name,
+		"Tasks copied": strconv.Itoa(copied),
+	})
+}
+
 func runBoardUpdate(cmd *cobra.Command, ns Namespace) error {
 	store, err := defaultStateStore()
 	if err != nil {
@@ -454,10 +567,7 @@ func runBoardGet(cmd *cobra.Command, ns Namespace) error {
 	}
 
 	if cfg.JSON {
-		return RenderWrappedJSON(cmd.OutOrStdout(), "board", map[string]string{
-			"id":   board.ID,
-			"name": board.Name,
-		})
+		return RenderWrappedJSON(cmd.OutOrStdout(), "board", boardJSON(board))
 	}
 
 	return RenderKV(cmd.OutOrStdout(), map[string]string{
@@ -547,10 +657,7 @@ func runBoardListWithStore(cmd *cobra.Command, ns Namespace, store *state.Store)
 	if cfg.JSON {
 		items := make([]map[string]string, len(boards))
 		for i, b := range boards {
-			items[i] = map[string]string{
-				"id":   b.ID,
-				"name": b.Name,
-			}
+			items[i] = boardJSON(b)
 		}
 		return RenderWrappedListJSON(cmd.OutOrStdout(), "boards", items, len(boards))
 	}
@@ -189,16 +189,7 @@ func runColumnCreateWithStore(cmd *cobra.Command, ns Namespace, store *state.Sto
 	}
 
 	if cfg.JSON {
-		payload := map[string]interface{}{
-			"id":       column.ID,
-			"name":     column.Name,
-			"color":    column.Color,
-			"position": column.Position,
-		}
-		if column.WIPLimit != nil {
-			payload["wip_limit"] = *column.WIPLimit
-		}
-		return RenderWriteResultJSON(cmd.OutOrStdout(), "column", payload)
+		return RenderWriteResultJSON(cmd.OutOrStdout(), "column", columnJSON(column))
 	}
 
 	fields := map[string]string{
@@ -532,16 +523,7 @@ func runColumnGet(cmd *cobra.Command, ns Namespace) error {
 	}
 
 	if cfg.JSON {
-		payload := map[string]interface{}{
-			"id":       column.ID,
-			"name":     column.Name,
-			"color":    column.Color,
-			"position": column.Position,
-		}
-		if column.WIPLimit != nil {
-			payload["wip_limit"] = *column.WIPLimit
-		}
-		return RenderWrappedJSON(cmd.OutOrStdout(), "column", payload)
+		return RenderWrappedJSON(cmd.OutOrStdout(), "column", columnJSON(column))
 	}
 
 	pairs := map[string]string{
@@ -627,13 +609,7 @@ func runColumnListWithStore(cmd *cobra.Command, ns Namespace, store *state.Store
 	if cfg.JSON {
 		items := make([]map[string]interface{}, len(columns))
 		for i, c := range columns {
-			items[i] = map[string]interface{}{
-				"id":        c.ID,
-				"name":      c.Name,
-				"color":     c.Color,
-				"position":  c.Position,
-				"wip_limit": c.WIPLimit,
-			}
+			items[i] = columnListItemJSON(c)
 		}
 		return RenderWrappedListJSON(cmd.OutOrStdout(), "columns", items, len(columns))
 	}
@@ -782,16 +758,7 @@ func runColumnUpdate(cmd *cobra.Command, ns Namespace) error {
 	}
 
 	if cfg.JSON {
-		payload := map[string]interface{}{
-			"id":       updated.ID,
-			"name":     updated.Name,
-			"color":    updated.Color,
-			"position": updated.Position,
-		}
-		if updated.WIPLimit != nil {
-			payload["wip_limit"] = *updated.WIPLimit
-		}
-		return RenderWrappedJSON(cmd.OutOrStdout(), "column", payload)
+		return RenderWrappedJSON(cmd.OutOrStdout(), "column", columnJSON(updated))
 	}
 
 	pairs := map[string]string{
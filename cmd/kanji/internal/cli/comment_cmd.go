@@ -63,6 +63,7 @@ Supports inline text, file input, or stdin (-).`,
 	cmd.Flags().String("comment-id", "", "comment ID")
 	cmd.Flags().String("body", "", "new comment body")
 	cmd.Flags().String("body-file", "", "path to file containing comment body (- for stdin)")
+	cmd.Flags().Int("if-version", 0, "fail unless the comment is still at this version")
 	return cmd
 }
 
@@ -101,28 +102,48 @@ func runCommentCreate(cmd *cobra.Command, ns Namespace) error {
 		return err
 	}
 
-	ctx := context.Background()
+	comment, err := createComment(context.Background(), cmd, rt)
+	if err != nil {
+		return err
+	}
+
+	if cfg.JSON {
+		return RenderWriteResultJSON(cmd.OutOrStdout(), "comment", commentJSON(comment))
+	}
+
+	fields := map[string]string{
+		"Task ID": comment.TaskID,
+		"Body":    comment.BodyMD,
+	}
+	if comment.Author != nil {
+		fields["Author"] = *comment.Author
+	}
+	return RenderWriteResult(cmd.OutOrStdout(), "comment", comment.ID, fields)
+}
 
+// createComment resolves the task and body from the flags of
+// `kanji comment create` and adds the comment.
+func createComment(ctx context.Context, cmd *cobra.Command, rt *Runtime) (domain.Comment, error) {
 	// Resolve task.
 	var taskID string
 	if cmd.Flags().Changed("task-id") {
 		taskID, _ = cmd.Flags().GetString("task-id")
 	} else {
-		return NewValidation("task-id is required")
+		return domain.Comment{}, NewValidation("task-id is required")
 	}
 
 	task, err := rt.TaskService.GetTask(ctx, taskID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return NewNotFound("task", taskID)
+			return domain.Comment{}, NewNotFound("task", taskID)
 		}
-		return err
+		return domain.Comment{}, err
 	}
 
 	// Resolve body.
 	body, err := ResolveTextInput(cmd, "body", "body-file", false, nil)
 	if err != nil {
-		return err
+		return domain.Comment{}, err
 	}
 
 	// Optional author.
@@ -139,29 +160,9 @@ func runCommentCreate(cmd *cobra.Command, ns Namespace) error {
 		Author:     author,
 	})
 	if err != nil {
-		return err
+		return domain.Comment{}, NewHookRejected(err)
 	}
-
-	if cfg.JSON {
-		payload := map[string]interface{}{
-			"id":      comment.ID,
-			"task_id": comment.TaskID,
-			"body":    comment.BodyMD,
-		}
-		if comment.Author != nil {
-			payload["author"] = *comment.Author
-		}
-		return RenderWriteResultJSON(cmd.OutOrStdout(), "comment", payload)
-	}
-
-	fields := map[string]string{
-		"Task ID": comment.TaskID,
-		"Body":    comment.BodyMD,
-	}
-	if comment.Author != nil {
-		fields["Author"] = *comment.Author
-	}
-	return RenderWriteResult(cmd.OutOrStdout(), "comment", comment.ID, fields)
+	return comment, nil
 }
 
 func newCommentListCommand() *cobra.Command {
@@ -226,9 +227,13 @@ func runCommentUpdate(cmd *cobra.Command, ns Namespace) error {
 		return err
 	}
 
-	if err := rt.CommentService.UpdateComment(ctx, commentID, body); err != nil {
+	ifVersion, err := ifVersionFlag(cmd)
+	if err != nil {
 		return err
 	}
+	if err := rt.CommentService.UpdateComment(ctx, commentID, body, ifVersion); err != nil {
+		return NewHookRejected(NewVersionConflict(err))
+	}
 
 	if cfg.JSON {
 		return RenderWriteResultJSON(cmd.OutOrStdout(), "comment", map[string]interface{}{
@@ -274,7 +279,7 @@ func runCommentDelete(cmd *cobra.Command, ns Namespace) error {
 	}
 
 	if err := rt.CommentService.DeleteComment(ctx, commentID); err != nil {
-		return err
+		return NewHookRejected(err)
 	}
 
 	if cfg.JSON {
@@ -348,15 +353,7 @@ func runCommentGet(cmd *cobra.Command, ns Namespace) error {
 	}
 
 	if cfg.JSON {
-		payload := map[string]interface{}{
-			"id":      comment.ID,
-			"task_id": comment.TaskID,
-			"body":    comment.BodyMD,
-		}
-		if comment.Author != nil {
-			payload["author"] = *comment.Author
-		}
-		return RenderWrappedJSON(cmd.OutOrStdout(), "comment", payload)
+		return RenderWrappedJSON(cmd.OutOrStdout(), "comment", commentJSON(comment))
 	}
 
 	pairs := map[string]string{
@@ -404,21 +401,7 @@ func runCommentList(cmd *cobra.Command, ns Namespace) error {
 	if cfg.JSON {
 		items := make([]map[string]string, len(comments))
 		for i, c := range comments {
-			preview := c.BodyMD
-			if len(preview) > 50 {
-				preview = preview[:47] + "..."
-			}
-			author := ""
-			if c.Author != nil {
-				author = *c.Author
-			}
-			items[i] = map[string]string{
-				"id":         c.ID,
-				"task_id":    c.TaskID,
-				"author":     author,
-				"created_at": c.CreatedAt.Format("2006-01-02"),
-				"preview":    preview,
-			}
+			items[i] = commentListItemJSON(c)
 		}
 		return RenderWrappedListJSON(cmd.OutOrStdout(), "comments", items, len(comments))
 	}
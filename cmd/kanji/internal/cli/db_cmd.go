@@ -187,6 +187,17 @@ func runDBDoctor(cmd *cobra.Command, ns Namespace) error {
 					findings = append(findings, doctorFinding{Code: "dangling_context", Message: d})
 				}
 			}
+
+			// Provider credentials
+			credIssues, _ := application.FindCredentialIssues(ctx, setupRepo, rt.Credentials)
+			for _, issue := range credIssues {
+				switch issue.Problem {
+				case application.CredentialUnencrypted:
+					findings = append(findings, doctorFinding{Code: "unencrypted_credentials", Message: fmt.Sprintf("provider %q stores credentials unencrypted. Run: kanji provider auth rotate --yes", issue.ProviderName)})
+				case application.CredentialUndecryptable:
+					findings = append(findings, doctorFinding{Code: "undecryptable_credentials", Message: fmt.Sprintf("provider %q credentials cannot be decrypted: %v", issue.ProviderName, issue.Err)})
+				}
+			}
 		}
 	}
 
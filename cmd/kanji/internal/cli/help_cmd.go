@@ -18,6 +18,7 @@ Available topics:
   context     Namespace and context model
   selectors   Resource selection rules
   output      Output formats and options
+  filters     Task filter expressions
 `,
 	}
 
@@ -25,6 +26,7 @@ Available topics:
 	help.AddCommand(newHelpTopicCommand("context", helpContextLong))
 	help.AddCommand(newHelpTopicCommand("selectors", helpSelectorsLong))
 	help.AddCommand(newHelpTopicCommand("output", helpOutputLong))
+	help.AddCommand(newHelpTopicCommand("filters", helpFiltersLong))
 
 	return help
 }
@@ -131,3 +133,41 @@ Verbose Output (--verbose)
   Adds runtime metadata such as namespace key, db path, and resolution details.
   Does not alter the JSON contract.
 `
+
+const helpFiltersLong = `Filters
+
+'kanji task list --filter' and the TUI filter prompt (F) narrow tasks with an
+expression such as:
+
+  priority<=high and label:bug and due<7d and not column:Done
+
+Terms
+  word, "quoted phrase"   Title or description contains the text
+  field:value             Field equals value (= works too)
+  field!=value            Field does not equal value
+  field<value             Also <=, > and >= for priority and due
+
+Fields
+  title       Title contains the text
+  text        Title or description contains the text
+  priority    0-5 or critical, urgent, high, medium, low, none; lower numbers
+              are more urgent, so priority<=high is high, urgent or critical
+  label       Has the label
+  column      Is in the column with this name
+  status      Has this status
+  assignee    Is assigned to this person
+  due         Due date: YYYY-MM-DD, RFC 3339, now, today, tomorrow,
+              yesterday, or days and weeks from today like 7d, -1d or 2w
+
+  Text comparisons ignore case. Quote values with spaces: column:"In progress".
+  label:none, column:none, status:none, assignee:none and due:none match
+  tasks without a value. Dates are whole UTC days: due:today is any time
+  today and due<=today includes later today.
+
+Combining
+  Terms next to each other must all match; "and" may be written out. "or"
+  matches either side and binds looser than "and". "not" negates the next
+  term. Parentheses group terms:
+
+  (assignee:alice or assignee:none) not label:wontfix
+`
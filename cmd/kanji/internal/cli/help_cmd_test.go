@@ -11,7 +11,7 @@ import (
 func TestHelpTopics_Exist(t *testing.T) {
 	root := NewRootCommand()
 
-	topics := []string{"concepts", "context", "selectors", "output"}
+	topics := []string{"concepts", "context", "selectors", "output", "filters"}
 	for _, topic := range topics {
 		cmd, _, err := root.Find([]string{"help", topic})
 		require.NoError(t, err, "topic %s should exist", topic)
@@ -0,0 +1,210 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+	"github.com/tiagokriok/kanji/internal/state"
+)
+
+func newTaskHistoryCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Show the change history of a task, oldest first",
+		Long: `Show who created, changed, moved, commented on or deleted a task. History is
+recorded with every change and kept after the task is deleted. The actor is
+$KANJI_ACTOR when set, otherwise the OS user running kanji.`,
+		Example: `  kanji task history --task-id <id>
+  kanji task history --task-id <id> --json`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns, err := ResolveNamespace()
+			if err != nil {
+				return err
+			}
+			return runTaskHistory(cmd, ns)
+		},
+	}
+	cmd.Flags().String("task-id", "", "task ID")
+	return cmd
+}
+
+func newActivityCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "activity",
+		Short: "Show recent task changes in a workspace, newest first",
+		Example: `  kanji activity
+  kanji activity --workspace "App" --board "Sprint" --limit 20`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns, err := ResolveNamespace()
+			if err != nil {
+				return err
+			}
+			return runActivity(cmd, ns)
+		},
+	}
+	cmd.Flags().String("workspace-id", "", "workspace ID")
+	cmd.Flags().String("workspace", "", "workspace name")
+	cmd.Flags().String("board-id", "", "board ID (optional narrowing)")
+	cmd.Flags().String("board", "", "board name (optional narrowing)")
+	cmd.Flags().Int("limit", 50, "maximum number of events to show (0 for all)")
+	return cmd
+}
+
+func runTaskHistory(cmd *cobra.Command, ns Namespace) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	if err := GuardBootstrap(rt); err != nil {
+		return err
+	}
+
+	events, err := taskHistory(context.Background(), cmd, rt)
+	if err != nil {
+		return err
+	}
+
+	if cfg.JSON {
+		return RenderWrappedListJSON(cmd.OutOrStdout(), "events", taskEventsJSON(events), len(events))
+	}
+	headers := []string{"Time", "Kind", "Actor", "Changes"}
+	rows := make([][]string, len(events))
+	for i, e := range events {
+		rows[i] = []string{e.CreatedAt.Format(time.RFC3339), e.Kind, eventActor(e), formatFieldChanges(e.Changes)}
+	}
+	return RenderTable(cmd.OutOrStdout(), headers, rows)
+}
+
+// taskHistory lists the events of the task named by --task-id. A task
+// without events is only reported missing when it does not exist either.
+func taskHistory(ctx context.Context, cmd *cobra.Command, rt *Runtime) ([]domain.TaskEvent, error) {
+	taskID, _ := cmd.Flags().GetString("task-id")
+	if strings.TrimSpace(taskID) == "" {
+		return nil, NewValidation("task-id is required")
+	}
+	events, err := rt.HistoryService.TaskHistory(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		if _, err := rt.TaskService.GetTask(ctx, taskID); err != nil {
+			return nil, NewNotFound("task", taskID)
+		}
+	}
+	return events, nil
+}
+
+func runActivity(cmd *cobra.Command, ns Namespace) error {
+	store, err := defaultStateStore()
+	if err != nil {
+		return err
+	}
+	return runActivityWithStore(cmd, ns, store)
+}
+
+func runActivityWithStore(cmd *cobra.Command, ns Namespace, store *state.Store) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	if err := GuardBootstrap(rt); err != nil {
+		return err
+	}
+
+	events, err := listActivity(context.Background(), cmd, rt, store, ns)
+	if err != nil {
+		return err
+	}
+
+	if cfg.JSON {
+		return RenderWrappedListJSON(cmd.OutOrStdout(), "events", taskEventsJSON(events), len(events))
+	}
+	headers := []string{"Time", "Task", "Kind", "Actor", "Changes"}
+	rows := make([][]string, len(events))
+	for i, e := range events {
+		rows[i] = []string{e.CreatedAt.Format(time.RFC3339), e.Title, e.Kind, eventActor(e), formatFieldChanges(e.Changes)}
+	}
+	return RenderTable(cmd.OutOrStdout(), headers, rows)
+}
+
+// listActivity resolves the workspace and optional board of `kanji
+// activity` and lists their events.
+func listActivity(ctx context.Context, cmd *cobra.Command, rt *Runtime, store *state.Store, ns Namespace) ([]domain.TaskEvent, error) {
+	limit, _ := cmd.Flags().GetInt("limit")
+	if limit < 0 {
+		return nil, NewValidation("--limit must not be negative")
+	}
+	workspaceID, _, err := ResolveWorkspaceScope(cmd, rt, store, ns)
+	if err != nil {
+		return nil, err
+	}
+	var boardID string
+	if cmd.Flags().Changed("board-id") || cmd.Flags().Changed("board") {
+		if boardID, _, err = ResolveBoardScope(cmd, rt, store, ns, workspaceID); err != nil {
+			return nil, err
+		}
+	}
+	return rt.HistoryService.Activity(ctx, domain.ActivityFilter{
+		WorkspaceID: workspaceID,
+		BoardID:     boardID,
+		Limit:       limit,
+	})
+}
+
+func eventActor(e domain.TaskEvent) string {
+	if e.Actor == nil {
+		return ""
+	}
+	return *e.Actor
+}
+
+// formatFieldChanges summarizes changes on one line. Long values such as
+// descriptions and comment bodies are shortened.
+func formatFieldChanges(changes []domain.FieldChange) string {
+	parts := make([]string, 0, len(changes))
+	for _, c := range changes {
+		switch {
+		case c.Field == "description":
+			parts = append(parts, "description changed")
+		case c.Old == nil && c.New != nil:
+			parts = append(parts, fmt.Sprintf("%s: %s", c.Field, shortValue(*c.New)))
+		default:
+			parts = append(parts, fmt.Sprintf("%s: %s -> %s", c.Field, shortValue(derefString(c.Old)), shortValue(derefString(c.New))))
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+func shortValue(v string) string {
+	v = strings.ReplaceAll(v, "\n", " ")
+	if len(v) > 40 {
+		v = v[:37] + "..."
+	}
+	return fmt.Sprintf("%q", v)
+}
+
+func derefString(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskHistoryAndActivity(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("KANJI_ACTOR", "ci-bot")
+	dbPath, setup, taskID := setupFullDoingColumn(t)
+	ns := Namespace{Key: "test-ns", Source: "cwd"}
+
+	update := newVersionedCommand(t, newTaskUpdateCommand(), dbPath, "--task-id", taskID, "--title", "Renamed")
+	require.NoError(t, runTaskUpdate(update, ns))
+
+	history := newVersionedCommand(t, newTaskHistoryCommand(), dbPath, "--task-id", taskID)
+	history.Flags().Bool("json", true, "")
+	require.NoError(t, runTaskHistory(history, ns))
+	var got struct {
+		Events []struct {
+			Kind    string `json:"kind"`
+			Actor   string `json:"actor"`
+			Title   string `json:"title"`
+			Changes []struct {
+				Field string  `json:"field"`
+				Old   *string `json:"old"`
+				New   *string `json:"new"`
+			} `json:"changes"`
+		} `json:"events"`
+		Count int `json:"count"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(history.OutOrStdout().(*strings.Builder).String()), &got))
+	require.Equal(t, 2, got.Count)
+	assert.Equal(t, "created", got.Events[0].Kind)
+	assert.Equal(t, "updated", got.Events[1].Kind)
+	assert.Equal(t, "ci-bot", got.Events[1].Actor)
+	require.Len(t, got.Events[1].Changes, 1)
+	assert.Equal(t, "Waiting", *got.Events[1].Changes[0].Old)
+	assert.Equal(t, "Renamed", *got.Events[1].Changes[0].New)
+
+	activity := newVersionedCommand(t, newActivityCommand(), dbPath, "--workspace-id", setup.Workspace.ID, "--limit", "2")
+	require.NoError(t, runActivity(activity, ns))
+	out := activity.OutOrStdout().(*strings.Builder).String()
+	assert.Contains(t, out, "Renamed")
+	assert.Contains(t, out, `title: "Waiting" -> "Renamed"`)
+	assert.NotContains(t, out, "In progress", "the limit keeps only the two newest events")
+
+	missing := newVersionedCommand(t, newTaskHistoryCommand(), dbPath, "--task-id", "nope")
+	assert.True(t, errors.Is(runTaskHistory(missing, ns), &SelectorError{Code: "not_found"}))
+}
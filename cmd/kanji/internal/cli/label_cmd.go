@@ -0,0 +1,521 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tiagokriok/kanji/internal/application"
+	"github.com/tiagokriok/kanji/internal/domain"
+	"github.com/tiagokriok/kanji/internal/state"
+)
+
+func newLabelCommand() *cobra.Command {
+	l := &cobra.Command{
+		Use:   "label",
+		Short: "Labels of a workspace",
+		Long: `Labels are registered per workspace, with an optional color and
+description. A label is registered the first time a task uses it. Renaming,
+merging or deleting a label rewrites the labels of every task of the
+workspace in one transaction.
+
+Label names are lowercased like the --label flag of task commands.`,
+	}
+	l.AddCommand(newLabelListCommand())
+	l.AddCommand(newLabelCreateCommand())
+	l.AddCommand(newLabelUpdateCommand())
+	l.AddCommand(newLabelRenameCommand())
+	l.AddCommand(newLabelMergeCommand())
+	l.AddCommand(newLabelDeleteCommand())
+	return l
+}
+
+func newLabelListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the labels of a workspace with their task counts",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns, err := ResolveNamespace()
+			if err != nil {
+				return err
+			}
+			return runLabelList(cmd, ns)
+		},
+	}
+	addLabelWorkspaceFlags(cmd)
+	return cmd
+}
+
+func newLabelCreateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "create",
+		Short:   "Create a label",
+		Example: `  kanji label create --name bug --color "#EF4444" --description "Something is broken"`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns, err := ResolveNamespace()
+			if err != nil {
+				return err
+			}
+			return runLabelCreate(cmd, ns)
+		},
+	}
+	cmd.Flags().String("name", "", "label name")
+	cmd.Flags().String("color", "", "HEX color (#RRGGBB)")
+	cmd.Flags().String("description", "", "label description")
+	addLabelWorkspaceFlags(cmd)
+	return cmd
+}
+
+func newLabelUpdateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "update",
+		Short:   "Change the color or description of a label",
+		Example: `  kanji label update --name bug --color "#DC2626"`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns, err := ResolveNamespace()
+			if err != nil {
+				return err
+			}
+			return runLabelUpdate(cmd, ns)
+		},
+	}
+	cmd.Flags().String("name", "", "label name")
+	cmd.Flags().String("color", "", `HEX color (#RRGGBB), or "" to remove it`)
+	cmd.Flags().String("description", "", "label description")
+	addLabelWorkspaceFlags(cmd)
+	return cmd
+}
+
+func newLabelRenameCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "rename",
+		Short:   "Rename a label on every task of the workspace",
+		Example: `  kanji label rename --name bgu --to bug`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns, err := ResolveNamespace()
+			if err != nil {
+				return err
+			}
+			return runLabelRename(cmd, ns)
+		},
+	}
+	cmd.Flags().String("name", "", "label name")
+	cmd.Flags().String("to", "", "new label name")
+	addLabelWorkspaceFlags(cmd)
+	return cmd
+}
+
+func newLabelMergeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "merge",
+		Short: "Merge labels into another label",
+		Long: `Replace the --from labels with the --into label on every task of the
+workspace and delete the --from labels. The --into label keeps its color and
+description.`,
+		Example: `  kanji label merge --from defect --from bugs --into bug`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns, err := ResolveNamespace()
+			if err != nil {
+				return err
+			}
+			return runLabelMerge(cmd, ns)
+		},
+	}
+	cmd.Flags().StringSlice("from", nil, "label to merge (repeatable)")
+	cmd.Flags().String("into", "", "label to merge into")
+	addLabelWorkspaceFlags(cmd)
+	return cmd
+}
+
+func newLabelDeleteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete a label and remove it from every task",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns, err := ResolveNamespace()
+			if err != nil {
+				return err
+			}
+			return runLabelDelete(cmd, ns)
+		},
+	}
+	cmd.Flags().String("name", "", "label name")
+	cmd.Flags().Bool("yes", false, "confirm deletion")
+	addLabelWorkspaceFlags(cmd)
+	return cmd
+}
+
+func addLabelWorkspaceFlags(cmd *cobra.Command) {
+	cmd.Flags().String("workspace-id", "", "workspace ID")
+	cmd.Flags().String("workspace", "", "workspace name")
+}
+
+// labelNameFlag returns a label name flag normalized like task labels, or a
+// validation error when it is empty.
+func labelNameFlag(cmd *cobra.Command, flag string) (string, error) {
+	value, _ := cmd.Flags().GetString(flag)
+	name := strings.ToLower(strings.TrimSpace(value))
+	if name == "" {
+		return "", NewValidation("--" + flag + " is required")
+	}
+	return name, nil
+}
+
+// labelError maps label service errors to CLI errors.
+func labelError(err error, name string) error {
+	if errors.Is(err, application.ErrLabelNotFound) {
+		return NewNotFound("label", name)
+	}
+	return NewValidation(err.Error())
+}
+
+func runLabelList(cmd *cobra.Command, ns Namespace) error {
+	store, err := defaultStateStore()
+	if err != nil {
+		return err
+	}
+	return runLabelListWithStore(cmd, ns, store)
+}
+
+func runLabelListWithStore(cmd *cobra.Command, ns Namespace, store *state.Store) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	if err := GuardBootstrap(rt); err != nil {
+		return err
+	}
+
+	workspaceID, _, err := ResolveWorkspaceScope(cmd, rt, store, ns)
+	if err != nil {
+		return err
+	}
+
+	labels, err := rt.LabelService.ListLabels(context.Background(), workspaceID)
+	if err != nil {
+		return err
+	}
+
+	if cfg.JSON {
+		items := make([]map[string]interface{}, len(labels))
+		for i, l := range labels {
+			items[i] = labelJSON(l)
+		}
+		return RenderWrappedListJSON(cmd.OutOrStdout(), "labels", items, len(items))
+	}
+
+	headers := []string{"Name", "Color", "Tasks", "Description"}
+	rows := make([][]string, len(labels))
+	for i, l := range labels {
+		rows[i] = []string{l.Name, l.Color, strconv.Itoa(l.TaskCount), l.Description}
+	}
+	return RenderTable(cmd.OutOrStdout(), headers, rows)
+}
+
+func runLabelCreate(cmd *cobra.Command, ns Namespace) error {
+	store, err := defaultStateStore()
+	if err != nil {
+		return err
+	}
+	return runLabelCreateWithStore(cmd, ns, store)
+}
+
+func runLabelCreateWithStore(cmd *cobra.Command, ns Namespace, store *state.Store) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	name, err := labelNameFlag(cmd, "name")
+	if err != nil {
+		return err
+	}
+	color, _ := cmd.Flags().GetString("color")
+	description, _ := cmd.Flags().GetString("description")
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	if err := GuardBootstrap(rt); err != nil {
+		return err
+	}
+
+	workspaceID, _, err := ResolveWorkspaceScope(cmd, rt, store, ns)
+	if err != nil {
+		return err
+	}
+
+	label, err := rt.LabelService.CreateLabel(context.Background(), application.CreateLabelInput{
+		WorkspaceID: workspaceID,
+		Name:        name,
+		Color:       color,
+		Description: description,
+	})
+	if err != nil {
+		return labelError(err, name)
+	}
+
+	if cfg.JSON {
+		return RenderWriteResultJSON(cmd.OutOrStdout(), "label", labelJSON(label))
+	}
+	return RenderWriteResult(cmd.OutOrStdout(), "Label", label.ID, map[string]string{
+		"Name":  label.Name,
+		"Color": label.Color,
+	})
+}
+
+func runLabelUpdate(cmd *cobra.Command, ns Namespace) error {
+	store, err := defaultStateStore()
+	if err != nil {
+		return err
+	}
+	return runLabelUpdateWithStore(cmd, ns, store)
+}
+
+func runLabelUpdateWithStore(cmd *cobra.Command, ns Namespace, store *state.Store) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	name, err := labelNameFlag(cmd, "name")
+	if err != nil {
+		return err
+	}
+	if err := RequireAtLeastOneFlag(cmd, "color", "description"); err != nil {
+		return err
+	}
+	var color, description *string
+	if cmd.Flags().Changed("color") {
+		value, _ := cmd.Flags().GetString("color")
+		color = &value
+	}
+	if cmd.Flags().Changed("description") {
+		value, _ := cmd.Flags().GetString("description")
+		description = &value
+	}
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	if err := GuardBootstrap(rt); err != nil {
+		return err
+	}
+
+	workspaceID, _, err := ResolveWorkspaceScope(cmd, rt, store, ns)
+	if err != nil {
+		return err
+	}
+
+	label, err := rt.LabelService.UpdateLabel(context.Background(), workspaceID, name, color, description)
+	if err != nil {
+		return labelError(err, name)
+	}
+
+	if cfg.JSON {
+		return RenderWriteResultJSON(cmd.OutOrStdout(), "label", labelJSON(label))
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), "Label updated")
+	return RenderKV(cmd.OutOrStdout(), map[string]string{
+		"Name":        label.Name,
+		"Color":       label.Color,
+		"Description": label.Description,
+	})
+}
+
+func runLabelRename(cmd *cobra.Command, ns Namespace) error {
+	store, err := defaultStateStore()
+	if err != nil {
+		return err
+	}
+	return runLabelRenameWithStore(cmd, ns, store)
+}
+
+func runLabelRenameWithStore(cmd *cobra.Command, ns Namespace, store *state.Store) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	name, err := labelNameFlag(cmd, "name")
+	if err != nil {
+		return err
+	}
+	newName, err := labelNameFlag(cmd, "to")
+	if err != nil {
+		return err
+	}
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	if err := GuardBootstrap(rt); err != nil {
+		return err
+	}
+
+	workspaceID, _, err := ResolveWorkspaceScope(cmd, rt, store, ns)
+	if err != nil {
+		return err
+	}
+
+	label, changed, err := rt.LabelService.RenameLabel(context.Background(), workspaceID, name, newName)
+	if err != nil {
+		return labelError(err, name)
+	}
+	return renderLabelChange(cmd, cfg, "Label renamed", label, changed)
+}
+
+func runLabelMerge(cmd *cobra.Command, ns Namespace) error {
+	store, err := defaultStateStore()
+	if err != nil {
+		return err
+	}
+	return runLabelMergeWithStore(cmd, ns, store)
+}
+
+func runLabelMergeWithStore(cmd *cobra.Command, ns Namespace, store *state.Store) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	from, _ := cmd.Flags().GetStringSlice("from")
+	sources := NormalizeLabels(from)
+	if len(sources) == 0 {
+		return NewValidation("--from is required")
+	}
+	target, err := labelNameFlag(cmd, "into")
+	if err != nil {
+		return err
+	}
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	if err := GuardBootstrap(rt); err != nil {
+		return err
+	}
+
+	workspaceID, _, err := ResolveWorkspaceScope(cmd, rt, store, ns)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	for _, name := range append(sources, target) {
+		if _, err := rt.LabelService.GetLabel(ctx, workspaceID, name); err != nil {
+			return labelError(err, name)
+		}
+	}
+	label, changed, err := rt.LabelService.MergeLabels(ctx, workspaceID, sources, target)
+	if err != nil {
+		return labelError(err, target)
+	}
+	return renderLabelChange(cmd, cfg, "Labels merged", label, changed)
+}
+
+func runLabelDelete(cmd *cobra.Command, ns Namespace) error {
+	store, err := defaultStateStore()
+	if err != nil {
+		return err
+	}
+	return runLabelDeleteWithStore(cmd, ns, store)
+}
+
+func runLabelDeleteWithStore(cmd *cobra.Command, ns Namespace, store *state.Store) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	name, err := labelNameFlag(cmd, "name")
+	if err != nil {
+		return err
+	}
+	if err := RequireConfirmation(cmd, "yes"); err != nil {
+		return err
+	}
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	if err := GuardBootstrap(rt); err != nil {
+		return err
+	}
+
+	workspaceID, _, err := ResolveWorkspaceScope(cmd, rt, store, ns)
+	if err != nil {
+		return err
+	}
+
+	label, changed, err := rt.LabelService.DeleteLabel(context.Background(), workspaceID, name)
+	if err != nil {
+		return labelError(err, name)
+	}
+	if cfg.JSON {
+		return RenderWrappedJSON(cmd.OutOrStdout(), "label", map[string]interface{}{
+			"id":            label.ID,
+			"name":          label.Name,
+			"deleted":       true,
+			"tasks_changed": changed,
+		})
+	}
+	if err := RenderDeleteResult(cmd.OutOrStdout(), "label", label.ID); err != nil {
+		return err
+	}
+	return RenderKV(cmd.OutOrStdout(), map[string]string{"Tasks changed": strconv.Itoa(changed)})
+}
+
+// renderLabelChange writes the result of a rename or a merge: the label and
+// how many tasks were relabeled.
+func renderLabelChange(cmd *cobra.Command, cfg RuntimeConfig, title string, label domain.Label, changed int) error {
+	if cfg.JSON {
+		payload := labelJSON(label)
+		payload["tasks_changed"] = changed
+		return RenderWrappedJSON(cmd.OutOrStdout(), "label", payload)
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), title)
+	return RenderKV(cmd.OutOrStdout(), map[string]string{
+		"Name":          label.Name,
+		"Tasks changed": strconv.Itoa(changed),
+	})
+}
+
+func labelJSON(l domain.Label) map[string]interface{} {
+	return map[string]interface{}{
+		"id":          l.ID,
+		"name":        l.Name,
+		"color":       l.Color,
+		"description": l.Description,
+		"task_count":  l.TaskCount,
+		"created_at":  l.CreatedAt.Format(time.RFC3339),
+		"updated_at":  l.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tiagokriok/kanji/internal/application"
+)
+
+func TestLabel(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	dbPath, setup, waitingID := setupFullDoingColumn(t)
+	ns := Namespace{Key: "test-ns", Source: "cwd"}
+
+	ctx := context.Background()
+	rt, err := NewRuntime(ctx, RuntimeConfig{DBPath: dbPath})
+	require.NoError(t, err)
+	labels := []string{"bgu", "ui"}
+	require.NoError(t, rt.TaskService.UpdateTask(ctx, waitingID, application.UpdateTaskInput{Labels: &labels}))
+	require.NoError(t, rt.Close())
+
+	create := newVersionedCommand(t, newLabelCreateCommand(), dbPath, "--workspace-id", setup.Workspace.ID, "--name", "Bug", "--color", "#ef4444")
+	require.NoError(t, runLabelCreate(create, ns))
+	assert.Contains(t, create.OutOrStdout().(*strings.Builder).String(), "#EF4444")
+
+	dup := newVersionedCommand(t, newLabelCreateCommand(), dbPath, "--workspace-id", setup.Workspace.ID, "--name", "ui")
+	err = runLabelCreate(dup, ns)
+	assert.True(t, errors.Is(err, &SelectorError{Code: "validation"}))
+	assert.Contains(t, err.Error(), "already exists")
+
+	list := newVersionedCommand(t, newLabelListCommand(), dbPath, "--workspace-id", setup.Workspace.ID)
+	require.NoError(t, runLabelList(list, ns))
+	out := list.OutOrStdout().(*strings.Builder).String()
+	assert.Contains(t, out, "bgu")
+	assert.Contains(t, out, "ui")
+
+	onto := newVersionedCommand(t, newLabelRenameCommand(), dbPath, "--workspace-id", setup.Workspace.ID, "--name", "bgu", "--to", "bug")
+	assert.True(t, errors.Is(runLabelRename(onto, ns), &SelectorError{Code: "validation"}))
+
+	merge := newVersionedCommand(t, newLabelMergeCommand(), dbPath, "--workspace-id", setup.Workspace.ID, "--from", "bgu", "--into", "bug")
+	merge.Flags().Bool("json", true, "")
+	require.NoError(t, runLabelMerge(merge, ns))
+	var merged struct {
+		Label struct {
+			Name         string `json:"name"`
+			Color        string `json:"color"`
+			TasksChanged int    `json:"tasks_changed"`
+		} `json:"label"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(merge.OutOrStdout().(*strings.Builder).String()), &merged))
+	assert.Equal(t, "bug", merged.Label.Name)
+	assert.Equal(t, "#EF4444", merged.Label.Color)
+	assert.Equal(t, 1, merged.Label.TasksChanged)
+
+	rename := newVersionedCommand(t, newLabelRenameCommand(), dbPath, "--workspace-id", setup.Workspace.ID, "--name", "ui", "--to", "frontend")
+	require.NoError(t, runLabelRename(rename, ns))
+	assert.Contains(t, rename.OutOrStdout().(*strings.Builder).String(), "frontend")
+
+	unconfirmed := newVersionedCommand(t, newLabelDeleteCommand(), dbPath, "--workspace-id", setup.Workspace.ID, "--name", "bug")
+	assert.Error(t, runLabelDelete(unconfirmed, ns))
+
+	del := newVersionedCommand(t, newLabelDeleteCommand(), dbPath, "--workspace-id", setup.Workspace.ID, "--name", "bug", "--yes")
+	require.NoError(t, runLabelDelete(del, ns))
+
+	missing := newVersionedCommand(t, newLabelDeleteCommand(), dbPath, "--workspace-id", setup.Workspace.ID, "--name", "bug", "--yes")
+	assert.True(t, errors.Is(runLabelDelete(missing, ns), &SelectorError{Code: "not_found"}))
+
+	rt, err = NewRuntime(ctx, RuntimeConfig{DBPath: dbPath})
+	require.NoError(t, err)
+	defer rt.Close()
+	task, err := rt.TaskService.GetTask(ctx, waitingID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"frontend"}, task.Labels)
+}
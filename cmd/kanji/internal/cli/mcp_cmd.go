@@ -0,0 +1,223 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"runtime/debug"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tiagokriok/kanji/internal/state"
+)
+
+func newMCPCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mcp",
+		Short: "Serve kanji tools to editors and agents over stdio (MCP)",
+		Long: `Serve a Model Context Protocol server over stdin and stdout.
+
+Requests are newline-delimited JSON-RPC 2.0 messages. Tools list workspaces,
+boards, columns, tasks and comments and create, update and move tasks. Tool
+arguments are the flags of the equivalent CLI commands with underscores
+instead of dashes, and scopes fall back to the stored context of the current
+namespace just like the CLI does. Results are the --json output of the
+equivalent CLI commands.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns, err := ResolveNamespace()
+			if err != nil {
+				return err
+			}
+			return runMCP(cmd, ns)
+		},
+	}
+	return cmd
+}
+
+func runMCP(cmd *cobra.Command, ns Namespace) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	if err := GuardBootstrap(rt); err != nil {
+		return err
+	}
+
+	store, err := defaultStateStore()
+	if err != nil {
+		return err
+	}
+
+	// stdout carries the protocol, so hook warnings must not end up there.
+	rt.Hooks.SetWarnings(cmd.ErrOrStderr())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go deliverWebhooks(ctx, rt)
+
+	server := &mcpServer{rt: rt, store: store, ns: ns}
+	return server.serve(ctx, cmd.InOrStdin(), cmd.OutOrStdout())
+}
+
+// mcpProtocolVersions lists the MCP revisions the server speaks, newest first.
+var mcpProtocolVersions = []string{"2025-06-18", "2025-03-26", "2024-11-05"}
+
+// JSON-RPC 2.0 error codes.
+const (
+	rpcParseError     = -32700
+	rpcInvalidRequest = -32600
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+)
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return e.Message
+}
+
+// mcpServer answers MCP requests against one runtime and namespace.
+type mcpServer struct {
+	rt    *Runtime
+	store *state.Store
+	ns    Namespace
+}
+
+// serve handles one request per line of r until r is exhausted.
+func (s *mcpServer) serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	reader := bufio.NewReader(r)
+	enc := json.NewEncoder(w)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			if resp := s.handle(ctx, line); resp != nil {
+				if err := enc.Encode(resp); err != nil {
+					return fmt.Errorf("write response: %w", err)
+				}
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read request: %w", err)
+		}
+	}
+}
+
+// handle answers one message. Notifications get no response.
+func (s *mcpServer) handle(ctx context.Context, line []byte) *rpcResponse {
+	if line[0] == '[' {
+		return rpcFailure(nil, &rpcError{Code: rpcInvalidRequest, Message: "batch requests are not supported"})
+	}
+	var req rpcRequest
+	if err := json.Unmarshal(line, &req); err != nil {
+		return rpcFailure(nil, &rpcError{Code: rpcParseError, Message: "parse error: " + err.Error()})
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return rpcFailure(req.ID, &rpcError{Code: rpcInvalidRequest, Message: "invalid JSON-RPC 2.0 request"})
+	}
+
+	result, err := s.dispatch(ctx, req)
+	if len(req.ID) == 0 {
+		return nil
+	}
+	if err != nil {
+		var rpcErr *rpcError
+		if !errors.As(err, &rpcErr) {
+			rpcErr = &rpcError{Code: rpcInvalidParams, Message: err.Error()}
+		}
+		return rpcFailure(req.ID, rpcErr)
+	}
+	return &rpcResponse{JSONRPC: "2.0", ID: req.ID, Result: result}
+}
+
+func (s *mcpServer) dispatch(ctx context.Context, req rpcRequest) (interface{}, error) {
+	switch req.Method {
+	case "initialize":
+		var params struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		_ = json.Unmarshal(req.Params, &params)
+		version := mcpProtocolVersions[0]
+		for _, v := range mcpProtocolVersions {
+			if v == params.ProtocolVersion {
+				version = v
+			}
+		}
+		return map[string]interface{}{
+			"protocolVersion": version,
+			"capabilities":    map[string]interface{}{"tools": map[string]interface{}{}},
+			"serverInfo":      map[string]string{"name": "kanji", "version": buildVersion()},
+		}, nil
+	case "ping":
+		return map[string]interface{}{}, nil
+	case "tools/list":
+		tools := make([]map[string]interface{}, len(mcpTools))
+		for i, tool := range mcpTools {
+			tools[i] = map[string]interface{}{
+				"name":        tool.name,
+				"description": tool.description,
+				"inputSchema": tool.inputSchema(),
+			}
+		}
+		return map[string]interface{}{"tools": tools}, nil
+	case "tools/call":
+		var params struct {
+			Name      string                     `json:"name"`
+			Arguments map[string]json.RawMessage `json:"arguments"`
+		}
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, fmt.Errorf("invalid params: %w", err)
+		}
+		return s.callTool(ctx, params.Name, params.Arguments)
+	}
+	if len(req.ID) == 0 {
+		// Notifications such as notifications/initialized need no handling.
+		return nil, nil
+	}
+	return nil, &rpcError{Code: rpcMethodNotFound, Message: "method not found: " + req.Method}
+}
+
+func rpcFailure(id json.RawMessage, err *rpcError) *rpcResponse {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	return &rpcResponse{JSONRPC: "2.0", ID: id, Error: err}
+}
+
+// buildVersion reports the module version kanji was built from.
+func buildVersion() string {
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" {
+		return info.Main.Version
+	}
+	return "(devel)"
+}
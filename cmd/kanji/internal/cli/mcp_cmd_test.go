@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tiagokriok/kanji/internal/state"
+)
+
+// mcpExchange feeds requests to a server and decodes its responses.
+func mcpExchange(t *testing.T, s *mcpServer, requests ...string) []map[string]interface{} {
+	t.Helper()
+	var out bytes.Buffer
+	require.NoError(t, s.serve(context.Background(), strings.NewReader(strings.Join(requests, "\n")), &out))
+
+	var responses []map[string]interface{}
+	dec := json.NewDecoder(&out)
+	for dec.More() {
+		var resp map[string]interface{}
+		require.NoError(t, dec.Decode(&resp))
+		responses = append(responses, resp)
+	}
+	return responses
+}
+
+// toolText decodes the JSON text of a tools/call result.
+func toolText(t *testing.T, resp map[string]interface{}) (map[string]interface{}, bool) {
+	t.Helper()
+	result := resp["result"].(map[string]interface{})
+	content := result["content"].([]interface{})[0].(map[string]interface{})
+	var payload map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(content["text"].(string)), &payload))
+	return payload, result["isError"].(bool)
+}
+
+func TestMCP_ToolsResolveThroughContext(t *testing.T) {
+	dbPath := setupSyncTestDB(t)
+	ctx := context.Background()
+	rt, err := NewRuntime(ctx, RuntimeConfig{DBPath: dbPath})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = rt.Close() })
+	setup, err := rt.BootstrapService.EnsureDefaultSetup(ctx)
+	require.NoError(t, err)
+
+	ns := Namespace{Key: "test-ns", Source: "cwd"}
+	store := state.NewStore(filepath.Join(t.TempDir(), "state.json"))
+	require.NoError(t, store.SetCLIContext(ns.Key, state.CLIContext{WorkspaceID: setup.Workspace.ID, BoardID: setup.Board.ID}))
+	server := &mcpServer{rt: rt, store: store, ns: ns}
+
+	responses := mcpExchange(t, server,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26"}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"create_task","arguments":{"title":"From agent","priority":"high","labels":["mcp"]}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"move_task","arguments":{"task":"From agent","to_column":"Done"}}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"list_tasks","arguments":{"query":"agent"}}}`,
+		`{"jsonrpc":"2.0","id":6,"method":"tools/call","params":{"name":"get_task","arguments":{"task":"Missing"}}}`,
+		`{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"create_task","arguments":{"title":"x","nope":true}}}`,
+		`{"jsonrpc":"2.0","id":8,"method":"tasks/purge"}`,
+		`not json`,
+	)
+	require.Len(t, responses, 9, "the notification must not be answered")
+
+	initResult := responses[0]["result"].(map[string]interface{})
+	assert.Equal(t, "2025-03-26", initResult["protocolVersion"])
+	assert.Equal(t, "kanji", initResult["serverInfo"].(map[string]interface{})["name"])
+
+	tools := responses[1]["result"].(map[string]interface{})["tools"].([]interface{})
+	var create map[string]interface{}
+	for _, tool := range tools {
+		if tool.(map[string]interface{})["name"] == "create_task" {
+			create = tool.(map[string]interface{})
+		}
+	}
+	require.NotNil(t, create)
+	props := create["inputSchema"].(map[string]interface{})["properties"].(map[string]interface{})
+	assert.Equal(t, "array", props["labels"].(map[string]interface{})["type"])
+	assert.Equal(t, "boolean", props["force"].(map[string]interface{})["type"])
+	assert.NotContains(t, props, "description_file")
+
+	payload, isError := toolText(t, responses[2])
+	require.False(t, isError, payload)
+	task := payload["task"].(map[string]interface{})
+	assert.Equal(t, "From agent", task["title"])
+	assert.Equal(t, float64(2), task["priority"])
+
+	payload, isError = toolText(t, responses[3])
+	require.False(t, isError, payload)
+	assert.Equal(t, setup.Columns[len(setup.Columns)-1].ID, payload["task"].(map[string]interface{})["column_id"])
+
+	payload, isError = toolText(t, responses[4])
+	require.False(t, isError, payload)
+	assert.Equal(t, float64(1), payload["count"])
+
+	payload, isError = toolText(t, responses[5])
+	assert.True(t, isError)
+	assert.Equal(t, "not_found", payload["error"].(map[string]interface{})["code"])
+
+	assert.Equal(t, float64(rpcInvalidParams), responses[6]["error"].(map[string]interface{})["code"])
+	assert.Equal(t, float64(rpcMethodNotFound), responses[7]["error"].(map[string]interface{})["code"])
+	assert.Equal(t, float64(rpcParseError), responses[8]["error"].(map[string]interface{})["code"])
+	assert.Nil(t, responses[8]["id"])
+}
@@ -0,0 +1,496 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/tiagokriok/kanji/internal/application"
+)
+
+// mcpTool is a tool served by `kanji mcp`. Its arguments are the flags of
+// the CLI command it mirrors, so scopes and selectors resolve the same way.
+type mcpTool struct {
+	name        string
+	description string
+	command     func() *cobra.Command
+	required    []string
+	run         func(ctx context.Context, s *mcpServer, cmd *cobra.Command, w io.Writer) error
+}
+
+// mcpSkippedFlags are CLI flags that make no sense for a tool call.
+var mcpSkippedFlags = map[string]bool{
+	"description-file": true,
+	"body-file":        true,
+}
+
+var mcpTools = []mcpTool{
+	{
+		name:        "get_context",
+		description: "Show the stored workspace and board context of the current namespace.",
+		command:     newContextShowCommand,
+		run: func(_ context.Context, s *mcpServer, _ *cobra.Command, w io.Writer) error {
+			cliCtx, _ := s.store.GetCLIContext(s.ns.Key)
+			tuiState, _ := s.store.GetTUIState(s.ns.Key)
+			return renderContextJSON(w, s.ns, cliCtx, tuiState)
+		},
+	},
+	{
+		name:        "list_workspaces",
+		description: "List workspaces.",
+		command:     newWorkspaceListCommand,
+		run: func(ctx context.Context, s *mcpServer, _ *cobra.Command, w io.Writer) error {
+			workspaces, err := s.rt.ContextService.ListWorkspaces(ctx)
+			if err != nil {
+				return err
+			}
+			items := make([]map[string]string, len(workspaces))
+			for i, ws := range workspaces {
+				items[i] = workspaceJSON(ws)
+			}
+			return RenderWrappedListJSON(w, "workspaces", items, len(workspaces))
+		},
+	},
+	{
+		name:        "list_boards",
+		description: "List the boards of a workspace, defaulting to the context workspace.",
+		command:     newBoardListCommand,
+		run: func(ctx context.Context, s *mcpServer, cmd *cobra.Command, w io.Writer) error {
+			workspaceID, _, err := ResolveWorkspaceScope(cmd, s.rt, s.store, s.ns)
+			if err != nil {
+				return err
+			}
+			boards, err := s.rt.ContextService.ListBoards(ctx, workspaceID)
+			if err != nil {
+				return err
+			}
+			items := make([]map[string]string, len(boards))
+			for i, b := range boards {
+				items[i] = boardJSON(b)
+			}
+			return RenderWrappedListJSON(w, "boards", items, len(boards))
+		},
+	},
+	{
+		name:        "list_columns",
+		description: "List the columns of a board, defaulting to the context board.",
+		command: func() *cobra.Command {
+			cmd := newColumnListCommand()
+			cmd.Flags().String("workspace-id", "", "workspace ID")
+			cmd.Flags().String("workspace", "", "workspace name")
+			return cmd
+		},
+		run: func(ctx context.Context, s *mcpServer, cmd *cobra.Command, w io.Writer) error {
+			workspaceID, _, err := ResolveWorkspaceScope(cmd, s.rt, s.store, s.ns)
+			if err != nil {
+				return err
+			}
+			boardID, _, err := ResolveBoardScope(cmd, s.rt, s.store, s.ns, workspaceID)
+			if err != nil {
+				return err
+			}
+			columns, err := s.rt.ContextService.ListColumns(ctx, boardID)
+			if err != nil {
+				return err
+			}
+			items := make([]map[string]interface{}, len(columns))
+			for i, c := range columns {
+				items[i] = columnListItemJSON(c)
+			}
+			return RenderWrappedListJSON(w, "columns", items, len(columns))
+		},
+	},
+	{
+		name:        "list_tasks",
+		description: "List the tasks of a workspace, defaulting to the context workspace.",
+		command:     newTaskListCommand,
+		run: func(ctx context.Context, s *mcpServer, cmd *cobra.Command, w io.Writer) error {
+			if err := s.scopeWorkspace(cmd); err != nil {
+				return err
+			}
+			tasks, err := listTasks(ctx, cmd, s.rt)
+			if err != nil {
+				return err
+			}
+			items := make([]map[string]string, len(tasks))
+			for i, task := range tasks {
+				items[i] = taskListItemJSON(task)
+			}
+			return RenderWrappedListJSON(w, "tasks", items, len(tasks))
+		},
+	},
+	{
+		name:        "get_task",
+		description: "Get a task by ID, or by title within a workspace.",
+		command:     newTaskGetCommand,
+		run: func(ctx context.Context, s *mcpServer, cmd *cobra.Command, w io.Writer) error {
+			if cmd.Flags().Changed("task") {
+				if err := s.scopeWorkspace(cmd); err != nil {
+					return err
+				}
+			}
+			task, err := getTask(ctx, cmd, s.rt)
+			if err != nil {
+				return err
+			}
+			return RenderWrappedJSON(w, "task", taskJSON(task))
+		},
+	},
+	{
+		name:        "create_task",
+		description: "Create a task, defaulting to the context workspace and board.",
+		command:     newTaskCreateCommand,
+		required:    []string{"title"},
+		run: func(ctx context.Context, s *mcpServer, cmd *cobra.Command, w io.Writer) error {
+			task, err := createTask(ctx, cmd, s.rt, s.store, s.ns)
+			if err != nil {
+				return err
+			}
+			return RenderWriteResultJSON(w, "task", taskJSON(task))
+		},
+	},
+	{
+		name:        "update_task",
+		description: "Update the title, description, priority, due date or labels of a task.",
+		command:     newTaskUpdateCommand,
+		run: func(ctx context.Context, s *mcpServer, cmd *cobra.Command, w io.Writer) error {
+			taskID, err := updateTask(ctx, cmd, s.rt, s.store, s.ns)
+			if err != nil {
+				return err
+			}
+			return RenderWriteResultJSON(w, "task", map[string]interface{}{
+				"id":      taskID,
+				"updated": true,
+			})
+		},
+	},
+	{
+		name:        "move_task",
+		description: "Move a task to another column or board, or reorder it within its column.",
+		command:     newTaskMoveCommand,
+		run: func(ctx context.Context, s *mcpServer, cmd *cobra.Command, w io.Writer) error {
+			taskID, columnID, status, err := moveTask(ctx, cmd, s.rt, s.store, s.ns)
+			if err != nil {
+				return err
+			}
+			return RenderWriteResultJSON(w, "task", map[string]interface{}{
+				"id":        taskID,
+				"column_id": columnID,
+				"status":    status,
+			})
+		},
+	},
+	{
+		name:        "copy_task",
+		description: "Copy a task, optionally with its comments and to another board or workspace.",
+		command:     newTaskCopyCommand,
+		run: func(ctx context.Context, s *mcpServer, cmd *cobra.Command, w io.Writer) error {
+			task, err := copyTask(ctx, cmd, s.rt, s.store, s.ns)
+			if err != nil {
+				return err
+			}
+			return RenderWriteResultJSON(w, "task", taskJSON(task))
+		},
+	},
+	{
+		name:        "list_comments",
+		description: "List the comments of a task.",
+		command:     newCommentListCommand,
+		required:    []string{"task_id"},
+		run: func(ctx context.Context, s *mcpServer, cmd *cobra.Command, w io.Writer) error {
+			taskID, _ := cmd.Flags().GetString("task-id")
+			comments, err := s.rt.CommentService.ListComments(ctx, taskID)
+			if err != nil {
+				return err
+			}
+			items := make([]map[string]string, len(comments))
+			for i, c := range comments {
+				items[i] = commentListItemJSON(c)
+			}
+			return RenderWrappedListJSON(w, "comments", items, len(comments))
+		},
+	},
+	{
+		name:        "add_comment",
+		description: "Add a comment to a task.",
+		command:     newCommentCreateCommand,
+		required:    []string{"task_id", "body"},
+		run: func(ctx context.Context, s *mcpServer, cmd *cobra.Command, w io.Writer) error {
+			comment, err := createComment(ctx, cmd, s.rt)
+			if err != nil {
+				return err
+			}
+			return RenderWriteResultJSON(w, "comment", commentJSON(comment))
+		},
+	},
+	{
+		name:        "task_history",
+		description: "Show who created, changed, moved, commented on or deleted a task, oldest first.",
+		command:     newTaskHistoryCommand,
+		required:    []string{"task_id"},
+		run: func(ctx context.Context, s *mcpServer, cmd *cobra.Command, w io.Writer) error {
+			events, err := taskHistory(ctx, cmd, s.rt)
+			if err != nil {
+				return err
+			}
+			return RenderWrappedListJSON(w, "events", taskEventsJSON(events), len(events))
+		},
+	},
+	{
+		name:        "list_activity",
+		description: "List recent task changes in a workspace, newest first, defaulting to the context workspace.",
+		command:     newActivityCommand,
+		run: func(ctx context.Context, s *mcpServer, cmd *cobra.Command, w io.Writer) error {
+			events, err := listActivity(ctx, cmd, s.rt, s.store, s.ns)
+			if err != nil {
+				return err
+			}
+			return RenderWrappedListJSON(w, "events", taskEventsJSON(events), len(events))
+		},
+	},
+	{
+		name:        "search_tasks",
+		description: "Search task titles, descriptions, labels and comments by full text, best match first, defaulting to the context workspace.",
+		command:     newSearchCommand,
+		required:    []string{"query"},
+		run: func(ctx context.Context, s *mcpServer, cmd *cobra.Command, w io.Writer) error {
+			hits, err := searchTasks(ctx, cmd, s.rt, s.store, s.ns)
+			if err != nil {
+				return err
+			}
+			return RenderWrappedListJSON(w, "results", searchHitsJSON(hits), len(hits))
+		},
+	},
+	{
+		name:        "list_views",
+		description: "List the saved views of a workspace, defaulting to the context workspace.",
+		command:     newViewListCommand,
+		run: func(ctx context.Context, s *mcpServer, cmd *cobra.Command, w io.Writer) error {
+			workspaceID, boardID, err := resolveViewScope(cmd, s.rt, s.store, s.ns)
+			if err != nil {
+				return err
+			}
+			views, err := s.rt.ViewService.ListViews(ctx, workspaceID, boardID)
+			if err != nil {
+				return err
+			}
+			items := make([]map[string]interface{}, len(views))
+			for i, v := range views {
+				items[i] = viewJSON(v)
+			}
+			return RenderWrappedListJSON(w, "views", items, len(items))
+		},
+	},
+	{
+		name:        "apply_view",
+		description: "List the tasks a saved view shows, filtered and sorted like the TUI.",
+		command:     newViewApplyCommand,
+		run: func(ctx context.Context, s *mcpServer, cmd *cobra.Command, w io.Writer) error {
+			tasks, err := applyView(ctx, cmd, s.rt, s.store, s.ns)
+			if err != nil {
+				return err
+			}
+			items := make([]map[string]string, len(tasks))
+			for i, task := range tasks {
+				items[i] = taskListItemJSON(task)
+			}
+			return RenderWrappedListJSON(w, "tasks", items, len(tasks))
+		},
+	},
+	{
+		name:        "list_labels",
+		description: "List the labels of a workspace with their colors and task counts, defaulting to the context workspace.",
+		command:     newLabelListCommand,
+		run: func(ctx context.Context, s *mcpServer, cmd *cobra.Command, w io.Writer) error {
+			workspaceID, _, err := ResolveWorkspaceScope(cmd, s.rt, s.store, s.ns)
+			if err != nil {
+				return err
+			}
+			labels, err := s.rt.LabelService.ListLabels(ctx, workspaceID)
+			if err != nil {
+				return err
+			}
+			items := make([]map[string]interface{}, len(labels))
+			for i, l := range labels {
+				items[i] = labelJSON(l)
+			}
+			return RenderWrappedListJSON(w, "labels", items, len(items))
+		},
+	},
+}
+
+// inputSchema describes the tool's arguments as a JSON Schema object.
+func (t mcpTool) inputSchema() map[string]interface{} {
+	properties := map[string]interface{}{}
+	t.command().Flags().VisitAll(func(f *pflag.Flag) {
+		if mcpSkippedFlags[f.Name] {
+			return
+		}
+		prop := map[string]interface{}{"description": f.Usage}
+		switch f.Value.Type() {
+		case "bool":
+			prop["type"] = "boolean"
+		case "int":
+			prop["type"] = "integer"
+		case "stringSlice":
+			prop["type"] = "array"
+			prop["items"] = map[string]string{"type": "string"}
+		default:
+			prop["type"] = "string"
+		}
+		properties[mcpArgName(f.Name)] = prop
+	})
+	schema := map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if len(t.required) > 0 {
+		schema["required"] = t.required
+	}
+	return schema
+}
+
+// callTool runs a tool and wraps its output, or its error, as tool content.
+func (s *mcpServer) callTool(ctx context.Context, name string, args map[string]json.RawMessage) (interface{}, error) {
+	var tool *mcpTool
+	for i := range mcpTools {
+		if mcpTools[i].name == name {
+			tool = &mcpTools[i]
+		}
+	}
+	if tool == nil {
+		return nil, &rpcError{Code: rpcInvalidParams, Message: "unknown tool: " + name}
+	}
+
+	cmd := tool.command()
+	if err := setMCPArgs(cmd.Flags(), args, tool.required); err != nil {
+		return nil, &rpcError{Code: rpcInvalidParams, Message: err.Error()}
+	}
+
+	var out bytes.Buffer
+	isError := false
+	if err := tool.run(ctx, s, cmd, &out); err != nil {
+		out.Reset()
+		isError = true
+		_ = RenderJSONError(&out, mcpErrorCode(err), err.Error())
+	}
+	return map[string]interface{}{
+		"content": []map[string]string{{"type": "text", "text": strings.TrimSpace(out.String())}},
+		"isError": isError,
+	}, nil
+}
+
+// setMCPArgs sets the flags named by args, marking them as changed.
+func setMCPArgs(fs *pflag.FlagSet, args map[string]json.RawMessage, required []string) error {
+	for _, name := range required {
+		if _, ok := args[name]; !ok {
+			return fmt.Errorf("missing required argument %q", name)
+		}
+	}
+
+	names := make([]string, 0, len(args))
+	for name := range args {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		flagName := strings.ReplaceAll(name, "_", "-")
+		f := fs.Lookup(flagName)
+		if f == nil || mcpSkippedFlags[flagName] {
+			return fmt.Errorf("unknown argument %q", name)
+		}
+		value, err := mcpFlagValue(f.Value.Type(), args[name])
+		if err != nil {
+			return fmt.Errorf("argument %q: %w", name, err)
+		}
+		if err := fs.Set(flagName, value); err != nil {
+			return fmt.Errorf("argument %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// mcpFlagValue converts a JSON argument to the string form of a flag value.
+func mcpFlagValue(flagType string, raw json.RawMessage) (string, error) {
+	switch flagType {
+	case "bool":
+		var v bool
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return "", errors.New("must be a boolean")
+		}
+		return fmt.Sprint(v), nil
+	case "int":
+		var v int
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return "", errors.New("must be an integer")
+		}
+		return fmt.Sprint(v), nil
+	case "stringSlice":
+		var v []string
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return "", errors.New("must be an array of strings")
+		}
+		// StringSlice flags parse their value as CSV.
+		var buf bytes.Buffer
+		for i, item := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if strings.ContainsAny(item, ",\"\n") {
+				item = `"` + strings.ReplaceAll(item, `"`, `""`) + `"`
+			}
+			buf.WriteString(item)
+		}
+		return buf.String(), nil
+	default:
+		var v string
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return "", errors.New("must be a string")
+		}
+		return v, nil
+	}
+}
+
+// scopeWorkspace falls back to the context workspace for commands whose CLI
+// form requires an explicit workspace flag.
+func (s *mcpServer) scopeWorkspace(cmd *cobra.Command) error {
+	if cmd.Flags().Changed("workspace-id") || cmd.Flags().Changed("workspace") {
+		return nil
+	}
+	workspaceID, _, err := ResolveWorkspaceScope(cmd, s.rt, s.store, s.ns)
+	if err != nil {
+		return err
+	}
+	return cmd.Flags().Set("workspace-id", workspaceID)
+}
+
+func mcpArgName(flagName string) string {
+	return strings.ReplaceAll(flagName, "-", "_")
+}
+
+// mcpErrorCode maps a tool failure to the error codes of the CLI and API.
+func mcpErrorCode(err error) string {
+	var selErr *SelectorError
+	switch {
+	case errors.As(err, &selErr):
+		return selErr.Code
+	case errors.Is(err, sql.ErrNoRows):
+		return "not_found"
+	case errors.Is(err, application.ErrWIPLimitExceeded):
+		return "wip_limit_exceeded"
+	case errors.Is(err, application.ErrHookRejected):
+		return "hook_rejected"
+	}
+	return "error"
+}
@@ -0,0 +1,152 @@
+package cli
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+// JSON shapes shared by the --json CLI output and the HTTP API. Changing one
+// of these changes both contracts.
+
+func workspaceJSON(ws domain.Workspace) map[string]string {
+	return map[string]string{
+		"id":   ws.ID,
+		"name": ws.Name,
+	}
+}
+
+func boardJSON(b domain.Board) map[string]string {
+	return map[string]string{
+		"id":   b.ID,
+		"name": b.Name,
+	}
+}
+
+func columnJSON(c domain.Column) map[string]interface{} {
+	payload := map[string]interface{}{
+		"id":       c.ID,
+		"name":     c.Name,
+		"color":    c.Color,
+		"position": c.Position,
+	}
+	if c.WIPLimit != nil {
+		payload["wip_limit"] = *c.WIPLimit
+	}
+	return payload
+}
+
+func columnListItemJSON(c domain.Column) map[string]interface{} {
+	return map[string]interface{}{
+		"id":        c.ID,
+		"name":      c.Name,
+		"color":     c.Color,
+		"position":  c.Position,
+		"wip_limit": c.WIPLimit,
+	}
+}
+
+func taskJSON(t domain.Task) map[string]interface{} {
+	payload := map[string]interface{}{
+		"id":       t.ID,
+		"title":    t.Title,
+		"priority": t.Priority,
+		"version":  t.Version,
+	}
+	if t.Status != nil {
+		payload["status"] = *t.Status
+	}
+	return payload
+}
+
+func taskListItemJSON(t domain.Task) map[string]string {
+	status := ""
+	if t.Status != nil {
+		status = *t.Status
+	}
+	return map[string]string{
+		"id":       t.ID,
+		"title":    t.Title,
+		"status":   status,
+		"priority": strconv.Itoa(t.Priority),
+	}
+}
+
+func commentJSON(c domain.Comment) map[string]interface{} {
+	payload := map[string]interface{}{
+		"id":      c.ID,
+		"task_id": c.TaskID,
+		"body":    c.BodyMD,
+		"version": c.Version,
+	}
+	if c.Author != nil {
+		payload["author"] = *c.Author
+	}
+	return payload
+}
+
+func commentListItemJSON(c domain.Comment) map[string]string {
+	preview := c.BodyMD
+	if len(preview) > 50 {
+		preview = preview[:47] + "..."
+	}
+	author := ""
+	if c.Author != nil {
+		author = *c.Author
+	}
+	return map[string]string{
+		"id":         c.ID,
+		"task_id":    c.TaskID,
+		"author":     author,
+		"created_at": c.CreatedAt.Format("2006-01-02"),
+		"preview":    preview,
+	}
+}
+
+func taskEventJSON(e domain.TaskEvent) map[string]interface{} {
+	payload := map[string]interface{}{
+		"id":           e.ID,
+		"task_id":      e.TaskID,
+		"workspace_id": e.WorkspaceID,
+		"kind":         e.Kind,
+		"title":        e.Title,
+		"changes":      e.Changes,
+		"created_at":   e.CreatedAt.Format(time.RFC3339),
+	}
+	if e.BoardID != nil {
+		payload["board_id"] = *e.BoardID
+	}
+	if e.Actor != nil {
+		payload["actor"] = *e.Actor
+	}
+	return payload
+}
+
+func taskEventsJSON(events []domain.TaskEvent) []map[string]interface{} {
+	items := make([]map[string]interface{}, len(events))
+	for i, e := range events {
+		items[i] = taskEventJSON(e)
+	}
+	return items
+}
+
+func operationJSON(op domain.Operation) map[string]interface{} {
+	return map[string]interface{}{
+		"id":         op.ID,
+		"kind":       op.Kind,
+		"entity_id":  op.EntityID,
+		"title":      op.Title,
+		"created_at": op.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func searchHitsJSON(hits []domain.SearchHit) []map[string]interface{} {
+	items := make([]map[string]interface{}, len(hits))
+	for i, hit := range hits {
+		payload := taskJSON(hit.Task)
+		payload["snippet"] = markSnippet(hit.Snippet)
+		items[i] = payload
+	}
+	return items
+}
@@ -0,0 +1,559 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tiagokriok/kanji/internal/application"
+	"github.com/tiagokriok/kanji/internal/domain"
+	"github.com/tiagokriok/kanji/internal/infrastructure/secrets"
+)
+
+func newProviderCommand() *cobra.Command {
+	p := &cobra.Command{
+		Use:   "provider",
+		Short: "Provider operations",
+		Long: `Providers connect kanji to external trackers. Each workspace belongs to a
+provider; its changes are pushed there by "kanji sync run".`,
+	}
+	p.AddCommand(newProviderListCommand())
+	p.AddCommand(newProviderAddCommand())
+	p.AddCommand(newProviderRemoveCommand())
+	p.AddCommand(newProviderTestCommand())
+	p.AddCommand(newProviderAuthCommand())
+	return p
+}
+
+func newProviderAuthCommand() *cobra.Command {
+	a := &cobra.Command{
+		Use:   "auth",
+		Short: "Manage provider credentials",
+		Long: `Provider credentials are stored encrypted. The key is derived from the
+passphrase in KANJI_PASSPHRASE when it is set, otherwise it is read from a key
+file in the kanji config dir, which is created on first use.`,
+	}
+	a.AddCommand(newProviderAuthSetCommand())
+	a.AddCommand(newProviderAuthRotateCommand())
+	a.AddCommand(newProviderAuthClearCommand())
+	return a
+}
+
+func newProviderListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List configured providers",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns, err := ResolveNamespace()
+			if err != nil {
+				return err
+			}
+			return runProviderList(cmd, ns)
+		},
+	}
+}
+
+func newProviderAddCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add",
+		Short: "Add a provider",
+		Long: `Add a provider of a registered type. Credentials are JSON, can be given
+inline, from a file, or from stdin (--auth-file -), and are stored encrypted.`,
+		Example: `  kanji provider add --type local --name "Scratch"
+  kanji provider add --type <type> --name "Tracker" --auth-file auth.json`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns, err := ResolveNamespace()
+			if err != nil {
+				return err
+			}
+			return runProviderAdd(cmd, ns)
+		},
+	}
+	cmd.Flags().String("type", "", "provider type")
+	cmd.Flags().String("name", "", "provider name")
+	cmd.Flags().String("auth-json", "", "provider credentials as JSON")
+	cmd.Flags().String("auth-file", "", "path to file containing credentials JSON (- for stdin)")
+	return cmd
+}
+
+func newProviderRemoveCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove",
+		Short: "Remove a provider",
+		Long: `Remove a provider that no workspace uses. Its queued sync changes are
+dropped. Requires --yes for confirmation.`,
+		Example: `  kanji provider remove --provider-id <id> --yes
+  kanji provider remove --provider "Tracker" --yes`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns, err := ResolveNamespace()
+			if err != nil {
+				return err
+			}
+			return runProviderRemove(cmd, ns)
+		},
+	}
+	cmd.Flags().String("provider-id", "", "provider ID")
+	cmd.Flags().String("provider", "", "provider name")
+	cmd.Flags().Bool("yes", false, "confirm removal")
+	return cmd
+}
+
+func newProviderTestCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "test",
+		Short: "Check provider connectivity and capabilities",
+		Example: `  kanji provider test --provider-id <id>
+  kanji provider test --provider "Tracker"`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns, err := ResolveNamespace()
+			if err != nil {
+				return err
+			}
+			return runProviderTest(cmd, ns)
+		},
+	}
+	cmd.Flags().String("provider-id", "", "provider ID")
+	cmd.Flags().String("provider", "", "provider name")
+	return cmd
+}
+
+func newProviderAuthSetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set",
+		Short: "Replace a provider's credentials",
+		Example: `  kanji provider auth set --provider "GitHub" --auth-file auth.json
+  echo '{"token":"..."}' | kanji provider auth set --provider-id <id> --auth-file -`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns, err := ResolveNamespace()
+			if err != nil {
+				return err
+			}
+			return runProviderAuthSet(cmd, ns)
+		},
+	}
+	cmd.Flags().String("provider-id", "", "provider ID")
+	cmd.Flags().String("provider", "", "provider name")
+	cmd.Flags().String("auth-json", "", "provider credentials as JSON")
+	cmd.Flags().String("auth-file", "", "path to file containing credentials JSON (- for stdin)")
+	return cmd
+}
+
+func newProviderAuthRotateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rotate",
+		Short: "Re-encrypt all credentials with a new key",
+		Long: `Re-encrypt every provider's credentials with a new key, encrypting any that
+are still stored in plain text. Without KANJI_NEW_PASSPHRASE a new key file
+is generated; with it, the key is derived from the new passphrase, which must
+then be exported as KANJI_PASSPHRASE. Requires --yes.`,
+		Example: `  kanji provider auth rotate --yes
+  KANJI_NEW_PASSPHRASE=... kanji provider auth rotate --yes`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns, err := ResolveNamespace()
+			if err != nil {
+				return err
+			}
+			return runProviderAuthRotate(cmd, ns)
+		},
+	}
+	cmd.Flags().Bool("yes", false, "confirm rotation")
+	return cmd
+}
+
+func newProviderAuthClearCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "clear",
+		Short:   "Remove a provider's credentials",
+		Example: `  kanji provider auth clear --provider "GitHub" --yes`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns, err := ResolveNamespace()
+			if err != nil {
+				return err
+			}
+			return runProviderAuthClear(cmd, ns)
+		},
+	}
+	cmd.Flags().String("provider-id", "", "provider ID")
+	cmd.Flags().String("provider", "", "provider name")
+	cmd.Flags().Bool("yes", false, "confirm removal")
+	return cmd
+}
+
+// resolveProvider selects a provider by --provider-id or --provider (name).
+func resolveProvider(ctx context.Context, cmd *cobra.Command, rt *Runtime) (domain.Provider, error) {
+	idChanged := cmd.Flags().Changed("provider-id")
+	nameChanged := cmd.Flags().Changed("provider")
+	if idChanged && nameChanged {
+		return domain.Provider{}, NewValidation("--provider-id and --provider are mutually exclusive")
+	}
+	if !idChanged && !nameChanged {
+		return domain.Provider{}, NewValidation("provider-id or provider is required")
+	}
+
+	providers, err := rt.ProviderService.ListProviders(ctx)
+	if err != nil {
+		return domain.Provider{}, err
+	}
+
+	if idChanged {
+		id, _ := cmd.Flags().GetString("provider-id")
+		id = strings.TrimSpace(id)
+		for _, p := range providers {
+			if p.ID == id {
+				return p, nil
+			}
+		}
+		return domain.Provider{}, NewNotFound("provider", id)
+	}
+
+	name, _ := cmd.Flags().GetString("provider")
+	name = strings.TrimSpace(name)
+	var matches []domain.Provider
+	for _, p := range providers {
+		if strings.EqualFold(strings.TrimSpace(p.Name), name) {
+			matches = append(matches, p)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return domain.Provider{}, NewNotFound("provider", name)
+	case 1:
+		return matches[0], nil
+	default:
+		return domain.Provider{}, NewAmbiguous("provider", name, len(matches))
+	}
+}
+
+func runProviderList(cmd *cobra.Command, ns Namespace) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	providers, err := rt.ProviderService.ListProviders(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if cfg.JSON {
+		items := make([]map[string]interface{}, len(providers))
+		for i, p := range providers {
+			items[i] = map[string]interface{}{
+				"id":         p.ID,
+				"type":       p.Type,
+				"name":       p.Name,
+				"has_auth":   p.AuthJSON != nil,
+				"encrypted":  p.AuthJSON != nil && rt.Credentials.IsEncrypted(*p.AuthJSON),
+				"created_at": p.CreatedAt.Format(time.RFC3339),
+			}
+		}
+		return RenderWrappedListJSON(cmd.OutOrStdout(), "providers", items, len(items))
+	}
+
+	headers := []string{"ID", "Type", "Name", "Auth", "Created"}
+	rows := make([][]string, len(providers))
+	for i, p := range providers {
+		auth := "none"
+		if p.AuthJSON != nil {
+			auth = "plaintext"
+			if rt.Credentials.IsEncrypted(*p.AuthJSON) {
+				auth = "encrypted"
+			}
+		}
+		rows[i] = []string{p.ID, p.Type, p.Name, auth, p.CreatedAt.Format("2006-01-02")}
+	}
+	return RenderTable(cmd.OutOrStdout(), headers, rows)
+}
+
+func runProviderAdd(cmd *cobra.Command, ns Namespace) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	providerType, _ := cmd.Flags().GetString("type")
+	if strings.TrimSpace(providerType) == "" {
+		return NewValidation("type is required")
+	}
+	name, _ := cmd.Flags().GetString("name")
+	if strings.TrimSpace(name) == "" {
+		return NewValidation("name is required")
+	}
+	auth, err := ResolveTextInput(cmd, "auth-json", "auth-file", true, nil)
+	if err != nil {
+		return err
+	}
+	var authJSON *string
+	if strings.TrimSpace(auth) != "" {
+		auth = strings.TrimSpace(auth)
+		authJSON = &auth
+	}
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	provider, err := rt.ProviderService.AddProvider(context.Background(), application.AddProviderInput{
+		Type:     providerType,
+		Name:     name,
+		AuthJSON: authJSON,
+	})
+	if err != nil {
+		return NewValidation(err.Error())
+	}
+
+	if cfg.JSON {
+		return RenderWriteResultJSON(cmd.OutOrStdout(), "provider", map[string]interface{}{
+			"id":       provider.ID,
+			"type":     provider.Type,
+			"name":     provider.Name,
+			"has_auth": provider.AuthJSON != nil,
+		})
+	}
+	return RenderWriteResult(cmd.OutOrStdout(), "provider", provider.ID, map[string]string{
+		"Type": provider.Type,
+		"Name": provider.Name,
+	})
+}
+
+func runProviderRemove(cmd *cobra.Command, ns Namespace) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	ctx := context.Background()
+	provider, err := resolveProvider(ctx, cmd, rt)
+	if err != nil {
+		return err
+	}
+
+	if err := RequireConfirmation(cmd, "yes"); err != nil {
+		return err
+	}
+
+	if err := rt.ProviderService.RemoveProvider(ctx, provider.ID); err != nil {
+		return NewValidation(err.Error())
+	}
+
+	if cfg.JSON {
+		return RenderDeleteResultJSON(cmd.OutOrStdout(), "provider", provider.ID, false)
+	}
+	return RenderDeleteResult(cmd.OutOrStdout(), "provider", provider.ID)
+}
+
+func runProviderTest(cmd *cobra.Command, ns Namespace) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	ctx := context.Background()
+	provider, err := resolveProvider(ctx, cmd, rt)
+	if err != nil {
+		return err
+	}
+
+	result, err := rt.ProviderService.TestProvider(ctx, provider.ID)
+	if err != nil {
+		return err
+	}
+
+	caps := result.Capabilities
+	entities := caps.Entities
+	if entities == nil {
+		entities = []string{}
+	}
+	if cfg.JSON {
+		return RenderWrappedJSON(cmd.OutOrStdout(), "provider", map[string]interface{}{
+			"id":       provider.ID,
+			"type":     provider.Type,
+			"name":     provider.Name,
+			"ok":       true,
+			"pull":     caps.Pull,
+			"push":     caps.Push,
+			"entities": entities,
+		})
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Provider OK\n")
+	return RenderKV(cmd.OutOrStdout(), map[string]string{
+		"ID":       provider.ID,
+		"Type":     provider.Type,
+		"Name":     provider.Name,
+		"Pull":     yesNo(caps.Pull),
+		"Push":     yesNo(caps.Push),
+		"Entities": strings.Join(entities, ", "),
+	})
+}
+
+func runProviderAuthSet(cmd *cobra.Command, ns Namespace) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	auth, err := ResolveTextInput(cmd, "auth-json", "auth-file", true, nil)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(auth) == "" {
+		return NewValidation("auth-json or auth-file is required")
+	}
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	ctx := context.Background()
+	provider, err := resolveProvider(ctx, cmd, rt)
+	if err != nil {
+		return err
+	}
+
+	provider, err = rt.ProviderService.SetAuth(ctx, provider.ID, auth)
+	if err != nil {
+		return NewValidation(err.Error())
+	}
+
+	if cfg.JSON {
+		return RenderWrappedJSON(cmd.OutOrStdout(), "provider", map[string]interface{}{
+			"id":        provider.ID,
+			"name":      provider.Name,
+			"has_auth":  true,
+			"encrypted": rt.Credentials.IsEncrypted(*provider.AuthJSON),
+		})
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), "Provider credentials updated")
+	return RenderKV(cmd.OutOrStdout(), map[string]string{
+		"ID":   provider.ID,
+		"Name": provider.Name,
+	})
+}
+
+func runProviderAuthClear(cmd *cobra.Command, ns Namespace) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	ctx := context.Background()
+	provider, err := resolveProvider(ctx, cmd, rt)
+	if err != nil {
+		return err
+	}
+
+	if err := RequireConfirmation(cmd, "yes"); err != nil {
+		return err
+	}
+
+	if _, err := rt.ProviderService.ClearAuth(ctx, provider.ID); err != nil {
+		return err
+	}
+
+	if cfg.JSON {
+		return RenderWrappedJSON(cmd.OutOrStdout(), "provider", map[string]interface{}{
+			"id":       provider.ID,
+			"name":     provider.Name,
+			"has_auth": false,
+		})
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), "Provider credentials cleared")
+	return RenderKV(cmd.OutOrStdout(), map[string]string{
+		"ID":   provider.ID,
+		"Name": provider.Name,
+	})
+}
+
+func runProviderAuthRotate(cmd *cobra.Command, ns Namespace) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	if err := RequireConfirmation(cmd, "yes"); err != nil {
+		return err
+	}
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	newPassphrase := os.Getenv(secrets.NewPassphraseEnv)
+	if rt.Credentials.UsesPassphrase() && newPassphrase == "" {
+		return NewValidation(fmt.Sprintf("%s is set: provide the new passphrase in %s", secrets.PassphraseEnv, secrets.NewPassphraseEnv))
+	}
+
+	rotation, err := rt.Credentials.Rotate(newPassphrase)
+	if err != nil {
+		return err
+	}
+	count, err := rt.ProviderService.RotateAuth(context.Background(), rotation.Next)
+	if err != nil {
+		rotation.Abort()
+		return err
+	}
+	// Webhook signing secrets are sealed with the same key.
+	webhookCount, err := rt.WebhookService.RotateSecrets(context.Background(), rotation.Next)
+	if err != nil {
+		rotation.Abort()
+		return err
+	}
+	count += webhookCount
+	if err := rotation.Commit(); err != nil {
+		return err
+	}
+
+	if cfg.JSON {
+		return RenderWrappedJSON(cmd.OutOrStdout(), "credentials", map[string]interface{}{
+			"rotated":    count,
+			"passphrase": newPassphrase != "",
+		})
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Credentials rotated: %d\n", count)
+	if newPassphrase != "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "Export the new passphrase as %s from now on.\n", secrets.PassphraseEnv)
+	}
+	return nil
+}
+
+func yesNo(v bool) string {
+	if v {
+		return "yes"
+	}
+	return "no"
+}
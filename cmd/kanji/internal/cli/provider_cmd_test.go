@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tiagokriok/kanji/internal/infrastructure/db/sqlc"
+)
+
+func newProviderCLITestCommand(dbPath string, args ...string) *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("db-path", "", "")
+	cmd.Flags().Bool("json", false, "")
+	cmd.Flags().String("type", "", "")
+	cmd.Flags().String("name", "", "")
+	cmd.Flags().String("auth-json", "", "")
+	cmd.Flags().String("auth-file", "", "")
+	cmd.Flags().String("provider-id", "", "")
+	cmd.Flags().String("provider", "", "")
+	cmd.Flags().Bool("yes", false, "")
+	_ = cmd.ParseFlags(append([]string{"--db-path", dbPath}, args...))
+	return cmd
+}
+
+func TestProviderAdd_ListHidesAuth(t *testing.T) {
+	dbPath := setupSyncTestDB(t)
+	ns := Namespace{Key: "test-ns", Source: "cwd"}
+
+	cmd := newProviderCLITestCommand(dbPath, "--type", "local", "--name", "Scratch", "--auth-json", `{"token":"secret"}`)
+	buf := new(strings.Builder)
+	cmd.SetOut(buf)
+	require.NoError(t, runProviderAdd(cmd, ns))
+	assert.Contains(t, buf.String(), "provider created")
+
+	cmd = newProviderCLITestCommand(dbPath, "--json")
+	buf = new(strings.Builder)
+	cmd.SetOut(buf)
+	require.NoError(t, runProviderList(cmd, ns))
+	assert.NotContains(t, buf.String(), "secret")
+
+	var out struct {
+		Providers []map[string]interface{} `json:"providers"`
+		Count     int                      `json:"count"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(buf.String()), &out))
+	require.Equal(t, 2, out.Count)
+	for _, p := range out.Providers {
+		if p["name"] == "Scratch" {
+			assert.Equal(t, true, p["has_auth"])
+		}
+	}
+}
+
+func TestProviderAdd_UnknownType(t *testing.T) {
+	dbPath := setupSyncTestDB(t)
+
+	cmd := newProviderCLITestCommand(dbPath, "--type", "bogus", "--name", "X")
+	err := runProviderAdd(cmd, Namespace{Key: "test-ns", Source: "cwd"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown provider type")
+}
+
+func TestProviderRemove(t *testing.T) {
+	dbPath := setupSyncTestDB(t)
+	ns := Namespace{Key: "test-ns", Source: "cwd"}
+
+	cmd := newProviderCLITestCommand(dbPath, "--type", "local", "--name", "Spare")
+	cmd.SetOut(new(strings.Builder))
+	require.NoError(t, runProviderAdd(cmd, ns))
+
+	cmd = newProviderCLITestCommand(dbPath, "--provider", "spare")
+	err := runProviderRemove(cmd, ns)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--yes")
+
+	cmd = newProviderCLITestCommand(dbPath, "--provider", "spare", "--yes")
+	buf := new(strings.Builder)
+	cmd.SetOut(buf)
+	require.NoError(t, runProviderRemove(cmd, ns))
+	assert.Contains(t, buf.String(), "Provider deleted")
+
+	cmd = newProviderCLITestCommand(dbPath, "--provider", "Local", "--yes")
+	err = runProviderRemove(cmd, ns)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "used by 1 workspace")
+}
+
+func TestProviderTest_Local(t *testing.T) {
+	dbPath := setupSyncTestDB(t)
+
+	cmd := newProviderCLITestCommand(dbPath, "--provider", "Local")
+	buf := new(strings.Builder)
+	cmd.SetOut(buf)
+	require.NoError(t, runProviderTest(cmd, Namespace{Key: "test-ns", Source: "cwd"}))
+	assert.Contains(t, buf.String(), "Provider OK")
+	assert.Contains(t, buf.String(), "local")
+}
+
+func TestProviderAuth_SetClearAndDoctor(t *testing.T) {
+	dbPath := setupSyncTestDB(t)
+	ns := Namespace{Key: "test-ns", Source: "cwd"}
+
+	// A provider stored before credentials were encrypted.
+	rt, err := NewRuntime(context.Background(), RuntimeConfig{DBPath: dbPath})
+	require.NoError(t, err)
+	require.NoError(t, rt.Store.Queries().CreateProvider(context.Background(), sqlc.CreateProviderParams{
+		ID:        "p-legacy",
+		Type:      "github",
+		Name:      "Legacy",
+		AuthJSON:  sql.NullString{String: `{"token":"hunter2"}`, Valid: true},
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+	}))
+	rt.Close()
+
+	cmd := newProviderCLITestCommand(dbPath)
+	buf := new(strings.Builder)
+	cmd.SetOut(buf)
+	require.Error(t, runDBDoctor(cmd, ns))
+	assert.Contains(t, buf.String(), "unencrypted_credentials")
+	assert.Contains(t, buf.String(), "Legacy")
+
+	cmd = newProviderCLITestCommand(dbPath, "--yes")
+	buf = new(strings.Builder)
+	cmd.SetOut(buf)
+	require.NoError(t, runProviderAuthRotate(cmd, ns))
+	assert.Contains(t, buf.String(), "Credentials rotated: 1")
+
+	cmd = newProviderCLITestCommand(dbPath)
+	buf = new(strings.Builder)
+	cmd.SetOut(buf)
+	require.NoError(t, runDBDoctor(cmd, ns))
+
+	cmd = newProviderCLITestCommand(dbPath, "--provider", "Legacy", "--auth-json", `{"token":"swordfish"}`)
+	buf = new(strings.Builder)
+	cmd.SetOut(buf)
+	require.NoError(t, runProviderAuthSet(cmd, ns))
+	assert.Contains(t, buf.String(), "Provider credentials updated")
+
+	rt, err = NewRuntime(context.Background(), RuntimeConfig{DBPath: dbPath})
+	require.NoError(t, err)
+	provider, err := rt.ProviderService.GetProvider(context.Background(), "p-legacy")
+	require.NoError(t, err)
+	assert.NotContains(t, *provider.AuthJSON, "swordfish")
+	plaintext, err := rt.Credentials.Decrypt(*provider.AuthJSON)
+	require.NoError(t, err)
+	assert.Equal(t, `{"token":"swordfish"}`, plaintext)
+	rt.Close()
+
+	cmd = newProviderCLITestCommand(dbPath, "--provider", "Legacy")
+	require.ErrorContains(t, runProviderAuthClear(cmd, ns), "--yes")
+
+	cmd = newProviderCLITestCommand(dbPath, "--provider", "Legacy", "--yes", "--json")
+	buf = new(strings.Builder)
+	cmd.SetOut(buf)
+	require.NoError(t, runProviderAuthClear(cmd, ns))
+	assert.Contains(t, buf.String(), `"has_auth": false`)
+}
@@ -153,7 +153,13 @@ func RenderWriteResolved(w io.Writer, resolved map[string]string) error {
 
 // RenderDryRunImpact writes a human-readable dry-run impact summary.
 func RenderDryRunImpact(w io.Writer, resourceName string, impact map[string]int) error {
-	fmt.Fprintf(w, "Dry-run: %s delete impact\n", resourceName)
+	return RenderDryRunActionImpact(w, resourceName, "delete", impact)
+}
+
+// RenderDryRunActionImpact writes a human-readable dry-run impact summary
+// for an action other than delete.
+func RenderDryRunActionImpact(w io.Writer, resourceName, action string, impact map[string]int) error {
+	fmt.Fprintf(w, "Dry-run: %s %s impact\n", resourceName, action)
 	pairs := map[string]string{}
 	for k, v := range impact {
 		pairs[k] = strconv.Itoa(v)
@@ -33,9 +33,21 @@ Use "kanji help" for available commands.
 	root.AddCommand(newDBCommand())
 	root.AddCommand(newWorkspaceCommand())
 	root.AddCommand(newBoardCommand())
+	root.AddCommand(newTemplateCommand())
 	root.AddCommand(newColumnCommand())
 	root.AddCommand(newTaskCommand())
 	root.AddCommand(newCommentCommand())
+	root.AddCommand(newActivityCommand())
+	root.AddCommand(newSearchCommand())
+	root.AddCommand(newUndoCommand())
+	root.AddCommand(newRedoCommand())
+	root.AddCommand(newProviderCommand())
+	root.AddCommand(newSyncCommand())
+	root.AddCommand(newWebhookCommand())
+	root.AddCommand(newViewCommand())
+	root.AddCommand(newLabelCommand())
+	root.AddCommand(newServeCommand())
+	root.AddCommand(newMCPCommand())
 	root.AddCommand(newTUICommand())
 
 	return root
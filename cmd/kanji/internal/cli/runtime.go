@@ -2,35 +2,24 @@ package cli
 
 import (
 	"context"
-	"fmt"
+	"os"
 
-	"github.com/tiagokriok/kanji/internal/application"
-	"github.com/tiagokriok/kanji/internal/infrastructure/db"
-	"github.com/tiagokriok/kanji/internal/infrastructure/repositories"
-	"github.com/tiagokriok/kanji/internal/infrastructure/store"
+	"github.com/tiagokriok/kanji/internal/app"
 )
 
 // Runtime holds the initialized infrastructure and application services
 // for a single CLI command invocation.
 type Runtime struct {
-	DB                     *db.SQLiteAdapter
-	Store                  store.Store
-	BootstrapService       *application.BootstrapService
-	TaskService            *application.TaskService
-	TaskFlow               *application.TaskFlow
-	CommentService         *application.CommentService
-	ContextService         *application.ContextService
-	BoardDeleteService     *application.BoardDeleteService
-	ColumnDeleteService    *application.ColumnDeleteService
-	WorkspaceDeleteService *application.WorkspaceDeleteService
+	*app.App
 }
 
-// Close releases the database connection.
+// Close sends webhook deliveries queued by the command, then releases the
+// database connection. Deliveries that fail stay queued for a later run.
 func (r *Runtime) Close() error {
-	if r.DB != nil {
-		return r.DB.Close()
+	if r.App == nil {
+		return nil
 	}
-	return nil
+	return r.App.Close()
 }
 
 // NewRuntime opens the database, runs migrations, and wires all
@@ -38,33 +27,15 @@ func (r *Runtime) Close() error {
 // that need bootstrap should call BootstrapService.EnsureDefaultSetup
 // explicitly.
 func NewRuntime(ctx context.Context, cfg RuntimeConfig) (*Runtime, error) {
-	adapter, err := db.NewSQLiteAdapter(cfg.DBPath)
-	if err != nil {
-		return nil, fmt.Errorf("open database: %w", err)
-	}
-
-	if err := db.RunMigrations(ctx, adapter.Raw()); err != nil {
-		_ = adapter.Close()
-		return nil, fmt.Errorf("run migrations: %w", err)
+	opts := app.Options{DBPath: cfg.DBPath, HookWarnings: os.Stderr}
+	// Changes are journaled under the namespace the command runs in, where
+	// `kanji undo` reverts them.
+	if ns, err := ResolveNamespace(); err == nil {
+		opts.Journal = ns.Key
 	}
-
-	s := store.New(adapter)
-	setupRepo := repositories.NewSetupRepository(s)
-	taskRepo := repositories.NewTaskRepository(s)
-	commentRepo := repositories.NewCommentRepository(s)
-
-	rt := &Runtime{
-		DB:                     adapter,
-		Store:                  s,
-		BootstrapService:       application.NewBootstrapService(setupRepo),
-		TaskService:            application.NewTaskService(taskRepo),
-		TaskFlow:               application.NewTaskFlow(taskRepo),
-		CommentService:         application.NewCommentService(commentRepo),
-		ContextService:         application.NewContextService(setupRepo),
-		BoardDeleteService:     application.NewBoardDeleteService(setupRepo, taskRepo, commentRepo),
-		ColumnDeleteService:    application.NewColumnDeleteService(setupRepo, taskRepo, application.NewTaskFlow(taskRepo)),
-		WorkspaceDeleteService: application.NewWorkspaceDeleteService(setupRepo, taskRepo, commentRepo),
+	a, err := app.Open(ctx, opts)
+	if err != nil {
+		return nil, err
 	}
-
-	return rt, nil
+	return &Runtime{App: a}, nil
 }
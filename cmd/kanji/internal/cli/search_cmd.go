@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"context"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+	"github.com/tiagokriok/kanji/internal/state"
+)
+
+func newSearchCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "search",
+		Short: "Search task titles, descriptions, labels and comments",
+		Long: `Search the tasks of a workspace by full text, best match first. A task matches
+when its title, description, labels or comments contain every word of the
+query; words also match as prefixes, so "auth" finds "authentication". Title
+matches rank above label, description and comment matches.
+
+The Match column shows the text around the match with matched words in
+**bold** markers.`,
+		Example: `  kanji search --query "login bug"
+  kanji search --query redirect --board "Sprint" --limit 5 --json`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns, err := ResolveNamespace()
+			if err != nil {
+				return err
+			}
+			return runSearch(cmd, ns)
+		},
+	}
+	cmd.Flags().String("query", "", "words to search for")
+	cmd.Flags().String("workspace-id", "", "workspace ID")
+	cmd.Flags().String("workspace", "", "workspace name")
+	cmd.Flags().String("board-id", "", "board ID (optional narrowing)")
+	cmd.Flags().String("board", "", "board name (optional narrowing)")
+	cmd.Flags().Int("limit", 20, "maximum number of results (0 for all)")
+	return cmd
+}
+
+func runSearch(cmd *cobra.Command, ns Namespace) error {
+	store, err := defaultStateStore()
+	if err != nil {
+		return err
+	}
+	return runSearchWithStore(cmd, ns, store)
+}
+
+func runSearchWithStore(cmd *cobra.Command, ns Namespace, store *state.Store) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	if err := GuardBootstrap(rt); err != nil {
+		return err
+	}
+
+	hits, err := searchTasks(context.Background(), cmd, rt, store, ns)
+	if err != nil {
+		return err
+	}
+
+	if cfg.JSON {
+		return RenderWrappedListJSON(cmd.OutOrStdout(), "results", searchHitsJSON(hits), len(hits))
+	}
+	headers := []string{"ID", "Title", "Status", "Match"}
+	rows := make([][]string, len(hits))
+	for i, hit := range hits {
+		status := ""
+		if hit.Task.Status != nil {
+			status = *hit.Task.Status
+		}
+		rows[i] = []string{hit.Task.ID, hit.Task.Title, status, markSnippet(hit.Snippet)}
+	}
+	return RenderTable(cmd.OutOrStdout(), headers, rows)
+}
+
+// searchTasks resolves the scope of `kanji search` and runs the query.
+func searchTasks(ctx context.Context, cmd *cobra.Command, rt *Runtime, store *state.Store, ns Namespace) ([]domain.SearchHit, error) {
+	query, _ := cmd.Flags().GetString("query")
+	if strings.TrimSpace(query) == "" {
+		return nil, NewValidation("--query is required")
+	}
+	limit, _ := cmd.Flags().GetInt("limit")
+	if limit < 0 {
+		return nil, NewValidation("--limit must not be negative")
+	}
+	workspaceID, _, err := ResolveWorkspaceScope(cmd, rt, store, ns)
+	if err != nil {
+		return nil, err
+	}
+	var boardID string
+	if cmd.Flags().Changed("board-id") || cmd.Flags().Changed("board") {
+		if boardID, _, err = ResolveBoardScope(cmd, rt, store, ns, workspaceID); err != nil {
+			return nil, err
+		}
+	}
+	return rt.SearchService.Search(ctx, domain.SearchFilter{
+		WorkspaceID: workspaceID,
+		BoardID:     boardID,
+		Query:       query,
+		Limit:       limit,
+	})
+}
+
+// markSnippet puts the matched words of a snippet between ** markers and
+// keeps it on one line.
+func markSnippet(snippet string) string {
+	snippet = strings.NewReplacer(
+		domain.SearchMatchStart, "**",
+		domain.SearchMatchEnd, "**",
+		"\r", " ",
+		"\n", " ",
+	).Replace(snippet)
+	return strings.Join(strings.Fields(snippet), " ")
+}
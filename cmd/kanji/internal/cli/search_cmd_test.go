@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearch(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	dbPath, setup, taskID := setupFullDoingColumn(t)
+	ns := Namespace{Key: "test-ns", Source: "cwd"}
+
+	comment := newVersionedCommand(t, newCommentCreateCommand(), dbPath, "--task-id", taskID, "--body", "Waiting on the vendor contract")
+	require.NoError(t, runCommentCreate(comment, ns))
+
+	search := newVersionedCommand(t, newSearchCommand(), dbPath, "--workspace-id", setup.Workspace.ID, "--query", "vend")
+	search.Flags().Bool("json", true, "")
+	require.NoError(t, runSearch(search, ns))
+	var got struct {
+		Results []struct {
+			ID      string `json:"id"`
+			Title   string `json:"title"`
+			Snippet string `json:"snippet"`
+		} `json:"results"`
+		Count int `json:"count"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(search.OutOrStdout().(*strings.Builder).String()), &got))
+	require.Equal(t, 1, got.Count)
+	assert.Equal(t, taskID, got.Results[0].ID)
+	assert.Equal(t, "Waiting on the **vendor** contract", got.Results[0].Snippet)
+
+	text := newVersionedCommand(t, newSearchCommand(), dbPath, "--workspace-id", setup.Workspace.ID, "--query", "progress")
+	require.NoError(t, runSearch(text, ns))
+	out := text.OutOrStdout().(*strings.Builder).String()
+	assert.Contains(t, out, "In **progress**")
+	assert.NotContains(t, out, "Waiting")
+
+	empty := newVersionedCommand(t, newSearchCommand(), dbPath, "--workspace-id", setup.Workspace.ID)
+	assert.True(t, errors.Is(runSearch(empty, ns), &SelectorError{Code: "validation"}))
+}
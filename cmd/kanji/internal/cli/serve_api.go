@@ -0,0 +1,1134 @@
+package cli
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tiagokriok/kanji/internal/application"
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+// maxRequestBody caps JSON request bodies.
+const maxRequestBody = 1 << 20
+
+// apiServer serves the JSON HTTP API on top of a long-lived Runtime. Payloads
+// use the same shapes as the --json CLI output.
+type apiServer struct {
+	rt *Runtime
+}
+
+// newAPIHandler returns the API routes behind guardAPI. Every request must
+// carry token as a bearer token.
+func newAPIHandler(rt *Runtime, token string) http.Handler {
+	s := &apiServer{rt: rt}
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /v1/health", s.handleHealth)
+
+	mux.HandleFunc("GET /v1/workspaces", s.handleListWorkspaces)
+	mux.HandleFunc("POST /v1/workspaces", s.handleCreateWorkspace)
+	mux.HandleFunc("GET /v1/workspaces/{id}", s.handleGetWorkspace)
+	mux.HandleFunc("PATCH /v1/workspaces/{id}", s.handleUpdateWorkspace)
+	mux.HandleFunc("DELETE /v1/workspaces/{id}", s.handleDeleteWorkspace)
+
+	mux.HandleFunc("GET /v1/workspaces/{id}/boards", s.handleListBoards)
+	mux.HandleFunc("POST /v1/workspaces/{id}/boards", s.handleCreateBoard)
+	mux.HandleFunc("GET /v1/boards/{id}", s.handleGetBoard)
+	mux.HandleFunc("PATCH /v1/boards/{id}", s.handleUpdateBoard)
+	mux.HandleFunc("DELETE /v1/boards/{id}", s.handleDeleteBoard)
+
+	mux.HandleFunc("GET /v1/boards/{id}/columns", s.handleListColumns)
+	mux.HandleFunc("POST /v1/boards/{id}/columns", s.handleCreateColumn)
+	mux.HandleFunc("GET /v1/columns/{id}", s.handleGetColumn)
+	mux.HandleFunc("PATCH /v1/columns/{id}", s.handleUpdateColumn)
+	mux.HandleFunc("DELETE /v1/columns/{id}", s.handleDeleteColumn)
+
+	mux.HandleFunc("GET /v1/boards/{id}/tasks", s.handleListTasks)
+	mux.HandleFunc("POST /v1/boards/{id}/tasks", s.handleCreateTask)
+	mux.HandleFunc("GET /v1/tasks/{id}", s.handleGetTask)
+	mux.HandleFunc("PATCH /v1/tasks/{id}", s.handleUpdateTask)
+	mux.HandleFunc("POST /v1/tasks/{id}/move", s.handleMoveTask)
+	mux.HandleFunc("DELETE /v1/tasks/{id}", s.handleDeleteTask)
+
+	mux.HandleFunc("GET /v1/tasks/{id}/comments", s.handleListComments)
+	mux.HandleFunc("POST /v1/tasks/{id}/comments", s.handleCreateComment)
+	mux.HandleFunc("GET /v1/tasks/{id}/comments/{comment_id}", s.handleGetComment)
+	mux.HandleFunc("PATCH /v1/tasks/{id}/comments/{comment_id}", s.handleUpdateComment)
+	mux.HandleFunc("DELETE /v1/tasks/{id}/comments/{comment_id}", s.handleDeleteComment)
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeAPIError(w, &SelectorError{Code: "not_found", Message: fmt.Sprintf("no route for %s %s", r.Method, r.URL.Path)})
+	})
+	return guardAPI(token, mux)
+}
+
+// guardAPI keeps web pages the user visits away from the API. Writes run
+// hooks and send webhooks, so a page must not reach them with a simple
+// cross-origin request or through DNS rebinding: requests need a loopback
+// Host, a loopback Origin when a browser sends one, JSON bodies, and the
+// bearer token printed by kanji serve.
+func guardAPI(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isLoopbackHost(r.Host) {
+			writeAPIError(w, &SelectorError{Code: "forbidden", Message: fmt.Sprintf("host %q is not allowed", r.Host)})
+			return
+		}
+		if origin := r.Header.Get("Origin"); origin != "" && !isLoopbackOrigin(origin) {
+			writeAPIError(w, &SelectorError{Code: "forbidden", Message: fmt.Sprintf("origin %q is not allowed", origin)})
+			return
+		}
+		got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			writeAPIError(w, &SelectorError{Code: "unauthorized", Message: "missing or invalid bearer token"})
+			return
+		}
+		if r.ContentLength != 0 || r.Method == http.MethodPost || r.Method == http.MethodPatch || r.Method == http.MethodPut {
+			mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			if err != nil || mediaType != "application/json" {
+				writeAPIError(w, &SelectorError{Code: "unsupported_media_type", Message: "Content-Type must be application/json"})
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isLoopbackHost reports whether a Host header names localhost or a loopback
+// address, with or without a port.
+func isLoopbackHost(host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func isLoopbackOrigin(origin string) bool {
+	u, err := url.Parse(origin)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return false
+	}
+	return isLoopbackHost(u.Host)
+}
+
+// ── Responses ──
+
+func writeAPIJSON(w http.ResponseWriter, status int, key string, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = RenderWrappedJSON(w, key, data)
+}
+
+func writeAPIList(w http.ResponseWriter, key string, items interface{}, count int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = RenderWrappedListJSON(w, key, items, count)
+}
+
+// writeAPIError renders err with RenderJSONError. Typed CLI errors keep their
+// code; a missing row is not_found; anything else is an internal error.
+func writeAPIError(w http.ResponseWriter, err error) {
+	code, message, status := "internal", err.Error(), http.StatusInternalServerError
+	var selErr *SelectorError
+	switch {
+	case errors.As(err, &selErr):
+		code = selErr.Code
+		switch selErr.Code {
+		case "not_found":
+			status = http.StatusNotFound
+		case "unauthorized":
+			status = http.StatusUnauthorized
+		case "forbidden":
+			status = http.StatusForbidden
+		case "unsupported_media_type":
+			status = http.StatusUnsupportedMediaType
+		case "ambiguous", "wip_limit_exceeded", "version_conflict":
+			status = http.StatusConflict
+		default:
+			status = http.StatusBadRequest
+		}
+	case errors.Is(err, sql.ErrNoRows):
+		code, message, status = "not_found", "resource not found", http.StatusNotFound
+	case errors.Is(err, application.ErrWIPLimitExceeded):
+		code, message, status = "wip_limit_exceeded", err.Error()+`; set "force": true to override`, http.StatusConflict
+	case errors.Is(err, application.ErrHookRejected):
+		code, status = "hook_rejected", http.StatusConflict
+	case errors.Is(err, domain.ErrVersionConflict):
+		code, message, status = "version_conflict", err.Error()+"; fetch it again and retry", http.StatusConflict
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = RenderJSONError(w, code, message)
+}
+
+// writeAPIWriteError renders a failed create or update. WIP limit, hook and
+// version conflict errors keep their codes; other service errors are
+// validation errors.
+func writeAPIWriteError(w http.ResponseWriter, err error) {
+	if errors.Is(err, application.ErrWIPLimitExceeded) || errors.Is(err, application.ErrHookRejected) ||
+		errors.Is(err, domain.ErrVersionConflict) {
+		writeAPIError(w, err)
+		return
+	}
+	writeAPIError(w, NewValidation(err.Error()))
+}
+
+// decodeAPIBody reads a JSON object into v, rejecting unknown fields.
+func decodeAPIBody(r *http.Request, v interface{}) error {
+	dec := json.NewDecoder(io.LimitReader(r.Body, maxRequestBody))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		if errors.Is(err, io.EOF) {
+			return NewValidation("request body is required")
+		}
+		return NewValidation("invalid request body: " + err.Error())
+	}
+	return nil
+}
+
+// queryBool reads a boolean query parameter, treating absence as false.
+func queryBool(r *http.Request, name string) (bool, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return false, nil
+	}
+	v, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, NewValidation(fmt.Sprintf("%s must be true or false", name))
+	}
+	return v, nil
+}
+
+// ── Lookups ──
+
+func (s *apiServer) workspace(ctx context.Context, id string) (domain.Workspace, error) {
+	workspaces, err := s.rt.ContextService.ListWorkspaces(ctx)
+	if err != nil {
+		return domain.Workspace{}, err
+	}
+	for _, ws := range workspaces {
+		if ws.ID == id {
+			return ws, nil
+		}
+	}
+	return domain.Workspace{}, NewNotFound("workspace", id)
+}
+
+func (s *apiServer) board(ctx context.Context, id string) (domain.Board, error) {
+	workspaces, err := s.rt.ContextService.ListWorkspaces(ctx)
+	if err != nil {
+		return domain.Board{}, err
+	}
+	for _, ws := range workspaces {
+		boards, err := s.rt.ContextService.ListBoards(ctx, ws.ID)
+		if err != nil {
+			return domain.Board{}, err
+		}
+		for _, b := range boards {
+			if b.ID == id {
+				return b, nil
+			}
+		}
+	}
+	return domain.Board{}, NewNotFound("board", id)
+}
+
+// column finds a column by ID together with its board.
+func (s *apiServer) column(ctx context.Context, id string) (domain.Column, domain.Board, error) {
+	workspaces, err := s.rt.ContextService.ListWorkspaces(ctx)
+	if err != nil {
+		return domain.Column{}, domain.Board{}, err
+	}
+	for _, ws := range workspaces {
+		boards, err := s.rt.ContextService.ListBoards(ctx, ws.ID)
+		if err != nil {
+			return domain.Column{}, domain.Board{}, err
+		}
+		for _, b := range boards {
+			columns, err := s.rt.ContextService.ListColumns(ctx, b.ID)
+			if err != nil {
+				return domain.Column{}, domain.Board{}, err
+			}
+			for _, c := range columns {
+				if c.ID == id {
+					return c, b, nil
+				}
+			}
+		}
+	}
+	return domain.Column{}, domain.Board{}, NewNotFound("column", id)
+}
+
+func (s *apiServer) task(ctx context.Context, id string) (domain.Task, error) {
+	task, err := s.rt.TaskService.GetTask(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Task{}, NewNotFound("task", id)
+	}
+	return task, err
+}
+
+func (s *apiServer) comment(ctx context.Context, taskID, commentID string) (domain.Comment, error) {
+	if _, err := s.task(ctx, taskID); err != nil {
+		return domain.Comment{}, err
+	}
+	comments, err := s.rt.CommentService.ListComments(ctx, taskID)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	for _, c := range comments {
+		if c.ID == commentID {
+			return c, nil
+		}
+	}
+	return domain.Comment{}, NewNotFound("comment", commentID)
+}
+
+// ── Health ──
+
+func (s *apiServer) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeAPIJSON(w, http.StatusOK, "health", map[string]string{"status": "ok"})
+}
+
+// ── Workspaces ──
+
+func (s *apiServer) handleListWorkspaces(w http.ResponseWriter, r *http.Request) {
+	workspaces, err := s.rt.ContextService.ListWorkspaces(r.Context())
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	items := make([]map[string]string, len(workspaces))
+	for i, ws := range workspaces {
+		items[i] = workspaceJSON(ws)
+	}
+	writeAPIList(w, "workspaces", items, len(items))
+}
+
+func (s *apiServer) handleGetWorkspace(w http.ResponseWriter, r *http.Request) {
+	ws, err := s.workspace(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	writeAPIJSON(w, http.StatusOK, "workspace", workspaceJSON(ws))
+}
+
+func (s *apiServer) handleCreateWorkspace(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Name       string `json:"name"`
+		ProviderID string `json:"provider_id"`
+	}
+	if err := decodeAPIBody(r, &body); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	if strings.TrimSpace(body.Name) == "" {
+		writeAPIError(w, NewValidation("name is required"))
+		return
+	}
+
+	ctx := r.Context()
+	providerID := strings.TrimSpace(body.ProviderID)
+	if providerID == "" {
+		setup, err := s.rt.BootstrapService.EnsureDefaultSetup(ctx)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		providerID = setup.Provider.ID
+	} else if _, err := s.rt.ProviderService.GetProvider(ctx, providerID); err != nil {
+		writeAPIError(w, NewNotFound("provider", providerID))
+		return
+	}
+
+	ws, board, err := s.rt.ContextService.CreateWorkspace(ctx, providerID, body.Name)
+	if err != nil {
+		writeAPIError(w, NewValidation(err.Error()))
+		return
+	}
+	writeAPIJSON(w, http.StatusCreated, "workspace", map[string]interface{}{
+		"id":          ws.ID,
+		"name":        ws.Name,
+		"provider_id": ws.ProviderID,
+		"board":       board.Name,
+		"board_id":    board.ID,
+	})
+}
+
+func (s *apiServer) handleUpdateWorkspace(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	ws, err := s.workspace(ctx, r.PathValue("id"))
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	var body struct {
+		Name string `json:"name"`
+	}
+	if err := decodeAPIBody(r, &body); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	if strings.TrimSpace(body.Name) == "" {
+		writeAPIError(w, NewValidation("name is required"))
+		return
+	}
+	if err := s.rt.ContextService.RenameWorkspace(ctx, ws.ID, body.Name); err != nil {
+		writeAPIError(w, NewValidation(err.Error()))
+		return
+	}
+	writeAPIJSON(w, http.StatusOK, "workspace", map[string]interface{}{
+		"id":   ws.ID,
+		"name": body.Name,
+	})
+}
+
+func (s *apiServer) handleDeleteWorkspace(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	ws, err := s.workspace(ctx, r.PathValue("id"))
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	dryRun, err := queryBool(r, "dry_run")
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	if dryRun {
+		impact, err := s.rt.WorkspaceDeleteService.Impact(ctx, ws.ID)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		writeAPIJSON(w, http.StatusOK, "workspace", map[string]interface{}{
+			"dry_run": true,
+			"impact": map[string]int{
+				"boards":   impact.Boards,
+				"columns":  impact.Columns,
+				"tasks":    impact.Tasks,
+				"comments": impact.Comments,
+			},
+		})
+		return
+	}
+	cascade, err := queryBool(r, "cascade")
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	if !cascade {
+		writeAPIError(w, NewValidation("deletion requires cascade=true"))
+		return
+	}
+	if err := s.rt.WorkspaceDeleteService.Delete(ctx, ws.ID); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	writeAPIJSON(w, http.StatusOK, "workspace", map[string]interface{}{
+		"id":      ws.ID,
+		"deleted": true,
+		"cascade": true,
+	})
+}
+
+// ── Boards ──
+
+func (s *apiServer) handleListBoards(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	ws, err := s.workspace(ctx, r.PathValue("id"))
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	boards, err := s.rt.ContextService.ListBoards(ctx, ws.ID)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	items := make([]map[string]string, len(boards))
+	for i, b := range boards {
+		items[i] = boardJSON(b)
+	}
+	writeAPIList(w, "boards", items, len(items))
+}
+
+func (s *apiServer) handleGetBoard(w http.ResponseWriter, r *http.Request) {
+	board, err := s.board(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	writeAPIJSON(w, http.StatusOK, "board", boardJSON(board))
+}
+
+func (s *apiServer) handleCreateBoard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	ws, err := s.workspace(ctx, r.PathValue("id"))
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	var body struct {
+		Name    string `json:"name"`
+		Columns []struct {
+			Name  string `json:"name"`
+			Color string `json:"color"`
+		} `json:"columns"`
+	}
+	if err := decodeAPIBody(r, &body); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	if strings.TrimSpace(body.Name) == "" {
+		writeAPIError(w, NewValidation("name is required"))
+		return
+	}
+
+	var board domain.Board
+	if len(body.Columns) > 0 {
+		inputs := make([]application.CreateBoardColumnInput, len(body.Columns))
+		for i, c := range body.Columns {
+			inputs[i] = application.CreateBoardColumnInput{Name: c.Name, Color: c.Color}
+		}
+		board, err = s.rt.ContextService.CreateBoardWithColumns(ctx, ws.ID, body.Name, inputs)
+	} else {
+		board, err = s.rt.ContextService.CreateBoard(ctx, ws.ID, body.Name)
+	}
+	if err != nil {
+		writeAPIError(w, NewValidation(err.Error()))
+		return
+	}
+	writeAPIJSON(w, http.StatusCreated, "board", boardJSON(board))
+}
+
+func (s *apiServer) handleUpdateBoard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	board, err := s.board(ctx, r.PathValue("id"))
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	var body struct {
+		Name string `json:"name"`
+	}
+	if err := decodeAPIBody(r, &body); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	if strings.TrimSpace(body.Name) == "" {
+		writeAPIError(w, NewValidation("name is required"))
+		return
+	}
+	if err := s.rt.ContextService.RenameBoard(ctx, board.ID, body.Name); err != nil {
+		writeAPIError(w, NewValidation(err.Error()))
+		return
+	}
+	board.Name = body.Name
+	writeAPIJSON(w, http.StatusOK, "board", boardJSON(board))
+}
+
+func (s *apiServer) handleDeleteBoard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	board, err := s.board(ctx, r.PathValue("id"))
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	dryRun, err := queryBool(r, "dry_run")
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	if dryRun {
+		impact, err := s.rt.BoardDeleteService.BoardDeleteImpact(ctx, board.WorkspaceID, board.ID)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		writeAPIJSON(w, http.StatusOK, "board", map[string]interface{}{
+			"dry_run": true,
+			"impact": map[string]int{
+				"columns":  impact.Columns,
+				"tasks":    impact.Tasks,
+				"comments": impact.Comments,
+			},
+		})
+		return
+	}
+	cascade, err := queryBool(r, "cascade")
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	if !cascade {
+		writeAPIError(w, NewValidation("deletion requires cascade=true"))
+		return
+	}
+	if err := s.rt.BoardDeleteService.DeleteBoard(ctx, board.ID); err != nil {
+		writeAPIError(w, NewValidation(err.Error()))
+		return
+	}
+	writeAPIJSON(w, http.StatusOK, "board", map[string]interface{}{
+		"id":      board.ID,
+		"deleted": true,
+		"cascade": true,
+	})
+}
+
+// ── Columns ──
+
+func (s *apiServer) handleListColumns(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	board, err := s.board(ctx, r.PathValue("id"))
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	columns, err := s.rt.ContextService.ListColumns(ctx, board.ID)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	items := make([]map[string]interface{}, len(columns))
+	for i, c := range columns {
+		items[i] = columnListItemJSON(c)
+	}
+	writeAPIList(w, "columns", items, len(items))
+}
+
+func (s *apiServer) handleGetColumn(w http.ResponseWriter, r *http.Request) {
+	column, _, err := s.column(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	writeAPIJSON(w, http.StatusOK, "column", columnJSON(column))
+}
+
+func (s *apiServer) handleCreateColumn(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	board, err := s.board(ctx, r.PathValue("id"))
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	var body struct {
+		Name     string `json:"name"`
+		Color    string `json:"color"`
+		WIPLimit *int   `json:"wip_limit"`
+	}
+	if err := decodeAPIBody(r, &body); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	if strings.TrimSpace(body.Name) == "" {
+		writeAPIError(w, NewValidation("name is required"))
+		return
+	}
+	column, err := s.rt.ContextService.CreateColumn(ctx, board.ID, body.Name, body.Color, body.WIPLimit)
+	if err != nil {
+		writeAPIError(w, NewValidation(err.Error()))
+		return
+	}
+	writeAPIJSON(w, http.StatusCreated, "column", columnJSON(column))
+}
+
+func (s *apiServer) handleUpdateColumn(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	column, board, err := s.column(ctx, r.PathValue("id"))
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	var body struct {
+		Name          *string `json:"name"`
+		Color         *string `json:"color"`
+		WIPLimit      *int    `json:"wip_limit"`
+		ClearWIPLimit bool    `json:"clear_wip_limit"`
+	}
+	if err := decodeAPIBody(r, &body); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	if body.Name == nil && body.Color == nil && body.WIPLimit == nil && !body.ClearWIPLimit {
+		writeAPIError(w, NewValidation("at least one of name, color, wip_limit, clear_wip_limit is required"))
+		return
+	}
+	if body.WIPLimit != nil && body.ClearWIPLimit {
+		writeAPIError(w, NewValidation("wip_limit and clear_wip_limit are mutually exclusive"))
+		return
+	}
+	if err := s.rt.ContextService.UpdateColumn(ctx, column.ID, body.Name, body.Color, body.WIPLimit, body.ClearWIPLimit); err != nil {
+		writeAPIError(w, NewValidation(err.Error()))
+		return
+	}
+	columns, err := s.rt.ContextService.ListColumns(ctx, board.ID)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	for _, c := range columns {
+		if c.ID == column.ID {
+			column = c
+			break
+		}
+	}
+	writeAPIJSON(w, http.StatusOK, "column", columnJSON(column))
+}
+
+func (s *apiServer) handleDeleteColumn(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	column, board, err := s.column(ctx, r.PathValue("id"))
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	count, err := s.rt.ColumnDeleteService.ColumnTaskCount(ctx, board.WorkspaceID, column.ID)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	moveTasksTo := r.URL.Query().Get("move_tasks_to")
+	if count > 0 && moveTasksTo == "" {
+		writeAPIError(w, NewValidation(fmt.Sprintf("column has %d tasks: set move_tasks_to to reassign before deleting", count)))
+		return
+	}
+	if moveTasksTo != "" {
+		if moveTasksTo == column.ID {
+			writeAPIError(w, NewValidation("cannot move tasks to the same column being deleted"))
+			return
+		}
+		dest, destBoard, err := s.column(ctx, moveTasksTo)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		if destBoard.ID != board.ID {
+			writeAPIError(w, NewValidation("move_tasks_to must be a column of the same board"))
+			return
+		}
+		if err := s.rt.ColumnDeleteService.ReassignTasks(ctx, board.WorkspaceID, column.ID, dest.ID, strings.ToLower(dest.Name)); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+	}
+	if err := s.rt.ColumnDeleteService.DeleteColumn(ctx, column.ID); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	writeAPIJSON(w, http.StatusOK, "column", map[string]interface{}{
+		"id":     column.ID,
+		"status": "deleted",
+	})
+}
+
+// ── Tasks ──
+
+func (s *apiServer) handleListTasks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	board, err := s.board(ctx, r.PathValue("id"))
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	q := r.URL.Query()
+	filters := application.ListTaskFilters{
+		WorkspaceID: board.WorkspaceID,
+		BoardID:     board.ID,
+		TitleQuery:  q.Get("query"),
+		ColumnID:    q.Get("column_id"),
+		Filter:      q.Get("filter"),
+	}
+	if raw := q.Get("due_soon"); raw != "" {
+		days, err := strconv.Atoi(raw)
+		if err != nil || days < 0 {
+			writeAPIError(w, NewValidation("due_soon must be a non-negative number of days"))
+			return
+		}
+		filters.DueSoonDays = days
+	}
+	tasks, err := s.rt.TaskFlow.ListTasks(ctx, filters)
+	if err != nil {
+		writeAPIError(w, NewFilterError(err, "filter"))
+		return
+	}
+	items := make([]map[string]string, len(tasks))
+	for i, t := range tasks {
+		items[i] = taskListItemJSON(t)
+	}
+	writeAPIList(w, "tasks", items, len(items))
+}
+
+func (s *apiServer) handleGetTask(w http.ResponseWriter, r *http.Request) {
+	task, err := s.task(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	writeAPIJSON(w, http.StatusOK, "task", taskJSON(task))
+}
+
+// apiPriority accepts a priority as a number (0-5) or a label ("high").
+type apiPriority struct {
+	value int
+}
+
+func (p *apiPriority) UnmarshalJSON(data []byte) error {
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	var text string
+	switch v := raw.(type) {
+	case float64:
+		text = strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		text = v
+	default:
+		return fmt.Errorf("priority must be a number or a label")
+	}
+	value, err := ParsePriority(text)
+	if err != nil {
+		return err
+	}
+	p.value = value
+	return nil
+}
+
+func parseAPIDueDate(raw string) (*time.Time, error) {
+	due, err := ParseDueDate(raw)
+	if err != nil {
+		return nil, err
+	}
+	return &due, nil
+}
+
+func (s *apiServer) handleCreateTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	board, err := s.board(ctx, r.PathValue("id"))
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	var body struct {
+		Title       string       `json:"title"`
+		Description string       `json:"description"`
+		ColumnID    string       `json:"column_id"`
+		Priority    *apiPriority `json:"priority"`
+		DueDate     string       `json:"due_date"`
+		Labels      []string     `json:"labels"`
+		Force       bool         `json:"force"`
+	}
+	if err := decodeAPIBody(r, &body); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	if strings.TrimSpace(body.Title) == "" {
+		writeAPIError(w, NewValidation("title is required"))
+		return
+	}
+	ws, err := s.workspace(ctx, board.WorkspaceID)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	columns, err := s.rt.ContextService.ListColumns(ctx, board.ID)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	var column *domain.Column
+	for i, c := range columns {
+		if body.ColumnID == "" || c.ID == body.ColumnID {
+			column = &columns[i]
+			break
+		}
+	}
+	if column == nil {
+		if body.ColumnID == "" {
+			writeAPIError(w, NewValidation("board has no columns"))
+		} else {
+			writeAPIError(w, NewNotFound("column", body.ColumnID))
+		}
+		return
+	}
+
+	priority := 3 // default medium
+	if body.Priority != nil {
+		priority = body.Priority.value
+	}
+	var dueAt *time.Time
+	if body.DueDate != "" {
+		if dueAt, err = parseAPIDueDate(body.DueDate); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+	}
+	status := strings.ToLower(column.Name)
+	task, err := s.rt.TaskService.CreateTask(ctx, application.CreateTaskInput{
+		ProviderID:    ws.ProviderID,
+		WorkspaceID:   ws.ID,
+		BoardID:       &board.ID,
+		ColumnID:      &column.ID,
+		Title:         body.Title,
+		DescriptionMD: body.Description,
+		Status:        &status,
+		Priority:      priority,
+		DueAt:         dueAt,
+		Labels:        NormalizeLabels(body.Labels),
+		Force:         body.Force,
+	})
+	if err != nil {
+		writeAPIWriteError(w, err)
+		return
+	}
+	writeAPIJSON(w, http.StatusCreated, "task", taskJSON(task))
+}
+
+func (s *apiServer) handleUpdateTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	task, err := s.task(ctx, r.PathValue("id"))
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	var body struct {
+		Title            *string      `json:"title"`
+		Description      *string      `json:"description"`
+		Priority         *apiPriority `json:"priority"`
+		DueDate          *string      `json:"due_date"`
+		Labels           *[]string    `json:"labels"`
+		ClearDescription bool         `json:"clear_description"`
+		ClearDueDate     bool         `json:"clear_due_date"`
+		ClearLabels      bool         `json:"clear_labels"`
+		IfVersion        *int         `json:"if_version"`
+	}
+	if err := decodeAPIBody(r, &body); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	var input application.UpdateTaskInput
+	input.Title = body.Title
+	if body.Title != nil && strings.TrimSpace(*body.Title) == "" {
+		writeAPIError(w, NewValidation("title cannot be empty"))
+		return
+	}
+	input.DescriptionMD = body.Description
+	if body.ClearDescription {
+		empty := ""
+		input.DescriptionMD = &empty
+	}
+	if body.Priority != nil {
+		input.Priority = &body.Priority.value
+	}
+	if body.DueDate != nil {
+		if input.DueAt, err = parseAPIDueDate(*body.DueDate); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+	}
+	input.ClearDueAt = body.ClearDueDate
+	if body.Labels != nil {
+		labels := NormalizeLabels(*body.Labels)
+		input.Labels = &labels
+	}
+	if body.ClearLabels {
+		labels := []string{}
+		input.Labels = &labels
+	}
+	if input.Title == nil && input.DescriptionMD == nil && input.Priority == nil &&
+		input.DueAt == nil && !input.ClearDueAt && input.Labels == nil {
+		writeAPIError(w, NewValidation("at least one of title, description, priority, due_date, labels, clear_description, clear_due_date, clear_labels is required"))
+		return
+	}
+	input.IfVersion = body.IfVersion
+
+	if err := s.rt.TaskService.UpdateTask(ctx, task.ID, input); err != nil {
+		writeAPIWriteError(w, err)
+		return
+	}
+	writeAPIJSON(w, http.StatusOK, "task", map[string]interface{}{
+		"id":      task.ID,
+		"updated": true,
+	})
+}
+
+func (s *apiServer) handleMoveTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	task, err := s.task(ctx, r.PathValue("id"))
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	var body struct {
+		ColumnID  string `json:"column_id"`
+		Force     bool   `json:"force"`
+		IfVersion *int   `json:"if_version"`
+	}
+	if err := decodeAPIBody(r, &body); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	if body.ColumnID == "" {
+		writeAPIError(w, NewValidation("column_id is required"))
+		return
+	}
+	column, board, err := s.column(ctx, body.ColumnID)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	if task.BoardID == nil || *task.BoardID != board.ID {
+		writeAPIError(w, NewValidation("column_id must be a column of the task's board"))
+		return
+	}
+
+	status := strings.ToLower(column.Name)
+	opts := application.MoveOptions{Force: body.Force, IfVersion: body.IfVersion}
+	if err := s.rt.TaskFlow.MoveTaskWith(ctx, task.ID, &column.ID, &status, 0, opts); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	writeAPIJSON(w, http.StatusOK, "task", map[string]interface{}{
+		"id":        task.ID,
+		"column_id": column.ID,
+		"status":    status,
+	})
+}
+
+func (s *apiServer) handleDeleteTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	task, err := s.task(ctx, r.PathValue("id"))
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	if err := s.rt.TaskService.DeleteTask(ctx, task.ID); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	writeAPIJSON(w, http.StatusOK, "task", map[string]interface{}{
+		"id":      task.ID,
+		"deleted": true,
+	})
+}
+
+// ── Comments ──
+
+func (s *apiServer) handleListComments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	task, err := s.task(ctx, r.PathValue("id"))
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	comments, err := s.rt.CommentService.ListComments(ctx, task.ID)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	items := make([]map[string]string, len(comments))
+	for i, c := range comments {
+		items[i] = commentListItemJSON(c)
+	}
+	writeAPIList(w, "comments", items, len(items))
+}
+
+func (s *apiServer) handleGetComment(w http.ResponseWriter, r *http.Request) {
+	comment, err := s.comment(r.Context(), r.PathValue("id"), r.PathValue("comment_id"))
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	writeAPIJSON(w, http.StatusOK, "comment", commentJSON(comment))
+}
+
+func (s *apiServer) handleCreateComment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	task, err := s.task(ctx, r.PathValue("id"))
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	var body struct {
+		Body   string  `json:"body"`
+		Author *string `json:"author"`
+	}
+	if err := decodeAPIBody(r, &body); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	comment, err := s.rt.CommentService.AddComment(ctx, application.AddCommentInput{
+		TaskID:     task.ID,
+		ProviderID: task.ProviderID,
+		BodyMD:     body.Body,
+		Author:     body.Author,
+	})
+	if err != nil {
+		writeAPIWriteError(w, err)
+		return
+	}
+	writeAPIJSON(w, http.StatusCreated, "comment", commentJSON(comment))
+}
+
+func (s *apiServer) handleUpdateComment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	comment, err := s.comment(ctx, r.PathValue("id"), r.PathValue("comment_id"))
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	var body struct {
+		Body      string `json:"body"`
+		IfVersion *int   `json:"if_version"`
+	}
+	if err := decodeAPIBody(r, &body); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	if err := s.rt.CommentService.UpdateComment(ctx, comment.ID, body.Body, body.IfVersion); err != nil {
+		writeAPIWriteError(w, err)
+		return
+	}
+	writeAPIJSON(w, http.StatusOK, "comment", map[string]interface{}{
+		"id":   comment.ID,
+		"body": body.Body,
+	})
+}
+
+func (s *apiServer) handleDeleteComment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	comment, err := s.comment(ctx, r.PathValue("id"), r.PathValue("comment_id"))
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	if err := s.rt.CommentService.DeleteComment(ctx, comment.ID); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	writeAPIJSON(w, http.StatusOK, "comment", map[string]interface{}{
+		"id":      comment.ID,
+		"deleted": true,
+		"cascade": false,
+	})
+}
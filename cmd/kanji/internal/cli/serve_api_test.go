@@ -0,0 +1,196 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tiagokriok/kanji/internal/application"
+)
+
+const testAPIToken = "test-token"
+
+func newAPITestServer(t *testing.T) (*httptest.Server, *Runtime) {
+	t.Helper()
+	dbPath := setupSyncTestDB(t)
+	rt, err := NewRuntime(context.Background(), RuntimeConfig{DBPath: dbPath})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = rt.Close() })
+
+	srv := httptest.NewServer(newAPIHandler(rt, testAPIToken))
+	t.Cleanup(srv.Close)
+	return srv, rt
+}
+
+func apiRequest(t *testing.T, srv *httptest.Server, method, path, body string) (int, map[string]interface{}) {
+	t.Helper()
+	req, err := http.NewRequest(method, srv.URL+path, strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+testAPIToken)
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return doAPIRequest(t, req)
+}
+
+func doAPIRequest(t *testing.T, req *http.Request) (int, map[string]interface{}) {
+	t.Helper()
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+	var payload map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&payload))
+	return resp.StatusCode, payload
+}
+
+func TestServeAPI_TaskLifecycle(t *testing.T) {
+	srv, rt := newAPITestServer(t)
+	ctx := context.Background()
+	setup, err := rt.BootstrapService.EnsureDefaultSetup(ctx)
+	require.NoError(t, err)
+	boardID := setup.Board.ID
+	doneID := setup.Columns[len(setup.Columns)-1].ID
+
+	status, payload := apiRequest(t, srv, http.MethodGet, "/v1/boards/"+boardID+"/tasks", "")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, float64(1), payload["count"])
+
+	status, payload = apiRequest(t, srv, http.MethodPost, "/v1/boards/"+boardID+"/tasks",
+		`{"title":"From API","priority":"high","labels":["Api"]}`)
+	require.Equal(t, http.StatusCreated, status, payload)
+	task := payload["task"].(map[string]interface{})
+	taskID := task["id"].(string)
+	assert.Equal(t, "From API", task["title"])
+	assert.Equal(t, float64(2), task["priority"])
+
+	status, _ = apiRequest(t, srv, http.MethodPatch, "/v1/tasks/"+taskID, `{"title":"Renamed"}`)
+	require.Equal(t, http.StatusOK, status)
+
+	status, payload = apiRequest(t, srv, http.MethodPost, "/v1/tasks/"+taskID+"/move",
+		`{"column_id":"`+doneID+`"}`)
+	require.Equal(t, http.StatusOK, status, payload)
+	assert.Equal(t, doneID, payload["task"].(map[string]interface{})["column_id"])
+
+	status, payload = apiRequest(t, srv, http.MethodPost, "/v1/tasks/"+taskID+"/comments", `{"body":"looks good"}`)
+	require.Equal(t, http.StatusCreated, status, payload)
+	commentID := payload["comment"].(map[string]interface{})["id"].(string)
+
+	status, payload = apiRequest(t, srv, http.MethodGet, "/v1/tasks/"+taskID+"/comments/"+commentID, "")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "looks good", payload["comment"].(map[string]interface{})["body"])
+
+	status, payload = apiRequest(t, srv, http.MethodGet, "/v1/tasks/"+taskID, "")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "Renamed", payload["task"].(map[string]interface{})["title"])
+
+	status, _ = apiRequest(t, srv, http.MethodDelete, "/v1/tasks/"+taskID+"/comments/"+commentID, "")
+	require.Equal(t, http.StatusOK, status)
+	status, _ = apiRequest(t, srv, http.MethodDelete, "/v1/tasks/"+taskID, "")
+	require.Equal(t, http.StatusOK, status)
+
+	status, payload = apiRequest(t, srv, http.MethodGet, "/v1/tasks/"+taskID, "")
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Equal(t, "not_found", payload["error"].(map[string]interface{})["code"])
+}
+
+func TestServeAPI_Errors(t *testing.T) {
+	srv, rt := newAPITestServer(t)
+	setup, err := rt.BootstrapService.EnsureDefaultSetup(context.Background())
+	require.NoError(t, err)
+	firstCol := setup.Columns[0].ID
+
+	errorCode := func(payload map[string]interface{}) string {
+		return payload["error"].(map[string]interface{})["code"].(string)
+	}
+
+	status, payload := apiRequest(t, srv, http.MethodGet, "/v1/boards/missing", "")
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Equal(t, "not_found", errorCode(payload))
+
+	status, payload = apiRequest(t, srv, http.MethodPost, "/v1/boards/"+setup.Board.ID+"/tasks", `{"title":"x","bogus":1}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "validation", errorCode(payload))
+
+	status, payload = apiRequest(t, srv, http.MethodDelete, "/v1/workspaces/"+setup.Workspace.ID, "")
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, payload["error"].(map[string]interface{})["message"], "cascade=true")
+
+	status, _ = apiRequest(t, srv, http.MethodPatch, "/v1/columns/"+firstCol, `{"wip_limit":1}`)
+	require.Equal(t, http.StatusOK, status)
+	status, payload = apiRequest(t, srv, http.MethodPost, "/v1/boards/"+setup.Board.ID+"/tasks", `{"title":"Over"}`)
+	assert.Equal(t, http.StatusConflict, status)
+	assert.Equal(t, "wip_limit_exceeded", errorCode(payload))
+
+	status, payload = apiRequest(t, srv, http.MethodPost, "/v1/boards/"+setup.Board.ID+"/tasks", `{"title":"Over","force":true}`)
+	assert.Equal(t, http.StatusCreated, status, payload)
+	task := payload["task"].(map[string]interface{})
+	assert.Equal(t, float64(1), task["version"])
+
+	taskPath := "/v1/tasks/" + task["id"].(string)
+	status, _ = apiRequest(t, srv, http.MethodPatch, taskPath, `{"title":"Mine","if_version":1}`)
+	require.Equal(t, http.StatusOK, status)
+	status, payload = apiRequest(t, srv, http.MethodPatch, taskPath, `{"title":"Theirs","if_version":1}`)
+	assert.Equal(t, http.StatusConflict, status)
+	assert.Equal(t, "version_conflict", errorCode(payload))
+}
+
+func TestServeAPI_Guard(t *testing.T) {
+	srv, rt := newAPITestServer(t)
+	setup, err := rt.BootstrapService.EnsureDefaultSetup(context.Background())
+	require.NoError(t, err)
+	tasksURL := srv.URL + "/v1/boards/" + setup.Board.ID + "/tasks"
+
+	newRequest := func(body string, header map[string]string) *http.Request {
+		req, err := http.NewRequest(http.MethodPost, tasksURL, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+testAPIToken)
+		req.Header.Set("Content-Type", "application/json")
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		return req
+	}
+	errorCode := func(payload map[string]interface{}) string {
+		return payload["error"].(map[string]interface{})["code"].(string)
+	}
+
+	cases := []struct {
+		name   string
+		header map[string]string
+		host   string
+		status int
+		code   string
+	}{
+		{"no token", map[string]string{"Authorization": ""}, "", http.StatusUnauthorized, "unauthorized"},
+		{"wrong token", map[string]string{"Authorization": "Bearer nope"}, "", http.StatusUnauthorized, "unauthorized"},
+		{"simple request", map[string]string{"Content-Type": "text/plain"}, "", http.StatusUnsupportedMediaType, "unsupported_media_type"},
+		{"foreign origin", map[string]string{"Origin": "https://evil.example"}, "", http.StatusForbidden, "forbidden"},
+		{"rebound host", nil, "evil.example:7777", http.StatusForbidden, "forbidden"},
+	}
+	for _, tc := range cases {
+		req := newRequest(`{"title":"Drive-by"}`, tc.header)
+		if tc.host != "" {
+			req.Host = tc.host
+		}
+		status, payload := doAPIRequest(t, req)
+		assert.Equal(t, tc.status, status, tc.name)
+		assert.Equal(t, tc.code, errorCode(payload), tc.name)
+	}
+
+	tasks, err := rt.TaskFlow.ListTasks(context.Background(), application.ListTaskFilters{WorkspaceID: setup.Workspace.ID, BoardID: setup.Board.ID})
+	require.NoError(t, err)
+	for _, task := range tasks {
+		assert.NotEqual(t, "Drive-by", task.Title)
+	}
+
+	status, _ := doAPIRequest(t, newRequest(`{"title":"Local"}`, map[string]string{"Origin": "http://localhost:3000"}))
+	assert.Equal(t, http.StatusCreated, status)
+}
@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const defaultServeAddr = "127.0.0.1:7777"
+
+func newServeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve a JSON HTTP API over the local database",
+		Long: `Serve a JSON HTTP API for workspaces, boards, columns, tasks and comments.
+
+The server binds to localhost and only answers requests addressed to a
+loopback host. Every request needs the bearer token printed on startup, or
+the one in $KANJI_API_TOKEN when it is set, and request bodies must be
+application/json. Payloads match the --json output of the equivalent CLI
+commands.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runServe(cmd)
+		},
+	}
+	cmd.Flags().String("addr", defaultServeAddr, "address to listen on")
+	return cmd
+}
+
+func runServe(cmd *cobra.Command) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	if err := GuardBootstrap(rt); err != nil {
+		return err
+	}
+
+	token := os.Getenv("KANJI_API_TOKEN")
+	if token == "" {
+		if token, err = newAPIToken(); err != nil {
+			return err
+		}
+	}
+
+	addr, _ := cmd.Flags().GetString("addr")
+	handler := newAPIHandler(rt, token)
+	if cfg.Verbose {
+		handler = logRequests(cmd.ErrOrStderr(), handler)
+	}
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.ListenAndServe()
+	}()
+	go deliverWebhooks(ctx, rt)
+	fmt.Fprintf(cmd.OutOrStdout(), "Serving kanji API on http://%s\n", addr)
+	fmt.Fprintf(cmd.OutOrStdout(), "Token: %s\n", token)
+
+	select {
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return fmt.Errorf("serve: %w", err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("shutdown: %w", err)
+	}
+	return nil
+}
+
+// newAPIToken returns a random bearer token for one server process.
+func newAPIToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate API token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// webhookInterval is how often the server sends queued webhook deliveries.
+const webhookInterval = 5 * time.Second
+
+// deliverWebhooks sends deliveries queued by API requests until ctx is done.
+func deliverWebhooks(ctx context.Context, rt *Runtime) {
+	ticker := time.NewTicker(webhookInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, _ = rt.WebhookService.Deliver(ctx)
+		}
+	}
+}
+
+// statusRecorder captures the response status for request logging.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func logRequests(w io.Writer, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: rw, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		fmt.Fprintf(w, "%s %s %d %s\n", r.Method, r.URL.Path, rec.status, time.Since(start).Round(time.Millisecond))
+	})
+}
@@ -0,0 +1,688 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tiagokriok/kanji/internal/application"
+	"github.com/tiagokriok/kanji/internal/domain"
+	"github.com/tiagokriok/kanji/internal/state"
+)
+
+func newSyncCommand() *cobra.Command {
+	s := &cobra.Command{
+		Use:   "sync",
+		Short: "Provider synchronization",
+		Long: `Every change to workspaces, boards, columns, tasks, and comments is
+recorded in a local outbox (the sync queue). "kanji sync run" pushes
+queued changes to their providers; failed pushes are retried with
+exponential backoff. Providers that support pulling are merged first:
+fields changed on only one side are combined, and fields changed on both
+sides are held as conflicts until resolved with "kanji sync conflicts".`,
+	}
+	s.AddCommand(newSyncRunCommand())
+	s.AddCommand(newSyncStatusCommand())
+	s.AddCommand(newSyncQueueCommand())
+	s.AddCommand(newSyncLinkCommand())
+	s.AddCommand(newSyncConflictsCommand())
+	return s
+}
+
+func newSyncQueueCommand() *cobra.Command {
+	q := &cobra.Command{
+		Use:   "queue",
+		Short: "Inspect and manage the sync queue",
+	}
+	q.AddCommand(newSyncQueueListCommand())
+	q.AddCommand(newSyncQueueRetryCommand())
+	q.AddCommand(newSyncQueuePurgeCommand())
+	return q
+}
+
+func newSyncConflictsCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "conflicts",
+		Short: "Inspect and resolve sync conflicts",
+	}
+	c.AddCommand(newSyncConflictsListCommand())
+	c.AddCommand(newSyncConflictsResolveCommand())
+	return c
+}
+
+func newSyncRunCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "run",
+		Short: "Pull remote changes and push queued changes",
+		Example: `  kanji sync run
+  kanji sync run --provider-id <id> --limit 50`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns, err := ResolveNamespace()
+			if err != nil {
+				return err
+			}
+			return runSyncRun(cmd, ns)
+		},
+	}
+	cmd.Flags().String("provider-id", "", "only push changes for this provider")
+	cmd.Flags().Int("limit", 0, "maximum number of push attempts (0 = no limit)")
+	return cmd
+}
+
+func newSyncLinkCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "link",
+		Short: "Link a board to its remote counterpart",
+		Long: `Record the remote ID a board syncs with, such as "owner/repo" for a GitHub
+provider. Queued changes of the board's provider are retried on the next run.`,
+		Example: `  kanji sync link --board-id <id> --remote-id acme/app
+  kanji sync link --workspace "Team" --board "Main" --remote-id acme/app`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns, err := ResolveNamespace()
+			if err != nil {
+				return err
+			}
+			return runSyncLink(cmd, ns)
+		},
+	}
+	cmd.Flags().String("workspace-id", "", "workspace ID")
+	cmd.Flags().String("workspace", "", "workspace name")
+	cmd.Flags().String("board-id", "", "board ID")
+	cmd.Flags().String("board", "", "board name")
+	cmd.Flags().String("remote-id", "", "remote ID of the board (e.g. owner/repo)")
+	return cmd
+}
+
+func newSyncStatusCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show sync queue status per provider",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns, err := ResolveNamespace()
+			if err != nil {
+				return err
+			}
+			return runSyncStatus(cmd, ns)
+		},
+	}
+}
+
+func newSyncQueueListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List queued changes",
+		Example: `  kanji sync queue list
+  kanji sync queue list --state failed`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns, err := ResolveNamespace()
+			if err != nil {
+				return err
+			}
+			return runSyncQueueList(cmd, ns)
+		},
+	}
+	cmd.Flags().String("provider-id", "", "only list changes for this provider")
+	cmd.Flags().String("state", "", "filter by state: pending, retrying, failed")
+	return cmd
+}
+
+func newSyncQueueRetryCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "retry",
+		Short: "Reset attempts so queued changes are pushed on the next run",
+		Example: `  kanji sync queue retry --id <id>
+  kanji sync queue retry --all`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns, err := ResolveNamespace()
+			if err != nil {
+				return err
+			}
+			return runSyncQueueRetry(cmd, ns)
+		},
+	}
+	cmd.Flags().String("id", "", "sync item ID")
+	cmd.Flags().Bool("all", false, "retry every item that ran out of attempts")
+	return cmd
+}
+
+func newSyncQueuePurgeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "purge",
+		Short: "Drop queued changes",
+		Long: `Drop queued changes without pushing them. By default only items that ran
+out of attempts are removed; --all empties the queue. Requires --yes.`,
+		Example: `  kanji sync queue purge --yes
+  kanji sync queue purge --all --yes`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns, err := ResolveNamespace()
+			if err != nil {
+				return err
+			}
+			return runSyncQueuePurge(cmd, ns)
+		},
+	}
+	cmd.Flags().Bool("all", false, "drop every queued change, not only failed ones")
+	cmd.Flags().Bool("yes", false, "confirm purge")
+	return cmd
+}
+
+func newSyncConflictsListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List fields changed both locally and remotely",
+		Example: `  kanji sync conflicts list
+  kanji sync conflicts list --provider-id <id>`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns, err := ResolveNamespace()
+			if err != nil {
+				return err
+			}
+			return runSyncConflictsList(cmd, ns)
+		},
+	}
+	cmd.Flags().String("provider-id", "", "only list conflicts for this provider")
+	return cmd
+}
+
+func newSyncConflictsResolveCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "resolve",
+		Short: "Resolve a conflict by taking the local or remote values",
+		Long: `Resolve a conflict by taking the local or remote value of every conflicting
+field. Changes made on only one side are kept either way. The resolved task
+is pushed on the next run.`,
+		Example: `  kanji sync conflicts resolve --id <task-id> --take local
+  kanji sync conflicts resolve --id <conflict-id> --take remote`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns, err := ResolveNamespace()
+			if err != nil {
+				return err
+			}
+			return runSyncConflictsResolve(cmd, ns)
+		},
+	}
+	cmd.Flags().String("id", "", "conflict ID or task ID")
+	cmd.Flags().String("take", "", "side to keep: local or remote")
+	return cmd
+}
+
+func runSyncRun(cmd *cobra.Command, ns Namespace) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	if err := GuardBootstrap(rt); err != nil {
+		return err
+	}
+
+	providerID, _ := cmd.Flags().GetString("provider-id")
+	limit, _ := cmd.Flags().GetInt("limit")
+	if limit < 0 {
+		return NewValidation("limit must be zero or positive")
+	}
+
+	result, err := rt.SyncEngine.Run(context.Background(), application.SyncRunOptions{
+		ProviderID: strings.TrimSpace(providerID),
+		Limit:      limit,
+	})
+	if err != nil {
+		return err
+	}
+
+	pullErrors := result.PullErrors
+	if pullErrors == nil {
+		pullErrors = []string{}
+	}
+	if cfg.JSON {
+		return RenderWrappedJSON(cmd.OutOrStdout(), "sync", map[string]interface{}{
+			"pushed":      result.Pushed,
+			"failed":      result.Failed,
+			"skipped":     result.Skipped,
+			"ignored":     result.Ignored,
+			"pulled":      result.Pulled,
+			"conflicts":   result.Conflicts,
+			"pull_errors": pullErrors,
+		})
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Sync run complete\nPushed:  %d\nFailed:  %d\nSkipped: %d\nIgnored: %d\nPulled:  %d\nConflicts: %d\n",
+		result.Pushed, result.Failed, result.Skipped, result.Ignored, result.Pulled, result.Conflicts)
+	for _, msg := range pullErrors {
+		fmt.Fprintf(cmd.OutOrStdout(), "Pull error: %s\n", msg)
+	}
+	return nil
+}
+
+func runSyncLink(cmd *cobra.Command, ns Namespace) error {
+	store, err := defaultStateStore()
+	if err != nil {
+		return err
+	}
+	return runSyncLinkWithStore(cmd, ns, store)
+}
+
+func runSyncLinkWithStore(cmd *cobra.Command, ns Namespace, store *state.Store) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	remoteID, _ := cmd.Flags().GetString("remote-id")
+	if strings.TrimSpace(remoteID) == "" {
+		return NewValidation("remote-id is required")
+	}
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	if err := GuardBootstrap(rt); err != nil {
+		return err
+	}
+
+	var boardID string
+	if cmd.Flags().Changed("board-id") {
+		boardID, _ = cmd.Flags().GetString("board-id")
+		boardID = strings.TrimSpace(boardID)
+	} else {
+		workspaceID, _, err := ResolveWorkspaceScope(cmd, rt, store, ns)
+		if err != nil {
+			return err
+		}
+		boardID, _, err = ResolveBoardScope(cmd, rt, store, ns, workspaceID)
+		if err != nil {
+			return err
+		}
+	}
+
+	board, err := rt.SyncEngine.LinkBoard(context.Background(), boardID, remoteID)
+	if err != nil {
+		if errors.Is(err, application.ErrBoardNotFound) {
+			return NewNotFound("board", boardID)
+		}
+		return err
+	}
+
+	if cfg.JSON {
+		return RenderWrappedJSON(cmd.OutOrStdout(), "board", map[string]interface{}{
+			"id":        board.ID,
+			"name":      board.Name,
+			"remote_id": *board.RemoteID,
+		})
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Board linked\n")
+	return RenderKV(cmd.OutOrStdout(), map[string]string{
+		"ID":        board.ID,
+		"Name":      board.Name,
+		"Remote ID": *board.RemoteID,
+	})
+}
+
+func runSyncStatus(cmd *cobra.Command, ns Namespace) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	if err := GuardBootstrap(rt); err != nil {
+		return err
+	}
+
+	statuses, err := rt.SyncEngine.Status(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if cfg.JSON {
+		items := make([]map[string]interface{}, len(statuses))
+		for i, st := range statuses {
+			item := map[string]interface{}{
+				"provider_id":   st.Provider.ID,
+				"provider_name": st.Provider.Name,
+				"provider_type": st.Provider.Type,
+				"pending":       st.Pending,
+				"retrying":      st.Retrying,
+				"failed":        st.Failed,
+			}
+			if st.Oldest != nil {
+				item["oldest"] = st.Oldest.Format(time.RFC3339)
+			}
+			items[i] = item
+		}
+		return RenderWrappedListJSON(cmd.OutOrStdout(), "providers", items, len(items))
+	}
+
+	headers := []string{"Provider ID", "Name", "Type", "Pending", "Retrying", "Failed", "Oldest"}
+	rows := make([][]string, len(statuses))
+	for i, st := range statuses {
+		oldest := ""
+		if st.Oldest != nil {
+			oldest = st.Oldest.Format(time.RFC3339)
+		}
+		rows[i] = []string{
+			st.Provider.ID,
+			st.Provider.Name,
+			st.Provider.Type,
+			strconv.Itoa(st.Pending),
+			strconv.Itoa(st.Retrying),
+			strconv.Itoa(st.Failed),
+			oldest,
+		}
+	}
+	return RenderTable(cmd.OutOrStdout(), headers, rows)
+}
+
+func runSyncQueueList(cmd *cobra.Command, ns Namespace) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	if err := GuardBootstrap(rt); err != nil {
+		return err
+	}
+
+	providerID, _ := cmd.Flags().GetString("provider-id")
+	state, _ := cmd.Flags().GetString("state")
+
+	items, err := rt.SyncEngine.ListQueue(context.Background(), strings.TrimSpace(providerID), state)
+	if err != nil {
+		return NewValidation(err.Error())
+	}
+
+	if cfg.JSON {
+		payload := make([]map[string]interface{}, len(items))
+		for i, item := range items {
+			payload[i] = syncItemJSON(item)
+		}
+		return RenderWrappedListJSON(cmd.OutOrStdout(), "items", payload, len(items))
+	}
+
+	headers := []string{"ID", "Provider ID", "Entity", "Entity ID", "Action", "State", "Attempts", "Next Attempt", "Last Error"}
+	rows := make([][]string, len(items))
+	for i, item := range items {
+		next := ""
+		if item.NextAttemptAt != nil {
+			next = item.NextAttemptAt.Format(time.RFC3339)
+		}
+		lastError := ""
+		if item.LastError != nil {
+			lastError = *item.LastError
+			if len(lastError) > 50 {
+				lastError = lastError[:47] + "..."
+			}
+			lastError = strings.ReplaceAll(lastError, "\n", " ")
+		}
+		rows[i] = []string{
+			item.ID,
+			item.ProviderID,
+			item.Entity,
+			item.EntityID,
+			item.Action,
+			application.SyncItemState(item),
+			strconv.Itoa(item.Attempts),
+			next,
+			lastError,
+		}
+	}
+	return RenderTable(cmd.OutOrStdout(), headers, rows)
+}
+
+func syncItemJSON(item domain.SyncItem) map[string]interface{} {
+	payload := map[string]interface{}{
+		"id":          item.ID,
+		"provider_id": item.ProviderID,
+		"entity":      item.Entity,
+		"entity_id":   item.EntityID,
+		"action":      item.Action,
+		"state":       application.SyncItemState(item),
+		"attempts":    item.Attempts,
+		"created_at":  item.CreatedAt.Format(time.RFC3339),
+	}
+	if item.LastError != nil {
+		payload["last_error"] = *item.LastError
+	}
+	if item.NextAttemptAt != nil {
+		payload["next_attempt_at"] = item.NextAttemptAt.Format(time.RFC3339)
+	}
+	return payload
+}
+
+func runSyncQueueRetry(cmd *cobra.Command, ns Namespace) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	itemID, _ := cmd.Flags().GetString("id")
+	itemID = strings.TrimSpace(itemID)
+	all, _ := cmd.Flags().GetBool("all")
+	if itemID == "" && !all {
+		return NewValidation("either --id or --all is required")
+	}
+	if itemID != "" && all {
+		return NewValidation("--id and --all are mutually exclusive")
+	}
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	if err := GuardBootstrap(rt); err != nil {
+		return err
+	}
+
+	count, err := rt.SyncEngine.Retry(context.Background(), itemID)
+	if err != nil {
+		return err
+	}
+	if itemID != "" && count == 0 {
+		return NewNotFound("sync item", itemID)
+	}
+
+	if cfg.JSON {
+		return RenderWrappedJSON(cmd.OutOrStdout(), "sync_queue", map[string]interface{}{
+			"retried": count,
+		})
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Sync items reset: %d\n", count)
+	return nil
+}
+
+func runSyncQueuePurge(cmd *cobra.Command, ns Namespace) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	if err := RequireConfirmation(cmd, "yes"); err != nil {
+		return err
+	}
+	all, _ := cmd.Flags().GetBool("all")
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	if err := GuardBootstrap(rt); err != nil {
+		return err
+	}
+
+	count, err := rt.SyncEngine.Purge(context.Background(), all)
+	if err != nil {
+		return err
+	}
+
+	if cfg.JSON {
+		return RenderWrappedJSON(cmd.OutOrStdout(), "sync_queue", map[string]interface{}{
+			"purged": count,
+			"all":    all,
+		})
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Sync items purged: %d\n", count)
+	return nil
+}
+
+func runSyncConflictsList(cmd *cobra.Command, ns Namespace) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	if err := GuardBootstrap(rt); err != nil {
+		return err
+	}
+
+	providerID, _ := cmd.Flags().GetString("provider-id")
+	conflicts, err := rt.SyncEngine.ListConflicts(context.Background(), strings.TrimSpace(providerID))
+	if err != nil {
+		return err
+	}
+
+	if cfg.JSON {
+		payload := make([]map[string]interface{}, len(conflicts))
+		for i, tc := range conflicts {
+			payload[i] = syncConflictJSON(tc)
+		}
+		return RenderWrappedListJSON(cmd.OutOrStdout(), "conflicts", payload, len(conflicts))
+	}
+
+	headers := []string{"ID", "Task ID", "Title", "Field", "Local", "Remote"}
+	rows := make([][]string, 0, len(conflicts))
+	for _, tc := range conflicts {
+		for _, field := range tc.Conflict.Fields {
+			local := "(deleted)"
+			if tc.Local != nil {
+				local = conflictCell(application.TaskFieldValue(field, *tc.Local))
+			}
+			rows = append(rows, []string{
+				tc.Conflict.ID,
+				tc.Conflict.EntityID,
+				conflictCell(tc.Remote.Title),
+				field,
+				local,
+				conflictCell(application.TaskFieldValue(field, tc.Remote)),
+			})
+		}
+	}
+	return RenderTable(cmd.OutOrStdout(), headers, rows)
+}
+
+func conflictCell(value string) string {
+	value = strings.ReplaceAll(value, "\n", " ")
+	if len(value) > 40 {
+		value = value[:37] + "..."
+	}
+	return value
+}
+
+func syncConflictJSON(tc application.TaskConflict) map[string]interface{} {
+	local := map[string]interface{}{}
+	remote := map[string]interface{}{}
+	for _, field := range tc.Conflict.Fields {
+		if tc.Local != nil {
+			local[field] = application.TaskFieldValue(field, *tc.Local)
+		}
+		remote[field] = application.TaskFieldValue(field, tc.Remote)
+	}
+	payload := map[string]interface{}{
+		"id":          tc.Conflict.ID,
+		"provider_id": tc.Conflict.ProviderID,
+		"task_id":     tc.Conflict.EntityID,
+		"title":       tc.Remote.Title,
+		"fields":      tc.Conflict.Fields,
+		"local":       local,
+		"remote":      remote,
+		"created_at":  tc.Conflict.CreatedAt.Format(time.RFC3339),
+	}
+	if tc.Local == nil {
+		payload["local"] = nil
+	}
+	return payload
+}
+
+func runSyncConflictsResolve(cmd *cobra.Command, ns Namespace) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	id, _ := cmd.Flags().GetString("id")
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return NewValidation("--id is required")
+	}
+	take, _ := cmd.Flags().GetString("take")
+	take = strings.ToLower(strings.TrimSpace(take))
+	if take != application.ConflictTakeLocal && take != application.ConflictTakeRemote {
+		return NewValidation("--take must be local or remote")
+	}
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	if err := GuardBootstrap(rt); err != nil {
+		return err
+	}
+
+	task, err := rt.SyncEngine.ResolveConflict(context.Background(), id, take)
+	if err != nil {
+		if errors.Is(err, application.ErrConflictNotFound) {
+			return NewNotFound("sync conflict", id)
+		}
+		return err
+	}
+
+	if cfg.JSON {
+		return RenderWrappedJSON(cmd.OutOrStdout(), "conflict", map[string]interface{}{
+			"task_id": task.ID,
+			"title":   task.Title,
+			"take":    take,
+		})
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), "Conflict resolved")
+	return RenderKV(cmd.OutOrStdout(), map[string]string{
+		"Task ID": task.ID,
+		"Title":   task.Title,
+		"Kept":    take,
+	})
+}
@@ -0,0 +1,257 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tiagokriok/kanji/internal/application"
+	"github.com/tiagokriok/kanji/internal/state"
+)
+
+func setupSyncTestDB(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "test.db")
+	// Keep the credential key file out of the real config dir.
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(dir, "config"))
+
+	ctx := context.Background()
+	rt, err := NewRuntime(ctx, RuntimeConfig{DBPath: dbPath})
+	require.NoError(t, err)
+	defer rt.Close()
+	setup, err := rt.BootstrapService.EnsureDefaultSetup(ctx)
+	require.NoError(t, err)
+
+	_, err = rt.TaskService.CreateTask(ctx, application.CreateTaskInput{
+		ProviderID:  setup.Provider.ID,
+		WorkspaceID: setup.Workspace.ID,
+		BoardID:     &setup.Board.ID,
+		ColumnID:    &setup.Columns[0].ID,
+		Title:       "Queued",
+	})
+	require.NoError(t, err)
+	return dbPath
+}
+
+// setupQueuedSyncTestDB adds a markdown workspace with a task to the sync
+// test database. Unlike the local provider, its writes are queued for sync.
+func setupQueuedSyncTestDB(t *testing.T) string {
+	t.Helper()
+	dbPath := setupSyncTestDB(t)
+	ctx := context.Background()
+	rt, err := NewRuntime(ctx, RuntimeConfig{DBPath: dbPath})
+	require.NoError(t, err)
+	defer rt.Close()
+
+	provider, err := rt.ProviderService.AddProvider(ctx, application.AddProviderInput{Type: "markdown", Name: "Notes"})
+	require.NoError(t, err)
+	workspace, board, err := rt.ContextService.CreateWorkspace(ctx, provider.ID, "Notes")
+	require.NoError(t, err)
+	_, err = rt.TaskService.CreateTask(ctx, application.CreateTaskInput{
+		ProviderID:  provider.ID,
+		WorkspaceID: workspace.ID,
+		BoardID:     &board.ID,
+		Title:       "Queued",
+	})
+	require.NoError(t, err)
+	return dbPath
+}
+
+func newSyncTestCommand(dbPath string, args ...string) *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("db-path", "", "")
+	cmd.Flags().Bool("json", false, "")
+	cmd.Flags().String("provider-id", "", "")
+	cmd.Flags().Int("limit", 0, "")
+	cmd.Flags().String("state", "", "")
+	cmd.Flags().String("id", "", "")
+	cmd.Flags().Bool("all", false, "")
+	cmd.Flags().Bool("yes", false, "")
+	cmd.Flags().String("board-id", "", "")
+	cmd.Flags().String("remote-id", "", "")
+	cmd.Flags().String("take", "", "")
+	_ = cmd.ParseFlags(append([]string{"--db-path", dbPath}, args...))
+	return cmd
+}
+
+func TestSyncQueueList_ShowsEnqueuedWrites(t *testing.T) {
+	dbPath := setupQueuedSyncTestDB(t)
+
+	cmd := newSyncTestCommand(dbPath, "--json")
+	buf := new(strings.Builder)
+	cmd.SetOut(buf)
+	require.NoError(t, runSyncQueueList(cmd, Namespace{Key: "test-ns", Source: "cwd"}))
+
+	var out struct {
+		Items []map[string]interface{} `json:"items"`
+		Count int                      `json:"count"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(buf.String()), &out))
+	require.Greater(t, out.Count, 0)
+
+	found := false
+	for _, item := range out.Items {
+		if item["entity"] == "task" && item["action"] == "create" {
+			found = true
+			assert.Equal(t, "pending", item["state"])
+		}
+	}
+	assert.True(t, found, "expected task create in queue")
+}
+
+func TestSyncRun_DrainsLocalProvider(t *testing.T) {
+	dbPath := setupSyncTestDB(t)
+
+	cmd := newSyncTestCommand(dbPath)
+	buf := new(strings.Builder)
+	cmd.SetOut(buf)
+	require.NoError(t, runSyncRun(cmd, Namespace{Key: "test-ns", Source: "cwd"}))
+	assert.Contains(t, buf.String(), "Sync run complete")
+	assert.Contains(t, buf.String(), "Failed:  0")
+
+	cmd = newSyncTestCommand(dbPath, "--json")
+	buf = new(strings.Builder)
+	cmd.SetOut(buf)
+	require.NoError(t, runSyncStatus(cmd, Namespace{Key: "test-ns", Source: "cwd"}))
+
+	var out struct {
+		Providers []map[string]interface{} `json:"providers"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(buf.String()), &out))
+	require.Len(t, out.Providers, 1)
+	assert.EqualValues(t, 0, out.Providers[0]["pending"])
+}
+
+func TestSyncQueueList_InvalidState(t *testing.T) {
+	dbPath := setupSyncTestDB(t)
+
+	cmd := newSyncTestCommand(dbPath, "--state", "bogus")
+	cmd.SetOut(new(strings.Builder))
+	err := runSyncQueueList(cmd, Namespace{Key: "test-ns", Source: "cwd"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid sync state")
+}
+
+func TestSyncQueueRetry_RequiresTarget(t *testing.T) {
+	dbPath := setupSyncTestDB(t)
+
+	cmd := newSyncTestCommand(dbPath)
+	err := runSyncQueueRetry(cmd, Namespace{Key: "test-ns", Source: "cwd"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--id or --all")
+}
+
+func TestSyncQueueRetry_UnknownID(t *testing.T) {
+	dbPath := setupSyncTestDB(t)
+
+	cmd := newSyncTestCommand(dbPath, "--id", "missing")
+	err := runSyncQueueRetry(cmd, Namespace{Key: "test-ns", Source: "cwd"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "sync item not found")
+}
+
+func TestSyncQueuePurge_RequiresConfirmation(t *testing.T) {
+	dbPath := setupSyncTestDB(t)
+
+	cmd := newSyncTestCommand(dbPath, "--all")
+	err := runSyncQueuePurge(cmd, Namespace{Key: "test-ns", Source: "cwd"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--yes")
+}
+
+func TestSyncQueuePurge_All(t *testing.T) {
+	dbPath := setupQueuedSyncTestDB(t)
+
+	cmd := newSyncTestCommand(dbPath, "--all", "--yes")
+	buf := new(strings.Builder)
+	cmd.SetOut(buf)
+	require.NoError(t, runSyncQueuePurge(cmd, Namespace{Key: "test-ns", Source: "cwd"}))
+	assert.Contains(t, buf.String(), "Sync items purged:")
+	assert.NotContains(t, buf.String(), "purged: 0")
+}
+
+func TestSyncLink_BoardOfGitHubWorkspace(t *testing.T) {
+	dbPath := setupSyncTestDB(t)
+	ns := Namespace{Key: "test-ns", Source: "cwd"}
+	store := state.NewStore(filepath.Join(t.TempDir(), "state.json"))
+
+	cmd := newProviderCLITestCommand(dbPath, "--type", "github", "--name", "GH", "--auth-json", `{"token":"abc"}`)
+	cmd.SetOut(new(strings.Builder))
+	require.NoError(t, runProviderAdd(cmd, ns))
+
+	cmd = newProviderCLITestCommand(dbPath, "--name", "Team", "--provider", "GH", "--json")
+	cmd.Flags().Bool("set-context", false, "")
+	buf := new(strings.Builder)
+	cmd.SetOut(buf)
+	require.NoError(t, runWorkspaceCreateWithStore(cmd, ns, store))
+
+	var created struct {
+		Workspace struct {
+			ProviderID string `json:"provider_id"`
+			BoardID    string `json:"board_id"`
+		} `json:"workspace"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(buf.String()), &created))
+	require.NotEmpty(t, created.Workspace.BoardID)
+
+	cmd = newSyncTestCommand(dbPath, "--provider-id", created.Workspace.ProviderID, "--json")
+	buf = new(strings.Builder)
+	cmd.SetOut(buf)
+	require.NoError(t, runSyncQueueList(cmd, ns))
+	assert.Contains(t, buf.String(), `"entity": "board"`)
+
+	cmd = newSyncTestCommand(dbPath, "--board-id", created.Workspace.BoardID, "--remote-id", "acme/app")
+	buf = new(strings.Builder)
+	cmd.SetOut(buf)
+	require.NoError(t, runSyncLinkWithStore(cmd, ns, store))
+	assert.Contains(t, buf.String(), "Board linked")
+	assert.Contains(t, buf.String(), "acme/app")
+}
+
+func TestSyncLink_UnknownBoard(t *testing.T) {
+	dbPath := setupSyncTestDB(t)
+	store := state.NewStore(filepath.Join(t.TempDir(), "state.json"))
+
+	cmd := newSyncTestCommand(dbPath, "--board-id", "missing", "--remote-id", "acme/app")
+	err := runSyncLinkWithStore(cmd, Namespace{Key: "test-ns", Source: "cwd"}, store)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "board not found")
+}
+
+func TestSyncConflictsList_Empty(t *testing.T) {
+	dbPath := setupSyncTestDB(t)
+
+	cmd := newSyncTestCommand(dbPath, "--json")
+	buf := new(strings.Builder)
+	cmd.SetOut(buf)
+	require.NoError(t, runSyncConflictsList(cmd, Namespace{Key: "test-ns", Source: "cwd"}))
+
+	var out struct {
+		Conflicts []map[string]interface{} `json:"conflicts"`
+		Count     int                      `json:"count"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(buf.String()), &out))
+	assert.Equal(t, 0, out.Count)
+	assert.Empty(t, out.Conflicts)
+}
+
+func TestSyncConflictsResolve_ValidatesInput(t *testing.T) {
+	dbPath := setupSyncTestDB(t)
+
+	cmd := newSyncTestCommand(dbPath, "--id", "t1", "--take", "both")
+	err := runSyncConflictsResolve(cmd, Namespace{Key: "test-ns", Source: "cwd"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "local or remote")
+
+	cmd = newSyncTestCommand(dbPath, "--id", "missing", "--take", "remote")
+	err = runSyncConflictsResolve(cmd, Namespace{Key: "test-ns", Source: "cwd"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "sync conflict not found")
+}
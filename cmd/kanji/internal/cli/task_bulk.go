@@ -0,0 +1,274 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tiagokriok/kanji/internal/application"
+)
+
+// bulkFilterFlags are the task list filters a bulk command selects by.
+var bulkFilterFlags = []string{"workspace-id", "workspace", "board-id", "board", "query", "column", "due-soon", "filter"}
+
+// bulkApply applies the change of one bulk command to the selected tasks.
+type bulkApply func(ctx context.Context, cmd *cobra.Command, rt *Runtime, taskIDs []string, opts application.BulkOptions) ([]application.BulkTaskResult, error)
+
+func newTaskBulkCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bulk",
+		Short: "Change many tasks at once",
+		Long: `Apply one change to many tasks in a single transaction: either every task
+is changed or none is.
+
+Tasks are selected with the filters of kanji task list, or by IDs read from
+stdin with --stdin. Use --dry-run to preview the impact.`,
+		Example: `  # Raise the priority of every bug on a board
+  kanji task bulk update --workspace Work --board Sprint --filter "label:bug" --priority high
+
+  # Move tasks listed by ID
+  printf "id1\nid2\n" | kanji task bulk move --stdin --to-column Done
+
+  # Preview a bulk delete
+  kanji task bulk delete --workspace Work --filter "status:done" --dry-run`,
+	}
+	cmd.AddCommand(newTaskBulkUpdateCommand())
+	cmd.AddCommand(newTaskBulkMoveCommand())
+	cmd.AddCommand(newTaskBulkDeleteCommand())
+	cmd.AddCommand(newTaskBulkLabelCommand())
+	return cmd
+}
+
+func newTaskBulkUpdateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "Update the priority, due date or labels of many tasks",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runTaskBulk(cmd, "update", "updated", bulkUpdate)
+		},
+	}
+	addBulkSelectionFlags(cmd)
+	cmd.Flags().String("priority", "", "priority: critical, urgent, high, medium, low, none, or 0-5")
+	cmd.Flags().String("due-date", "", "due date: YYYY-MM-DD or RFC3339")
+	cmd.Flags().Bool("clear-due-date", false, "clear the due date")
+	cmd.Flags().StringSlice("labels", nil, "comma-separated labels, replacing the current ones")
+	cmd.Flags().Bool("clear-labels", false, "clear all labels")
+	return cmd
+}
+
+func newTaskBulkMoveCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "move",
+		Short: "Move many tasks of one board to a column",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runTaskBulk(cmd, "move", "moved", bulkMove)
+		},
+	}
+	addBulkSelectionFlags(cmd)
+	cmd.Flags().String("to-column-id", "", "destination column ID")
+	cmd.Flags().String("to-column", "", "destination column name")
+	cmd.Flags().Bool("force", false, "move even if the column goes over its WIP limit")
+	return cmd
+}
+
+func newTaskBulkDeleteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete many tasks",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runTaskBulk(cmd, "delete", "deleted", bulkDelete)
+		},
+	}
+	addBulkSelectionFlags(cmd)
+	cmd.Flags().Bool("yes", false, "confirm deletion")
+	return cmd
+}
+
+func newTaskBulkLabelCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "label",
+		Short: "Add and remove labels on many tasks",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runTaskBulk(cmd, "label", "relabeled", bulkLabel)
+		},
+	}
+	addBulkSelectionFlags(cmd)
+	cmd.Flags().StringSlice("add", nil, "comma-separated labels to add")
+	cmd.Flags().StringSlice("remove", nil, "comma-separated labels to remove")
+	return cmd
+}
+
+func addBulkSelectionFlags(cmd *cobra.Command) {
+	cmd.Flags().String("workspace-id", "", "workspace ID")
+	cmd.Flags().String("workspace", "", "workspace name")
+	cmd.Flags().String("board-id", "", "board ID (optional narrowing)")
+	cmd.Flags().String("board", "", "board name (optional narrowing)")
+	cmd.Flags().String("query", "", "title query filter")
+	cmd.Flags().String("column", "", "column ID filter")
+	cmd.Flags().Int("due-soon", 0, "due within N days")
+	cmd.Flags().String("filter", "", `filter expression, e.g. "priority<=high and label:bug and due<7d"`)
+	cmd.Flags().Bool("stdin", false, "read task IDs from stdin instead of filtering")
+	cmd.Flags().Bool("dry-run", false, "show impact without changing anything")
+}
+
+func runTaskBulk(cmd *cobra.Command, action, done string, apply bulkApply) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	if err := GuardBootstrap(rt); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	taskIDs, err := bulkTaskIDs(ctx, cmd, rt)
+	if err != nil {
+		return err
+	}
+
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	results, err := apply(ctx, cmd, rt, taskIDs, application.BulkOptions{DryRun: dryRun})
+	if err != nil {
+		return NewHookRejected(NewWIPLimitExceeded(NewVersionConflict(err)))
+	}
+
+	if dryRun {
+		changed := 0
+		for _, result := range results {
+			if result.Changed {
+				changed++
+			}
+		}
+		impact := map[string]int{"tasks": len(results), "changed": changed}
+		if cfg.JSON {
+			return RenderDryRunImpactJSON(cmd.OutOrStdout(), "task", impact)
+		}
+		return RenderDryRunActionImpact(cmd.OutOrStdout(), "task", action, impact)
+	}
+	return renderBulkResults(cmd.OutOrStdout(), cfg.JSON, done, results)
+}
+
+// bulkTaskIDs returns the IDs of the tasks a bulk command applies to.
+func bulkTaskIDs(ctx context.Context, cmd *cobra.Command, rt *Runtime) ([]string, error) {
+	fromStdin, _ := cmd.Flags().GetBool("stdin")
+	if !fromStdin {
+		tasks, err := listTasks(ctx, cmd, rt)
+		if err != nil {
+			return nil, err
+		}
+		if len(tasks) == 0 {
+			return nil, NewValidation("no tasks match the filters")
+		}
+		ids := make([]string, len(tasks))
+		for i, task := range tasks {
+			ids[i] = task.ID
+		}
+		return ids, nil
+	}
+
+	for _, name := range bulkFilterFlags {
+		if cmd.Flags().Changed(name) {
+			return nil, NewValidation(fmt.Sprintf("--stdin cannot be combined with --%s", name))
+		}
+	}
+	input, err := io.ReadAll(cmd.InOrStdin())
+	if err != nil {
+		return nil, err
+	}
+	ids := strings.Fields(string(input))
+	if len(ids) == 0 {
+		return nil, NewValidation("no task IDs on stdin")
+	}
+	for _, id := range ids {
+		if _, err := rt.TaskService.GetTask(ctx, id); err != nil {
+			return nil, NewNotFound("task", id)
+		}
+	}
+	return ids, nil
+}
+
+func bulkUpdate(ctx context.Context, cmd *cobra.Command, rt *Runtime, taskIDs []string, opts application.BulkOptions) ([]application.BulkTaskResult, error) {
+	if err := RequireAtLeastOneFlag(cmd, "priority", "due-date", "clear-due-date", "labels", "clear-labels"); err != nil {
+		return nil, err
+	}
+	input, err := AssembleUpdateTaskInput(cmd)
+	if err != nil {
+		return nil, err
+	}
+	return rt.TaskService.BulkUpdateTasks(ctx, taskIDs, input, opts)
+}
+
+func bulkMove(ctx context.Context, cmd *cobra.Command, rt *Runtime, taskIDs []string, opts application.BulkOptions) ([]application.BulkTaskResult, error) {
+	first, err := rt.TaskService.GetTask(ctx, taskIDs[0])
+	if err != nil {
+		return nil, NewNotFound("task", taskIDs[0])
+	}
+	if first.BoardID == nil {
+		return nil, NewValidation(fmt.Sprintf("task %s is not on a board", first.ID))
+	}
+	columnID, status, err := ResolveMoveDestination(cmd, rt, *first.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	opts.Force, _ = cmd.Flags().GetBool("force")
+	return rt.TaskFlow.BulkMoveTasks(ctx, taskIDs, columnID, status, opts)
+}
+
+func bulkDelete(ctx context.Context, cmd *cobra.Command, rt *Runtime, taskIDs []string, opts application.BulkOptions) ([]application.BulkTaskResult, error) {
+	if !opts.DryRun {
+		if err := RequireConfirmation(cmd, "yes"); err != nil {
+			return nil, err
+		}
+	}
+	return rt.TaskService.BulkDeleteTasks(ctx, taskIDs, opts)
+}
+
+func bulkLabel(ctx context.Context, cmd *cobra.Command, rt *Runtime, taskIDs []string, opts application.BulkOptions) ([]application.BulkTaskResult, error) {
+	if err := RequireAtLeastOneFlag(cmd, "add", "remove"); err != nil {
+		return nil, err
+	}
+	add, _ := cmd.Flags().GetStringSlice("add")
+	remove, _ := cmd.Flags().GetStringSlice("remove")
+	return rt.TaskService.BulkLabelTasks(ctx, taskIDs, NormalizeLabels(add), NormalizeLabels(remove), opts)
+}
+
+// renderBulkResults writes what a bulk change did to each task.
+func renderBulkResults(w io.Writer, asJSON bool, done string, results []application.BulkTaskResult) error {
+	if asJSON {
+		items := make([]map[string]interface{}, len(results))
+		for i, result := range results {
+			items[i] = map[string]interface{}{
+				"id":      result.Task.ID,
+				"title":   result.Task.Title,
+				"changed": result.Changed,
+			}
+		}
+		return RenderWrappedListJSON(w, "tasks", items, len(results))
+	}
+
+	changed := 0
+	rows := make([][]string, len(results))
+	for i, result := range results {
+		outcome := "unchanged"
+		if result.Changed {
+			outcome = done
+			changed++
+		}
+		rows[i] = []string{result.Task.ID, result.Task.Title, outcome}
+	}
+	if err := RenderTable(w, []string{"ID", "Title", "Result"}, rows); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "%d of %d tasks %s\n", changed, len(results), done)
+	return nil
+}
@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tiagokriok/kanji/internal/application"
+)
+
+func TestTaskBulk(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	dbPath, setup, waitingID := setupFullDoingColumn(t)
+	todo, doing := setup.Columns[0].ID, setup.Columns[1].ID
+
+	ctx := context.Background()
+	rt, err := NewRuntime(ctx, RuntimeConfig{DBPath: dbPath})
+	require.NoError(t, err)
+	other, err := rt.TaskService.CreateTask(ctx, application.CreateTaskInput{
+		ProviderID:  setup.Provider.ID,
+		WorkspaceID: setup.Workspace.ID,
+		BoardID:     &setup.Board.ID,
+		ColumnID:    &todo,
+		Title:       "Also waiting",
+		Labels:      []string{"ui"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, rt.Close())
+
+	run := func(cmd *cobra.Command) error { return cmd.RunE(cmd, nil) }
+
+	blocked := newVersionedCommand(t, newTaskBulkMoveCommand(), dbPath, "--workspace-id", setup.Workspace.ID, "--column", todo, "--to-column-id", doing)
+	assert.True(t, errors.Is(run(blocked), &SelectorError{Code: "wip_limit_exceeded"}))
+
+	dry := newVersionedCommand(t, newTaskBulkMoveCommand(), dbPath, "--workspace-id", setup.Workspace.ID, "--column", todo, "--to-column-id", doing, "--force", "--dry-run")
+	require.NoError(t, run(dry))
+	out := dry.OutOrStdout().(*strings.Builder).String()
+	assert.Contains(t, out, "Dry-run: task move impact")
+	assert.Contains(t, out, "tasks")
+
+	update := newVersionedCommand(t, newTaskBulkUpdateCommand(), dbPath, "--stdin", "--priority", "high")
+	update.SetIn(strings.NewReader(waitingID + "\n" + other.ID + "\n"))
+	update.Flags().Bool("json", true, "")
+	require.NoError(t, run(update))
+	var updated struct {
+		Tasks []struct {
+			ID      string `json:"id"`
+			Changed bool   `json:"changed"`
+		} `json:"tasks"`
+		Count int `json:"count"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(update.OutOrStdout().(*strings.Builder).String()), &updated))
+	assert.Equal(t, 2, updated.Count)
+	assert.True(t, updated.Tasks[0].Changed && updated.Tasks[1].Changed)
+
+	mixed := newVersionedCommand(t, newTaskBulkUpdateCommand(), dbPath, "--stdin", "--workspace-id", setup.Workspace.ID, "--priority", "low")
+	assert.True(t, errors.Is(run(mixed), &SelectorError{Code: "validation"}))
+
+	label := newVersionedCommand(t, newTaskBulkLabelCommand(), dbPath, "--workspace-id", setup.Workspace.ID, "--column", todo, "--add", "bug", "--remove", "ui")
+	require.NoError(t, run(label))
+	assert.Contains(t, label.OutOrStdout().(*strings.Builder).String(), "2 of 2 tasks relabeled")
+
+	unconfirmed := newVersionedCommand(t, newTaskBulkDeleteCommand(), dbPath, "--stdin")
+	unconfirmed.SetIn(strings.NewReader(other.ID))
+	assert.Error(t, run(unconfirmed))
+
+	del := newVersionedCommand(t, newTaskBulkDeleteCommand(), dbPath, "--stdin", "--yes")
+	del.SetIn(strings.NewReader(other.ID))
+	require.NoError(t, run(del))
+
+	rt, err = NewRuntime(ctx, RuntimeConfig{DBPath: dbPath})
+	require.NoError(t, err)
+	defer rt.Close()
+	task, err := rt.TaskService.GetTask(ctx, waitingID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, task.Priority)
+	assert.Equal(t, []string{"bug"}, task.Labels)
+	_, err = rt.TaskService.GetTask(ctx, other.ID)
+	assert.Error(t, err)
+}
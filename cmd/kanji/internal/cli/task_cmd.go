@@ -2,6 +2,8 @@ package cli
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"strconv"
 
 	"github.com/spf13/cobra"
@@ -20,7 +22,10 @@ func newTaskCommand() *cobra.Command {
 	t.AddCommand(newTaskCreateCommand())
 	t.AddCommand(newTaskUpdateCommand())
 	t.AddCommand(newTaskMoveCommand())
+	t.AddCommand(newTaskCopyCommand())
 	t.AddCommand(newTaskDeleteCommand())
+	t.AddCommand(newTaskHistoryCommand())
+	t.AddCommand(newTaskBulkCommand())
 	return t
 }
 
@@ -43,6 +48,7 @@ func newTaskListCommand() *cobra.Command {
 	cmd.Flags().String("query", "", "title query filter")
 	cmd.Flags().String("column", "", "column ID filter")
 	cmd.Flags().Int("due-soon", 0, "due within N days")
+	cmd.Flags().String("filter", "", `filter expression, e.g. "priority<=high and label:bug and due<7d"`)
 	return cmd
 }
 
@@ -82,15 +88,77 @@ func runTaskGet(cmd *cobra.Command, ns Namespace) error {
 		return err
 	}
 
-	ctx := context.Background()
+	task, err := getTask(context.Background(), cmd, rt)
+	if err != nil {
+		return err
+	}
+
+	if cfg.JSON {
+		return RenderWrappedJSON(cmd.OutOrStdout(), "task", taskJSON(task))
+	}
+
+	pairs := map[string]string{
+		"ID":       task.ID,
+		"Title":    task.Title,
+		"Priority": strconv.Itoa(task.Priority),
+		"Version":  strconv.Itoa(task.Version),
+	}
+	if task.Status != nil {
+		pairs["Status"] = *task.Status
+	}
+	return RenderKV(cmd.OutOrStdout(), pairs)
+}
+
+func runTaskList(cmd *cobra.Command, ns Namespace) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	if err := GuardBootstrap(rt); err != nil {
+		return err
+	}
+
+	tasks, err := listTasks(context.Background(), cmd, rt)
+	if err != nil {
+		return err
+	}
+
+	if cfg.JSON {
+		items := make([]map[string]string, len(tasks))
+		for i, task := range tasks {
+			items[i] = taskListItemJSON(task)
+		}
+		return RenderWrappedListJSON(cmd.OutOrStdout(), "tasks", items, len(tasks))
+	}
+
+	headers := []string{"ID", "Title", "Status", "Priority"}
+	rows := make([][]string, len(tasks))
+	for i, task := range tasks {
+		status := ""
+		if task.Status != nil {
+			status = *task.Status
+		}
+		rows[i] = []string{task.ID, task.Title, status, strconv.Itoa(task.Priority)}
+	}
+	return RenderTable(cmd.OutOrStdout(), headers, rows)
+}
 
+// getTask resolves a task from the flags of `kanji task get`.
+func getTask(ctx context.Context, cmd *cobra.Command, rt *Runtime) (domain.Task, error) {
 	// Resolve task.
 	var task domain.Task
 	if cmd.Flags().Changed("task-id") {
 		id, _ := cmd.Flags().GetString("task-id")
 		t, err := rt.TaskService.GetTask(ctx, id)
 		if err != nil {
-			return NewNotFound("task", id)
+			return domain.Task{}, NewNotFound("task", id)
 		}
 		task = t
 	} else if cmd.Flags().Changed("task") {
@@ -103,7 +171,7 @@ func runTaskGet(cmd *cobra.Command, ns Namespace) error {
 			name, _ := cmd.Flags().GetString("workspace")
 			workspaces, err := rt.ContextService.ListWorkspaces(ctx)
 			if err != nil {
-				return err
+				return domain.Task{}, err
 			}
 			found := false
 			for _, ws := range workspaces {
@@ -114,16 +182,16 @@ func runTaskGet(cmd *cobra.Command, ns Namespace) error {
 				}
 			}
 			if !found {
-				return NewNotFound("workspace", name)
+				return domain.Task{}, NewNotFound("workspace", name)
 			}
 		} else {
-			return NewValidation("workspace scope required for task title resolution")
+			return domain.Task{}, NewValidation("workspace scope required for task title resolution")
 		}
 
 		filters := application.ListTaskFilters{WorkspaceID: workspaceID}
 		tasks, err := rt.TaskFlow.ListTasks(ctx, filters)
 		if err != nil {
-			return err
+			return domain.Task{}, err
 		}
 		found := false
 		for _, t := range tasks {
@@ -134,53 +202,17 @@ func runTaskGet(cmd *cobra.Command, ns Namespace) error {
 			}
 		}
 		if !found {
-			return NewNotFound("task", title)
+			return domain.Task{}, NewNotFound("task", title)
 		}
 	} else {
-		return NewValidation("task-id or task is required")
-	}
-
-	if cfg.JSON {
-		payload := map[string]interface{}{
-			"id":       task.ID,
-			"title":    task.Title,
-			"priority": task.Priority,
-		}
-		if task.Status != nil {
-			payload["status"] = *task.Status
-		}
-		return RenderWrappedJSON(cmd.OutOrStdout(), "task", payload)
-	}
-
-	pairs := map[string]string{
-		"ID":       task.ID,
-		"Title":    task.Title,
-		"Priority": strconv.Itoa(task.Priority),
-	}
-	if task.Status != nil {
-		pairs["Status"] = *task.Status
+		return domain.Task{}, NewValidation("task-id or task is required")
 	}
-	return RenderKV(cmd.OutOrStdout(), pairs)
+	return task, nil
 }
 
-func runTaskList(cmd *cobra.Command, ns Namespace) error {
-	cfg, err := ResolveConfig(cmd)
-	if err != nil {
-		return err
-	}
-
-	rt, err := NewRuntime(context.Background(), cfg)
-	if err != nil {
-		return err
-	}
-	defer rt.Close()
-
-	if err := GuardBootstrap(rt); err != nil {
-		return err
-	}
-
-	ctx := context.Background()
-
+// listTasks resolves the scope and filters of `kanji task list` and lists
+// the matching tasks.
+func listTasks(ctx context.Context, cmd *cobra.Command, rt *Runtime) ([]domain.Task, error) {
 	// Resolve workspace scope.
 	var workspaceID string
 	if cmd.Flags().Changed("workspace-id") {
@@ -189,7 +221,7 @@ func runTaskList(cmd *cobra.Command, ns Namespace) error {
 		name, _ := cmd.Flags().GetString("workspace")
 		workspaces, err := rt.ContextService.ListWorkspaces(ctx)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		found := false
 		for _, ws := range workspaces {
@@ -200,10 +232,10 @@ func runTaskList(cmd *cobra.Command, ns Namespace) error {
 			}
 		}
 		if !found {
-			return NewNotFound("workspace", name)
+			return nil, NewNotFound("workspace", name)
 		}
 	} else {
-		return NewValidation("workspace scope required: use --workspace-id, --workspace, or kanji context set")
+		return nil, NewValidation("workspace scope required: use --workspace-id, --workspace, or kanji context set")
 	}
 
 	// Resolve optional board narrowing.
@@ -214,7 +246,7 @@ func runTaskList(cmd *cobra.Command, ns Namespace) error {
 		name, _ := cmd.Flags().GetString("board")
 		boards, err := rt.ContextService.ListBoards(ctx, workspaceID)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		found := false
 		for _, b := range boards {
@@ -225,7 +257,7 @@ func runTaskList(cmd *cobra.Command, ns Namespace) error {
 			}
 		}
 		if !found {
-			return NewNotFound("board", name)
+			return nil, NewNotFound("board", name)
 		}
 	}
 
@@ -243,37 +275,23 @@ func runTaskList(cmd *cobra.Command, ns Namespace) error {
 	if cmd.Flags().Changed("due-soon") {
 		filters.DueSoonDays, _ = cmd.Flags().GetInt("due-soon")
 	}
+	if cmd.Flags().Changed("filter") {
+		filters.Filter, _ = cmd.Flags().GetString("filter")
+	}
 
 	tasks, err := rt.TaskFlow.ListTasks(ctx, filters)
 	if err != nil {
-		return err
-	}
-
-	if cfg.JSON {
-		items := make([]map[string]string, len(tasks))
-		for i, task := range tasks {
-			status := ""
-			if task.Status != nil {
-				status = *task.Status
-			}
-			items[i] = map[string]string{
-				"id":       task.ID,
-				"title":    task.Title,
-				"status":   status,
-				"priority": strconv.Itoa(task.Priority),
-			}
-		}
-		return RenderWrappedListJSON(cmd.OutOrStdout(), "tasks", items, len(tasks))
+		return nil, NewFilterError(err, "--filter")
 	}
+	return tasks, nil
+}
 
-	headers := []string{"ID", "Title", "Status", "Priority"}
-	rows := make([][]string, len(tasks))
-	for i, task := range tasks {
-		status := ""
-		if task.Status != nil {
-			status = *task.Status
-		}
-		rows[i] = []string{task.ID, task.Title, status, strconv.Itoa(task.Priority)}
+// NewFilterError maps a filter expression that does not parse to a
+// validation error naming the input it came from. Other errors pass through
+// unchanged.
+func NewFilterError(err error, input string) error {
+	if errors.Is(err, application.ErrInvalidTaskQuery) {
+		return NewValidation(fmt.Sprintf("invalid %s: %v", input, err))
 	}
-	return RenderTable(cmd.OutOrStdout(), headers, rows)
+	return err
 }
@@ -2,6 +2,8 @@ package cli
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -99,3 +101,29 @@ func TestTaskList_JSON(t *testing.T) {
 	output := buf.String()
 	assert.Contains(t, output, "tasks")
 }
+
+func TestTaskList_Filter(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	dbPath, setup, waitingID := setupFullDoingColumn(t)
+	ns := Namespace{Key: "test-ns", Source: "cwd"}
+
+	list := newVersionedCommand(t, newTaskListCommand(), dbPath, "--workspace-id", setup.Workspace.ID, "--filter", "not column:doing")
+	list.Flags().Bool("json", true, "")
+	require.NoError(t, runTaskList(list, ns))
+	var got struct {
+		Tasks []struct {
+			ID string `json:"id"`
+		} `json:"tasks"`
+		Count int `json:"count"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(list.OutOrStdout().(*strings.Builder).String()), &got))
+	require.Equal(t, 1, got.Count)
+	assert.Equal(t, waitingID, got.Tasks[0].ID)
+
+	invalid := newVersionedCommand(t, newTaskListCommand(), dbPath, "--workspace-id", setup.Workspace.ID, "--filter", "color:red")
+	err := runTaskList(invalid, ns)
+	var selErr *SelectorError
+	require.True(t, errors.As(err, &selErr), "got %v", err)
+	assert.Equal(t, "validation", selErr.Code)
+	assert.Contains(t, selErr.Message, `invalid --filter: unknown field "color" at position 1`)
+}
@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tiagokriok/kanji/internal/application"
+	"github.com/tiagokriok/kanji/internal/domain"
+	"github.com/tiagokriok/kanji/internal/state"
+)
+
+func newTaskCopyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "copy",
+		Short: "Copy a task, optionally to another board or workspace",
+		Long: `Create a new task with the title, description, priority, due date and labels
+of an existing one. Without destination flags the copy goes to the bottom of
+the task's own column.
+
+--to-board-id/--to-board copy the task to another board, and
+--to-workspace-id/--to-workspace to a board of another workspace. Without
+--to-column-id or --to-column the copy goes to the column of the destination
+board named like the task's current one.`,
+		Example: `  # Duplicate a task in place
+  kanji task copy --task "Release checklist" --title "Release checklist (v2)"
+
+  # Copy a task and its comments to another board
+  kanji task copy --task-id <id> --to-board Archive --with-comments`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns, err := ResolveNamespace()
+			if err != nil {
+				return err
+			}
+			return runTaskCopy(cmd, ns)
+		},
+	}
+	cmd.Flags().String("task-id", "", "task ID")
+	cmd.Flags().String("task", "", "task title")
+	cmd.Flags().String("title", "", "title of the copy (default: the task's title)")
+	cmd.Flags().Bool("with-comments", false, "copy the task's comments too")
+	cmd.Flags().String("to-column-id", "", "destination column ID")
+	cmd.Flags().String("to-column", "", "destination column name")
+	cmd.Flags().String("to-board-id", "", "destination board ID")
+	cmd.Flags().String("to-board", "", "destination board name")
+	cmd.Flags().String("to-workspace-id", "", "destination workspace ID")
+	cmd.Flags().String("to-workspace", "", "destination workspace name")
+	cmd.Flags().String("workspace-id", "", "workspace ID (required for title resolution)")
+	cmd.Flags().String("workspace", "", "workspace name (required for title resolution)")
+	cmd.Flags().Bool("force", false, "copy even if the destination column is at its WIP limit")
+	return cmd
+}
+
+func runTaskCopy(cmd *cobra.Command, ns Namespace) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	if err := GuardBootstrap(rt); err != nil {
+		return err
+	}
+
+	store, err := defaultStateStore()
+	if err != nil {
+		return err
+	}
+
+	task, err := copyTask(context.Background(), cmd, rt, store, ns)
+	if err != nil {
+		return err
+	}
+
+	if cfg.JSON {
+		payload := taskJSON(task)
+		if task.ColumnID != nil {
+			payload["column_id"] = *task.ColumnID
+		}
+		return RenderWriteResultJSON(cmd.OutOrStdout(), "task", payload)
+	}
+
+	fields := map[string]string{
+		"Title":    task.Title,
+		"Priority": strconv.Itoa(task.Priority),
+	}
+	if task.Status != nil {
+		fields["Status"] = *task.Status
+	}
+	return RenderWriteResult(cmd.OutOrStdout(), "Task", task.ID, fields)
+}
+
+// copyTask resolves a task and its destination from the flags of
+// `kanji task copy` and copies it.
+func copyTask(ctx context.Context, cmd *cobra.Command, rt *Runtime, store *state.Store, ns Namespace) (domain.Task, error) {
+	taskID, err := resolveTaskInScope(cmd, rt, store, ns)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	task, err := rt.TaskService.GetTask(ctx, taskID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	dest, _, err := resolveTaskDestination(ctx, cmd, rt, task)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	input := application.CopyTaskInput{Destination: dest}
+	input.Title, _ = cmd.Flags().GetString("title")
+	input.WithComments, _ = cmd.Flags().GetBool("with-comments")
+	input.Force, _ = cmd.Flags().GetBool("force")
+	copied, err := rt.TaskCopyService.CopyTask(ctx, task.ID, input)
+	if err != nil {
+		return domain.Task{}, NewHookRejected(NewWIPLimitExceeded(err))
+	}
+	return copied, nil
+}
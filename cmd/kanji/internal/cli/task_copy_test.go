@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tiagokriok/kanji/internal/application"
+)
+
+func TestTaskCopy(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	ctx := context.Background()
+
+	rt, err := NewRuntime(ctx, RuntimeConfig{DBPath: dbPath})
+	require.NoError(t, err)
+	setup, err := rt.BootstrapService.EnsureDefaultSetup(ctx)
+	require.NoError(t, err)
+	personal, home, err := rt.ContextService.CreateWorkspace(ctx, setup.Provider.ID, "Personal")
+	require.NoError(t, err)
+	task, err := rt.TaskService.CreateTask(ctx, application.CreateTaskInput{
+		ProviderID:    setup.Provider.ID,
+		WorkspaceID:   setup.Workspace.ID,
+		BoardID:       &setup.Board.ID,
+		ColumnID:      &setup.Columns[1].ID,
+		Title:         "Checklist",
+		DescriptionMD: "- [ ] ship",
+		Priority:      1,
+		Labels:        []string{"release"},
+	})
+	require.NoError(t, err)
+	_, err = rt.CommentService.AddComment(ctx, application.AddCommentInput{
+		TaskID:     task.ID,
+		ProviderID: setup.Provider.ID,
+		BodyMD:     "remember the changelog",
+	})
+	require.NoError(t, err)
+	require.NoError(t, rt.Close())
+	ns := Namespace{Key: "test-ns", Source: "cwd"}
+
+	// In place, without comments.
+	inPlace := newVersionedCommand(t, newTaskCopyCommand(), dbPath, "--task-id", task.ID, "--title", "Checklist v2")
+	require.NoError(t, runTaskCopy(inPlace, ns))
+
+	// To another workspace, with comments; "Doing" maps by name.
+	elsewhere := newVersionedCommand(t, newTaskCopyCommand(), dbPath, "--task-id", task.ID, "--to-workspace-id", personal.ID, "--to-board", home.Name, "--with-comments")
+	require.NoError(t, runTaskCopy(elsewhere, ns))
+
+	check, err := NewRuntime(ctx, RuntimeConfig{DBPath: dbPath})
+	require.NoError(t, err)
+	defer check.Close()
+
+	same, err := check.TaskFlow.ListTasks(ctx, application.ListTaskFilters{WorkspaceID: setup.Workspace.ID, ColumnID: setup.Columns[1].ID})
+	require.NoError(t, err)
+	require.Len(t, same, 2)
+	for _, copied := range same {
+		if copied.ID == task.ID {
+			continue
+		}
+		assert.Equal(t, "Checklist v2", copied.Title)
+		assert.Equal(t, "- [ ] ship", copied.DescriptionMD)
+		comments, err := check.CommentService.ListComments(ctx, copied.ID)
+		require.NoError(t, err)
+		assert.Empty(t, comments)
+	}
+
+	other, err := check.TaskFlow.ListTasks(ctx, application.ListTaskFilters{WorkspaceID: personal.ID})
+	require.NoError(t, err)
+	require.Len(t, other, 1)
+	copied := other[0]
+	assert.Equal(t, "Checklist", copied.Title)
+	assert.Equal(t, []string{"release"}, copied.Labels)
+	require.NotNil(t, copied.Status)
+	assert.Equal(t, "doing", *copied.Status)
+	comments, err := check.CommentService.ListComments(ctx, copied.ID)
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	assert.Equal(t, "remember the changelog", comments[0].BodyMD)
+
+	// The original is untouched.
+	original, err := check.TaskService.GetTask(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, setup.Workspace.ID, original.WorkspaceID)
+}
+
+func TestTaskCopy_MissingTask(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	ctx := context.Background()
+
+	rt, err := NewRuntime(ctx, RuntimeConfig{DBPath: dbPath})
+	require.NoError(t, err)
+	_, err = rt.BootstrapService.EnsureDefaultSetup(ctx)
+	require.NoError(t, err)
+	require.NoError(t, rt.Close())
+
+	cmd := newVersionedCommand(t, newTaskCopyCommand(), dbPath, "--task-id", "nope")
+	err = runTaskCopy(cmd, Namespace{Key: "test-ns", Source: "cwd"})
+	assert.True(t, errors.Is(err, &SelectorError{Code: "not_found"}), "got %v", err)
+}
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tiagokriok/kanji/internal/application"
+)
+
+// installHook writes an executable shell hook into the hooks directory of
+// the config dir the test points XDG_CONFIG_HOME at.
+func installHook(t *testing.T, name, script string) {
+	t.Helper()
+	dir := filepath.Join(os.Getenv("XDG_CONFIG_HOME"), "kanji", "hooks")
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"+script), 0o755))
+}
+
+func TestTaskMove_PreMoveHookEnforcesPolicy(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks are shell scripts")
+	}
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(t.TempDir(), "config"))
+	dbPath, setup, taskID := setupFullDoingColumn(t)
+	log := filepath.Join(t.TempDir(), "moved.log")
+
+	installHook(t, "pre-task-move", `payload=$(cat)
+case "$payload" in
+*'"column_name":"Done"'*)
+	case "$payload" in *'"reviewed"'*) exit 0 ;; esac
+	echo "tasks need the reviewed label before Done" >&2
+	exit 1 ;;
+esac
+`)
+	installHook(t, "task-moved", `echo "$KANJI_TASK_ID $KANJI_DB_PATH" >> "`+log+`"`)
+
+	done := setup.Columns[2].ID
+	err := runTaskMove(newWIPMoveCommand(dbPath, taskID, done), Namespace{Key: "test-ns", Source: "cwd"})
+	var selErr *SelectorError
+	require.True(t, errors.As(err, &selErr), "got %v", err)
+	assert.Equal(t, "hook_rejected", selErr.Code)
+	assert.Contains(t, err.Error(), "tasks need the reviewed label before Done")
+	_, statErr := os.Stat(log)
+	assert.True(t, os.IsNotExist(statErr), "task-moved must not run for a vetoed move")
+
+	ctx := context.Background()
+	rt, err := NewRuntime(ctx, RuntimeConfig{DBPath: dbPath})
+	require.NoError(t, err)
+	task, err := rt.TaskService.GetTask(ctx, taskID)
+	require.NoError(t, err)
+	assert.Equal(t, setup.Columns[0].ID, *task.ColumnID)
+	labels := []string{"reviewed"}
+	require.NoError(t, rt.TaskService.UpdateTask(ctx, taskID, application.UpdateTaskInput{Labels: &labels}))
+	require.NoError(t, rt.Close())
+
+	require.NoError(t, runTaskMove(newWIPMoveCommand(dbPath, taskID, done), Namespace{Key: "test-ns", Source: "cwd"}))
+	data, err := os.ReadFile(log)
+	require.NoError(t, err)
+	assert.Equal(t, taskID+" "+dbPath, strings.TrimSpace(string(data)))
+}
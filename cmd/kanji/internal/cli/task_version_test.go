@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newVersionedCommand(t *testing.T, cmd *cobra.Command, dbPath string, args ...string) *cobra.Command {
+	t.Helper()
+	cmd.Flags().String("db-path", "", "")
+	require.NoError(t, cmd.ParseFlags(append([]string{"--db-path", dbPath}, args...)))
+	cmd.SetOut(new(strings.Builder))
+	return cmd
+}
+
+func TestTaskUpdateAndMove_IfVersion(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	dbPath, setup, taskID := setupFullDoingColumn(t)
+	ns := Namespace{Key: "test-ns", Source: "cwd"}
+
+	update := newVersionedCommand(t, newTaskUpdateCommand(), dbPath, "--task-id", taskID, "--title", "Edited", "--if-version", "1")
+	require.NoError(t, runTaskUpdate(update, ns))
+
+	// The task is at version 2 now, so a writer that read version 1 is stale.
+	stale := newVersionedCommand(t, newTaskUpdateCommand(), dbPath, "--task-id", taskID, "--title", "Stale", "--if-version", "1")
+	err := runTaskUpdate(stale, ns)
+	var selErr *SelectorError
+	require.True(t, errors.As(err, &selErr), "got %v", err)
+	assert.Equal(t, "version_conflict", selErr.Code)
+
+	move := newVersionedCommand(t, newTaskMoveCommand(), dbPath, "--task-id", taskID, "--to-column-id", setup.Columns[2].ID, "--if-version", "1")
+	assert.True(t, errors.Is(runTaskMove(move, ns), &SelectorError{Code: "version_conflict"}))
+
+	move = newVersionedCommand(t, newTaskMoveCommand(), dbPath, "--task-id", taskID, "--to-column-id", setup.Columns[2].ID, "--if-version", "2")
+	require.NoError(t, runTaskMove(move, ns))
+
+	invalid := newVersionedCommand(t, newTaskUpdateCommand(), dbPath, "--task-id", taskID, "--title", "Zero", "--if-version", "0")
+	assert.True(t, errors.Is(runTaskUpdate(invalid, ns), &SelectorError{Code: "validation"}))
+}
@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tiagokriok/kanji/internal/application"
+)
+
+// setupFullDoingColumn bootstraps a database whose "Doing" column has a WIP
+// limit of 1 and already holds one task. It returns the db path, the setup and
+// the ID of a task waiting in "Todo".
+func setupFullDoingColumn(t *testing.T) (string, application.BootstrapResult, string) {
+	t.Helper()
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	ctx := context.Background()
+	rt, err := NewRuntime(ctx, RuntimeConfig{DBPath: dbPath})
+	require.NoError(t, err)
+	defer rt.Close()
+	setup, err := rt.BootstrapService.EnsureDefaultSetup(ctx)
+	require.NoError(t, err)
+
+	limit := 1
+	require.NoError(t, rt.ContextService.UpdateColumn(ctx, setup.Columns[1].ID, nil, nil, &limit, false))
+
+	_, err = rt.TaskService.CreateTask(ctx, application.CreateTaskInput{
+		ProviderID:  setup.Provider.ID,
+		WorkspaceID: setup.Workspace.ID,
+		BoardID:     &setup.Board.ID,
+		ColumnID:    &setup.Columns[1].ID,
+		Title:       "In progress",
+	})
+	require.NoError(t, err)
+
+	waiting, err := rt.TaskService.CreateTask(ctx, application.CreateTaskInput{
+		ProviderID:  setup.Provider.ID,
+		WorkspaceID: setup.Workspace.ID,
+		BoardID:     &setup.Board.ID,
+		ColumnID:    &setup.Columns[0].ID,
+		Title:       "Waiting",
+	})
+	require.NoError(t, err)
+	return dbPath, setup, waiting.ID
+}
+
+func newWIPMoveCommand(dbPath, taskID, columnID string, extra ...string) *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("db-path", "", "")
+	cmd.Flags().String("task-id", "", "")
+	cmd.Flags().String("task", "", "")
+	cmd.Flags().String("to-column-id", "", "")
+	cmd.Flags().String("to-column", "", "")
+	cmd.Flags().Bool("force", false, "")
+	args := append([]string{"--db-path", dbPath, "--task-id", taskID, "--to-column-id", columnID}, extra...)
+	_ = cmd.ParseFlags(args)
+	return cmd
+}
+
+func TestTaskMove_WIPLimitBlocked(t *testing.T) {
+	dbPath, setup, taskID := setupFullDoingColumn(t)
+
+	cmd := newWIPMoveCommand(dbPath, taskID, setup.Columns[1].ID)
+	err := runTaskMove(cmd, Namespace{Key: "test-ns", Source: "cwd"})
+	require.Error(t, err)
+
+	var selErr *SelectorError
+	require.True(t, errors.As(err, &selErr))
+	assert.Equal(t, "wip_limit_exceeded", selErr.Code)
+	assert.Contains(t, err.Error(), "1/1")
+	assert.Contains(t, err.Error(), "--force")
+}
+
+func TestTaskMove_WIPLimitJSON(t *testing.T) {
+	dbPath, setup, taskID := setupFullDoingColumn(t)
+
+	cmd := newWIPMoveCommand(dbPath, taskID, setup.Columns[1].ID)
+	cmd.Flags().Bool("json", false, "")
+	require.NoError(t, cmd.Flags().Set("json", "true"))
+	buf := new(strings.Builder)
+	cmd.SetOut(buf)
+	err := runTaskMove(cmd, Namespace{Key: "test-ns", Source: "cwd"})
+	assert.True(t, errors.Is(err, &SelectorError{Code: "wip_limit_exceeded"}))
+
+	var payload struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(buf.String()), &payload))
+	assert.Equal(t, "wip_limit_exceeded", payload.Error.Code)
+	assert.Contains(t, payload.Error.Message, "--force")
+}
+
+func TestTaskMove_WIPLimitForce(t *testing.T) {
+	dbPath, setup, taskID := setupFullDoingColumn(t)
+
+	cmd := newWIPMoveCommand(dbPath, taskID, setup.Columns[1].ID, "--force")
+	buf := new(strings.Builder)
+	cmd.SetOut(buf)
+	require.NoError(t, runTaskMove(cmd, Namespace{Key: "test-ns", Source: "cwd"}))
+	assert.Contains(t, buf.String(), "Task moved")
+}
+
+func TestTaskCreate_WIPLimitBlocked(t *testing.T) {
+	dbPath, setup, _ := setupFullDoingColumn(t)
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("db-path", "", "")
+	cmd.Flags().String("workspace-id", "", "")
+	cmd.Flags().String("board-id", "", "")
+	cmd.Flags().String("title", "", "")
+	cmd.Flags().String("column-id", "", "")
+	cmd.Flags().Bool("force", false, "")
+	cmd.Flags().Bool("json", false, "")
+	require.NoError(t, cmd.ParseFlags([]string{
+		"--db-path", dbPath,
+		"--workspace-id", setup.Workspace.ID,
+		"--board-id", setup.Board.ID,
+		"--column-id", setup.Columns[1].ID,
+		"--title", "One too many",
+	}))
+
+	err := runTaskCreate(cmd, Namespace{Key: "test-ns", Source: "cwd"})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, &SelectorError{Code: "wip_limit_exceeded"}))
+
+	require.NoError(t, cmd.Flags().Set("json", "true"))
+	buf := new(strings.Builder)
+	cmd.SetOut(buf)
+	err = runTaskCreate(cmd, Namespace{Key: "test-ns", Source: "cwd"})
+	assert.True(t, errors.Is(err, &SelectorError{Code: "wip_limit_exceeded"}))
+	assert.Contains(t, buf.String(), `"code": "wip_limit_exceeded"`)
+	require.NoError(t, cmd.Flags().Set("json", "false"))
+
+	require.NoError(t, cmd.Flags().Set("force", "true"))
+	cmd.SetOut(new(strings.Builder))
+	require.NoError(t, runTaskCreate(cmd, Namespace{Key: "test-ns", Source: "cwd"}))
+}
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -10,6 +11,8 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/tiagokriok/kanji/internal/application"
+	"github.com/tiagokriok/kanji/internal/domain"
+	"github.com/tiagokriok/kanji/internal/state"
 )
 
 // AssembleCreateTaskInput builds a CreateTaskInput from command flags.
@@ -226,6 +229,69 @@ func ResolveMoveDestination(cmd *cobra.Command, rt *Runtime, boardID string) (st
 	return "", "", NewValidation("to-column-id or to-column is required")
 }
 
+// NewWIPLimitExceeded converts a WIP limit violation into a typed CLI error
+// carrying the "wip_limit_exceeded" code. Other errors pass through unchanged.
+func NewWIPLimitExceeded(err error) error {
+	if !errors.Is(err, application.ErrWIPLimitExceeded) {
+		return err
+	}
+	return &SelectorError{
+		Code:    "wip_limit_exceeded",
+		Message: err.Error() + "; use --force to override",
+	}
+}
+
+// NewVersionConflict converts a write based on a stale version into a typed
+// CLI error carrying the "version_conflict" code. Other errors pass through
+// unchanged.
+func NewVersionConflict(err error) error {
+	if !errors.Is(err, domain.ErrVersionConflict) {
+		return err
+	}
+	return &SelectorError{
+		Code:    "version_conflict",
+		Message: err.Error() + "; fetch it again and retry",
+	}
+}
+
+// ifVersionFlag returns the --if-version value, or nil when the flag is unset.
+func ifVersionFlag(cmd *cobra.Command) (*int, error) {
+	if !cmd.Flags().Changed("if-version") {
+		return nil, nil
+	}
+	version, err := cmd.Flags().GetInt("if-version")
+	if err != nil {
+		return nil, err
+	}
+	if version < 1 {
+		return nil, NewValidation("--if-version must be at least 1")
+	}
+	return &version, nil
+}
+
+// NewHookRejected converts a change vetoed by a pre-* hook into a typed CLI
+// error carrying the "hook_rejected" code. Other errors pass through unchanged.
+func NewHookRejected(err error) error {
+	if !errors.Is(err, application.ErrHookRejected) {
+		return err
+	}
+	return &SelectorError{
+		Code:    "hook_rejected",
+		Message: err.Error(),
+	}
+}
+
+// renderWriteError writes a typed error of a task write to stdout as a JSON
+// error payload when --json is set, so scripts can branch on its code as API
+// and MCP clients do. The error is still returned for the exit status.
+func renderWriteError(cmd *cobra.Command, asJSON bool, err error) error {
+	var typed *SelectorError
+	if asJSON && errors.As(err, &typed) {
+		_ = RenderJSONError(cmd.OutOrStdout(), typed.Code, typed.Message)
+	}
+	return err
+}
+
 // ── Commands ──
 
 func newTaskCreateCommand() *cobra.Command {
@@ -252,6 +318,7 @@ func newTaskCreateCommand() *cobra.Command {
 	cmd.Flags().String("board", "", "board name")
 	cmd.Flags().String("column-id", "", "column ID")
 	cmd.Flags().String("column", "", "column name")
+	cmd.Flags().Bool("force", false, "create even if the column is at its WIP limit")
 	return cmd
 }
 
@@ -276,18 +343,38 @@ func runTaskCreate(cmd *cobra.Command, ns Namespace) error {
 		return err
 	}
 
-	ctx := context.Background()
+	task, err := createTask(context.Background(), cmd, rt, store, ns)
+	if err != nil {
+		return renderWriteError(cmd, cfg.JSON, err)
+	}
 
+	if cfg.JSON {
+		return RenderWriteResultJSON(cmd.OutOrStdout(), "task", taskJSON(task))
+	}
+
+	fields := map[string]string{
+		"Title":    task.Title,
+		"Priority": strconv.Itoa(task.Priority),
+	}
+	if task.Status != nil {
+		fields["Status"] = *task.Status
+	}
+	return RenderWriteResult(cmd.OutOrStdout(), "Task", task.ID, fields)
+}
+
+// createTask resolves the scope and fields of a new task from the flags of
+// `kanji task create` and creates it.
+func createTask(ctx context.Context, cmd *cobra.Command, rt *Runtime, store *state.Store, ns Namespace) (domain.Task, error) {
 	// Resolve workspace.
 	workspaceID, _, err := ResolveWorkspaceScope(cmd, rt, store, ns)
 	if err != nil {
-		return err
+		return domain.Task{}, err
 	}
 
 	// Get provider ID from workspace.
 	workspaces, err := rt.ContextService.ListWorkspaces(ctx)
 	if err != nil {
-		return err
+		return domain.Task{}, err
 	}
 	var providerID string
 	for _, ws := range workspaces {
@@ -297,13 +384,13 @@ func runTaskCreate(cmd *cobra.Command, ns Namespace) error {
 		}
 	}
 	if providerID == "" {
-		return NewNotFound("workspace", workspaceID)
+		return domain.Task{}, NewNotFound("workspace", workspaceID)
 	}
 
 	// Resolve board.
 	boardID, _, err := ResolveBoardScope(cmd, rt, store, ns, workspaceID)
 	if err != nil {
-		return err
+		return domain.Task{}, err
 	}
 
 	// Resolve column.
@@ -312,7 +399,7 @@ func runTaskCreate(cmd *cobra.Command, ns Namespace) error {
 		columnID, _ = cmd.Flags().GetString("column-id")
 		columns, err := rt.ContextService.ListColumns(ctx, boardID)
 		if err != nil {
-			return err
+			return domain.Task{}, err
 		}
 		found := false
 		for _, col := range columns {
@@ -323,13 +410,13 @@ func runTaskCreate(cmd *cobra.Command, ns Namespace) error {
 			}
 		}
 		if !found {
-			return NewNotFound("column", columnID)
+			return domain.Task{}, NewNotFound("column", columnID)
 		}
 	} else if cmd.Flags().Changed("column") {
 		name, _ := cmd.Flags().GetString("column")
 		columns, err := rt.ContextService.ListColumns(ctx, boardID)
 		if err != nil {
-			return err
+			return domain.Task{}, err
 		}
 		found := false
 		for _, col := range columns {
@@ -341,16 +428,16 @@ func runTaskCreate(cmd *cobra.Command, ns Namespace) error {
 			}
 		}
 		if !found {
-			return NewNotFound("column", name)
+			return domain.Task{}, NewNotFound("column", name)
 		}
 	} else {
 		// Default to first column.
 		columns, err := rt.ContextService.ListColumns(ctx, boardID)
 		if err != nil {
-			return err
+			return domain.Task{}, err
 		}
 		if len(columns) == 0 {
-			return NewValidation("board has no columns")
+			return domain.Task{}, NewValidation("board has no columns")
 		}
 		columnID = columns[0].ID
 		status = strings.ToLower(columns[0].Name)
@@ -358,36 +445,17 @@ func runTaskCreate(cmd *cobra.Command, ns Namespace) error {
 
 	input, err := AssembleCreateTaskInput(cmd, workspaceID, boardID, columnID)
 	if err != nil {
-		return err
+		return domain.Task{}, err
 	}
 	input.ProviderID = providerID
 	input.Status = &status
+	input.Force, _ = cmd.Flags().GetBool("force")
 
 	task, err := rt.TaskService.CreateTask(ctx, input)
 	if err != nil {
-		return err
-	}
-
-	if cfg.JSON {
-		data := map[string]interface{}{
-			"id":       task.ID,
-			"title":    task.Title,
-			"priority": task.Priority,
-		}
-		if task.Status != nil {
-			data["status"] = *task.Status
-		}
-		return RenderWriteResultJSON(cmd.OutOrStdout(), "task", data)
-	}
-
-	fields := map[string]string{
-		"Title":    task.Title,
-		"Priority": strconv.Itoa(task.Priority),
+		return domain.Task{}, NewHookRejected(NewWIPLimitExceeded(err))
 	}
-	if task.Status != nil {
-		fields["Status"] = *task.Status
-	}
-	return RenderWriteResult(cmd.OutOrStdout(), "Task", task.ID, fields)
+	return task, nil
 }
 
 func newTaskUpdateCommand() *cobra.Command {
@@ -413,6 +481,7 @@ func newTaskUpdateCommand() *cobra.Command {
 	cmd.Flags().Bool("clear-description", false, "clear description")
 	cmd.Flags().Bool("clear-due-date", false, "clear due date")
 	cmd.Flags().Bool("clear-labels", false, "clear labels")
+	cmd.Flags().Int("if-version", 0, "fail unless the task is still at this version")
 	cmd.Flags().String("workspace-id", "", "workspace ID (required for title resolution)")
 	cmd.Flags().String("workspace", "", "workspace name (required for title resolution)")
 	return cmd
@@ -439,52 +508,83 @@ func runTaskUpdate(cmd *cobra.Command, ns Namespace) error {
 		return err
 	}
 
-	ctx := context.Background()
+	taskID, err := updateTask(context.Background(), cmd, rt, store, ns)
+	if err != nil {
+		return err
+	}
 
-	// Resolve workspace for task title resolution.
-	var workspaceID string
-	if cmd.Flags().Changed("task") {
-		workspaceID, _, err = ResolveWorkspaceScope(cmd, rt, store, ns)
-		if err != nil {
-			return err
-		}
+	if cfg.JSON {
+		return RenderWriteResultJSON(cmd.OutOrStdout(), "task", map[string]interface{}{
+			"id":      taskID,
+			"updated": true,
+		})
 	}
 
-	taskID, err := ResolveTaskID(cmd, rt, workspaceID)
+	fmt.Fprintf(cmd.OutOrStdout(), "Task updated\nID:  %s\n", taskID)
+	return nil
+}
+
+// updateTask resolves a task and its changes from the flags of
+// `kanji task update` and applies them. It returns the task ID.
+func updateTask(ctx context.Context, cmd *cobra.Command, rt *Runtime, store *state.Store, ns Namespace) (string, error) {
+	taskID, err := resolveTaskInScope(cmd, rt, store, ns)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	input, err := AssembleUpdateTaskInput(cmd)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	// Ensure at least one patch field is present.
 	if input.Title == nil && input.DescriptionMD == nil && input.Priority == nil &&
 		input.DueAt == nil && !input.ClearDueAt && input.Labels == nil {
-		return NewValidation("at least one of --title, --description, --priority, --due-date, --labels, --clear-description, --clear-due-date, --clear-labels is required")
+		return "", NewValidation("at least one of --title, --description, --priority, --due-date, --labels, --clear-description, --clear-due-date, --clear-labels is required")
+	}
+	if input.IfVersion, err = ifVersionFlag(cmd); err != nil {
+		return "", err
 	}
 
 	if err := rt.TaskService.UpdateTask(ctx, taskID, input); err != nil {
-		return err
+		return "", NewHookRejected(NewVersionConflict(err))
 	}
+	return taskID, nil
+}
 
-	if cfg.JSON {
-		return RenderWriteResultJSON(cmd.OutOrStdout(), "task", map[string]interface{}{
-			"id":      taskID,
-			"updated": true,
-		})
+// resolveTaskInScope resolves --task-id or --task, looking titles up in the
+// workspace given by the flags or the stored context.
+func resolveTaskInScope(cmd *cobra.Command, rt *Runtime, store *state.Store, ns Namespace) (string, error) {
+	var workspaceID string
+	if cmd.Flags().Changed("task") {
+		var err error
+		workspaceID, _, err = ResolveWorkspaceScope(cmd, rt, store, ns)
+		if err != nil {
+			return "", err
+		}
 	}
-
-	fmt.Fprintf(cmd.OutOrStdout(), "Task updated\nID:  %s\n", taskID)
-	return nil
+	return ResolveTaskID(cmd, rt, workspaceID)
 }
 
 func newTaskMoveCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "move",
-		Short: "Move a task to a different column",
+		Short: "Move a task to a different column or board, or reorder it within its column",
+		Long: `Move a task to another column of its board, or reorder it within its column
+with a placement flag.
+
+--to-board-id/--to-board move the task to another board, and
+--to-workspace-id/--to-workspace to a board of another workspace. Without
+--to-column-id or --to-column the task goes to the column of the destination
+board named like its current one.`,
+		Example: `  # Move a task to Done on its board
+  kanji task move --task "Fix login" --to-column Done
+
+  # Move a task to the same column of another board
+  kanji task move --task "Fix login" --to-board Backlog
+
+  # Move a task to another workspace
+  kanji task move --task-id <id> --to-workspace Personal --to-board Home --to-column "To Do"`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			ns, err := ResolveNamespace()
 			if err != nil {
@@ -497,10 +597,20 @@ func newTaskMoveCommand() *cobra.Command {
 	cmd.Flags().String("task", "", "task title")
 	cmd.Flags().String("to-column-id", "", "destination column ID")
 	cmd.Flags().String("to-column", "", "destination column name")
+	cmd.Flags().String("to-board-id", "", "destination board ID, to move the task to another board")
+	cmd.Flags().String("to-board", "", "destination board name, to move the task to another board")
+	cmd.Flags().String("to-workspace-id", "", "destination workspace ID, to move the task to another workspace")
+	cmd.Flags().String("to-workspace", "", "destination workspace name, to move the task to another workspace")
 	cmd.Flags().String("workspace-id", "", "workspace ID (required for title resolution)")
 	cmd.Flags().String("workspace", "", "workspace name (required for title resolution)")
 	cmd.Flags().String("board-id", "", "board ID (required for column name resolution)")
 	cmd.Flags().String("board", "", "board name (required for column name resolution)")
+	cmd.Flags().String("before", "", "place the task right above this task (ID or title) of the destination column")
+	cmd.Flags().String("after", "", "place the task right below this task (ID or title) of the destination column")
+	cmd.Flags().Bool("top", false, "place the task at the top of the destination column")
+	cmd.Flags().Bool("bottom", false, "place the task at the bottom of the destination column")
+	cmd.Flags().Bool("force", false, "move even if the destination column is at its WIP limit")
+	cmd.Flags().Int("if-version", 0, "fail unless the task is still at this version")
 	return cmd
 }
 
@@ -525,20 +635,31 @@ func runTaskMove(cmd *cobra.Command, ns Namespace) error {
 		return err
 	}
 
-	ctx := context.Background()
+	taskID, columnID, status, err := moveTask(context.Background(), cmd, rt, store, ns)
+	if err != nil {
+		return renderWriteError(cmd, cfg.JSON, err)
+	}
 
-	// Resolve workspace for task title resolution.
-	var workspaceID string
-	if cmd.Flags().Changed("task") {
-		workspaceID, _, err = ResolveWorkspaceScope(cmd, rt, store, ns)
-		if err != nil {
-			return err
-		}
+	if cfg.JSON {
+		return RenderWriteResultJSON(cmd.OutOrStdout(), "task", map[string]interface{}{
+			"id":        taskID,
+			"column_id": columnID,
+			"status":    status,
+		})
 	}
 
-	taskID, err := ResolveTaskID(cmd, rt, workspaceID)
+	fmt.Fprintf(cmd.OutOrStdout(), "Task moved\nID:      %s\nColumn:  %s\nStatus:  %s\n", taskID, columnID, status)
+	return nil
+}
+
+// moveTask resolves a task and its destination column from the flags of
+// `kanji task move` and moves it. Without a destination column a placement
+// flag reorders the task within its column. It returns the task ID, column
+// ID and status.
+func moveTask(ctx context.Context, cmd *cobra.Command, rt *Runtime, store *state.Store, ns Namespace) (string, string, string, error) {
+	taskID, err := resolveTaskInScope(cmd, rt, store, ns)
 	if err != nil {
-		return err
+		return "", "", "", err
 	}
 
 	// Resolve board for column resolution.
@@ -546,48 +667,255 @@ func runTaskMove(cmd *cobra.Command, ns Namespace) error {
 	// Look up the task to get its board ID.
 	task, err := rt.TaskService.GetTask(ctx, taskID)
 	if err != nil {
-		return err
+		return "", "", "", err
 	}
 	boardID := ""
 	if task.BoardID != nil {
 		boardID = *task.BoardID
 	}
 	if boardID == "" {
-		return NewValidation("task has no board")
+		return "", "", "", NewValidation("task has no board")
+	}
+	if hasTransferFlag(cmd) {
+		return transferTask(ctx, cmd, rt, task)
 	}
 
 	// If --to-column or --to-column-id uses name resolution, board scope is needed.
 	if cmd.Flags().Changed("to-column") {
 		boardID, _, err = ResolveBoardScope(cmd, rt, store, ns, task.WorkspaceID)
 		if err != nil {
-			return err
+			return "", "", "", err
 		}
 	}
 
-	columnID, status, err := ResolveMoveDestination(cmd, rt, boardID)
-	if err != nil {
-		return err
+	reorder := !cmd.Flags().Changed("to-column-id") && !cmd.Flags().Changed("to-column") && hasPlacementFlag(cmd)
+	var columnID, status string
+	if reorder {
+		if task.ColumnID == nil {
+			return "", "", "", NewValidation("task is not in a column; use --to-column-id or --to-column")
+		}
+		columnID = *task.ColumnID
+		if task.Status != nil {
+			status = *task.Status
+		}
+	} else if columnID, status, err = ResolveMoveDestination(cmd, rt, boardID); err != nil {
+		return "", "", "", err
 	}
 
 	// Cross-board guard.
 	if task.BoardID == nil || boardID != *task.BoardID {
-		return NewValidation("cannot move task to a different board")
+		return "", "", "", NewValidation("cannot move task to a different board with --board; use --to-board-id or --to-board")
 	}
 
-	if err := rt.TaskFlow.MoveTask(ctx, taskID, &columnID, &status, 0); err != nil {
-		return err
+	var opts application.MoveOptions
+	opts.Force, _ = cmd.Flags().GetBool("force")
+	if opts.IfVersion, err = ifVersionFlag(cmd); err != nil {
+		return "", "", "", err
+	}
+	if opts.Placement, err = movePlacement(ctx, cmd, rt, task, columnID); err != nil {
+		return "", "", "", err
 	}
+	if reorder {
+		err = rt.TaskFlow.ReorderTask(ctx, taskID, opts)
+	} else {
+		err = rt.TaskFlow.MoveTaskWith(ctx, taskID, &columnID, &status, 0, opts)
+	}
+	if err != nil {
+		return "", "", "", NewHookRejected(NewWIPLimitExceeded(NewVersionConflict(err)))
+	}
+	return taskID, columnID, status, nil
+}
 
-	if cfg.JSON {
-		return RenderWriteResultJSON(cmd.OutOrStdout(), "task", map[string]interface{}{
-			"id":        taskID,
-			"column_id": columnID,
-			"status":    status,
-		})
+// hasTransferFlag reports whether any of the flags moving a task to another
+// board or workspace is set.
+func hasTransferFlag(cmd *cobra.Command) bool {
+	for _, name := range []string{"to-board-id", "to-board", "to-workspace-id", "to-workspace"} {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
 	}
+	return false
+}
 
-	fmt.Fprintf(cmd.OutOrStdout(), "Task moved\nID:      %s\nColumn:  %s\nStatus:  %s\n", taskID, columnID, status)
-	return nil
+// transferTask moves a task to another board, possibly of another
+// workspace, for `kanji task move`. It returns the task ID, column ID and
+// status.
+func transferTask(ctx context.Context, cmd *cobra.Command, rt *Runtime, task domain.Task) (string, string, string, error) {
+	dest, column, err := resolveTaskDestination(ctx, cmd, rt, task)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	var opts application.MoveOptions
+	opts.Force, _ = cmd.Flags().GetBool("force")
+	if opts.IfVersion, err = ifVersionFlag(cmd); err != nil {
+		return "", "", "", err
+	}
+	// Placement flags name tasks of the destination column.
+	placed := task
+	placed.WorkspaceID, placed.BoardID = dest.WorkspaceID, &dest.BoardID
+	if opts.Placement, err = movePlacement(ctx, cmd, rt, placed, column.ID); err != nil {
+		return "", "", "", err
+	}
+	if _, err := rt.TaskFlow.TransferTask(ctx, task.ID, dest, opts); err != nil {
+		return "", "", "", NewHookRejected(NewWIPLimitExceeded(NewVersionConflict(err)))
+	}
+	return task.ID, column.ID, strings.ToLower(column.Name), nil
+}
+
+// resolveTaskDestination resolves where `kanji task move` and
+// `kanji task copy` put a task from the --to-workspace-id/--to-workspace,
+// --to-board-id/--to-board and --to-column-id/--to-column flags. The
+// workspace and board default to the task's own, and the column to the one
+// of the destination board named like the task's current column.
+func resolveTaskDestination(ctx context.Context, cmd *cobra.Command, rt *Runtime, task domain.Task) (application.TaskDestination, domain.Column, error) {
+	dest := application.TaskDestination{WorkspaceID: task.WorkspaceID}
+
+	workspaces, err := rt.ContextService.ListWorkspaces(ctx)
+	if err != nil {
+		return application.TaskDestination{}, domain.Column{}, err
+	}
+	if cmd.Flags().Changed("to-workspace-id") || cmd.Flags().Changed("to-workspace") {
+		byID := cmd.Flags().Changed("to-workspace-id")
+		value, _ := cmd.Flags().GetString("to-workspace")
+		if byID {
+			value, _ = cmd.Flags().GetString("to-workspace-id")
+		}
+		dest.WorkspaceID = ""
+		for _, ws := range workspaces {
+			if (byID && ws.ID == value) || (!byID && ExactMatch(ws.Name, value)) {
+				dest.WorkspaceID = ws.ID
+				break
+			}
+		}
+		if dest.WorkspaceID == "" {
+			return application.TaskDestination{}, domain.Column{}, NewNotFound("workspace", value)
+		}
+	}
+
+	boards, err := rt.ContextService.ListBoards(ctx, dest.WorkspaceID)
+	if err != nil {
+		return application.TaskDestination{}, domain.Column{}, err
+	}
+	switch {
+	case cmd.Flags().Changed("to-board-id") || cmd.Flags().Changed("to-board"):
+		byID := cmd.Flags().Changed("to-board-id")
+		value, _ := cmd.Flags().GetString("to-board")
+		if byID {
+			value, _ = cmd.Flags().GetString("to-board-id")
+		}
+		for _, b := range boards {
+			if (byID && b.ID == value) || (!byID && ExactMatch(b.Name, value)) {
+				dest.BoardID = b.ID
+				break
+			}
+		}
+		if dest.BoardID == "" {
+			return application.TaskDestination{}, domain.Column{}, NewNotFound("board", value)
+		}
+	case dest.WorkspaceID == task.WorkspaceID && task.BoardID != nil:
+		dest.BoardID = *task.BoardID
+	default:
+		return application.TaskDestination{}, domain.Column{}, NewValidation("to-board-id or to-board is required for another workspace")
+	}
+
+	if cmd.Flags().Changed("to-column-id") || cmd.Flags().Changed("to-column") {
+		if dest.ColumnID, _, err = ResolveMoveDestination(cmd, rt, dest.BoardID); err != nil {
+			return application.TaskDestination{}, domain.Column{}, err
+		}
+	}
+	column, err := rt.TaskFlow.DestinationColumn(ctx, task, dest)
+	if errors.Is(err, application.ErrNoMatchingColumn) {
+		return application.TaskDestination{}, domain.Column{}, NewValidation(err.Error() + "; use --to-column-id or --to-column")
+	}
+	if err != nil {
+		return application.TaskDestination{}, domain.Column{}, err
+	}
+	dest.ColumnID = column.ID
+	return dest, column, nil
+}
+
+// hasPlacementFlag reports whether any of the placement flags of
+// `kanji task move` is set.
+func hasPlacementFlag(cmd *cobra.Command) bool {
+	for _, name := range []string{"before", "after", "top", "bottom"} {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// movePlacement reads the placement flags of `kanji task move`. --before and
+// --after name a task of the destination column by ID or title.
+func movePlacement(ctx context.Context, cmd *cobra.Command, rt *Runtime, task domain.Task, columnID string) (application.Placement, error) {
+	var placement application.Placement
+	placement.Top, _ = cmd.Flags().GetBool("top")
+	placement.Bottom, _ = cmd.Flags().GetBool("bottom")
+	before, _ := cmd.Flags().GetString("before")
+	after, _ := cmd.Flags().GetString("after")
+
+	set := 0
+	for _, on := range []bool{cmd.Flags().Changed("before"), cmd.Flags().Changed("after"), placement.Top, placement.Bottom} {
+		if on {
+			set++
+		}
+	}
+	if set > 1 {
+		return application.Placement{}, NewValidation("only one of --before, --after, --top and --bottom can be set")
+	}
+
+	var err error
+	if cmd.Flags().Changed("before") {
+		placement.Before, err = resolveColumnTask(ctx, rt, task, columnID, before)
+	}
+	if cmd.Flags().Changed("after") {
+		placement.After, err = resolveColumnTask(ctx, rt, task, columnID, after)
+	}
+	if err != nil {
+		return application.Placement{}, err
+	}
+	if placement.Before == task.ID || placement.After == task.ID {
+		return application.Placement{}, NewValidation("a task cannot be placed relative to itself")
+	}
+	return placement, nil
+}
+
+// resolveColumnTask returns the ID of the task of a column whose ID or,
+// failing that, title matches value.
+func resolveColumnTask(ctx context.Context, rt *Runtime, task domain.Task, columnID, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", NewValidation("a task ID or title is required")
+	}
+	boardID := ""
+	if task.BoardID != nil {
+		boardID = *task.BoardID
+	}
+	tasks, err := rt.TaskFlow.ListTasks(ctx, application.ListTaskFilters{
+		WorkspaceID: task.WorkspaceID,
+		BoardID:     boardID,
+		ColumnID:    columnID,
+	})
+	if err != nil {
+		return "", err
+	}
+	var matches []string
+	for _, t := range tasks {
+		if t.ID == value {
+			return t.ID, nil
+		}
+		if ExactMatch(t.Title, value) {
+			matches = append(matches, t.ID)
+		}
+	}
+	if len(matches) == 0 {
+		return "", NewNotFound("task", value)
+	}
+	if len(matches) > 1 {
+		return "", NewAmbiguous("task", value, len(matches))
+	}
+	return matches[0], nil
 }
 
 func newTaskDeleteCommand() *cobra.Command {
@@ -635,22 +963,13 @@ func runTaskDelete(cmd *cobra.Command, ns Namespace) error {
 		return err
 	}
 
-	// Resolve workspace for task title resolution.
-	var workspaceID string
-	if cmd.Flags().Changed("task") {
-		workspaceID, _, err = ResolveWorkspaceScope(cmd, rt, store, ns)
-		if err != nil {
-			return err
-		}
-	}
-
-	taskID, err := ResolveTaskID(cmd, rt, workspaceID)
+	taskID, err := resolveTaskInScope(cmd, rt, store, ns)
 	if err != nil {
 		return err
 	}
 
 	if err := rt.TaskService.DeleteTask(context.Background(), taskID); err != nil {
-		return err
+		return NewHookRejected(err)
 	}
 
 	if cfg.JSON {
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -884,6 +885,167 @@ func TestTaskMove_CrossBoardBlocked(t *testing.T) {
 	assert.Contains(t, err.Error(), "cannot move task to a different board")
 }
 
+func TestTaskMove_Placement(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	ctx := context.Background()
+
+	rt, err := NewRuntime(ctx, RuntimeConfig{DBPath: dbPath})
+	require.NoError(t, err)
+	setup, err := rt.BootstrapService.EnsureDefaultSetup(ctx)
+	require.NoError(t, err)
+	ids := map[string]string{}
+	for _, title := range []string{"A", "B", "C"} {
+		task, err := rt.TaskService.CreateTask(ctx, application.CreateTaskInput{
+			ProviderID:  setup.Provider.ID,
+			WorkspaceID: setup.Workspace.ID,
+			BoardID:     &setup.Board.ID,
+			ColumnID:    &setup.Columns[0].ID,
+			Title:       title,
+		})
+		require.NoError(t, err)
+		ids[title] = task.ID
+	}
+	require.NoError(t, rt.Close())
+	ns := Namespace{Key: "test-ns", Source: "cwd"}
+
+	order := func(columnID string) []string {
+		t.Helper()
+		rt, err := NewRuntime(ctx, RuntimeConfig{DBPath: dbPath})
+		require.NoError(t, err)
+		defer rt.Close()
+		tasks, err := rt.TaskFlow.ListTasks(ctx, application.ListTaskFilters{WorkspaceID: setup.Workspace.ID, ColumnID: columnID})
+		require.NoError(t, err)
+		application.SortTasks(tasks, "manual")
+		titles := make([]string, 0, len(tasks))
+		for _, task := range tasks {
+			titles = append(titles, task.Title)
+		}
+		return titles
+	}
+	assert.Equal(t, []string{"A", "B", "C"}, order(setup.Columns[0].ID))
+
+	before := newVersionedCommand(t, newTaskMoveCommand(), dbPath, "--task-id", ids["C"], "--before", "A", "--workspace-id", setup.Workspace.ID)
+	require.NoError(t, runTaskMove(before, ns))
+	assert.Equal(t, []string{"C", "A", "B"}, order(setup.Columns[0].ID))
+
+	after := newVersionedCommand(t, newTaskMoveCommand(), dbPath, "--task-id", ids["B"], "--after", ids["C"])
+	require.NoError(t, runTaskMove(after, ns))
+	assert.Equal(t, []string{"C", "B", "A"}, order(setup.Columns[0].ID))
+
+	bottom := newVersionedCommand(t, newTaskMoveCommand(), dbPath, "--task-id", ids["C"], "--bottom")
+	require.NoError(t, runTaskMove(bottom, ns))
+	assert.Equal(t, []string{"B", "A", "C"}, order(setup.Columns[0].ID))
+
+	both := newVersionedCommand(t, newTaskMoveCommand(), dbPath, "--task-id", ids["C"], "--top", "--bottom")
+	assert.True(t, errors.Is(runTaskMove(both, ns), &SelectorError{Code: "validation"}))
+
+	missing := newVersionedCommand(t, newTaskMoveCommand(), dbPath, "--task-id", ids["C"], "--before", "Nope")
+	assert.True(t, errors.Is(runTaskMove(missing, ns), &SelectorError{Code: "not_found"}))
+
+	// Moving to another column places the task there; B is not in Doing.
+	outside := newVersionedCommand(t, newTaskMoveCommand(), dbPath, "--task-id", ids["A"], "--to-column-id", setup.Columns[1].ID, "--before", ids["B"])
+	assert.True(t, errors.Is(runTaskMove(outside, ns), &SelectorError{Code: "not_found"}))
+	first := newVersionedCommand(t, newTaskMoveCommand(), dbPath, "--task-id", ids["A"], "--to-column-id", setup.Columns[1].ID)
+	require.NoError(t, runTaskMove(first, ns))
+	top := newVersionedCommand(t, newTaskMoveCommand(), dbPath, "--task-id", ids["B"], "--to-column-id", setup.Columns[1].ID, "--top")
+	require.NoError(t, runTaskMove(top, ns))
+	assert.Equal(t, []string{"B", "A"}, order(setup.Columns[1].ID))
+	assert.Equal(t, []string{"C"}, order(setup.Columns[0].ID))
+}
+
+func TestTaskMove_ToAnotherBoard(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	ctx := context.Background()
+
+	rt, err := NewRuntime(ctx, RuntimeConfig{DBPath: dbPath})
+	require.NoError(t, err)
+	setup, err := rt.BootstrapService.EnsureDefaultSetup(ctx)
+	require.NoError(t, err)
+	backlog, err := rt.ContextService.CreateBoardWithColumns(ctx, setup.Workspace.ID, "Backlog", []application.CreateBoardColumnInput{
+		{Name: "Ideas", Color: "#FF0000"},
+		{Name: "Doing", Color: "#00FF00"},
+	})
+	require.NoError(t, err)
+	personal, home, err := rt.ContextService.CreateWorkspace(ctx, setup.Provider.ID, "Personal")
+	require.NoError(t, err)
+	task, err := rt.TaskService.CreateTask(ctx, application.CreateTaskInput{
+		ProviderID:  setup.Provider.ID,
+		WorkspaceID: setup.Workspace.ID,
+		BoardID:     &setup.Board.ID,
+		ColumnID:    &setup.Columns[1].ID,
+		Title:       "Travelling",
+		Labels:      []string{"trip"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, rt.Close())
+	ns := Namespace{Key: "test-ns", Source: "cwd"}
+
+	// Without a destination column the task keeps its column name.
+	toBoard := newVersionedCommand(t, newTaskMoveCommand(), dbPath, "--task-id", task.ID, "--to-board", "Backlog")
+	require.NoError(t, runTaskMove(toBoard, ns))
+
+	check, err := NewRuntime(ctx, RuntimeConfig{DBPath: dbPath})
+	require.NoError(t, err)
+	moved, err := check.TaskService.GetTask(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, backlog.ID, *moved.BoardID)
+	columns, err := check.ContextService.ListColumns(ctx, backlog.ID)
+	require.NoError(t, err)
+	assert.Equal(t, columns[1].ID, *moved.ColumnID)
+	require.NoError(t, check.Close())
+
+	// An explicit column must be on the destination board, and another
+	// workspace needs a destination board.
+	missing := newVersionedCommand(t, newTaskMoveCommand(), dbPath, "--task-id", task.ID, "--to-workspace", "Personal", "--to-board-id", home.ID, "--to-column", "Ideas")
+	assert.True(t, errors.Is(runTaskMove(missing, ns), &SelectorError{Code: "not_found"}))
+	noBoard := newVersionedCommand(t, newTaskMoveCommand(), dbPath, "--task-id", task.ID, "--to-workspace", "Personal")
+	assert.True(t, errors.Is(runTaskMove(noBoard, ns), &SelectorError{Code: "validation"}))
+
+	toWorkspace := newVersionedCommand(t, newTaskMoveCommand(), dbPath, "--task-id", task.ID, "--to-workspace", "Personal", "--to-board-id", home.ID, "--to-column", "Done")
+	require.NoError(t, runTaskMove(toWorkspace, ns))
+
+	check, err = NewRuntime(ctx, RuntimeConfig{DBPath: dbPath})
+	require.NoError(t, err)
+	defer check.Close()
+	moved, err = check.TaskService.GetTask(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, personal.ID, moved.WorkspaceID)
+	assert.Equal(t, home.ID, *moved.BoardID)
+	require.NotNil(t, moved.Status)
+	assert.Equal(t, "done", *moved.Status)
+}
+
+func TestTaskMove_ToAnotherBoardWithoutMatchingColumn(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	ctx := context.Background()
+
+	rt, err := NewRuntime(ctx, RuntimeConfig{DBPath: dbPath})
+	require.NoError(t, err)
+	setup, err := rt.BootstrapService.EnsureDefaultSetup(ctx)
+	require.NoError(t, err)
+	_, err = rt.ContextService.CreateBoardWithColumns(ctx, setup.Workspace.ID, "Ideas", []application.CreateBoardColumnInput{
+		{Name: "Someday", Color: "#FF0000"},
+	})
+	require.NoError(t, err)
+	task, err := rt.TaskService.CreateTask(ctx, application.CreateTaskInput{
+		ProviderID:  setup.Provider.ID,
+		WorkspaceID: setup.Workspace.ID,
+		BoardID:     &setup.Board.ID,
+		ColumnID:    &setup.Columns[0].ID,
+		Title:       "Unmapped",
+	})
+	require.NoError(t, err)
+	require.NoError(t, rt.Close())
+
+	cmd := newVersionedCommand(t, newTaskMoveCommand(), dbPath, "--task-id", task.ID, "--to-board", "Ideas")
+	err = runTaskMove(cmd, Namespace{Key: "test-ns", Source: "cwd"})
+	require.True(t, errors.Is(err, &SelectorError{Code: "validation"}), "got %v", err)
+	assert.Contains(t, err.Error(), "--to-column")
+}
+
 // ── task delete ──
 
 func TestTaskDelete_Success(t *testing.T) {
@@ -0,0 +1,272 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tiagokriok/kanji/internal/application"
+	"github.com/tiagokriok/kanji/internal/domain"
+	"github.com/tiagokriok/kanji/internal/state"
+)
+
+func newTemplateCommand() *cobra.Command {
+	t := &cobra.Command{
+		Use:   "template",
+		Short: "Board templates",
+		Long: `A board template holds the columns of a board with their colors and WIP
+limits, the labels it adds to the workspace, and the tasks it starts with.
+Create a board from one with ` + "`kanji board create --template <name>`" + `.
+
+kanji ships the kanban and scrum templates. Saved templates are YAML files
+in the templates directory of the kanji config dir (for example
+~/.config/kanji/templates/scrum.yaml) and may be written by hand; a saved
+template hides the built-in one of the same name.`,
+	}
+	t.AddCommand(newTemplateListCommand())
+	t.AddCommand(newTemplateSaveCommand())
+	t.AddCommand(newTemplateDeleteCommand())
+	return t
+}
+
+func newTemplateListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List board templates",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runTemplateList(cmd)
+		},
+	}
+}
+
+func newTemplateSaveCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "save",
+		Short: "Save a board as a template",
+		Long: `Save the columns, colors and WIP limits of a board as a template, with the
+workspace labels its tasks carry. --with-tasks saves its tasks as starter
+tasks too.`,
+		Example: `  kanji template save --name sprint --from-board "Sprint 12"
+  kanji template save --name onboarding --from-board-id <id> --with-tasks --replace`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns, err := ResolveNamespace()
+			if err != nil {
+				return err
+			}
+			return runTemplateSave(cmd, ns)
+		},
+	}
+	cmd.Flags().String("name", "", "template name: letters, digits, '-' or '_'")
+	cmd.Flags().String("description", "", "template description")
+	cmd.Flags().String("from-board-id", "", "board ID to save")
+	cmd.Flags().String("from-board", "", "board name to save")
+	cmd.Flags().String("workspace-id", "", "workspace ID (required for name resolution)")
+	cmd.Flags().String("workspace", "", "workspace name (required for name resolution)")
+	cmd.Flags().Bool("with-tasks", false, "save the tasks of the board as starter tasks")
+	cmd.Flags().Bool("replace", false, "replace an existing template of the same name")
+	return cmd
+}
+
+func newTemplateDeleteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete a saved template",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runTemplateDelete(cmd)
+		},
+	}
+	cmd.Flags().String("name", "", "template name")
+	cmd.Flags().Bool("yes", false, "confirm deletion")
+	return cmd
+}
+
+func templateSource(t domain.BoardTemplate) string {
+	if t.Builtin {
+		return "built-in"
+	}
+	return "saved"
+}
+
+func templateJSON(t domain.BoardTemplate) map[string]interface{} {
+	columns := make([]map[string]interface{}, len(t.Columns))
+	for i, c := range t.Columns {
+		column := map[string]interface{}{"name": c.Name, "color": c.Color}
+		if c.WIPLimit != nil {
+			column["wip_limit"] = *c.WIPLimit
+		}
+		columns[i] = column
+	}
+	labels := make([]string, len(t.Labels))
+	for i, l := range t.Labels {
+		labels[i] = l.Name
+	}
+	return map[string]interface{}{
+		"name":        t.Name,
+		"description": t.Description,
+		"source":      templateSource(t),
+		"columns":     columns,
+		"labels":      labels,
+		"tasks":       len(t.Tasks),
+	}
+}
+
+func templateColumnNames(t domain.BoardTemplate) string {
+	names := make([]string, len(t.Columns))
+	for i, c := range t.Columns {
+		names[i] = c.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+func runTemplateList(cmd *cobra.Command) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	list, err := rt.TemplateService.ListTemplates(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if cfg.JSON {
+		items := make([]map[string]interface{}, len(list))
+		for i, t := range list {
+			items[i] = templateJSON(t)
+		}
+		return RenderWrappedListJSON(cmd.OutOrStdout(), "templates", items, len(items))
+	}
+
+	headers := []string{"Name", "Source", "Columns", "Labels", "Tasks", "Description"}
+	rows := make([][]string, len(list))
+	for i, t := range list {
+		rows[i] = []string{t.Name, templateSource(t), templateColumnNames(t), strconv.Itoa(len(t.Labels)), strconv.Itoa(len(t.Tasks)), t.Description}
+	}
+	return RenderTable(cmd.OutOrStdout(), headers, rows)
+}
+
+func runTemplateSave(cmd *cobra.Command, ns Namespace) error {
+	store, err := defaultStateStore()
+	if err != nil {
+		return err
+	}
+	return runTemplateSaveWithStore(cmd, ns, store)
+}
+
+func runTemplateSaveWithStore(cmd *cobra.Command, ns Namespace, store *state.Store) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	name, _ := cmd.Flags().GetString("name")
+	if strings.TrimSpace(name) == "" {
+		return NewValidation("--name is required")
+	}
+	if !cmd.Flags().Changed("from-board-id") && !cmd.Flags().Changed("from-board") {
+		return NewValidation("--from-board-id or --from-board is required")
+	}
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	if err := GuardBootstrap(rt); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	workspaceID, _, err := ResolveWorkspaceScope(cmd, rt, store, ns)
+	if err != nil {
+		return err
+	}
+	boardID, err := resolveBoardFlags(ctx, cmd, rt, workspaceID, "from-board-id", "from-board")
+	if err != nil {
+		return err
+	}
+
+	withTasks, _ := cmd.Flags().GetBool("with-tasks")
+	replace, _ := cmd.Flags().GetBool("replace")
+	template, err := rt.TemplateService.TemplateFromBoard(ctx, boardID, name, withTasks)
+	if err != nil {
+		return err
+	}
+	template.Description, _ = cmd.Flags().GetString("description")
+	saved, err := rt.TemplateService.SaveTemplate(ctx, template, replace)
+	if err != nil {
+		return NewValidation(err.Error())
+	}
+
+	if cfg.JSON {
+		return RenderWrappedJSON(cmd.OutOrStdout(), "template", templateJSON(saved))
+	}
+	return RenderKV(cmd.OutOrStdout(), map[string]string{
+		"Name":    saved.Name,
+		"Columns": templateColumnNames(saved),
+		"Labels":  strconv.Itoa(len(saved.Labels)),
+		"Tasks":   strconv.Itoa(len(saved.Tasks)),
+	})
+}
+
+func runTemplateDelete(cmd *cobra.Command) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+	name, _ := cmd.Flags().GetString("name")
+	if strings.TrimSpace(name) == "" {
+		return NewValidation("--name is required")
+	}
+	if err := RequireConfirmation(cmd, "yes"); err != nil {
+		return err
+	}
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	if err := rt.TemplateService.DeleteTemplate(context.Background(), name); err != nil {
+		if errors.Is(err, application.ErrTemplateNotFound) {
+			return NewNotFound("template", name)
+		}
+		return NewValidation(err.Error())
+	}
+
+	if cfg.JSON {
+		return RenderDeleteResultJSON(cmd.OutOrStdout(), "template", name, false)
+	}
+	return RenderDeleteResult(cmd.OutOrStdout(), "template", name)
+}
+
+// resolveBoardFlags resolves the board of a workspace given by an ID flag
+// or a name flag, such as --from-board-id and --from-board.
+func resolveBoardFlags(ctx context.Context, cmd *cobra.Command, rt *Runtime, workspaceID, idFlag, nameFlag string) (string, error) {
+	byID := cmd.Flags().Changed(idFlag)
+	value, _ := cmd.Flags().GetString(nameFlag)
+	if byID {
+		value, _ = cmd.Flags().GetString(idFlag)
+	}
+	boards, err := rt.ContextService.ListBoards(ctx, workspaceID)
+	if err != nil {
+		return "", err
+	}
+	for _, b := range boards {
+		if (byID && b.ID == value) || (!byID && ExactMatch(b.Name, value)) {
+			return b.ID, nil
+		}
+	}
+	return "", NewNotFound("board", value)
+}
@@ -0,0 +1,156 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tiagokriok/kanji/internal/application"
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+func setupTemplateDB(t *testing.T) (string, application.BootstrapResult) {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	rt, err := NewRuntime(context.Background(), RuntimeConfig{DBPath: dbPath})
+	require.NoError(t, err)
+	setup, err := rt.BootstrapService.EnsureDefaultSetup(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, rt.Close())
+	return dbPath, setup
+}
+
+func findBoardByName(t *testing.T, rt *Runtime, workspaceID, name string) domain.Board {
+	t.Helper()
+	boards, err := rt.ContextService.ListBoards(context.Background(), workspaceID)
+	require.NoError(t, err)
+	for _, b := range boards {
+		if b.Name == name {
+			return b
+		}
+	}
+	t.Fatalf("board %q not found", name)
+	return domain.Board{}
+}
+
+func TestTemplateSaveListDelete(t *testing.T) {
+	dbPath, setup := setupTemplateDB(t)
+	ns := Namespace{Key: "test-ns", Source: "cwd"}
+
+	save := newVersionedCommand(t, newTemplateSaveCommand(), dbPath, "--workspace-id", setup.Workspace.ID, "--from-board", setup.Board.Name, "--name", "daily", "--description", "Daily work")
+	require.NoError(t, runTemplateSave(save, ns))
+
+	again := newVersionedCommand(t, newTemplateSaveCommand(), dbPath, "--workspace-id", setup.Workspace.ID, "--from-board-id", setup.Board.ID, "--name", "daily")
+	assert.ErrorContains(t, runTemplateSave(again, ns), "already exists")
+
+	listCmd := newTemplateListCommand()
+	listCmd.Flags().Bool("json", false, "")
+	list := newVersionedCommand(t, listCmd, dbPath, "--json")
+	require.NoError(t, runTemplateList(list))
+	var payload struct {
+		Templates []map[string]interface{} `json:"templates"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(list.OutOrStdout().(*strings.Builder).String()), &payload))
+	names := make([]string, len(payload.Templates))
+	for i, tpl := range payload.Templates {
+		names[i] = tpl["name"].(string)
+	}
+	assert.Equal(t, []string{"daily", "kanban", "scrum"}, names)
+	assert.Equal(t, "saved", payload.Templates[0]["source"])
+	assert.Equal(t, "Daily work", payload.Templates[0]["description"])
+
+	unconfirmed := newVersionedCommand(t, newTemplateDeleteCommand(), dbPath, "--name", "daily")
+	assert.Error(t, runTemplateDelete(unconfirmed))
+
+	builtin := newVersionedCommand(t, newTemplateDeleteCommand(), dbPath, "--name", "scrum", "--yes")
+	assert.ErrorContains(t, runTemplateDelete(builtin), "cannot be deleted")
+
+	del := newVersionedCommand(t, newTemplateDeleteCommand(), dbPath, "--name", "daily", "--yes")
+	require.NoError(t, runTemplateDelete(del))
+
+	missing := newVersionedCommand(t, newTemplateDeleteCommand(), dbPath, "--name", "daily", "--yes")
+	var selErr *SelectorError
+	require.True(t, errors.As(runTemplateDelete(missing), &selErr))
+	assert.Equal(t, "not_found", selErr.Code)
+}
+
+func TestBoardCreate_FromTemplate(t *testing.T) {
+	dbPath, setup := setupTemplateDB(t)
+	ns := Namespace{Key: "test-ns", Source: "cwd"}
+
+	create := newVersionedCommand(t, newBoardCreateCommand(), dbPath, "--workspace-id", setup.Workspace.ID, "--name", "Sprint", "--template", "scrum")
+	require.NoError(t, runBoardCreate(create, ns))
+
+	both := newVersionedCommand(t, newBoardCreateCommand(), dbPath, "--workspace-id", setup.Workspace.ID, "--name", "Both", "--template", "scrum", "--column", "Todo")
+	assert.ErrorContains(t, runBoardCreate(both, ns), "either --template or --column")
+
+	unknown := newVersionedCommand(t, newBoardCreateCommand(), dbPath, "--workspace-id", setup.Workspace.ID, "--name", "Other", "--template", "nope")
+	var selErr *SelectorError
+	require.True(t, errors.As(runBoardCreate(unknown, ns), &selErr))
+	assert.Equal(t, "not_found", selErr.Code)
+
+	rt, err := NewRuntime(context.Background(), RuntimeConfig{DBPath: dbPath})
+	require.NoError(t, err)
+	defer rt.Close()
+	board := findBoardByName(t, rt, setup.Workspace.ID, "Sprint")
+	columns, err := rt.ContextService.ListColumns(context.Background(), board.ID)
+	require.NoError(t, err)
+	require.Len(t, columns, 5)
+	assert.Equal(t, "Backlog", columns[0].Name)
+	require.NotNil(t, columns[2].WIPLimit)
+	assert.Equal(t, 3, *columns[2].WIPLimit)
+	_, err = rt.LabelService.GetLabel(context.Background(), setup.Workspace.ID, "bug")
+	assert.NoError(t, err)
+}
+
+func TestBoardClone(t *testing.T) {
+	dbPath, setup := setupTemplateDB(t)
+	ctx := context.Background()
+	rt, err := NewRuntime(ctx, RuntimeConfig{DBPath: dbPath})
+	require.NoError(t, err)
+	_, err = rt.TaskService.CreateTask(ctx, application.CreateTaskInput{
+		ProviderID:  setup.Provider.ID,
+		WorkspaceID: setup.Workspace.ID,
+		BoardID:     &setup.Board.ID,
+		ColumnID:    &setup.Columns[1].ID,
+		Title:       "Carry over",
+	})
+	require.NoError(t, err)
+	require.NoError(t, rt.Close())
+	ns := Namespace{Key: "test-ns", Source: "cwd"}
+
+	cloneCmd := newBoardCloneCommand()
+	cloneCmd.Flags().Bool("json", false, "")
+	clone := newVersionedCommand(t, cloneCmd, dbPath, "--workspace-id", setup.Workspace.ID, "--board-id", setup.Board.ID, "--name", "Next", "--with-tasks", "--json")
+	require.NoError(t, runBoardClone(clone, ns))
+	var payload struct {
+		Board map[string]interface{} `json:"board"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(clone.OutOrStdout().(*strings.Builder).String()), &payload))
+	assert.Equal(t, "Next", payload.Board["name"])
+	assert.Equal(t, setup.Board.ID, payload.Board["source_id"])
+	assert.EqualValues(t, 1, payload.Board["tasks_copied"])
+
+	empty := newVersionedCommand(t, newBoardCloneCommand(), dbPath, "--board", setup.Board.Name, "--workspace-id", setup.Workspace.ID)
+	require.NoError(t, runBoardClone(empty, ns))
+
+	check, err := NewRuntime(ctx, RuntimeConfig{DBPath: dbPath})
+	require.NoError(t, err)
+	defer check.Close()
+	next := findBoardByName(t, check, setup.Workspace.ID, "Next")
+	tasks, err := check.TaskFlow.ListTasks(ctx, application.ListTaskFilters{WorkspaceID: setup.Workspace.ID, BoardID: next.ID})
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "Carry over", tasks[0].Title)
+	copyBoard := findBoardByName(t, check, setup.Workspace.ID, setup.Board.Name+" (copy)")
+	columns, err := check.ContextService.ListColumns(ctx, copyBoard.ID)
+	require.NoError(t, err)
+	assert.Len(t, columns, len(setup.Columns))
+}
@@ -3,6 +3,7 @@ package cli
 import (
 	"context"
 	"fmt"
+	"io"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
@@ -42,7 +43,30 @@ func runTUI(cmd *cobra.Command) error {
 		return fmt.Errorf("ensure setup: %w", err)
 	}
 
-	model := ui.NewModel(rt.TaskService, rt.TaskFlow, rt.CommentService, rt.ContextService, setup)
+	// Hook warnings would draw over the alt screen; failed pre hooks still
+	// surface as errors in the status line.
+	rt.Hooks.SetWarnings(io.Discard)
+
+	// Reload when other processes, such as CLI commands, change the database.
+	watcher, err := rt.DB.WatchChanges(ctx)
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	model := ui.NewModel(rt.TaskService, rt.TaskFlow, rt.CommentService, rt.ContextService, rt.SyncEngine, setup)
+	model.SetChangeWatcher(watcher)
+	model.SetHistoryService(rt.HistoryService)
+	model.SetSearchService(rt.SearchService)
+	model.SetViewService(rt.ViewService)
+	model.SetLabelService(rt.LabelService)
+	model.SetTaskCopyService(rt.TaskCopyService)
+	model.SetTemplateService(rt.TemplateService)
+	ns, err := ResolveNamespace()
+	if err != nil {
+		return err
+	}
+	model.SetUndoService(rt.UndoService, ns.Key)
 	program := tea.NewProgram(model, tea.WithAltScreen())
 	_, err = program.Run()
 	return err
@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+func newUndoCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "undo",
+		Short: "Undo the latest task and comment changes made in this context",
+		Long: `Revert the latest task and comment changes made from the current namespace
+(the working directory, or $KANJI_CONTEXT), newest first. Creates, edits, moves
+and deletes of tasks and comments can be undone; undone changes can be replayed
+with kanji redo until a new change is made.
+
+A change that was modified since, for example by another user or a sync, is not
+reverted: it is dropped from the undo history and the command fails with the
+undo_conflict code.`,
+		Example: `  kanji undo
+  kanji undo --steps 3`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns, err := ResolveNamespace()
+			if err != nil {
+				return err
+			}
+			return runUndo(cmd, ns)
+		},
+	}
+	cmd.Flags().Int("steps", 1, "number of changes to undo")
+	return cmd
+}
+
+func newRedoCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "redo",
+		Short: "Redo changes reverted by kanji undo",
+		Example: `  kanji redo
+  kanji redo --steps 2`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns, err := ResolveNamespace()
+			if err != nil {
+				return err
+			}
+			return runRedo(cmd, ns)
+		},
+	}
+	cmd.Flags().Int("steps", 1, "number of changes to redo")
+	return cmd
+}
+
+func runUndo(cmd *cobra.Command, ns Namespace) error {
+	return runUndoStep(cmd, ns, true)
+}
+
+func runRedo(cmd *cobra.Command, ns Namespace) error {
+	return runUndoStep(cmd, ns, false)
+}
+
+func runUndoStep(cmd *cobra.Command, ns Namespace, undo bool) error {
+	steps, _ := cmd.Flags().GetInt("steps")
+	if steps < 1 {
+		return NewValidation("--steps must be at least 1")
+	}
+
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	if err := GuardBootstrap(rt); err != nil {
+		return err
+	}
+
+	run, verb := rt.UndoService.Undo, "Undid"
+	if !undo {
+		run, verb = rt.UndoService.Redo, "Redid"
+	}
+	ops, err := run(context.Background(), ns.Key, steps)
+	// Report what was done before an error; the changes are committed.
+	if len(ops) > 0 || err == nil {
+		if renderErr := renderOperations(cmd, cfg.JSON, verb, ops); renderErr != nil {
+			return renderErr
+		}
+	}
+	return NewUndoError(err)
+}
+
+func renderOperations(cmd *cobra.Command, asJSON bool, verb string, ops []domain.Operation) error {
+	if asJSON {
+		items := make([]map[string]interface{}, len(ops))
+		for i, op := range ops {
+			items[i] = operationJSON(op)
+		}
+		return RenderWrappedListJSON(cmd.OutOrStdout(), "operations", items, len(ops))
+	}
+	for _, op := range ops {
+		fmt.Fprintf(cmd.OutOrStdout(), "%s %s\n", verb, op.Describe())
+	}
+	return nil
+}
+
+// NewUndoError converts an empty journal or a change modified since into a
+// typed CLI error. Other errors pass through unchanged.
+func NewUndoError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrNothingToUndo):
+		return &SelectorError{Code: "nothing_to_undo", Message: err.Error()}
+	case errors.Is(err, domain.ErrNothingToRedo):
+		return &SelectorError{Code: "nothing_to_redo", Message: err.Error()}
+	case errors.Is(err, domain.ErrOperationConflict):
+		return &SelectorError{
+			Code:    "undo_conflict",
+			Message: err.Error() + "; the change was dropped from the undo history",
+		}
+	}
+	return err
+}
@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUndoRedo(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	// Commands journal under the namespace resolved from the environment.
+	t.Setenv("KANJI_CONTEXT", "undo-ns")
+	dbPath, _, taskID := setupFullDoingColumn(t)
+	ns := Namespace{Key: "undo-ns", Source: "env"}
+
+	for _, title := range []string{"First", "Second"} {
+		update := newVersionedCommand(t, newTaskUpdateCommand(), dbPath, "--task-id", taskID, "--title", title)
+		require.NoError(t, runTaskUpdate(update, ns))
+	}
+	title := func() string {
+		rt, err := NewRuntime(context.Background(), RuntimeConfig{DBPath: dbPath})
+		require.NoError(t, err)
+		defer rt.Close()
+		task, err := rt.TaskService.GetTask(context.Background(), taskID)
+		require.NoError(t, err)
+		return task.Title
+	}
+
+	undo := newVersionedCommand(t, newUndoCommand(), dbPath, "--steps", "2")
+	require.NoError(t, runUndo(undo, ns))
+	out := undo.OutOrStdout().(*strings.Builder).String()
+	assert.Equal(t, "Undid edit of \"Second\"\nUndid edit of \"First\"\n", out)
+	assert.Equal(t, "Waiting", title())
+
+	redo := newVersionedCommand(t, newRedoCommand(), dbPath)
+	require.NoError(t, runRedo(redo, ns))
+	assert.Equal(t, "First", title())
+
+	other := newVersionedCommand(t, newUndoCommand(), dbPath)
+	err := runUndo(other, Namespace{Key: "elsewhere", Source: "env"})
+	assert.True(t, errors.Is(err, &SelectorError{Code: "nothing_to_undo"}), "got %v", err)
+
+	invalid := newVersionedCommand(t, newRedoCommand(), dbPath, "--steps", "0")
+	assert.True(t, errors.Is(runRedo(invalid, ns), &SelectorError{Code: "validation"}))
+}
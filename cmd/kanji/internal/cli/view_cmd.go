@@ -0,0 +1,502 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tiagokriok/kanji/internal/application"
+	"github.com/tiagokriok/kanji/internal/domain"
+	"github.com/tiagokriok/kanji/internal/state"
+)
+
+func newViewCommand() *cobra.Command {
+	v := &cobra.Command{
+		Use:   "view",
+		Short: "Saved views of filters, sort order and layout",
+		Long: `A view saves the TUI filter panel settings under a name: a column, a
+priority, a due date filter, a sort order and the list or kanban layout,
+plus search text and a filter expression. Views created with --board or
+--board-id belong to that board; the others apply to every board of the
+workspace, matching the column by name.
+
+In the TUI, press V to pick a view and restore all of its settings at once.`,
+	}
+	v.AddCommand(newViewCreateCommand())
+	v.AddCommand(newViewListCommand())
+	v.AddCommand(newViewDeleteCommand())
+	v.AddCommand(newViewApplyCommand())
+	return v
+}
+
+func newViewCreateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Save a view",
+		Example: `  kanji view create --name "Urgent" --priority 1 --sort due
+  kanji view create --board "Sprint" --name "Review" --column "In Review" --layout kanban
+  kanji view create --name "Overdue bugs" --due overdue --filter "label:bug" --replace`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns, err := ResolveNamespace()
+			if err != nil {
+				return err
+			}
+			return runViewCreate(cmd, ns)
+		},
+	}
+	cmd.Flags().String("name", "", "view name")
+	addViewScopeFlags(cmd)
+	cmd.Flags().String("column", "", "only show tasks in the column of this name")
+	cmd.Flags().Int("priority", 0, "only show tasks of this priority (0-5)")
+	cmd.Flags().String("due", "", "due date filter: "+strings.Join(domain.ViewDueFilters, ", "))
+	cmd.Flags().String("sort", "", "sort order: "+strings.Join(domain.ViewSorts, ", "))
+	cmd.Flags().String("layout", "", "TUI layout: "+strings.Join(domain.ViewLayouts, ", "))
+	cmd.Flags().String("search", "", "search text")
+	cmd.Flags().String("filter", "", `filter expression, e.g. "label:bug and due<7d"`)
+	cmd.Flags().Bool("replace", false, "replace the settings of an existing view of the same name")
+	return cmd
+}
+
+func newViewListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the views of a workspace",
+		Long: `List the views of a workspace. With --board or --board-id only the views
+that apply to that board are listed: its own and the workspace-wide ones.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns, err := ResolveNamespace()
+			if err != nil {
+				return err
+			}
+			return runViewList(cmd, ns)
+		},
+	}
+	addViewScopeFlags(cmd)
+	return cmd
+}
+
+func newViewDeleteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete a view",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns, err := ResolveNamespace()
+			if err != nil {
+				return err
+			}
+			return runViewDelete(cmd, ns)
+		},
+	}
+	cmd.Flags().String("id", "", "view ID")
+	cmd.Flags().String("name", "", "view name")
+	addViewScopeFlags(cmd)
+	cmd.Flags().Bool("yes", false, "confirm deletion")
+	return cmd
+}
+
+func newViewApplyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "List the tasks a view shows",
+		Long: `List the tasks a view shows, filtered and sorted the way the TUI shows them.
+A board view lists the tasks of its board; a workspace view lists the tasks
+of every board unless --board or --board-id narrows it.`,
+		Example: `  kanji view apply --name "Urgent"
+  kanji view apply --board "Sprint" --name "Review" --json`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns, err := ResolveNamespace()
+			if err != nil {
+				return err
+			}
+			return runViewApply(cmd, ns)
+		},
+	}
+	cmd.Flags().String("id", "", "view ID")
+	cmd.Flags().String("name", "", "view name")
+	addViewScopeFlags(cmd)
+	return cmd
+}
+
+func addViewScopeFlags(cmd *cobra.Command) {
+	cmd.Flags().String("workspace-id", "", "workspace ID")
+	cmd.Flags().String("workspace", "", "workspace name")
+	cmd.Flags().String("board-id", "", "board ID (omit for a workspace-wide view)")
+	cmd.Flags().String("board", "", "board name (omit for a workspace-wide view)")
+}
+
+// resolveViewScope resolves the workspace of a view command and the board
+// given by --board or --board-id, if any. Unlike other commands it does not
+// fall back to the context board, since no board means every board.
+func resolveViewScope(cmd *cobra.Command, rt *Runtime, store *state.Store, ns Namespace) (string, string, error) {
+	workspaceID, _, err := ResolveWorkspaceScope(cmd, rt, store, ns)
+	if err != nil {
+		return "", "", err
+	}
+	var boardID string
+	if cmd.Flags().Changed("board-id") || cmd.Flags().Changed("board") {
+		if boardID, _, err = ResolveBoardScope(cmd, rt, store, ns, workspaceID); err != nil {
+			return "", "", err
+		}
+	}
+	return workspaceID, boardID, nil
+}
+
+// resolveView finds the view named by --id or --name among the views that
+// apply to the scope.
+func resolveView(ctx context.Context, cmd *cobra.Command, rt *Runtime, store *state.Store, ns Namespace) (domain.View, error) {
+	if cmd.Flags().Changed("id") {
+		id, _ := cmd.Flags().GetString("id")
+		view, err := rt.ViewService.GetView(ctx, strings.TrimSpace(id))
+		if errors.Is(err, application.ErrViewNotFound) {
+			return domain.View{}, NewNotFound("view", id)
+		}
+		return view, err
+	}
+	name, _ := cmd.Flags().GetString("name")
+	if strings.TrimSpace(name) == "" {
+		return domain.View{}, NewValidation("--id or --name is required")
+	}
+	workspaceID, boardID, err := resolveViewScope(cmd, rt, store, ns)
+	if err != nil {
+		return domain.View{}, err
+	}
+	view, err := rt.ViewService.FindView(ctx, workspaceID, boardID, name)
+	if errors.Is(err, application.ErrViewNotFound) {
+		return domain.View{}, NewNotFound("view", name)
+	}
+	return view, err
+}
+
+func runViewCreate(cmd *cobra.Command, ns Namespace) error {
+	store, err := defaultStateStore()
+	if err != nil {
+		return err
+	}
+	return runViewCreateWithStore(cmd, ns, store)
+}
+
+func runViewCreateWithStore(cmd *cobra.Command, ns Namespace, store *state.Store) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	name, _ := cmd.Flags().GetString("name")
+	if strings.TrimSpace(name) == "" {
+		return NewValidation("--name is required")
+	}
+	var settings domain.ViewSettings
+	settings.Column, _ = cmd.Flags().GetString("column")
+	if cmd.Flags().Changed("priority") {
+		priority, _ := cmd.Flags().GetInt("priority")
+		settings.Priority = &priority
+	}
+	settings.Due, _ = cmd.Flags().GetString("due")
+	settings.Sort, _ = cmd.Flags().GetString("sort")
+	settings.Layout, _ = cmd.Flags().GetString("layout")
+	settings.Search, _ = cmd.Flags().GetString("search")
+	settings.Filter, _ = cmd.Flags().GetString("filter")
+	replace, _ := cmd.Flags().GetBool("replace")
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	if err := GuardBootstrap(rt); err != nil {
+		return err
+	}
+
+	workspaceID, boardID, err := resolveViewScope(cmd, rt, store, ns)
+	if err != nil {
+		return err
+	}
+
+	view, err := rt.ViewService.SaveView(context.Background(), application.SaveViewInput{
+		WorkspaceID: workspaceID,
+		BoardID:     boardID,
+		Name:        name,
+		Settings:    settings,
+		Replace:     replace,
+	})
+	if err != nil {
+		return NewValidation(err.Error())
+	}
+
+	if cfg.JSON {
+		return RenderWrappedJSON(cmd.OutOrStdout(), "view", viewJSON(view))
+	}
+	return RenderKV(cmd.OutOrStdout(), map[string]string{
+		"ID":       view.ID,
+		"Name":     view.Name,
+		"Board ID": viewBoardLabel(view),
+		"Settings": view.Settings.Summary(),
+	})
+}
+
+func runViewList(cmd *cobra.Command, ns Namespace) error {
+	store, err := defaultStateStore()
+	if err != nil {
+		return err
+	}
+	return runViewListWithStore(cmd, ns, store)
+}
+
+func runViewListWithStore(cmd *cobra.Command, ns Namespace, store *state.Store) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	if err := GuardBootstrap(rt); err != nil {
+		return err
+	}
+
+	workspaceID, boardID, err := resolveViewScope(cmd, rt, store, ns)
+	if err != nil {
+		return err
+	}
+	views, err := rt.ViewService.ListViews(context.Background(), workspaceID, boardID)
+	if err != nil {
+		return err
+	}
+
+	if cfg.JSON {
+		items := make([]map[string]interface{}, len(views))
+		for i, v := range views {
+			items[i] = viewJSON(v)
+		}
+		return RenderWrappedListJSON(cmd.OutOrStdout(), "views", items, len(items))
+	}
+
+	headers := []string{"ID", "Name", "Board ID", "Settings"}
+	rows := make([][]string, len(views))
+	for i, v := range views {
+		rows[i] = []string{v.ID, v.Name, viewBoardLabel(v), v.Settings.Summary()}
+	}
+	return RenderTable(cmd.OutOrStdout(), headers, rows)
+}
+
+func runViewDelete(cmd *cobra.Command, ns Namespace) error {
+	store, err := defaultStateStore()
+	if err != nil {
+		return err
+	}
+	return runViewDeleteWithStore(cmd, ns, store)
+}
+
+func runViewDeleteWithStore(cmd *cobra.Command, ns Namespace, store *state.Store) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+	if err := RequireConfirmation(cmd, "yes"); err != nil {
+		return err
+	}
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	if err := GuardBootstrap(rt); err != nil {
+		return err
+	}
+
+	view, err := resolveView(context.Background(), cmd, rt, store, ns)
+	if err != nil {
+		return err
+	}
+	if err := rt.ViewService.DeleteView(context.Background(), view.ID); err != nil {
+		if errors.Is(err, application.ErrViewNotFound) {
+			return NewNotFound("view", view.ID)
+		}
+		return err
+	}
+
+	if cfg.JSON {
+		return RenderDeleteResultJSON(cmd.OutOrStdout(), "view", view.ID, false)
+	}
+	return RenderDeleteResult(cmd.OutOrStdout(), "view", view.ID)
+}
+
+func runViewApply(cmd *cobra.Command, ns Namespace) error {
+	store, err := defaultStateStore()
+	if err != nil {
+		return err
+	}
+	return runViewApplyWithStore(cmd, ns, store)
+}
+
+func runViewApplyWithStore(cmd *cobra.Command, ns Namespace, store *state.Store) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	if err := GuardBootstrap(rt); err != nil {
+		return err
+	}
+
+	tasks, err := applyView(context.Background(), cmd, rt, store, ns)
+	if err != nil {
+		return err
+	}
+
+	if cfg.JSON {
+		items := make([]map[string]string, len(tasks))
+		for i, task := range tasks {
+			items[i] = taskListItemJSON(task)
+		}
+		return RenderWrappedListJSON(cmd.OutOrStdout(), "tasks", items, len(tasks))
+	}
+
+	headers := []string{"ID", "Title", "Status", "Priority"}
+	rows := make([][]string, len(tasks))
+	for i, task := range tasks {
+		status := ""
+		if task.Status != nil {
+			status = *task.Status
+		}
+		rows[i] = []string{task.ID, task.Title, status, strconv.Itoa(task.Priority)}
+	}
+	return RenderTable(cmd.OutOrStdout(), headers, rows)
+}
+
+// applyView resolves the view of `kanji view apply` and lists its tasks:
+// those matching its search text and filter expression, then its column,
+// priority and due filters, in its sort order.
+func applyView(ctx context.Context, cmd *cobra.Command, rt *Runtime, store *state.Store, ns Namespace) ([]domain.Task, error) {
+	view, err := resolveView(ctx, cmd, rt, store, ns)
+	if err != nil {
+		return nil, err
+	}
+	boardID := ""
+	if view.BoardID != nil {
+		boardID = *view.BoardID
+	} else if cmd.Flags().Changed("board-id") || cmd.Flags().Changed("board") {
+		if boardID, _, err = ResolveBoardScope(cmd, rt, store, ns, view.WorkspaceID); err != nil {
+			return nil, err
+		}
+	}
+	settings := view.Settings
+
+	tasks, err := rt.TaskFlow.ListTasks(ctx, application.ListTaskFilters{
+		WorkspaceID: view.WorkspaceID,
+		BoardID:     boardID,
+		Filter:      settings.Filter,
+	})
+	if err != nil {
+		return nil, NewFilterError(err, "view filter")
+	}
+	if settings.Search != "" {
+		hits, err := rt.SearchService.Search(ctx, domain.SearchFilter{
+			WorkspaceID: view.WorkspaceID,
+			BoardID:     boardID,
+			Query:       settings.Search,
+		})
+		if err != nil {
+			return nil, err
+		}
+		matched := make(map[string]bool, len(hits))
+		for _, hit := range hits {
+			matched[hit.Task.ID] = true
+		}
+		tasks = keepTasks(tasks, func(t domain.Task) bool { return matched[t.ID] })
+	}
+	if settings.Column != "" {
+		columnIDs, err := columnIDsByName(ctx, rt, view.WorkspaceID, boardID, settings.Column)
+		if err != nil {
+			return nil, err
+		}
+		tasks = keepTasks(tasks, func(t domain.Task) bool { return t.ColumnID != nil && columnIDs[*t.ColumnID] })
+	}
+	priority := -1
+	if settings.Priority != nil {
+		priority = *settings.Priority
+	}
+	tasks = application.FilterTasks(tasks, "", priority, settings.Due, time.Now())
+	application.SortTasks(tasks, settings.Sort)
+	return tasks, nil
+}
+
+// columnIDsByName returns the IDs of the columns with the given name on a
+// board, or on every board of the workspace when boardID is empty.
+func columnIDsByName(ctx context.Context, rt *Runtime, workspaceID, boardID, name string) (map[string]bool, error) {
+	boardIDs := []string{boardID}
+	if boardID == "" {
+		boards, err := rt.ContextService.ListBoards(ctx, workspaceID)
+		if err != nil {
+			return nil, err
+		}
+		boardIDs = boardIDs[:0]
+		for _, b := range boards {
+			boardIDs = append(boardIDs, b.ID)
+		}
+	}
+	ids := map[string]bool{}
+	for _, id := range boardIDs {
+		columns, err := rt.ContextService.ListColumns(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range columns {
+			if ExactMatch(c.Name, name) {
+				ids[c.ID] = true
+			}
+		}
+	}
+	return ids, nil
+}
+
+func keepTasks(tasks []domain.Task, keep func(domain.Task) bool) []domain.Task {
+	kept := make([]domain.Task, 0, len(tasks))
+	for _, t := range tasks {
+		if keep(t) {
+			kept = append(kept, t)
+		}
+	}
+	return kept
+}
+
+func viewJSON(v domain.View) map[string]interface{} {
+	var boardID interface{}
+	if v.BoardID != nil {
+		boardID = *v.BoardID
+	}
+	return map[string]interface{}{
+		"id":           v.ID,
+		"workspace_id": v.WorkspaceID,
+		"board_id":     boardID,
+		"name":         v.Name,
+		"settings":     v.Settings,
+		"created_at":   v.CreatedAt.Format(time.RFC3339),
+		"updated_at":   v.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// viewBoardLabel is the board ID of a view, or "all" for a workspace view.
+func viewBoardLabel(v domain.View) string {
+	if v.BoardID == nil {
+		return "all"
+	}
+	return *v.BoardID
+}
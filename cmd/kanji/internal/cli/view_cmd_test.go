@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestView(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	dbPath, setup, waitingID := setupFullDoingColumn(t)
+	ns := Namespace{Key: "test-ns", Source: "cwd"}
+
+	create := newVersionedCommand(t, newViewCreateCommand(), dbPath, "--workspace-id", setup.Workspace.ID, "--name", "Todo only", "--column", "todo", "--sort", "title", "--layout", "kanban")
+	require.NoError(t, runViewCreate(create, ns))
+	assert.Contains(t, create.OutOrStdout().(*strings.Builder).String(), "column=todo sort=title layout=kanban")
+
+	dup := newVersionedCommand(t, newViewCreateCommand(), dbPath, "--workspace-id", setup.Workspace.ID, "--name", "TODO ONLY")
+	err := runViewCreate(dup, ns)
+	assert.True(t, errors.Is(err, &SelectorError{Code: "validation"}))
+	assert.Contains(t, err.Error(), "already exists")
+
+	invalid := newVersionedCommand(t, newViewCreateCommand(), dbPath, "--workspace-id", setup.Workspace.ID, "--name", "Bad", "--sort", "random")
+	assert.True(t, errors.Is(runViewCreate(invalid, ns), &SelectorError{Code: "validation"}))
+
+	board := newVersionedCommand(t, newViewCreateCommand(), dbPath, "--workspace-id", setup.Workspace.ID, "--board-id", setup.Board.ID, "--name", "Progress", "--search", "progress")
+	require.NoError(t, runViewCreate(board, ns))
+
+	list := newVersionedCommand(t, newViewListCommand(), dbPath, "--workspace-id", setup.Workspace.ID)
+	list.Flags().Bool("json", true, "")
+	require.NoError(t, runViewList(list, ns))
+	var listed struct {
+		Views []struct {
+			Name     string  `json:"name"`
+			BoardID  *string `json:"board_id"`
+			Settings struct {
+				Column string `json:"column"`
+			} `json:"settings"`
+		} `json:"views"`
+		Count int `json:"count"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(list.OutOrStdout().(*strings.Builder).String()), &listed))
+	require.Equal(t, 2, listed.Count)
+	assert.Equal(t, "Progress", listed.Views[0].Name)
+	require.NotNil(t, listed.Views[0].BoardID)
+	assert.Equal(t, setup.Board.ID, *listed.Views[0].BoardID)
+	assert.Equal(t, "Todo only", listed.Views[1].Name)
+	assert.Nil(t, listed.Views[1].BoardID)
+	assert.Equal(t, "todo", listed.Views[1].Settings.Column)
+
+	apply := newVersionedCommand(t, newViewApplyCommand(), dbPath, "--workspace-id", setup.Workspace.ID, "--name", "todo only")
+	apply.Flags().Bool("json", true, "")
+	require.NoError(t, runViewApply(apply, ns))
+	var applied struct {
+		Tasks []struct {
+			ID string `json:"id"`
+		} `json:"tasks"`
+		Count int `json:"count"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(apply.OutOrStdout().(*strings.Builder).String()), &applied))
+	require.Equal(t, 1, applied.Count)
+	assert.Equal(t, waitingID, applied.Tasks[0].ID)
+
+	search := newVersionedCommand(t, newViewApplyCommand(), dbPath, "--workspace-id", setup.Workspace.ID, "--board-id", setup.Board.ID, "--name", "Progress")
+	require.NoError(t, runViewApply(search, ns))
+	out := search.OutOrStdout().(*strings.Builder).String()
+	assert.Contains(t, out, "In progress")
+	assert.NotContains(t, out, "Waiting")
+
+	unconfirmed := newVersionedCommand(t, newViewDeleteCommand(), dbPath, "--workspace-id", setup.Workspace.ID, "--name", "Todo only")
+	assert.Error(t, runViewDelete(unconfirmed, ns))
+
+	del := newVersionedCommand(t, newViewDeleteCommand(), dbPath, "--workspace-id", setup.Workspace.ID, "--name", "Todo only", "--yes")
+	require.NoError(t, runViewDelete(del, ns))
+
+	missing := newVersionedCommand(t, newViewApplyCommand(), dbPath, "--workspace-id", setup.Workspace.ID, "--name", "Todo only")
+	assert.True(t, errors.Is(runViewApply(missing, ns), &SelectorError{Code: "not_found"}))
+}
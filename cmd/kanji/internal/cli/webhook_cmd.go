@@ -0,0 +1,460 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tiagokriok/kanji/internal/application"
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+func newWebhookCommand() *cobra.Command {
+	w := &cobra.Command{
+		Use:   "webhook",
+		Short: "Outgoing webhooks",
+		Long: `Webhooks POST signed JSON payloads to a URL when tasks or comments of a
+workspace change. Deliveries are queued in the same transaction as the
+change and sent when the command finishes; failed deliveries are retried
+with exponential backoff by later commands or "kanji webhook deliver".
+
+Events: ` + strings.Join(domain.WebhookEvents, ", "),
+	}
+	w.AddCommand(newWebhookAddCommand())
+	w.AddCommand(newWebhookListCommand())
+	w.AddCommand(newWebhookRemoveCommand())
+	w.AddCommand(newWebhookDeliveriesCommand())
+	w.AddCommand(newWebhookDeliverCommand())
+	w.AddCommand(newWebhookRetryCommand())
+	return w
+}
+
+func newWebhookAddCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add",
+		Short: "Register a webhook for a workspace",
+		Long: `Register a webhook for a workspace. The signing secret is generated unless
+--secret is given, and is only shown once. Each request carries an
+X-Kanji-Signature header: "sha256=" followed by the hex HMAC-SHA256 of the
+body keyed with the secret.`,
+		Example: `  kanji webhook add --url https://example.com/hooks/kanji --events task.created,task.moved
+  kanji webhook add --workspace "App" --url http://localhost:9000 --events comment.created --secret s3cret`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns, err := ResolveNamespace()
+			if err != nil {
+				return err
+			}
+			return runWebhookAdd(cmd, ns)
+		},
+	}
+	cmd.Flags().String("url", "", "URL to POST events to")
+	cmd.Flags().StringSlice("events", nil, "comma-separated events to subscribe to")
+	cmd.Flags().String("secret", "", "signing secret (generated when omitted)")
+	cmd.Flags().String("workspace-id", "", "workspace ID")
+	cmd.Flags().String("workspace", "", "workspace name")
+	return cmd
+}
+
+func newWebhookListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List webhooks",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns, err := ResolveNamespace()
+			if err != nil {
+				return err
+			}
+			return runWebhookList(cmd, ns)
+		},
+	}
+	cmd.Flags().String("workspace-id", "", "only list webhooks of this workspace")
+	cmd.Flags().String("workspace", "", "only list webhooks of this workspace (by name)")
+	return cmd
+}
+
+func newWebhookRemoveCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove",
+		Short: "Remove a webhook and its delivery log",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns, err := ResolveNamespace()
+			if err != nil {
+				return err
+			}
+			return runWebhookRemove(cmd, ns)
+		},
+	}
+	cmd.Flags().String("id", "", "webhook ID")
+	cmd.Flags().Bool("yes", false, "confirm removal")
+	return cmd
+}
+
+func newWebhookDeliveriesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deliveries",
+		Short: "Show the delivery log, newest first",
+		Example: `  kanji webhook deliveries
+  kanji webhook deliveries --webhook-id <id> --state failed`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns, err := ResolveNamespace()
+			if err != nil {
+				return err
+			}
+			return runWebhookDeliveries(cmd, ns)
+		},
+	}
+	cmd.Flags().String("webhook-id", "", "only show deliveries of this webhook")
+	cmd.Flags().String("state", "", "filter by state: delivered, pending, retrying, failed")
+	cmd.Flags().Int("limit", 50, "maximum number of deliveries to show (0 for all)")
+	return cmd
+}
+
+func newWebhookDeliverCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "deliver",
+		Short: "Send queued deliveries that are due",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns, err := ResolveNamespace()
+			if err != nil {
+				return err
+			}
+			return runWebhookDeliver(cmd, ns)
+		},
+	}
+}
+
+func newWebhookRetryCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "retry",
+		Short: "Reset attempts so a delivery is sent on the next run",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ns, err := ResolveNamespace()
+			if err != nil {
+				return err
+			}
+			return runWebhookRetry(cmd, ns)
+		},
+	}
+	cmd.Flags().String("id", "", "delivery ID")
+	return cmd
+}
+
+func runWebhookAdd(cmd *cobra.Command, ns Namespace) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	rawURL, _ := cmd.Flags().GetString("url")
+	if strings.TrimSpace(rawURL) == "" {
+		return NewValidation("--url is required")
+	}
+	events, _ := cmd.Flags().GetStringSlice("events")
+	if len(events) == 0 {
+		return NewValidation("--events is required; choose from " + strings.Join(domain.WebhookEvents, ", "))
+	}
+	secret, _ := cmd.Flags().GetString("secret")
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	if err := GuardBootstrap(rt); err != nil {
+		return err
+	}
+
+	store, err := defaultStateStore()
+	if err != nil {
+		return err
+	}
+	workspaceID, _, err := ResolveWorkspaceScope(cmd, rt, store, ns)
+	if err != nil {
+		return err
+	}
+
+	webhook, plainSecret, err := rt.WebhookService.AddWebhook(context.Background(), application.AddWebhookInput{
+		WorkspaceID: workspaceID,
+		URL:         rawURL,
+		Events:      events,
+		Secret:      secret,
+	})
+	if err != nil {
+		return NewValidation(err.Error())
+	}
+
+	if cfg.JSON {
+		payload := webhookJSON(webhook)
+		payload["secret"] = plainSecret
+		return RenderWrappedJSON(cmd.OutOrStdout(), "webhook", payload)
+	}
+	return RenderKV(cmd.OutOrStdout(), map[string]string{
+		"ID":        webhook.ID,
+		"Workspace": webhook.WorkspaceID,
+		"URL":       webhook.URL,
+		"Events":    strings.Join(webhook.Events, ","),
+		"Secret":    plainSecret,
+	})
+}
+
+func runWebhookList(cmd *cobra.Command, ns Namespace) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	if err := GuardBootstrap(rt); err != nil {
+		return err
+	}
+
+	var workspaceID string
+	if cmd.Flags().Changed("workspace-id") || cmd.Flags().Changed("workspace") {
+		workspaceID, _, err = ResolveWorkspaceScope(cmd, rt, nil, ns)
+		if err != nil {
+			return err
+		}
+	}
+
+	webhooks, err := rt.WebhookService.ListWebhooks(context.Background(), workspaceID)
+	if err != nil {
+		return err
+	}
+
+	if cfg.JSON {
+		items := make([]map[string]interface{}, len(webhooks))
+		for i, w := range webhooks {
+			items[i] = webhookJSON(w)
+		}
+		return RenderWrappedListJSON(cmd.OutOrStdout(), "webhooks", items, len(items))
+	}
+
+	headers := []string{"ID", "Workspace ID", "URL", "Events"}
+	rows := make([][]string, len(webhooks))
+	for i, w := range webhooks {
+		rows[i] = []string{w.ID, w.WorkspaceID, w.URL, strings.Join(w.Events, ",")}
+	}
+	return RenderTable(cmd.OutOrStdout(), headers, rows)
+}
+
+func webhookJSON(w domain.Webhook) map[string]interface{} {
+	return map[string]interface{}{
+		"id":           w.ID,
+		"workspace_id": w.WorkspaceID,
+		"url":          w.URL,
+		"events":       w.Events,
+		"created_at":   w.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func runWebhookRemove(cmd *cobra.Command, ns Namespace) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	id, _ := cmd.Flags().GetString("id")
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return NewValidation("--id is required")
+	}
+	if err := RequireConfirmation(cmd, "yes"); err != nil {
+		return err
+	}
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	if err := GuardBootstrap(rt); err != nil {
+		return err
+	}
+
+	if err := rt.WebhookService.RemoveWebhook(context.Background(), id); err != nil {
+		if errors.Is(err, application.ErrWebhookNotFound) {
+			return NewNotFound("webhook", id)
+		}
+		return err
+	}
+
+	if cfg.JSON {
+		return RenderDeleteResultJSON(cmd.OutOrStdout(), "webhook", id, false)
+	}
+	return RenderDeleteResult(cmd.OutOrStdout(), "webhook", id)
+}
+
+func runWebhookDeliveries(cmd *cobra.Command, ns Namespace) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	if err := GuardBootstrap(rt); err != nil {
+		return err
+	}
+
+	webhookID, _ := cmd.Flags().GetString("webhook-id")
+	state, _ := cmd.Flags().GetString("state")
+	limit, _ := cmd.Flags().GetInt("limit")
+	if limit < 0 {
+		return NewValidation("--limit must not be negative")
+	}
+
+	deliveries, err := rt.WebhookService.ListDeliveries(context.Background(), webhookID, state, limit)
+	if err != nil {
+		if errors.Is(err, application.ErrWebhookNotFound) {
+			return NewNotFound("webhook", webhookID)
+		}
+		return NewValidation(err.Error())
+	}
+
+	if cfg.JSON {
+		items := make([]map[string]interface{}, len(deliveries))
+		for i, d := range deliveries {
+			items[i] = webhookDeliveryJSON(d)
+		}
+		return RenderWrappedListJSON(cmd.OutOrStdout(), "deliveries", items, len(items))
+	}
+
+	headers := []string{"ID", "Webhook ID", "Event", "State", "Attempts", "Status", "Created", "Last Error"}
+	rows := make([][]string, len(deliveries))
+	for i, d := range deliveries {
+		status := ""
+		if d.ResponseStatus != nil {
+			status = strconv.Itoa(*d.ResponseStatus)
+		}
+		lastError := ""
+		if d.LastError != nil {
+			lastError = strings.ReplaceAll(*d.LastError, "\n", " ")
+			if len(lastError) > 50 {
+				lastError = lastError[:47] + "..."
+			}
+		}
+		rows[i] = []string{
+			d.ID,
+			d.WebhookID,
+			d.Event,
+			application.WebhookDeliveryState(d),
+			strconv.Itoa(d.Attempts),
+			status,
+			d.CreatedAt.Format(time.RFC3339),
+			lastError,
+		}
+	}
+	return RenderTable(cmd.OutOrStdout(), headers, rows)
+}
+
+func webhookDeliveryJSON(d domain.WebhookDelivery) map[string]interface{} {
+	payload := map[string]interface{}{
+		"id":         d.ID,
+		"webhook_id": d.WebhookID,
+		"event":      d.Event,
+		"state":      application.WebhookDeliveryState(d),
+		"attempts":   d.Attempts,
+		"created_at": d.CreatedAt.Format(time.RFC3339),
+	}
+	if d.ResponseStatus != nil {
+		payload["response_status"] = *d.ResponseStatus
+	}
+	if d.LastError != nil {
+		payload["last_error"] = *d.LastError
+	}
+	if d.NextAttemptAt != nil {
+		payload["next_attempt_at"] = d.NextAttemptAt.Format(time.RFC3339)
+	}
+	if d.DeliveredAt != nil {
+		payload["delivered_at"] = d.DeliveredAt.Format(time.RFC3339)
+	}
+	return payload
+}
+
+func runWebhookDeliver(cmd *cobra.Command, ns Namespace) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	if err := GuardBootstrap(rt); err != nil {
+		return err
+	}
+
+	result, err := rt.WebhookService.Deliver(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if cfg.JSON {
+		return RenderWrappedJSON(cmd.OutOrStdout(), "webhook_deliveries", map[string]interface{}{
+			"delivered": result.Delivered,
+			"failed":    result.Failed,
+			"skipped":   result.Skipped,
+		})
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Delivered: %d\nFailed:    %d\nSkipped:   %d\n", result.Delivered, result.Failed, result.Skipped)
+	return nil
+}
+
+func runWebhookRetry(cmd *cobra.Command, ns Namespace) error {
+	cfg, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	id, _ := cmd.Flags().GetString("id")
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return NewValidation("--id is required")
+	}
+
+	rt, err := NewRuntime(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer rt.Close()
+
+	if err := GuardBootstrap(rt); err != nil {
+		return err
+	}
+
+	count, err := rt.WebhookService.Retry(context.Background(), id)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return NewNotFound("undelivered webhook delivery", id)
+	}
+
+	if cfg.JSON {
+		return RenderWrappedJSON(cmd.OutOrStdout(), "webhook_delivery", map[string]interface{}{
+			"id":      id,
+			"retried": true,
+		})
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Delivery reset: %s\n", id)
+	return nil
+}
@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tiagokriok/kanji/internal/application"
+	"github.com/tiagokriok/kanji/internal/infrastructure/webhooks"
+)
+
+func newWebhookTestCommand(dbPath string, args ...string) *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("db-path", "", "")
+	cmd.Flags().Bool("json", false, "")
+	cmd.Flags().String("url", "", "")
+	cmd.Flags().StringSlice("events", nil, "")
+	cmd.Flags().String("secret", "", "")
+	cmd.Flags().String("workspace-id", "", "")
+	cmd.Flags().String("workspace", "", "")
+	cmd.Flags().String("webhook-id", "", "")
+	cmd.Flags().String("state", "", "")
+	cmd.Flags().Int("limit", 50, "")
+	_ = cmd.Flags().Set("db-path", dbPath)
+	_ = cmd.ParseFlags(args)
+	return cmd
+}
+
+type webhookReceiver struct {
+	mu       sync.Mutex
+	bodies   [][]byte
+	headers  []http.Header
+	response int
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	r.bodies = append(r.bodies, body)
+	r.headers = append(r.headers, req.Header.Clone())
+	r.mu.Unlock()
+	w.WriteHeader(r.response)
+}
+
+func TestWebhook_DeliversSignedPayloads(t *testing.T) {
+	dbPath := setupSyncTestDB(t)
+	receiver := &webhookReceiver{response: http.StatusNoContent}
+	srv := httptest.NewServer(receiver)
+	defer srv.Close()
+
+	ctx := context.Background()
+	rt, err := NewRuntime(ctx, RuntimeConfig{DBPath: dbPath})
+	require.NoError(t, err)
+	setup, err := rt.BootstrapService.EnsureDefaultSetup(ctx)
+	require.NoError(t, err)
+	require.NoError(t, rt.Close())
+
+	var out bytes.Buffer
+	cmd := newWebhookTestCommand(dbPath, "--json", "--url", srv.URL, "--events", "task.created,task.moved",
+		"--secret", "s3cret", "--workspace-id", setup.Workspace.ID)
+	cmd.SetOut(&out)
+	require.NoError(t, runWebhookAdd(cmd, Namespace{}))
+	assert.Contains(t, out.String(), `"secret": "s3cret"`)
+
+	rt, err = NewRuntime(ctx, RuntimeConfig{DBPath: dbPath})
+	require.NoError(t, err)
+	task, err := rt.TaskService.CreateTask(ctx, application.CreateTaskInput{
+		ProviderID:  setup.Provider.ID,
+		WorkspaceID: setup.Workspace.ID,
+		BoardID:     &setup.Board.ID,
+		ColumnID:    &setup.Columns[0].ID,
+		Title:       "Hooked",
+	})
+	require.NoError(t, err)
+	title := "Not subscribed"
+	require.NoError(t, rt.TaskService.UpdateTask(ctx, task.ID, application.UpdateTaskInput{Title: &title}))
+	// Close sends the deliveries queued by the command.
+	require.NoError(t, rt.Close())
+
+	require.Len(t, receiver.bodies, 1)
+	headers := receiver.headers[0]
+	assert.Equal(t, "task.created", headers.Get(webhooks.HeaderEvent))
+	assert.True(t, webhooks.Verify("s3cret", receiver.bodies[0], headers.Get(webhooks.HeaderSignature)))
+
+	var payload struct {
+		ID    string `json:"id"`
+		Event string `json:"event"`
+		Data  struct {
+			ID    string `json:"id"`
+			Title string `json:"title"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(receiver.bodies[0], &payload))
+	assert.Equal(t, headers.Get(webhooks.HeaderDelivery), payload.ID)
+	assert.Equal(t, task.ID, payload.Data.ID)
+	assert.Equal(t, "Hooked", payload.Data.Title)
+
+	out.Reset()
+	cmd = newWebhookTestCommand(dbPath, "--json")
+	cmd.SetOut(&out)
+	require.NoError(t, runWebhookDeliveries(cmd, Namespace{}))
+	var log struct {
+		Deliveries []map[string]interface{} `json:"deliveries"`
+		Count      int                      `json:"count"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &log))
+	require.Equal(t, 1, log.Count)
+	assert.Equal(t, "delivered", log.Deliveries[0]["state"])
+	assert.Equal(t, float64(http.StatusNoContent), log.Deliveries[0]["response_status"])
+}
+
+func TestWebhook_FailedDeliveryIsLogged(t *testing.T) {
+	dbPath := setupSyncTestDB(t)
+	receiver := &webhookReceiver{response: http.StatusBadGateway}
+	srv := httptest.NewServer(receiver)
+	defer srv.Close()
+
+	ctx := context.Background()
+	rt, err := NewRuntime(ctx, RuntimeConfig{DBPath: dbPath})
+	require.NoError(t, err)
+	setup, err := rt.BootstrapService.EnsureDefaultSetup(ctx)
+	require.NoError(t, err)
+	_, _, err = rt.WebhookService.AddWebhook(ctx, application.AddWebhookInput{
+		WorkspaceID: setup.Workspace.ID,
+		URL:         srv.URL,
+		Events:      []string{"comment.created"},
+	})
+	require.NoError(t, err)
+	tasks, err := rt.TaskFlow.ListTasks(ctx, application.ListTaskFilters{WorkspaceID: setup.Workspace.ID})
+	require.NoError(t, err)
+	_, err = rt.CommentService.AddComment(ctx, application.AddCommentInput{
+		TaskID:     tasks[0].ID,
+		ProviderID: setup.Provider.ID,
+		BodyMD:     "ping",
+	})
+	require.NoError(t, err)
+	require.NoError(t, rt.Close())
+	require.Len(t, receiver.bodies, 1)
+
+	var out bytes.Buffer
+	cmd := newWebhookTestCommand(dbPath, "--state", "retrying")
+	cmd.SetOut(&out)
+	require.NoError(t, runWebhookDeliveries(cmd, Namespace{}))
+	assert.Contains(t, out.String(), "comment.created")
+	assert.Contains(t, out.String(), "502")
+
+	// The retry is not due yet, so closing again sends nothing.
+	rt, err = NewRuntime(ctx, RuntimeConfig{DBPath: dbPath})
+	require.NoError(t, err)
+	require.NoError(t, rt.Close())
+	assert.Len(t, receiver.bodies, 1)
+}
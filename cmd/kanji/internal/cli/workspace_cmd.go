@@ -36,6 +36,8 @@ func newWorkspaceCreateCommand() *cobra.Command {
 	}
 	cmd.Flags().String("name", "", "workspace name")
 	cmd.Flags().Bool("set-context", false, "set context to the new workspace")
+	cmd.Flags().String("provider-id", "", "provider ID (default: the local provider)")
+	cmd.Flags().String("provider", "", "provider name (default: the local provider)")
 	return cmd
 }
 
@@ -120,8 +122,16 @@ func runWorkspaceCreateWithStore(cmd *cobra.Command, ns Namespace, store *state.
 	if err != nil {
 		return err
 	}
+	providerID := setup.Provider.ID
+	if cmd.Flags().Changed("provider-id") || cmd.Flags().Changed("provider") {
+		provider, err := resolveProvider(ctx, cmd, rt)
+		if err != nil {
+			return err
+		}
+		providerID = provider.ID
+	}
 
-	workspace, board, err := rt.ContextService.CreateWorkspace(ctx, setup.Provider.ID, name)
+	workspace, board, err := rt.ContextService.CreateWorkspace(ctx, providerID, name)
 	if err != nil {
 		return err
 	}
@@ -138,17 +148,19 @@ func runWorkspaceCreateWithStore(cmd *cobra.Command, ns Namespace, store *state.
 
 	if cfg.JSON {
 		return RenderWriteResultJSON(cmd.OutOrStdout(), "workspace", map[string]interface{}{
-			"id":       workspace.ID,
-			"name":     workspace.Name,
-			"board":    board.Name,
-			"board_id": board.ID,
+			"id":          workspace.ID,
+			"name":        workspace.Name,
+			"provider_id": workspace.ProviderID,
+			"board":       board.Name,
+			"board_id":    board.ID,
 		})
 	}
 
 	return RenderWriteResult(cmd.OutOrStdout(), "workspace", workspace.ID, map[string]string{
-		"Name":     workspace.Name,
-		"Board":    board.Name,
-		"Board ID": board.ID,
+		"Name":        workspace.Name,
+		"Provider ID": workspace.ProviderID,
+		"Board":       board.Name,
+		"Board ID":    board.ID,
 	})
 }
 
@@ -357,10 +369,7 @@ func runWorkspaceGet(cmd *cobra.Command, ns Namespace) error {
 	}
 
 	if cfg.JSON {
-		return RenderWrappedJSON(cmd.OutOrStdout(), "workspace", map[string]string{
-			"id":   workspace.ID,
-			"name": workspace.Name,
-		})
+		return RenderWrappedJSON(cmd.OutOrStdout(), "workspace", workspaceJSON(workspace))
 	}
 
 	return RenderKV(cmd.OutOrStdout(), map[string]string{
@@ -393,10 +402,7 @@ func runWorkspaceList(cmd *cobra.Command, ns Namespace) error {
 	if cfg.JSON {
 		items := make([]map[string]string, len(workspaces))
 		for i, ws := range workspaces {
-			items[i] = map[string]string{
-				"id":   ws.ID,
-				"name": ws.Name,
-			}
+			items[i] = workspaceJSON(ws)
 		}
 		return RenderWrappedListJSON(cmd.OutOrStdout(), "workspaces", items, len(workspaces))
 	}
@@ -0,0 +1,161 @@
+// Package app wires the kanji application services over a database. The CLI
+// and the embeddable pkg/kanji client both build on it, so a change made
+// through either runs into the same hooks, webhooks and WIP limits.
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/tiagokriok/kanji/internal/application"
+	"github.com/tiagokriok/kanji/internal/infrastructure/db"
+	"github.com/tiagokriok/kanji/internal/infrastructure/hooks"
+	"github.com/tiagokriok/kanji/internal/infrastructure/providers"
+	"github.com/tiagokriok/kanji/internal/infrastructure/repositories"
+	"github.com/tiagokriok/kanji/internal/infrastructure/secrets"
+	"github.com/tiagokriok/kanji/internal/infrastructure/store"
+	"github.com/tiagokriok/kanji/internal/infrastructure/templates"
+	"github.com/tiagokriok/kanji/internal/infrastructure/webhooks"
+)
+
+// WebhookFlushTimeout bounds how long Close spends sending webhooks.
+const WebhookFlushTimeout = 10 * time.Second
+
+// Options adjusts how the services are wired.
+type Options struct {
+	// DBPath is the path of the database. Hooks get it as KANJI_DB_PATH so
+	// they can call kanji against it.
+	DBPath string
+	// Journal, when set, journals task and comment changes under that
+	// namespace, where `kanji undo` reverts them.
+	Journal string
+	// HookWarnings receives failures of hooks that run after a change. Nil
+	// discards them.
+	HookWarnings io.Writer
+}
+
+// App holds an open database and the application services wired over it.
+type App struct {
+	DB                     *db.SQLiteAdapter
+	Store                  store.Store
+	BootstrapService       *application.BootstrapService
+	TaskService            *application.TaskService
+	TaskFlow               *application.TaskFlow
+	TaskCopyService        *application.TaskCopyService
+	CommentService         *application.CommentService
+	HistoryService         *application.HistoryService
+	UndoService            *application.UndoService
+	SearchService          *application.SearchService
+	ContextService         *application.ContextService
+	BoardDeleteService     *application.BoardDeleteService
+	ColumnDeleteService    *application.ColumnDeleteService
+	WorkspaceDeleteService *application.WorkspaceDeleteService
+	SyncEngine             *application.SyncEngine
+	ProviderService        *application.ProviderService
+	WebhookService         *application.WebhookService
+	ViewService            *application.ViewService
+	LabelService           *application.LabelService
+	TemplateService        *application.TemplateService
+	Credentials            *secrets.Cipher
+	Hooks                  *hooks.Runner
+}
+
+// Open opens the database at opts.DBPath, runs pending migrations and wires
+// the services over it.
+func Open(ctx context.Context, opts Options) (*App, error) {
+	adapter, err := db.NewSQLiteAdapter(opts.DBPath)
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+	if err := db.RunMigrations(ctx, adapter.Raw()); err != nil {
+		_ = adapter.Close()
+		return nil, fmt.Errorf("run migrations: %w", err)
+	}
+	a, err := New(adapter, opts)
+	if err != nil {
+		_ = adapter.Close()
+		return nil, err
+	}
+	return a, nil
+}
+
+// New wires the services over an open, migrated database. The App takes
+// over the adapter: Close closes it.
+func New(adapter *db.SQLiteAdapter, opts Options) (*App, error) {
+	credentials, err := secrets.FromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("resolve credential key: %w", err)
+	}
+	hooksDir, err := hooks.DefaultDir()
+	if err != nil {
+		return nil, fmt.Errorf("resolve hooks dir: %w", err)
+	}
+	templatesDir, err := templates.DefaultDir()
+	if err != nil {
+		return nil, fmt.Errorf("resolve templates dir: %w", err)
+	}
+
+	s := store.New(adapter)
+	setupRepo := repositories.NewSetupRepository(s)
+	taskRepo := repositories.NewTaskRepository(s)
+	commentRepo := repositories.NewCommentRepository(s)
+	operationRepo := repositories.NewOperationRepository(s)
+	labelRepo := repositories.NewLabelRepository(s)
+	actor := repositories.DefaultActor()
+	taskRepo.SetActor(actor)
+	commentRepo.SetActor(actor)
+	operationRepo.SetActor(actor)
+	labelRepo.SetActor(actor)
+	taskRepo.SetJournal(opts.Journal)
+	commentRepo.SetJournal(opts.Journal)
+	labelRepo.SetJournal(opts.Journal)
+
+	providerService := application.NewProviderService(setupRepo, providers.DefaultRegistry(), credentials)
+	hookRunner := hooks.NewRunner(hooksDir, []string{"KANJI_DB_PATH=" + opts.DBPath}, opts.HookWarnings)
+	taskHooks := application.NewHooks(hookRunner, taskRepo)
+
+	a := &App{
+		DB:                     adapter,
+		Store:                  s,
+		BootstrapService:       application.NewBootstrapService(setupRepo),
+		TaskService:            application.NewTaskService(taskRepo),
+		TaskFlow:               application.NewTaskFlow(taskRepo),
+		CommentService:         application.NewCommentService(commentRepo),
+		HistoryService:         application.NewHistoryService(repositories.NewTaskEventRepository(s)),
+		UndoService:            application.NewUndoService(operationRepo),
+		SearchService:          application.NewSearchService(repositories.NewSearchRepository(s)),
+		ContextService:         application.NewContextService(setupRepo),
+		BoardDeleteService:     application.NewBoardDeleteService(setupRepo, taskRepo, commentRepo),
+		WorkspaceDeleteService: application.NewWorkspaceDeleteService(setupRepo, taskRepo, commentRepo),
+		SyncEngine:             application.NewSyncEngine(repositories.NewSyncQueueRepository(s), setupRepo, providerService.Client),
+		ProviderService:        providerService,
+		WebhookService:         application.NewWebhookService(repositories.NewWebhookRepository(s), webhooks.NewSender(nil), credentials),
+		ViewService:            application.NewViewService(repositories.NewViewRepository(s)),
+		LabelService:           application.NewLabelService(labelRepo),
+		Credentials:            credentials,
+		Hooks:                  hookRunner,
+	}
+	a.TaskService.SetHooks(taskHooks)
+	a.TaskFlow.SetHooks(taskHooks)
+	a.CommentService.SetHooks(taskHooks)
+	a.UndoService.SetHooks(taskHooks)
+	a.ColumnDeleteService = application.NewColumnDeleteService(setupRepo, taskRepo, a.TaskFlow)
+	a.TaskCopyService = application.NewTaskCopyService(setupRepo, a.TaskService, a.CommentService)
+	a.TemplateService = application.NewTemplateService(templates.NewStore(templatesDir), setupRepo, taskRepo, a.TaskFlow, a.LabelService)
+	a.TemplateService.SetHooks(taskHooks)
+	return a, nil
+}
+
+// Close sends queued webhook deliveries, then closes the database. It only
+// sends when something was written through the App, so reads never wait on
+// webhook receivers. Deliveries that fail stay queued for a later run.
+func (a *App) Close() error {
+	if a.Store.Committed() {
+		ctx, cancel := context.WithTimeout(context.Background(), WebhookFlushTimeout)
+		_, _ = a.WebhookService.Deliver(ctx)
+		cancel()
+	}
+	return a.DB.Close()
+}
@@ -53,8 +53,10 @@ func (s *ColumnDeleteService) ReassignTasks(ctx context.Context, workspaceID, fr
 	if err != nil {
 		return err
 	}
+	// Reassignment is part of removing a column, so it must not stop halfway
+	// because the destination is full.
 	for _, task := range tasks {
-		if err := s.taskFlow.MoveTask(ctx, task.ID, &toColumnID, &toStatus, 0); err != nil {
+		if err := s.taskFlow.ForceMoveTask(ctx, task.ID, &toColumnID, &toStatus, 0); err != nil {
 			return err
 		}
 	}
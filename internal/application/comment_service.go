@@ -19,13 +19,19 @@ type AddCommentInput struct {
 }
 
 type CommentService struct {
-	repo domain.CommentRepository
+	repo  domain.CommentRepository
+	hooks *Hooks
 }
 
 func NewCommentService(repo domain.CommentRepository) *CommentService {
 	return &CommentService{repo: repo}
 }
 
+// SetHooks makes the service run local hooks around comment changes.
+func (s *CommentService) SetHooks(hooks *Hooks) {
+	s.hooks = hooks
+}
+
 func (s *CommentService) AddComment(ctx context.Context, input AddCommentInput) (domain.Comment, error) {
 	if strings.TrimSpace(input.TaskID) == "" {
 		return domain.Comment{}, errors.New("task id is required")
@@ -45,10 +51,27 @@ func (s *CommentService) AddComment(ctx context.Context, input AddCommentInput)
 		BodyMD:     input.BodyMD,
 		Author:     input.Author,
 		CreatedAt:  now,
+		Version:    1,
+	}
+	if s.hooks == nil {
+		if err := s.repo.Create(ctx, comment); err != nil {
+			return domain.Comment{}, err
+		}
+		return comment, nil
+	}
+
+	event, err := s.hooks.commentEvent(ctx, domain.HookPreCommentCreate, comment)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	if err := s.hooks.pre(ctx, event); err != nil {
+		return domain.Comment{}, err
 	}
 	if err := s.repo.Create(ctx, comment); err != nil {
 		return domain.Comment{}, err
 	}
+	event.Hook = domain.HookCommentCreated
+	s.hooks.post(ctx, event)
 	return comment, nil
 }
 
@@ -59,19 +82,64 @@ func (s *CommentService) ListComments(ctx context.Context, taskID string) ([]dom
 	return s.repo.ListByTask(ctx, taskID)
 }
 
-func (s *CommentService) UpdateComment(ctx context.Context, commentID string, bodyMD string) error {
+// UpdateComment replaces a comment's body. A non-nil ifVersion makes it fail
+// with a *domain.VersionConflictError unless the comment is still at that
+// version.
+func (s *CommentService) UpdateComment(ctx context.Context, commentID string, bodyMD string, ifVersion *int) error {
 	if strings.TrimSpace(commentID) == "" {
 		return errors.New("comment id is required")
 	}
 	if strings.TrimSpace(bodyMD) == "" {
 		return errors.New("comment body is required")
 	}
-	return s.repo.Update(ctx, commentID, bodyMD)
+	if s.hooks == nil {
+		return s.repo.Update(ctx, commentID, bodyMD, ifVersion)
+	}
+
+	comment, err := s.repo.GetByID(ctx, commentID)
+	if err != nil {
+		return err
+	}
+	event, err := s.hooks.commentEvent(ctx, domain.HookPreCommentUpdate, comment)
+	if err != nil {
+		return err
+	}
+	event.Changes = map[string]any{"body": bodyMD}
+	if err := s.hooks.pre(ctx, event); err != nil {
+		return err
+	}
+	if err := s.repo.Update(ctx, commentID, bodyMD, ifVersion); err != nil {
+		return err
+	}
+	event.Comment.BodyMD = bodyMD
+	event.Hook, event.Changes = domain.HookCommentUpdated, nil
+	s.hooks.post(ctx, event)
+	return nil
 }
 
 func (s *CommentService) DeleteComment(ctx context.Context, commentID string) error {
 	if strings.TrimSpace(commentID) == "" {
 		return errors.New("comment id is required")
 	}
-	return s.repo.Delete(ctx, commentID)
+	if s.hooks == nil {
+		return s.repo.Delete(ctx, commentID)
+	}
+
+	comment, err := s.repo.GetByID(ctx, commentID)
+	if err != nil {
+		return err
+	}
+	event, err := s.hooks.commentEvent(ctx, domain.HookPreCommentDelete, comment)
+	if err != nil {
+		return err
+	}
+	if err := s.hooks.pre(ctx, event); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, commentID); err != nil {
+		return err
+	}
+	event.Hook = domain.HookCommentDeleted
+	s.hooks.post(ctx, event)
+	return nil
 }
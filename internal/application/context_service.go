@@ -17,6 +17,8 @@ var hexColorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
 type CreateBoardColumnInput struct {
 	Name  string
 	Color string
+	// WIPLimit caps the tasks of the column; nil means no limit.
+	WIPLimit *int
 }
 
 type ContextService struct {
@@ -107,13 +109,26 @@ func (s *ContextService) CreateBoardWithColumns(
 		Name:        name,
 		ViewDefault: "list",
 	}
+	boardColumns, err := newBoardColumns(board.ID, columns)
+	if err != nil {
+		return domain.Board{}, err
+	}
 	if err := s.repo.CreateBoard(ctx, board); err != nil {
 		return domain.Board{}, err
 	}
+	for _, c := range boardColumns {
+		if err := s.repo.CreateColumn(ctx, c); err != nil {
+			return domain.Board{}, err
+		}
+	}
+	return board, nil
+}
 
-	var createdColumns []domain.Column
-	position := 1
-	created := 0
+// newBoardColumns turns the columns of a new board into columns in the given
+// order, skipping blank names and giving the next default color to columns
+// without one.
+func newBoardColumns(boardID string, columns []CreateBoardColumnInput) ([]domain.Column, error) {
+	var created []domain.Column
 	for i, input := range columns {
 		columnName := strings.TrimSpace(input.Name)
 		if columnName == "" {
@@ -121,32 +136,24 @@ func (s *ContextService) CreateBoardWithColumns(
 		}
 		color := strings.ToUpper(strings.TrimSpace(input.Color))
 		if color == "" {
-			color = NextDefaultColor(createdColumns)
+			color = NextDefaultColor(created)
 		}
 		if !hexColorPattern.MatchString(color) {
-			return domain.Board{}, fmt.Errorf("column %d color must be HEX (#RRGGBB)", i+1)
+			return nil, fmt.Errorf("column %d color must be HEX (#RRGGBB)", i+1)
 		}
-
-		c := domain.Column{
+		created = append(created, domain.Column{
 			ID:       uuid.NewString(),
-			BoardID:  board.ID,
+			BoardID:  boardID,
 			Name:     columnName,
 			Color:    color,
-			Position: position,
-		}
-		if err := s.repo.CreateColumn(ctx, c); err != nil {
-			return domain.Board{}, err
-		}
-		createdColumns = append(createdColumns, c)
-		position++
-		created++
+			Position: len(created) + 1,
+			WIPLimit: input.WIPLimit,
+		})
 	}
-
-	if created == 0 {
-		return domain.Board{}, errors.New("at least one column name is required")
+	if len(created) == 0 {
+		return nil, errors.New("at least one column name is required")
 	}
-
-	return board, nil
+	return created, nil
 }
 
 func (s *ContextService) RenameBoard(ctx context.Context, boardID, name string) error {
@@ -9,6 +9,7 @@ import (
 )
 
 type fakeSetupRepo struct {
+	providers         []domain.Provider
 	workspaces        []domain.Workspace
 	boards            []domain.Board
 	columns           []domain.Column
@@ -17,9 +18,27 @@ type fakeSetupRepo struct {
 }
 
 func (r *fakeSetupRepo) ListProviders(ctx context.Context) ([]domain.Provider, error) {
-	return nil, nil
+	return r.providers, nil
 }
 func (r *fakeSetupRepo) CreateProvider(ctx context.Context, provider domain.Provider) error {
+	r.providers = append(r.providers, provider)
+	return nil
+}
+func (r *fakeSetupRepo) SetProviderAuth(ctx context.Context, auth map[string]*string) error {
+	for i, p := range r.providers {
+		if value, ok := auth[p.ID]; ok {
+			r.providers[i].AuthJSON = value
+		}
+	}
+	return nil
+}
+func (r *fakeSetupRepo) DeleteProvider(ctx context.Context, providerID string) error {
+	for i, p := range r.providers {
+		if p.ID == providerID {
+			r.providers = append(r.providers[:i], r.providers[i+1:]...)
+			return nil
+		}
+	}
 	return nil
 }
 func (r *fakeSetupRepo) ListWorkspaces(ctx context.Context) ([]domain.Workspace, error) {
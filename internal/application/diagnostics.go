@@ -162,3 +162,46 @@ func FindDanglingContextRefs(ctx context.Context, setupRepo domain.SetupReposito
 
 	return dangling, nil
 }
+
+// Credential problems reported by FindCredentialIssues.
+const (
+	CredentialUnencrypted   = "unencrypted"
+	CredentialUndecryptable = "undecryptable"
+)
+
+// CredentialIssue describes a provider whose stored credentials are not
+// encrypted or cannot be decrypted with the current key.
+type CredentialIssue struct {
+	ProviderID   string
+	ProviderName string
+	Problem      string
+	Err          error
+}
+
+// FindCredentialIssues checks the stored credentials of every provider
+// against cipher.
+func FindCredentialIssues(ctx context.Context, repo domain.SetupRepository, cipher CredentialCipher) ([]CredentialIssue, error) {
+	providers, err := repo.ListProviders(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []CredentialIssue
+	for _, p := range providers {
+		if p.AuthJSON == nil {
+			continue
+		}
+		issue := CredentialIssue{ProviderID: p.ID, ProviderName: p.Name}
+		if !cipher.IsEncrypted(*p.AuthJSON) {
+			issue.Problem = CredentialUnencrypted
+			issues = append(issues, issue)
+			continue
+		}
+		if _, err := cipher.Decrypt(*p.AuthJSON); err != nil {
+			issue.Problem = CredentialUndecryptable
+			issue.Err = err
+			issues = append(issues, issue)
+		}
+	}
+	return issues, nil
+}
@@ -22,6 +22,12 @@ func (r *diagFakeRepo) ListProviders(ctx context.Context) ([]domain.Provider, er
 func (r *diagFakeRepo) CreateProvider(ctx context.Context, provider domain.Provider) error {
 	return nil
 }
+func (r *diagFakeRepo) SetProviderAuth(ctx context.Context, auth map[string]*string) error {
+	return nil
+}
+func (r *diagFakeRepo) DeleteProvider(ctx context.Context, providerID string) error {
+	return nil
+}
 func (r *diagFakeRepo) ListWorkspaces(ctx context.Context) ([]domain.Workspace, error) {
 	return r.workspaces, nil
 }
@@ -9,6 +9,8 @@ type ListTaskFilters struct {
 	ColumnID    string
 	Status      string
 	DueSoonDays int
+	// Filter is a filter expression parsed by ParseTaskQuery.
+	Filter string
 }
 
 func (f ListTaskFilters) DueSoonBy(now time.Time) *time.Time {
@@ -0,0 +1,39 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+// HistoryService reads the task history recorded by the repositories.
+type HistoryService struct {
+	repo domain.TaskEventRepository
+}
+
+func NewHistoryService(repo domain.TaskEventRepository) *HistoryService {
+	return &HistoryService{repo: repo}
+}
+
+// TaskHistory returns every event of a task, oldest first. Deleted tasks
+// keep their history.
+func (s *HistoryService) TaskHistory(ctx context.Context, taskID string) ([]domain.TaskEvent, error) {
+	if strings.TrimSpace(taskID) == "" {
+		return nil, errors.New("task id is required")
+	}
+	return s.repo.ListByTask(ctx, taskID)
+}
+
+// Activity returns the latest events of a workspace, optionally limited to
+// a board, newest first.
+func (s *HistoryService) Activity(ctx context.Context, filter domain.ActivityFilter) ([]domain.TaskEvent, error) {
+	if strings.TrimSpace(filter.WorkspaceID) == "" {
+		return nil, errors.New("workspace id is required")
+	}
+	if filter.Limit < 0 {
+		return nil, errors.New("limit must not be negative")
+	}
+	return s.repo.ListActivity(ctx, filter)
+}
@@ -0,0 +1,143 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+// ErrHookRejected is the sentinel matched by every HookRejectedError via
+// errors.Is.
+var ErrHookRejected = errors.New("rejected by hook")
+
+// HookRejectedError reports that a pre-* hook vetoed a change.
+type HookRejectedError struct {
+	Hook   string
+	Reason string
+}
+
+func (e *HookRejectedError) Error() string {
+	return fmt.Sprintf("%s hook rejected the change: %s", e.Hook, e.Reason)
+}
+
+// Is reports whether target is ErrHookRejected.
+func (e *HookRejectedError) Is(target error) bool {
+	return target == ErrHookRejected
+}
+
+// Hooks runs local hooks around task and comment changes. A nil *Hooks runs
+// nothing, so services work unchanged without one.
+type Hooks struct {
+	runner domain.HookRunner
+	tasks  domain.TaskRepository
+}
+
+// NewHooks creates Hooks that run through runner. tasks is used to describe
+// the task a comment belongs to.
+func NewHooks(runner domain.HookRunner, tasks domain.TaskRepository) *Hooks {
+	return &Hooks{runner: runner, tasks: tasks}
+}
+
+// pre runs a pre-* hook and turns a failure into a *HookRejectedError.
+func (h *Hooks) pre(ctx context.Context, event domain.HookEvent) error {
+	if h == nil {
+		return nil
+	}
+	if err := h.runner.Run(ctx, event); err != nil {
+		return &HookRejectedError{Hook: event.Hook, Reason: err.Error()}
+	}
+	return nil
+}
+
+// post runs a hook after the change is saved. The change stands whatever the
+// hook does; the runner reports failures itself.
+func (h *Hooks) post(ctx context.Context, event domain.HookEvent) {
+	if h == nil {
+		return
+	}
+	_ = h.runner.Run(ctx, event)
+}
+
+// postTask loads a task and runs a post hook for it.
+func (h *Hooks) postTask(ctx context.Context, hook, taskID string) {
+	if h == nil {
+		return
+	}
+	task, err := h.tasks.GetByID(ctx, taskID)
+	if err != nil {
+		return
+	}
+	h.post(ctx, taskHookEvent(hook, task, nil))
+}
+
+// commentEvent describes a comment hook, including the comment's task.
+func (h *Hooks) commentEvent(ctx context.Context, hook string, comment domain.Comment) (domain.HookEvent, error) {
+	task, err := h.tasks.GetByID(ctx, comment.TaskID)
+	if err != nil {
+		return domain.HookEvent{}, err
+	}
+	return domain.HookEvent{
+		Hook:        hook,
+		WorkspaceID: task.WorkspaceID,
+		Task:        &task,
+		Comment:     &comment,
+	}, nil
+}
+
+func taskHookEvent(hook string, task domain.Task, changes map[string]any) domain.HookEvent {
+	return domain.HookEvent{
+		Hook:        hook,
+		WorkspaceID: task.WorkspaceID,
+		Task:        &task,
+		Changes:     changes,
+	}
+}
+
+// moveHookChanges describes a move for pre-task-move, including the
+// destination column's name so hooks can match on it.
+func (h *Hooks) moveHookChanges(ctx context.Context, task domain.Task, columnID, status *string) map[string]any {
+	changes := map[string]any{}
+	if columnID != nil {
+		changes["column_id"] = *columnID
+		if task.BoardID != nil {
+			if columns, err := h.tasks.ListColumns(ctx, *task.BoardID); err == nil {
+				for _, c := range columns {
+					if c.ID == *columnID {
+						changes["column_name"] = c.Name
+						break
+					}
+				}
+			}
+		}
+	}
+	if status != nil {
+		changes["status"] = *status
+	}
+	return changes
+}
+
+// updateHookChanges describes a task patch for pre-task-update.
+func (h *Hooks) updateHookChanges(ctx context.Context, task domain.Task, patch domain.TaskPatch) map[string]any {
+	changes := h.moveHookChanges(ctx, task, patch.ColumnID, patch.Status)
+	if patch.Title != nil {
+		changes["title"] = *patch.Title
+	}
+	if patch.DescriptionMD != nil {
+		changes["description"] = *patch.DescriptionMD
+	}
+	if patch.Priority != nil {
+		changes["priority"] = *patch.Priority
+	}
+	if patch.ClearDueAt {
+		changes["due_at"] = nil
+	} else if patch.DueAt != nil {
+		changes["due_at"] = patch.DueAt.UTC().Format(time.RFC3339)
+	}
+	if patch.Labels != nil {
+		changes["labels"] = *patch.Labels
+	}
+	return changes
+}
@@ -0,0 +1,167 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+type fakeHookRunner struct {
+	reject map[string]bool
+	events []domain.HookEvent
+}
+
+func (r *fakeHookRunner) Run(_ context.Context, event domain.HookEvent) error {
+	r.events = append(r.events, event)
+	if r.reject[event.Hook] {
+		return errors.New("not allowed")
+	}
+	return nil
+}
+
+// hookTaskRepo serves a single task so hooks can describe it.
+type hookTaskRepo struct {
+	fakeTaskRepo
+	task    domain.Task
+	updated bool
+	deleted bool
+}
+
+func (r *hookTaskRepo) GetByID(context.Context, string) (domain.Task, error) { return r.task, nil }
+func (r *hookTaskRepo) Update(context.Context, string, domain.TaskPatch) error {
+	r.updated = true
+	return nil
+}
+func (r *hookTaskRepo) Delete(context.Context, string) error {
+	r.deleted = true
+	return nil
+}
+
+func newHookTaskRepo() *hookTaskRepo {
+	board, column := "b1", "c1"
+	return &hookTaskRepo{
+		fakeTaskRepo: fakeTaskRepo{columns: []domain.Column{{ID: "c1", Name: "Todo"}, {ID: "c2", Name: "Done"}}},
+		task:         domain.Task{ID: "t1", WorkspaceID: "w1", BoardID: &board, ColumnID: &column},
+	}
+}
+
+func TestTaskFlow_PreMoveHookVetoesMove(t *testing.T) {
+	repo := newHookTaskRepo()
+	runner := &fakeHookRunner{reject: map[string]bool{domain.HookPreTaskMove: true}}
+	flow := NewTaskFlow(repo)
+	flow.SetHooks(NewHooks(runner, repo))
+
+	done := "c2"
+	err := flow.MoveTask(context.Background(), "t1", &done, nil, 0)
+	var rejected *HookRejectedError
+	if !errors.As(err, &rejected) || !errors.Is(err, ErrHookRejected) || rejected.Hook != domain.HookPreTaskMove {
+		t.Fatalf("expected pre-task-move rejection, got %v", err)
+	}
+	if repo.lastMoveInput.TaskID != "" {
+		t.Fatalf("vetoed move must not reach the repository: %+v", repo.lastMoveInput)
+	}
+	if len(runner.events) != 1 || runner.events[0].Changes["column_name"] != "Done" || runner.events[0].WorkspaceID != "w1" {
+		t.Fatalf("unexpected hook events: %+v", runner.events)
+	}
+
+	runner.reject = nil
+	runner.events = nil
+	if err := flow.MoveTask(context.Background(), "t1", &done, nil, 0); err != nil {
+		t.Fatalf("move: %v", err)
+	}
+	if len(runner.events) != 2 || runner.events[1].Hook != domain.HookTaskMoved {
+		t.Fatalf("expected pre-task-move then task-moved, got %+v", runner.events)
+	}
+}
+
+func TestTaskService_HooksAroundUpdateAndDelete(t *testing.T) {
+	repo := newHookTaskRepo()
+	runner := &fakeHookRunner{reject: map[string]bool{domain.HookPreTaskMove: true}}
+	service := NewTaskService(repo)
+	service.SetHooks(NewHooks(runner, repo))
+	ctx := context.Background()
+
+	// Moving through an update is still a move.
+	done := "c2"
+	if err := service.UpdateTask(ctx, "t1", UpdateTaskInput{ColumnID: &done}); !errors.Is(err, ErrHookRejected) {
+		t.Fatalf("expected rejection, got %v", err)
+	}
+	if repo.updated {
+		t.Fatal("vetoed update must not reach the repository")
+	}
+
+	title := "Renamed"
+	if err := service.UpdateTask(ctx, "t1", UpdateTaskInput{Title: &title}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	pre := runner.events[1]
+	if pre.Hook != domain.HookPreTaskUpdate || pre.Changes["title"] != "Renamed" {
+		t.Fatalf("unexpected pre-task-update event: %+v", pre)
+	}
+	if runner.events[2].Hook != domain.HookTaskUpdated || !repo.updated {
+		t.Fatalf("expected task-updated after the update, got %+v", runner.events[2])
+	}
+
+	runner.reject = map[string]bool{domain.HookPreTaskDelete: true}
+	if err := service.DeleteTask(ctx, "t1"); !errors.Is(err, ErrHookRejected) || repo.deleted {
+		t.Fatalf("expected delete to be vetoed, got %v", err)
+	}
+}
+
+// hookOperationRepo journals one task creation for undo.
+type hookOperationRepo struct {
+	task   domain.Task
+	undone bool
+}
+
+func (r *hookOperationRepo) NextUndo(context.Context, string) (domain.OperationChange, error) {
+	if r.undone {
+		return domain.OperationChange{}, domain.ErrNothingToUndo
+	}
+	op := domain.Operation{ID: "op-1", Kind: domain.OperationTaskCreate, EntityID: r.task.ID}
+	return domain.OperationChange{Operation: op, FromTask: &r.task}, nil
+}
+
+func (r *hookOperationRepo) NextRedo(context.Context, string) (domain.OperationChange, error) {
+	return domain.OperationChange{}, domain.ErrNothingToRedo
+}
+
+func (r *hookOperationRepo) Undo(_ context.Context, _, operationID string) (domain.Operation, error) {
+	if operationID != "op-1" {
+		return domain.Operation{}, errors.New("unexpected operation " + operationID)
+	}
+	r.undone = true
+	return domain.Operation{ID: "op-1", Kind: domain.OperationTaskCreate, EntityID: r.task.ID}, nil
+}
+
+func (r *hookOperationRepo) Redo(context.Context, string, string) (domain.Operation, error) {
+	return domain.Operation{}, domain.ErrNothingToRedo
+}
+
+func TestUndoService_PreDeleteHookVetoesUndo(t *testing.T) {
+	repo := newHookTaskRepo()
+	ops := &hookOperationRepo{task: repo.task}
+	runner := &fakeHookRunner{reject: map[string]bool{domain.HookPreTaskDelete: true}}
+	service := NewUndoService(ops)
+	service.SetHooks(NewHooks(runner, repo))
+	ctx := context.Background()
+
+	// Undoing a creation deletes the task, so pre-task-delete can veto it.
+	if _, err := service.Undo(ctx, "ns", 1); !errors.Is(err, ErrHookRejected) {
+		t.Fatalf("expected pre-task-delete rejection, got %v", err)
+	}
+	if ops.undone {
+		t.Fatal("vetoed undo must not reach the repository")
+	}
+
+	runner.reject = nil
+	runner.events = nil
+	if _, err := service.Undo(ctx, "ns", 1); err != nil {
+		t.Fatalf("undo: %v", err)
+	}
+	if !ops.undone || len(runner.events) != 2 || runner.events[0].Hook != domain.HookPreTaskDelete || runner.events[1].Hook != domain.HookTaskDeleted {
+		t.Fatalf("expected pre-task-delete then task-deleted, got %+v", runner.events)
+	}
+}
@@ -0,0 +1,209 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+// ErrLabelNotFound is returned when a label name does not exist in a
+// workspace.
+var ErrLabelNotFound = errors.New("label not found")
+
+// CreateLabelInput describes a label to add to a workspace.
+type CreateLabelInput struct {
+	WorkspaceID string
+	Name        string
+	Color       string
+	Description string
+}
+
+// LabelService manages the labels of a workspace.
+type LabelService struct {
+	repo domain.LabelRepository
+	now  func() time.Time
+}
+
+// NewLabelService creates a new LabelService.
+func NewLabelService(repo domain.LabelRepository) *LabelService {
+	return &LabelService{repo: repo, now: time.Now}
+}
+
+// ListLabels returns the labels of a workspace by name with their task
+// counts.
+func (s *LabelService) ListLabels(ctx context.Context, workspaceID string) ([]domain.Label, error) {
+	return s.repo.List(ctx, strings.TrimSpace(workspaceID))
+}
+
+// LabelColors maps the lowercased names of the labels of a workspace that
+// have a color to that color.
+func (s *LabelService) LabelColors(ctx context.Context, workspaceID string) (map[string]string, error) {
+	labels, err := s.ListLabels(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	colors := make(map[string]string, len(labels))
+	for _, label := range labels {
+		if label.Color != "" {
+			colors[strings.ToLower(label.Name)] = label.Color
+		}
+	}
+	return colors, nil
+}
+
+// GetLabel returns the label of a workspace with the given name, ignoring
+// case.
+func (s *LabelService) GetLabel(ctx context.Context, workspaceID, name string) (domain.Label, error) {
+	label, err := s.repo.GetByName(ctx, strings.TrimSpace(workspaceID), strings.TrimSpace(name))
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Label{}, ErrLabelNotFound
+	}
+	return label, err
+}
+
+// CreateLabel validates and stores a label. A label tasks already use has
+// been registered with them, so creating it again fails.
+func (s *LabelService) CreateLabel(ctx context.Context, input CreateLabelInput) (domain.Label, error) {
+	workspaceID := strings.TrimSpace(input.WorkspaceID)
+	if workspaceID == "" {
+		return domain.Label{}, errors.New("workspace id is required")
+	}
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return domain.Label{}, errors.New("label name is required")
+	}
+	color, err := normalizeLabelColor(input.Color)
+	if err != nil {
+		return domain.Label{}, err
+	}
+	if existing, err := s.GetLabel(ctx, workspaceID, name); err == nil {
+		return domain.Label{}, fmt.Errorf("label %q already exists", existing.Name)
+	} else if !errors.Is(err, ErrLabelNotFound) {
+		return domain.Label{}, err
+	}
+
+	now := s.now().UTC()
+	label := domain.Label{
+		ID:          uuid.NewString(),
+		WorkspaceID: workspaceID,
+		Name:        name,
+		Color:       color,
+		Description: strings.TrimSpace(input.Description),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.repo.Create(ctx, label); err != nil {
+		return domain.Label{}, err
+	}
+	return label, nil
+}
+
+// UpdateLabel changes the color or the description of a label; nil leaves
+// the field as it is.
+func (s *LabelService) UpdateLabel(ctx context.Context, workspaceID, name string, color, description *string) (domain.Label, error) {
+	label, err := s.GetLabel(ctx, workspaceID, name)
+	if err != nil {
+		return domain.Label{}, err
+	}
+	if color != nil {
+		if label.Color, err = normalizeLabelColor(*color); err != nil {
+			return domain.Label{}, err
+		}
+	}
+	if description != nil {
+		label.Description = strings.TrimSpace(*description)
+	}
+	label.UpdatedAt = s.now().UTC()
+	if err := s.repo.Update(ctx, label); err != nil {
+		return domain.Label{}, err
+	}
+	return label, nil
+}
+
+// RenameLabel renames a label on every task of the workspace and returns the
+// renamed label and the number of tasks changed. Renaming onto another
+// existing label fails; merge them instead.
+func (s *LabelService) RenameLabel(ctx context.Context, workspaceID, name, newName string) (domain.Label, int, error) {
+	newName = strings.TrimSpace(newName)
+	if newName == "" {
+		return domain.Label{}, 0, errors.New("new label name is required")
+	}
+	label, err := s.GetLabel(ctx, workspaceID, name)
+	if err != nil {
+		return domain.Label{}, 0, err
+	}
+	if other, err := s.GetLabel(ctx, workspaceID, newName); err == nil && other.ID != label.ID {
+		return domain.Label{}, 0, fmt.Errorf("label %q already exists; merge the labels instead", other.Name)
+	} else if err != nil && !errors.Is(err, ErrLabelNotFound) {
+		return domain.Label{}, 0, err
+	}
+	changed, err := s.repo.Rename(ctx, label, newName)
+	if err != nil {
+		return domain.Label{}, 0, err
+	}
+	label.Name = newName
+	return label, changed, nil
+}
+
+// MergeLabels replaces the source labels with the target on every task of
+// the workspace and deletes the sources. It returns the target and the
+// number of tasks changed.
+func (s *LabelService) MergeLabels(ctx context.Context, workspaceID string, sourceNames []string, targetName string) (domain.Label, int, error) {
+	target, err := s.GetLabel(ctx, workspaceID, targetName)
+	if err != nil {
+		return domain.Label{}, 0, fmt.Errorf("target: %w", err)
+	}
+	sources := make([]domain.Label, 0, len(sourceNames))
+	seen := map[string]bool{target.ID: true}
+	for _, name := range sourceNames {
+		source, err := s.GetLabel(ctx, workspaceID, name)
+		if err != nil {
+			return domain.Label{}, 0, fmt.Errorf("label %q: %w", strings.TrimSpace(name), err)
+		}
+		if seen[source.ID] {
+			continue
+		}
+		seen[source.ID] = true
+		sources = append(sources, source)
+	}
+	if len(sources) == 0 {
+		return domain.Label{}, 0, errors.New("at least one label other than the target is required")
+	}
+	changed, err := s.repo.Merge(ctx, sources, target)
+	if err != nil {
+		return domain.Label{}, 0, err
+	}
+	return target, changed, nil
+}
+
+// DeleteLabel deletes a label and removes it from every task of the
+// workspace. It returns the deleted label and the number of tasks changed.
+func (s *LabelService) DeleteLabel(ctx context.Context, workspaceID, name string) (domain.Label, int, error) {
+	label, err := s.GetLabel(ctx, workspaceID, name)
+	if err != nil {
+		return domain.Label{}, 0, err
+	}
+	changed, err := s.repo.Delete(ctx, label)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Label{}, 0, ErrLabelNotFound
+	}
+	if err != nil {
+		return domain.Label{}, 0, err
+	}
+	return label, changed, nil
+}
+
+func normalizeLabelColor(color string) (string, error) {
+	color = strings.ToUpper(strings.TrimSpace(color))
+	if color != "" && !hexColorPattern.MatchString(color) {
+		return "", errors.New("color must be HEX (#RRGGBB)")
+	}
+	return color, nil
+}
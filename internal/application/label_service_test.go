@@ -0,0 +1,128 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+type fakeLabelRepo struct {
+	labels  []domain.Label
+	renamed map[string]string
+	merged  []string
+}
+
+func (r *fakeLabelRepo) List(_ context.Context, workspaceID string) ([]domain.Label, error) {
+	var result []domain.Label
+	for _, l := range r.labels {
+		if l.WorkspaceID == workspaceID {
+			result = append(result, l)
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeLabelRepo) GetByName(_ context.Context, workspaceID, name string) (domain.Label, error) {
+	for _, l := range r.labels {
+		if l.WorkspaceID == workspaceID && strings.EqualFold(l.Name, name) {
+			return l, nil
+		}
+	}
+	return domain.Label{}, sql.ErrNoRows
+}
+
+func (r *fakeLabelRepo) Create(_ context.Context, label domain.Label) error {
+	r.labels = append(r.labels, label)
+	return nil
+}
+
+func (r *fakeLabelRepo) Update(_ context.Context, label domain.Label) error {
+	for i := range r.labels {
+		if r.labels[i].ID == label.ID {
+			r.labels[i] = label
+			return nil
+		}
+	}
+	return sql.ErrNoRows
+}
+
+func (r *fakeLabelRepo) Rename(_ context.Context, label domain.Label, newName string) (int, error) {
+	if r.renamed == nil {
+		r.renamed = map[string]string{}
+	}
+	r.renamed[label.Name] = newName
+	return 1, nil
+}
+
+func (r *fakeLabelRepo) Merge(_ context.Context, sources []domain.Label, _ domain.Label) (int, error) {
+	for _, s := range sources {
+		r.merged = append(r.merged, s.Name)
+	}
+	return len(sources), nil
+}
+
+func (r *fakeLabelRepo) Delete(_ context.Context, _ domain.Label) (int, error) {
+	return 0, nil
+}
+
+func TestLabelService_CreateLabel(t *testing.T) {
+	ctx := context.Background()
+	svc := NewLabelService(&fakeLabelRepo{})
+
+	label, err := svc.CreateLabel(ctx, CreateLabelInput{WorkspaceID: "ws", Name: " bug ", Color: "#ef4444"})
+	if err != nil {
+		t.Fatalf("CreateLabel: %v", err)
+	}
+	if label.Name != "bug" || label.Color != "#EF4444" {
+		t.Fatalf("CreateLabel = %+v", label)
+	}
+	if _, err := svc.CreateLabel(ctx, CreateLabelInput{WorkspaceID: "ws", Name: "BUG"}); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("duplicate err = %v", err)
+	}
+	if _, err := svc.CreateLabel(ctx, CreateLabelInput{WorkspaceID: "ws", Name: "ui", Color: "red"}); err == nil {
+		t.Fatal("expected an invalid color error")
+	}
+
+	colors, err := svc.LabelColors(ctx, "ws")
+	if err != nil || len(colors) != 1 || colors["bug"] != "#EF4444" {
+		t.Fatalf("LabelColors = %v, %v", colors, err)
+	}
+
+	empty := ""
+	updated, err := svc.UpdateLabel(ctx, "ws", "Bug", &empty, nil)
+	if err != nil || updated.Color != "" {
+		t.Fatalf("UpdateLabel = %+v, %v", updated, err)
+	}
+}
+
+func TestLabelService_RenameAndMerge(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeLabelRepo{labels: []domain.Label{
+		{ID: "l1", WorkspaceID: "ws", Name: "bug"},
+		{ID: "l2", WorkspaceID: "ws", Name: "defect"},
+	}}
+	svc := NewLabelService(repo)
+
+	if _, _, err := svc.RenameLabel(ctx, "ws", "defect", "Bug"); err == nil || !strings.Contains(err.Error(), "merge") {
+		t.Fatalf("rename onto an existing label err = %v", err)
+	}
+	// A change of case renames the label onto itself.
+	if _, _, err := svc.RenameLabel(ctx, "ws", "bug", "Bug"); err != nil {
+		t.Fatalf("rename case: %v", err)
+	}
+	if _, _, err := svc.RenameLabel(ctx, "ws", "missing", "x"); !errors.Is(err, ErrLabelNotFound) {
+		t.Fatalf("rename missing err = %v", err)
+	}
+
+	if _, _, err := svc.MergeLabels(ctx, "ws", []string{"bug"}, "BUG"); err == nil {
+		t.Fatal("expected merging a label into itself to fail")
+	}
+	target, changed, err := svc.MergeLabels(ctx, "ws", []string{"defect", "Defect"}, "bug")
+	if err != nil || target.ID != "l1" || changed != 1 || len(repo.merged) != 1 {
+		t.Fatalf("MergeLabels = %+v, %d, %v (merged %v)", target, changed, err, repo.merged)
+	}
+}
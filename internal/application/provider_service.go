@@ -0,0 +1,276 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+// ProviderRegistry lists the provider types kanji can talk to and builds
+// clients for configured providers.
+type ProviderRegistry interface {
+	Types() []string
+	Client(provider domain.Provider) (domain.ProviderClient, error)
+}
+
+// CredentialCipher encrypts provider credentials at rest. Decrypt returns
+// values that were never encrypted unchanged.
+type CredentialCipher interface {
+	Encrypt(plaintext string) (string, error)
+	Decrypt(stored string) (string, error)
+	IsEncrypted(stored string) bool
+}
+
+// AddProviderInput describes a provider to register.
+type AddProviderInput struct {
+	Type     string
+	Name     string
+	AuthJSON *string
+}
+
+// ProviderTestResult reports the outcome of a connectivity check.
+type ProviderTestResult struct {
+	Provider     domain.Provider
+	Capabilities domain.ProviderCapabilities
+}
+
+// ProviderService manages configured providers and their credentials.
+type ProviderService struct {
+	repo     domain.SetupRepository
+	registry ProviderRegistry
+	cipher   CredentialCipher
+}
+
+// NewProviderService creates a new ProviderService. Credentials are stored
+// encrypted with cipher; a nil cipher stores them as given.
+func NewProviderService(repo domain.SetupRepository, registry ProviderRegistry, cipher CredentialCipher) *ProviderService {
+	return &ProviderService{repo: repo, registry: registry, cipher: cipher}
+}
+
+// Types returns the provider types that can be added.
+func (s *ProviderService) Types() []string {
+	return s.registry.Types()
+}
+
+func (s *ProviderService) ListProviders(ctx context.Context) ([]domain.Provider, error) {
+	return s.repo.ListProviders(ctx)
+}
+
+// AddProvider validates and stores a new provider. The type must be known to
+// the registry, the name must be unique, and credentials must be valid JSON.
+func (s *ProviderService) AddProvider(ctx context.Context, input AddProviderInput) (domain.Provider, error) {
+	providerType := strings.ToLower(strings.TrimSpace(input.Type))
+	if providerType == "" {
+		return domain.Provider{}, errors.New("provider type is required")
+	}
+	if !s.knownType(providerType) {
+		return domain.Provider{}, fmt.Errorf("unknown provider type %q: must be one of %s", providerType, strings.Join(s.registry.Types(), ", "))
+	}
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return domain.Provider{}, errors.New("provider name is required")
+	}
+	if input.AuthJSON != nil && !json.Valid([]byte(*input.AuthJSON)) {
+		return domain.Provider{}, errors.New("provider auth must be valid JSON")
+	}
+	authJSON, err := s.encryptAuth(input.AuthJSON)
+	if err != nil {
+		return domain.Provider{}, err
+	}
+
+	existing, err := s.repo.ListProviders(ctx)
+	if err != nil {
+		return domain.Provider{}, err
+	}
+	for _, p := range existing {
+		if strings.EqualFold(strings.TrimSpace(p.Name), name) {
+			return domain.Provider{}, fmt.Errorf("provider name %q already exists", name)
+		}
+	}
+
+	provider := domain.Provider{
+		ID:        uuid.NewString(),
+		Type:      providerType,
+		Name:      name,
+		AuthJSON:  authJSON,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := s.repo.CreateProvider(ctx, provider); err != nil {
+		return domain.Provider{}, err
+	}
+	return provider, nil
+}
+
+// RemoveProvider deletes a provider that no workspace uses anymore. Its
+// pending sync queue entries are dropped with it.
+func (s *ProviderService) RemoveProvider(ctx context.Context, providerID string) error {
+	providerID = strings.TrimSpace(providerID)
+	if providerID == "" {
+		return errors.New("provider id is required")
+	}
+	if _, err := s.GetProvider(ctx, providerID); err != nil {
+		return err
+	}
+
+	workspaces, err := s.repo.ListWorkspaces(ctx)
+	if err != nil {
+		return err
+	}
+	inUse := 0
+	for _, w := range workspaces {
+		if w.ProviderID == providerID {
+			inUse++
+		}
+	}
+	if inUse > 0 {
+		return fmt.Errorf("provider is used by %d workspace(s); delete or move them first", inUse)
+	}
+	return s.repo.DeleteProvider(ctx, providerID)
+}
+
+// GetProvider returns the provider with the given ID.
+func (s *ProviderService) GetProvider(ctx context.Context, providerID string) (domain.Provider, error) {
+	providers, err := s.repo.ListProviders(ctx)
+	if err != nil {
+		return domain.Provider{}, err
+	}
+	for _, p := range providers {
+		if p.ID == providerID {
+			return p, nil
+		}
+	}
+	return domain.Provider{}, fmt.Errorf("provider %s not found", providerID)
+}
+
+// SetAuth validates and stores new credentials for a provider, encrypted.
+func (s *ProviderService) SetAuth(ctx context.Context, providerID, authJSON string) (domain.Provider, error) {
+	provider, err := s.GetProvider(ctx, providerID)
+	if err != nil {
+		return domain.Provider{}, err
+	}
+	authJSON = strings.TrimSpace(authJSON)
+	if authJSON == "" {
+		return domain.Provider{}, errors.New("provider auth is required")
+	}
+	if !json.Valid([]byte(authJSON)) {
+		return domain.Provider{}, errors.New("provider auth must be valid JSON")
+	}
+	stored, err := s.encryptAuth(&authJSON)
+	if err != nil {
+		return domain.Provider{}, err
+	}
+	if err := s.repo.SetProviderAuth(ctx, map[string]*string{provider.ID: stored}); err != nil {
+		return domain.Provider{}, err
+	}
+	provider.AuthJSON = stored
+	return provider, nil
+}
+
+// ClearAuth removes the stored credentials of a provider.
+func (s *ProviderService) ClearAuth(ctx context.Context, providerID string) (domain.Provider, error) {
+	provider, err := s.GetProvider(ctx, providerID)
+	if err != nil {
+		return domain.Provider{}, err
+	}
+	if err := s.repo.SetProviderAuth(ctx, map[string]*string{provider.ID: nil}); err != nil {
+		return domain.Provider{}, err
+	}
+	provider.AuthJSON = nil
+	return provider, nil
+}
+
+// RotateAuth re-encrypts every stored credential with next, including ones
+// that were stored unencrypted, in one transaction. It fails without
+// changing anything when a credential cannot be decrypted with the current
+// key. It returns the number of providers re-encrypted.
+func (s *ProviderService) RotateAuth(ctx context.Context, next CredentialCipher) (int, error) {
+	providers, err := s.repo.ListProviders(ctx)
+	if err != nil {
+		return 0, err
+	}
+	updates := make(map[string]*string)
+	for _, p := range providers {
+		if p.AuthJSON == nil {
+			continue
+		}
+		plaintext, err := s.decryptAuth(*p.AuthJSON)
+		if err != nil {
+			return 0, fmt.Errorf("provider %s: %w", p.Name, err)
+		}
+		stored, err := next.Encrypt(plaintext)
+		if err != nil {
+			return 0, err
+		}
+		updates[p.ID] = &stored
+	}
+	if len(updates) == 0 {
+		return 0, nil
+	}
+	if err := s.repo.SetProviderAuth(ctx, updates); err != nil {
+		return 0, err
+	}
+	return len(updates), nil
+}
+
+// Client builds the client for a provider with its credentials decrypted.
+func (s *ProviderService) Client(provider domain.Provider) (domain.ProviderClient, error) {
+	if provider.AuthJSON != nil {
+		plaintext, err := s.decryptAuth(*provider.AuthJSON)
+		if err != nil {
+			return nil, fmt.Errorf("provider %s: %w", provider.Name, err)
+		}
+		provider.AuthJSON = &plaintext
+	}
+	return s.registry.Client(provider)
+}
+
+// TestProvider builds the provider client, checks connectivity, and reports
+// the advertised capabilities.
+func (s *ProviderService) TestProvider(ctx context.Context, providerID string) (ProviderTestResult, error) {
+	provider, err := s.GetProvider(ctx, providerID)
+	if err != nil {
+		return ProviderTestResult{}, err
+	}
+	client, err := s.Client(provider)
+	if err != nil {
+		return ProviderTestResult{}, err
+	}
+	if err := client.Test(ctx); err != nil {
+		return ProviderTestResult{}, fmt.Errorf("provider %s: %w", provider.Name, err)
+	}
+	return ProviderTestResult{Provider: provider, Capabilities: client.Capabilities()}, nil
+}
+
+func (s *ProviderService) knownType(providerType string) bool {
+	for _, t := range s.registry.Types() {
+		if t == providerType {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *ProviderService) encryptAuth(authJSON *string) (*string, error) {
+	if authJSON == nil || s.cipher == nil {
+		return authJSON, nil
+	}
+	stored, err := s.cipher.Encrypt(*authJSON)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt provider auth: %w", err)
+	}
+	return &stored, nil
+}
+
+func (s *ProviderService) decryptAuth(stored string) (string, error) {
+	if s.cipher == nil {
+		return stored, nil
+	}
+	return s.cipher.Decrypt(stored)
+}
@@ -0,0 +1,176 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+type fakeProviderRegistry struct {
+	types   []string
+	testErr error
+}
+
+func (r *fakeProviderRegistry) Types() []string {
+	return r.types
+}
+
+func (r *fakeProviderRegistry) Client(provider domain.Provider) (domain.ProviderClient, error) {
+	return &fakeTestClient{caps: domain.ProviderCapabilities{Pull: true}, err: r.testErr}, nil
+}
+
+type fakeTestClient struct {
+	domain.ProviderClient
+	caps domain.ProviderCapabilities
+	err  error
+}
+
+func (c *fakeTestClient) Capabilities() domain.ProviderCapabilities { return c.caps }
+func (c *fakeTestClient) Test(ctx context.Context) error            { return c.err }
+
+func TestProviderService_AddProvider(t *testing.T) {
+	repo := &fakeSetupRepo{}
+	svc := NewProviderService(repo, &fakeProviderRegistry{types: []string{"local"}}, nil)
+	ctx := context.Background()
+
+	provider, err := svc.AddProvider(ctx, AddProviderInput{Type: " Local ", Name: "Scratch"})
+	if err != nil {
+		t.Fatalf("add provider: %v", err)
+	}
+	if provider.Type != "local" {
+		t.Fatalf("expected normalized type, got %q", provider.Type)
+	}
+	if len(repo.providers) != 1 {
+		t.Fatalf("expected provider to be stored, got %d", len(repo.providers))
+	}
+
+	if _, err := svc.AddProvider(ctx, AddProviderInput{Type: "local", Name: "scratch"}); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("expected duplicate name error, got %v", err)
+	}
+	if _, err := svc.AddProvider(ctx, AddProviderInput{Type: "jira", Name: "Other"}); err == nil || !strings.Contains(err.Error(), "must be one of local") {
+		t.Fatalf("expected unknown type error, got %v", err)
+	}
+	bad := "{not json"
+	if _, err := svc.AddProvider(ctx, AddProviderInput{Type: "local", Name: "Other", AuthJSON: &bad}); err == nil || !strings.Contains(err.Error(), "valid JSON") {
+		t.Fatalf("expected auth JSON error, got %v", err)
+	}
+}
+
+func TestProviderService_RemoveProviderInUse(t *testing.T) {
+	repo := &fakeSetupRepo{
+		providers:  []domain.Provider{{ID: "p1", Type: "local", Name: "Local"}, {ID: "p2", Type: "local", Name: "Spare"}},
+		workspaces: []domain.Workspace{{ID: "w1", ProviderID: "p1"}},
+	}
+	svc := NewProviderService(repo, &fakeProviderRegistry{types: []string{"local"}}, nil)
+	ctx := context.Background()
+
+	if err := svc.RemoveProvider(ctx, "p1"); err == nil || !strings.Contains(err.Error(), "used by 1 workspace") {
+		t.Fatalf("expected in-use error, got %v", err)
+	}
+	if err := svc.RemoveProvider(ctx, "p2"); err != nil {
+		t.Fatalf("remove unused provider: %v", err)
+	}
+	if len(repo.providers) != 1 || repo.providers[0].ID != "p1" {
+		t.Fatalf("expected only p1 to remain, got %+v", repo.providers)
+	}
+	if err := svc.RemoveProvider(ctx, "missing"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
+
+func TestProviderService_TestProvider(t *testing.T) {
+	repo := &fakeSetupRepo{providers: []domain.Provider{{ID: "p1", Type: "local", Name: "Local"}}}
+	registry := &fakeProviderRegistry{types: []string{"local"}}
+	svc := NewProviderService(repo, registry, nil)
+	ctx := context.Background()
+
+	result, err := svc.TestProvider(ctx, "p1")
+	if err != nil {
+		t.Fatalf("test provider: %v", err)
+	}
+	if !result.Capabilities.Pull {
+		t.Fatalf("expected capabilities from client, got %+v", result.Capabilities)
+	}
+
+	registry.testErr = errors.New("unauthorized")
+	if _, err := svc.TestProvider(ctx, "p1"); err == nil || !strings.Contains(err.Error(), "Local: unauthorized") {
+		t.Fatalf("expected wrapped test error, got %v", err)
+	}
+}
+
+// prefixCipher "encrypts" by prefixing a key marker, enough to tell which
+// key sealed a value.
+type prefixCipher struct{ key string }
+
+func (c prefixCipher) Encrypt(plaintext string) (string, error) {
+	return "enc:" + c.key + ":" + plaintext, nil
+}
+
+func (c prefixCipher) Decrypt(stored string) (string, error) {
+	if !c.IsEncrypted(stored) {
+		return stored, nil
+	}
+	plaintext, ok := strings.CutPrefix(stored, "enc:"+c.key+":")
+	if !ok {
+		return "", errors.New("wrong key")
+	}
+	return plaintext, nil
+}
+
+func (c prefixCipher) IsEncrypted(stored string) bool {
+	return strings.HasPrefix(stored, "enc:")
+}
+
+func TestProviderService_Credentials(t *testing.T) {
+	plain := `{"token":"old"}`
+	repo := &fakeSetupRepo{providers: []domain.Provider{
+		{ID: "p1", Type: "local", Name: "Legacy", AuthJSON: &plain},
+		{ID: "p2", Type: "local", Name: "None"},
+	}}
+	svc := NewProviderService(repo, &fakeProviderRegistry{types: []string{"local"}}, prefixCipher{key: "k1"})
+	ctx := context.Background()
+
+	added, err := svc.AddProvider(ctx, AddProviderInput{Type: "local", Name: "New", AuthJSON: &plain})
+	if err != nil {
+		t.Fatalf("add provider: %v", err)
+	}
+	if *added.AuthJSON != `enc:k1:{"token":"old"}` {
+		t.Fatalf("expected credentials to be encrypted on add, got %s", *added.AuthJSON)
+	}
+
+	issues, err := FindCredentialIssues(ctx, repo, prefixCipher{key: "k1"})
+	if err != nil || len(issues) != 1 || issues[0].ProviderID != "p1" || issues[0].Problem != CredentialUnencrypted {
+		t.Fatalf("issues = %+v, %v", issues, err)
+	}
+
+	if _, err := svc.SetAuth(ctx, "p2", "{bad"); err == nil || !strings.Contains(err.Error(), "valid JSON") {
+		t.Fatalf("expected JSON error, got %v", err)
+	}
+	if _, err := svc.SetAuth(ctx, "p2", `{"token":"new"}`); err != nil {
+		t.Fatalf("set auth: %v", err)
+	}
+	if got := *repo.providers[1].AuthJSON; got != `enc:k1:{"token":"new"}` {
+		t.Fatalf("stored auth = %s", got)
+	}
+
+	count, err := svc.RotateAuth(ctx, prefixCipher{key: "k2"})
+	if err != nil || count != 3 {
+		t.Fatalf("rotate = %d, %v", count, err)
+	}
+	for _, p := range repo.providers {
+		if !strings.HasPrefix(*p.AuthJSON, "enc:k2:") {
+			t.Fatalf("provider %s not re-encrypted: %s", p.Name, *p.AuthJSON)
+		}
+	}
+	issues, _ = FindCredentialIssues(ctx, repo, prefixCipher{key: "k1"})
+	if len(issues) != 3 || issues[0].Problem != CredentialUndecryptable {
+		t.Fatalf("expected old key to fail on every provider, got %+v", issues)
+	}
+
+	if _, err := svc.ClearAuth(ctx, "p2"); err != nil || repo.providers[1].AuthJSON != nil {
+		t.Fatalf("clear auth: %v, %+v", err, repo.providers[1])
+	}
+}
@@ -0,0 +1,34 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+// SearchService runs full-text searches over tasks and their comments.
+type SearchService struct {
+	repo domain.TaskSearchRepository
+}
+
+func NewSearchService(repo domain.TaskSearchRepository) *SearchService {
+	return &SearchService{repo: repo}
+}
+
+// Search returns the tasks of a workspace, optionally limited to a board,
+// whose title, description, labels or comments contain every word of the
+// query, best match first.
+func (s *SearchService) Search(ctx context.Context, filter domain.SearchFilter) ([]domain.SearchHit, error) {
+	if strings.TrimSpace(filter.WorkspaceID) == "" {
+		return nil, errors.New("workspace id is required")
+	}
+	if strings.TrimSpace(filter.Query) == "" {
+		return nil, errors.New("search query is required")
+	}
+	if filter.Limit < 0 {
+		return nil, errors.New("limit must not be negative")
+	}
+	return s.repo.Search(ctx, filter)
+}
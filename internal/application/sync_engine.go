@@ -0,0 +1,508 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+const (
+	// MaxSyncAttempts is the number of failed pushes after which an item stops
+	// being retried automatically and needs `kanji sync queue retry`.
+	MaxSyncAttempts = 8
+
+	syncBackoffBase = 30 * time.Second
+	syncBackoffMax  = time.Hour
+)
+
+// Sync item states derived from attempts and backoff.
+const (
+	SyncStatePending  = "pending"
+	SyncStateRetrying = "retrying"
+	SyncStateFailed   = "failed"
+)
+
+// SyncItemState classifies a queued item: never attempted, waiting for
+// another attempt, or out of attempts.
+func SyncItemState(item domain.SyncItem) string {
+	switch {
+	case item.Attempts >= MaxSyncAttempts:
+		return SyncStateFailed
+	case item.Attempts > 0:
+		return SyncStateRetrying
+	default:
+		return SyncStatePending
+	}
+}
+
+// SyncBackoff returns how long to wait before retrying an item that has
+// failed the given number of times. The delay doubles on every failure and
+// is capped at one hour.
+func SyncBackoff(attempts int) time.Duration {
+	if attempts <= 0 {
+		return 0
+	}
+	delay := syncBackoffBase
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= syncBackoffMax {
+			return syncBackoffMax
+		}
+	}
+	return delay
+}
+
+// ProviderClientResolver returns the client used to push changes for a
+// configured provider.
+type ProviderClientResolver func(provider domain.Provider) (domain.ProviderClient, error)
+
+// SyncRunOptions narrows a sync run.
+type SyncRunOptions struct {
+	ProviderID string
+	// Limit caps the number of push attempts. Zero means no limit.
+	Limit int
+}
+
+// SyncRunResult summarizes a sync run.
+type SyncRunResult struct {
+	Pushed int
+	Failed int
+	// Skipped counts items left for a later run: backing off, out of
+	// attempts, queued behind a failed item, or held by a conflict.
+	Skipped int
+	// Ignored counts changes acknowledged without a push because the
+	// provider does not track that entity.
+	Ignored int
+	// Pulled counts local tasks and comments created or updated from
+	// provider state.
+	Pulled int
+	// PullErrors holds one message per board or task that could not be
+	// pulled. Pulling continues past them.
+	PullErrors []string
+	// Conflicts counts open conflicts after the run; see ListConflicts.
+	Conflicts int
+}
+
+// SyncProviderStatus summarizes the queue for one provider.
+type SyncProviderStatus struct {
+	Provider domain.Provider
+	Pending  int
+	Retrying int
+	Failed   int
+	Oldest   *time.Time
+}
+
+// SyncEngine drains the sync_queue outbox through provider clients.
+type SyncEngine struct {
+	queue   domain.SyncQueueRepository
+	setup   domain.SetupRepository
+	resolve ProviderClientResolver
+	now     func() time.Time
+}
+
+// NewSyncEngine creates a new SyncEngine.
+func NewSyncEngine(queue domain.SyncQueueRepository, setup domain.SetupRepository, resolve ProviderClientResolver) *SyncEngine {
+	return &SyncEngine{
+		queue:   queue,
+		setup:   setup,
+		resolve: resolve,
+		now:     func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Run pulls remote changes from providers that support it, merging them
+// with local edits, then pushes every due item in recording order. Items of
+// the same entity are strictly ordered: once one of them fails or is still
+// backing off, the later ones are skipped until the next run. Changes to a
+// task with an open conflict are held until it is resolved.
+func (e *SyncEngine) Run(ctx context.Context, opts SyncRunOptions) (SyncRunResult, error) {
+	var result SyncRunResult
+
+	providers, err := e.providersByID(ctx)
+	if err != nil {
+		return result, err
+	}
+	clients := make(map[string]domain.ProviderClient)
+	if err := e.pull(ctx, opts, providers, clients, &result); err != nil {
+		return result, err
+	}
+
+	items, err := e.queue.List(ctx, opts.ProviderID)
+	if err != nil {
+		return result, err
+	}
+	conflicts, err := e.queue.ListConflicts(ctx, opts.ProviderID)
+	if err != nil {
+		return result, err
+	}
+	result.Conflicts = len(conflicts)
+	held := make(map[string]bool, len(conflicts))
+	for _, c := range conflicts {
+		held[c.ProviderID+"/"+c.Entity+"/"+c.EntityID] = true
+	}
+
+	now := e.now()
+	blocked := make(map[string]bool)
+	for _, item := range items {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		if opts.Limit > 0 && result.Pushed+result.Failed+result.Ignored >= opts.Limit {
+			break
+		}
+
+		key := item.ProviderID + "/" + item.Entity + "/" + item.EntityID
+		if blocked[key] || item.Attempts >= MaxSyncAttempts ||
+			(item.NextAttemptAt != nil && item.NextAttemptAt.After(now)) ||
+			(held[key] && item.Action != domain.SyncActionDelete) {
+			blocked[key] = true
+			result.Skipped++
+			continue
+		}
+
+		client, pushErr := e.client(providers, clients, item.ProviderID)
+		if pushErr == nil {
+			caps := client.Capabilities()
+			if !caps.Push || !caps.Supports(item.Entity) {
+				// The provider does not track this entity: nothing to push.
+				if err := e.queue.Complete(ctx, item.ID); err != nil {
+					return result, err
+				}
+				result.Ignored++
+				continue
+			}
+			pushErr = e.push(ctx, client, item)
+			if errors.Is(pushErr, domain.ErrRemoteChanged) && item.Entity == domain.SyncEntityTask {
+				var pushed bool
+				pushed, pushErr = e.pushOverRemoteChange(ctx, client, item, &result)
+				if pushErr == nil && !pushed {
+					// The item was merged with the remote copy or is held by
+					// a conflict; what is left goes out on a later run.
+					blocked[key] = true
+					result.Skipped++
+					continue
+				}
+			}
+		}
+		if pushErr != nil {
+			blocked[key] = true
+			result.Failed++
+			next := now.Add(SyncBackoff(item.Attempts + 1))
+			if err := e.queue.RecordFailure(ctx, item.ID, pushErr.Error(), next); err != nil {
+				return result, err
+			}
+			continue
+		}
+		if err := e.queue.Complete(ctx, item.ID); err != nil {
+			return result, err
+		}
+		result.Pushed++
+	}
+	return result, nil
+}
+
+func (e *SyncEngine) client(providers map[string]domain.Provider, clients map[string]domain.ProviderClient, providerID string) (domain.ProviderClient, error) {
+	if client, ok := clients[providerID]; ok {
+		return client, nil
+	}
+	provider, found := providers[providerID]
+	if !found {
+		return nil, fmt.Errorf("provider %s not found", providerID)
+	}
+	client, err := e.resolve(provider)
+	if err != nil {
+		return nil, err
+	}
+	clients[providerID] = client
+	return client, nil
+}
+
+// push decodes the item snapshot, resolves the remote keys of the entity and
+// its parents, and hands the change to the matching Push method. A remote ID
+// returned for a create or update is stored on the local entity.
+func (e *SyncEngine) push(ctx context.Context, client domain.ProviderClient, item domain.SyncItem) error {
+	var (
+		local    domain.LocalRefs
+		remoteID *string
+		apply    func(refs domain.RemoteRefs) (string, error)
+	)
+
+	switch item.Entity {
+	case domain.SyncEntityWorkspace:
+		var w domain.Workspace
+		if err := json.Unmarshal([]byte(item.PayloadJSON), &w); err != nil {
+			return fmt.Errorf("decode workspace payload: %w", err)
+		}
+		local = domain.LocalRefs{WorkspaceID: w.ID}
+		remoteID = w.RemoteID
+		apply = func(refs domain.RemoteRefs) (string, error) {
+			w.RemoteID = optionalString(refs.Workspace)
+			return client.PushWorkspace(ctx, item.Action, w, refs)
+		}
+	case domain.SyncEntityBoard:
+		var b domain.Board
+		if err := json.Unmarshal([]byte(item.PayloadJSON), &b); err != nil {
+			return fmt.Errorf("decode board payload: %w", err)
+		}
+		local = domain.LocalRefs{WorkspaceID: b.WorkspaceID, BoardID: b.ID}
+		remoteID = b.RemoteID
+		apply = func(refs domain.RemoteRefs) (string, error) {
+			b.RemoteID = optionalString(refs.Board)
+			return client.PushBoard(ctx, item.Action, b, refs)
+		}
+	case domain.SyncEntityColumn:
+		var c domain.Column
+		if err := json.Unmarshal([]byte(item.PayloadJSON), &c); err != nil {
+			return fmt.Errorf("decode column payload: %w", err)
+		}
+		local = domain.LocalRefs{BoardID: c.BoardID, ColumnID: c.ID}
+		remoteID = c.RemoteID
+		apply = func(refs domain.RemoteRefs) (string, error) {
+			c.RemoteID = optionalString(refs.Column)
+			return client.PushColumn(ctx, item.Action, c, refs)
+		}
+	case domain.SyncEntityTask:
+		var t domain.Task
+		if err := json.Unmarshal([]byte(item.PayloadJSON), &t); err != nil {
+			return fmt.Errorf("decode task payload: %w", err)
+		}
+		local = domain.LocalRefs{WorkspaceID: t.WorkspaceID, TaskID: t.ID}
+		if t.BoardID != nil {
+			local.BoardID = *t.BoardID
+		}
+		if t.ColumnID != nil {
+			local.ColumnID = *t.ColumnID
+		}
+		remoteID = t.RemoteID
+		apply = func(refs domain.RemoteRefs) (string, error) {
+			t.RemoteID = optionalString(refs.Task)
+			return client.PushTask(ctx, item.Action, t, refs)
+		}
+	case domain.SyncEntityComment:
+		var c domain.Comment
+		if err := json.Unmarshal([]byte(item.PayloadJSON), &c); err != nil {
+			return fmt.Errorf("decode comment payload: %w", err)
+		}
+		local = domain.LocalRefs{TaskID: c.TaskID, CommentID: c.ID}
+		remoteID = c.RemoteID
+		apply = func(refs domain.RemoteRefs) (string, error) {
+			c.RemoteID = optionalString(refs.Comment)
+			return client.PushComment(ctx, item.Action, c, refs)
+		}
+	default:
+		return fmt.Errorf("unknown sync entity %q", item.Entity)
+	}
+
+	refs, err := e.queue.ResolveRemoteRefs(ctx, local)
+	if err != nil {
+		return err
+	}
+	// Deleted rows no longer resolve; fall back to the snapshot.
+	current := ownRemoteID(item.Entity, refs)
+	if current == "" && remoteID != nil {
+		current = *remoteID
+		setOwnRemoteID(item.Entity, &refs, current)
+	}
+
+	// A provider may report a remote ID together with an error, e.g. when an
+	// entity was created but a follow-up edit failed. Record it anyway so the
+	// retry edits that entity instead of creating another one.
+	pushed, pushErr := apply(refs)
+	if item.Action != domain.SyncActionDelete && pushed != "" && pushed != current {
+		if err := e.queue.SetRemoteID(ctx, item.Entity, item.EntityID, pushed); err != nil {
+			return err
+		}
+	}
+	if pushErr != nil {
+		return pushErr
+	}
+	if pushed == "" {
+		pushed = current
+	}
+	return e.recordPushed(ctx, item, pushed)
+}
+
+func ownRemoteID(entity string, refs domain.RemoteRefs) string {
+	switch entity {
+	case domain.SyncEntityWorkspace:
+		return refs.Workspace
+	case domain.SyncEntityBoard:
+		return refs.Board
+	case domain.SyncEntityColumn:
+		return refs.Column
+	case domain.SyncEntityTask:
+		return refs.Task
+	case domain.SyncEntityComment:
+		return refs.Comment
+	}
+	return ""
+}
+
+func setOwnRemoteID(entity string, refs *domain.RemoteRefs, remoteID string) {
+	switch entity {
+	case domain.SyncEntityWorkspace:
+		refs.Workspace = remoteID
+	case domain.SyncEntityBoard:
+		refs.Board = remoteID
+	case domain.SyncEntityColumn:
+		refs.Column = remoteID
+	case domain.SyncEntityTask:
+		refs.Task = remoteID
+	case domain.SyncEntityComment:
+		refs.Comment = remoteID
+	}
+}
+
+func optionalString(v string) *string {
+	if v == "" {
+		return nil
+	}
+	return &v
+}
+
+// ErrBoardNotFound is returned by LinkBoard for an unknown board ID.
+var ErrBoardNotFound = errors.New("board not found")
+
+// LinkBoard records the remote ID a board syncs with, such as a repository
+// for GitHub. Queued changes of the board's provider are made due again so
+// items that failed while the board was unlinked go out on the next run.
+func (e *SyncEngine) LinkBoard(ctx context.Context, boardID, remoteID string) (domain.Board, error) {
+	remoteID = strings.TrimSpace(remoteID)
+	if remoteID == "" {
+		return domain.Board{}, errors.New("remote id is required")
+	}
+
+	workspaces, err := e.setup.ListWorkspaces(ctx)
+	if err != nil {
+		return domain.Board{}, err
+	}
+	for _, ws := range workspaces {
+		boards, err := e.setup.ListBoards(ctx, ws.ID)
+		if err != nil {
+			return domain.Board{}, err
+		}
+		for _, b := range boards {
+			if b.ID != boardID {
+				continue
+			}
+			if err := e.queue.SetRemoteID(ctx, domain.SyncEntityBoard, b.ID, remoteID); err != nil {
+				return domain.Board{}, err
+			}
+			items, err := e.queue.List(ctx, ws.ProviderID)
+			if err != nil {
+				return domain.Board{}, err
+			}
+			for _, item := range items {
+				if item.Attempts == 0 {
+					continue
+				}
+				if _, err := e.queue.Retry(ctx, item.ID); err != nil {
+					return domain.Board{}, err
+				}
+			}
+			b.RemoteID = &remoteID
+			return b, nil
+		}
+	}
+	return domain.Board{}, fmt.Errorf("%w: %s", ErrBoardNotFound, boardID)
+}
+
+// Status returns a queue summary for every configured provider.
+func (e *SyncEngine) Status(ctx context.Context) ([]SyncProviderStatus, error) {
+	providers, err := e.setup.ListProviders(ctx)
+	if err != nil {
+		return nil, err
+	}
+	items, err := e.queue.List(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[string]int, len(providers))
+	statuses := make([]SyncProviderStatus, len(providers))
+	for i, p := range providers {
+		index[p.ID] = i
+		statuses[i] = SyncProviderStatus{Provider: p}
+	}
+	for _, item := range items {
+		i, ok := index[item.ProviderID]
+		if !ok {
+			continue
+		}
+		st := &statuses[i]
+		switch SyncItemState(item) {
+		case SyncStateFailed:
+			st.Failed++
+		case SyncStateRetrying:
+			st.Retrying++
+		default:
+			st.Pending++
+		}
+		if st.Oldest == nil || item.CreatedAt.Before(*st.Oldest) {
+			created := item.CreatedAt
+			st.Oldest = &created
+		}
+	}
+	return statuses, nil
+}
+
+// ListQueue returns queued items, optionally narrowed to a provider and a
+// state (pending, retrying or failed).
+func (e *SyncEngine) ListQueue(ctx context.Context, providerID, state string) ([]domain.SyncItem, error) {
+	state = strings.ToLower(strings.TrimSpace(state))
+	switch state {
+	case "", SyncStatePending, SyncStateRetrying, SyncStateFailed:
+	default:
+		return nil, fmt.Errorf("invalid sync state %q: must be pending, retrying, or failed", state)
+	}
+
+	items, err := e.queue.List(ctx, providerID)
+	if err != nil {
+		return nil, err
+	}
+	if state == "" {
+		return items, nil
+	}
+	filtered := make([]domain.SyncItem, 0, len(items))
+	for _, item := range items {
+		if SyncItemState(item) == state {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered, nil
+}
+
+// Retry makes items eligible for the next run again. With an empty itemID it
+// resets every item that ran out of attempts.
+func (e *SyncEngine) Retry(ctx context.Context, itemID string) (int, error) {
+	if strings.TrimSpace(itemID) == "" {
+		return e.queue.RetryExhausted(ctx, MaxSyncAttempts)
+	}
+	return e.queue.Retry(ctx, itemID)
+}
+
+// Purge drops items that ran out of attempts, or the whole queue when all is
+// set.
+func (e *SyncEngine) Purge(ctx context.Context, all bool) (int, error) {
+	if all {
+		return e.queue.PurgeAll(ctx)
+	}
+	return e.queue.PurgeExhausted(ctx, MaxSyncAttempts)
+}
+
+func (e *SyncEngine) providersByID(ctx context.Context) (map[string]domain.Provider, error) {
+	providers, err := e.setup.ListProviders(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]domain.Provider, len(providers))
+	for _, p := range providers {
+		byID[p.ID] = p
+	}
+	return byID, nil
+}
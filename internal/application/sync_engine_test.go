@@ -0,0 +1,633 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+type fakeSyncQueue struct {
+	items     []domain.SyncItem
+	completed []string
+	remoteIDs map[string]string
+	tasks     []domain.Task
+	comments  []domain.Comment
+	snapshots map[string]domain.SyncSnapshot
+	conflicts []domain.SyncConflict
+}
+
+func (q *fakeSyncQueue) List(ctx context.Context, providerID string) ([]domain.SyncItem, error) {
+	var out []domain.SyncItem
+	for _, item := range q.items {
+		if providerID == "" || item.ProviderID == providerID {
+			out = append(out, item)
+		}
+	}
+	return out, nil
+}
+
+func (q *fakeSyncQueue) Complete(ctx context.Context, itemID string) error {
+	q.completed = append(q.completed, itemID)
+	for i, item := range q.items {
+		if item.ID == itemID {
+			q.items = append(q.items[:i], q.items[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (q *fakeSyncQueue) RecordFailure(ctx context.Context, itemID string, lastError string, nextAttemptAt time.Time) error {
+	for i := range q.items {
+		if q.items[i].ID == itemID {
+			q.items[i].Attempts++
+			q.items[i].LastError = &lastError
+			q.items[i].NextAttemptAt = &nextAttemptAt
+		}
+	}
+	return nil
+}
+
+func (q *fakeSyncQueue) Retry(ctx context.Context, itemID string) (int, error) {
+	return 0, nil
+}
+
+func (q *fakeSyncQueue) RetryExhausted(ctx context.Context, maxAttempts int) (int, error) {
+	return 0, nil
+}
+
+func (q *fakeSyncQueue) PurgeExhausted(ctx context.Context, maxAttempts int) (int, error) {
+	return 0, nil
+}
+
+func (q *fakeSyncQueue) PurgeAll(ctx context.Context) (int, error) {
+	return 0, nil
+}
+
+func (q *fakeSyncQueue) ResolveRemoteRefs(ctx context.Context, refs domain.LocalRefs) (domain.RemoteRefs, error) {
+	return domain.RemoteRefs{Task: q.remoteIDs[refs.TaskID]}, nil
+}
+
+func (q *fakeSyncQueue) SetRemoteID(ctx context.Context, entity, entityID, remoteID string) error {
+	if q.remoteIDs == nil {
+		q.remoteIDs = make(map[string]string)
+	}
+	q.remoteIDs[entityID] = remoteID
+	return nil
+}
+
+func (q *fakeSyncQueue) ListBoardTasks(ctx context.Context, workspaceID, boardID string) ([]domain.Task, error) {
+	return q.tasks, nil
+}
+
+func (q *fakeSyncQueue) ListTaskComments(ctx context.Context, taskID string) ([]domain.Comment, error) {
+	var out []domain.Comment
+	for _, c := range q.comments {
+		if c.TaskID == taskID {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+func (q *fakeSyncQueue) ImportTask(ctx context.Context, task domain.Task, place domain.PlaceFunc) error {
+	index := slices.IndexFunc(q.tasks, func(t domain.Task) bool { return t.ID == task.ID })
+	if place != nil && (index < 0 || columnOf(q.tasks[index]) != columnOf(task)) {
+		var siblings []domain.Task
+		for _, t := range q.tasks {
+			if t.ID != task.ID && columnOf(t) == columnOf(task) {
+				siblings = append(siblings, t)
+			}
+		}
+		position, _, err := place(siblings)
+		if err != nil {
+			return err
+		}
+		task.Position = position
+	}
+	if index >= 0 {
+		q.tasks[index] = task
+		return nil
+	}
+	q.tasks = append(q.tasks, task)
+	return nil
+}
+
+func (q *fakeSyncQueue) ImportComment(ctx context.Context, comment domain.Comment) error {
+	for i := range q.comments {
+		if q.comments[i].ID == comment.ID {
+			q.comments[i] = comment
+			return nil
+		}
+	}
+	q.comments = append(q.comments, comment)
+	return nil
+}
+
+func (q *fakeSyncQueue) GetSnapshot(ctx context.Context, entity, entityID string) (*domain.SyncSnapshot, error) {
+	snapshot, ok := q.snapshots[entity+"/"+entityID]
+	if !ok {
+		return nil, nil
+	}
+	return &snapshot, nil
+}
+
+func (q *fakeSyncQueue) SaveSnapshot(ctx context.Context, snapshot domain.SyncSnapshot) error {
+	if q.snapshots == nil {
+		q.snapshots = make(map[string]domain.SyncSnapshot)
+	}
+	q.snapshots[snapshot.Entity+"/"+snapshot.EntityID] = snapshot
+	return nil
+}
+
+func (q *fakeSyncQueue) ForgetEntity(ctx context.Context, entity, entityID string) error {
+	delete(q.snapshots, entity+"/"+entityID)
+	return q.DeleteConflict(ctx, entity, entityID)
+}
+
+func (q *fakeSyncQueue) ListConflicts(ctx context.Context, providerID string) ([]domain.SyncConflict, error) {
+	var out []domain.SyncConflict
+	for _, c := range q.conflicts {
+		if providerID == "" || c.ProviderID == providerID {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+func (q *fakeSyncQueue) SaveConflict(ctx context.Context, conflict domain.SyncConflict) error {
+	for i := range q.conflicts {
+		if q.conflicts[i].Entity == conflict.Entity && q.conflicts[i].EntityID == conflict.EntityID {
+			conflict.ID = q.conflicts[i].ID
+			q.conflicts[i] = conflict
+			return nil
+		}
+	}
+	if conflict.ID == "" {
+		conflict.ID = "conflict-" + conflict.EntityID
+	}
+	q.conflicts = append(q.conflicts, conflict)
+	return nil
+}
+
+func (q *fakeSyncQueue) DeleteConflict(ctx context.Context, entity, entityID string) error {
+	for i := range q.conflicts {
+		if q.conflicts[i].Entity == entity && q.conflicts[i].EntityID == entityID {
+			q.conflicts = append(q.conflicts[:i], q.conflicts[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (q *fakeSyncQueue) MergeTask(ctx context.Context, task domain.Task, base domain.SyncSnapshot, place domain.PlaceFunc) error {
+	if err := q.ImportTask(ctx, task, place); err != nil {
+		return err
+	}
+	kept := q.items[:0]
+	for _, item := range q.items {
+		if item.Entity != domain.SyncEntityTask || item.EntityID != task.ID {
+			kept = append(kept, item)
+		}
+	}
+	payload, _ := json.Marshal(task)
+	q.items = append(kept, domain.SyncItem{
+		ID:          "merge-" + task.ID,
+		ProviderID:  task.ProviderID,
+		Entity:      domain.SyncEntityTask,
+		EntityID:    task.ID,
+		Action:      domain.SyncActionUpdate,
+		PayloadJSON: string(payload),
+	})
+	if err := q.SaveSnapshot(ctx, base); err != nil {
+		return err
+	}
+	return q.DeleteConflict(ctx, domain.SyncEntityTask, task.ID)
+}
+
+// fakeProviderClient pushes tasks only; the embedded interface leaves every
+// other method unimplemented.
+type fakeProviderClient struct {
+	domain.ProviderClient
+	fail     map[string]error
+	pushed   []string
+	seenRefs []domain.RemoteRefs
+}
+
+func (c *fakeProviderClient) Type() string { return "fake" }
+func (c *fakeProviderClient) Name() string { return "Fake" }
+func (c *fakeProviderClient) Capabilities() domain.ProviderCapabilities {
+	return domain.ProviderCapabilities{Push: true, Entities: []string{domain.SyncEntityTask}}
+}
+func (c *fakeProviderClient) PushTask(ctx context.Context, action string, task domain.Task, refs domain.RemoteRefs) (string, error) {
+	if err := c.fail[task.ID]; err != nil {
+		return "", err
+	}
+	c.pushed = append(c.pushed, task.ID)
+	c.seenRefs = append(c.seenRefs, refs)
+	return "remote-" + task.ID, nil
+}
+
+func newTestSyncEngine(queue *fakeSyncQueue, client *fakeProviderClient) *SyncEngine {
+	setup := &fakeSetupRepo{providers: []domain.Provider{{ID: "p1", Type: "fake", Name: "Fake"}}}
+	engine := NewSyncEngine(queue, setup, func(domain.Provider) (domain.ProviderClient, error) {
+		return client, nil
+	})
+	engine.now = func() time.Time { return time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC) }
+	return engine
+}
+
+func TestSyncEngine_Run_PushesAndCompletes(t *testing.T) {
+	queue := &fakeSyncQueue{items: []domain.SyncItem{
+		{ID: "i1", ProviderID: "p1", Entity: "task", EntityID: "t1", PayloadJSON: `{"ID":"t1"}`, Action: "create"},
+		{ID: "i2", ProviderID: "p1", Entity: "task", EntityID: "t2", PayloadJSON: `{"ID":"t2"}`, Action: "create"},
+	}}
+	client := &fakeProviderClient{}
+	engine := newTestSyncEngine(queue, client)
+
+	result, err := engine.Run(context.Background(), SyncRunOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Pushed != 2 || result.Failed != 0 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if len(queue.items) != 0 {
+		t.Fatalf("expected empty queue, got %d items", len(queue.items))
+	}
+}
+
+func TestSyncEngine_Run_FailureBacksOffAndBlocksEntity(t *testing.T) {
+	queue := &fakeSyncQueue{items: []domain.SyncItem{
+		{ID: "i1", ProviderID: "p1", Entity: "task", EntityID: "t1", PayloadJSON: `{"ID":"t1"}`, Action: "create"},
+		{ID: "i2", ProviderID: "p1", Entity: "task", EntityID: "t1", PayloadJSON: `{"ID":"t1"}`, Action: "update"},
+		{ID: "i3", ProviderID: "p1", Entity: "task", EntityID: "t2", PayloadJSON: `{"ID":"t2"}`, Action: "create"},
+	}}
+	client := &fakeProviderClient{fail: map[string]error{"t1": errors.New("boom")}}
+	engine := newTestSyncEngine(queue, client)
+
+	result, err := engine.Run(context.Background(), SyncRunOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Pushed != 1 || result.Failed != 1 || result.Skipped != 1 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if len(client.pushed) != 1 || client.pushed[0] != "t2" {
+		t.Fatalf("expected only t2 pushed, got %v", client.pushed)
+	}
+
+	failed := queue.items[0]
+	if failed.Attempts != 1 || failed.LastError == nil || *failed.LastError != "boom" {
+		t.Fatalf("expected failure recorded, got %+v", failed)
+	}
+	wantNext := engine.now().Add(SyncBackoff(1))
+	if failed.NextAttemptAt == nil || !failed.NextAttemptAt.Equal(wantNext) {
+		t.Fatalf("NextAttemptAt = %v, want %v", failed.NextAttemptAt, wantNext)
+	}
+
+	// A second run inside the backoff window must not retry anything.
+	result, err = engine.Run(context.Background(), SyncRunOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Pushed != 0 || result.Failed != 0 || result.Skipped != 2 {
+		t.Fatalf("unexpected second result: %+v", result)
+	}
+}
+
+func TestSyncEngine_Run_SkipsExhaustedItems(t *testing.T) {
+	queue := &fakeSyncQueue{items: []domain.SyncItem{
+		{ID: "i1", ProviderID: "p1", Entity: "task", EntityID: "t1", PayloadJSON: `{"ID":"t1"}`, Action: "create", Attempts: MaxSyncAttempts},
+	}}
+	client := &fakeProviderClient{}
+	engine := newTestSyncEngine(queue, client)
+
+	result, err := engine.Run(context.Background(), SyncRunOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Skipped != 1 || len(client.pushed) != 0 {
+		t.Fatalf("expected exhausted item skipped, got %+v", result)
+	}
+}
+
+func TestSyncEngine_Run_RespectsLimit(t *testing.T) {
+	queue := &fakeSyncQueue{items: []domain.SyncItem{
+		{ID: "i1", ProviderID: "p1", Entity: "task", EntityID: "t1", PayloadJSON: `{"ID":"t1"}`},
+		{ID: "i2", ProviderID: "p1", Entity: "task", EntityID: "t2", PayloadJSON: `{"ID":"t2"}`},
+	}}
+	engine := newTestSyncEngine(queue, &fakeProviderClient{})
+
+	result, err := engine.Run(context.Background(), SyncRunOptions{Limit: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Pushed != 1 || len(queue.items) != 1 {
+		t.Fatalf("expected a single push, got %+v with %d left", result, len(queue.items))
+	}
+}
+
+func TestSyncEngine_Run_UnknownProviderRecordsFailure(t *testing.T) {
+	queue := &fakeSyncQueue{items: []domain.SyncItem{
+		{ID: "i1", ProviderID: "missing", Entity: "task", EntityID: "t1", PayloadJSON: `{"ID":"t1"}`},
+	}}
+	engine := newTestSyncEngine(queue, &fakeProviderClient{})
+
+	result, err := engine.Run(context.Background(), SyncRunOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Failed != 1 || queue.items[0].LastError == nil {
+		t.Fatalf("expected recorded failure, got %+v", result)
+	}
+}
+
+func TestSyncEngine_Run_StoresRemoteIDAndReusesIt(t *testing.T) {
+	queue := &fakeSyncQueue{items: []domain.SyncItem{
+		{ID: "i1", ProviderID: "p1", Entity: "task", EntityID: "t1", Action: "create", PayloadJSON: `{"ID":"t1"}`},
+		{ID: "i2", ProviderID: "p1", Entity: "task", EntityID: "t1", Action: "update", PayloadJSON: `{"ID":"t1"}`},
+	}}
+	client := &fakeProviderClient{}
+	engine := newTestSyncEngine(queue, client)
+
+	if _, err := engine.Run(context.Background(), SyncRunOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := queue.remoteIDs["t1"]; got != "remote-t1" {
+		t.Fatalf("stored remote id = %q, want remote-t1", got)
+	}
+	if len(client.seenRefs) != 2 || client.seenRefs[1].Task != "remote-t1" {
+		t.Fatalf("expected update to see the remote id from the create, got %+v", client.seenRefs)
+	}
+}
+
+func TestSyncEngine_Run_IgnoresUnsupportedEntities(t *testing.T) {
+	queue := &fakeSyncQueue{items: []domain.SyncItem{
+		{ID: "i1", ProviderID: "p1", Entity: "workspace", EntityID: "w1", Action: "create", PayloadJSON: `{"ID":"w1"}`},
+	}}
+	engine := newTestSyncEngine(queue, &fakeProviderClient{})
+
+	result, err := engine.Run(context.Background(), SyncRunOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Ignored != 1 || len(queue.items) != 0 {
+		t.Fatalf("expected ignored and completed item, got %+v", result)
+	}
+}
+
+// fakePullClient serves remote tasks and comments for pull tests.
+type fakePullClient struct {
+	domain.ProviderClient
+	tasks    []domain.Task
+	listErr  error
+	comments map[string][]domain.Comment
+}
+
+func (c *fakePullClient) Capabilities() domain.ProviderCapabilities {
+	return domain.ProviderCapabilities{
+		Pull:       true,
+		Entities:   []string{domain.SyncEntityTask, domain.SyncEntityComment},
+		TaskFields: []string{domain.TaskFieldTitle, domain.TaskFieldColumn, domain.TaskFieldLabels},
+	}
+}
+func (c *fakePullClient) ListTasks(ctx context.Context, boardRemoteID string) ([]domain.Task, error) {
+	return c.tasks, c.listErr
+}
+func (c *fakePullClient) ListComments(ctx context.Context, boardRemoteID, taskRemoteID string) ([]domain.Comment, error) {
+	return c.comments[taskRemoteID], nil
+}
+
+func TestSyncEngine_Run_PullsRemoteTasksAndComments(t *testing.T) {
+	str := func(v string) *string { return &v }
+	boardID, todo, done := "b1", "c1", "c2"
+	setup := &fakeSetupRepo{
+		providers:  []domain.Provider{{ID: "p1", Type: "fake", Name: "Fake"}},
+		workspaces: []domain.Workspace{{ID: "w1", ProviderID: "p1"}},
+		boards:     []domain.Board{{ID: boardID, WorkspaceID: "w1", RemoteID: str("acme/app")}},
+		columns: []domain.Column{
+			{ID: todo, BoardID: boardID, Name: "Todo", RemoteID: str("Todo")},
+			{ID: done, BoardID: boardID, Name: "Done", RemoteID: str("Done")},
+		},
+	}
+	queue := &fakeSyncQueue{
+		items: []domain.SyncItem{{ID: "i1", ProviderID: "p1", Entity: "task", EntityID: "t2", Attempts: MaxSyncAttempts}},
+		tasks: []domain.Task{
+			{ID: "t1", WorkspaceID: "w1", BoardID: &boardID, ColumnID: &todo, RemoteID: str("1"), Title: "Old"},
+			{ID: "t2", WorkspaceID: "w1", BoardID: &boardID, ColumnID: &todo, RemoteID: str("2"), Title: "Local edit"},
+		},
+	}
+	client := &fakePullClient{
+		tasks: []domain.Task{
+			{RemoteID: str("1"), Title: "New", Labels: []string{"Done", "bug"}},
+			{RemoteID: str("2"), Title: "Remote edit"},
+			{RemoteID: str("3"), Title: "Fresh", Labels: []string{"todo"}},
+		},
+		comments: map[string][]domain.Comment{"1": {{RemoteID: str("100"), BodyMD: "hi"}}},
+	}
+	engine := NewSyncEngine(queue, setup, func(domain.Provider) (domain.ProviderClient, error) {
+		return client, nil
+	})
+
+	result, err := engine.Run(context.Background(), SyncRunOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Pulled != 3 || len(result.PullErrors) != 0 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	t1 := queue.tasks[0]
+	if t1.Title != "New" || *t1.ColumnID != done || len(t1.Labels) != 1 || t1.Labels[0] != "bug" {
+		t.Fatalf("t1 not merged: %+v", t1)
+	}
+	if t1.Position != positionStep {
+		t.Fatalf("t1 position = %v, want the bottom of its new column", t1.Position)
+	}
+	if queue.tasks[1].Title != "Local edit" {
+		t.Fatalf("task with queued changes must not be overwritten, got %q", queue.tasks[1].Title)
+	}
+	fresh := queue.tasks[2]
+	if fresh.Title != "Fresh" || *fresh.ColumnID != todo || fresh.ProviderID != "p1" || fresh.Priority != 3 {
+		t.Fatalf("unexpected imported task: %+v", fresh)
+	}
+	if fresh.Position != positionStep {
+		t.Fatalf("fresh position = %v, want a step below t2", fresh.Position)
+	}
+	if len(queue.comments) != 1 || queue.comments[0].TaskID != "t1" || queue.comments[0].BodyMD != "hi" {
+		t.Fatalf("unexpected comments: %+v", queue.comments)
+	}
+
+	// Pulling unchanged state again is a no-op.
+	result, err = engine.Run(context.Background(), SyncRunOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Pulled != 0 {
+		t.Fatalf("expected idempotent pull, got %+v", result)
+	}
+}
+
+func TestSyncEngine_Run_ReportsUnreadableRemoteTasks(t *testing.T) {
+	str := func(v string) *string { return &v }
+	boardID := "b1"
+	setup := &fakeSetupRepo{
+		providers:  []domain.Provider{{ID: "p1", Type: "fake", Name: "Fake"}},
+		workspaces: []domain.Workspace{{ID: "w1", ProviderID: "p1"}},
+		boards:     []domain.Board{{ID: boardID, WorkspaceID: "w1", Name: "Notes", RemoteID: str("notes")}},
+		columns:    []domain.Column{{ID: "c1", BoardID: boardID, Name: "Todo"}},
+	}
+	queue := &fakeSyncQueue{}
+	client := &fakePullClient{
+		tasks:   []domain.Task{{RemoteID: str("good.md"), Title: "Good"}},
+		listErr: &domain.PartialListError{Items: []error{errors.New("bad.md: invalid front matter")}},
+	}
+	engine := NewSyncEngine(queue, setup, func(domain.Provider) (domain.ProviderClient, error) {
+		return client, nil
+	})
+
+	result, err := engine.Run(context.Background(), SyncRunOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Pulled != 1 || len(queue.tasks) != 1 || queue.tasks[0].Title != "Good" {
+		t.Fatalf("expected the readable task pulled, got %+v and %+v", result, queue.tasks)
+	}
+	if len(result.PullErrors) != 1 || !strings.Contains(result.PullErrors[0], "bad.md") {
+		t.Fatalf("expected bad.md reported, got %v", result.PullErrors)
+	}
+}
+
+// remoteChangedClient refuses pushes older than its remote copy, as the
+// markdown provider does for files edited after the local change.
+type remoteChangedClient struct {
+	domain.ProviderClient
+	remote domain.Task
+	pushed []domain.Task
+}
+
+func (c *remoteChangedClient) Capabilities() domain.ProviderCapabilities {
+	return domain.ProviderCapabilities{
+		Push:       true,
+		Entities:   []string{domain.SyncEntityTask},
+		TaskFields: []string{domain.TaskFieldTitle, domain.TaskFieldLabels},
+	}
+}
+func (c *remoteChangedClient) PushTask(ctx context.Context, action string, task domain.Task, refs domain.RemoteRefs) (string, error) {
+	if !task.UpdatedAt.After(c.remote.UpdatedAt) {
+		return refs.Task, domain.ErrRemoteChanged
+	}
+	c.pushed = append(c.pushed, task)
+	return refs.Task, nil
+}
+func (c *remoteChangedClient) PullTask(ctx context.Context, boardRemoteID, remoteID string) (domain.Task, error) {
+	return c.remote, nil
+}
+
+func TestSyncEngine_Run_MergesRemoteChangeRefusingPush(t *testing.T) {
+	boardID := "b1"
+	edited := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	base := domain.Task{ID: "t1", WorkspaceID: "w1", BoardID: &boardID, Title: "Base", Labels: []string{}}
+	local := base
+	local.Title = "Local"
+	local.UpdatedAt = edited
+	newRun := func(remote domain.Task) (*fakeSyncQueue, *remoteChangedClient, SyncRunResult) {
+		t.Helper()
+		snapshot, _ := taskSnapshot("p1", base, edited)
+		payload, _ := json.Marshal(local)
+		queue := &fakeSyncQueue{
+			items:     []domain.SyncItem{{ID: "i1", ProviderID: "p1", Entity: "task", EntityID: "t1", Action: "update", PayloadJSON: string(payload)}},
+			tasks:     []domain.Task{local},
+			remoteIDs: map[string]string{"t1": "task.md"},
+			snapshots: map[string]domain.SyncSnapshot{"task/t1": snapshot},
+		}
+		client := &remoteChangedClient{remote: remote}
+		setup := &fakeSetupRepo{providers: []domain.Provider{{ID: "p1", Type: "fake", Name: "Fake"}}}
+		engine := NewSyncEngine(queue, setup, func(domain.Provider) (domain.ProviderClient, error) {
+			return client, nil
+		})
+		engine.now = func() time.Time { return time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC) }
+		result, err := engine.Run(context.Background(), SyncRunOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return queue, client, result
+	}
+
+	// The remote copy only got a newer timestamp: the local change goes out
+	// over it.
+	queue, client, result := newRun(domain.Task{Title: "Base", UpdatedAt: edited.Add(time.Hour)})
+	if result.Pushed != 1 || len(client.pushed) != 1 || client.pushed[0].Title != "Local" || len(queue.items) != 0 {
+		t.Fatalf("expected the local change pushed, got %+v, pushed %+v", result, client.pushed)
+	}
+
+	// The remote copy gained a label: it is merged into the queued change
+	// instead of being overwritten.
+	queue, client, result = newRun(domain.Task{Title: "Base", Labels: []string{"urgent"}, UpdatedAt: edited.Add(time.Hour)})
+	if result.Skipped != 1 || result.Failed != 0 || len(client.pushed) != 0 {
+		t.Fatalf("expected the push held for the merge, got %+v", result)
+	}
+	if len(queue.items) != 1 || queue.items[0].ID != "merge-t1" {
+		t.Fatalf("expected the queued change replaced by the merge, got %+v", queue.items)
+	}
+	if merged := queue.tasks[0]; merged.Title != "Local" || len(merged.Labels) != 1 || merged.Labels[0] != "urgent" {
+		t.Fatalf("task not merged: %+v", merged)
+	}
+}
+
+func TestSyncEngine_Status(t *testing.T) {
+	older := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	queue := &fakeSyncQueue{items: []domain.SyncItem{
+		{ID: "i1", ProviderID: "p1", CreatedAt: older.Add(time.Hour)},
+		{ID: "i2", ProviderID: "p1", Attempts: 2, CreatedAt: older},
+		{ID: "i3", ProviderID: "p1", Attempts: MaxSyncAttempts, CreatedAt: older.Add(2 * time.Hour)},
+	}}
+	engine := newTestSyncEngine(queue, &fakeProviderClient{})
+
+	statuses, err := engine.Status(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(statuses) != 1 {
+		t.Fatalf("expected 1 provider status, got %d", len(statuses))
+	}
+	st := statuses[0]
+	if st.Pending != 1 || st.Retrying != 1 || st.Failed != 1 {
+		t.Fatalf("unexpected counts: %+v", st)
+	}
+	if st.Oldest == nil || !st.Oldest.Equal(older) {
+		t.Fatalf("Oldest = %v, want %v", st.Oldest, older)
+	}
+}
+
+func TestSyncEngine_ListQueue_InvalidState(t *testing.T) {
+	engine := newTestSyncEngine(&fakeSyncQueue{}, &fakeProviderClient{})
+	if _, err := engine.ListQueue(context.Background(), "", "bogus"); err == nil {
+		t.Fatal("expected error for invalid state")
+	}
+}
+
+func TestSyncBackoff(t *testing.T) {
+	cases := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{0, 0},
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{20, time.Hour},
+	}
+	for _, tc := range cases {
+		if got := SyncBackoff(tc.attempts); got != tc.want {
+			t.Errorf("SyncBackoff(%d) = %v, want %v", tc.attempts, got, tc.want)
+		}
+	}
+}
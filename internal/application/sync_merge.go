@@ -0,0 +1,455 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+// Sides a sync conflict can be resolved to.
+const (
+	ConflictTakeLocal  = "local"
+	ConflictTakeRemote = "remote"
+)
+
+// ErrConflictNotFound is returned by ResolveConflict for an unknown conflict.
+var ErrConflictNotFound = errors.New("sync conflict not found")
+
+// taskMergeFields are the task fields compared in three-way merges.
+var taskMergeFields = []string{
+	domain.TaskFieldTitle,
+	domain.TaskFieldDescription,
+	domain.TaskFieldColumn,
+	domain.TaskFieldLabels,
+	domain.TaskFieldPriority,
+	domain.TaskFieldDue,
+}
+
+// TaskConflict is an open conflict together with the two task copies it is
+// between. Local is nil when the task no longer exists locally.
+type TaskConflict struct {
+	Conflict domain.SyncConflict
+	Local    *domain.Task
+	Remote   domain.Task
+}
+
+// reconcileTask merges a pulled task into its local copy.
+//
+// With a snapshot of the last synced state, fields changed on one side only
+// are taken from that side, and fields changed on both sides to different
+// values are recorded as a conflict; the task is then left alone until the
+// conflict is resolved. Without a snapshot the remote copy wins unless local
+// changes are still queued. It returns the task as stored afterwards.
+func (e *SyncEngine) reconcileTask(ctx context.Context, caps domain.ProviderCapabilities, columns []domain.Column, ws domain.Workspace, local, remote domain.Task, pending, conflicted bool, result *SyncRunResult) (domain.Task, error) {
+	now := e.now()
+	snapshot, err := e.queue.GetSnapshot(ctx, domain.SyncEntityTask, local.ID)
+	if err != nil {
+		return local, err
+	}
+	if snapshot == nil {
+		if pending {
+			// No common base: local changes win until they are pushed.
+			return local, nil
+		}
+		theirs := local
+		if mergePulledTask(&theirs, remote, caps, columns) {
+			theirs.UpdatedAt = pulledTime(remote.UpdatedAt, now)
+			if err := e.queue.ImportTask(ctx, theirs, placeFunc(theirs.ID, Placement{Bottom: true})); err != nil {
+				return local, err
+			}
+			result.Pulled++
+		}
+		return theirs, e.saveTaskSnapshot(ctx, ws.ProviderID, theirs)
+	}
+
+	var base domain.Task
+	if err := json.Unmarshal([]byte(snapshot.PayloadJSON), &base); err != nil {
+		return local, fmt.Errorf("decode task snapshot: %w", err)
+	}
+	// The remote copy is the base with the pulled fields applied, so fields
+	// the provider does not carry never look changed remotely.
+	theirs := base
+	mergePulledTask(&theirs, remote, caps, columns)
+	theirs.UpdatedAt = pulledTime(remote.UpdatedAt, now)
+	merged, fields := mergeTaskFields(base, local, theirs)
+	if len(fields) > 0 {
+		remoteJSON, err := json.Marshal(theirs)
+		if err != nil {
+			return local, err
+		}
+		if err := e.queue.SaveConflict(ctx, domain.SyncConflict{
+			ProviderID: ws.ProviderID,
+			Entity:     domain.SyncEntityTask,
+			EntityID:   local.ID,
+			Fields:     fields,
+			BaseJSON:   snapshot.PayloadJSON,
+			RemoteJSON: string(remoteJSON),
+			CreatedAt:  now,
+		}); err != nil {
+			return local, err
+		}
+		return local, nil
+	}
+
+	if sameTaskFields(theirs, base) {
+		// Nothing changed remotely; queued local changes go out as usual.
+		if conflicted {
+			return local, e.queue.DeleteConflict(ctx, domain.SyncEntityTask, local.ID)
+		}
+		return local, nil
+	}
+
+	if pending && !sameTaskFields(merged, theirs) {
+		merged.UpdatedAt = now
+		base, err := taskSnapshot(ws.ProviderID, theirs, now)
+		if err != nil {
+			return local, err
+		}
+		if err := e.queue.MergeTask(ctx, merged, base, placeFunc(merged.ID, Placement{Bottom: true})); err != nil {
+			return local, err
+		}
+	} else {
+		merged.UpdatedAt = theirs.UpdatedAt
+		if err := e.queue.ImportTask(ctx, merged, placeFunc(merged.ID, Placement{Bottom: true})); err != nil {
+			return local, err
+		}
+		if err := e.saveTaskSnapshot(ctx, ws.ProviderID, theirs); err != nil {
+			return local, err
+		}
+		if conflicted {
+			if err := e.queue.DeleteConflict(ctx, domain.SyncEntityTask, local.ID); err != nil {
+				return local, err
+			}
+		}
+	}
+	result.Pulled++
+	return merged, nil
+}
+
+// pushOverRemoteChange handles a task push the provider refused because the
+// remote copy changed after the local change. When the remote copy carries
+// changes since the last sync, they are merged as a pull merges them: the
+// queued change is replaced by the merged state, or held by a conflict.
+// When it carries none, only its timestamp moved, and the local change is
+// pushed over it. It reports whether the item was pushed.
+func (e *SyncEngine) pushOverRemoteChange(ctx context.Context, client domain.ProviderClient, item domain.SyncItem, result *SyncRunResult) (bool, error) {
+	var task domain.Task
+	if err := json.Unmarshal([]byte(item.PayloadJSON), &task); err != nil {
+		return false, fmt.Errorf("decode task payload: %w", err)
+	}
+	if task.BoardID == nil {
+		return false, domain.ErrRemoteChanged
+	}
+	refs, err := e.queue.ResolveRemoteRefs(ctx, domain.LocalRefs{WorkspaceID: task.WorkspaceID, BoardID: *task.BoardID, TaskID: task.ID})
+	if err != nil {
+		return false, err
+	}
+	if refs.Task == "" && task.RemoteID != nil {
+		refs.Task = *task.RemoteID
+	}
+	remote, err := client.PullTask(ctx, refs.Board, refs.Task)
+	if err != nil {
+		return false, err
+	}
+	columns, err := e.setup.ListColumns(ctx, *task.BoardID)
+	if err != nil {
+		return false, err
+	}
+	snapshot, err := e.queue.GetSnapshot(ctx, domain.SyncEntityTask, task.ID)
+	if err != nil {
+		return false, err
+	}
+
+	caps := client.Capabilities()
+	if snapshot != nil {
+		var base domain.Task
+		if err := json.Unmarshal([]byte(snapshot.PayloadJSON), &base); err != nil {
+			return false, fmt.Errorf("decode task snapshot: %w", err)
+		}
+		theirs := base
+		mergePulledTask(&theirs, remote, caps, columns)
+		if !sameTaskFields(theirs, base) {
+			tasks, err := e.queue.ListBoardTasks(ctx, task.WorkspaceID, *task.BoardID)
+			if err != nil {
+				return false, err
+			}
+			index := slices.IndexFunc(tasks, func(t domain.Task) bool { return t.ID == task.ID })
+			if index < 0 {
+				return false, domain.ErrRemoteChanged
+			}
+			ws := domain.Workspace{ID: task.WorkspaceID, ProviderID: item.ProviderID}
+			_, err = e.reconcileTask(ctx, caps, columns, ws, tasks[index], remote, true, false, result)
+			return false, err
+		}
+	}
+
+	// The pushed copy is dated now so the provider takes it over the newer
+	// remote timestamp.
+	task.UpdatedAt = e.now()
+	payload, err := json.Marshal(task)
+	if err != nil {
+		return false, err
+	}
+	item.PayloadJSON = string(payload)
+	return true, e.push(ctx, client, item)
+}
+
+// mergeTaskFields applies the remote side's changes since base to local and
+// returns the fields both sides changed to different values.
+func mergeTaskFields(base, local, remote domain.Task) (domain.Task, []string) {
+	merged := local
+	var conflicts []string
+	for _, field := range taskMergeFields {
+		localChanged := !sameTaskField(field, local, base)
+		remoteChanged := !sameTaskField(field, remote, base)
+		switch {
+		case remoteChanged && !localChanged:
+			copyTaskField(field, &merged, remote)
+		case remoteChanged && !sameTaskField(field, local, remote):
+			conflicts = append(conflicts, field)
+		}
+	}
+	return merged, conflicts
+}
+
+func sameTaskFields(a, b domain.Task) bool {
+	for _, field := range taskMergeFields {
+		if !sameTaskField(field, a, b) {
+			return false
+		}
+	}
+	return true
+}
+
+// sameTaskField compares one merge field. Surrounding whitespace in text is
+// not an edit; providers commonly trim it.
+func sameTaskField(field string, a, b domain.Task) bool {
+	switch field {
+	case domain.TaskFieldTitle:
+		return strings.TrimSpace(a.Title) == strings.TrimSpace(b.Title)
+	case domain.TaskFieldDescription:
+		return strings.TrimSpace(a.DescriptionMD) == strings.TrimSpace(b.DescriptionMD)
+	case domain.TaskFieldColumn:
+		return sameStringPtr(a.ColumnID, b.ColumnID)
+	case domain.TaskFieldLabels:
+		return sameLabels(a.Labels, b.Labels)
+	case domain.TaskFieldPriority:
+		return a.Priority == b.Priority
+	case domain.TaskFieldDue:
+		return sameTime(a.DueAt, b.DueAt)
+	}
+	return true
+}
+
+func copyTaskField(field string, dst *domain.Task, src domain.Task) {
+	switch field {
+	case domain.TaskFieldTitle:
+		dst.Title = src.Title
+	case domain.TaskFieldDescription:
+		dst.DescriptionMD = src.DescriptionMD
+	case domain.TaskFieldColumn:
+		dst.ColumnID = src.ColumnID
+		dst.Status = src.Status
+		dst.Position = src.Position
+	case domain.TaskFieldLabels:
+		dst.Labels = src.Labels
+	case domain.TaskFieldPriority:
+		dst.Priority = src.Priority
+	case domain.TaskFieldDue:
+		dst.DueAt = src.DueAt
+	}
+}
+
+// TaskFieldValue renders a merge field of a task for display.
+func TaskFieldValue(field string, task domain.Task) string {
+	switch field {
+	case domain.TaskFieldTitle:
+		return task.Title
+	case domain.TaskFieldDescription:
+		return task.DescriptionMD
+	case domain.TaskFieldColumn:
+		if task.Status != nil {
+			return *task.Status
+		}
+		if task.ColumnID != nil {
+			return *task.ColumnID
+		}
+		return ""
+	case domain.TaskFieldLabels:
+		return strings.Join(task.Labels, ", ")
+	case domain.TaskFieldPriority:
+		return strconv.Itoa(task.Priority)
+	case domain.TaskFieldDue:
+		if task.DueAt == nil {
+			return ""
+		}
+		return task.DueAt.UTC().Format(time.RFC3339)
+	}
+	return ""
+}
+
+func sameStringPtr(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func taskSnapshot(providerID string, task domain.Task, now time.Time) (domain.SyncSnapshot, error) {
+	payload, err := json.Marshal(task)
+	if err != nil {
+		return domain.SyncSnapshot{}, fmt.Errorf("encode task snapshot: %w", err)
+	}
+	return domain.SyncSnapshot{
+		ProviderID:  providerID,
+		Entity:      domain.SyncEntityTask,
+		EntityID:    task.ID,
+		PayloadJSON: string(payload),
+		SyncedAt:    now,
+	}, nil
+}
+
+func (e *SyncEngine) saveTaskSnapshot(ctx context.Context, providerID string, task domain.Task) error {
+	snapshot, err := taskSnapshot(providerID, task, e.now())
+	if err != nil {
+		return err
+	}
+	return e.queue.SaveSnapshot(ctx, snapshot)
+}
+
+// recordPushed keeps the snapshot of a pushed task in step with what the
+// provider now holds.
+func (e *SyncEngine) recordPushed(ctx context.Context, item domain.SyncItem, remoteID string) error {
+	if item.Entity != domain.SyncEntityTask {
+		return nil
+	}
+	if item.Action == domain.SyncActionDelete {
+		return e.queue.ForgetEntity(ctx, item.Entity, item.EntityID)
+	}
+	var task domain.Task
+	if err := json.Unmarshal([]byte(item.PayloadJSON), &task); err != nil {
+		return fmt.Errorf("decode task payload: %w", err)
+	}
+	if remoteID != "" {
+		task.RemoteID = &remoteID
+	}
+	return e.saveTaskSnapshot(ctx, item.ProviderID, task)
+}
+
+// ListConflicts returns open conflicts with their local and remote task
+// copies, optionally narrowed to a provider.
+func (e *SyncEngine) ListConflicts(ctx context.Context, providerID string) ([]TaskConflict, error) {
+	conflicts, err := e.queue.ListConflicts(ctx, providerID)
+	if err != nil {
+		return nil, err
+	}
+	boards := make(map[string][]domain.Task)
+	result := make([]TaskConflict, 0, len(conflicts))
+	for _, c := range conflicts {
+		tc := TaskConflict{Conflict: c}
+		if err := json.Unmarshal([]byte(c.RemoteJSON), &tc.Remote); err != nil {
+			return nil, fmt.Errorf("decode conflict %s: %w", c.ID, err)
+		}
+		local, err := e.localTask(ctx, boards, tc.Remote)
+		if err != nil {
+			return nil, err
+		}
+		tc.Local = local
+		result = append(result, tc)
+	}
+	return result, nil
+}
+
+// ConflictedTaskIDs returns the IDs of tasks with an open conflict.
+func (e *SyncEngine) ConflictedTaskIDs(ctx context.Context) (map[string]bool, error) {
+	conflicts, err := e.queue.ListConflicts(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[string]bool, len(conflicts))
+	for _, c := range conflicts {
+		if c.Entity == domain.SyncEntityTask {
+			ids[c.EntityID] = true
+		}
+	}
+	return ids, nil
+}
+
+// ResolveConflict settles a conflict, found by conflict or task ID, by taking
+// the local or remote value of every conflicting field. Changes made on only
+// one side are kept. The resolved task is queued as one update so the
+// provider receives it on the next run.
+func (e *SyncEngine) ResolveConflict(ctx context.Context, id, take string) (domain.Task, error) {
+	take = strings.ToLower(strings.TrimSpace(take))
+	if take != ConflictTakeLocal && take != ConflictTakeRemote {
+		return domain.Task{}, fmt.Errorf("invalid side %q: must be local or remote", take)
+	}
+	conflicts, err := e.ListConflicts(ctx, "")
+	if err != nil {
+		return domain.Task{}, err
+	}
+	for _, tc := range conflicts {
+		if tc.Conflict.ID != id && tc.Conflict.EntityID != id {
+			continue
+		}
+		if tc.Local == nil {
+			if err := e.queue.ForgetEntity(ctx, tc.Conflict.Entity, tc.Conflict.EntityID); err != nil {
+				return domain.Task{}, err
+			}
+			return domain.Task{}, fmt.Errorf("task %s no longer exists; conflict dropped", tc.Conflict.EntityID)
+		}
+
+		var base domain.Task
+		if err := json.Unmarshal([]byte(tc.Conflict.BaseJSON), &base); err != nil {
+			return domain.Task{}, fmt.Errorf("decode conflict %s: %w", tc.Conflict.ID, err)
+		}
+		merged, fields := mergeTaskFields(base, *tc.Local, tc.Remote)
+		if take == ConflictTakeRemote {
+			for _, field := range fields {
+				copyTaskField(field, &merged, tc.Remote)
+			}
+		}
+		now := e.now()
+		merged.UpdatedAt = now
+		snapshot, err := taskSnapshot(tc.Conflict.ProviderID, tc.Remote, now)
+		if err != nil {
+			return domain.Task{}, err
+		}
+		if err := e.queue.MergeTask(ctx, merged, snapshot, placeFunc(merged.ID, Placement{Bottom: true})); err != nil {
+			return domain.Task{}, err
+		}
+		return merged, nil
+	}
+	return domain.Task{}, fmt.Errorf("%w: %s", ErrConflictNotFound, id)
+}
+
+// localTask finds the current local copy of a task, caching board listings.
+func (e *SyncEngine) localTask(ctx context.Context, boards map[string][]domain.Task, task domain.Task) (*domain.Task, error) {
+	if task.BoardID == nil {
+		return nil, nil
+	}
+	key := task.WorkspaceID + "/" + *task.BoardID
+	tasks, ok := boards[key]
+	if !ok {
+		var err error
+		tasks, err = e.queue.ListBoardTasks(ctx, task.WorkspaceID, *task.BoardID)
+		if err != nil {
+			return nil, err
+		}
+		boards[key] = tasks
+	}
+	for i := range tasks {
+		if tasks[i].ID == task.ID {
+			local := tasks[i]
+			return &local, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,123 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+func TestMergeTaskFields(t *testing.T) {
+	base := domain.Task{Title: "Base", DescriptionMD: "body", Priority: 3, Labels: []string{"a"}}
+
+	local := base
+	local.DescriptionMD = "local body"
+	local.Priority = 1
+	local.Labels = []string{"b"}
+	remote := base
+	remote.Title = "Remote title"
+	remote.Priority = 2
+	remote.Labels = []string{"b"}
+
+	merged, conflicts := mergeTaskFields(base, local, remote)
+	if merged.Title != "Remote title" {
+		t.Fatalf("remote-only change not taken: %q", merged.Title)
+	}
+	if merged.DescriptionMD != "local body" {
+		t.Fatalf("local-only change lost: %q", merged.DescriptionMD)
+	}
+	if len(conflicts) != 1 || conflicts[0] != domain.TaskFieldPriority {
+		t.Fatalf("conflicts = %v, want only priority (labels changed the same way)", conflicts)
+	}
+}
+
+func TestSyncEngine_Run_MergesAndHoldsConflicts(t *testing.T) {
+	str := func(v string) *string { return &v }
+	boardID, todo := "b1", "c1"
+	setup := &fakeSetupRepo{
+		providers:  []domain.Provider{{ID: "p1", Type: "fake", Name: "Fake"}},
+		workspaces: []domain.Workspace{{ID: "w1", ProviderID: "p1"}},
+		boards:     []domain.Board{{ID: boardID, WorkspaceID: "w1", RemoteID: str("acme/app")}},
+		columns:    []domain.Column{{ID: todo, BoardID: boardID, Name: "Todo"}},
+	}
+	base1 := domain.Task{ID: "t1", ProviderID: "p1", WorkspaceID: "w1", BoardID: &boardID, ColumnID: &todo, RemoteID: str("1"), Title: "One", DescriptionMD: "d"}
+	base2 := domain.Task{ID: "t2", ProviderID: "p1", WorkspaceID: "w1", BoardID: &boardID, ColumnID: &todo, RemoteID: str("2"), Title: "Two"}
+	local1, local2 := base1, base2
+	local1.DescriptionMD = "local d"
+	local2.Title = "Two (local)"
+
+	queue := &fakeSyncQueue{
+		items: []domain.SyncItem{
+			{ID: "i1", ProviderID: "p1", Entity: "task", EntityID: "t1", Action: "update", PayloadJSON: mustJSON(t, local1)},
+			{ID: "i2", ProviderID: "p1", Entity: "task", EntityID: "t2", Action: "update", PayloadJSON: mustJSON(t, local2)},
+		},
+		tasks: []domain.Task{local1, local2},
+	}
+	for _, base := range []domain.Task{base1, base2} {
+		snapshot, err := taskSnapshot("p1", base, base.UpdatedAt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = queue.SaveSnapshot(context.Background(), snapshot)
+	}
+	client := &fakePullClient{tasks: []domain.Task{
+		{RemoteID: str("1"), Title: "One (remote)"},
+		{RemoteID: str("2"), Title: "Two (remote)"},
+	}}
+	engine := NewSyncEngine(queue, setup, func(domain.Provider) (domain.ProviderClient, error) {
+		return client, nil
+	})
+
+	result, err := engine.Run(context.Background(), SyncRunOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Pulled != 1 || result.Conflicts != 1 || result.Skipped != 1 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if got := queue.tasks[0]; got.Title != "One (remote)" || got.DescriptionMD != "local d" {
+		t.Fatalf("t1 not auto-merged: %+v", got)
+	}
+	if got := queue.tasks[1]; got.Title != "Two (local)" {
+		t.Fatalf("conflicted task must be left alone, got %q", got.Title)
+	}
+	if len(queue.items) != 1 || queue.items[0].ID != "i2" {
+		t.Fatalf("expected the conflicted task's change to be held, got %+v", queue.items)
+	}
+
+	conflicts, err := engine.ListConflicts(context.Background(), "")
+	if err != nil || len(conflicts) != 1 {
+		t.Fatalf("list conflicts = %+v, %v", conflicts, err)
+	}
+	c := conflicts[0]
+	if c.Local == nil || c.Local.Title != "Two (local)" || c.Remote.Title != "Two (remote)" || c.Conflict.Fields[0] != domain.TaskFieldTitle {
+		t.Fatalf("unexpected conflict: %+v", c)
+	}
+
+	resolved, err := engine.ResolveConflict(context.Background(), "t2", ConflictTakeRemote)
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if resolved.Title != "Two (remote)" || queue.tasks[1].Title != "Two (remote)" {
+		t.Fatalf("remote side not taken: %+v", resolved)
+	}
+	if len(queue.conflicts) != 0 {
+		t.Fatalf("conflict should be dropped, got %+v", queue.conflicts)
+	}
+	if len(queue.items) != 1 || queue.items[0].ID != "merge-t2" {
+		t.Fatalf("expected one update with the resolved task, got %+v", queue.items)
+	}
+	if _, err := engine.ResolveConflict(context.Background(), "t2", ConflictTakeLocal); err == nil {
+		t.Fatal("expected resolving twice to fail")
+	}
+}
+
+func mustJSON(t *testing.T, v any) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
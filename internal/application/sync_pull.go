@@ -0,0 +1,323 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+// pull imports remote tasks and comments for every linked board (one with a
+// remote ID) whose provider can pull. Tasks are merged three ways against
+// their last synced snapshot (see reconcileTask); comments with changes still
+// in the queue are left alone. Tasks deleted locally but not yet pushed are
+// not re-imported. Errors are collected per board or task and do not stop the
+// run.
+func (e *SyncEngine) pull(ctx context.Context, opts SyncRunOptions, providers map[string]domain.Provider, clients map[string]domain.ProviderClient, result *SyncRunResult) error {
+	items, err := e.queue.List(ctx, opts.ProviderID)
+	if err != nil {
+		return err
+	}
+	pending := make(map[string]bool, len(items))
+	for _, item := range items {
+		pending[item.Entity+"/"+item.EntityID] = true
+		if item.Entity != domain.SyncEntityTask || item.Action != domain.SyncActionDelete {
+			continue
+		}
+		var deleted domain.Task
+		if err := json.Unmarshal([]byte(item.PayloadJSON), &deleted); err == nil && deleted.RemoteID != nil {
+			pending[deletedRemoteKey(item.ProviderID, *deleted.RemoteID)] = true
+		}
+	}
+	conflicts, err := e.queue.ListConflicts(ctx, opts.ProviderID)
+	if err != nil {
+		return err
+	}
+	conflicted := make(map[string]bool, len(conflicts))
+	for _, c := range conflicts {
+		conflicted[c.Entity+"/"+c.EntityID] = true
+	}
+
+	workspaces, err := e.setup.ListWorkspaces(ctx)
+	if err != nil {
+		return err
+	}
+	for _, ws := range workspaces {
+		if opts.ProviderID != "" && ws.ProviderID != opts.ProviderID {
+			continue
+		}
+		client, err := e.client(providers, clients, ws.ProviderID)
+		if err != nil {
+			result.PullErrors = append(result.PullErrors, fmt.Sprintf("workspace %s: %v", ws.Name, err))
+			continue
+		}
+		caps := client.Capabilities()
+		if !caps.Pull || !caps.Supports(domain.SyncEntityTask) {
+			continue
+		}
+
+		boards, err := e.setup.ListBoards(ctx, ws.ID)
+		if err != nil {
+			return err
+		}
+		for _, board := range boards {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if board.RemoteID == nil || *board.RemoteID == "" {
+				continue
+			}
+			if err := e.pullBoard(ctx, client, caps, ws, board, pending, conflicted, result); err != nil {
+				result.PullErrors = append(result.PullErrors, fmt.Sprintf("board %s: %v", board.Name, err))
+			}
+		}
+	}
+	return nil
+}
+
+func (e *SyncEngine) pullBoard(ctx context.Context, client domain.ProviderClient, caps domain.ProviderCapabilities, ws domain.Workspace, board domain.Board, pending, conflicted map[string]bool, result *SyncRunResult) error {
+	remoteTasks, err := client.ListTasks(ctx, *board.RemoteID)
+	if errors.Is(err, domain.ErrProviderUnsupported) {
+		return nil
+	}
+	// Tasks the provider could not read are reported one by one; the rest
+	// are pulled.
+	var partial *domain.PartialListError
+	if errors.As(err, &partial) {
+		for _, itemErr := range partial.Items {
+			result.PullErrors = append(result.PullErrors, fmt.Sprintf("board %s: %v", board.Name, itemErr))
+		}
+		err = nil
+	}
+	if err != nil {
+		return err
+	}
+	columns, err := e.setup.ListColumns(ctx, board.ID)
+	if err != nil {
+		return err
+	}
+	localTasks, err := e.queue.ListBoardTasks(ctx, ws.ID, board.ID)
+	if err != nil {
+		return err
+	}
+	byRemote := make(map[string]domain.Task, len(localTasks))
+	for _, t := range localTasks {
+		if t.RemoteID != nil && *t.RemoteID != "" {
+			byRemote[*t.RemoteID] = t
+		}
+	}
+
+	for _, remote := range remoteTasks {
+		if remote.RemoteID == nil || *remote.RemoteID == "" {
+			continue
+		}
+		task, found := byRemote[*remote.RemoteID]
+		switch {
+		case found:
+			key := domain.SyncEntityTask + "/" + task.ID
+			task, err = e.reconcileTask(ctx, caps, columns, ws, task, remote, pending[key], conflicted[key], result)
+			if err != nil {
+				return err
+			}
+		case pending[deletedRemoteKey(ws.ProviderID, *remote.RemoteID)]:
+			continue
+		default:
+			now := e.now()
+			task = domain.Task{
+				ID:          uuid.NewString(),
+				ProviderID:  ws.ProviderID,
+				WorkspaceID: ws.ID,
+				BoardID:     &board.ID,
+				RemoteID:    remote.RemoteID,
+				Priority:    3,
+				Labels:      []string{},
+				CreatedAt:   pulledTime(remote.CreatedAt, now),
+				UpdatedAt:   pulledTime(remote.UpdatedAt, now),
+			}
+			mergePulledTask(&task, remote, caps, columns)
+			if task.ColumnID == nil && len(columns) > 0 {
+				first := columns[0]
+				status := strings.ToLower(first.Name)
+				task.ColumnID = &first.ID
+				task.Status = &status
+			}
+			if err := e.queue.ImportTask(ctx, task, placeFunc(task.ID, Placement{Bottom: true})); err != nil {
+				return err
+			}
+			if err := e.saveTaskSnapshot(ctx, ws.ProviderID, task); err != nil {
+				return err
+			}
+			result.Pulled++
+		}
+
+		if caps.Supports(domain.SyncEntityComment) {
+			if err := e.pullComments(ctx, client, board, task, pending, result); err != nil {
+				result.PullErrors = append(result.PullErrors, fmt.Sprintf("task %s comments: %v", task.Title, err))
+			}
+		}
+	}
+	return nil
+}
+
+func (e *SyncEngine) pullComments(ctx context.Context, client domain.ProviderClient, board domain.Board, task domain.Task, pending map[string]bool, result *SyncRunResult) error {
+	remoteComments, err := client.ListComments(ctx, *board.RemoteID, *task.RemoteID)
+	if errors.Is(err, domain.ErrProviderUnsupported) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	localComments, err := e.queue.ListTaskComments(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+	byRemote := make(map[string]domain.Comment, len(localComments))
+	for _, c := range localComments {
+		if c.RemoteID != nil && *c.RemoteID != "" {
+			byRemote[*c.RemoteID] = c
+		}
+	}
+
+	for _, remote := range remoteComments {
+		if remote.RemoteID == nil || *remote.RemoteID == "" {
+			continue
+		}
+		comment, found := byRemote[*remote.RemoteID]
+		if found {
+			if pending[domain.SyncEntityComment+"/"+comment.ID] || comment.BodyMD == remote.BodyMD {
+				continue
+			}
+			comment.BodyMD = remote.BodyMD
+		} else {
+			comment = domain.Comment{
+				ID:         uuid.NewString(),
+				TaskID:     task.ID,
+				ProviderID: task.ProviderID,
+				RemoteID:   remote.RemoteID,
+				BodyMD:     remote.BodyMD,
+				Author:     remote.Author,
+				CreatedAt:  pulledTime(remote.CreatedAt, e.now()),
+			}
+		}
+		if err := e.queue.ImportComment(ctx, comment); err != nil {
+			return err
+		}
+		result.Pulled++
+	}
+	return nil
+}
+
+func deletedRemoteKey(providerID, remoteID string) string {
+	return "deleted/" + providerID + "/" + remoteID
+}
+
+// mergePulledTask copies the fields the provider carries from remote onto
+// task and reports whether anything changed. A task changing column is
+// placed at the bottom of the new one when it is stored.
+func mergePulledTask(task *domain.Task, remote domain.Task, caps domain.ProviderCapabilities, columns []domain.Column) bool {
+	changed := false
+	column, labels := pulledColumn(remote, columns)
+
+	if caps.CarriesTaskField(domain.TaskFieldTitle) && remote.Title != "" && task.Title != remote.Title {
+		task.Title = remote.Title
+		changed = true
+	}
+	if caps.CarriesTaskField(domain.TaskFieldDescription) && task.DescriptionMD != remote.DescriptionMD {
+		task.DescriptionMD = remote.DescriptionMD
+		changed = true
+	}
+	if caps.CarriesTaskField(domain.TaskFieldColumn) && column != nil &&
+		(task.ColumnID == nil || *task.ColumnID != column.ID) {
+		status := strings.ToLower(column.Name)
+		task.ColumnID = &column.ID
+		task.Status = &status
+		changed = true
+	}
+	if caps.CarriesTaskField(domain.TaskFieldLabels) && !sameLabels(task.Labels, labels) {
+		task.Labels = labels
+		changed = true
+	}
+	if caps.CarriesTaskField(domain.TaskFieldPriority) && task.Priority != remote.Priority {
+		task.Priority = remote.Priority
+		changed = true
+	}
+	if caps.CarriesTaskField(domain.TaskFieldDue) && !sameTime(task.DueAt, remote.DueAt) {
+		task.DueAt = remote.DueAt
+		changed = true
+	}
+	return changed
+}
+
+// pulledColumn finds the local column a pulled task belongs to, by status
+// first and then by a label equal to a column's remote ID. Column labels are
+// removed from the returned labels.
+func pulledColumn(remote domain.Task, columns []domain.Column) (*domain.Column, []string) {
+	var found *domain.Column
+	if remote.Status != nil {
+		for i := range columns {
+			c := &columns[i]
+			if (c.RemoteID != nil && strings.EqualFold(*c.RemoteID, *remote.Status)) || strings.EqualFold(c.Name, *remote.Status) {
+				found = c
+				break
+			}
+		}
+	}
+
+	labels := make([]string, 0, len(remote.Labels))
+	for _, label := range remote.Labels {
+		var match *domain.Column
+		for i := range columns {
+			if columns[i].RemoteID != nil && strings.EqualFold(*columns[i].RemoteID, label) {
+				match = &columns[i]
+				break
+			}
+		}
+		if match == nil {
+			labels = append(labels, label)
+			continue
+		}
+		if found == nil {
+			found = match
+		}
+	}
+	return found, labels
+}
+
+func sameLabels(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	x := append([]string(nil), a...)
+	y := append([]string(nil), b...)
+	sort.Strings(x)
+	sort.Strings(y)
+	for i := range x {
+		if x[i] != y[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Equal(*b)
+}
+
+// pulledTime returns the provider timestamp, or fallback when the provider
+// did not report one.
+func pulledTime(t, fallback time.Time) time.Time {
+	if t.IsZero() {
+		return fallback
+	}
+	return t.UTC()
+}
@@ -0,0 +1,284 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+// BulkOptions adjusts a bulk change.
+type BulkOptions struct {
+	// Force skips the WIP limit check of a bulk move's destination column.
+	Force bool
+	// DryRun works out what the change would do without storing anything or
+	// running hooks.
+	DryRun bool
+}
+
+// BulkTaskResult is what a bulk change does to one task.
+type BulkTaskResult struct {
+	// Task is the task as it was before the change.
+	Task domain.Task
+	// Changed is false when the change leaves the task as it is.
+	Changed bool
+}
+
+// bulkStep is one task of a bulk change. change is nil for a task the change
+// leaves as it is.
+type bulkStep struct {
+	task     domain.Task
+	change   *domain.TaskChange
+	preHook  string
+	changes  map[string]any
+	postHook string
+}
+
+// BulkUpdateTasks applies input to every task in taskIDs in one transaction.
+// Moving tasks is left to TaskFlow.BulkMoveTasks, so input.ColumnID must be
+// nil.
+func (s *TaskService) BulkUpdateTasks(ctx context.Context, taskIDs []string, input UpdateTaskInput, opts BulkOptions) ([]BulkTaskResult, error) {
+	if input.ColumnID != nil {
+		return nil, errors.New("bulk updates cannot change the column; move the tasks instead")
+	}
+	patch := domain.TaskPatch{
+		Title:         trimStringPointer(input.Title),
+		DescriptionMD: input.DescriptionMD,
+		Status:        trimStringPointer(input.Status),
+		Priority:      input.Priority,
+		DueAt:         input.DueAt,
+		ClearDueAt:    input.ClearDueAt,
+		Labels:        normalizeLabelPatch(input.Labels),
+	}
+	tasks, err := loadBulkTasks(ctx, s.repo, taskIDs)
+	if err != nil {
+		return nil, err
+	}
+	steps := make([]bulkStep, len(tasks))
+	for i, task := range tasks {
+		steps[i] = s.updateStep(ctx, task, patch)
+	}
+	return runBulk(ctx, s.repo, s.hooks, steps, opts)
+}
+
+// BulkLabelTasks adds and removes labels on every task in taskIDs in one
+// transaction. Labels match case-insensitively.
+func (s *TaskService) BulkLabelTasks(ctx context.Context, taskIDs, add, remove []string, opts BulkOptions) ([]BulkTaskResult, error) {
+	add, remove = normalizeLabels(add), normalizeLabels(remove)
+	if len(add) == 0 && len(remove) == 0 {
+		return nil, errors.New("at least one label to add or remove is required")
+	}
+	tasks, err := loadBulkTasks(ctx, s.repo, taskIDs)
+	if err != nil {
+		return nil, err
+	}
+	steps := make([]bulkStep, len(tasks))
+	for i, task := range tasks {
+		labels := relabel(task.Labels, add, remove)
+		steps[i] = s.updateStep(ctx, task, domain.TaskPatch{Labels: &labels})
+	}
+	return runBulk(ctx, s.repo, s.hooks, steps, opts)
+}
+
+// BulkDeleteTasks deletes every task in taskIDs in one transaction.
+func (s *TaskService) BulkDeleteTasks(ctx context.Context, taskIDs []string, opts BulkOptions) ([]BulkTaskResult, error) {
+	tasks, err := loadBulkTasks(ctx, s.repo, taskIDs)
+	if err != nil {
+		return nil, err
+	}
+	steps := make([]bulkStep, len(tasks))
+	for i, task := range tasks {
+		steps[i] = bulkStep{
+			task:     task,
+			change:   &domain.TaskChange{TaskID: task.ID, Delete: true},
+			preHook:  domain.HookPreTaskDelete,
+			postHook: domain.HookTaskDeleted,
+		}
+	}
+	return runBulk(ctx, s.repo, s.hooks, steps, opts)
+}
+
+func (s *TaskService) updateStep(ctx context.Context, task domain.Task, patch domain.TaskPatch) bulkStep {
+	step := bulkStep{task: task}
+	if !patchChangesTask(task, patch) {
+		return step
+	}
+	version := task.Version
+	patch.IfVersion = &version
+	step.change = &domain.TaskChange{TaskID: task.ID, Patch: &patch}
+	step.preHook, step.postHook = domain.HookPreTaskUpdate, domain.HookTaskUpdated
+	if s.hooks != nil {
+		step.changes = s.hooks.updateHookChanges(ctx, task, patch)
+	}
+	return step
+}
+
+// BulkMoveTasks moves every task in taskIDs to columnID in one transaction.
+// The tasks must all be on the board of columnID; they keep their order and
+// go to the bottom of the column. Tasks already in the column stay where
+// they are. Unless opts.Force is set, the move fails when the column cannot
+// take all the incoming tasks under its WIP limit.
+func (f *TaskFlow) BulkMoveTasks(ctx context.Context, taskIDs []string, columnID, status string, opts BulkOptions) ([]BulkTaskResult, error) {
+	columnID, status = strings.TrimSpace(columnID), strings.TrimSpace(status)
+	if columnID == "" {
+		return nil, errors.New("column id is required")
+	}
+	tasks, err := loadBulkTasks(ctx, f.repo, taskIDs)
+	if err != nil {
+		return nil, err
+	}
+	var moving []domain.Task
+	for _, task := range tasks {
+		if task.BoardID == nil {
+			return nil, fmt.Errorf("task %s is not on a board", task.ID)
+		}
+		if *task.BoardID != *tasks[0].BoardID {
+			return nil, errors.New("tasks moved together must be on the same board")
+		}
+		if columnOf(task) != columnID {
+			moving = append(moving, task)
+		}
+	}
+
+	var limited *domain.Column
+	if len(moving) > 0 {
+		board := *moving[0].BoardID
+		columns, err := f.repo.ListColumns(ctx, board)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.ContainsFunc(columns, func(c domain.Column) bool { return c.ID == columnID }) {
+			return nil, fmt.Errorf("column %s is not on the board of the tasks", columnID)
+		}
+		if !opts.Force {
+			if limited, err = checkWIPCapacity(ctx, f.repo, moving[0].WorkspaceID, board, columnID, "", len(moving)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	now := time.Now().UTC()
+	steps := make([]bulkStep, len(tasks))
+	for i, task := range tasks {
+		steps[i].task = task
+		if columnOf(task) == columnID {
+			continue
+		}
+		version := task.Version
+		steps[i].change = &domain.TaskChange{TaskID: task.ID, Move: &domain.MoveTaskInput{
+			ColumnID:  &columnID,
+			Status:    &status,
+			UpdatedAt: now,
+			Place:     placeUnderWIPLimit(limited, placeFunc(task.ID, Placement{Bottom: true})),
+			IfVersion: &version,
+		}}
+		steps[i].preHook, steps[i].postHook = domain.HookPreTaskMove, domain.HookTaskMoved
+		if f.hooks != nil {
+			steps[i].changes = f.hooks.moveHookChanges(ctx, task, &columnID, &status)
+		}
+	}
+	return runBulk(ctx, f.repo, f.hooks, steps, opts)
+}
+
+// runBulk runs the pre hooks of the changed steps, stores their changes in
+// one transaction and runs the post hooks. A hook rejecting any task stops
+// the whole change before anything is stored.
+func runBulk(ctx context.Context, repo domain.TaskRepository, hooks *Hooks, steps []bulkStep, opts BulkOptions) ([]BulkTaskResult, error) {
+	results := make([]BulkTaskResult, len(steps))
+	var changes []domain.TaskChange
+	for i, step := range steps {
+		results[i] = BulkTaskResult{Task: step.task, Changed: step.change != nil}
+		if step.change != nil {
+			changes = append(changes, *step.change)
+		}
+	}
+	if opts.DryRun || len(changes) == 0 {
+		return results, nil
+	}
+
+	for _, step := range steps {
+		if step.change == nil {
+			continue
+		}
+		if err := hooks.pre(ctx, taskHookEvent(step.preHook, step.task, step.changes)); err != nil {
+			return nil, fmt.Errorf("task %s: %w", step.task.ID, err)
+		}
+	}
+	if err := repo.Bulk(ctx, changes); err != nil {
+		return nil, err
+	}
+	for _, step := range steps {
+		switch {
+		case step.change == nil:
+		case step.change.Delete:
+			hooks.post(ctx, taskHookEvent(step.postHook, step.task, nil))
+		default:
+			hooks.postTask(ctx, step.postHook, step.task.ID)
+		}
+	}
+	return results, nil
+}
+
+// loadBulkTasks loads the tasks of a bulk change, dropping repeated IDs.
+func loadBulkTasks(ctx context.Context, repo domain.TaskRepository, taskIDs []string) ([]domain.Task, error) {
+	tasks := make([]domain.Task, 0, len(taskIDs))
+	seen := make(map[string]struct{}, len(taskIDs))
+	for _, id := range taskIDs {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		task, err := repo.GetByID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("task %s: %w", id, err)
+		}
+		tasks = append(tasks, task)
+	}
+	if len(tasks) == 0 {
+		return nil, errors.New("at least one task id is required")
+	}
+	return tasks, nil
+}
+
+// patchChangesTask reports whether applying patch would change task.
+func patchChangesTask(task domain.Task, patch domain.TaskPatch) bool {
+	switch {
+	case patch.Title != nil && *patch.Title != task.Title,
+		patch.DescriptionMD != nil && *patch.DescriptionMD != task.DescriptionMD,
+		patch.Status != nil && (task.Status == nil || *patch.Status != *task.Status),
+		patch.Priority != nil && *patch.Priority != task.Priority,
+		patch.ClearDueAt && task.DueAt != nil,
+		!patch.ClearDueAt && patch.DueAt != nil && (task.DueAt == nil || !patch.DueAt.Equal(*task.DueAt)),
+		patch.Labels != nil && !slices.Equal(*patch.Labels, task.Labels):
+		return true
+	}
+	return false
+}
+
+// relabel returns labels without the ones in remove and with the ones in add
+// it does not have yet, keeping their order.
+func relabel(labels, add, remove []string) []string {
+	has := func(list []string, label string) bool {
+		return slices.ContainsFunc(list, func(l string) bool { return strings.EqualFold(l, label) })
+	}
+	out := make([]string, 0, len(labels)+len(add))
+	for _, label := range labels {
+		if !has(remove, label) {
+			out = append(out, label)
+		}
+	}
+	for _, label := range add {
+		if !has(out, label) {
+			out = append(out, label)
+		}
+	}
+	return out
+}
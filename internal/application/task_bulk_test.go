@@ -0,0 +1,99 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+func TestTaskService_BulkLabelTasks(t *testing.T) {
+	ctx := context.Background()
+	repo := &positionTaskRepo{fakeTaskRepo: fakeTaskRepo{tasks: []domain.Task{
+		{ID: "a", Labels: []string{"bug"}, Version: 3},
+		{ID: "b", Labels: []string{"ui", "Bug"}, Version: 5},
+	}}}
+	svc := NewTaskService(repo)
+
+	if _, err := svc.BulkLabelTasks(ctx, []string{"a"}, nil, []string{" "}, BulkOptions{}); err == nil {
+		t.Fatal("expected a label to be required")
+	}
+
+	results, err := svc.BulkLabelTasks(ctx, []string{"a", "b", "a"}, []string{"bug"}, []string{"UI"}, BulkOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if len(results) != 2 || results[0].Changed || !results[1].Changed || repo.lastBulk != nil {
+		t.Fatalf("dry run = %+v, stored %+v", results, repo.lastBulk)
+	}
+
+	if _, err := svc.BulkLabelTasks(ctx, []string{"a", "b"}, []string{"bug"}, []string{"UI"}, BulkOptions{}); err != nil {
+		t.Fatalf("BulkLabelTasks: %v", err)
+	}
+	if len(repo.lastBulk) != 1 {
+		t.Fatalf("changes = %+v, want only b", repo.lastBulk)
+	}
+	patch := repo.lastBulk[0].Patch
+	if repo.lastBulk[0].TaskID != "b" || !slices.Equal(*patch.Labels, []string{"Bug"}) || *patch.IfVersion != 5 {
+		t.Fatalf("change = %+v, labels %v", repo.lastBulk[0], *patch.Labels)
+	}
+}
+
+func TestTaskFlow_BulkMoveTasks(t *testing.T) {
+	ctx := context.Background()
+	board, todo, doing := "board", "todo", "doing"
+	limit := 2
+	repo := &positionTaskRepo{fakeTaskRepo: fakeTaskRepo{
+		columns: []domain.Column{{ID: todo, Name: "Todo"}, {ID: doing, Name: "Doing", WIPLimit: &limit}},
+		tasks: []domain.Task{
+			{ID: "a", BoardID: &board, ColumnID: &todo, Position: 1024},
+			{ID: "b", BoardID: &board, ColumnID: &todo, Position: 2048},
+			{ID: "c", BoardID: &board, ColumnID: &doing, Position: 1024},
+		},
+	}}
+	flow := NewTaskFlow(repo)
+
+	if _, err := flow.BulkMoveTasks(ctx, []string{"b", "a", "c"}, doing, "doing", BulkOptions{}); !errors.Is(err, ErrWIPLimitExceeded) {
+		t.Fatalf("expected the WIP limit to refuse two more tasks, got %v", err)
+	}
+	if _, err := flow.BulkMoveTasks(ctx, []string{"a"}, "elsewhere", "", BulkOptions{Force: true}); err == nil {
+		t.Fatal("expected a column of another board to be refused")
+	}
+
+	results, err := flow.BulkMoveTasks(ctx, []string{"b", "a", "c"}, doing, "doing", BulkOptions{Force: true})
+	if err != nil {
+		t.Fatalf("BulkMoveTasks: %v", err)
+	}
+	if len(results) != 3 || !results[0].Changed || !results[1].Changed || results[2].Changed {
+		t.Fatalf("results = %+v", results)
+	}
+	// The moved tasks keep their order below the task already in the column.
+	if len(repo.lastBulk) != 2 || repo.lastBulk[0].TaskID != "b" || repo.lastBulk[0].Move.Position != 2048 || repo.lastBulk[1].Move.Position != 3072 {
+		t.Fatalf("changes = %+v", repo.lastBulk)
+	}
+}
+
+func TestTaskService_BulkDeleteTasksStopsOnHookRejection(t *testing.T) {
+	repo := newHookTaskRepo()
+	runner := &fakeHookRunner{reject: map[string]bool{domain.HookPreTaskDelete: true}}
+	svc := NewTaskService(repo)
+	svc.SetHooks(NewHooks(runner, repo))
+
+	if _, err := svc.BulkDeleteTasks(context.Background(), []string{"t1"}, BulkOptions{}); !errors.Is(err, ErrHookRejected) {
+		t.Fatalf("expected a hook rejection, got %v", err)
+	}
+	if repo.lastBulk != nil {
+		t.Fatalf("rejected bulk delete must not reach the repository: %+v", repo.lastBulk)
+	}
+
+	runner.reject = nil
+	runner.events = nil
+	if _, err := svc.BulkDeleteTasks(context.Background(), []string{"t1"}, BulkOptions{}); err != nil {
+		t.Fatalf("BulkDeleteTasks: %v", err)
+	}
+	if len(repo.lastBulk) != 1 || !repo.lastBulk[0].Delete || len(runner.events) != 2 || runner.events[1].Hook != domain.HookTaskDeleted {
+		t.Fatalf("changes = %+v, events = %+v", repo.lastBulk, runner.events)
+	}
+}
@@ -11,25 +11,56 @@ import (
 )
 
 type TaskFlow struct {
-	repo domain.TaskRepository
+	repo  domain.TaskRepository
+	hooks *Hooks
 }
 
 func NewTaskFlow(repo domain.TaskRepository) *TaskFlow {
 	return &TaskFlow{repo: repo}
 }
 
+// SetHooks makes moves run the pre-task-move and task-moved hooks.
+func (f *TaskFlow) SetHooks(hooks *Hooks) {
+	f.hooks = hooks
+}
+
 func (f *TaskFlow) ListTasks(ctx context.Context, filters ListTaskFilters) ([]domain.Task, error) {
 	if strings.TrimSpace(filters.WorkspaceID) == "" {
 		return nil, errors.New("workspace id is required")
 	}
-	return f.repo.List(ctx, domain.TaskFilter{
+	now := time.Now().UTC()
+	filter := domain.TaskFilter{
 		WorkspaceID: filters.WorkspaceID,
 		BoardID:     filters.BoardID,
 		TitleQuery:  strings.TrimSpace(filters.TitleQuery),
 		ColumnID:    strings.TrimSpace(filters.ColumnID),
 		Status:      strings.TrimSpace(filters.Status),
-		DueSoonBy:   filters.DueSoonBy(time.Now().UTC()),
-	})
+		DueSoonBy:   filters.DueSoonBy(now),
+	}
+	if strings.TrimSpace(filters.Filter) != "" {
+		query, err := ParseTaskQuery(filters.Filter, now)
+		if err != nil {
+			return nil, err
+		}
+		filter.Query = &query
+	}
+	return f.repo.List(ctx, filter)
+}
+
+// CountTasksByColumn counts the tasks of a board by column ID the way WIP
+// limits do: whatever filters the caller shows the tasks through.
+func (f *TaskFlow) CountTasksByColumn(ctx context.Context, workspaceID, boardID string) (map[string]int, error) {
+	tasks, err := f.repo.List(ctx, domain.TaskFilter{WorkspaceID: workspaceID, BoardID: boardID})
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int)
+	for _, task := range tasks {
+		if column := columnOf(task); column != "" {
+			counts[column]++
+		}
+	}
+	return counts, nil
 }
 
 type AdjacentMoveResult struct {
@@ -63,7 +94,7 @@ func (f *TaskFlow) MoveTaskAdjacent(ctx context.Context, taskID string, columns
 	columnID := col.ID
 	status := strings.ToLower(col.Name)
 
-	err := f.MoveTask(ctx, taskID, &columnID, &status, float64(time.Now().UTC().UnixNano()))
+	err := f.MoveTask(ctx, taskID, &columnID, &status, 0)
 	if err != nil {
 		return AdjacentMoveResult{}, err
 	}
@@ -76,18 +107,123 @@ func (f *TaskFlow) MoveTaskAdjacent(ctx context.Context, taskID string, columns
 	}, nil
 }
 
+// MoveOptions adjusts how MoveTaskWith moves a task.
+type MoveOptions struct {
+	// Force skips the destination column's WIP limit check.
+	Force bool
+	// IfVersion makes the move fail with a *domain.VersionConflictError
+	// unless the task is still at that version.
+	IfVersion *int
+	// Placement says where the task goes in its destination column when the
+	// move is given no position.
+	Placement Placement
+}
+
+// MoveTask moves a task, refusing to enter a column that is already at its
+// WIP limit. The returned error matches ErrWIPLimitExceeded in that case.
 func (f *TaskFlow) MoveTask(ctx context.Context, taskID string, columnID, status *string, position float64) error {
+	return f.MoveTaskWith(ctx, taskID, columnID, status, position, MoveOptions{})
+}
+
+// ForceMoveTask moves a task without checking the destination WIP limit.
+func (f *TaskFlow) ForceMoveTask(ctx context.Context, taskID string, columnID, status *string, position float64) error {
+	return f.MoveTaskWith(ctx, taskID, columnID, status, position, MoveOptions{Force: true})
+}
+
+// MoveTaskWith moves a task as MoveTask does, adjusted by opts. A zero
+// position places the task by opts.Placement.
+func (f *TaskFlow) MoveTaskWith(ctx context.Context, taskID string, columnID, status *string, position float64, opts MoveOptions) error {
 	if strings.TrimSpace(taskID) == "" {
 		return errors.New("task id is required")
 	}
+	if err := opts.Placement.validate(); err != nil {
+		return err
+	}
+	var limited *domain.Column
+	if !opts.Force && columnID != nil {
+		var err error
+		if limited, err = f.checkMoveWIPLimit(ctx, taskID, strings.TrimSpace(*columnID)); err != nil {
+			return err
+		}
+	}
+	columnID, status = trimStringPointer(columnID), trimStringPointer(status)
+	var task domain.Task
+	if f.hooks != nil || position == 0 {
+		var err error
+		if task, err = f.repo.GetByID(ctx, taskID); err != nil {
+			return err
+		}
+	}
+	if f.hooks != nil {
+		changes := f.hooks.moveHookChanges(ctx, task, columnID, status)
+		if err := f.hooks.pre(ctx, taskHookEvent(domain.HookPreTaskMove, task, changes)); err != nil {
+			return err
+		}
+	}
+	var place domain.PlaceFunc
 	if position == 0 {
-		position = float64(time.Now().UTC().UnixNano())
+		position, place = movePosition(task, columnID, opts.Placement)
 	}
-	return f.repo.Move(ctx, domain.MoveTaskInput{
+	if limited != nil && place == nil {
+		// A given position still goes through the WIP check as it is stored.
+		fixed := position
+		place = func([]domain.Task) (float64, map[string]float64, error) { return fixed, nil, nil }
+	}
+	place = placeUnderWIPLimit(limited, place)
+	if err := f.repo.Move(ctx, domain.MoveTaskInput{
 		TaskID:    taskID,
-		ColumnID:  trimStringPointer(columnID),
-		Status:    trimStringPointer(status),
+		ColumnID:  columnID,
+		Status:    status,
 		Position:  position,
 		UpdatedAt: time.Now().UTC(),
-	})
+		Place:     place,
+		IfVersion: opts.IfVersion,
+	}); err != nil {
+		return err
+	}
+	f.hooks.postTask(ctx, domain.HookTaskMoved, taskID)
+	return nil
+}
+
+// ReorderTask moves a task to opts.Placement within the column it is in.
+func (f *TaskFlow) ReorderTask(ctx context.Context, taskID string, opts MoveOptions) error {
+	if opts.Placement.IsZero() {
+		return errors.New("one of before, after, top or bottom is required")
+	}
+	task, err := f.repo.GetByID(ctx, strings.TrimSpace(taskID))
+	if err != nil {
+		return err
+	}
+	if columnOf(task) == "" {
+		return errors.New("task is not in a column")
+	}
+	return f.MoveTaskWith(ctx, task.ID, task.ColumnID, task.Status, 0, opts)
+}
+
+// movePosition returns the position of a task moving to columnID: a task
+// staying in its column without a placement keeps its position, any other
+// is placed by the PlaceFunc returned instead.
+func movePosition(task domain.Task, columnID *string, placement Placement) (float64, domain.PlaceFunc) {
+	destination := ""
+	if columnID != nil {
+		destination = *columnID
+	}
+	if placement.IsZero() && destination == columnOf(task) {
+		return task.Position, nil
+	}
+	return 0, placeFunc(task.ID, placement)
+}
+
+func (f *TaskFlow) checkMoveWIPLimit(ctx context.Context, taskID, columnID string) (*domain.Column, error) {
+	task, err := f.repo.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if task.BoardID == nil {
+		return nil, nil
+	}
+	if task.ColumnID != nil && *task.ColumnID == columnID {
+		return nil, nil
+	}
+	return checkWIPLimit(ctx, f.repo, task.WorkspaceID, *task.BoardID, columnID, taskID)
 }
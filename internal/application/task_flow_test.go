@@ -19,9 +19,14 @@ type fakeTaskRepo struct {
 
 	lastListFilter domain.TaskFilter
 	lastMoveInput  domain.MoveTaskInput
+	lastPositions  map[string]float64
+	lastBulk       []domain.TaskChange
 }
 
 func (r *fakeTaskRepo) Create(ctx context.Context, task domain.Task) error { return nil }
+func (r *fakeTaskRepo) CreatePlaced(ctx context.Context, task domain.Task, place domain.PlaceFunc) error {
+	return r.Create(ctx, task)
+}
 func (r *fakeTaskRepo) Update(ctx context.Context, taskID string, patch domain.TaskPatch) error {
 	return nil
 }
@@ -36,13 +41,57 @@ func (r *fakeTaskRepo) List(ctx context.Context, filter domain.TaskFilter) ([]do
 	return r.tasks, nil
 }
 func (r *fakeTaskRepo) Move(ctx context.Context, input domain.MoveTaskInput) error {
-	r.lastMoveInput = input
 	if r.moveErr != nil {
+		r.lastMoveInput = input
 		return r.moveErr
 	}
-	return nil
+	err := r.place(&input)
+	r.lastMoveInput = input
+	return err
 }
 func (r *fakeTaskRepo) Delete(ctx context.Context, id string) error { return nil }
+func (r *fakeTaskRepo) Bulk(ctx context.Context, changes []domain.TaskChange) error {
+	for _, change := range changes {
+		if change.Move != nil {
+			change.Move.TaskID = change.TaskID
+			if err := r.place(change.Move); err != nil {
+				return err
+			}
+		}
+	}
+	r.lastBulk = changes
+	return nil
+}
+
+// place positions a moving task among the tasks of its destination column
+// as the repository does, moving it there in r.tasks.
+func (r *fakeTaskRepo) place(input *domain.MoveTaskInput) error {
+	if input.Place == nil {
+		return nil
+	}
+	var siblings []domain.Task
+	for _, task := range r.tasks {
+		if task.ID != input.TaskID && input.ColumnID != nil && columnOf(task) == *input.ColumnID {
+			siblings = append(siblings, task)
+		}
+	}
+	position, renumber, err := input.Place(siblings)
+	if err != nil {
+		return err
+	}
+	input.Position = position
+	r.lastPositions = renumber
+	for i := range r.tasks {
+		if p, ok := renumber[r.tasks[i].ID]; ok {
+			r.tasks[i].Position = p
+		}
+		if r.tasks[i].ID == input.TaskID {
+			r.tasks[i].ColumnID = input.ColumnID
+			r.tasks[i].Position = position
+		}
+	}
+	return nil
+}
 func (r *fakeTaskRepo) ListColumns(ctx context.Context, boardID string) ([]domain.Column, error) {
 	return r.columns, nil
 }
@@ -109,6 +158,27 @@ func TestTaskFlow_ListTasks_DelegatesToRepo(t *testing.T) {
 	}
 }
 
+func TestTaskFlow_ListTasks_ParsesFilter(t *testing.T) {
+	repo := &fakeTaskRepo{}
+	flow := NewTaskFlow(repo)
+
+	if _, err := flow.ListTasks(context.Background(), ListTaskFilters{WorkspaceID: "ws-1", Filter: "label:bug"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if q := repo.lastListFilter.Query; q == nil || q.Cond.Field != domain.QueryFieldLabel || q.Cond.Text != "bug" {
+		t.Fatalf("Query = %+v, want label:bug", q)
+	}
+
+	repo.lastListFilter = domain.TaskFilter{}
+	_, err := flow.ListTasks(context.Background(), ListTaskFilters{WorkspaceID: "ws-1", Filter: "label<bug"})
+	if !errors.Is(err, ErrInvalidTaskQuery) {
+		t.Fatalf("expected ErrInvalidTaskQuery, got %v", err)
+	}
+	if repo.lastListFilter.WorkspaceID != "" {
+		t.Fatal("repo should not be queried with an invalid filter")
+	}
+}
+
 func TestTaskFlow_ListTasks_ReturnsRepoError(t *testing.T) {
 	repo := &fakeTaskRepo{listErr: errors.New("db down")}
 	flow := NewTaskFlow(repo)
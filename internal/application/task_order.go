@@ -0,0 +1,124 @@
+package application
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+// FilterTasks returns the tasks matching a column ID, a priority (negative
+// for any) and a due filter, one of domain.ViewDueFilters or "" for any.
+// Empty criteria match every task. Due soon means due between now and seven
+// days from now.
+func FilterTasks(tasks []domain.Task, columnID string, priority int, due string, now time.Time) []domain.Task {
+	if len(tasks) == 0 {
+		return tasks
+	}
+	now = now.UTC()
+	soonLimit := now.AddDate(0, 0, 7)
+	filtered := make([]domain.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if columnID != "" {
+			if task.ColumnID == nil || *task.ColumnID != columnID {
+				continue
+			}
+		}
+		if priority >= 0 && priorityRank(task.Priority) != priority {
+			continue
+		}
+		switch due {
+		case domain.ViewDueSoon:
+			if task.DueAt == nil {
+				continue
+			}
+			at := task.DueAt.UTC()
+			if at.Before(now) || at.After(soonLimit) {
+				continue
+			}
+		case domain.ViewDueOverdue:
+			if task.DueAt == nil || !task.DueAt.UTC().Before(now) {
+				continue
+			}
+		case domain.ViewDueNone:
+			if task.DueAt != nil {
+				continue
+			}
+		}
+		filtered = append(filtered, task)
+	}
+	return filtered
+}
+
+// SortTasks sorts tasks in place by one of domain.ViewSorts. Any other order
+// sorts by priority, then by due date (sooner first) and last update (newer
+// first).
+func SortTasks(tasks []domain.Task, order string) {
+	switch order {
+	case domain.ViewSortDue:
+		sort.SliceStable(tasks, func(i, j int) bool {
+			if tasks[i].DueAt != nil && tasks[j].DueAt == nil {
+				return true
+			}
+			if tasks[i].DueAt == nil && tasks[j].DueAt != nil {
+				return false
+			}
+			if tasks[i].DueAt != nil && tasks[j].DueAt != nil && !tasks[i].DueAt.Equal(*tasks[j].DueAt) {
+				return tasks[i].DueAt.Before(*tasks[j].DueAt)
+			}
+			return tasks[i].UpdatedAt.After(tasks[j].UpdatedAt)
+		})
+	case domain.ViewSortTitle:
+		sort.SliceStable(tasks, func(i, j int) bool {
+			ti := strings.ToLower(strings.TrimSpace(tasks[i].Title))
+			tj := strings.ToLower(strings.TrimSpace(tasks[j].Title))
+			if ti != tj {
+				return ti < tj
+			}
+			return tasks[i].UpdatedAt.After(tasks[j].UpdatedAt)
+		})
+	case domain.ViewSortUpdated:
+		sort.SliceStable(tasks, func(i, j int) bool {
+			return tasks[i].UpdatedAt.After(tasks[j].UpdatedAt)
+		})
+	case domain.ViewSortCreated:
+		sort.SliceStable(tasks, func(i, j int) bool {
+			return tasks[i].CreatedAt.After(tasks[j].CreatedAt)
+		})
+	case domain.ViewSortManual:
+		sort.SliceStable(tasks, func(i, j int) bool {
+			if tasks[i].Position != tasks[j].Position {
+				return tasks[i].Position < tasks[j].Position
+			}
+			return tasks[i].CreatedAt.Before(tasks[j].CreatedAt)
+		})
+	default:
+		sort.SliceStable(tasks, func(i, j int) bool {
+			pi := priorityRank(tasks[i].Priority)
+			pj := priorityRank(tasks[j].Priority)
+			if pi != pj {
+				return pi < pj
+			}
+			if tasks[i].DueAt != nil && tasks[j].DueAt == nil {
+				return true
+			}
+			if tasks[i].DueAt == nil && tasks[j].DueAt != nil {
+				return false
+			}
+			if tasks[i].DueAt != nil && tasks[j].DueAt != nil && !tasks[i].DueAt.Equal(*tasks[j].DueAt) {
+				return tasks[i].DueAt.Before(*tasks[j].DueAt)
+			}
+			return tasks[i].UpdatedAt.After(tasks[j].UpdatedAt)
+		})
+	}
+}
+
+// priorityRank maps out-of-range priorities to 6, after "none", leaving 0..5
+// unchanged.
+func priorityRank(priority int) int {
+	if priority < 0 || priority > 5 {
+		return 6
+	}
+	return priority
+}
@@ -0,0 +1,155 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+const (
+	// positionStep is the gap between neighbouring tasks after a column is
+	// renumbered and between the last task and one appended after it.
+	positionStep = 1024.0
+	// minPositionGap and maxPosition bound the positions placement keeps
+	// using. A column whose neighbours are closer together, or whose
+	// positions have grown past maxPosition (as the timestamps older
+	// versions stored have), is renumbered instead.
+	minPositionGap = 1e-6
+	maxPosition    = 1e12
+)
+
+// Placement says where a move puts a task among the tasks of its
+// destination column. The zero value keeps a task that stays in its column
+// where it is and puts one that changes column at the bottom.
+type Placement struct {
+	// Before and After place the task right above or below another task of
+	// the destination column, by ID.
+	Before string
+	After  string
+	Top    bool
+	Bottom bool
+}
+
+// IsZero reports whether no placement was asked for.
+func (p Placement) IsZero() bool {
+	return p == Placement{}
+}
+
+func (p Placement) validate() error {
+	set := 0
+	for _, on := range []bool{p.Before != "", p.After != "", p.Top, p.Bottom} {
+		if on {
+			set++
+		}
+	}
+	if set > 1 {
+		return errors.New("only one of before, after, top and bottom can be set")
+	}
+	return nil
+}
+
+// placeFunc returns the domain.PlaceFunc that puts a task at placement among
+// the other tasks of its destination column. taskID may be empty for a task
+// not created yet.
+func placeFunc(taskID string, placement Placement) domain.PlaceFunc {
+	return func(siblings []domain.Task) (float64, map[string]float64, error) {
+		return placeAmong(siblings, taskID, placement)
+	}
+}
+
+// placeAmong returns the position that puts a task at placement among
+// siblings and, when the neighbouring positions leave no room, the new
+// positions of the siblings by ID.
+func placeAmong(siblings []domain.Task, taskID string, placement Placement) (float64, map[string]float64, error) {
+	if err := placement.validate(); err != nil {
+		return 0, nil, err
+	}
+	siblings = slices.Clone(siblings)
+	SortTasks(siblings, domain.ViewSortManual)
+
+	index := len(siblings)
+	switch {
+	case placement.Top:
+		index = 0
+	case placement.Before != "" || placement.After != "":
+		anchor := placement.Before + placement.After
+		if anchor == taskID {
+			return 0, nil, errors.New("a task cannot be placed relative to itself")
+		}
+		index = -1
+		for i, task := range siblings {
+			if task.ID == anchor {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return 0, nil, fmt.Errorf("task %s is not in the destination column", anchor)
+		}
+		if placement.After != "" {
+			index++
+		}
+	}
+
+	if position, ok := positionBetween(siblings, index); ok {
+		return position, nil, nil
+	}
+	position, renumber := renumberColumn(siblings, index)
+	return position, renumber, nil
+}
+
+// positionBetween returns a position between the tasks at index-1 and index,
+// or false when they leave no usable room.
+func positionBetween(tasks []domain.Task, index int) (float64, bool) {
+	var position float64
+	switch {
+	case len(tasks) == 0:
+		return positionStep, true
+	case index == 0:
+		position = tasks[0].Position - positionStep
+	case index == len(tasks):
+		position = tasks[index-1].Position + positionStep
+	default:
+		low, high := tasks[index-1].Position, tasks[index].Position
+		if high-low < minPositionGap {
+			return 0, false
+		}
+		position = low + (high-low)/2
+		if position <= low || position >= high {
+			return 0, false
+		}
+	}
+	for _, task := range tasks {
+		if math.Abs(task.Position) > maxPosition {
+			return 0, false
+		}
+	}
+	return position, math.Abs(position) <= maxPosition
+}
+
+// renumberColumn spreads the tasks of a column positionStep apart, leaving a
+// slot at index, and returns the position of that slot with the new
+// positions of the tasks.
+func renumberColumn(tasks []domain.Task, index int) (float64, map[string]float64) {
+	positions := make(map[string]float64, len(tasks))
+	for i, task := range tasks {
+		slot := i
+		if i >= index {
+			slot++
+		}
+		positions[task.ID] = float64(slot+1) * positionStep
+	}
+	return float64(index+1) * positionStep, positions
+}
+
+// columnOf returns the ID of the column of a task, or "".
+func columnOf(task domain.Task) string {
+	if task.ColumnID == nil {
+		return ""
+	}
+	return strings.TrimSpace(*task.ColumnID)
+}
@@ -0,0 +1,113 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+func TestPlaceAmong_Midpoint(t *testing.T) {
+	siblings := []domain.Task{
+		{ID: "b", Position: 2048},
+		{ID: "a", Position: 1024},
+	}
+
+	position, renumber, err := placeAmong(siblings, "x", Placement{After: "a"})
+	if err != nil {
+		t.Fatalf("placeAmong: %v", err)
+	}
+	if position != 1536 {
+		t.Fatalf("position = %v, want 1536", position)
+	}
+	if renumber != nil {
+		t.Fatalf("expected no renumbering, got %v", renumber)
+	}
+
+	if position, _, _ := placeAmong(siblings, "x", Placement{Top: true}); position != 0 {
+		t.Fatalf("top position = %v, want 0", position)
+	}
+	if position, _, _ := placeAmong(siblings, "", Placement{}); position != 3072 {
+		t.Fatalf("bottom position = %v, want 3072", position)
+	}
+	if position, _, _ := placeAmong(nil, "", Placement{Bottom: true}); position != positionStep {
+		t.Fatalf("empty column position = %v, want %v", position, positionStep)
+	}
+	if _, _, err := placeAmong(siblings, "x", Placement{Before: "missing"}); err == nil {
+		t.Fatal("expected an error for a task outside the column")
+	}
+}
+
+func TestPlaceAmong_RenumbersWhenPositionsRunOut(t *testing.T) {
+	for name, tasks := range map[string][]domain.Task{
+		"no gap left": {
+			{ID: "a", Position: 1},
+			{ID: "b", Position: 1 + 1e-9},
+			{ID: "c", Position: 5},
+		},
+		"timestamps": {
+			{ID: "a", Position: 1.7e18},
+			{ID: "b", Position: 1.7e18 + 1e9},
+			{ID: "c", Position: 1.7e18 + 2e9},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			position, renumber, err := placeAmong(tasks, "x", Placement{Before: "b"})
+			if err != nil {
+				t.Fatalf("placeAmong: %v", err)
+			}
+			want := map[string]float64{"a": 1024, "b": 3072, "c": 4096}
+			if position != 2048 || len(renumber) != len(want) {
+				t.Fatalf("position = %v, renumbered %v", position, renumber)
+			}
+			for id, p := range want {
+				if renumber[id] != p {
+					t.Fatalf("renumbered %v, want %v", renumber, want)
+				}
+			}
+		})
+	}
+}
+
+func TestTaskFlow_ReorderTask(t *testing.T) {
+	column := "col"
+	status := "todo"
+	repo := &positionTaskRepo{fakeTaskRepo: fakeTaskRepo{tasks: []domain.Task{
+		{ID: "a", ColumnID: &column, Status: &status, Position: 1024},
+		{ID: "b", ColumnID: &column, Status: &status, Position: 2048},
+	}}}
+	flow := NewTaskFlow(repo)
+
+	if err := flow.ReorderTask(context.Background(), "b", MoveOptions{}); err == nil {
+		t.Fatal("expected a placement to be required")
+	}
+	if err := flow.ReorderTask(context.Background(), "b", MoveOptions{Placement: Placement{Top: true}}); err != nil {
+		t.Fatalf("ReorderTask: %v", err)
+	}
+	// b goes a step above a.
+	move := repo.lastMoveInput
+	if move.Position != 0 || move.ColumnID == nil || *move.ColumnID != column || move.Status == nil || *move.Status != status {
+		t.Fatalf("move = %+v", move)
+	}
+
+	// Staying in the column without a placement keeps the position.
+	if err := flow.MoveTask(context.Background(), "a", &column, &status, 0); err != nil {
+		t.Fatalf("MoveTask: %v", err)
+	}
+	if repo.lastMoveInput.Position != 1024 {
+		t.Fatalf("position = %v, want 1024", repo.lastMoveInput.Position)
+	}
+}
+
+type positionTaskRepo struct {
+	fakeTaskRepo
+}
+
+func (r *positionTaskRepo) GetByID(_ context.Context, id string) (domain.Task, error) {
+	for _, task := range r.tasks {
+		if task.ID == id {
+			return task, nil
+		}
+	}
+	return domain.Task{}, nil
+}
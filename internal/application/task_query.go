@@ -0,0 +1,418 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+// ErrInvalidTaskQuery is the sentinel matched by every TaskQueryError via
+// errors.Is.
+var ErrInvalidTaskQuery = errors.New("invalid task filter")
+
+// TaskQueryError reports a filter expression that does not parse.
+type TaskQueryError struct {
+	// Position is the 1-based character position of the problem.
+	Position int
+	Message  string
+}
+
+func (e *TaskQueryError) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Message, e.Position)
+}
+
+// Is reports whether target is ErrInvalidTaskQuery.
+func (e *TaskQueryError) Is(target error) bool {
+	return target == ErrInvalidTaskQuery
+}
+
+var taskQueryFields = map[string]domain.TaskQueryField{
+	"text":     domain.QueryFieldText,
+	"title":    domain.QueryFieldTitle,
+	"priority": domain.QueryFieldPriority,
+	"label":    domain.QueryFieldLabel,
+	"labels":   domain.QueryFieldLabel,
+	"column":   domain.QueryFieldColumn,
+	"status":   domain.QueryFieldStatus,
+	"assignee": domain.QueryFieldAssignee,
+	"due":      domain.QueryFieldDue,
+}
+
+var taskQueryPriorities = map[string]int{
+	"critical": 0,
+	"urgent":   1,
+	"high":     2,
+	"medium":   3,
+	"low":      4,
+	"none":     5,
+}
+
+var relativeDayPattern = regexp.MustCompile(`^([+-]?\d+)([dw])$`)
+
+// ParseTaskQuery parses a filter expression into a query plan. Relative due
+// dates such as "today" or "7d" resolve against now.
+//
+// An expression is a list of terms, all of which must match; "or" and "not"
+// combine terms and parentheses group them. A term is either a word or quoted
+// phrase matched against the title and description, or a field, an operator
+// (":" "=" "!=" "<" "<=" ">" ">=") and a value:
+//
+//	priority<=high and label:bug and due<7d and not column:Done
+//	(assignee:alice or assignee:none) "login page"
+func ParseTaskQuery(input string, now time.Time) (domain.TaskQuery, error) {
+	p := &taskQueryParser{input: input, now: now.UTC()}
+	p.skipSpace()
+	if p.done() {
+		return domain.TaskQuery{}, p.errorf(p.pos, "filter is empty")
+	}
+	query, err := p.parseOr()
+	if err != nil {
+		return domain.TaskQuery{}, err
+	}
+	if !p.done() {
+		return domain.TaskQuery{}, p.errorf(p.pos, "unexpected %q", string(p.input[p.pos]))
+	}
+	return query, nil
+}
+
+type taskQueryParser struct {
+	input string
+	pos   int
+	now   time.Time
+}
+
+func (p *taskQueryParser) errorf(pos int, format string, args ...any) error {
+	return &TaskQueryError{Position: pos + 1, Message: fmt.Sprintf(format, args...)}
+}
+
+func (p *taskQueryParser) done() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *taskQueryParser) skipSpace() {
+	for !p.done() && (p.input[p.pos] == ' ' || p.input[p.pos] == '\t' || p.input[p.pos] == '\n') {
+		p.pos++
+	}
+}
+
+// isWordEnd reports whether c ends a bare word.
+func isWordEnd(c byte) bool {
+	return strings.IndexByte(" \t\n()\":=!<>", c) >= 0
+}
+
+// peekWord returns the bare word at the current position without consuming it.
+func (p *taskQueryParser) peekWord() string {
+	end := p.pos
+	for end < len(p.input) && !isWordEnd(p.input[end]) {
+		end++
+	}
+	return p.input[p.pos:end]
+}
+
+// peekKeyword reports whether the next word is the given keyword.
+func (p *taskQueryParser) peekKeyword(keyword string) bool {
+	return strings.EqualFold(p.peekWord(), keyword)
+}
+
+func (p *taskQueryParser) parseOr() (domain.TaskQuery, error) {
+	first, err := p.parseAnd()
+	if err != nil {
+		return domain.TaskQuery{}, err
+	}
+	operands := []domain.TaskQuery{first}
+	for p.peekKeyword("or") {
+		p.pos += len("or")
+		p.skipSpace()
+		next, err := p.parseAnd()
+		if err != nil {
+			return domain.TaskQuery{}, err
+		}
+		operands = append(operands, next)
+	}
+	if len(operands) == 1 {
+		return first, nil
+	}
+	return domain.TaskQuery{Op: domain.TaskQueryOr, Operands: operands}, nil
+}
+
+func (p *taskQueryParser) parseAnd() (domain.TaskQuery, error) {
+	var operands []domain.TaskQuery
+	for {
+		if p.peekKeyword("and") && len(operands) > 0 {
+			p.pos += len("and")
+			p.skipSpace()
+		}
+		operand, err := p.parseUnary()
+		if err != nil {
+			return domain.TaskQuery{}, err
+		}
+		operands = append(operands, operand)
+		if p.done() || p.input[p.pos] == ')' || p.peekKeyword("or") {
+			break
+		}
+	}
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+	return domain.TaskQuery{Op: domain.TaskQueryAnd, Operands: operands}, nil
+}
+
+func (p *taskQueryParser) parseUnary() (domain.TaskQuery, error) {
+	if p.peekKeyword("not") {
+		p.pos += len("not")
+		p.skipSpace()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return domain.TaskQuery{}, err
+		}
+		return not(operand), nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *taskQueryParser) parsePrimary() (domain.TaskQuery, error) {
+	if p.done() {
+		return domain.TaskQuery{}, p.errorf(p.pos, "expected a term")
+	}
+	start := p.pos
+	switch p.input[p.pos] {
+	case '(':
+		p.pos++
+		p.skipSpace()
+		query, err := p.parseOr()
+		if err != nil {
+			return domain.TaskQuery{}, err
+		}
+		if p.done() || p.input[p.pos] != ')' {
+			return domain.TaskQuery{}, p.errorf(start, "missing closing parenthesis")
+		}
+		p.pos++
+		p.skipSpace()
+		return query, nil
+	case '"':
+		text, err := p.readQuoted()
+		if err != nil {
+			return domain.TaskQuery{}, err
+		}
+		p.skipSpace()
+		return cond(domain.QueryFieldText, domain.CompareContains, text), nil
+	}
+
+	word := p.peekWord()
+	if word == "" {
+		return domain.TaskQuery{}, p.errorf(p.pos, "unexpected %q", string(p.input[p.pos]))
+	}
+	p.pos += len(word)
+	op := p.readOperator()
+	if op == "" {
+		p.skipSpace()
+		return cond(domain.QueryFieldText, domain.CompareContains, word), nil
+	}
+	field, ok := taskQueryFields[strings.ToLower(word)]
+	if !ok {
+		return domain.TaskQuery{}, p.errorf(start, "unknown field %q", word)
+	}
+	valuePos := p.pos
+	value, err := p.readValue()
+	if err != nil {
+		return domain.TaskQuery{}, err
+	}
+	if value == "" {
+		return domain.TaskQuery{}, p.errorf(valuePos, "missing value for %s", word)
+	}
+	query, err := p.term(field, op, value, valuePos)
+	if err != nil {
+		return domain.TaskQuery{}, err
+	}
+	p.skipSpace()
+	return query, nil
+}
+
+// readOperator consumes the comparison operator right after a field name,
+// returning "" when there is none.
+func (p *taskQueryParser) readOperator() string {
+	for _, op := range []string{"!=", "<=", ">=", ":", "=", "<", ">"} {
+		if strings.HasPrefix(p.input[p.pos:], op) {
+			p.pos += len(op)
+			return op
+		}
+	}
+	return ""
+}
+
+// readValue consumes a quoted value or everything up to the next space or
+// closing parenthesis, so values like RFC 3339 times keep their colons.
+func (p *taskQueryParser) readValue() (string, error) {
+	if !p.done() && p.input[p.pos] == '"' {
+		return p.readQuoted()
+	}
+	end := p.pos
+	for end < len(p.input) && strings.IndexByte(" \t\n()", p.input[end]) < 0 {
+		end++
+	}
+	value := p.input[p.pos:end]
+	p.pos = end
+	return value, nil
+}
+
+// readQuoted consumes a double-quoted string. A doubled quote stands for a
+// literal quote.
+func (p *taskQueryParser) readQuoted() (string, error) {
+	start := p.pos
+	p.pos++
+	var b strings.Builder
+	for !p.done() {
+		c := p.input[p.pos]
+		p.pos++
+		if c != '"' {
+			b.WriteByte(c)
+			continue
+		}
+		if !p.done() && p.input[p.pos] == '"' {
+			b.WriteByte('"')
+			p.pos++
+			continue
+		}
+		return b.String(), nil
+	}
+	return "", p.errorf(start, "missing closing quote")
+}
+
+// term builds the query of one field comparison.
+func (p *taskQueryParser) term(field domain.TaskQueryField, op, value string, pos int) (domain.TaskQuery, error) {
+	equal := op == ":" || op == "="
+	switch field {
+	case domain.QueryFieldText, domain.QueryFieldTitle:
+		if !equal && op != "!=" {
+			return domain.TaskQuery{}, p.errorf(pos, "%s only supports :, = and !=", field)
+		}
+		return negateIf(op == "!=", cond(field, domain.CompareContains, value)), nil
+	case domain.QueryFieldPriority:
+		priority, ok := taskQueryPriorities[strings.ToLower(value)]
+		if !ok {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 || n > 5 {
+				return domain.TaskQuery{}, p.errorf(pos, "invalid priority %q; use 0-5 or critical, urgent, high, medium, low, none", value)
+			}
+			priority = n
+		}
+		if equal || op == "!=" {
+			query := cond(field, domain.CompareEqual, "")
+			query.Cond.Number = priority
+			return negateIf(op == "!=", query), nil
+		}
+		query := cond(field, domain.TaskComparison(op), "")
+		query.Cond.Number = priority
+		return query, nil
+	case domain.QueryFieldDue:
+		return p.dueTerm(op, value, pos)
+	default:
+		if !equal && op != "!=" {
+			return domain.TaskQuery{}, p.errorf(pos, "%s only supports :, = and !=", field)
+		}
+		if strings.EqualFold(value, "none") {
+			return negateIf(op == "!=", cond(field, domain.CompareUnset, "")), nil
+		}
+		return negateIf(op == "!=", cond(field, domain.CompareEqual, value)), nil
+	}
+}
+
+// dueTerm builds a due date comparison. Dates stand for whole UTC days, so
+// due<=today includes tasks due later today and due:today any time today.
+func (p *taskQueryParser) dueTerm(op, value string, pos int) (domain.TaskQuery, error) {
+	if strings.EqualFold(value, "none") {
+		if op != ":" && op != "=" && op != "!=" {
+			return domain.TaskQuery{}, p.errorf(pos, "due:none only supports :, = and !=")
+		}
+		return negateIf(op == "!=", cond(domain.QueryFieldDue, domain.CompareUnset, "")), nil
+	}
+	start, end, err := p.dueRange(value)
+	if err != nil {
+		return domain.TaskQuery{}, p.errorf(pos, "%s", err.Error())
+	}
+	at := func(cmp domain.TaskComparison, t time.Time) domain.TaskQuery {
+		query := cond(domain.QueryFieldDue, cmp, "")
+		query.Cond.Time = t
+		return query
+	}
+	switch op {
+	case "<":
+		return at(domain.CompareLess, start), nil
+	case "<=":
+		if start.Equal(end) {
+			return at(domain.CompareLessEqual, end), nil
+		}
+		return at(domain.CompareLess, end), nil
+	case ">":
+		if start.Equal(end) {
+			return at(domain.CompareGreater, end), nil
+		}
+		return at(domain.CompareGreaterEqual, end), nil
+	case ">=":
+		return at(domain.CompareGreaterEqual, start), nil
+	}
+	query := at(domain.CompareEqual, start)
+	if !start.Equal(end) {
+		query = domain.TaskQuery{Op: domain.TaskQueryAnd, Operands: []domain.TaskQuery{
+			at(domain.CompareGreaterEqual, start),
+			at(domain.CompareLess, end),
+		}}
+	}
+	return negateIf(op == "!=", query), nil
+}
+
+// dueRange resolves a due date value to the span it stands for: a whole day
+// for dates, or a single instant (start equal to end) for "now" and RFC 3339
+// times.
+func (p *taskQueryParser) dueRange(value string) (time.Time, time.Time, error) {
+	today := time.Date(p.now.Year(), p.now.Month(), p.now.Day(), 0, 0, 0, 0, time.UTC)
+	day := func(t time.Time) (time.Time, time.Time, error) {
+		return t, t.AddDate(0, 0, 1), nil
+	}
+	switch strings.ToLower(value) {
+	case "now":
+		return p.now, p.now, nil
+	case "today":
+		return day(today)
+	case "tomorrow":
+		return day(today.AddDate(0, 0, 1))
+	case "yesterday":
+		return day(today.AddDate(0, 0, -1))
+	}
+	if m := relativeDayPattern.FindStringSubmatch(strings.ToLower(value)); m != nil {
+		n, err := strconv.Atoi(m[1])
+		if err == nil {
+			if m[2] == "w" {
+				n *= 7
+			}
+			return day(today.AddDate(0, 0, n))
+		}
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return day(t)
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), t.UTC(), nil
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("invalid due date %q; use YYYY-MM-DD, RFC 3339, now, today, tomorrow, yesterday or a day offset like 7d or -2w", value)
+}
+
+func cond(field domain.TaskQueryField, op domain.TaskComparison, text string) domain.TaskQuery {
+	return domain.TaskQuery{Op: domain.TaskQueryCond, Cond: domain.TaskCondition{Field: field, Op: op, Text: text}}
+}
+
+func not(query domain.TaskQuery) domain.TaskQuery {
+	return domain.TaskQuery{Op: domain.TaskQueryNot, Operands: []domain.TaskQuery{query}}
+}
+
+func negateIf(negate bool, query domain.TaskQuery) domain.TaskQuery {
+	if negate {
+		return not(query)
+	}
+	return query
+}
@@ -0,0 +1,105 @@
+package application
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+func TestParseTaskQuery(t *testing.T) {
+	now := time.Date(2024, 3, 10, 15, 30, 0, 0, time.UTC)
+	day := func(d int) time.Time { return time.Date(2024, 3, 10+d, 0, 0, 0, 0, time.UTC) }
+	text := func(field domain.TaskQueryField, value string) domain.TaskQuery {
+		return cond(field, domain.CompareContains, value)
+	}
+	equal := func(field domain.TaskQueryField, value string) domain.TaskQuery {
+		return cond(field, domain.CompareEqual, value)
+	}
+	priority := func(op domain.TaskComparison, n int) domain.TaskQuery {
+		q := cond(domain.QueryFieldPriority, op, "")
+		q.Cond.Number = n
+		return q
+	}
+	due := func(op domain.TaskComparison, at time.Time) domain.TaskQuery {
+		q := cond(domain.QueryFieldDue, op, "")
+		q.Cond.Time = at
+		return q
+	}
+	and := func(operands ...domain.TaskQuery) domain.TaskQuery {
+		return domain.TaskQuery{Op: domain.TaskQueryAnd, Operands: operands}
+	}
+	or := func(operands ...domain.TaskQuery) domain.TaskQuery {
+		return domain.TaskQuery{Op: domain.TaskQueryOr, Operands: operands}
+	}
+
+	tests := []struct {
+		input string
+		want  domain.TaskQuery
+	}{
+		{"login", text(domain.QueryFieldText, "login")},
+		{`"login page"`, text(domain.QueryFieldText, "login page")},
+		{"priority<=high and label:bug and due<7d and not column:Done", and(
+			priority(domain.CompareLessEqual, 2),
+			equal(domain.QueryFieldLabel, "bug"),
+			due(domain.CompareLess, day(7)),
+			not(equal(domain.QueryFieldColumn, "Done")),
+		)},
+		{`label:bug column:"In progress"`, and(equal(domain.QueryFieldLabel, "bug"), equal(domain.QueryFieldColumn, "In progress"))},
+		{"(assignee:alice OR assignee:none) title!=wip", and(
+			or(equal(domain.QueryFieldAssignee, "alice"), cond(domain.QueryFieldAssignee, domain.CompareUnset, "")),
+			not(text(domain.QueryFieldTitle, "wip")),
+		)},
+		{"a or b c", or(text(domain.QueryFieldText, "a"), and(text(domain.QueryFieldText, "b"), text(domain.QueryFieldText, "c")))},
+		{"priority:1", priority(domain.CompareEqual, 1)},
+		{"priority!=low", not(priority(domain.CompareEqual, 4))},
+		{"due:today", and(due(domain.CompareGreaterEqual, day(0)), due(domain.CompareLess, day(1)))},
+		{"due<=tomorrow", due(domain.CompareLess, day(2))},
+		{"due>-1w", due(domain.CompareGreaterEqual, day(-6))},
+		{"due<now", due(domain.CompareLess, now)},
+		{"due>=2024-01-05", due(domain.CompareGreaterEqual, time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC))},
+		{"due<2024-01-05T10:00:00+02:00", due(domain.CompareLess, time.Date(2024, 1, 5, 8, 0, 0, 0, time.UTC))},
+		{"due!=none", not(cond(domain.QueryFieldDue, domain.CompareUnset, ""))},
+	}
+	for _, tt := range tests {
+		got, err := ParseTaskQuery(tt.input, now)
+		if err != nil {
+			t.Errorf("ParseTaskQuery(%q): %v", tt.input, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseTaskQuery(%q) =\n%+v\nwant\n%+v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseTaskQuery_Errors(t *testing.T) {
+	tests := []struct {
+		input    string
+		position int
+	}{
+		{"", 1},
+		{"color:red", 1},
+		{"label<bug", 7},
+		{"priority:huge", 10},
+		{"due<someday", 5},
+		{"(label:bug", 1},
+		{"label:bug and", 14},
+		{`"open`, 1},
+		{"label:", 7},
+		{"a)", 2},
+	}
+	for _, tt := range tests {
+		_, err := ParseTaskQuery(tt.input, time.Now())
+		var queryErr *TaskQueryError
+		if !errors.As(err, &queryErr) || !errors.Is(err, ErrInvalidTaskQuery) {
+			t.Errorf("ParseTaskQuery(%q) err = %v, want a TaskQueryError", tt.input, err)
+			continue
+		}
+		if queryErr.Position != tt.position {
+			t.Errorf("ParseTaskQuery(%q) position = %d (%v), want %d", tt.input, queryErr.Position, err, tt.position)
+		}
+	}
+}
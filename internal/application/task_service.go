@@ -22,6 +22,8 @@ type CreateTaskInput struct {
 	Priority      int
 	DueAt         *time.Time
 	Labels        []string
+	// Force skips the destination column's WIP limit check.
+	Force bool
 }
 
 type UpdateTaskInput struct {
@@ -33,16 +35,27 @@ type UpdateTaskInput struct {
 	ClearDueAt    bool
 	ColumnID      *string
 	Labels        *[]string
+	// Force skips the WIP limit check when ColumnID moves the task.
+	Force bool
+	// IfVersion makes the update fail with a *domain.VersionConflictError
+	// unless the task is still at that version.
+	IfVersion *int
 }
 
 type TaskService struct {
-	repo domain.TaskRepository
+	repo  domain.TaskRepository
+	hooks *Hooks
 }
 
 func NewTaskService(repo domain.TaskRepository) *TaskService {
 	return &TaskService{repo: repo}
 }
 
+// SetHooks makes the service run local hooks around task changes.
+func (s *TaskService) SetHooks(hooks *Hooks) {
+	s.hooks = hooks
+}
+
 func (s *TaskService) CreateTask(ctx context.Context, input CreateTaskInput) (domain.Task, error) {
 	if strings.TrimSpace(input.ProviderID) == "" {
 		return domain.Task{}, errors.New("provider id is required")
@@ -53,6 +66,13 @@ func (s *TaskService) CreateTask(ctx context.Context, input CreateTaskInput) (do
 	if strings.TrimSpace(input.Title) == "" {
 		return domain.Task{}, errors.New("title is required")
 	}
+	var limited *domain.Column
+	if !input.Force && input.BoardID != nil && input.ColumnID != nil {
+		var err error
+		if limited, err = checkWIPLimit(ctx, s.repo, input.WorkspaceID, *input.BoardID, *input.ColumnID, ""); err != nil {
+			return domain.Task{}, err
+		}
+	}
 
 	now := time.Now().UTC()
 	task := domain.Task{
@@ -67,13 +87,24 @@ func (s *TaskService) CreateTask(ctx context.Context, input CreateTaskInput) (do
 		Priority:      input.Priority,
 		DueAt:         input.DueAt,
 		Labels:        normalizeLabels(input.Labels),
-		Position:      float64(now.UnixNano()),
 		CreatedAt:     now,
 		UpdatedAt:     now,
+		Version:       1,
+	}
+	if err := s.hooks.pre(ctx, taskHookEvent(domain.HookPreTaskCreate, task, nil)); err != nil {
+		return domain.Task{}, err
 	}
-	if err := s.repo.Create(ctx, task); err != nil {
+	// The task goes to the bottom of its column, placed as it is stored.
+	place := placeUnderWIPLimit(limited, func(siblings []domain.Task) (float64, map[string]float64, error) {
+		var renumber map[string]float64
+		var err error
+		task.Position, renumber, err = placeAmong(siblings, "", Placement{Bottom: true})
+		return task.Position, renumber, err
+	})
+	if err := s.repo.CreatePlaced(ctx, task, place); err != nil {
 		return domain.Task{}, err
 	}
+	s.hooks.post(ctx, taskHookEvent(domain.HookTaskCreated, task, nil))
 	return task, nil
 }
 
@@ -90,15 +121,68 @@ func (s *TaskService) UpdateTask(ctx context.Context, taskID string, input Updat
 		ClearDueAt:    input.ClearDueAt,
 		ColumnID:      trimStringPointer(input.ColumnID),
 		Labels:        normalizeLabelPatch(input.Labels),
+		IfVersion:     input.IfVersion,
+	}
+	if patch.ColumnID != nil {
+		// A task changing column goes to the bottom of the new one.
+		patch.Place = placeFunc(taskID, Placement{Bottom: true})
+	}
+	if s.hooks == nil && (input.Force || patch.ColumnID == nil) {
+		return s.repo.Update(ctx, taskID, patch)
+	}
+
+	current, err := s.repo.GetByID(ctx, taskID)
+	if err != nil {
+		return err
 	}
-	return s.repo.Update(ctx, taskID, patch)
+	moved := patch.ColumnID != nil && (current.ColumnID == nil || *current.ColumnID != *patch.ColumnID)
+	if !input.Force && moved && current.BoardID != nil {
+		limited, err := checkWIPLimit(ctx, s.repo, current.WorkspaceID, *current.BoardID, *patch.ColumnID, taskID)
+		if err != nil {
+			return err
+		}
+		patch.Place = placeUnderWIPLimit(limited, patch.Place)
+	}
+	if s.hooks != nil {
+		// A column change is a move too, so move policies hold however the
+		// task is moved.
+		if moved {
+			changes := s.hooks.moveHookChanges(ctx, current, patch.ColumnID, patch.Status)
+			if err := s.hooks.pre(ctx, taskHookEvent(domain.HookPreTaskMove, current, changes)); err != nil {
+				return err
+			}
+		}
+		changes := s.hooks.updateHookChanges(ctx, current, patch)
+		if err := s.hooks.pre(ctx, taskHookEvent(domain.HookPreTaskUpdate, current, changes)); err != nil {
+			return err
+		}
+	}
+	if err := s.repo.Update(ctx, taskID, patch); err != nil {
+		return err
+	}
+	s.hooks.postTask(ctx, domain.HookTaskUpdated, taskID)
+	return nil
 }
 
 func (s *TaskService) DeleteTask(ctx context.Context, id string) error {
 	if strings.TrimSpace(id) == "" {
 		return errors.New("task id is required")
 	}
-	return s.repo.Delete(ctx, id)
+	if s.hooks == nil {
+		return s.repo.Delete(ctx, id)
+	}
+	task, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.hooks.pre(ctx, taskHookEvent(domain.HookPreTaskDelete, task, nil)); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.hooks.post(ctx, taskHookEvent(domain.HookTaskDeleted, task, nil))
+	return nil
 }
 
 func (s *TaskService) GetTask(ctx context.Context, taskID string) (domain.Task, error) {
@@ -0,0 +1,274 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+// ErrNoMatchingColumn is returned when a task goes to another board without
+// a destination column and that board has no column named like the task's
+// current one.
+var ErrNoMatchingColumn = errors.New("no column with a matching name on the destination board")
+
+// TaskDestination is where TransferTask and CopyTask put a task. An empty
+// WorkspaceID keeps the task's workspace and an empty ColumnID picks the
+// column of the destination board named like the task's current column.
+type TaskDestination struct {
+	WorkspaceID string
+	BoardID     string
+	ColumnID    string
+}
+
+// columnLister lists the boards of a workspace and the columns of a board.
+type columnLister interface {
+	ListBoards(ctx context.Context, workspaceID string) ([]domain.Board, error)
+	ListColumns(ctx context.Context, boardID string) ([]domain.Column, error)
+}
+
+// resolveDestination fills in the workspace of dest and returns the column
+// the task goes to.
+func resolveDestination(ctx context.Context, lister columnLister, task domain.Task, dest *TaskDestination) (domain.Column, error) {
+	dest.WorkspaceID = strings.TrimSpace(dest.WorkspaceID)
+	dest.BoardID = strings.TrimSpace(dest.BoardID)
+	dest.ColumnID = strings.TrimSpace(dest.ColumnID)
+	if dest.WorkspaceID == "" {
+		dest.WorkspaceID = task.WorkspaceID
+	}
+	if dest.BoardID == "" {
+		return domain.Column{}, errors.New("destination board id is required")
+	}
+
+	boards, err := lister.ListBoards(ctx, dest.WorkspaceID)
+	if err != nil {
+		return domain.Column{}, err
+	}
+	found := false
+	for _, b := range boards {
+		if b.ID == dest.BoardID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return domain.Column{}, fmt.Errorf("board %s not found in workspace %s", dest.BoardID, dest.WorkspaceID)
+	}
+
+	columns, err := lister.ListColumns(ctx, dest.BoardID)
+	if err != nil {
+		return domain.Column{}, err
+	}
+	if dest.ColumnID != "" {
+		for _, c := range columns {
+			if c.ID == dest.ColumnID {
+				return c, nil
+			}
+		}
+		return domain.Column{}, fmt.Errorf("column %s not found on board %s", dest.ColumnID, dest.BoardID)
+	}
+
+	name, err := currentColumnName(ctx, lister, task)
+	if err != nil {
+		return domain.Column{}, err
+	}
+	for _, c := range columns {
+		if strings.EqualFold(strings.TrimSpace(c.Name), name) {
+			return c, nil
+		}
+	}
+	return domain.Column{}, fmt.Errorf("%w: %q", ErrNoMatchingColumn, name)
+}
+
+// currentColumnName returns the name of the column a task is in, falling
+// back to its status for a task outside any column.
+func currentColumnName(ctx context.Context, lister columnLister, task domain.Task) (string, error) {
+	if task.BoardID != nil && columnOf(task) != "" {
+		columns, err := lister.ListColumns(ctx, *task.BoardID)
+		if err != nil {
+			return "", err
+		}
+		for _, c := range columns {
+			if c.ID == columnOf(task) {
+				return strings.TrimSpace(c.Name), nil
+			}
+		}
+	}
+	if task.Status != nil {
+		return strings.TrimSpace(*task.Status), nil
+	}
+	return "", nil
+}
+
+// DestinationColumn returns the column dest puts a task in, mapping it by
+// name when dest names no column. The returned error matches
+// ErrNoMatchingColumn when no column matches.
+func (f *TaskFlow) DestinationColumn(ctx context.Context, task domain.Task, dest TaskDestination) (domain.Column, error) {
+	return resolveDestination(ctx, f.repo, task, &dest)
+}
+
+// TransferTask moves a task to a column of another board, possibly of
+// another workspace. It checks the destination WIP limit and runs the move
+// hooks as MoveTaskWith does. A destination on the task's own board is a
+// plain move. It returns the destination column.
+func (f *TaskFlow) TransferTask(ctx context.Context, taskID string, dest TaskDestination, opts MoveOptions) (domain.Column, error) {
+	if strings.TrimSpace(taskID) == "" {
+		return domain.Column{}, errors.New("task id is required")
+	}
+	if err := opts.Placement.validate(); err != nil {
+		return domain.Column{}, err
+	}
+	task, err := f.repo.GetByID(ctx, strings.TrimSpace(taskID))
+	if err != nil {
+		return domain.Column{}, err
+	}
+	column, err := resolveDestination(ctx, f.repo, task, &dest)
+	if err != nil {
+		return domain.Column{}, err
+	}
+	status := strings.ToLower(column.Name)
+	if dest.WorkspaceID == task.WorkspaceID && task.BoardID != nil && *task.BoardID == dest.BoardID {
+		return column, f.MoveTaskWith(ctx, task.ID, &column.ID, &status, 0, opts)
+	}
+
+	var limited *domain.Column
+	if !opts.Force {
+		if limited, err = checkWIPLimit(ctx, f.repo, dest.WorkspaceID, dest.BoardID, column.ID, task.ID); err != nil {
+			return domain.Column{}, err
+		}
+	}
+	if f.hooks != nil {
+		changes := map[string]any{
+			"workspace_id": dest.WorkspaceID,
+			"board_id":     dest.BoardID,
+			"column_id":    column.ID,
+			"column_name":  column.Name,
+			"status":       status,
+		}
+		if err := f.hooks.pre(ctx, taskHookEvent(domain.HookPreTaskMove, task, changes)); err != nil {
+			return domain.Column{}, err
+		}
+	}
+	if err := f.repo.Move(ctx, domain.MoveTaskInput{
+		TaskID:      task.ID,
+		ColumnID:    &column.ID,
+		Status:      &status,
+		UpdatedAt:   time.Now().UTC(),
+		Place:       placeUnderWIPLimit(limited, placeFunc(task.ID, opts.Placement)),
+		BoardID:     &dest.BoardID,
+		WorkspaceID: &dest.WorkspaceID,
+		IfVersion:   opts.IfVersion,
+	}); err != nil {
+		return domain.Column{}, err
+	}
+	f.hooks.postTask(ctx, domain.HookTaskMoved, task.ID)
+	return column, nil
+}
+
+// CopyTaskInput adjusts how CopyTask duplicates a task.
+type CopyTaskInput struct {
+	// Destination is where the copy goes. An empty BoardID keeps the task's
+	// board when the copy stays in its workspace.
+	Destination TaskDestination
+	// Title names the copy; empty keeps the original title.
+	Title string
+	// WithComments copies the comments of the task too.
+	WithComments bool
+	// Force skips the destination column's WIP limit check.
+	Force bool
+}
+
+// TaskCopyService duplicates tasks, optionally with their comments, within
+// a board or onto another board or workspace.
+type TaskCopyService struct {
+	setupRepo domain.SetupRepository
+	tasks     *TaskService
+	comments  *CommentService
+}
+
+// NewTaskCopyService creates a new TaskCopyService.
+func NewTaskCopyService(setup domain.SetupRepository, tasks *TaskService, comments *CommentService) *TaskCopyService {
+	return &TaskCopyService{setupRepo: setup, tasks: tasks, comments: comments}
+}
+
+// CopyTask creates a new task with the fields of taskID at the bottom of the
+// destination column. The copy goes through the same WIP limit and hook
+// checks as any new task. Comments keep their author and body but are
+// dated now.
+func (s *TaskCopyService) CopyTask(ctx context.Context, taskID string, input CopyTaskInput) (domain.Task, error) {
+	task, err := s.tasks.GetTask(ctx, strings.TrimSpace(taskID))
+	if err != nil {
+		return domain.Task{}, err
+	}
+	dest := input.Destination
+	sameWorkspace := strings.TrimSpace(dest.WorkspaceID) == "" || strings.TrimSpace(dest.WorkspaceID) == task.WorkspaceID
+	if strings.TrimSpace(dest.BoardID) == "" && sameWorkspace && task.BoardID != nil {
+		dest.BoardID = *task.BoardID
+	}
+	column, err := resolveDestination(ctx, s.setupRepo, task, &dest)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	providerID := task.ProviderID
+	if dest.WorkspaceID != task.WorkspaceID {
+		workspaces, err := s.setupRepo.ListWorkspaces(ctx)
+		if err != nil {
+			return domain.Task{}, err
+		}
+		providerID = ""
+		for _, ws := range workspaces {
+			if ws.ID == dest.WorkspaceID {
+				providerID = ws.ProviderID
+				break
+			}
+		}
+		if providerID == "" {
+			return domain.Task{}, fmt.Errorf("workspace %s not found", dest.WorkspaceID)
+		}
+	}
+
+	title := task.Title
+	if strings.TrimSpace(input.Title) != "" {
+		title = input.Title
+	}
+	status := strings.ToLower(column.Name)
+	created, err := s.tasks.CreateTask(ctx, CreateTaskInput{
+		ProviderID:    providerID,
+		WorkspaceID:   dest.WorkspaceID,
+		BoardID:       &dest.BoardID,
+		ColumnID:      &column.ID,
+		Title:         title,
+		DescriptionMD: task.DescriptionMD,
+		Status:        &status,
+		Priority:      task.Priority,
+		DueAt:         task.DueAt,
+		Labels:        task.Labels,
+		Force:         input.Force,
+	})
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if !input.WithComments {
+		return created, nil
+	}
+
+	comments, err := s.comments.ListComments(ctx, task.ID)
+	if err != nil {
+		return created, err
+	}
+	for _, c := range comments {
+		if _, err := s.comments.AddComment(ctx, AddCommentInput{
+			TaskID:     created.ID,
+			ProviderID: providerID,
+			BodyMD:     c.BodyMD,
+			Author:     c.Author,
+		}); err != nil {
+			return created, fmt.Errorf("copy comment %s: %w", c.ID, err)
+		}
+	}
+	return created, nil
+}
@@ -0,0 +1,77 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+// transferRepo lists the columns of the board asked for.
+type transferRepo struct {
+	wipRepo
+}
+
+func (r *transferRepo) ListColumns(ctx context.Context, boardID string) ([]domain.Column, error) {
+	var out []domain.Column
+	for _, c := range r.columns {
+		if c.BoardID == boardID {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+func newTransferRepo() *transferRepo {
+	repo := &transferRepo{wipRepo: *newWIPRepo(5)}
+	repo.boards = []domain.Board{{ID: "board-1"}, {ID: "board-2"}}
+	repo.columns = append(repo.columns,
+		domain.Column{ID: "col-backlog", BoardID: "board-2", Name: "Backlog"},
+		domain.Column{ID: "col-doing-2", BoardID: "board-2", Name: "doing"},
+	)
+	return repo
+}
+
+func TestTaskFlow_TransferTask_MapsColumnByName(t *testing.T) {
+	repo := newTransferRepo()
+	flow := NewTaskFlow(repo)
+
+	column, err := flow.TransferTask(context.Background(), "t1", TaskDestination{BoardID: "board-2"}, MoveOptions{})
+	if err != nil {
+		t.Fatalf("TransferTask() error = %v", err)
+	}
+	if column.ID != "col-doing-2" {
+		t.Fatalf("column = %s, want col-doing-2", column.ID)
+	}
+	in := repo.lastMoveInput
+	if in.BoardID == nil || *in.BoardID != "board-2" || in.WorkspaceID == nil || *in.WorkspaceID != "ws-1" {
+		t.Fatalf("unexpected move input: %+v", in)
+	}
+	if in.Status == nil || *in.Status != "doing" {
+		t.Fatalf("status = %v, want doing", in.Status)
+	}
+}
+
+func TestTaskFlow_TransferTask_NoMatchingColumn(t *testing.T) {
+	repo := newTransferRepo()
+	flow := NewTaskFlow(repo)
+
+	_, err := flow.TransferTask(context.Background(), "t2", TaskDestination{BoardID: "board-2"}, MoveOptions{})
+	if !errors.Is(err, ErrNoMatchingColumn) {
+		t.Fatalf("expected ErrNoMatchingColumn, got %v", err)
+	}
+	if repo.lastMoveInput.TaskID != "" {
+		t.Fatal("expected no move to reach the repository")
+	}
+}
+
+func TestTaskFlow_TransferTask_UnknownBoard(t *testing.T) {
+	repo := newTransferRepo()
+	flow := NewTaskFlow(repo)
+
+	_, err := flow.TransferTask(context.Background(), "t1", TaskDestination{BoardID: "board-9", ColumnID: "col-backlog"}, MoveOptions{})
+	if err == nil {
+		t.Fatal("expected an error for a board outside the workspace")
+	}
+}
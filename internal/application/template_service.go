@@ -0,0 +1,590 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+// ErrTemplateNotFound is returned when no template has the given name.
+var ErrTemplateNotFound = errors.New("template not found")
+
+var templateNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// defaultTaskPriority is the priority of starter tasks that set none.
+const defaultTaskPriority = 3
+
+func intPtr(v int) *int { return &v }
+
+// builtinTemplates returns the templates kanji ships with. A saved template
+// with the same name takes their place.
+func builtinTemplates() []domain.BoardTemplate {
+	kanban := domain.BoardTemplate{
+		Name:        "kanban",
+		Description: "The default Todo, Doing and Done columns",
+		Builtin:     true,
+	}
+	for _, d := range defaultColumnSpecs() {
+		kanban.Columns = append(kanban.Columns, domain.TemplateColumn{Name: d.Name, Color: d.Color})
+	}
+	scrum := domain.BoardTemplate{
+		Name:        "scrum",
+		Description: "A sprint board with WIP limits on work in progress and review",
+		Columns: []domain.TemplateColumn{
+			{Name: "Backlog", Color: "#9CA3AF"},
+			{Name: "Sprint", Color: "#60A5FA"},
+			{Name: "In Progress", Color: "#F59E0B", WIPLimit: intPtr(3)},
+			{Name: "Review", Color: "#A78BFA", WIPLimit: intPtr(2)},
+			{Name: "Done", Color: "#22C55E"},
+		},
+		Labels: []domain.TemplateLabel{
+			{Name: "bug", Color: "#F87171"},
+			{Name: "feature", Color: "#34D399"},
+			{Name: "chore", Color: "#9CA3AF"},
+		},
+		Builtin: true,
+	}
+	return []domain.BoardTemplate{kanban, scrum}
+}
+
+// CloneBoardInput adjusts how CloneBoard copies a board.
+type CloneBoardInput struct {
+	// Name names the clone; empty uses the board's name followed by
+	// "(copy)".
+	Name string
+	// WithTasks copies the tasks of the board too.
+	WithTasks bool
+}
+
+// TemplateService manages board templates and creates boards from them.
+type TemplateService struct {
+	repo    domain.TemplateRepository
+	setup   domain.SetupRepository
+	builder domain.BoardBuilder
+	flow    *TaskFlow
+	labels  *LabelService
+	hooks   *Hooks
+}
+
+// NewTemplateService creates a new TemplateService. builder stores the
+// boards it creates.
+func NewTemplateService(repo domain.TemplateRepository, setup domain.SetupRepository, builder domain.BoardBuilder, flow *TaskFlow, labels *LabelService) *TemplateService {
+	return &TemplateService{
+		repo:    repo,
+		setup:   setup,
+		builder: builder,
+		flow:    flow,
+		labels:  labels,
+	}
+}
+
+// SetHooks makes the service run local hooks around the tasks it creates.
+func (s *TemplateService) SetHooks(hooks *Hooks) {
+	s.hooks = hooks
+}
+
+// ListTemplates returns the saved and built-in templates by name.
+func (s *TemplateService) ListTemplates(ctx context.Context) ([]domain.BoardTemplate, error) {
+	saved, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := append([]domain.BoardTemplate(nil), saved...)
+	for _, builtin := range builtinTemplates() {
+		if !containsTemplate(saved, builtin.Name) {
+			out = append(out, builtin)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return strings.ToLower(out[i].Name) < strings.ToLower(out[j].Name)
+	})
+	return out, nil
+}
+
+// GetTemplate returns the template with the given name, ignoring case.
+func (s *TemplateService) GetTemplate(ctx context.Context, name string) (domain.BoardTemplate, error) {
+	name = strings.TrimSpace(name)
+	if !templateNamePattern.MatchString(name) {
+		return domain.BoardTemplate{}, ErrTemplateNotFound
+	}
+	template, err := s.repo.Get(ctx, name)
+	if err == nil {
+		return template, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return domain.BoardTemplate{}, err
+	}
+	for _, builtin := range builtinTemplates() {
+		if strings.EqualFold(builtin.Name, name) {
+			return builtin, nil
+		}
+	}
+	return domain.BoardTemplate{}, ErrTemplateNotFound
+}
+
+// SaveTemplate validates and stores a template. Without overwrite it fails
+// when a template with that name exists; a saved template hides the
+// built-in one of the same name.
+func (s *TemplateService) SaveTemplate(ctx context.Context, template domain.BoardTemplate, overwrite bool) (domain.BoardTemplate, error) {
+	template, err := normalizeTemplate(template)
+	if err != nil {
+		return domain.BoardTemplate{}, err
+	}
+	if !overwrite {
+		if existing, err := s.GetTemplate(ctx, template.Name); err == nil {
+			return domain.BoardTemplate{}, fmt.Errorf("template %q already exists", existing.Name)
+		} else if !errors.Is(err, ErrTemplateNotFound) {
+			return domain.BoardTemplate{}, err
+		}
+	}
+	template.Builtin = false
+	if err := s.repo.Save(ctx, template); err != nil {
+		return domain.BoardTemplate{}, err
+	}
+	return template, nil
+}
+
+// DeleteTemplate removes a saved template. Built-in templates cannot be
+// deleted; deleting a saved template that hid one brings it back.
+func (s *TemplateService) DeleteTemplate(ctx context.Context, name string) error {
+	name = strings.TrimSpace(name)
+	if !templateNamePattern.MatchString(name) {
+		return ErrTemplateNotFound
+	}
+	err := s.repo.Delete(ctx, name)
+	if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	for _, builtin := range builtinTemplates() {
+		if strings.EqualFold(builtin.Name, name) {
+			return fmt.Errorf("built-in template %q cannot be deleted", builtin.Name)
+		}
+	}
+	return ErrTemplateNotFound
+}
+
+// TemplateFromBoard returns a template with the columns of a board and the
+// workspace labels its tasks carry. With withTasks the tasks of the board
+// become starter tasks, in column order.
+func (s *TemplateService) TemplateFromBoard(ctx context.Context, boardID, name string, withTasks bool) (domain.BoardTemplate, error) {
+	board, err := s.findBoard(ctx, boardID)
+	if err != nil {
+		return domain.BoardTemplate{}, err
+	}
+	columns, err := s.sortedColumns(ctx, board.ID)
+	if err != nil {
+		return domain.BoardTemplate{}, err
+	}
+	template := domain.BoardTemplate{Name: strings.TrimSpace(name)}
+	columnNames := make(map[string]string, len(columns))
+	for _, c := range columns {
+		template.Columns = append(template.Columns, domain.TemplateColumn{Name: c.Name, Color: c.Color, WIPLimit: c.WIPLimit})
+		columnNames[c.ID] = c.Name
+	}
+
+	tasks, err := s.boardTasks(ctx, board, columns)
+	if err != nil {
+		return domain.BoardTemplate{}, err
+	}
+	used := map[string]bool{}
+	for _, task := range tasks {
+		for _, label := range task.Labels {
+			used[strings.ToLower(label)] = true
+		}
+		if !withTasks {
+			continue
+		}
+		priority := task.Priority
+		starter := domain.TemplateTask{
+			Title:       task.Title,
+			Description: task.DescriptionMD,
+			Priority:    &priority,
+			Labels:      task.Labels,
+		}
+		if task.ColumnID != nil {
+			starter.Column = columnNames[*task.ColumnID]
+		}
+		template.Tasks = append(template.Tasks, starter)
+	}
+	if len(used) > 0 {
+		labels, err := s.labels.ListLabels(ctx, board.WorkspaceID)
+		if err != nil {
+			return domain.BoardTemplate{}, err
+		}
+		for _, label := range labels {
+			if used[strings.ToLower(label.Name)] {
+				template.Labels = append(template.Labels, domain.TemplateLabel{Name: label.Name, Color: label.Color, Description: label.Description})
+			}
+		}
+	}
+	return template, nil
+}
+
+// CreateBoardFromTemplate creates a board with the columns of a template,
+// adds the template labels the workspace lacks, and creates the starter
+// tasks. Starter tasks skip WIP limits. The board is stored at once: when a
+// hook rejects a starter task, or anything fails, no board is created.
+func (s *TemplateService) CreateBoardFromTemplate(ctx context.Context, workspaceID, name string, template domain.BoardTemplate) (domain.Board, error) {
+	build, err := s.planBoard(ctx, workspaceID, name, template)
+	if err != nil {
+		return domain.Board{}, err
+	}
+	if err := s.buildBoard(ctx, build); err != nil {
+		return domain.Board{}, err
+	}
+	return build.Board, nil
+}
+
+// CloneBoard creates a board in the same workspace with the columns, WIP
+// limits and colors of another. With WithTasks it copies the tasks too,
+// keeping their columns, order and due dates but not their comments. It
+// returns the new board and the number of tasks copied. Like
+// CreateBoardFromTemplate, it creates the whole board or nothing.
+func (s *TemplateService) CloneBoard(ctx context.Context, boardID string, input CloneBoardInput) (domain.Board, int, error) {
+	source, err := s.findBoard(ctx, boardID)
+	if err != nil {
+		return domain.Board{}, 0, err
+	}
+	template, err := s.TemplateFromBoard(ctx, source.ID, "", false)
+	if err != nil {
+		return domain.Board{}, 0, err
+	}
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		name = source.Name + " (copy)"
+	}
+	build, err := s.planBoard(ctx, source.WorkspaceID, name, template)
+	if err != nil {
+		return domain.Board{}, 0, err
+	}
+
+	if input.WithTasks {
+		from, err := s.sortedColumns(ctx, source.ID)
+		if err != nil {
+			return domain.Board{}, 0, err
+		}
+		// The template keeps the columns of the source in order, so the
+		// columns of the clone line up with them.
+		mapped := make(map[string]domain.Column, len(from))
+		for i, c := range from {
+			mapped[c.ID] = build.Columns[i]
+		}
+		tasks, err := s.boardTasks(ctx, source, from)
+		if err != nil {
+			return domain.Board{}, 0, err
+		}
+		for _, task := range tasks {
+			column := build.Columns[0]
+			if task.ColumnID != nil {
+				if c, ok := mapped[*task.ColumnID]; ok {
+					column = c
+				}
+			}
+			if err := addBuildTask(&build, column, domain.Task{
+				ProviderID:    task.ProviderID,
+				Title:         task.Title,
+				DescriptionMD: task.DescriptionMD,
+				Priority:      task.Priority,
+				DueAt:         task.DueAt,
+				Labels:        task.Labels,
+			}); err != nil {
+				return domain.Board{}, 0, err
+			}
+		}
+	}
+
+	if err := s.buildBoard(ctx, build); err != nil {
+		return domain.Board{}, 0, err
+	}
+	return build.Board, len(build.Tasks), nil
+}
+
+// planBoard lays out the board a template creates, with its columns, the
+// template labels and the starter tasks, without storing anything.
+func (s *TemplateService) planBoard(ctx context.Context, workspaceID, name string, template domain.BoardTemplate) (domain.BoardBuild, error) {
+	template, err := normalizeTemplateShape(template)
+	if err != nil {
+		return domain.BoardBuild{}, err
+	}
+	workspace, err := s.findWorkspace(ctx, workspaceID)
+	if err != nil {
+		return domain.BoardBuild{}, err
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return domain.BoardBuild{}, errors.New("board name is required")
+	}
+
+	build := domain.BoardBuild{Board: domain.Board{
+		ID:          uuid.NewString(),
+		WorkspaceID: workspace.ID,
+		Name:        name,
+		ViewDefault: "list",
+	}}
+	inputs := make([]CreateBoardColumnInput, 0, len(template.Columns))
+	for _, c := range template.Columns {
+		inputs = append(inputs, CreateBoardColumnInput{Name: c.Name, Color: c.Color, WIPLimit: c.WIPLimit})
+	}
+	if build.Columns, err = newBoardColumns(build.Board.ID, inputs); err != nil {
+		return domain.BoardBuild{}, err
+	}
+
+	now := time.Now().UTC()
+	for _, label := range template.Labels {
+		build.Labels = append(build.Labels, domain.Label{
+			ID:          uuid.NewString(),
+			WorkspaceID: workspace.ID,
+			Name:        label.Name,
+			Color:       label.Color,
+			Description: label.Description,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		})
+	}
+
+	for _, starter := range template.Tasks {
+		column := build.Columns[0]
+		for _, c := range build.Columns {
+			if strings.EqualFold(c.Name, starter.Column) {
+				column = c
+				break
+			}
+		}
+		priority := defaultTaskPriority
+		if starter.Priority != nil {
+			priority = *starter.Priority
+		}
+		if err := addBuildTask(&build, column, domain.Task{
+			ProviderID:    workspace.ProviderID,
+			Title:         starter.Title,
+			DescriptionMD: starter.Description,
+			Priority:      priority,
+			Labels:        starter.Labels,
+		}); err != nil {
+			return domain.BoardBuild{}, err
+		}
+	}
+	return build, nil
+}
+
+// addBuildTask adds a task to the bottom of a column of a planned board.
+func addBuildTask(build *domain.BoardBuild, column domain.Column, task domain.Task) error {
+	var siblings []domain.Task
+	for _, t := range build.Tasks {
+		if *t.ColumnID == column.ID {
+			siblings = append(siblings, t)
+		}
+	}
+	position, _, err := placeAmong(siblings, "", Placement{Bottom: true})
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	status := strings.ToLower(column.Name)
+	task.ID = uuid.NewString()
+	task.WorkspaceID = build.Board.WorkspaceID
+	task.BoardID = &build.Board.ID
+	task.ColumnID = &column.ID
+	task.Title = strings.TrimSpace(task.Title)
+	task.Status = &status
+	task.Labels = normalizeLabels(task.Labels)
+	task.Position = position
+	task.CreatedAt = now
+	task.UpdatedAt = now
+	task.Version = 1
+	build.Tasks = append(build.Tasks, task)
+	return nil
+}
+
+// buildBoard stores a planned board. pre-task-create hooks run for every
+// task before anything is written, so a veto leaves no board behind;
+// task-created hooks run once the whole board is stored.
+func (s *TemplateService) buildBoard(ctx context.Context, build domain.BoardBuild) error {
+	for _, task := range build.Tasks {
+		if err := s.hooks.pre(ctx, taskHookEvent(domain.HookPreTaskCreate, task, nil)); err != nil {
+			return fmt.Errorf("create task %q: %w", task.Title, err)
+		}
+	}
+	if err := s.builder.BuildBoard(ctx, build); err != nil {
+		return err
+	}
+	for _, task := range build.Tasks {
+		s.hooks.post(ctx, taskHookEvent(domain.HookTaskCreated, task, nil))
+	}
+	return nil
+}
+
+func (s *TemplateService) findWorkspace(ctx context.Context, workspaceID string) (domain.Workspace, error) {
+	workspaceID = strings.TrimSpace(workspaceID)
+	if workspaceID == "" {
+		return domain.Workspace{}, errors.New("workspace id is required")
+	}
+	workspaces, err := s.setup.ListWorkspaces(ctx)
+	if err != nil {
+		return domain.Workspace{}, err
+	}
+	for _, ws := range workspaces {
+		if ws.ID == workspaceID {
+			return ws, nil
+		}
+	}
+	return domain.Workspace{}, fmt.Errorf("workspace %s not found", workspaceID)
+}
+
+func (s *TemplateService) findBoard(ctx context.Context, boardID string) (domain.Board, error) {
+	boardID = strings.TrimSpace(boardID)
+	if boardID == "" {
+		return domain.Board{}, errors.New("board id is required")
+	}
+	workspaces, err := s.setup.ListWorkspaces(ctx)
+	if err != nil {
+		return domain.Board{}, err
+	}
+	for _, ws := range workspaces {
+		boards, err := s.setup.ListBoards(ctx, ws.ID)
+		if err != nil {
+			return domain.Board{}, err
+		}
+		for _, b := range boards {
+			if b.ID == boardID {
+				return b, nil
+			}
+		}
+	}
+	return domain.Board{}, fmt.Errorf("board %s not found", boardID)
+}
+
+func (s *TemplateService) sortedColumns(ctx context.Context, boardID string) ([]domain.Column, error) {
+	columns, err := s.setup.ListColumns(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("board %s has no columns", boardID)
+	}
+	sort.SliceStable(columns, func(i, j int) bool {
+		return columns[i].Position < columns[j].Position
+	})
+	return columns, nil
+}
+
+// boardTasks returns the tasks of a board column by column, in the manual
+// order of each column. Tasks outside the columns come last.
+func (s *TemplateService) boardTasks(ctx context.Context, board domain.Board, columns []domain.Column) ([]domain.Task, error) {
+	tasks, err := s.flow.ListTasks(ctx, ListTaskFilters{WorkspaceID: board.WorkspaceID, BoardID: board.ID})
+	if err != nil {
+		return nil, err
+	}
+	rank := make(map[string]int, len(columns))
+	for i, c := range columns {
+		rank[c.ID] = i
+	}
+	columnRank := func(task domain.Task) int {
+		if task.ColumnID != nil {
+			if r, ok := rank[*task.ColumnID]; ok {
+				return r
+			}
+		}
+		return len(columns)
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		ri, rj := columnRank(tasks[i]), columnRank(tasks[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return tasks[i].Position < tasks[j].Position
+	})
+	return tasks, nil
+}
+
+func containsTemplate(templates []domain.BoardTemplate, name string) bool {
+	for _, t := range templates {
+		if strings.EqualFold(t.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeTemplate trims a template and checks its name and shape.
+func normalizeTemplate(template domain.BoardTemplate) (domain.BoardTemplate, error) {
+	template.Name = strings.TrimSpace(template.Name)
+	if !templateNamePattern.MatchString(template.Name) {
+		return domain.BoardTemplate{}, fmt.Errorf("template name %q must be letters, digits, '-' or '_'", template.Name)
+	}
+	return normalizeTemplateShape(template)
+}
+
+// normalizeTemplateShape trims a template and checks its column and label
+// names, colors, WIP limits, priorities and the columns its tasks go to.
+func normalizeTemplateShape(template domain.BoardTemplate) (domain.BoardTemplate, error) {
+	template.Description = strings.TrimSpace(template.Description)
+	if len(template.Columns) == 0 {
+		return domain.BoardTemplate{}, errors.New("template needs at least one column")
+	}
+
+	columns := make([]domain.TemplateColumn, 0, len(template.Columns))
+	seen := map[string]bool{}
+	for i, c := range template.Columns {
+		c.Name = strings.TrimSpace(c.Name)
+		c.Color = strings.ToUpper(strings.TrimSpace(c.Color))
+		if c.Name == "" {
+			return domain.BoardTemplate{}, fmt.Errorf("column %d name is required", i+1)
+		}
+		if seen[strings.ToLower(c.Name)] {
+			return domain.BoardTemplate{}, fmt.Errorf("column %q appears twice", c.Name)
+		}
+		seen[strings.ToLower(c.Name)] = true
+		if c.Color != "" && !hexColorPattern.MatchString(c.Color) {
+			return domain.BoardTemplate{}, fmt.Errorf("column %d color must be HEX (#RRGGBB)", i+1)
+		}
+		if c.WIPLimit != nil && *c.WIPLimit < 1 {
+			return domain.BoardTemplate{}, fmt.Errorf("column %q WIP limit must be at least 1", c.Name)
+		}
+		columns = append(columns, c)
+	}
+	template.Columns = columns
+
+	labels := make([]domain.TemplateLabel, 0, len(template.Labels))
+	for _, l := range template.Labels {
+		l.Name = strings.TrimSpace(l.Name)
+		if l.Name == "" {
+			return domain.BoardTemplate{}, errors.New("label name is required")
+		}
+		color, err := normalizeLabelColor(l.Color)
+		if err != nil {
+			return domain.BoardTemplate{}, fmt.Errorf("label %q: %w", l.Name, err)
+		}
+		l.Color = color
+		l.Description = strings.TrimSpace(l.Description)
+		labels = append(labels, l)
+	}
+	template.Labels = labels
+
+	tasks := make([]domain.TemplateTask, 0, len(template.Tasks))
+	for i, t := range template.Tasks {
+		t.Title = strings.TrimSpace(t.Title)
+		t.Column = strings.TrimSpace(t.Column)
+		if t.Title == "" {
+			return domain.BoardTemplate{}, fmt.Errorf("task %d title is required", i+1)
+		}
+		if t.Column != "" && !seen[strings.ToLower(t.Column)] {
+			return domain.BoardTemplate{}, fmt.Errorf("task %q goes to unknown column %q", t.Title, t.Column)
+		}
+		if t.Priority != nil && (*t.Priority < 0 || *t.Priority > 5) {
+			return domain.BoardTemplate{}, fmt.Errorf("task %q priority must be between 0 and 5", t.Title)
+		}
+		tasks = append(tasks, t)
+	}
+	template.Tasks = tasks
+	return template, nil
+}
@@ -0,0 +1,221 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+	"github.com/tiagokriok/kanji/internal/infrastructure/repositories"
+	"github.com/tiagokriok/kanji/internal/infrastructure/store"
+	"github.com/tiagokriok/kanji/internal/infrastructure/templates"
+)
+
+type templateFixture struct {
+	service  *TemplateService
+	tasks    *TaskService
+	flow     *TaskFlow
+	labels   *LabelService
+	contexts *ContextService
+	setup    BootstrapResult
+}
+
+// newTestTemplateService wires a TemplateService over a fresh database. A
+// non-nil runner runs the task hooks.
+func newTestTemplateService(t *testing.T, runner domain.HookRunner) templateFixture {
+	t.Helper()
+	ctx := context.Background()
+	s := store.New(newTestDB(t))
+	setupRepo := repositories.NewSetupRepository(s)
+	taskRepo := repositories.NewTaskRepository(s)
+	setup, err := NewBootstrapService(setupRepo).EnsureDefaultSetup(ctx)
+	require.NoError(t, err)
+	f := templateFixture{
+		tasks:    NewTaskService(taskRepo),
+		flow:     NewTaskFlow(taskRepo),
+		labels:   NewLabelService(repositories.NewLabelRepository(s)),
+		contexts: NewContextService(setupRepo),
+		setup:    setup,
+	}
+	f.service = NewTemplateService(templates.NewStore(t.TempDir()), setupRepo, taskRepo, f.flow, f.labels)
+	if runner != nil {
+		f.service.SetHooks(NewHooks(runner, taskRepo))
+	}
+	return f
+}
+
+func TestTemplateService_BuiltinsAndSavedTemplates(t *testing.T) {
+	service := newTestTemplateService(t, nil).service
+	ctx := context.Background()
+
+	list, err := service.ListTemplates(ctx)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "kanban", list[0].Name)
+	assert.Equal(t, "scrum", list[1].Name)
+	assert.True(t, list[1].Builtin)
+
+	_, err = service.SaveTemplate(ctx, domain.BoardTemplate{Name: "Scrum", Columns: []domain.TemplateColumn{{Name: "Todo"}}}, false)
+	assert.ErrorContains(t, err, "already exists")
+
+	// A saved template hides the built-in one until it is deleted.
+	_, err = service.SaveTemplate(ctx, domain.BoardTemplate{Name: "scrum", Columns: []domain.TemplateColumn{{Name: "Todo"}}}, true)
+	require.NoError(t, err)
+	got, err := service.GetTemplate(ctx, "SCRUM")
+	require.NoError(t, err)
+	assert.False(t, got.Builtin)
+	assert.Len(t, got.Columns, 1)
+
+	require.NoError(t, service.DeleteTemplate(ctx, "scrum"))
+	got, err = service.GetTemplate(ctx, "scrum")
+	require.NoError(t, err)
+	assert.True(t, got.Builtin)
+	assert.ErrorContains(t, service.DeleteTemplate(ctx, "scrum"), "cannot be deleted")
+	assert.ErrorIs(t, service.DeleteTemplate(ctx, "nope"), ErrTemplateNotFound)
+}
+
+func TestTemplateService_SaveValidates(t *testing.T) {
+	service := newTestTemplateService(t, nil).service
+	ctx := context.Background()
+	zero := 0
+
+	cases := map[string]domain.BoardTemplate{
+		"bad name":       {Name: "my board", Columns: []domain.TemplateColumn{{Name: "Todo"}}},
+		"no columns":     {Name: "empty"},
+		"bad color":      {Name: "c", Columns: []domain.TemplateColumn{{Name: "Todo", Color: "blue"}}},
+		"zero wip limit": {Name: "w", Columns: []domain.TemplateColumn{{Name: "Todo", WIPLimit: &zero}}},
+		"unknown column": {Name: "t", Columns: []domain.TemplateColumn{{Name: "Todo"}}, Tasks: []domain.TemplateTask{{Title: "x", Column: "Doing"}}},
+	}
+	for name, template := range cases {
+		_, err := service.SaveTemplate(ctx, template, false)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestTemplateService_CreateBoardFromTemplate(t *testing.T) {
+	f := newTestTemplateService(t, nil)
+	service, flow, labels, contexts, setup := f.service, f.flow, f.labels, f.contexts, f.setup
+	ctx := context.Background()
+
+	limit := 2
+	board, err := service.CreateBoardFromTemplate(ctx, setup.Workspace.ID, "Release", domain.BoardTemplate{
+		Columns: []domain.TemplateColumn{{Name: "Ready", Color: "#60A5FA"}, {Name: "Testing", WIPLimit: &limit}},
+		Labels:  []domain.TemplateLabel{{Name: "blocker", Color: "#f87171"}},
+		Tasks: []domain.TemplateTask{
+			{Title: "Write notes"},
+			{Title: "Smoke test", Column: "testing", Labels: []string{"blocker"}},
+		},
+	})
+	require.NoError(t, err)
+
+	columns, err := contexts.ListColumns(ctx, board.ID)
+	require.NoError(t, err)
+	require.Len(t, columns, 2)
+	require.NotNil(t, columns[1].WIPLimit)
+	assert.Equal(t, 2, *columns[1].WIPLimit)
+
+	label, err := labels.GetLabel(ctx, setup.Workspace.ID, "blocker")
+	require.NoError(t, err)
+	assert.Equal(t, "#F87171", label.Color)
+
+	tasks, err := flow.ListTasks(ctx, ListTaskFilters{WorkspaceID: setup.Workspace.ID, BoardID: board.ID})
+	require.NoError(t, err)
+	require.Len(t, tasks, 2)
+	byTitle := map[string]domain.Task{}
+	for _, task := range tasks {
+		byTitle[task.Title] = task
+	}
+	assert.Equal(t, columns[0].ID, *byTitle["Write notes"].ColumnID)
+	assert.Equal(t, defaultTaskPriority, byTitle["Write notes"].Priority)
+	assert.Equal(t, columns[1].ID, *byTitle["Smoke test"].ColumnID)
+	assert.Equal(t, "testing", *byTitle["Smoke test"].Status)
+}
+
+func TestTemplateService_CloneBoard(t *testing.T) {
+	f := newTestTemplateService(t, nil)
+	service, flow, contexts, setup := f.service, f.flow, f.contexts, f.setup
+	ctx := context.Background()
+
+	limit := 1
+	require.NoError(t, contexts.UpdateColumn(ctx, setup.Columns[1].ID, nil, nil, &limit, false))
+	tasks := f.tasks
+	for _, title := range []string{"First", "Second"} {
+		_, err := tasks.CreateTask(ctx, CreateTaskInput{
+			ProviderID:  setup.Provider.ID,
+			WorkspaceID: setup.Workspace.ID,
+			BoardID:     &setup.Board.ID,
+			ColumnID:    &setup.Columns[0].ID,
+			Title:       title,
+			Labels:      []string{"ops"},
+		})
+		require.NoError(t, err)
+	}
+
+	empty, copied, err := service.CloneBoard(ctx, setup.Board.ID, CloneBoardInput{})
+	require.NoError(t, err)
+	assert.Equal(t, setup.Board.Name+" (copy)", empty.Name)
+	assert.Zero(t, copied)
+	columns, err := contexts.ListColumns(ctx, empty.ID)
+	require.NoError(t, err)
+	require.Len(t, columns, len(setup.Columns))
+	require.NotNil(t, columns[1].WIPLimit)
+	assert.Equal(t, 1, *columns[1].WIPLimit)
+
+	full, copied, err := service.CloneBoard(ctx, setup.Board.ID, CloneBoardInput{Name: "Sprint 2", WithTasks: true})
+	require.NoError(t, err)
+	assert.Equal(t, 2, copied)
+	cloned, err := flow.ListTasks(ctx, ListTaskFilters{WorkspaceID: setup.Workspace.ID, BoardID: full.ID})
+	require.NoError(t, err)
+	require.Len(t, cloned, 2)
+	first, second := cloned[0], cloned[1]
+	if first.Position > second.Position {
+		first, second = second, first
+	}
+	assert.Equal(t, "First", first.Title)
+	assert.Equal(t, "Second", second.Title)
+	assert.Equal(t, []string{"ops"}, first.Labels)
+
+	template, err := service.TemplateFromBoard(ctx, setup.Board.ID, "daily", true)
+	require.NoError(t, err)
+	require.Len(t, template.Tasks, 2)
+	assert.Equal(t, "First", template.Tasks[0].Title)
+	assert.Equal(t, setup.Columns[0].Name, template.Tasks[0].Column)
+	require.Len(t, template.Labels, 1)
+	assert.Equal(t, "ops", template.Labels[0].Name)
+}
+
+func TestTemplateService_HookVetoLeavesNoBoard(t *testing.T) {
+	runner := &fakeHookRunner{reject: map[string]bool{domain.HookPreTaskCreate: true}}
+	f := newTestTemplateService(t, runner)
+	ctx := context.Background()
+	template := domain.BoardTemplate{
+		Columns: []domain.TemplateColumn{{Name: "Todo"}, {Name: "Done"}},
+		Labels:  []domain.TemplateLabel{{Name: "blocker"}},
+		Tasks:   []domain.TemplateTask{{Title: "One"}, {Title: "Two", Column: "Done"}},
+	}
+
+	_, err := f.service.CreateBoardFromTemplate(ctx, f.setup.Workspace.ID, "Release", template)
+	require.ErrorIs(t, err, ErrHookRejected)
+	boards, err := f.contexts.ListBoards(ctx, f.setup.Workspace.ID)
+	require.NoError(t, err)
+	assert.Len(t, boards, 1)
+	_, err = f.labels.GetLabel(ctx, f.setup.Workspace.ID, "blocker")
+	assert.ErrorIs(t, err, ErrLabelNotFound)
+
+	// Once allowed, each starter task runs its hooks once, after the board
+	// is stored.
+	runner.reject = nil
+	runner.events = nil
+	board, err := f.service.CreateBoardFromTemplate(ctx, f.setup.Workspace.ID, "Release", template)
+	require.NoError(t, err)
+	var hooks []string
+	for _, event := range runner.events {
+		hooks = append(hooks, event.Hook)
+	}
+	assert.Equal(t, []string{domain.HookPreTaskCreate, domain.HookPreTaskCreate, domain.HookTaskCreated, domain.HookTaskCreated}, hooks)
+	tasks, err := f.flow.ListTasks(ctx, ListTaskFilters{WorkspaceID: f.setup.Workspace.ID, BoardID: board.ID})
+	require.NoError(t, err)
+	assert.Len(t, tasks, 2)
+}
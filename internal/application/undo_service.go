@@ -0,0 +1,178 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+// UndoService undoes and redoes the task and comment changes journaled for
+// a namespace.
+type UndoService struct {
+	repo  domain.OperationRepository
+	hooks *Hooks
+}
+
+func NewUndoService(repo domain.OperationRepository) *UndoService {
+	return &UndoService{repo: repo}
+}
+
+// SetHooks makes the service run local hooks around each step, named after
+// what the step does: recreating a task runs the create hooks, removing it
+// the delete hooks, and so on.
+func (s *UndoService) SetHooks(hooks *Hooks) {
+	s.hooks = hooks
+}
+
+// Undo reverts up to steps operations, newest first, and returns the ones
+// it reverted. It fails with domain.ErrNothingToUndo only when nothing was
+// reverted; operations reverted before another error stay reverted.
+func (s *UndoService) Undo(ctx context.Context, namespace string, steps int) ([]domain.Operation, error) {
+	return s.run(ctx, namespace, steps, s.repo.NextUndo, s.repo.Undo, domain.ErrNothingToUndo)
+}
+
+// Redo replays up to steps undone operations, most recently undone first.
+func (s *UndoService) Redo(ctx context.Context, namespace string, steps int) ([]domain.Operation, error) {
+	return s.run(ctx, namespace, steps, s.repo.NextRedo, s.repo.Redo, domain.ErrNothingToRedo)
+}
+
+func (s *UndoService) run(
+	ctx context.Context,
+	namespace string,
+	steps int,
+	next func(context.Context, string) (domain.OperationChange, error),
+	step func(context.Context, string, string) (domain.Operation, error),
+	empty error,
+) ([]domain.Operation, error) {
+	if strings.TrimSpace(namespace) == "" {
+		return nil, errors.New("namespace is required")
+	}
+	if steps < 1 {
+		return nil, errors.New("steps must be at least 1")
+	}
+	done := make([]domain.Operation, 0, steps)
+	for range steps {
+		op, err := s.hookedStep(ctx, namespace, next, step)
+		if errors.Is(err, empty) && len(done) > 0 {
+			break
+		}
+		if err != nil {
+			return done, err
+		}
+		done = append(done, op)
+	}
+	return done, nil
+}
+
+// hookedStep runs one step between the hooks for the change it makes. A
+// vetoing pre hook leaves the operation in place.
+func (s *UndoService) hookedStep(
+	ctx context.Context,
+	namespace string,
+	next func(context.Context, string) (domain.OperationChange, error),
+	step func(context.Context, string, string) (domain.Operation, error),
+) (domain.Operation, error) {
+	if s.hooks == nil {
+		return step(ctx, namespace, "")
+	}
+	change, err := next(ctx, namespace)
+	if err != nil {
+		return domain.Operation{}, err
+	}
+	pre, post := s.hooks.operationEvents(ctx, change)
+	for _, event := range pre {
+		if err := s.hooks.pre(ctx, event); err != nil {
+			return domain.Operation{}, err
+		}
+	}
+	op, err := step(ctx, namespace, change.Operation.ID)
+	if err != nil {
+		return op, err
+	}
+	for _, event := range post {
+		s.hooks.post(ctx, event)
+	}
+	return op, nil
+}
+
+// operationEvents describes the pre and post hooks of an undo or redo step.
+// A comment whose task is gone gets no hooks; the step reports the conflict.
+func (h *Hooks) operationEvents(ctx context.Context, change domain.OperationChange) (pre, post []domain.HookEvent) {
+	from, to := change.FromTask, change.ToTask
+	switch {
+	case from == nil && to != nil:
+		return []domain.HookEvent{taskHookEvent(domain.HookPreTaskCreate, *to, nil)},
+			[]domain.HookEvent{taskHookEvent(domain.HookTaskCreated, *to, nil)}
+	case from != nil && to == nil:
+		return []domain.HookEvent{taskHookEvent(domain.HookPreTaskDelete, *from, nil)},
+			[]domain.HookEvent{taskHookEvent(domain.HookTaskDeleted, *from, nil)}
+	case from != nil && to != nil:
+		patch := taskDifference(*from, *to)
+		if patch.ColumnID != nil {
+			pre = append(pre, taskHookEvent(domain.HookPreTaskMove, *from, h.moveHookChanges(ctx, *from, patch.ColumnID, patch.Status)))
+		}
+		if change.Operation.Kind == domain.OperationTaskMove {
+			return pre, []domain.HookEvent{taskHookEvent(domain.HookTaskMoved, *to, nil)}
+		}
+		pre = append(pre, taskHookEvent(domain.HookPreTaskUpdate, *from, h.updateHookChanges(ctx, *from, patch)))
+		return pre, []domain.HookEvent{taskHookEvent(domain.HookTaskUpdated, *to, nil)}
+	}
+
+	var preHook, postHook string
+	comment := change.FromComment
+	switch {
+	case change.FromComment == nil && change.ToComment != nil:
+		preHook, postHook, comment = domain.HookPreCommentCreate, domain.HookCommentCreated, change.ToComment
+	case change.FromComment != nil && change.ToComment == nil:
+		preHook, postHook = domain.HookPreCommentDelete, domain.HookCommentDeleted
+	case change.FromComment != nil && change.ToComment != nil:
+		preHook, postHook = domain.HookPreCommentUpdate, domain.HookCommentUpdated
+	default:
+		return nil, nil
+	}
+	event, err := h.commentEvent(ctx, preHook, *comment)
+	if err != nil {
+		return nil, nil
+	}
+	done := event
+	done.Hook = postHook
+	if preHook == domain.HookPreCommentUpdate {
+		event.Changes = map[string]any{"body": change.ToComment.BodyMD}
+		done.Comment = change.ToComment
+	}
+	return []domain.HookEvent{event}, []domain.HookEvent{done}
+}
+
+// taskDifference returns the patch that turns from into to, holding only
+// the fields hooks describe.
+func taskDifference(from, to domain.Task) domain.TaskPatch {
+	var patch domain.TaskPatch
+	if from.Title != to.Title {
+		patch.Title = &to.Title
+	}
+	if from.DescriptionMD != to.DescriptionMD {
+		patch.DescriptionMD = &to.DescriptionMD
+	}
+	if !sameStringPtr(from.Status, to.Status) {
+		patch.Status = to.Status
+	}
+	if from.Priority != to.Priority {
+		patch.Priority = &to.Priority
+	}
+	switch {
+	case to.DueAt == nil && from.DueAt != nil:
+		patch.ClearDueAt = true
+	case to.DueAt != nil && (from.DueAt == nil || !from.DueAt.Equal(*to.DueAt)):
+		patch.DueAt = to.DueAt
+	}
+	if !sameStringPtr(from.ColumnID, to.ColumnID) && to.ColumnID != nil {
+		patch.ColumnID = to.ColumnID
+	}
+	if !slices.Equal(from.Labels, to.Labels) {
+		patch.Labels = &to.Labels
+	}
+	return patch
+}
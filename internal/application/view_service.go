@@ -0,0 +1,182 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+// ErrViewNotFound is returned when a view ID or name does not exist.
+var ErrViewNotFound = errors.New("view not found")
+
+// SaveViewInput describes a view to store. An empty BoardID makes a view for
+// every board of the workspace. Saving a name that already exists in the
+// same scope fails unless Replace is set, in which case the settings of the
+// existing view are replaced.
+type SaveViewInput struct {
+	WorkspaceID string
+	BoardID     string
+	Name        string
+	Settings    domain.ViewSettings
+	Replace     bool
+}
+
+// ViewService manages saved views.
+type ViewService struct {
+	repo domain.ViewRepository
+	now  func() time.Time
+}
+
+// NewViewService creates a new ViewService.
+func NewViewService(repo domain.ViewRepository) *ViewService {
+	return &ViewService{repo: repo, now: time.Now}
+}
+
+// SaveView validates and stores a view.
+func (s *ViewService) SaveView(ctx context.Context, input SaveViewInput) (domain.View, error) {
+	workspaceID := strings.TrimSpace(input.WorkspaceID)
+	if workspaceID == "" {
+		return domain.View{}, errors.New("workspace id is required")
+	}
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return domain.View{}, errors.New("view name is required")
+	}
+	settings, err := s.normalizeSettings(input.Settings)
+	if err != nil {
+		return domain.View{}, err
+	}
+	boardID := strings.TrimSpace(input.BoardID)
+
+	existing, err := s.repo.List(ctx, workspaceID, boardID)
+	if err != nil {
+		return domain.View{}, err
+	}
+	now := s.now().UTC()
+	for _, view := range existing {
+		if viewBoardID(view) != boardID || !strings.EqualFold(view.Name, name) {
+			continue
+		}
+		if !input.Replace {
+			return domain.View{}, fmt.Errorf("view %q already exists", view.Name)
+		}
+		view.Settings = settings
+		view.UpdatedAt = now
+		if err := s.repo.Update(ctx, view); err != nil {
+			return domain.View{}, err
+		}
+		return view, nil
+	}
+
+	view := domain.View{
+		ID:          uuid.NewString(),
+		WorkspaceID: workspaceID,
+		Name:        name,
+		Settings:    settings,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if boardID != "" {
+		view.BoardID = &boardID
+	}
+	if err := s.repo.Create(ctx, view); err != nil {
+		return domain.View{}, err
+	}
+	return view, nil
+}
+
+// ListViews returns the views of a workspace by name. A non-empty boardID
+// limits them to the views that apply to that board.
+func (s *ViewService) ListViews(ctx context.Context, workspaceID, boardID string) ([]domain.View, error) {
+	return s.repo.List(ctx, workspaceID, boardID)
+}
+
+// GetView returns a view by ID.
+func (s *ViewService) GetView(ctx context.Context, id string) (domain.View, error) {
+	view, err := s.repo.Get(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.View{}, ErrViewNotFound
+	}
+	return view, err
+}
+
+// FindView returns the view with the given name, ignoring case, among the
+// views that apply to boardID. A board view wins over a workspace view of
+// the same name.
+func (s *ViewService) FindView(ctx context.Context, workspaceID, boardID, name string) (domain.View, error) {
+	views, err := s.repo.List(ctx, workspaceID, boardID)
+	if err != nil {
+		return domain.View{}, err
+	}
+	name = strings.TrimSpace(name)
+	var found *domain.View
+	for i, view := range views {
+		if !strings.EqualFold(view.Name, name) {
+			continue
+		}
+		if found == nil || (found.BoardID == nil && view.BoardID != nil) {
+			found = &views[i]
+		}
+	}
+	if found == nil {
+		return domain.View{}, ErrViewNotFound
+	}
+	return *found, nil
+}
+
+// DeleteView removes a view.
+func (s *ViewService) DeleteView(ctx context.Context, id string) error {
+	affected, err := s.repo.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrViewNotFound
+	}
+	return nil
+}
+
+// normalizeSettings trims the settings and rejects unknown values and filter
+// expressions that do not parse.
+func (s *ViewService) normalizeSettings(settings domain.ViewSettings) (domain.ViewSettings, error) {
+	settings.Column = strings.TrimSpace(settings.Column)
+	settings.Search = strings.TrimSpace(settings.Search)
+	settings.Filter = strings.TrimSpace(settings.Filter)
+	settings.Due = strings.ToLower(strings.TrimSpace(settings.Due))
+	settings.Sort = strings.ToLower(strings.TrimSpace(settings.Sort))
+	settings.Layout = strings.ToLower(strings.TrimSpace(settings.Layout))
+
+	if settings.Priority != nil && (*settings.Priority < 0 || *settings.Priority > 5) {
+		return domain.ViewSettings{}, fmt.Errorf("invalid priority %d: must be between 0 and 5", *settings.Priority)
+	}
+	if settings.Due != "" && !slices.Contains(domain.ViewDueFilters, settings.Due) {
+		return domain.ViewSettings{}, fmt.Errorf("invalid due filter %q: must be one of %s", settings.Due, strings.Join(domain.ViewDueFilters, ", "))
+	}
+	if settings.Sort != "" && !slices.Contains(domain.ViewSorts, settings.Sort) {
+		return domain.ViewSettings{}, fmt.Errorf("invalid sort %q: must be one of %s", settings.Sort, strings.Join(domain.ViewSorts, ", "))
+	}
+	if settings.Layout != "" && !slices.Contains(domain.ViewLayouts, settings.Layout) {
+		return domain.ViewSettings{}, fmt.Errorf("invalid layout %q: must be one of %s", settings.Layout, strings.Join(domain.ViewLayouts, ", "))
+	}
+	if settings.Filter != "" {
+		if _, err := ParseTaskQuery(settings.Filter, s.now()); err != nil {
+			return domain.ViewSettings{}, fmt.Errorf("invalid filter: %w", err)
+		}
+	}
+	return settings, nil
+}
+
+func viewBoardID(view domain.View) string {
+	if view.BoardID == nil {
+		return ""
+	}
+	return *view.BoardID
+}
@@ -0,0 +1,132 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+type fakeViewRepo struct {
+	views []domain.View
+}
+
+func (r *fakeViewRepo) Create(_ context.Context, v domain.View) error {
+	r.views = append(r.views, v)
+	return nil
+}
+
+func (r *fakeViewRepo) Update(_ context.Context, v domain.View) error {
+	for i := range r.views {
+		if r.views[i].ID == v.ID {
+			r.views[i] = v
+			return nil
+		}
+	}
+	return sql.ErrNoRows
+}
+
+func (r *fakeViewRepo) Get(_ context.Context, id string) (domain.View, error) {
+	for _, v := range r.views {
+		if v.ID == id {
+			return v, nil
+		}
+	}
+	return domain.View{}, sql.ErrNoRows
+}
+
+func (r *fakeViewRepo) List(_ context.Context, workspaceID, boardID string) ([]domain.View, error) {
+	var result []domain.View
+	for _, v := range r.views {
+		if v.WorkspaceID == workspaceID && (boardID == "" || v.BoardID == nil || *v.BoardID == boardID) {
+			result = append(result, v)
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeViewRepo) Delete(_ context.Context, id string) (int, error) {
+	for i, v := range r.views {
+		if v.ID == id {
+			r.views = append(r.views[:i], r.views[i+1:]...)
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func TestViewService_SaveView(t *testing.T) {
+	ctx := context.Background()
+	svc := NewViewService(&fakeViewRepo{})
+
+	view, err := svc.SaveView(ctx, SaveViewInput{
+		WorkspaceID: "ws",
+		Name:        " Urgent ",
+		Settings:    domain.ViewSettings{Sort: "Due", Layout: " kanban", Filter: "label:bug"},
+	})
+	if err != nil {
+		t.Fatalf("SaveView: %v", err)
+	}
+	if view.Name != "Urgent" || view.BoardID != nil || view.Settings.Sort != domain.ViewSortDue || view.Settings.Layout != domain.ViewLayoutKanban {
+		t.Fatalf("SaveView = %+v", view)
+	}
+
+	if _, err := svc.SaveView(ctx, SaveViewInput{WorkspaceID: "ws", Name: "urgent"}); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("duplicate name err = %v", err)
+	}
+	// The same name on a board is a different view.
+	if _, err := svc.SaveView(ctx, SaveViewInput{WorkspaceID: "ws", BoardID: "b1", Name: "Urgent", Settings: domain.ViewSettings{Due: "overdue"}}); err != nil {
+		t.Fatalf("board view: %v", err)
+	}
+
+	replaced, err := svc.SaveView(ctx, SaveViewInput{WorkspaceID: "ws", Name: "URGENT", Settings: domain.ViewSettings{Due: "soon"}, Replace: true})
+	if err != nil {
+		t.Fatalf("replace: %v", err)
+	}
+	if replaced.ID != view.ID || replaced.Name != "Urgent" || replaced.Settings.Due != domain.ViewDueSoon || replaced.Settings.Sort != "" {
+		t.Fatalf("replace = %+v", replaced)
+	}
+
+	found, err := svc.FindView(ctx, "ws", "b1", "urgent")
+	if err != nil || found.BoardID == nil || found.Settings.Due != domain.ViewDueOverdue {
+		t.Fatalf("FindView on the board = %+v, %v; want the board view", found, err)
+	}
+	found, err = svc.FindView(ctx, "ws", "b2", "urgent")
+	if err != nil || found.ID != view.ID {
+		t.Fatalf("FindView on another board = %+v, %v; want the workspace view", found, err)
+	}
+	if _, err := svc.FindView(ctx, "ws", "", "missing"); !errors.Is(err, ErrViewNotFound) {
+		t.Fatalf("FindView(missing) err = %v", err)
+	}
+}
+
+func TestViewService_SaveViewRejectsInvalidSettings(t *testing.T) {
+	priority := 9
+	for name, settings := range map[string]domain.ViewSettings{
+		"priority": {Priority: &priority},
+		"due":      {Due: "later"},
+		"sort":     {Sort: "random"},
+		"layout":   {Layout: "grid"},
+		"filter":   {Filter: "priority<"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			svc := NewViewService(&fakeViewRepo{})
+			if _, err := svc.SaveView(context.Background(), SaveViewInput{WorkspaceID: "ws", Name: "v", Settings: settings}); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestViewService_GetAndDeleteMissing(t *testing.T) {
+	svc := NewViewService(&fakeViewRepo{})
+	if _, err := svc.GetView(context.Background(), "nope"); !errors.Is(err, ErrViewNotFound) {
+		t.Fatalf("GetView err = %v", err)
+	}
+	if err := svc.DeleteView(context.Background(), "nope"); !errors.Is(err, ErrViewNotFound) {
+		t.Fatalf("DeleteView err = %v", err)
+	}
+}
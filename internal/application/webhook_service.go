@@ -0,0 +1,321 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+// MaxWebhookAttempts is the number of failed deliveries after which a
+// delivery stops being retried automatically and needs `kanji webhook retry`.
+const MaxWebhookAttempts = 8
+
+// Webhook delivery states derived from attempts and delivery time.
+const (
+	WebhookStateDelivered = "delivered"
+	WebhookStatePending   = SyncStatePending
+	WebhookStateRetrying  = SyncStateRetrying
+	WebhookStateFailed    = SyncStateFailed
+)
+
+// ErrWebhookNotFound is returned when a webhook ID does not exist.
+var ErrWebhookNotFound = errors.New("webhook not found")
+
+// WebhookDeliveryState classifies a delivery: delivered, never attempted,
+// waiting for another attempt, or out of attempts.
+func WebhookDeliveryState(d domain.WebhookDelivery) string {
+	switch {
+	case d.DeliveredAt != nil:
+		return WebhookStateDelivered
+	case d.Attempts >= MaxWebhookAttempts:
+		return WebhookStateFailed
+	case d.Attempts > 0:
+		return WebhookStateRetrying
+	default:
+		return WebhookStatePending
+	}
+}
+
+// WebhookSender POSTs a delivery to its webhook, signed with the plaintext
+// secret. It returns the response status, or 0 when there was no response.
+type WebhookSender interface {
+	Send(ctx context.Context, webhook domain.Webhook, secret string, delivery domain.WebhookDelivery) (int, error)
+}
+
+// AddWebhookInput describes a webhook to register. An empty Secret
+// generates one.
+type AddWebhookInput struct {
+	WorkspaceID string
+	URL         string
+	Events      []string
+	Secret      string
+}
+
+// WebhookDeliverResult summarizes a delivery run.
+type WebhookDeliverResult struct {
+	Delivered int
+	Failed    int
+	Skipped   int
+}
+
+// WebhookService manages webhooks and sends their queued deliveries.
+// Deliveries themselves are queued by the repositories, in the same
+// transaction as the task or comment change that caused them.
+type WebhookService struct {
+	repo   domain.WebhookRepository
+	sender WebhookSender
+	cipher CredentialCipher
+	now    func() time.Time
+}
+
+// NewWebhookService creates a new WebhookService. Signing secrets are stored
+// encrypted with cipher; a nil cipher stores them as given.
+func NewWebhookService(repo domain.WebhookRepository, sender WebhookSender, cipher CredentialCipher) *WebhookService {
+	return &WebhookService{repo: repo, sender: sender, cipher: cipher, now: time.Now}
+}
+
+// AddWebhook validates and stores a webhook. It returns the plaintext
+// signing secret, which is not shown again.
+func (s *WebhookService) AddWebhook(ctx context.Context, input AddWebhookInput) (domain.Webhook, string, error) {
+	workspaceID := strings.TrimSpace(input.WorkspaceID)
+	if workspaceID == "" {
+		return domain.Webhook{}, "", errors.New("workspace id is required")
+	}
+	target := strings.TrimSpace(input.URL)
+	parsed, err := url.Parse(target)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return domain.Webhook{}, "", fmt.Errorf("invalid webhook url %q: must be an http or https URL", target)
+	}
+	events, err := normalizeWebhookEvents(input.Events)
+	if err != nil {
+		return domain.Webhook{}, "", err
+	}
+
+	secret := input.Secret
+	if secret == "" {
+		buf := make([]byte, 32)
+		if _, err := rand.Read(buf); err != nil {
+			return domain.Webhook{}, "", fmt.Errorf("generate secret: %w", err)
+		}
+		secret = hex.EncodeToString(buf)
+	}
+	stored := secret
+	if s.cipher != nil {
+		if stored, err = s.cipher.Encrypt(secret); err != nil {
+			return domain.Webhook{}, "", fmt.Errorf("encrypt webhook secret: %w", err)
+		}
+	}
+
+	webhook := domain.Webhook{
+		ID:          uuid.NewString(),
+		WorkspaceID: workspaceID,
+		URL:         target,
+		Secret:      stored,
+		Events:      events,
+		CreatedAt:   s.now().UTC(),
+	}
+	if err := s.repo.Create(ctx, webhook); err != nil {
+		return domain.Webhook{}, "", err
+	}
+	return webhook, secret, nil
+}
+
+func normalizeWebhookEvents(events []string) ([]string, error) {
+	known := make(map[string]bool, len(domain.WebhookEvents))
+	for _, e := range domain.WebhookEvents {
+		known[e] = true
+	}
+	seen := make(map[string]bool, len(events))
+	result := make([]string, 0, len(events))
+	for _, raw := range events {
+		event := strings.ToLower(strings.TrimSpace(raw))
+		if event == "" || seen[event] {
+			continue
+		}
+		if !known[event] {
+			return nil, fmt.Errorf("unknown webhook event %q: must be one of %s", event, strings.Join(domain.WebhookEvents, ", "))
+		}
+		seen[event] = true
+		result = append(result, event)
+	}
+	if len(result) == 0 {
+		return nil, errors.New("at least one webhook event is required")
+	}
+	return result, nil
+}
+
+// ListWebhooks returns the webhooks of a workspace, or all of them when
+// workspaceID is empty.
+func (s *WebhookService) ListWebhooks(ctx context.Context, workspaceID string) ([]domain.Webhook, error) {
+	return s.repo.List(ctx, workspaceID)
+}
+
+// RotateSecrets re-encrypts every signing secret with next, in one
+// transaction. It returns the number of secrets rotated.
+func (s *WebhookService) RotateSecrets(ctx context.Context, next CredentialCipher) (int, error) {
+	webhooks, err := s.repo.List(ctx, "")
+	if err != nil {
+		return 0, err
+	}
+	updates := make(map[string]string, len(webhooks))
+	for _, w := range webhooks {
+		plaintext := w.Secret
+		if s.cipher != nil {
+			if plaintext, err = s.cipher.Decrypt(w.Secret); err != nil {
+				return 0, fmt.Errorf("webhook %s: %w", w.ID, err)
+			}
+		}
+		stored, err := next.Encrypt(plaintext)
+		if err != nil {
+			return 0, err
+		}
+		updates[w.ID] = stored
+	}
+	if len(updates) == 0 {
+		return 0, nil
+	}
+	if err := s.repo.SetSecrets(ctx, updates); err != nil {
+		return 0, err
+	}
+	return len(updates), nil
+}
+
+// RemoveWebhook deletes a webhook and its delivery log.
+func (s *WebhookService) RemoveWebhook(ctx context.Context, id string) error {
+	if strings.TrimSpace(id) == "" {
+		return errors.New("webhook id is required")
+	}
+	affected, err := s.repo.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrWebhookNotFound
+	}
+	return nil
+}
+
+// ListDeliveries returns the delivery log, newest first, optionally filtered
+// by webhook and state. A positive limit caps the number of entries.
+func (s *WebhookService) ListDeliveries(ctx context.Context, webhookID, state string, limit int) ([]domain.WebhookDelivery, error) {
+	state = strings.ToLower(strings.TrimSpace(state))
+	switch state {
+	case "", WebhookStateDelivered, WebhookStatePending, WebhookStateRetrying, WebhookStateFailed:
+	default:
+		return nil, fmt.Errorf("invalid state %q: must be %s, %s, %s or %s", state,
+			WebhookStateDelivered, WebhookStatePending, WebhookStateRetrying, WebhookStateFailed)
+	}
+	if webhookID = strings.TrimSpace(webhookID); webhookID != "" {
+		if _, err := s.repo.Get(ctx, webhookID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, ErrWebhookNotFound
+			}
+			return nil, err
+		}
+	}
+	deliveries, err := s.repo.ListDeliveries(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.WebhookDelivery, 0, len(deliveries))
+	for _, d := range deliveries {
+		if state != "" && WebhookDeliveryState(d) != state {
+			continue
+		}
+		result = append(result, d)
+		if limit > 0 && len(result) == limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+// Retry resets an undelivered delivery so the next run sends it
+// immediately.
+func (s *WebhookService) Retry(ctx context.Context, deliveryID string) (int, error) {
+	return s.repo.Retry(ctx, deliveryID)
+}
+
+// Deliver sends every delivery that is due, oldest first. A delivery waiting
+// for a retry holds back later deliveries of the same webhook so receivers
+// see events in order; deliveries out of attempts do not.
+func (s *WebhookService) Deliver(ctx context.Context) (WebhookDeliverResult, error) {
+	var result WebhookDeliverResult
+
+	pending, err := s.repo.ListUndelivered(ctx)
+	if err != nil || len(pending) == 0 {
+		return result, err
+	}
+	webhooks, err := s.repo.List(ctx, "")
+	if err != nil {
+		return result, err
+	}
+	byID := make(map[string]domain.Webhook, len(webhooks))
+	for _, w := range webhooks {
+		byID[w.ID] = w
+	}
+
+	now := s.now()
+	blocked := make(map[string]bool)
+	for _, delivery := range pending {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		if delivery.Attempts >= MaxWebhookAttempts {
+			result.Skipped++
+			continue
+		}
+		if blocked[delivery.WebhookID] || (delivery.NextAttemptAt != nil && delivery.NextAttemptAt.After(now)) {
+			blocked[delivery.WebhookID] = true
+			result.Skipped++
+			continue
+		}
+		webhook, ok := byID[delivery.WebhookID]
+		if !ok {
+			result.Skipped++
+			continue
+		}
+
+		status, sendErr := s.send(ctx, webhook, delivery)
+		if sendErr != nil {
+			blocked[delivery.WebhookID] = true
+			result.Failed++
+			var statusPtr *int
+			if status != 0 {
+				statusPtr = &status
+			}
+			next := now.Add(SyncBackoff(delivery.Attempts + 1))
+			if err := s.repo.RecordFailure(ctx, delivery.ID, statusPtr, sendErr.Error(), next); err != nil {
+				return result, err
+			}
+			continue
+		}
+		if err := s.repo.MarkDelivered(ctx, delivery.ID, status, s.now().UTC()); err != nil {
+			return result, err
+		}
+		result.Delivered++
+	}
+	return result, nil
+}
+
+func (s *WebhookService) send(ctx context.Context, webhook domain.Webhook, delivery domain.WebhookDelivery) (int, error) {
+	secret := webhook.Secret
+	if s.cipher != nil {
+		plaintext, err := s.cipher.Decrypt(secret)
+		if err != nil {
+			return 0, fmt.Errorf("decrypt webhook secret: %w", err)
+		}
+		secret = plaintext
+	}
+	return s.sender.Send(ctx, webhook, secret, delivery)
+}
@@ -0,0 +1,182 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+type fakeWebhookRepo struct {
+	webhooks   []domain.Webhook
+	deliveries []domain.WebhookDelivery
+}
+
+func (r *fakeWebhookRepo) Create(_ context.Context, w domain.Webhook) error {
+	r.webhooks = append(r.webhooks, w)
+	return nil
+}
+
+func (r *fakeWebhookRepo) Get(_ context.Context, id string) (domain.Webhook, error) {
+	for _, w := range r.webhooks {
+		if w.ID == id {
+			return w, nil
+		}
+	}
+	return domain.Webhook{}, errors.New("not found")
+}
+
+func (r *fakeWebhookRepo) List(context.Context, string) ([]domain.Webhook, error) {
+	return r.webhooks, nil
+}
+
+func (r *fakeWebhookRepo) SetSecrets(_ context.Context, secrets map[string]string) error {
+	for i := range r.webhooks {
+		if secret, ok := secrets[r.webhooks[i].ID]; ok {
+			r.webhooks[i].Secret = secret
+		}
+	}
+	return nil
+}
+
+func (r *fakeWebhookRepo) Delete(context.Context, string) (int, error) { return 0, nil }
+
+func (r *fakeWebhookRepo) ListDeliveries(context.Context, string) ([]domain.WebhookDelivery, error) {
+	return r.deliveries, nil
+}
+
+func (r *fakeWebhookRepo) ListUndelivered(context.Context) ([]domain.WebhookDelivery, error) {
+	var result []domain.WebhookDelivery
+	for _, d := range r.deliveries {
+		if d.DeliveredAt == nil {
+			result = append(result, d)
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeWebhookRepo) MarkDelivered(_ context.Context, id string, status int, at time.Time) error {
+	for i := range r.deliveries {
+		if r.deliveries[i].ID == id {
+			r.deliveries[i].Attempts++
+			r.deliveries[i].ResponseStatus = &status
+			r.deliveries[i].DeliveredAt = &at
+		}
+	}
+	return nil
+}
+
+func (r *fakeWebhookRepo) RecordFailure(_ context.Context, id string, status *int, lastError string, next time.Time) error {
+	for i := range r.deliveries {
+		if r.deliveries[i].ID == id {
+			r.deliveries[i].Attempts++
+			r.deliveries[i].ResponseStatus = status
+			r.deliveries[i].LastError = &lastError
+			r.deliveries[i].NextAttemptAt = &next
+		}
+	}
+	return nil
+}
+
+func (r *fakeWebhookRepo) Retry(context.Context, string) (int, error) { return 0, nil }
+
+type fakeWebhookSender struct {
+	fail    map[string]bool
+	secrets []string
+	sent    []string
+}
+
+func (s *fakeWebhookSender) Send(_ context.Context, w domain.Webhook, secret string, d domain.WebhookDelivery) (int, error) {
+	s.secrets = append(s.secrets, secret)
+	if s.fail[w.ID] {
+		return 500, errors.New("boom")
+	}
+	s.sent = append(s.sent, d.ID)
+	return 204, nil
+}
+
+func TestWebhookService_AddWebhookValidates(t *testing.T) {
+	repo := &fakeWebhookRepo{}
+	svc := NewWebhookService(repo, &fakeWebhookSender{}, prefixCipher{key: "k"})
+	ctx := context.Background()
+
+	for _, input := range []AddWebhookInput{
+		{WorkspaceID: "w1", URL: "ftp://example.com", Events: []string{"task.created"}},
+		{WorkspaceID: "w1", URL: "http://example.com", Events: []string{"task.exploded"}},
+		{WorkspaceID: "w1", URL: "http://example.com"},
+		{URL: "http://example.com", Events: []string{"task.created"}},
+	} {
+		if _, _, err := svc.AddWebhook(ctx, input); err == nil {
+			t.Fatalf("expected %+v to be rejected", input)
+		}
+	}
+
+	webhook, secret, err := svc.AddWebhook(ctx, AddWebhookInput{
+		WorkspaceID: "w1",
+		URL:         "http://example.com/hook",
+		Events:      []string{"Task.Created", "task.created", "comment.created"},
+	})
+	if err != nil {
+		t.Fatalf("add webhook: %v", err)
+	}
+	if len(secret) != 64 || webhook.Secret != "enc:k:"+secret {
+		t.Fatalf("expected a generated secret stored encrypted, got %q / %q", secret, webhook.Secret)
+	}
+	if len(webhook.Events) != 2 || webhook.Events[0] != "task.created" {
+		t.Fatalf("events not normalized: %v", webhook.Events)
+	}
+}
+
+func TestWebhookService_DeliverRetriesInOrder(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	later := now.Add(time.Minute)
+	repo := &fakeWebhookRepo{
+		webhooks: []domain.Webhook{
+			{ID: "ok", Secret: "enc:k:s1"},
+			{ID: "down", Secret: "enc:k:s2"},
+			{ID: "waiting", Secret: "s3"},
+		},
+		deliveries: []domain.WebhookDelivery{
+			{ID: "d1", WebhookID: "ok"},
+			{ID: "d2", WebhookID: "down"},
+			{ID: "d3", WebhookID: "down"},
+			{ID: "d4", WebhookID: "waiting", Attempts: 1, NextAttemptAt: &later},
+			{ID: "d5", WebhookID: "ok", Attempts: MaxWebhookAttempts},
+			{ID: "d6", WebhookID: "ok"},
+		},
+	}
+	sender := &fakeWebhookSender{fail: map[string]bool{"down": true}}
+	svc := NewWebhookService(repo, sender, prefixCipher{key: "k"})
+	svc.now = func() time.Time { return now }
+
+	result, err := svc.Deliver(context.Background())
+	if err != nil {
+		t.Fatalf("deliver: %v", err)
+	}
+	if result.Delivered != 2 || result.Failed != 1 || result.Skipped != 3 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if len(sender.sent) != 2 || sender.sent[0] != "d1" || sender.sent[1] != "d6" {
+		t.Fatalf("expected d1 and d6 sent, got %v", sender.sent)
+	}
+	if sender.secrets[0] != "s1" {
+		t.Fatalf("secret not decrypted before signing: %q", sender.secrets[0])
+	}
+
+	failed := repo.deliveries[1]
+	if failed.Attempts != 1 || failed.ResponseStatus == nil || *failed.ResponseStatus != 500 ||
+		failed.NextAttemptAt == nil || !failed.NextAttemptAt.Equal(now.Add(SyncBackoff(1))) {
+		t.Fatalf("failure not recorded with backoff: %+v", failed)
+	}
+	if repo.deliveries[2].Attempts != 0 {
+		t.Fatalf("later delivery of a failing webhook must wait, got %+v", repo.deliveries[2])
+	}
+	if got := WebhookDeliveryState(repo.deliveries[0]); got != WebhookStateDelivered {
+		t.Fatalf("state = %q, want delivered", got)
+	}
+	if got := WebhookDeliveryState(repo.deliveries[4]); got != WebhookStateFailed {
+		t.Fatalf("state = %q, want failed", got)
+	}
+}
@@ -0,0 +1,122 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+// ErrWIPLimitExceeded is the sentinel matched by every WIPLimitError via
+// errors.Is.
+var ErrWIPLimitExceeded = errors.New("column wip limit exceeded")
+
+// WIPLimitError reports that a column cannot accept another task without
+// exceeding its configured WIP limit.
+type WIPLimitError struct {
+	ColumnID   string
+	ColumnName string
+	Limit      int
+	Count      int
+}
+
+func (e *WIPLimitError) Error() string {
+	return fmt.Sprintf("column %q is at its WIP limit (%d/%d)", e.ColumnName, e.Count, e.Limit)
+}
+
+// Is reports whether target is ErrWIPLimitExceeded.
+func (e *WIPLimitError) Is(target error) bool {
+	return target == ErrWIPLimitExceeded
+}
+
+// WIPLimitReached reports whether a column already holds at least as many
+// tasks as its WIP limit allows. Columns without a positive limit never fill up.
+func WIPLimitReached(column domain.Column, count int) bool {
+	if column.WIPLimit == nil || *column.WIPLimit <= 0 {
+		return false
+	}
+	return count >= *column.WIPLimit
+}
+
+// checkWIPLimit returns a *WIPLimitError when columnID is full. The task
+// identified by excludeTaskID is not counted, so re-saving a task inside its
+// own column never trips the limit. Missing board or column IDs skip the check.
+// It returns the column when it has a limit, for placeUnderWIPLimit to check
+// again as the task is stored.
+func checkWIPLimit(ctx context.Context, repo domain.TaskRepository, workspaceID, boardID, columnID, excludeTaskID string) (*domain.Column, error) {
+	return checkWIPCapacity(ctx, repo, workspaceID, boardID, columnID, excludeTaskID, 1)
+}
+
+// checkWIPCapacity is checkWIPLimit for incoming tasks entering columnID at
+// once: it fails unless the column has room for all of them.
+func checkWIPCapacity(ctx context.Context, repo domain.TaskRepository, workspaceID, boardID, columnID, excludeTaskID string, incoming int) (*domain.Column, error) {
+	boardID = strings.TrimSpace(boardID)
+	columnID = strings.TrimSpace(columnID)
+	if boardID == "" || columnID == "" {
+		return nil, nil
+	}
+
+	columns, err := repo.ListColumns(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	var column domain.Column
+	found := false
+	for _, c := range columns {
+		if c.ID == columnID {
+			column = c
+			found = true
+			break
+		}
+	}
+	if !found || column.WIPLimit == nil || *column.WIPLimit <= 0 {
+		return nil, nil
+	}
+
+	tasks, err := repo.List(ctx, domain.TaskFilter{
+		WorkspaceID: workspaceID,
+		BoardID:     boardID,
+		ColumnID:    columnID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	count := 0
+	for _, t := range tasks {
+		if t.ID != excludeTaskID {
+			count++
+		}
+	}
+
+	if WIPLimitReached(column, count+incoming-1) {
+		return nil, wipLimitError(column, count)
+	}
+	return &column, nil
+}
+
+// placeUnderWIPLimit makes place fail with a *WIPLimitError when column is
+// already full. Repositories run place in the transaction that stores the
+// task, so the count cannot change between the check and the write, as it
+// can after checkWIPLimit. A nil column returns place as it is.
+func placeUnderWIPLimit(column *domain.Column, place domain.PlaceFunc) domain.PlaceFunc {
+	if column == nil {
+		return place
+	}
+	return func(siblings []domain.Task) (float64, map[string]float64, error) {
+		if WIPLimitReached(*column, len(siblings)) {
+			return 0, nil, wipLimitError(*column, len(siblings))
+		}
+		return place(siblings)
+	}
+}
+
+func wipLimitError(column domain.Column, count int) *WIPLimitError {
+	return &WIPLimitError{
+		ColumnID:   column.ID,
+		ColumnName: column.Name,
+		Limit:      *column.WIPLimit,
+		Count:      count,
+	}
+}
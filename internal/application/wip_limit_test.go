@@ -0,0 +1,192 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+// wipRepo is a TaskRepository fake whose List honours the column filter.
+type wipRepo struct {
+	fakeTaskRepo
+	created []domain.Task
+}
+
+func (r *wipRepo) GetByID(ctx context.Context, taskID string) (domain.Task, error) {
+	for _, t := range r.tasks {
+		if t.ID == taskID {
+			return t, nil
+		}
+	}
+	return domain.Task{}, errors.New("not found")
+}
+
+func (r *wipRepo) List(ctx context.Context, filter domain.TaskFilter) ([]domain.Task, error) {
+	var out []domain.Task
+	for _, t := range r.tasks {
+		if filter.ColumnID != "" && (t.ColumnID == nil || *t.ColumnID != filter.ColumnID) {
+			continue
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+func (r *wipRepo) Create(ctx context.Context, task domain.Task) error {
+	r.created = append(r.created, task)
+	return nil
+}
+
+func (r *wipRepo) CreatePlaced(ctx context.Context, task domain.Task, place domain.PlaceFunc) error {
+	return r.Create(ctx, task)
+}
+
+func newWIPRepo(limit int) *wipRepo {
+	board := "board-1"
+	todo := "col-todo"
+	doing := "col-doing"
+	return &wipRepo{fakeTaskRepo: fakeTaskRepo{
+		columns: []domain.Column{
+			{ID: todo, BoardID: board, Name: "Todo"},
+			{ID: doing, BoardID: board, Name: "Doing", WIPLimit: &limit},
+		},
+		tasks: []domain.Task{
+			{ID: "t1", WorkspaceID: "ws-1", BoardID: &board, ColumnID: &doing},
+			{ID: "t2", WorkspaceID: "ws-1", BoardID: &board, ColumnID: &todo},
+		},
+	}}
+}
+
+func TestTaskFlow_MoveTask_RejectsFullColumn(t *testing.T) {
+	repo := newWIPRepo(1)
+	flow := NewTaskFlow(repo)
+
+	doing := "col-doing"
+	err := flow.MoveTask(context.Background(), "t2", &doing, nil, 0)
+	if !errors.Is(err, ErrWIPLimitExceeded) {
+		t.Fatalf("expected ErrWIPLimitExceeded, got %v", err)
+	}
+	var wipErr *WIPLimitError
+	if !errors.As(err, &wipErr) {
+		t.Fatalf("expected *WIPLimitError, got %T", err)
+	}
+	if wipErr.Limit != 1 || wipErr.Count != 1 || wipErr.ColumnName != "Doing" {
+		t.Fatalf("unexpected error details: %+v", wipErr)
+	}
+	if repo.lastMoveInput.TaskID != "" {
+		t.Fatal("expected no move to reach the repository")
+	}
+}
+
+// racingWIPRepo adds a task to a column just before a move is stored, as
+// another process could between the WIP check and the write.
+type racingWIPRepo struct {
+	*wipRepo
+	column string
+}
+
+func (r *racingWIPRepo) Move(ctx context.Context, input domain.MoveTaskInput) error {
+	board := "board-1"
+	r.tasks = append(r.tasks, domain.Task{ID: "racer", WorkspaceID: "ws-1", BoardID: &board, ColumnID: &r.column})
+	return r.wipRepo.Move(ctx, input)
+}
+
+func TestTaskFlow_MoveTask_ChecksLimitAsItStores(t *testing.T) {
+	doing := "col-doing"
+	// A given position goes through the same check as a placed one.
+	for _, position := range []float64{0, 42} {
+		repo := &racingWIPRepo{wipRepo: newWIPRepo(2), column: doing}
+		flow := NewTaskFlow(repo)
+
+		err := flow.MoveTask(context.Background(), "t2", &doing, nil, position)
+		var wipErr *WIPLimitError
+		if !errors.As(err, &wipErr) || wipErr.Count != 2 {
+			t.Fatalf("position %v: expected the column filled meanwhile to refuse the move, got %v", position, err)
+		}
+	}
+}
+
+func TestTaskFlow_ForceMoveTask_IgnoresLimit(t *testing.T) {
+	repo := newWIPRepo(1)
+	flow := NewTaskFlow(repo)
+
+	doing := "col-doing"
+	if err := flow.ForceMoveTask(context.Background(), "t2", &doing, nil, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo.lastMoveInput.TaskID != "t2" {
+		t.Fatalf("expected t2 to move, got %q", repo.lastMoveInput.TaskID)
+	}
+}
+
+func TestTaskFlow_MoveTask_WithinSameColumnIgnoresLimit(t *testing.T) {
+	repo := newWIPRepo(1)
+	flow := NewTaskFlow(repo)
+
+	doing := "col-doing"
+	if err := flow.MoveTask(context.Background(), "t1", &doing, nil, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestTaskFlow_MoveTaskAdjacent_RejectsFullColumn(t *testing.T) {
+	repo := newWIPRepo(1)
+	flow := NewTaskFlow(repo)
+
+	todo := "col-todo"
+	_, err := flow.MoveTaskAdjacent(context.Background(), "t2", repo.columns, &todo, 1)
+	if !errors.Is(err, ErrWIPLimitExceeded) {
+		t.Fatalf("expected ErrWIPLimitExceeded, got %v", err)
+	}
+}
+
+func TestTaskService_CreateTask_RespectsWIPLimit(t *testing.T) {
+	repo := newWIPRepo(1)
+	service := NewTaskService(repo)
+
+	board := "board-1"
+	doing := "col-doing"
+	input := CreateTaskInput{
+		ProviderID:  "p1",
+		WorkspaceID: "ws-1",
+		BoardID:     &board,
+		ColumnID:    &doing,
+		Title:       "Another",
+	}
+	if _, err := service.CreateTask(context.Background(), input); !errors.Is(err, ErrWIPLimitExceeded) {
+		t.Fatalf("expected ErrWIPLimitExceeded, got %v", err)
+	}
+
+	input.Force = true
+	if _, err := service.CreateTask(context.Background(), input); err != nil {
+		t.Fatalf("unexpected error with force: %v", err)
+	}
+	if len(repo.created) != 1 {
+		t.Fatalf("expected 1 created task, got %d", len(repo.created))
+	}
+}
+
+func TestWIPLimitReached(t *testing.T) {
+	zero := 0
+	two := 2
+	cases := []struct {
+		name  string
+		limit *int
+		count int
+		want  bool
+	}{
+		{"no limit", nil, 10, false},
+		{"zero limit", &zero, 10, false},
+		{"below", &two, 1, false},
+		{"at limit", &two, 2, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := WIPLimitReached(domain.Column{WIPLimit: tc.limit}, tc.count); got != tc.want {
+				t.Fatalf("WIPLimitReached = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
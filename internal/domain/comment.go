@@ -10,4 +10,6 @@ type Comment struct {
 	BodyMD     string
 	Author     *string
 	CreatedAt  time.Time
+	// Version starts at 1 and goes up with every edit of the body.
+	Version int
 }
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrVersionConflict is the sentinel matched by every VersionConflictError via
+// errors.Is.
+var ErrVersionConflict = errors.New("version conflict")
+
+// VersionConflictError reports that a task or comment changed since the
+// version a write was based on. Entity is one of the SyncEntity* values.
+type VersionConflictError struct {
+	Entity   string
+	ID       string
+	Expected int
+	Actual   int
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("%s %s was changed elsewhere (now at version %d, expected %d)", e.Entity, e.ID, e.Actual, e.Expected)
+}
+
+// Is reports whether target is ErrVersionConflict.
+func (e *VersionConflictError) Is(target error) bool {
+	return target == ErrVersionConflict
+}
@@ -0,0 +1,59 @@
+package domain
+
+import "context"
+
+// Local hooks. A pre-* hook runs before the change and vetoes it by exiting
+// non-zero; the others run after the change is saved, with the same names as
+// the webhook events.
+const (
+	HookPreTaskCreate    = "pre-task-create"
+	HookPreTaskUpdate    = "pre-task-update"
+	HookPreTaskMove      = "pre-task-move"
+	HookPreTaskDelete    = "pre-task-delete"
+	HookPreCommentCreate = "pre-comment-create"
+	HookPreCommentUpdate = "pre-comment-update"
+	HookPreCommentDelete = "pre-comment-delete"
+	HookTaskCreated      = "task-created"
+	HookTaskUpdated      = "task-updated"
+	HookTaskMoved        = "task-moved"
+	HookTaskDeleted      = "task-deleted"
+	HookCommentCreated   = "comment-created"
+	HookCommentUpdated   = "comment-updated"
+	HookCommentDeleted   = "comment-deleted"
+)
+
+// Hooks lists every hook name kanji runs.
+var Hooks = []string{
+	HookPreTaskCreate,
+	HookPreTaskUpdate,
+	HookPreTaskMove,
+	HookPreTaskDelete,
+	HookPreCommentCreate,
+	HookPreCommentUpdate,
+	HookPreCommentDelete,
+	HookTaskCreated,
+	HookTaskUpdated,
+	HookTaskMoved,
+	HookTaskDeleted,
+	HookCommentCreated,
+	HookCommentUpdated,
+	HookCommentDeleted,
+}
+
+// HookEvent is what a hook is told about the change. Task is the task as it
+// is (post hooks) or would be created (pre-task-create); Comment is set for
+// comment hooks. Changes holds the fields a pre-task-update or pre-task-move
+// is about to apply, keyed by their JSON names.
+type HookEvent struct {
+	Hook        string
+	WorkspaceID string
+	Task        *Task
+	Comment     *Comment
+	Changes     map[string]any
+}
+
+// HookRunner runs the hook named by event.Hook, if one is installed. A
+// non-nil error from a pre-* hook vetoes the change.
+type HookRunner interface {
+	Run(ctx context.Context, event HookEvent) error
+}
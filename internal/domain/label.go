@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Label is a label of a workspace. Tasks refer to labels by name, compared
+// without regard to case; Color is a #RRGGBB hex color or empty.
+type Label struct {
+	ID          string
+	WorkspaceID string
+	Name        string
+	Color       string
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	// TaskCount is the number of tasks carrying the label, set by List.
+	TaskCount int
+}
+
+// LabelRepository stores the labels of a workspace. Rename, Merge and
+// Delete rewrite the labels of the affected tasks in the same transaction
+// and return how many tasks changed.
+type LabelRepository interface {
+	List(ctx context.Context, workspaceID string) ([]Label, error)
+	GetByName(ctx context.Context, workspaceID, name string) (Label, error)
+	Create(ctx context.Context, label Label) error
+	// Update replaces the color and description of a label.
+	Update(ctx context.Context, label Label) error
+	Rename(ctx context.Context, label Label, newName string) (int, error)
+	// Merge replaces the sources with the target on every task and deletes
+	// the sources.
+	Merge(ctx context.Context, sources []Label, target Label) (int, error)
+	Delete(ctx context.Context, label Label) (int, error)
+}
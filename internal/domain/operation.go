@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Journaled operation kinds.
+const (
+	OperationTaskCreate    = "task.create"
+	OperationTaskUpdate    = "task.update"
+	OperationTaskMove      = "task.move"
+	OperationTaskDelete    = "task.delete"
+	OperationCommentCreate = "comment.create"
+	OperationCommentUpdate = "comment.update"
+	OperationCommentDelete = "comment.delete"
+)
+
+var (
+	// ErrNothingToUndo reports that the namespace has no operation to undo.
+	ErrNothingToUndo = errors.New("nothing to undo")
+	// ErrNothingToRedo reports that the namespace has no undone operation.
+	ErrNothingToRedo = errors.New("nothing to redo")
+	// ErrOperationConflict reports that the entity an operation touched was
+	// changed since, so reverting or replaying it would lose that change.
+	ErrOperationConflict = errors.New("changed since the operation")
+)
+
+// Operation is one journaled task or comment change of a namespace. Title
+// is the task title at the time, for messages such as "Undid move of X".
+type Operation struct {
+	ID        string
+	Namespace string
+	Kind      string
+	EntityID  string
+	Title     string
+	CreatedAt time.Time
+}
+
+// Describe names the operation for messages, as in `move of "Fix login"`.
+func (o Operation) Describe() string {
+	what := map[string]string{
+		OperationTaskCreate:    "creation of",
+		OperationTaskUpdate:    "edit of",
+		OperationTaskMove:      "move of",
+		OperationTaskDelete:    "deletion of",
+		OperationCommentCreate: "comment on",
+		OperationCommentUpdate: "comment edit on",
+		OperationCommentDelete: "comment deletion on",
+	}[o.Kind]
+	if what == "" {
+		what = o.Kind + " of"
+	}
+	return fmt.Sprintf("%s %q", what, o.Title)
+}
+
+// OperationChange is what undoing or redoing an operation does to its
+// entity: it turns From into To. A nil From brings the entity back and a nil
+// To removes it. Only the pair matching the operation kind is set.
+type OperationChange struct {
+	Operation   Operation
+	FromTask    *Task
+	ToTask      *Task
+	FromComment *Comment
+	ToComment   *Comment
+}
+
+type OperationRepository interface {
+	// NextUndo returns the change the next Undo would make, without making
+	// it.
+	NextUndo(ctx context.Context, namespace string) (OperationChange, error)
+	// NextRedo returns the change the next Redo would make, without making
+	// it.
+	NextRedo(ctx context.Context, namespace string) (OperationChange, error)
+	// Undo reverts the latest operation of the namespace that is not undone.
+	// A non-empty operationID makes it fail unless that operation is the
+	// latest one.
+	Undo(ctx context.Context, namespace, operationID string) (Operation, error)
+	// Redo replays the operation undone most recently. A non-empty
+	// operationID makes it fail unless that operation is the one.
+	Redo(ctx context.Context, namespace, operationID string) (Operation, error)
+}
@@ -1,6 +1,10 @@
 package domain
 
-import "time"
+import (
+	"context"
+	"errors"
+	"time"
+)
 
 type Provider struct {
 	ID        string
@@ -10,8 +14,122 @@ type Provider struct {
 	CreatedAt time.Time
 }
 
-// ProviderClient defines the provider boundary for future external integrations.
+// ErrProviderUnsupported is returned by provider clients for operations they
+// do not implement. Callers should consult Capabilities first.
+var ErrProviderUnsupported = errors.New("operation not supported by provider")
+
+// ErrRemoteChanged is returned by a push the provider refused because the
+// remote copy changed after the local change. The sync engine merges the
+// remote copy instead of overwriting it.
+var ErrRemoteChanged = errors.New("remote copy changed after the local change")
+
+// PartialListError is returned by a List method together with the entries it
+// could read when some could not be. Items holds one error per entry left
+// out.
+type PartialListError struct {
+	Items []error
+}
+
+func (e *PartialListError) Error() string {
+	return errors.Join(e.Items...).Error()
+}
+
+// Task fields a provider can carry. A pull only overwrites the local fields
+// the provider advertises; the rest stay as they are.
+const (
+	TaskFieldTitle       = "title"
+	TaskFieldDescription = "description"
+	TaskFieldColumn      = "column"
+	TaskFieldLabels      = "labels"
+	TaskFieldPriority    = "priority"
+	TaskFieldDue         = "due"
+)
+
+// ProviderCapabilities describes what a provider client can do. Entities lists
+// the sync entities (SyncEntity*) the provider understands and TaskFields the
+// task fields (TaskField*) it stores.
+type ProviderCapabilities struct {
+	Pull       bool
+	Push       bool
+	Entities   []string
+	TaskFields []string
+}
+
+// Supports reports whether the provider understands the given sync entity.
+func (c ProviderCapabilities) Supports(entity string) bool {
+	for _, e := range c.Entities {
+		if e == entity {
+			return true
+		}
+	}
+	return false
+}
+
+// CarriesTaskField reports whether the provider stores the given task field.
+func (c ProviderCapabilities) CarriesTaskField(field string) bool {
+	for _, f := range c.TaskFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoteRefs carries the provider-side keys (remote_id) of an entity and its
+// parents, resolved at push time. Empty fields are unknown or not yet synced.
+type RemoteRefs struct {
+	Workspace string
+	Board     string
+	Column    string
+	Task      string
+	Comment   string
+}
+
+// LocalRefs carries the local IDs of an entity and its parents. Missing
+// parents are looked up from the stored rows when resolving RemoteRefs.
+type LocalRefs struct {
+	WorkspaceID string
+	BoardID     string
+	ColumnID    string
+	TaskID      string
+	CommentID   string
+}
+
+// ProviderClient is the boundary between kanji and an external tracker.
+// Entities travel as domain values; RemoteID carries the provider-side key.
+//
+// List methods pull every remote entity under a parent, Pull methods fetch a
+// single entity by remote ID, and Push methods apply a local change (one of
+// the SyncAction* values) and return the entity's remote ID.
+//
+// Pulled values carry remote IDs only: local IDs are left empty and are
+// matched by the sync engine. A pulled task names its column through Status
+// or through a label equal to a column's remote ID.
 type ProviderClient interface {
 	Type() string
 	Name() string
+	Capabilities() ProviderCapabilities
+	// Test checks that the provider is reachable with its configured
+	// credentials.
+	Test(ctx context.Context) error
+
+	ListWorkspaces(ctx context.Context) ([]Workspace, error)
+	PullWorkspace(ctx context.Context, remoteID string) (Workspace, error)
+	PushWorkspace(ctx context.Context, action string, workspace Workspace, refs RemoteRefs) (string, error)
+
+	ListBoards(ctx context.Context, workspaceRemoteID string) ([]Board, error)
+	PullBoard(ctx context.Context, remoteID string) (Board, error)
+	PushBoard(ctx context.Context, action string, board Board, refs RemoteRefs) (string, error)
+
+	ListColumns(ctx context.Context, boardRemoteID string) ([]Column, error)
+	PullColumn(ctx context.Context, boardRemoteID, remoteID string) (Column, error)
+	PushColumn(ctx context.Context, action string, column Column, refs RemoteRefs) (string, error)
+
+	ListTasks(ctx context.Context, boardRemoteID string) ([]Task, error)
+	PullTask(ctx context.Context, boardRemoteID, remoteID string) (Task, error)
+	PushTask(ctx context.Context, action string, task Task, refs RemoteRefs) (string, error)
+
+	ListComments(ctx context.Context, boardRemoteID, taskRemoteID string) ([]Comment, error)
+	PullComment(ctx context.Context, boardRemoteID, remoteID string) (Comment, error)
+	PushComment(ctx context.Context, action string, comment Comment, refs RemoteRefs) (string, error)
 }
@@ -4,25 +4,36 @@ import "context"
 
 type TaskRepository interface {
 	Create(ctx context.Context, task Task) error
+	// CreatePlaced creates a task at the position place gives it in its
+	// column.
+	CreatePlaced(ctx context.Context, task Task, place PlaceFunc) error
 	Update(ctx context.Context, taskID string, patch TaskPatch) error
 	GetByID(ctx context.Context, taskID string) (Task, error)
 	List(ctx context.Context, filter TaskFilter) ([]Task, error)
 	Move(ctx context.Context, input MoveTaskInput) error
 	Delete(ctx context.Context, id string) error
+	// Bulk applies changes to many tasks in one transaction: either all of
+	// them are stored or none is.
+	Bulk(ctx context.Context, changes []TaskChange) error
 	ListColumns(ctx context.Context, boardID string) ([]Column, error)
 	ListBoards(ctx context.Context, workspaceID string) ([]Board, error)
 }
 
 type CommentRepository interface {
 	Create(ctx context.Context, comment Comment) error
+	GetByID(ctx context.Context, commentID string) (Comment, error)
 	ListByTask(ctx context.Context, taskID string) ([]Comment, error)
-	Update(ctx context.Context, commentID string, bodyMD string) error
+	// Update replaces the body. A non-nil ifVersion makes it fail with a
+	// *VersionConflictError unless the comment is still at that version.
+	Update(ctx context.Context, commentID string, bodyMD string, ifVersion *int) error
 	Delete(ctx context.Context, commentID string) error
 }
 
 type SetupRepository interface {
 	ListProviders(ctx context.Context) ([]Provider, error)
 	CreateProvider(ctx context.Context, provider Provider) error
+	SetProviderAuth(ctx context.Context, auth map[string]*string) error
+	DeleteProvider(ctx context.Context, providerID string) error
 	ListWorkspaces(ctx context.Context) ([]Workspace, error)
 	CreateWorkspace(ctx context.Context, workspace Workspace) error
 	RenameWorkspace(ctx context.Context, workspaceID, name string) error
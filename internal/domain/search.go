@@ -0,0 +1,33 @@
+package domain
+
+import "context"
+
+// Search snippets wrap every matched term in these markers. They are
+// control characters that never occur in task text, so callers can swap
+// them for their own highlighting.
+const (
+	SearchMatchStart = "\x02"
+	SearchMatchEnd   = "\x03"
+)
+
+// SearchFilter selects tasks matching a full-text query in a workspace,
+// optionally limited to a board. A zero Limit returns every match.
+type SearchFilter struct {
+	WorkspaceID string
+	BoardID     string
+	Query       string
+	Limit       int
+}
+
+// SearchHit is a task matching a search, best match first. Snippet is the
+// text around the match from the title, description, labels or comments.
+type SearchHit struct {
+	Task    Task
+	Snippet string
+}
+
+type TaskSearchRepository interface {
+	// Search returns the tasks matching the query of the filter, ranked by
+	// relevance.
+	Search(ctx context.Context, filter SearchFilter) ([]SearchHit, error)
+}
@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Sync entities recorded in the outbox.
+const (
+	SyncEntityWorkspace = "workspace"
+	SyncEntityBoard     = "board"
+	SyncEntityColumn    = "column"
+	SyncEntityTask      = "task"
+	SyncEntityComment   = "comment"
+)
+
+// Sync actions recorded in the outbox.
+const (
+	SyncActionCreate = "create"
+	SyncActionUpdate = "update"
+	SyncActionMove   = "move"
+	SyncActionDelete = "delete"
+)
+
+// SyncItem is an outbox entry describing a local change that still has to be
+// pushed to its provider. PayloadJSON holds a JSON snapshot of the entity as it
+// was right after the change (or right before it, for deletes).
+type SyncItem struct {
+	ID            string
+	ProviderID    string
+	Entity        string
+	EntityID      string
+	Action        string
+	PayloadJSON   string
+	Attempts      int
+	LastError     *string
+	NextAttemptAt *time.Time
+	CreatedAt     time.Time
+}
+
+// SyncSnapshot is the last state of an entity that local and remote agreed
+// on, recorded after every successful push or pull. It is the common base of
+// three-way merges.
+type SyncSnapshot struct {
+	ProviderID  string
+	Entity      string
+	EntityID    string
+	PayloadJSON string
+	SyncedAt    time.Time
+}
+
+// SyncConflict records an entity whose local and remote copies changed the
+// same fields since its snapshot. Queued changes of the entity are held until
+// the conflict is resolved. RemoteJSON holds the remote copy in local terms.
+type SyncConflict struct {
+	ID         string
+	ProviderID string
+	Entity     string
+	EntityID   string
+	Fields     []string
+	BaseJSON   string
+	RemoteJSON string
+	CreatedAt  time.Time
+}
+
+type SyncQueueRepository interface {
+	List(ctx context.Context, providerID string) ([]SyncItem, error)
+	Complete(ctx context.Context, itemID string) error
+	RecordFailure(ctx context.Context, itemID string, lastError string, nextAttemptAt time.Time) error
+	Retry(ctx context.Context, itemID string) (int, error)
+	RetryExhausted(ctx context.Context, maxAttempts int) (int, error)
+	PurgeExhausted(ctx context.Context, maxAttempts int) (int, error)
+	PurgeAll(ctx context.Context) (int, error)
+	// ResolveRemoteRefs looks up the remote IDs of the referenced entities.
+	ResolveRemoteRefs(ctx context.Context, refs LocalRefs) (RemoteRefs, error)
+	// SetRemoteID records the provider-side key of an entity. It is sync
+	// bookkeeping and does not enqueue a new outbox entry.
+	SetRemoteID(ctx context.Context, entity, entityID, remoteID string) error
+
+	// Pull support. Imports apply remote state and never enqueue outbox
+	// entries. A task that is new or changes column is positioned by place.
+	ListBoardTasks(ctx context.Context, workspaceID, boardID string) ([]Task, error)
+	ListTaskComments(ctx context.Context, taskID string) ([]Comment, error)
+	ImportTask(ctx context.Context, task Task, place PlaceFunc) error
+	ImportComment(ctx context.Context, comment Comment) error
+
+	// Three-way merge bookkeeping. GetSnapshot returns nil when the entity
+	// has never been synced.
+	GetSnapshot(ctx context.Context, entity, entityID string) (*SyncSnapshot, error)
+	SaveSnapshot(ctx context.Context, snapshot SyncSnapshot) error
+	// ForgetEntity drops the snapshot and any conflict of a deleted entity.
+	ForgetEntity(ctx context.Context, entity, entityID string) error
+	ListConflicts(ctx context.Context, providerID string) ([]SyncConflict, error)
+	SaveConflict(ctx context.Context, conflict SyncConflict) error
+	DeleteConflict(ctx context.Context, entity, entityID string) error
+	// MergeTask stores a task merged with remote changes, replaces its
+	// queued changes with one update carrying the merged state, records
+	// base as its new snapshot and drops any conflict, all at once. place
+	// positions the task as ImportTask does.
+	MergeTask(ctx context.Context, task Task, base SyncSnapshot, place PlaceFunc) error
+}
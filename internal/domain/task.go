@@ -20,6 +20,8 @@ type Task struct {
 	Position        float64
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
+	// Version starts at 1 and goes up with every change to the task.
+	Version int
 }
 
 type TaskPatch struct {
@@ -31,6 +33,12 @@ type TaskPatch struct {
 	ClearDueAt    bool
 	ColumnID      *string
 	Labels        *[]string
+	// Place, when set with a ColumnID that moves the task, positions it in
+	// its new column.
+	Place PlaceFunc
+	// IfVersion, when set, makes the update fail with a *VersionConflictError
+	// unless the task is still at that version.
+	IfVersion *int
 }
 
 type TaskFilter struct {
@@ -40,6 +48,18 @@ type TaskFilter struct {
 	ColumnID    string
 	Status      string
 	DueSoonBy   *time.Time
+	// Query, when set, narrows the tasks to those matching a filter
+	// expression.
+	Query *TaskQuery
+}
+
+// TaskChange is the change a bulk change makes to one task: Delete deletes
+// it, Move moves it and Patch updates it.
+type TaskChange struct {
+	TaskID string
+	Patch  *TaskPatch
+	Move   *MoveTaskInput
+	Delete bool
 }
 
 type MoveTaskInput struct {
@@ -48,4 +68,21 @@ type MoveTaskInput struct {
 	Status    *string
 	Position  float64
 	UpdatedAt time.Time
+	// BoardID and WorkspaceID, when set, move the task to another board,
+	// possibly of another workspace.
+	BoardID     *string
+	WorkspaceID *string
+	// Place, when set, positions the task in its destination column instead
+	// of Position.
+	Place PlaceFunc
+	// IfVersion, when set, makes the move fail with a *VersionConflictError
+	// unless the task is still at that version.
+	IfVersion *int
 }
+
+// PlaceFunc positions a task among siblings, the other tasks of the column
+// it goes to, in no particular order. It returns the position of the task
+// and, when the siblings leave no room, their new positions by ID.
+// Repositories call it in the transaction that stores the task, so the
+// siblings cannot change before the task lands.
+type PlaceFunc func(siblings []Task) (position float64, renumber map[string]float64, err error)
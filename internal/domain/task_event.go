@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Task event kinds.
+const (
+	TaskEventCreated   = "created"
+	TaskEventUpdated   = "updated"
+	TaskEventMoved     = "moved"
+	TaskEventCommented = "commented"
+	TaskEventDeleted   = "deleted"
+)
+
+// TaskEvent is one entry of a task's audit history. Events are recorded in
+// the same transaction as the change they describe and outlive the task.
+// Title is the task title when the event happened.
+type TaskEvent struct {
+	ID          string
+	TaskID      string
+	WorkspaceID string
+	BoardID     *string
+	Kind        string
+	Title       string
+	Changes     []FieldChange
+	Actor       *string
+	CreatedAt   time.Time
+}
+
+// FieldChange is the old and new value of one task field, formatted as
+// text. A nil value means the field was unset.
+type FieldChange struct {
+	Field string  `json:"field"`
+	Old   *string `json:"old"`
+	New   *string `json:"new"`
+}
+
+// ActivityFilter selects events for a workspace activity feed. An empty
+// BoardID covers every board and a zero Limit returns every event.
+type ActivityFilter struct {
+	WorkspaceID string
+	BoardID     string
+	Limit       int
+}
+
+type TaskEventRepository interface {
+	// ListByTask returns the history of a task, oldest first.
+	ListByTask(ctx context.Context, taskID string) ([]TaskEvent, error)
+	// ListActivity returns the events of a workspace, newest first.
+	ListActivity(ctx context.Context, filter ActivityFilter) ([]TaskEvent, error)
+}
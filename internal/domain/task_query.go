@@ -0,0 +1,68 @@
+package domain
+
+import "time"
+
+// TaskQuery is the plan of a task filter expression such as
+// `priority<=high and label:bug and not column:Done`: a tree of conditions
+// joined by and, or and not. Repositories compile it to their own query
+// language.
+type TaskQuery struct {
+	Op TaskQueryOp
+	// Operands holds the subqueries of And, Or and Not. Not has exactly one.
+	Operands []TaskQuery
+	// Cond is the condition of a TaskQueryCond node.
+	Cond TaskCondition
+}
+
+type TaskQueryOp int
+
+const (
+	TaskQueryCond TaskQueryOp = iota
+	TaskQueryAnd
+	TaskQueryOr
+	TaskQueryNot
+)
+
+// TaskQueryField names the task attribute a condition tests.
+type TaskQueryField string
+
+const (
+	// QueryFieldText matches the title or the description.
+	QueryFieldText     TaskQueryField = "text"
+	QueryFieldTitle    TaskQueryField = "title"
+	QueryFieldPriority TaskQueryField = "priority"
+	QueryFieldLabel    TaskQueryField = "label"
+	// QueryFieldColumn matches the name of the task's column.
+	QueryFieldColumn   TaskQueryField = "column"
+	QueryFieldStatus   TaskQueryField = "status"
+	QueryFieldAssignee TaskQueryField = "assignee"
+	QueryFieldDue      TaskQueryField = "due"
+)
+
+// TaskComparison is the operator of a condition.
+type TaskComparison string
+
+const (
+	CompareEqual        TaskComparison = "="
+	CompareLess         TaskComparison = "<"
+	CompareLessEqual    TaskComparison = "<="
+	CompareGreater      TaskComparison = ">"
+	CompareGreaterEqual TaskComparison = ">="
+	// CompareContains matches text fields containing Text, ignoring case.
+	CompareContains TaskComparison = "~"
+	// CompareUnset matches tasks without a value for the field, such as no
+	// due date or no labels.
+	CompareUnset TaskComparison = "unset"
+)
+
+// TaskCondition compares one field of a task with a value. Text fields use
+// Text and compare ignoring case, priority uses Number and due uses Time. A
+// task without a value for the field fails every comparison but
+// CompareUnset.
+type TaskCondition struct {
+	Field  TaskQueryField
+	Op     TaskComparison
+	Text   string
+	Number int
+	Time   time.Time
+}
@@ -0,0 +1,69 @@
+package domain
+
+import "context"
+
+// BoardTemplate is the shape of a new board: its columns, the labels it
+// registers in the workspace and the tasks it starts with. Templates are
+// kept by name, compared without regard to case.
+type BoardTemplate struct {
+	Name        string           `yaml:"name"`
+	Description string           `yaml:"description,omitempty"`
+	Columns     []TemplateColumn `yaml:"columns"`
+	Labels      []TemplateLabel  `yaml:"labels,omitempty"`
+	Tasks       []TemplateTask   `yaml:"tasks,omitempty"`
+	// Builtin is set on the templates kanji ships with.
+	Builtin bool `yaml:"-"`
+}
+
+// TemplateColumn is a column of a board template. Color is a #RRGGBB hex
+// color or empty for the next default color.
+type TemplateColumn struct {
+	Name     string `yaml:"name"`
+	Color    string `yaml:"color,omitempty"`
+	WIPLimit *int   `yaml:"wip_limit,omitempty"`
+}
+
+// TemplateLabel is a label a board template adds to the workspace when it
+// does not have it yet.
+type TemplateLabel struct {
+	Name        string `yaml:"name"`
+	Color       string `yaml:"color,omitempty"`
+	Description string `yaml:"description,omitempty"`
+}
+
+// TemplateTask is a starter task of a board template. Column names a column
+// of the template; empty puts the task in the first column.
+type TemplateTask struct {
+	Title       string   `yaml:"title"`
+	Description string   `yaml:"description,omitempty"`
+	Column      string   `yaml:"column,omitempty"`
+	Priority    *int     `yaml:"priority,omitempty"`
+	Labels      []string `yaml:"labels,omitempty"`
+}
+
+// TemplateRepository stores the board templates users save.
+type TemplateRepository interface {
+	List(ctx context.Context) ([]BoardTemplate, error)
+	// Get returns the template with the given name, or an error matching
+	// os.ErrNotExist.
+	Get(ctx context.Context, name string) (BoardTemplate, error)
+	// Save creates or replaces the template with the same name.
+	Save(ctx context.Context, template BoardTemplate) error
+	Delete(ctx context.Context, name string) error
+}
+
+// BoardBuild is a new board with everything it starts with: its columns,
+// the labels it adds to the workspace and its tasks.
+type BoardBuild struct {
+	Board   Board
+	Columns []Column
+	// Labels are added unless the workspace has a label of the same name.
+	Labels []Label
+	Tasks  []Task
+}
+
+// BoardBuilder stores a BoardBuild in one transaction, so a failure leaves
+// nothing of the board behind.
+type BoardBuilder interface {
+	BuildBoard(ctx context.Context, build BoardBuild) error
+}
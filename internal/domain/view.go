@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// View layouts.
+const (
+	ViewLayoutList   = "list"
+	ViewLayoutKanban = "kanban"
+)
+
+// Task sort orders of a view.
+const (
+	ViewSortPriority = "priority"
+	ViewSortDue      = "due"
+	ViewSortTitle    = "title"
+	ViewSortUpdated  = "updated"
+	ViewSortCreated  = "created"
+	ViewSortManual   = "manual"
+)
+
+// Due date filters of a view. ViewDueSoon matches tasks due within the next
+// seven days.
+const (
+	ViewDueSoon    = "soon"
+	ViewDueOverdue = "overdue"
+	ViewDueNone    = "none"
+)
+
+// ViewLayouts, ViewSorts and ViewDueFilters list the values a view accepts.
+var (
+	ViewLayouts    = []string{ViewLayoutList, ViewLayoutKanban}
+	ViewSorts      = []string{ViewSortPriority, ViewSortDue, ViewSortTitle, ViewSortUpdated, ViewSortCreated, ViewSortManual}
+	ViewDueFilters = []string{ViewDueSoon, ViewDueOverdue, ViewDueNone}
+)
+
+// View is a named set of filters, sort order and layout. A view with a
+// BoardID belongs to that board; without one it applies to every board of
+// the workspace.
+type View struct {
+	ID          string
+	WorkspaceID string
+	BoardID     *string
+	Name        string
+	Settings    ViewSettings
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// ViewSettings is what a view restores. Empty fields leave that filter off.
+type ViewSettings struct {
+	// Column is a column name rather than an ID, so workspace views apply
+	// to every board that has a column of that name.
+	Column string `json:"column,omitempty"`
+	// Priority is 0 (critical) to 5 (none); nil matches any priority.
+	Priority *int   `json:"priority,omitempty"`
+	Due      string `json:"due,omitempty"`
+	Sort     string `json:"sort,omitempty"`
+	Layout   string `json:"layout,omitempty"`
+	// Search is full-text search input and Filter a filter expression.
+	Search string `json:"search,omitempty"`
+	Filter string `json:"filter,omitempty"`
+}
+
+// Summary lists the settings that are set, such as `column=Doing sort=due`,
+// or "-" when none are.
+func (s ViewSettings) Summary() string {
+	var parts []string
+	add := func(key, value string) {
+		if value != "" {
+			parts = append(parts, key+"="+value)
+		}
+	}
+	add("column", s.Column)
+	if s.Priority != nil {
+		add("priority", strconv.Itoa(*s.Priority))
+	}
+	add("due", s.Due)
+	add("sort", s.Sort)
+	add("layout", s.Layout)
+	if s.Search != "" {
+		add("search", fmt.Sprintf("%q", s.Search))
+	}
+	if s.Filter != "" {
+		add("filter", fmt.Sprintf("%q", s.Filter))
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	return strings.Join(parts, " ")
+}
+
+type ViewRepository interface {
+	Create(ctx context.Context, view View) error
+	// Update replaces the settings of a view.
+	Update(ctx context.Context, view View) error
+	Get(ctx context.Context, id string) (View, error)
+	// List returns the views of a workspace by name. A non-empty boardID
+	// limits them to the workspace-wide views and the views of that board.
+	List(ctx context.Context, workspaceID, boardID string) ([]View, error)
+	Delete(ctx context.Context, id string) (int, error)
+}
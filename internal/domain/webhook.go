@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Webhook events.
+const (
+	WebhookEventTaskCreated    = "task.created"
+	WebhookEventTaskUpdated    = "task.updated"
+	WebhookEventTaskMoved      = "task.moved"
+	WebhookEventTaskDeleted    = "task.deleted"
+	WebhookEventCommentCreated = "comment.created"
+	WebhookEventCommentUpdated = "comment.updated"
+	WebhookEventCommentDeleted = "comment.deleted"
+)
+
+// WebhookEvents lists every event a webhook can subscribe to.
+var WebhookEvents = []string{
+	WebhookEventTaskCreated,
+	WebhookEventTaskUpdated,
+	WebhookEventTaskMoved,
+	WebhookEventTaskDeleted,
+	WebhookEventCommentCreated,
+	WebhookEventCommentUpdated,
+	WebhookEventCommentDeleted,
+}
+
+// Webhook is a URL that receives signed JSON payloads for the events of a
+// workspace it subscribes to. Secret holds the signing secret as stored,
+// which may be encrypted.
+type Webhook struct {
+	ID          string
+	WorkspaceID string
+	URL         string
+	Secret      string
+	Events      []string
+	CreatedAt   time.Time
+}
+
+// Subscribes reports whether the webhook wants the given event.
+func (w Webhook) Subscribes(event string) bool {
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery is one event queued for a webhook. Deliveries are recorded
+// in the same transaction as the change that caused them and kept after they
+// succeed, as a delivery log.
+type WebhookDelivery struct {
+	ID             string
+	WebhookID      string
+	Event          string
+	PayloadJSON    string
+	Attempts       int
+	LastError      *string
+	ResponseStatus *int
+	NextAttemptAt  *time.Time
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+}
+
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook Webhook) error
+	Get(ctx context.Context, id string) (Webhook, error)
+	// List returns the webhooks of a workspace; an empty workspaceID lists
+	// all of them.
+	List(ctx context.Context, workspaceID string) ([]Webhook, error)
+	// SetSecrets replaces the stored secrets of several webhooks at once,
+	// keyed by webhook ID.
+	SetSecrets(ctx context.Context, secrets map[string]string) error
+	// Delete removes a webhook together with its delivery log.
+	Delete(ctx context.Context, id string) (int, error)
+	// ListDeliveries returns the log, newest first; an empty webhookID
+	// lists the deliveries of every webhook.
+	ListDeliveries(ctx context.Context, webhookID string) ([]WebhookDelivery, error)
+	// ListUndelivered returns deliveries not delivered yet, oldest first.
+	ListUndelivered(ctx context.Context) ([]WebhookDelivery, error)
+	MarkDelivered(ctx context.Context, id string, status int, at time.Time) error
+	RecordFailure(ctx context.Context, id string, status *int, lastError string, nextAttemptAt time.Time) error
+	// Retry clears the attempt counter and backoff of an undelivered
+	// delivery.
+	Retry(ctx context.Context, id string) (int, error)
+}
@@ -0,0 +1,55 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// ChangeWatcher detects commits made through other connections, including
+// other processes, by polling PRAGMA data_version on a dedicated connection.
+type ChangeWatcher struct {
+	conn    *sql.Conn
+	version int64
+}
+
+// WatchChanges reserves a connection from the pool for change detection.
+// The watcher must be closed to release it.
+func (a *SQLiteAdapter) WatchChanges(ctx context.Context) (*ChangeWatcher, error) {
+	conn, err := a.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reserve watch connection: %w", err)
+	}
+	w := &ChangeWatcher{conn: conn}
+	if w.version, err = w.dataVersion(ctx); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return w, nil
+}
+
+// Changed reports whether the database changed since the previous call.
+// data_version is per connection, so writes through the rest of the pool
+// count as changes too.
+func (w *ChangeWatcher) Changed(ctx context.Context) (bool, error) {
+	version, err := w.dataVersion(ctx)
+	if err != nil {
+		return false, err
+	}
+	changed := version != w.version
+	w.version = version
+	return changed, nil
+}
+
+// Close releases the watch connection.
+func (w *ChangeWatcher) Close() error {
+	return w.conn.Close()
+}
+
+func (w *ChangeWatcher) dataVersion(ctx context.Context) (int64, error) {
+	var version int64
+	if err := w.conn.QueryRowContext(ctx, "PRAGMA data_version").Scan(&version); err != nil {
+		return 0, fmt.Errorf("read data_version: %w", err)
+	}
+	return version, nil
+}
@@ -0,0 +1,47 @@
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestChangeWatcher_SeesCommitsFromOtherProcesses(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "app.db")
+	tui, err := NewSQLiteAdapter(path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer tui.Close()
+	// A second adapter stands in for a CLI command in another terminal.
+	cli, err := NewSQLiteAdapter(path)
+	if err != nil {
+		t.Fatalf("open second adapter: %v", err)
+	}
+	defer cli.Close()
+
+	var mode string
+	if err := tui.Raw().QueryRow("PRAGMA journal_mode").Scan(&mode); err != nil || mode != "wal" {
+		t.Fatalf("journal_mode = %q (%v), want wal", mode, err)
+	}
+
+	watcher, err := tui.WatchChanges(ctx)
+	if err != nil {
+		t.Fatalf("watch: %v", err)
+	}
+	defer watcher.Close()
+
+	if changed, err := watcher.Changed(ctx); err != nil || changed {
+		t.Fatalf("Changed before any write = %v, %v", changed, err)
+	}
+	if _, err := cli.Raw().Exec("CREATE TABLE t (id INTEGER)"); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if changed, err := watcher.Changed(ctx); err != nil || !changed {
+		t.Fatalf("Changed after a write = %v, %v", changed, err)
+	}
+	if changed, err := watcher.Changed(ctx); err != nil || changed {
+		t.Fatalf("Changed twice for one write = %v, %v", changed, err)
+	}
+}
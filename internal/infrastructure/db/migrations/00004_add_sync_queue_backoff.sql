@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE sync_queue ADD COLUMN next_attempt_at TEXT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_sync_queue_provider_created ON sync_queue(provider_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_sync_queue_provider_created;
+-- Intentionally keeps next_attempt_at. SQLite/libSQL/D1 compatibility makes dropping columns unsafe.
+-- +goose StatementEnd
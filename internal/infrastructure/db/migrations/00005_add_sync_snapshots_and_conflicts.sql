@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS sync_snapshots (
+  entity TEXT NOT NULL,
+  entity_id TEXT NOT NULL,
+  provider_id TEXT NOT NULL,
+  payload_json TEXT NOT NULL,
+  synced_at TEXT NOT NULL,
+  PRIMARY KEY (entity, entity_id),
+  FOREIGN KEY (provider_id) REFERENCES providers(id)
+);
+
+CREATE TABLE IF NOT EXISTS sync_conflicts (
+  id TEXT PRIMARY KEY,
+  provider_id TEXT NOT NULL,
+  entity TEXT NOT NULL,
+  entity_id TEXT NOT NULL,
+  fields_json TEXT NOT NULL,
+  base_json TEXT NOT NULL,
+  remote_json TEXT NOT NULL,
+  created_at TEXT NOT NULL,
+  FOREIGN KEY (provider_id) REFERENCES providers(id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sync_conflicts_entity ON sync_conflicts(entity, entity_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_sync_conflicts_entity;
+DROP TABLE IF EXISTS sync_conflicts;
+DROP TABLE IF EXISTS sync_snapshots;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+-- task_events has no foreign key to tasks so history outlives deleted tasks.
+CREATE TABLE IF NOT EXISTS task_events (
+  id TEXT PRIMARY KEY,
+  task_id TEXT NOT NULL,
+  workspace_id TEXT NOT NULL,
+  board_id TEXT NULL,
+  kind TEXT NOT NULL,
+  title TEXT NOT NULL,
+  changes_json TEXT NOT NULL,
+  actor TEXT NULL,
+  created_at TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_events_task_created ON task_events(task_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_task_events_workspace_created ON task_events(workspace_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_task_events_workspace_created;
+DROP INDEX IF EXISTS idx_task_events_task_created;
+DROP TABLE IF EXISTS task_events;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+-- operations journals task and comment changes per namespace for undo and
+-- redo. Snapshots hold the entity before and after the change; undone_at is
+-- set while an operation is undone and can be redone.
+CREATE TABLE IF NOT EXISTS operations (
+  id TEXT PRIMARY KEY,
+  namespace TEXT NOT NULL,
+  kind TEXT NOT NULL,
+  entity_id TEXT NOT NULL,
+  title TEXT NOT NULL,
+  before_json TEXT NULL,
+  after_json TEXT NULL,
+  undone_at TEXT NULL,
+  created_at TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_operations_namespace ON operations(namespace);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_operations_namespace;
+DROP TABLE IF EXISTS operations;
+-- +goose StatementEnd
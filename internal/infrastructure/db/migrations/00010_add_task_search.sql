@@ -0,0 +1,74 @@
+-- +goose Up
+-- +goose StatementBegin
+-- task_search is a full-text index with one row per task. Triggers keep it
+-- in step with the task fields and the concatenated bodies of its comments.
+-- Rows are keyed by task_id rather than rowid because VACUUM may renumber
+-- the rowids of tasks.
+CREATE VIRTUAL TABLE IF NOT EXISTS task_search USING fts5(
+  task_id UNINDEXED,
+  title,
+  description,
+  labels,
+  comments,
+  tokenize = 'unicode61 remove_diacritics 2'
+);
+
+INSERT INTO task_search (task_id, title, description, labels, comments)
+SELECT
+  t.id,
+  t.title,
+  t.description_md,
+  t.labels_json,
+  COALESCE((SELECT group_concat(c.body_md, char(10)) FROM comments c WHERE c.task_id = t.id), '')
+FROM tasks t;
+
+CREATE TRIGGER IF NOT EXISTS task_search_task_insert AFTER INSERT ON tasks BEGIN
+  INSERT INTO task_search (task_id, title, description, labels, comments)
+  VALUES (
+    new.id,
+    new.title,
+    new.description_md,
+    new.labels_json,
+    COALESCE((SELECT group_concat(body_md, char(10)) FROM comments WHERE task_id = new.id), '')
+  );
+END;
+
+CREATE TRIGGER IF NOT EXISTS task_search_task_update AFTER UPDATE OF title, description_md, labels_json ON tasks BEGIN
+  UPDATE task_search
+  SET title = new.title, description = new.description_md, labels = new.labels_json
+  WHERE task_id = new.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS task_search_task_delete AFTER DELETE ON tasks BEGIN
+  DELETE FROM task_search WHERE task_id = old.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS task_search_comment_insert AFTER INSERT ON comments BEGIN
+  UPDATE task_search
+  SET comments = COALESCE((SELECT group_concat(body_md, char(10)) FROM comments WHERE task_id = new.task_id), '')
+  WHERE task_id = new.task_id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS task_search_comment_update AFTER UPDATE OF body_md ON comments BEGIN
+  UPDATE task_search
+  SET comments = COALESCE((SELECT group_concat(body_md, char(10)) FROM comments WHERE task_id = new.task_id), '')
+  WHERE task_id = new.task_id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS task_search_comment_delete AFTER DELETE ON comments BEGIN
+  UPDATE task_search
+  SET comments = COALESCE((SELECT group_concat(body_md, char(10)) FROM comments WHERE task_id = old.task_id), '')
+  WHERE task_id = old.task_id;
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS task_search_comment_delete;
+DROP TRIGGER IF EXISTS task_search_comment_update;
+DROP TRIGGER IF EXISTS task_search_comment_insert;
+DROP TRIGGER IF EXISTS task_search_task_delete;
+DROP TRIGGER IF EXISTS task_search_task_update;
+DROP TRIGGER IF EXISTS task_search_task_insert;
+DROP TABLE IF EXISTS task_search;
+-- +goose StatementEnd
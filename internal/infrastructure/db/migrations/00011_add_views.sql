@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+-- views are named filter, sort and layout settings. A view with a board_id
+-- belongs to that board; without one it applies to every board of the
+-- workspace.
+CREATE TABLE IF NOT EXISTS views (
+  id TEXT PRIMARY KEY,
+  workspace_id TEXT NOT NULL,
+  board_id TEXT NULL,
+  name TEXT NOT NULL,
+  settings_json TEXT NOT NULL,
+  created_at TEXT NOT NULL,
+  updated_at TEXT NOT NULL,
+  FOREIGN KEY (workspace_id) REFERENCES workspaces(id),
+  FOREIGN KEY (board_id) REFERENCES boards(id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_views_name_unique ON views(workspace_id, COALESCE(board_id, ''), LOWER(TRIM(name)));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_views_name_unique;
+DROP TABLE IF EXISTS views;
+-- +goose StatementEnd
@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+-- labels is the registry of the labels of a workspace. Tasks keep their
+-- labels by name in labels_json; a label here adds a color and a
+-- description.
+CREATE TABLE IF NOT EXISTS labels (
+  id TEXT PRIMARY KEY,
+  workspace_id TEXT NOT NULL,
+  name TEXT NOT NULL,
+  color TEXT NOT NULL DEFAULT '',
+  description TEXT NOT NULL DEFAULT '',
+  created_at TEXT NOT NULL,
+  updated_at TEXT NOT NULL,
+  FOREIGN KEY (workspace_id) REFERENCES workspaces(id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_labels_name_unique ON labels(workspace_id, LOWER(TRIM(name)));
+
+-- Register the labels tasks already use. Names differing only in case
+-- become one label.
+INSERT OR IGNORE INTO labels (id, workspace_id, name, created_at, updated_at)
+SELECT
+  lower(hex(randomblob(16))),
+  t.workspace_id,
+  TRIM(j.value),
+  MIN(t.created_at),
+  MIN(t.created_at)
+FROM tasks t, json_each(t.labels_json) j
+WHERE j.type = 'text' AND TRIM(j.value) <> ''
+GROUP BY t.workspace_id, LOWER(TRIM(j.value));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_labels_name_unique;
+DROP TABLE IF EXISTS labels;
+-- +goose StatementEnd
@@ -53,6 +53,7 @@ type Task struct {
 	Position        float64
 	CreatedAt       string
 	UpdatedAt       string
+	Version         int64
 }
 
 type Comment struct {
@@ -63,4 +64,103 @@ type Comment struct {
 	BodyMd     string
 	Author     sql.NullString
 	CreatedAt  string
+	Version    int64
+}
+
+type SyncConflict struct {
+	ID         string
+	ProviderID string
+	Entity     string
+	EntityID   string
+	FieldsJSON string
+	BaseJSON   string
+	RemoteJSON string
+	CreatedAt  string
+}
+
+type SyncQueue struct {
+	ID            string
+	ProviderID    string
+	Entity        string
+	EntityID      string
+	Action        string
+	PayloadJSON   string
+	Attempts      int64
+	LastError     sql.NullString
+	NextAttemptAt sql.NullString
+	CreatedAt     string
+}
+
+type SyncSnapshot struct {
+	Entity      string
+	EntityID    string
+	ProviderID  string
+	PayloadJSON string
+	SyncedAt    string
+}
+
+type Webhook struct {
+	ID          string
+	WorkspaceID string
+	URL         string
+	Secret      string
+	EventsJSON  string
+	CreatedAt   string
+}
+
+type TaskEvent struct {
+	ID          string
+	TaskID      string
+	WorkspaceID string
+	BoardID     sql.NullString
+	Kind        string
+	Title       string
+	ChangesJSON string
+	Actor       sql.NullString
+	CreatedAt   string
+}
+
+type Operation struct {
+	ID         string
+	Namespace  string
+	Kind       string
+	EntityID   string
+	Title      string
+	BeforeJSON sql.NullString
+	AfterJSON  sql.NullString
+	UndoneAt   sql.NullString
+	CreatedAt  string
+}
+
+type WebhookDelivery struct {
+	ID             string
+	WebhookID      string
+	Event          string
+	PayloadJSON    string
+	Attempts       int64
+	LastError      sql.NullString
+	ResponseStatus sql.NullInt64
+	NextAttemptAt  sql.NullString
+	DeliveredAt    sql.NullString
+	CreatedAt      string
+}
+
+type View struct {
+	ID           string
+	WorkspaceID  string
+	BoardID      sql.NullString
+	Name         string
+	SettingsJSON string
+	CreatedAt    string
+	UpdatedAt    string
+}
+
+type Label struct {
+	ID          string
+	WorkspaceID string
+	Name        string
+	Color       string
+	Description string
+	CreatedAt   string
+	UpdatedAt   string
 }
@@ -7,6 +7,12 @@ SELECT id, type, name, auth_json, created_at
 FROM providers
 ORDER BY created_at ASC;
 
+-- name: GetProviderType :one
+SELECT type FROM providers WHERE id = ?;
+
+-- name: UpdateProviderAuth :exec
+UPDATE providers SET auth_json = ? WHERE id = ?;
+
 -- name: CreateWorkspace :exec
 INSERT INTO workspaces (id, provider_id, remote_id, name)
 VALUES (?, ?, ?, ?);
@@ -76,7 +82,8 @@ SET
   due_at = COALESCE(?, due_at),
   column_id = COALESCE(?, column_id),
   labels_json = COALESCE(?, labels_json),
-  updated_at = ?
+  updated_at = ?,
+  version = version + 1
 WHERE id = ?;
 
 -- name: GetTask :one
@@ -97,10 +104,14 @@ SELECT
   labels_json,
   position,
   created_at,
-  updated_at
+  updated_at,
+  version
 FROM tasks
 WHERE id = ?;
 
+-- name: GetTaskVersion :one
+SELECT version FROM tasks WHERE id = ?;
+
 -- name: ListTasks :many
 SELECT
   id,
@@ -119,7 +130,8 @@ SELECT
   labels_json,
   position,
   created_at,
-  updated_at
+  updated_at,
+  version
 FROM tasks
 WHERE workspace_id = ?
   AND (? = '' OR board_id = ?)
@@ -129,11 +141,72 @@ WHERE workspace_id = ?
   AND (? = 0 OR (due_at IS NOT NULL AND due_at <= ?))
 ORDER BY updated_at DESC;
 
+-- name: SearchTasks :many
+SELECT
+  t.id,
+  t.provider_id,
+  t.workspace_id,
+  t.board_id,
+  t.column_id,
+  t.remote_id,
+  t.title,
+  t.description_md,
+  t.status,
+  t.priority,
+  t.due_at,
+  t.estimate_minutes,
+  t.assignee,
+  t.labels_json,
+  t.position,
+  t.created_at,
+  t.updated_at,
+  t.version,
+  snippet(task_search, -1, ?, ?, '...', 12) AS snippet,
+  bm25(task_search, 0.0, 10.0, 4.0, 6.0, 2.0) AS rank
+FROM task_search
+JOIN tasks t ON t.id = task_search.task_id
+WHERE task_search MATCH ?
+  AND t.workspace_id = ?
+  AND (? = '' OR t.board_id = ?)
+ORDER BY rank, t.updated_at DESC
+LIMIT ?;
+
 -- name: MoveTask :exec
 UPDATE tasks
-SET column_id = ?, status = ?, position = ?, updated_at = ?
+SET column_id = ?, status = ?, position = ?, updated_at = ?, version = version + 1
+WHERE id = ?;
+
+-- name: UpdateTaskPosition :exec
+UPDATE tasks
+SET position = ?
+WHERE id = ?;
+
+-- name: RestoreTask :exec
+UPDATE tasks
+SET
+  board_id = ?,
+  column_id = ?,
+  title = ?,
+  description_md = ?,
+  status = ?,
+  priority = ?,
+  due_at = ?,
+  estimate_minutes = ?,
+  assignee = ?,
+  labels_json = ?,
+  position = ?,
+  updated_at = ?,
+  version = version + 1
+WHERE id = ?;
+
+-- name: TransferTask :exec
+UPDATE tasks
+SET provider_id = ?, workspace_id = ?, board_id = ?, remote_id = ?
 WHERE id = ?;
 
+-- name: TransferTaskComments :exec
+UPDATE comments SET provider_id = ?, remote_id = NULL WHERE task_id = ?;
+
 -- name: DeleteTask :exec
 DELETE FROM tasks WHERE id = ?;
 
@@ -142,13 +215,13 @@ INSERT INTO comments (id, task_id, provider_id, remote_id, body_md, author, crea
 VALUES (?, ?, ?, ?, ?, ?, ?);
 
 -- name: ListComments :many
-SELECT id, task_id, provider_id, remote_id, body_md, author, created_at
+SELECT id, task_id, provider_id, remote_id, body_md, author, created_at, version
 FROM comments
 WHERE task_id = ?
 ORDER BY created_at ASC;
 
 -- name: UpdateComment :exec
-UPDATE comments SET body_md = ? WHERE id = ?;
+UPDATE comments SET body_md = ?, version = version + 1 WHERE id = ?;
 
 -- name: DeleteComment :exec
 DELETE FROM comments WHERE id = ?;
@@ -167,3 +240,356 @@ DELETE FROM tasks WHERE board_id = ?;
 
 -- name: DeleteColumnsByBoard :exec
 DELETE FROM columns WHERE board_id = ?;
+
+-- name: GetWorkspace :one
+SELECT id, provider_id, remote_id, name
+FROM workspaces
+WHERE id = ?;
+
+-- name: GetBoard :one
+SELECT id, workspace_id, remote_id, name, view_default
+FROM boards
+WHERE id = ?;
+
+-- name: GetColumn :one
+SELECT id, board_id, remote_id, name, color, position, wip_limit
+FROM columns
+WHERE id = ?;
+
+-- name: GetComment :one
+SELECT id, task_id, provider_id, remote_id, body_md, author, created_at, version
+FROM comments
+WHERE id = ?;
+
+-- name: GetCommentVersion :one
+SELECT version FROM comments WHERE id = ?;
+
+-- name: CreateSyncItem :exec
+INSERT INTO sync_queue (id, provider_id, entity, entity_id, action, payload_json, attempts, created_at)
+VALUES (?, ?, ?, ?, ?, ?, 0, ?);
+
+-- name: ListSyncItems :many
+SELECT id, provider_id, entity, entity_id, action, payload_json, attempts, last_error, next_attempt_at, created_at
+FROM sync_queue
+WHERE (? = '' OR provider_id = ?)
+ORDER BY created_at ASC, rowid ASC;
+
+-- name: RecordSyncFailure :exec
+UPDATE sync_queue
+SET attempts = attempts + 1, last_error = ?, next_attempt_at = ?
+WHERE id = ?;
+
+-- name: DeleteSyncItem :execrows
+DELETE FROM sync_queue WHERE id = ?;
+
+-- name: ResetSyncItem :execrows
+UPDATE sync_queue
+SET attempts = 0, last_error = NULL, next_attempt_at = NULL
+WHERE id = ?;
+
+-- name: ResetExhaustedSyncItems :execrows
+UPDATE sync_queue
+SET attempts = 0, last_error = NULL, next_attempt_at = NULL
+WHERE attempts >= ?;
+
+-- name: DeleteExhaustedSyncItems :execrows
+DELETE FROM sync_queue WHERE attempts >= ?;
+
+-- name: DeleteAllSyncItems :execrows
+DELETE FROM sync_queue;
+
+-- name: SetWorkspaceRemoteID :exec
+UPDATE workspaces SET remote_id = ? WHERE id = ?;
+
+-- name: SetBoardRemoteID :exec
+UPDATE boards SET remote_id = ? WHERE id = ?;
+
+-- name: SetColumnRemoteID :exec
+UPDATE columns SET remote_id = ? WHERE id = ?;
+
+-- name: SetTaskRemoteID :exec
+UPDATE tasks SET remote_id = ? WHERE id = ?;
+
+-- name: SetCommentRemoteID :exec
+UPDATE comments SET remote_id = ? WHERE id = ?;
+
+-- name: DeleteProvider :exec
+DELETE FROM providers WHERE id = ?;
+
+-- name: DeleteSyncItemsByProvider :exec
+DELETE FROM sync_queue WHERE provider_id = ?;
+
+-- name: UpsertTask :exec
+INSERT INTO tasks (
+  id,
+  provider_id,
+  workspace_id,
+  board_id,
+  column_id,
+  remote_id,
+  title,
+  description_md,
+  status,
+  priority,
+  due_at,
+  estimate_minutes,
+  assignee,
+  labels_json,
+  position,
+  created_at,
+  updated_at
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(id) DO UPDATE SET
+  column_id = excluded.column_id,
+  remote_id = excluded.remote_id,
+  title = excluded.title,
+  description_md = excluded.description_md,
+  status = excluded.status,
+  priority = excluded.priority,
+  due_at = excluded.due_at,
+  labels_json = excluded.labels_json,
+  position = excluded.position,
+  updated_at = excluded.updated_at,
+  version = tasks.version + 1;
+
+-- name: UpsertComment :exec
+INSERT INTO comments (id, task_id, provider_id, remote_id, body_md, author, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(id) DO UPDATE SET
+  remote_id = excluded.remote_id,
+  body_md = excluded.body_md,
+  author = excluded.author,
+  version = comments.version + 1;
+
+-- name: GetSyncSnapshot :one
+SELECT entity, entity_id, provider_id, payload_json, synced_at
+FROM sync_snapshots
+WHERE entity = ? AND entity_id = ?;
+
+-- name: UpsertSyncSnapshot :exec
+INSERT INTO sync_snapshots (entity, entity_id, provider_id, payload_json, synced_at)
+VALUES (?, ?, ?, ?, ?)
+ON CONFLICT(entity, entity_id) DO UPDATE SET
+  provider_id = excluded.provider_id,
+  payload_json = excluded.payload_json,
+  synced_at = excluded.synced_at;
+
+-- name: DeleteSyncSnapshot :exec
+DELETE FROM sync_snapshots WHERE entity = ? AND entity_id = ?;
+
+-- name: DeleteSyncSnapshotsByProvider :exec
+DELETE FROM sync_snapshots WHERE provider_id = ?;
+
+-- name: ListSyncConflicts :many
+SELECT id, provider_id, entity, entity_id, fields_json, base_json, remote_json, created_at
+FROM sync_conflicts
+WHERE (? = '' OR provider_id = ?)
+ORDER BY created_at ASC, rowid ASC;
+
+-- name: GetSyncConflict :one
+SELECT id, provider_id, entity, entity_id, fields_json, base_json, remote_json, created_at
+FROM sync_conflicts
+WHERE id = ?;
+
+-- name: UpsertSyncConflict :exec
+INSERT INTO sync_conflicts (id, provider_id, entity, entity_id, fields_json, base_json, remote_json, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(entity, entity_id) DO UPDATE SET
+  fields_json = excluded.fields_json,
+  base_json = excluded.base_json,
+  remote_json = excluded.remote_json;
+
+-- name: DeleteSyncConflict :exec
+DELETE FROM sync_conflicts WHERE entity = ? AND entity_id = ?;
+
+-- name: DeleteSyncConflictsByProvider :exec
+DELETE FROM sync_conflicts WHERE provider_id = ?;
+
+-- name: DeleteSyncItemsByEntity :exec
+DELETE FROM sync_queue WHERE entity = ? AND entity_id = ?;
+
+-- name: CreateWebhook :exec
+INSERT INTO webhooks (id, workspace_id, url, secret, events_json, created_at)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: GetWebhook :one
+SELECT id, workspace_id, url, secret, events_json, created_at
+FROM webhooks
+WHERE id = ?;
+
+-- name: ListWebhooks :many
+SELECT id, workspace_id, url, secret, events_json, created_at
+FROM webhooks
+WHERE (? = '' OR workspace_id = ?)
+ORDER BY created_at ASC, rowid ASC;
+
+-- name: UpdateWebhookSecret :exec
+UPDATE webhooks SET secret = ? WHERE id = ?;
+
+-- name: DeleteWebhook :execrows
+DELETE FROM webhooks WHERE id = ?;
+
+-- name: DeleteWebhooksByWorkspace :exec
+DELETE FROM webhooks WHERE workspace_id = ?;
+
+-- name: CreateWebhookDelivery :exec
+INSERT INTO webhook_deliveries (id, webhook_id, event, payload_json, attempts, created_at)
+VALUES (?, ?, ?, ?, 0, ?);
+
+-- name: ListWebhookDeliveries :many
+SELECT id, webhook_id, event, payload_json, attempts, last_error, response_status, next_attempt_at, delivered_at, created_at
+FROM webhook_deliveries
+WHERE (? = '' OR webhook_id = ?)
+ORDER BY created_at DESC, rowid DESC;
+
+-- name: ListUndeliveredWebhookDeliveries :many
+SELECT id, webhook_id, event, payload_json, attempts, last_error, response_status, next_attempt_at, delivered_at, created_at
+FROM webhook_deliveries
+WHERE delivered_at IS NULL
+ORDER BY created_at ASC, rowid ASC;
+
+-- name: MarkWebhookDelivered :exec
+UPDATE webhook_deliveries
+SET attempts = attempts + 1, response_status = ?, last_error = NULL, next_attempt_at = NULL, delivered_at = ?
+WHERE id = ?;
+
+-- name: RecordWebhookFailure :exec
+UPDATE webhook_deliveries
+SET attempts = attempts + 1, response_status = ?, last_error = ?, next_attempt_at = ?
+WHERE id = ?;
+
+-- name: ResetWebhookDelivery :execrows
+UPDATE webhook_deliveries
+SET attempts = 0, next_attempt_at = NULL
+WHERE id = ? AND delivered_at IS NULL;
+
+-- name: DeleteWebhookDeliveriesByWebhook :exec
+DELETE FROM webhook_deliveries WHERE webhook_id = ?;
+
+-- name: DeleteWebhookDeliveriesByWorkspace :exec
+DELETE FROM webhook_deliveries
+WHERE webhook_id IN (SELECT id FROM webhooks WHERE workspace_id = ?);
+
+-- name: CreateTaskEvent :exec
+INSERT INTO task_events (id, task_id, workspace_id, board_id, kind, title, changes_json, actor, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: ListTaskEvents :many
+SELECT id, task_id, workspace_id, board_id, kind, title, changes_json, actor, created_at
+FROM task_events
+WHERE task_id = ?
+ORDER BY created_at ASC, rowid ASC;
+
+-- name: ListWorkspaceTaskEvents :many
+SELECT id, task_id, workspace_id, board_id, kind, title, changes_json, actor, created_at
+FROM task_events
+WHERE workspace_id = ?
+  AND (? = '' OR board_id = ?)
+ORDER BY created_at DESC, rowid DESC
+LIMIT ?;
+
+-- name: DeleteTaskEventsByWorkspace :exec
+DELETE FROM task_events WHERE workspace_id = ?;
+
+-- name: CreateOperation :exec
+INSERT INTO operations (id, namespace, kind, entity_id, title, before_json, after_json, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: GetLastOperation :one
+SELECT id, namespace, kind, entity_id, title, before_json, after_json, undone_at, created_at
+FROM operations
+WHERE namespace = ? AND undone_at IS NULL
+ORDER BY rowid DESC
+LIMIT 1;
+
+-- name: GetFirstUndoneOperation :one
+SELECT id, namespace, kind, entity_id, title, before_json, after_json, undone_at, created_at
+FROM operations
+WHERE namespace = ? AND undone_at IS NOT NULL
+ORDER BY rowid ASC
+LIMIT 1;
+
+-- name: SetOperationUndoneAt :exec
+UPDATE operations SET undone_at = ? WHERE id = ?;
+
+-- name: DeleteOperation :exec
+DELETE FROM operations WHERE id = ?;
+
+-- name: DeleteUndoneOperations :exec
+DELETE FROM operations WHERE namespace = ? AND undone_at IS NOT NULL;
+
+-- name: PruneOperations :exec
+DELETE FROM operations
+WHERE namespace = ?
+  AND rowid <= (
+    SELECT rowid FROM operations WHERE namespace = ? ORDER BY rowid DESC LIMIT 1 OFFSET ?
+  );
+
+-- name: CreateView :exec
+INSERT INTO views (id, workspace_id, board_id, name, settings_json, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?);
+
+-- name: UpdateView :execrows
+UPDATE views SET settings_json = ?, updated_at = ? WHERE id = ?;
+
+-- name: GetView :one
+SELECT id, workspace_id, board_id, name, settings_json, created_at, updated_at
+FROM views
+WHERE id = ?;
+
+-- name: ListViews :many
+SELECT id, workspace_id, board_id, name, settings_json, created_at, updated_at
+FROM views
+WHERE workspace_id = ? AND (? = '' OR board_id IS NULL OR board_id = ?)
+ORDER BY LOWER(name) ASC, board_id IS NULL ASC;
+
+-- name: DeleteView :execrows
+DELETE FROM views WHERE id = ?;
+
+-- name: DeleteViewsByBoard :exec
+DELETE FROM views WHERE board_id = ?;
+
+-- name: DeleteViewsByWorkspace :exec
+DELETE FROM views WHERE workspace_id = ?;
+
+-- name: CreateLabel :exec
+INSERT INTO labels (id, workspace_id, name, color, description, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?);
+
+-- name: EnsureLabel :exec
+INSERT INTO labels (id, workspace_id, name, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?)
+ON CONFLICT DO NOTHING;
+
+-- name: UpdateLabel :execrows
+UPDATE labels SET name = ?, color = ?, description = ?, updated_at = ? WHERE id = ?;
+
+-- name: GetLabelByName :one
+SELECT id, workspace_id, name, color, description, created_at, updated_at
+FROM labels
+WHERE workspace_id = ? AND LOWER(TRIM(name)) = LOWER(TRIM(?));
+
+-- name: ListLabels :many
+SELECT
+  l.id,
+  l.workspace_id,
+  l.name,
+  l.color,
+  l.description,
+  l.created_at,
+  l.updated_at,
+  (
+    SELECT COUNT(*)
+    FROM tasks t
+    WHERE t.workspace_id = l.workspace_id
+      AND EXISTS (SELECT 1 FROM json_each(t.labels_json) j WHERE LOWER(TRIM(j.value)) = LOWER(TRIM(l.name)))
+  ) AS task_count
+FROM labels l
+WHERE l.workspace_id = ?
+ORDER BY LOWER(l.name) ASC;
+
+-- name: DeleteLabel :execrows
+DELETE FROM labels WHERE id = ?;
+
+-- name: DeleteLabelsByWorkspace :exec
+DELETE FROM labels WHERE workspace_id = ?;
@@ -0,0 +1,103 @@
+package sqlc
+
+import (
+	"context"
+	"strings"
+)
+
+// whereFilter marks where ListTasksWhere puts its extra condition.
+const whereFilter = "/* filter */"
+
+// listTasksWhere is listTasks with a slot for an extra condition. Keep the
+// two in step.
+const listTasksWhere = `-- name: ListTasksWhere :many
+SELECT
+  id,
+  provider_id,
+  workspace_id,
+  board_id,
+  column_id,
+  remote_id,
+  title,
+  description_md,
+  status,
+  priority,
+  due_at,
+  estimate_minutes,
+  assignee,
+  labels_json,
+  position,
+  created_at,
+  updated_at,
+  version
+FROM tasks
+WHERE workspace_id = ?
+  AND (? = '' OR board_id = ?)
+  AND (? = '' OR LOWER(title) LIKE '%' || LOWER(?) || '%')
+  AND (? = '' OR column_id = ?)
+  AND (? = '' OR status = ?)
+  AND (? = 0 OR (due_at IS NOT NULL AND due_at <= ?))
+  AND (` + whereFilter + `)
+ORDER BY updated_at DESC
+`
+
+// ListTasksWhere is ListTasks narrowed by an extra condition on the tasks
+// table, such as a compiled filter expression. sqlc cannot generate a query
+// with a WHERE clause built at run time, so this one is written by hand; the
+// condition's placeholders take whereArgs.
+func (q *Queries) ListTasksWhere(ctx context.Context, arg ListTasksParams, where string, whereArgs ...any) ([]Task, error) {
+	query := strings.Replace(listTasksWhere, whereFilter, where, 1)
+	args := append([]any{
+		arg.WorkspaceID,
+		arg.BoardID,
+		arg.BoardID,
+		arg.TitleQuery,
+		arg.TitleQuery,
+		arg.ColumnID,
+		arg.ColumnID,
+		arg.Status,
+		arg.Status,
+		arg.DueSoonActive,
+		arg.DueSoonBefore,
+	}, whereArgs...)
+	rows, err := q.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]Task, 0)
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProviderID,
+			&i.WorkspaceID,
+			&i.BoardID,
+			&i.ColumnID,
+			&i.RemoteID,
+			&i.Title,
+			&i.DescriptionMd,
+			&i.Status,
+			&i.Priority,
+			&i.DueAt,
+			&i.EstimateMinutes,
+			&i.Assignee,
+			&i.LabelsJSON,
+			&i.Position,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
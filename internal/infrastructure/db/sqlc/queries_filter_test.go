@@ -0,0 +1,16 @@
+package sqlc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestListTasksWhere_HasFilterSlot(t *testing.T) {
+	if n := strings.Count(listTasksWhere, whereFilter); n != 1 {
+		t.Fatalf("listTasksWhere has %d filter slots, want 1", n)
+	}
+	// The hand-written query must take the same arguments as ListTasks.
+	if got, want := strings.Count(listTasksWhere, "?"), strings.Count(listTasks, "?"); got != want {
+		t.Fatalf("listTasksWhere has %d placeholders, ListTasks has %d", got, want)
+	}
+}
@@ -29,6 +29,17 @@ func (q *Queries) CreateProvider(ctx context.Context, arg CreateProviderParams)
 	return err
 }
 
+const getProviderType = `-- name: GetProviderType :one
+SELECT type FROM providers WHERE id = ?
+`
+
+func (q *Queries) GetProviderType(ctx context.Context, id string) (string, error) {
+	row := q.db.QueryRowContext(ctx, getProviderType, id)
+	var type_ string
+	err := row.Scan(&type_)
+	return type_, err
+}
+
 const listProviders = `-- name: ListProviders :many
 SELECT id, type, name, auth_json, created_at
 FROM providers
@@ -281,7 +292,8 @@ SET
   due_at = COALESCE(?, due_at),
   column_id = COALESCE(?, column_id),
   labels_json = COALESCE(?, labels_json),
-  updated_at = ?
+  updated_at = ?,
+  version = version + 1
 WHERE id = ?
 `
 
@@ -339,7 +351,8 @@ SELECT
   labels_json,
   position,
   created_at,
-  updated_at
+  updated_at,
+  version
 FROM tasks
 WHERE id = ?
 `
@@ -365,10 +378,22 @@ func (q *Queries) GetTask(ctx context.Context, id string) (Task, error) {
 		&i.Position,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
 	)
 	return i, err
 }
 
+const getTaskVersion = `-- name: GetTaskVersion :one
+SELECT version FROM tasks WHERE id = ?
+`
+
+func (q *Queries) GetTaskVersion(ctx context.Context, id string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getTaskVersion, id)
+	var version int64
+	err := row.Scan(&version)
+	return version, err
+}
+
 const listTasks = `-- name: ListTasks :many
 SELECT
   id,
@@ -387,7 +412,8 @@ SELECT
   labels_json,
   position,
   created_at,
-  updated_at
+  updated_at,
+  version
 FROM tasks
 WHERE workspace_id = ?
   AND (? = '' OR board_id = ?)
@@ -448,6 +474,123 @@ func (q *Queries) ListTasks(ctx context.Context, arg ListTasksParams) ([]Task, e
 			&i.Position,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchTasks = `-- name: SearchTasks :many
+SELECT
+  t.id,
+  t.provider_id,
+  t.workspace_id,
+  t.board_id,
+  t.column_id,
+  t.remote_id,
+  t.title,
+  t.description_md,
+  t.status,
+  t.priority,
+  t.due_at,
+  t.estimate_minutes,
+  t.assignee,
+  t.labels_json,
+  t.position,
+  t.created_at,
+  t.updated_at,
+  t.version,
+  snippet(task_search, -1, ?, ?, '...', 12) AS snippet,
+  bm25(task_search, 0.0, 10.0, 4.0, 6.0, 2.0) AS rank
+FROM task_search
+JOIN tasks t ON t.id = task_search.task_id
+WHERE task_search MATCH ?
+  AND t.workspace_id = ?
+  AND (? = '' OR t.board_id = ?)
+ORDER BY rank, t.updated_at DESC
+LIMIT ?
+`
+
+type SearchTasksParams struct {
+	MatchStart  string
+	MatchEnd    string
+	Query       string
+	WorkspaceID string
+	BoardID     string
+	Limit       int64
+}
+
+type SearchTasksRow struct {
+	ID              string
+	ProviderID      string
+	WorkspaceID     string
+	BoardID         sql.NullString
+	ColumnID        sql.NullString
+	RemoteID        sql.NullString
+	Title           string
+	DescriptionMd   string
+	Status          sql.NullString
+	Priority        int64
+	DueAt           sql.NullString
+	EstimateMinutes sql.NullInt64
+	Assignee        sql.NullString
+	LabelsJSON      string
+	Position        float64
+	CreatedAt       string
+	UpdatedAt       string
+	Version         int64
+	Snippet         string
+	Rank            float64
+}
+
+func (q *Queries) SearchTasks(ctx context.Context, arg SearchTasksParams) ([]SearchTasksRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchTasks,
+		arg.MatchStart,
+		arg.MatchEnd,
+		arg.Query,
+		arg.WorkspaceID,
+		arg.BoardID,
+		arg.BoardID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]SearchTasksRow, 0)
+	for rows.Next() {
+		var i SearchTasksRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProviderID,
+			&i.WorkspaceID,
+			&i.BoardID,
+			&i.ColumnID,
+			&i.RemoteID,
+			&i.Title,
+			&i.DescriptionMd,
+			&i.Status,
+			&i.Priority,
+			&i.DueAt,
+			&i.EstimateMinutes,
+			&i.Assignee,
+			&i.LabelsJSON,
+			&i.Position,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Version,
+			&i.Snippet,
+			&i.Rank,
 		); err != nil {
 			return nil, err
 		}
@@ -464,7 +607,7 @@ func (q *Queries) ListTasks(ctx context.Context, arg ListTasksParams) ([]Task, e
 
 const moveTask = `-- name: MoveTask :exec
 UPDATE tasks
-SET column_id = ?, status = ?, position = ?, updated_at = ?
+SET column_id = ?, status = ?, position = ?, updated_at = ?, version = version + 1
 WHERE id = ?
 `
 
@@ -481,6 +624,115 @@ func (q *Queries) MoveTask(ctx context.Context, arg MoveTaskParams) error {
 	return err
 }
 
+const updateTaskPosition = `-- name: UpdateTaskPosition :exec
+UPDATE tasks
+SET position = ?
+WHERE id = ?
+`
+
+type UpdateTaskPositionParams struct {
+	Position float64
+	ID       string
+}
+
+func (q *Queries) UpdateTaskPosition(ctx context.Context, arg UpdateTaskPositionParams) error {
+	_, err := q.db.ExecContext(ctx, updateTaskPosition, arg.Position, arg.ID)
+	return err
+}
+
+const restoreTask = `-- name: RestoreTask :exec
+UPDATE tasks
+SET
+  board_id = ?,
+  column_id = ?,
+  title = ?,
+  description_md = ?,
+  status = ?,
+  priority = ?,
+  due_at = ?,
+  estimate_minutes = ?,
+  assignee = ?,
+  labels_json = ?,
+  position = ?,
+  updated_at = ?,
+  version = version + 1
+WHERE id = ?
+`
+
+type RestoreTaskParams struct {
+	BoardID         sql.NullString
+	ColumnID        sql.NullString
+	Title           string
+	DescriptionMd   string
+	Status          sql.NullString
+	Priority        int64
+	DueAt           sql.NullString
+	EstimateMinutes sql.NullInt64
+	Assignee        sql.NullString
+	LabelsJSON      string
+	Position        float64
+	UpdatedAt       string
+	ID              string
+}
+
+func (q *Queries) RestoreTask(ctx context.Context, arg RestoreTaskParams) error {
+	_, err := q.db.ExecContext(ctx, restoreTask,
+		arg.BoardID,
+		arg.ColumnID,
+		arg.Title,
+		arg.DescriptionMd,
+		arg.Status,
+		arg.Priority,
+		arg.DueAt,
+		arg.EstimateMinutes,
+		arg.Assignee,
+		arg.LabelsJSON,
+		arg.Position,
+		arg.UpdatedAt,
+		arg.ID,
+	)
+	return err
+}
+
+const transferTask = `-- name: TransferTask :exec
+UPDATE tasks
+SET provider_id = ?, workspace_id = ?, board_id = ?, remote_id = ?
+WHERE id = ?
+`
+
+type TransferTaskParams struct {
+	ProviderID  string
+	WorkspaceID string
+	BoardID     sql.NullString
+	RemoteID    sql.NullString
+	ID          string
+}
+
+func (q *Queries) TransferTask(ctx context.Context, arg TransferTaskParams) error {
+	_, err := q.db.ExecContext(ctx, transferTask,
+		arg.ProviderID,
+		arg.WorkspaceID,
+		arg.BoardID,
+		arg.RemoteID,
+		arg.ID,
+	)
+	return err
+}
+
+const transferTaskComments = `-- name: TransferTaskComments :exec
+UPDATE comments SET provider_id = ?, remote_id = NULL WHERE task_id = ?
+`
+
+type TransferTaskCommentsParams struct {
+	ProviderID string
+	TaskID     string
+}
+
+func (q *Queries) TransferTaskComments(ctx context.Context, arg TransferTaskCommentsParams) error {
+	_, err := q.db.ExecContext(ctx, transferTaskComments, arg.ProviderID, arg.TaskID)
+	return err
+}
+
 const deleteTask = `-- name: DeleteTask :exec
 DELETE FROM tasks WHERE id = ?
 `
@@ -519,7 +771,7 @@ func (q *Queries) CreateComment(ctx context.Context, arg CreateCommentParams) er
 }
 
 const listComments = `-- name: ListComments :many
-SELECT id, task_id, provider_id, remote_id, body_md, author, created_at
+SELECT id, task_id, provider_id, remote_id, body_md, author, created_at, version
 FROM comments
 WHERE task_id = ?
 ORDER BY created_at ASC
@@ -535,7 +787,7 @@ func (q *Queries) ListComments(ctx context.Context, taskID string) ([]Comment, e
 	items := make([]Comment, 0)
 	for rows.Next() {
 		var i Comment
-		if err := rows.Scan(&i.ID, &i.TaskID, &i.ProviderID, &i.RemoteID, &i.BodyMd, &i.Author, &i.CreatedAt); err != nil {
+		if err := rows.Scan(&i.ID, &i.TaskID, &i.ProviderID, &i.RemoteID, &i.BodyMd, &i.Author, &i.CreatedAt, &i.Version); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -638,7 +890,7 @@ func (q *Queries) UpdateColumnWIPLimit(ctx context.Context, arg UpdateColumnWIPL
 }
 
 const updateComment = `-- name: UpdateComment :exec
-UPDATE comments SET body_md = ? WHERE id = ?
+UPDATE comments SET body_md = ?, version = version + 1 WHERE id = ?
 `
 
 type UpdateCommentParams struct {
@@ -749,3 +1001,1528 @@ func (q *Queries) DeleteColumnsByBoard(ctx context.Context, boardID string) erro
 	_, err := q.db.ExecContext(ctx, deleteColumnsByBoard, boardID)
 	return err
 }
+
+const getWorkspace = `-- name: GetWorkspace :one
+SELECT id, provider_id, remote_id, name
+FROM workspaces
+WHERE id = ?
+`
+
+func (q *Queries) GetWorkspace(ctx context.Context, id string) (Workspace, error) {
+	row := q.db.QueryRowContext(ctx, getWorkspace, id)
+	var i Workspace
+	err := row.Scan(&i.ID, &i.ProviderID, &i.RemoteID, &i.Name)
+	return i, err
+}
+
+const getBoard = `-- name: GetBoard :one
+SELECT id, workspace_id, remote_id, name, view_default
+FROM boards
+WHERE id = ?
+`
+
+func (q *Queries) GetBoard(ctx context.Context, id string) (Board, error) {
+	row := q.db.QueryRowContext(ctx, getBoard, id)
+	var i Board
+	err := row.Scan(&i.ID, &i.WorkspaceID, &i.RemoteID, &i.Name, &i.ViewDefault)
+	return i, err
+}
+
+const getColumn = `-- name: GetColumn :one
+SELECT id, board_id, remote_id, name, color, position, wip_limit
+FROM columns
+WHERE id = ?
+`
+
+func (q *Queries) GetColumn(ctx context.Context, id string) (Column, error) {
+	row := q.db.QueryRowContext(ctx, getColumn, id)
+	var i Column
+	err := row.Scan(&i.ID, &i.BoardID, &i.RemoteID, &i.Name, &i.Color, &i.Position, &i.WipLimit)
+	return i, err
+}
+
+const getComment = `-- name: GetComment :one
+SELECT id, task_id, provider_id, remote_id, body_md, author, created_at, version
+FROM comments
+WHERE id = ?
+`
+
+func (q *Queries) GetComment(ctx context.Context, id string) (Comment, error) {
+	row := q.db.QueryRowContext(ctx, getComment, id)
+	var i Comment
+	err := row.Scan(&i.ID, &i.TaskID, &i.ProviderID, &i.RemoteID, &i.BodyMd, &i.Author, &i.CreatedAt, &i.Version)
+	return i, err
+}
+
+const getCommentVersion = `-- name: GetCommentVersion :one
+SELECT version FROM comments WHERE id = ?
+`
+
+func (q *Queries) GetCommentVersion(ctx context.Context, id string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getCommentVersion, id)
+	var version int64
+	err := row.Scan(&version)
+	return version, err
+}
+
+const createSyncItem = `-- name: CreateSyncItem :exec
+INSERT INTO sync_queue (id, provider_id, entity, entity_id, action, payload_json, attempts, created_at)
+VALUES (?, ?, ?, ?, ?, ?, 0, ?)
+`
+
+type CreateSyncItemParams struct {
+	ID          string
+	ProviderID  string
+	Entity      string
+	EntityID    string
+	Action      string
+	PayloadJSON string
+	CreatedAt   string
+}
+
+func (q *Queries) CreateSyncItem(ctx context.Context, arg CreateSyncItemParams) error {
+	_, err := q.db.ExecContext(ctx, createSyncItem,
+		arg.ID,
+		arg.ProviderID,
+		arg.Entity,
+		arg.EntityID,
+		arg.Action,
+		arg.PayloadJSON,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const listSyncItems = `-- name: ListSyncItems :many
+SELECT id, provider_id, entity, entity_id, action, payload_json, attempts, last_error, next_attempt_at, created_at
+FROM sync_queue
+WHERE (? = '' OR provider_id = ?)
+ORDER BY created_at ASC, rowid ASC
+`
+
+func (q *Queries) ListSyncItems(ctx context.Context, providerID string) ([]SyncQueue, error) {
+	rows, err := q.db.QueryContext(ctx, listSyncItems, providerID, providerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]SyncQueue, 0)
+	for rows.Next() {
+		var i SyncQueue
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProviderID,
+			&i.Entity,
+			&i.EntityID,
+			&i.Action,
+			&i.PayloadJSON,
+			&i.Attempts,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const recordSyncFailure = `-- name: RecordSyncFailure :exec
+UPDATE sync_queue
+SET attempts = attempts + 1, last_error = ?, next_attempt_at = ?
+WHERE id = ?
+`
+
+type RecordSyncFailureParams struct {
+	LastError     sql.NullString
+	NextAttemptAt sql.NullString
+	ID            string
+}
+
+func (q *Queries) RecordSyncFailure(ctx context.Context, arg RecordSyncFailureParams) error {
+	_, err := q.db.ExecContext(ctx, recordSyncFailure, arg.LastError, arg.NextAttemptAt, arg.ID)
+	return err
+}
+
+const deleteSyncItem = `-- name: DeleteSyncItem :execrows
+DELETE FROM sync_queue WHERE id = ?
+`
+
+func (q *Queries) DeleteSyncItem(ctx context.Context, id string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteSyncItem, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const resetSyncItem = `-- name: ResetSyncItem :execrows
+UPDATE sync_queue
+SET attempts = 0, last_error = NULL, next_attempt_at = NULL
+WHERE id = ?
+`
+
+func (q *Queries) ResetSyncItem(ctx context.Context, id string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, resetSyncItem, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const resetExhaustedSyncItems = `-- name: ResetExhaustedSyncItems :execrows
+UPDATE sync_queue
+SET attempts = 0, last_error = NULL, next_attempt_at = NULL
+WHERE attempts >= ?
+`
+
+func (q *Queries) ResetExhaustedSyncItems(ctx context.Context, maxAttempts int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, resetExhaustedSyncItems, maxAttempts)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteExhaustedSyncItems = `-- name: DeleteExhaustedSyncItems :execrows
+DELETE FROM sync_queue WHERE attempts >= ?
+`
+
+func (q *Queries) DeleteExhaustedSyncItems(ctx context.Context, maxAttempts int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteExhaustedSyncItems, maxAttempts)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteAllSyncItems = `-- name: DeleteAllSyncItems :execrows
+DELETE FROM sync_queue
+`
+
+func (q *Queries) DeleteAllSyncItems(ctx context.Context) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteAllSyncItems)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const setWorkspaceRemoteID = `-- name: SetWorkspaceRemoteID :exec
+UPDATE workspaces SET remote_id = ? WHERE id = ?
+`
+
+type SetWorkspaceRemoteIDParams struct {
+	RemoteID sql.NullString
+	ID       string
+}
+
+func (q *Queries) SetWorkspaceRemoteID(ctx context.Context, arg SetWorkspaceRemoteIDParams) error {
+	_, err := q.db.ExecContext(ctx, setWorkspaceRemoteID, arg.RemoteID, arg.ID)
+	return err
+}
+
+const setBoardRemoteID = `-- name: SetBoardRemoteID :exec
+UPDATE boards SET remote_id = ? WHERE id = ?
+`
+
+type SetBoardRemoteIDParams struct {
+	RemoteID sql.NullString
+	ID       string
+}
+
+func (q *Queries) SetBoardRemoteID(ctx context.Context, arg SetBoardRemoteIDParams) error {
+	_, err := q.db.ExecContext(ctx, setBoardRemoteID, arg.RemoteID, arg.ID)
+	return err
+}
+
+const setColumnRemoteID = `-- name: SetColumnRemoteID :exec
+UPDATE columns SET remote_id = ? WHERE id = ?
+`
+
+type SetColumnRemoteIDParams struct {
+	RemoteID sql.NullString
+	ID       string
+}
+
+func (q *Queries) SetColumnRemoteID(ctx context.Context, arg SetColumnRemoteIDParams) error {
+	_, err := q.db.ExecContext(ctx, setColumnRemoteID, arg.RemoteID, arg.ID)
+	return err
+}
+
+const setTaskRemoteID = `-- name: SetTaskRemoteID :exec
+UPDATE tasks SET remote_id = ? WHERE id = ?
+`
+
+type SetTaskRemoteIDParams struct {
+	RemoteID sql.NullString
+	ID       string
+}
+
+func (q *Queries) SetTaskRemoteID(ctx context.Context, arg SetTaskRemoteIDParams) error {
+	_, err := q.db.ExecContext(ctx, setTaskRemoteID, arg.RemoteID, arg.ID)
+	return err
+}
+
+const setCommentRemoteID = `-- name: SetCommentRemoteID :exec
+UPDATE comments SET remote_id = ? WHERE id = ?
+`
+
+type SetCommentRemoteIDParams struct {
+	RemoteID sql.NullString
+	ID       string
+}
+
+func (q *Queries) SetCommentRemoteID(ctx context.Context, arg SetCommentRemoteIDParams) error {
+	_, err := q.db.ExecContext(ctx, setCommentRemoteID, arg.RemoteID, arg.ID)
+	return err
+}
+
+const updateProviderAuth = `-- name: UpdateProviderAuth :exec
+UPDATE providers SET auth_json = ? WHERE id = ?
+`
+
+type UpdateProviderAuthParams struct {
+	AuthJSON sql.NullString
+	ID       string
+}
+
+func (q *Queries) UpdateProviderAuth(ctx context.Context, arg UpdateProviderAuthParams) error {
+	_, err := q.db.ExecContext(ctx, updateProviderAuth, arg.AuthJSON, arg.ID)
+	return err
+}
+
+const deleteProvider = `-- name: DeleteProvider :exec
+DELETE FROM providers WHERE id = ?
+`
+
+func (q *Queries) DeleteProvider(ctx context.Context, id string) error {
+	_, err := q.db.ExecContext(ctx, deleteProvider, id)
+	return err
+}
+
+const deleteSyncItemsByProvider = `-- name: DeleteSyncItemsByProvider :exec
+DELETE FROM sync_queue WHERE provider_id = ?
+`
+
+func (q *Queries) DeleteSyncItemsByProvider(ctx context.Context, providerID string) error {
+	_, err := q.db.ExecContext(ctx, deleteSyncItemsByProvider, providerID)
+	return err
+}
+
+const upsertTask = `-- name: UpsertTask :exec
+INSERT INTO tasks (
+  id,
+  provider_id,
+  workspace_id,
+  board_id,
+  column_id,
+  remote_id,
+  title,
+  description_md,
+  status,
+  priority,
+  due_at,
+  estimate_minutes,
+  assignee,
+  labels_json,
+  position,
+  created_at,
+  updated_at
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(id) DO UPDATE SET
+  column_id = excluded.column_id,
+  remote_id = excluded.remote_id,
+  title = excluded.title,
+  description_md = excluded.description_md,
+  status = excluded.status,
+  priority = excluded.priority,
+  due_at = excluded.due_at,
+  labels_json = excluded.labels_json,
+  position = excluded.position,
+  updated_at = excluded.updated_at,
+  version = tasks.version + 1
+`
+
+type UpsertTaskParams struct {
+	ID              string
+	ProviderID      string
+	WorkspaceID     string
+	BoardID         sql.NullString
+	ColumnID        sql.NullString
+	RemoteID        sql.NullString
+	Title           string
+	DescriptionMd   string
+	Status          sql.NullString
+	Priority        int64
+	DueAt           sql.NullString
+	EstimateMinutes sql.NullInt64
+	Assignee        sql.NullString
+	LabelsJSON      string
+	Position        float64
+	CreatedAt       string
+	UpdatedAt       string
+}
+
+func (q *Queries) UpsertTask(ctx context.Context, arg UpsertTaskParams) error {
+	_, err := q.db.ExecContext(ctx, upsertTask,
+		arg.ID,
+		arg.ProviderID,
+		arg.WorkspaceID,
+		arg.BoardID,
+		arg.ColumnID,
+		arg.RemoteID,
+		arg.Title,
+		arg.DescriptionMd,
+		arg.Status,
+		arg.Priority,
+		arg.DueAt,
+		arg.EstimateMinutes,
+		arg.Assignee,
+		arg.LabelsJSON,
+		arg.Position,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
+const upsertComment = `-- name: UpsertComment :exec
+INSERT INTO comments (id, task_id, provider_id, remote_id, body_md, author, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(id) DO UPDATE SET
+  remote_id = excluded.remote_id,
+  body_md = excluded.body_md,
+  author = excluded.author,
+  version = comments.version + 1
+`
+
+type UpsertCommentParams struct {
+	ID         string
+	TaskID     string
+	ProviderID string
+	RemoteID   sql.NullString
+	BodyMd     string
+	Author     sql.NullString
+	CreatedAt  string
+}
+
+func (q *Queries) UpsertComment(ctx context.Context, arg UpsertCommentParams) error {
+	_, err := q.db.ExecContext(ctx, upsertComment,
+		arg.ID,
+		arg.TaskID,
+		arg.ProviderID,
+		arg.RemoteID,
+		arg.BodyMd,
+		arg.Author,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const getSyncSnapshot = `-- name: GetSyncSnapshot :one
+SELECT entity, entity_id, provider_id, payload_json, synced_at
+FROM sync_snapshots
+WHERE entity = ? AND entity_id = ?
+`
+
+type GetSyncSnapshotParams struct {
+	Entity   string
+	EntityID string
+}
+
+func (q *Queries) GetSyncSnapshot(ctx context.Context, arg GetSyncSnapshotParams) (SyncSnapshot, error) {
+	row := q.db.QueryRowContext(ctx, getSyncSnapshot, arg.Entity, arg.EntityID)
+	var i SyncSnapshot
+	err := row.Scan(&i.Entity, &i.EntityID, &i.ProviderID, &i.PayloadJSON, &i.SyncedAt)
+	return i, err
+}
+
+const upsertSyncSnapshot = `-- name: UpsertSyncSnapshot :exec
+INSERT INTO sync_snapshots (entity, entity_id, provider_id, payload_json, synced_at)
+VALUES (?, ?, ?, ?, ?)
+ON CONFLICT(entity, entity_id) DO UPDATE SET
+  provider_id = excluded.provider_id,
+  payload_json = excluded.payload_json,
+  synced_at = excluded.synced_at
+`
+
+type UpsertSyncSnapshotParams struct {
+	Entity      string
+	EntityID    string
+	ProviderID  string
+	PayloadJSON string
+	SyncedAt    string
+}
+
+func (q *Queries) UpsertSyncSnapshot(ctx context.Context, arg UpsertSyncSnapshotParams) error {
+	_, err := q.db.ExecContext(ctx, upsertSyncSnapshot,
+		arg.Entity,
+		arg.EntityID,
+		arg.ProviderID,
+		arg.PayloadJSON,
+		arg.SyncedAt,
+	)
+	return err
+}
+
+const deleteSyncSnapshot = `-- name: DeleteSyncSnapshot :exec
+DELETE FROM sync_snapshots WHERE entity = ? AND entity_id = ?
+`
+
+type DeleteSyncSnapshotParams struct {
+	Entity   string
+	EntityID string
+}
+
+func (q *Queries) DeleteSyncSnapshot(ctx context.Context, arg DeleteSyncSnapshotParams) error {
+	_, err := q.db.ExecContext(ctx, deleteSyncSnapshot, arg.Entity, arg.EntityID)
+	return err
+}
+
+const deleteSyncSnapshotsByProvider = `-- name: DeleteSyncSnapshotsByProvider :exec
+DELETE FROM sync_snapshots WHERE provider_id = ?
+`
+
+func (q *Queries) DeleteSyncSnapshotsByProvider(ctx context.Context, providerID string) error {
+	_, err := q.db.ExecContext(ctx, deleteSyncSnapshotsByProvider, providerID)
+	return err
+}
+
+const listSyncConflicts = `-- name: ListSyncConflicts :many
+SELECT id, provider_id, entity, entity_id, fields_json, base_json, remote_json, created_at
+FROM sync_conflicts
+WHERE (? = '' OR provider_id = ?)
+ORDER BY created_at ASC, rowid ASC
+`
+
+func (q *Queries) ListSyncConflicts(ctx context.Context, providerID string) ([]SyncConflict, error) {
+	rows, err := q.db.QueryContext(ctx, listSyncConflicts, providerID, providerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]SyncConflict, 0)
+	for rows.Next() {
+		var i SyncConflict
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProviderID,
+			&i.Entity,
+			&i.EntityID,
+			&i.FieldsJSON,
+			&i.BaseJSON,
+			&i.RemoteJSON,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSyncConflict = `-- name: GetSyncConflict :one
+SELECT id, provider_id, entity, entity_id, fields_json, base_json, remote_json, created_at
+FROM sync_conflicts
+WHERE id = ?
+`
+
+func (q *Queries) GetSyncConflict(ctx context.Context, id string) (SyncConflict, error) {
+	row := q.db.QueryRowContext(ctx, getSyncConflict, id)
+	var i SyncConflict
+	err := row.Scan(
+		&i.ID,
+		&i.ProviderID,
+		&i.Entity,
+		&i.EntityID,
+		&i.FieldsJSON,
+		&i.BaseJSON,
+		&i.RemoteJSON,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const upsertSyncConflict = `-- name: UpsertSyncConflict :exec
+INSERT INTO sync_conflicts (id, provider_id, entity, entity_id, fields_json, base_json, remote_json, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(entity, entity_id) DO UPDATE SET
+  fields_json = excluded.fields_json,
+  base_json = excluded.base_json,
+  remote_json = excluded.remote_json
+`
+
+type UpsertSyncConflictParams struct {
+	ID         string
+	ProviderID string
+	Entity     string
+	EntityID   string
+	FieldsJSON string
+	BaseJSON   string
+	RemoteJSON string
+	CreatedAt  string
+}
+
+func (q *Queries) UpsertSyncConflict(ctx context.Context, arg UpsertSyncConflictParams) error {
+	_, err := q.db.ExecContext(ctx, upsertSyncConflict,
+		arg.ID,
+		arg.ProviderID,
+		arg.Entity,
+		arg.EntityID,
+		arg.FieldsJSON,
+		arg.BaseJSON,
+		arg.RemoteJSON,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const deleteSyncConflict = `-- name: DeleteSyncConflict :exec
+DELETE FROM sync_conflicts WHERE entity = ? AND entity_id = ?
+`
+
+type DeleteSyncConflictParams struct {
+	Entity   string
+	EntityID string
+}
+
+func (q *Queries) DeleteSyncConflict(ctx context.Context, arg DeleteSyncConflictParams) error {
+	_, err := q.db.ExecContext(ctx, deleteSyncConflict, arg.Entity, arg.EntityID)
+	return err
+}
+
+const deleteSyncConflictsByProvider = `-- name: DeleteSyncConflictsByProvider :exec
+DELETE FROM sync_conflicts WHERE provider_id = ?
+`
+
+func (q *Queries) DeleteSyncConflictsByProvider(ctx context.Context, providerID string) error {
+	_, err := q.db.ExecContext(ctx, deleteSyncConflictsByProvider, providerID)
+	return err
+}
+
+const deleteSyncItemsByEntity = `-- name: DeleteSyncItemsByEntity :exec
+DELETE FROM sync_queue WHERE entity = ? AND entity_id = ?
+`
+
+type DeleteSyncItemsByEntityParams struct {
+	Entity   string
+	EntityID string
+}
+
+func (q *Queries) DeleteSyncItemsByEntity(ctx context.Context, arg DeleteSyncItemsByEntityParams) error {
+	_, err := q.db.ExecContext(ctx, deleteSyncItemsByEntity, arg.Entity, arg.EntityID)
+	return err
+}
+
+const createWebhook = `-- name: CreateWebhook :exec
+INSERT INTO webhooks (id, workspace_id, url, secret, events_json, created_at)
+VALUES (?, ?, ?, ?, ?, ?)
+`
+
+type CreateWebhookParams struct {
+	ID          string
+	WorkspaceID string
+	URL         string
+	Secret      string
+	EventsJSON  string
+	CreatedAt   string
+}
+
+func (q *Queries) CreateWebhook(ctx context.Context, arg CreateWebhookParams) error {
+	_, err := q.db.ExecContext(ctx, createWebhook,
+		arg.ID,
+		arg.WorkspaceID,
+		arg.URL,
+		arg.Secret,
+		arg.EventsJSON,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const getWebhook = `-- name: GetWebhook :one
+SELECT id, workspace_id, url, secret, events_json, created_at
+FROM webhooks
+WHERE id = ?
+`
+
+func (q *Queries) GetWebhook(ctx context.Context, id string) (Webhook, error) {
+	row := q.db.QueryRowContext(ctx, getWebhook, id)
+	var i Webhook
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.URL,
+		&i.Secret,
+		&i.EventsJSON,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listWebhooks = `-- name: ListWebhooks :many
+SELECT id, workspace_id, url, secret, events_json, created_at
+FROM webhooks
+WHERE (? = '' OR workspace_id = ?)
+ORDER BY created_at ASC, rowid ASC
+`
+
+func (q *Queries) ListWebhooks(ctx context.Context, workspaceID string) ([]Webhook, error) {
+	rows, err := q.db.QueryContext(ctx, listWebhooks, workspaceID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]Webhook, 0)
+	for rows.Next() {
+		var i Webhook
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.URL,
+			&i.Secret,
+			&i.EventsJSON,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateWebhookSecret = `-- name: UpdateWebhookSecret :exec
+UPDATE webhooks SET secret = ? WHERE id = ?
+`
+
+type UpdateWebhookSecretParams struct {
+	Secret string
+	ID     string
+}
+
+func (q *Queries) UpdateWebhookSecret(ctx context.Context, arg UpdateWebhookSecretParams) error {
+	_, err := q.db.ExecContext(ctx, updateWebhookSecret, arg.Secret, arg.ID)
+	return err
+}
+
+const deleteWebhook = `-- name: DeleteWebhook :execrows
+DELETE FROM webhooks WHERE id = ?
+`
+
+func (q *Queries) DeleteWebhook(ctx context.Context, id string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteWebhook, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteWebhooksByWorkspace = `-- name: DeleteWebhooksByWorkspace :exec
+DELETE FROM webhooks WHERE workspace_id = ?
+`
+
+func (q *Queries) DeleteWebhooksByWorkspace(ctx context.Context, workspaceID string) error {
+	_, err := q.db.ExecContext(ctx, deleteWebhooksByWorkspace, workspaceID)
+	return err
+}
+
+const createWebhookDelivery = `-- name: CreateWebhookDelivery :exec
+INSERT INTO webhook_deliveries (id, webhook_id, event, payload_json, attempts, created_at)
+VALUES (?, ?, ?, ?, 0, ?)
+`
+
+type CreateWebhookDeliveryParams struct {
+	ID          string
+	WebhookID   string
+	Event       string
+	PayloadJSON string
+	CreatedAt   string
+}
+
+func (q *Queries) CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) error {
+	_, err := q.db.ExecContext(ctx, createWebhookDelivery,
+		arg.ID,
+		arg.WebhookID,
+		arg.Event,
+		arg.PayloadJSON,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const listWebhookDeliveries = `-- name: ListWebhookDeliveries :many
+SELECT id, webhook_id, event, payload_json, attempts, last_error, response_status, next_attempt_at, delivered_at, created_at
+FROM webhook_deliveries
+WHERE (? = '' OR webhook_id = ?)
+ORDER BY created_at DESC, rowid DESC
+`
+
+func (q *Queries) ListWebhookDeliveries(ctx context.Context, webhookID string) ([]WebhookDelivery, error) {
+	rows, err := q.db.QueryContext(ctx, listWebhookDeliveries, webhookID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]WebhookDelivery, 0)
+	for rows.Next() {
+		var i WebhookDelivery
+		if err := rows.Scan(
+			&i.ID,
+			&i.WebhookID,
+			&i.Event,
+			&i.PayloadJSON,
+			&i.Attempts,
+			&i.LastError,
+			&i.ResponseStatus,
+			&i.NextAttemptAt,
+			&i.DeliveredAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUndeliveredWebhookDeliveries = `-- name: ListUndeliveredWebhookDeliveries :many
+SELECT id, webhook_id, event, payload_json, attempts, last_error, response_status, next_attempt_at, delivered_at, created_at
+FROM webhook_deliveries
+WHERE delivered_at IS NULL
+ORDER BY created_at ASC, rowid ASC
+`
+
+func (q *Queries) ListUndeliveredWebhookDeliveries(ctx context.Context) ([]WebhookDelivery, error) {
+	rows, err := q.db.QueryContext(ctx, listUndeliveredWebhookDeliveries)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]WebhookDelivery, 0)
+	for rows.Next() {
+		var i WebhookDelivery
+		if err := rows.Scan(
+			&i.ID,
+			&i.WebhookID,
+			&i.Event,
+			&i.PayloadJSON,
+			&i.Attempts,
+			&i.LastError,
+			&i.ResponseStatus,
+			&i.NextAttemptAt,
+			&i.DeliveredAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markWebhookDelivered = `-- name: MarkWebhookDelivered :exec
+UPDATE webhook_deliveries
+SET attempts = attempts + 1, response_status = ?, last_error = NULL, next_attempt_at = NULL, delivered_at = ?
+WHERE id = ?
+`
+
+type MarkWebhookDeliveredParams struct {
+	ResponseStatus sql.NullInt64
+	DeliveredAt    sql.NullString
+	ID             string
+}
+
+func (q *Queries) MarkWebhookDelivered(ctx context.Context, arg MarkWebhookDeliveredParams) error {
+	_, err := q.db.ExecContext(ctx, markWebhookDelivered, arg.ResponseStatus, arg.DeliveredAt, arg.ID)
+	return err
+}
+
+const recordWebhookFailure = `-- name: RecordWebhookFailure :exec
+UPDATE webhook_deliveries
+SET attempts = attempts + 1, response_status = ?, last_error = ?, next_attempt_at = ?
+WHERE id = ?
+`
+
+type RecordWebhookFailureParams struct {
+	ResponseStatus sql.NullInt64
+	LastError      sql.NullString
+	NextAttemptAt  sql.NullString
+	ID             string
+}
+
+func (q *Queries) RecordWebhookFailure(ctx context.Context, arg RecordWebhookFailureParams) error {
+	_, err := q.db.ExecContext(ctx, recordWebhookFailure,
+		arg.ResponseStatus,
+		arg.LastError,
+		arg.NextAttemptAt,
+		arg.ID,
+	)
+	return err
+}
+
+const resetWebhookDelivery = `-- name: ResetWebhookDelivery :execrows
+UPDATE webhook_deliveries
+SET attempts = 0, next_attempt_at = NULL
+WHERE id = ? AND delivered_at IS NULL
+`
+
+func (q *Queries) ResetWebhookDelivery(ctx context.Context, id string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, resetWebhookDelivery, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteWebhookDeliveriesByWebhook = `-- name: DeleteWebhookDeliveriesByWebhook :exec
+DELETE FROM webhook_deliveries WHERE webhook_id = ?
+`
+
+func (q *Queries) DeleteWebhookDeliveriesByWebhook(ctx context.Context, webhookID string) error {
+	_, err := q.db.ExecContext(ctx, deleteWebhookDeliveriesByWebhook, webhookID)
+	return err
+}
+
+const deleteWebhookDeliveriesByWorkspace = `-- name: DeleteWebhookDeliveriesByWorkspace :exec
+DELETE FROM webhook_deliveries
+WHERE webhook_id IN (SELECT id FROM webhooks WHERE workspace_id = ?)
+`
+
+func (q *Queries) DeleteWebhookDeliveriesByWorkspace(ctx context.Context, workspaceID string) error {
+	_, err := q.db.ExecContext(ctx, deleteWebhookDeliveriesByWorkspace, workspaceID)
+	return err
+}
+
+const createTaskEvent = `-- name: CreateTaskEvent :exec
+INSERT INTO task_events (id, task_id, workspace_id, board_id, kind, title, changes_json, actor, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateTaskEventParams struct {
+	ID          string
+	TaskID      string
+	WorkspaceID string
+	BoardID     sql.NullString
+	Kind        string
+	Title       string
+	ChangesJSON string
+	Actor       sql.NullString
+	CreatedAt   string
+}
+
+func (q *Queries) CreateTaskEvent(ctx context.Context, arg CreateTaskEventParams) error {
+	_, err := q.db.ExecContext(ctx, createTaskEvent,
+		arg.ID,
+		arg.TaskID,
+		arg.WorkspaceID,
+		arg.BoardID,
+		arg.Kind,
+		arg.Title,
+		arg.ChangesJSON,
+		arg.Actor,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const listTaskEvents = `-- name: ListTaskEvents :many
+SELECT id, task_id, workspace_id, board_id, kind, title, changes_json, actor, created_at
+FROM task_events
+WHERE task_id = ?
+ORDER BY created_at ASC, rowid ASC
+`
+
+func (q *Queries) ListTaskEvents(ctx context.Context, taskID string) ([]TaskEvent, error) {
+	rows, err := q.db.QueryContext(ctx, listTaskEvents, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]TaskEvent, 0)
+	for rows.Next() {
+		var i TaskEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.TaskID,
+			&i.WorkspaceID,
+			&i.BoardID,
+			&i.Kind,
+			&i.Title,
+			&i.ChangesJSON,
+			&i.Actor,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWorkspaceTaskEvents = `-- name: ListWorkspaceTaskEvents :many
+SELECT id, task_id, workspace_id, board_id, kind, title, changes_json, actor, created_at
+FROM task_events
+WHERE workspace_id = ?
+  AND (? = '' OR board_id = ?)
+ORDER BY created_at DESC, rowid DESC
+LIMIT ?
+`
+
+type ListWorkspaceTaskEventsParams struct {
+	WorkspaceID string
+	BoardID     string
+	Limit       int64
+}
+
+func (q *Queries) ListWorkspaceTaskEvents(ctx context.Context, arg ListWorkspaceTaskEventsParams) ([]TaskEvent, error) {
+	rows, err := q.db.QueryContext(ctx, listWorkspaceTaskEvents,
+		arg.WorkspaceID,
+		arg.BoardID,
+		arg.BoardID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]TaskEvent, 0)
+	for rows.Next() {
+		var i TaskEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.TaskID,
+			&i.WorkspaceID,
+			&i.BoardID,
+			&i.Kind,
+			&i.Title,
+			&i.ChangesJSON,
+			&i.Actor,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deleteTaskEventsByWorkspace = `-- name: DeleteTaskEventsByWorkspace :exec
+DELETE FROM task_events WHERE workspace_id = ?
+`
+
+func (q *Queries) DeleteTaskEventsByWorkspace(ctx context.Context, workspaceID string) error {
+	_, err := q.db.ExecContext(ctx, deleteTaskEventsByWorkspace, workspaceID)
+	return err
+}
+
+const createOperation = `-- name: CreateOperation :exec
+INSERT INTO operations (id, namespace, kind, entity_id, title, before_json, after_json, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateOperationParams struct {
+	ID         string
+	Namespace  string
+	Kind       string
+	EntityID   string
+	Title      string
+	BeforeJSON sql.NullString
+	AfterJSON  sql.NullString
+	CreatedAt  string
+}
+
+func (q *Queries) CreateOperation(ctx context.Context, arg CreateOperationParams) error {
+	_, err := q.db.ExecContext(ctx, createOperation,
+		arg.ID,
+		arg.Namespace,
+		arg.Kind,
+		arg.EntityID,
+		arg.Title,
+		arg.BeforeJSON,
+		arg.AfterJSON,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const getLastOperation = `-- name: GetLastOperation :one
+SELECT id, namespace, kind, entity_id, title, before_json, after_json, undone_at, created_at
+FROM operations
+WHERE namespace = ? AND undone_at IS NULL
+ORDER BY rowid DESC
+LIMIT 1
+`
+
+func (q *Queries) GetLastOperation(ctx context.Context, namespace string) (Operation, error) {
+	row := q.db.QueryRowContext(ctx, getLastOperation, namespace)
+	var i Operation
+	err := row.Scan(
+		&i.ID,
+		&i.Namespace,
+		&i.Kind,
+		&i.EntityID,
+		&i.Title,
+		&i.BeforeJSON,
+		&i.AfterJSON,
+		&i.UndoneAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getFirstUndoneOperation = `-- name: GetFirstUndoneOperation :one
+SELECT id, namespace, kind, entity_id, title, before_json, after_json, undone_at, created_at
+FROM operations
+WHERE namespace = ? AND undone_at IS NOT NULL
+ORDER BY rowid ASC
+LIMIT 1
+`
+
+func (q *Queries) GetFirstUndoneOperation(ctx context.Context, namespace string) (Operation, error) {
+	row := q.db.QueryRowContext(ctx, getFirstUndoneOperation, namespace)
+	var i Operation
+	err := row.Scan(
+		&i.ID,
+		&i.Namespace,
+		&i.Kind,
+		&i.EntityID,
+		&i.Title,
+		&i.BeforeJSON,
+		&i.AfterJSON,
+		&i.UndoneAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const setOperationUndoneAt = `-- name: SetOperationUndoneAt :exec
+UPDATE operations SET undone_at = ? WHERE id = ?
+`
+
+type SetOperationUndoneAtParams struct {
+	UndoneAt sql.NullString
+	ID       string
+}
+
+func (q *Queries) SetOperationUndoneAt(ctx context.Context, arg SetOperationUndoneAtParams) error {
+	_, err := q.db.ExecContext(ctx, setOperationUndoneAt, arg.UndoneAt, arg.ID)
+	return err
+}
+
+const deleteOperation = `-- name: DeleteOperation :exec
+DELETE FROM operations WHERE id = ?
+`
+
+func (q *Queries) DeleteOperation(ctx context.Context, id string) error {
+	_, err := q.db.ExecContext(ctx, deleteOperation, id)
+	return err
+}
+
+const deleteUndoneOperations = `-- name: DeleteUndoneOperations :exec
+DELETE FROM operations WHERE namespace = ? AND undone_at IS NOT NULL
+`
+
+func (q *Queries) DeleteUndoneOperations(ctx context.Context, namespace string) error {
+	_, err := q.db.ExecContext(ctx, deleteUndoneOperations, namespace)
+	return err
+}
+
+const pruneOperations = `-- name: PruneOperations :exec
+DELETE FROM operations
+WHERE namespace = ?
+  AND rowid <= (
+    SELECT rowid FROM operations WHERE namespace = ? ORDER BY rowid DESC LIMIT 1 OFFSET ?
+  )
+`
+
+type PruneOperationsParams struct {
+	Namespace string
+	Keep      int64
+}
+
+func (q *Queries) PruneOperations(ctx context.Context, arg PruneOperationsParams) error {
+	_, err := q.db.ExecContext(ctx, pruneOperations, arg.Namespace, arg.Namespace, arg.Keep)
+	return err
+}
+
+const createView = `-- name: CreateView :exec
+INSERT INTO views (id, workspace_id, board_id, name, settings_json, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateViewParams struct {
+	ID           string
+	WorkspaceID  string
+	BoardID      sql.NullString
+	Name         string
+	SettingsJSON string
+	CreatedAt    string
+	UpdatedAt    string
+}
+
+func (q *Queries) CreateView(ctx context.Context, arg CreateViewParams) error {
+	_, err := q.db.ExecContext(ctx, createView,
+		arg.ID,
+		arg.WorkspaceID,
+		arg.BoardID,
+		arg.Name,
+		arg.SettingsJSON,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
+const updateView = `-- name: UpdateView :execrows
+UPDATE views SET settings_json = ?, updated_at = ? WHERE id = ?
+`
+
+type UpdateViewParams struct {
+	SettingsJSON string
+	UpdatedAt    string
+	ID           string
+}
+
+func (q *Queries) UpdateView(ctx context.Context, arg UpdateViewParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateView, arg.SettingsJSON, arg.UpdatedAt, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getView = `-- name: GetView :one
+SELECT id, workspace_id, board_id, name, settings_json, created_at, updated_at
+FROM views
+WHERE id = ?
+`
+
+func (q *Queries) GetView(ctx context.Context, id string) (View, error) {
+	row := q.db.QueryRowContext(ctx, getView, id)
+	var i View
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.BoardID,
+		&i.Name,
+		&i.SettingsJSON,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listViews = `-- name: ListViews :many
+SELECT id, workspace_id, board_id, name, settings_json, created_at, updated_at
+FROM views
+WHERE workspace_id = ? AND (? = '' OR board_id IS NULL OR board_id = ?)
+ORDER BY LOWER(name) ASC, board_id IS NULL ASC
+`
+
+type ListViewsParams struct {
+	WorkspaceID string
+	BoardID     string
+}
+
+func (q *Queries) ListViews(ctx context.Context, arg ListViewsParams) ([]View, error) {
+	rows, err := q.db.QueryContext(ctx, listViews, arg.WorkspaceID, arg.BoardID, arg.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]View, 0)
+	for rows.Next() {
+		var i View
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.BoardID,
+			&i.Name,
+			&i.SettingsJSON,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deleteView = `-- name: DeleteView :execrows
+DELETE FROM views WHERE id = ?
+`
+
+func (q *Queries) DeleteView(ctx context.Context, id string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteView, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteViewsByBoard = `-- name: DeleteViewsByBoard :exec
+DELETE FROM views WHERE board_id = ?
+`
+
+func (q *Queries) DeleteViewsByBoard(ctx context.Context, boardID string) error {
+	_, err := q.db.ExecContext(ctx, deleteViewsByBoard, boardID)
+	return err
+}
+
+const deleteViewsByWorkspace = `-- name: DeleteViewsByWorkspace :exec
+DELETE FROM views WHERE workspace_id = ?
+`
+
+func (q *Queries) DeleteViewsByWorkspace(ctx context.Context, workspaceID string) error {
+	_, err := q.db.ExecContext(ctx, deleteViewsByWorkspace, workspaceID)
+	return err
+}
+
+const createLabel = `-- name: CreateLabel :exec
+INSERT INTO labels (id, workspace_id, name, color, description, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateLabelParams struct {
+	ID          string
+	WorkspaceID string
+	Name        string
+	Color       string
+	Description string
+	CreatedAt   string
+	UpdatedAt   string
+}
+
+func (q *Queries) CreateLabel(ctx context.Context, arg CreateLabelParams) error {
+	_, err := q.db.ExecContext(ctx, createLabel,
+		arg.ID,
+		arg.WorkspaceID,
+		arg.Name,
+		arg.Color,
+		arg.Description,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
+const ensureLabel = `-- name: EnsureLabel :exec
+INSERT INTO labels (id, workspace_id, name, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?)
+ON CONFLICT DO NOTHING
+`
+
+type EnsureLabelParams struct {
+	ID          string
+	WorkspaceID string
+	Name        string
+	CreatedAt   string
+	UpdatedAt   string
+}
+
+func (q *Queries) EnsureLabel(ctx context.Context, arg EnsureLabelParams) error {
+	_, err := q.db.ExecContext(ctx, ensureLabel,
+		arg.ID,
+		arg.WorkspaceID,
+		arg.Name,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
+const updateLabel = `-- name: UpdateLabel :execrows
+UPDATE labels SET name = ?, color = ?, description = ?, updated_at = ? WHERE id = ?
+`
+
+type UpdateLabelParams struct {
+	Name        string
+	Color       string
+	Description string
+	UpdatedAt   string
+	ID          string
+}
+
+func (q *Queries) UpdateLabel(ctx context.Context, arg UpdateLabelParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateLabel,
+		arg.Name,
+		arg.Color,
+		arg.Description,
+		arg.UpdatedAt,
+		arg.ID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getLabelByName = `-- name: GetLabelByName :one
+SELECT id, workspace_id, name, color, description, created_at, updated_at
+FROM labels
+WHERE workspace_id = ? AND LOWER(TRIM(name)) = LOWER(TRIM(?))
+`
+
+type GetLabelByNameParams struct {
+	WorkspaceID string
+	Name        string
+}
+
+func (q *Queries) GetLabelByName(ctx context.Context, arg GetLabelByNameParams) (Label, error) {
+	row := q.db.QueryRowContext(ctx, getLabelByName, arg.WorkspaceID, arg.Name)
+	var i Label
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.Name,
+		&i.Color,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listLabels = `-- name: ListLabels :many
+SELECT
+  l.id,
+  l.workspace_id,
+  l.name,
+  l.color,
+  l.description,
+  l.created_at,
+  l.updated_at,
+  (
+    SELECT COUNT(*)
+    FROM tasks t
+    WHERE t.workspace_id = l.workspace_id
+      AND EXISTS (SELECT 1 FROM json_each(t.labels_json) j WHERE LOWER(TRIM(j.value)) = LOWER(TRIM(l.name)))
+  ) AS task_count
+FROM labels l
+WHERE l.workspace_id = ?
+ORDER BY LOWER(l.name) ASC
+`
+
+type ListLabelsRow struct {
+	ID          string
+	WorkspaceID string
+	Name        string
+	Color       string
+	Description string
+	CreatedAt   string
+	UpdatedAt   string
+	TaskCount   int64
+}
+
+func (q *Queries) ListLabels(ctx context.Context, workspaceID string) ([]ListLabelsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listLabels, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]ListLabelsRow, 0)
+	for rows.Next() {
+		var i ListLabelsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.Name,
+			&i.Color,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.TaskCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deleteLabel = `-- name: DeleteLabel :execrows
+DELETE FROM labels WHERE id = ?
+`
+
+func (q *Queries) DeleteLabel(ctx context.Context, id string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteLabel, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteLabelsByWorkspace = `-- name: DeleteLabelsByWorkspace :exec
+DELETE FROM labels WHERE workspace_id = ?
+`
+
+func (q *Queries) DeleteLabelsByWorkspace(ctx context.Context, workspaceID string) error {
+	_, err := q.db.ExecContext(ctx, deleteLabelsByWorkspace, workspaceID)
+	return err
+}
@@ -53,6 +53,7 @@ CREATE TABLE tasks (
   position REAL NOT NULL DEFAULT 0,
   created_at TEXT NOT NULL,
   updated_at TEXT NOT NULL,
+  version INTEGER NOT NULL DEFAULT 1,
   FOREIGN KEY (provider_id) REFERENCES providers(id),
   FOREIGN KEY (workspace_id) REFERENCES workspaces(id),
   FOREIGN KEY (board_id) REFERENCES boards(id),
@@ -67,6 +68,7 @@ CREATE TABLE comments (
   body_md TEXT NOT NULL,
   author TEXT NULL,
   created_at TEXT NOT NULL,
+  version INTEGER NOT NULL DEFAULT 1,
   FOREIGN KEY (task_id) REFERENCES tasks(id),
   FOREIGN KEY (provider_id) REFERENCES providers(id)
 );
@@ -81,12 +83,163 @@ CREATE TABLE sync_queue (
   attempts INTEGER NOT NULL DEFAULT 0,
   last_error TEXT NULL,
   created_at TEXT NOT NULL,
+  next_attempt_at TEXT NULL,
   FOREIGN KEY (provider_id) REFERENCES providers(id)
 );
 
+CREATE TABLE sync_snapshots (
+  entity TEXT NOT NULL,
+  entity_id TEXT NOT NULL,
+  provider_id TEXT NOT NULL,
+  payload_json TEXT NOT NULL,
+  synced_at TEXT NOT NULL,
+  PRIMARY KEY (entity, entity_id),
+  FOREIGN KEY (provider_id) REFERENCES providers(id)
+);
+
+CREATE TABLE sync_conflicts (
+  id TEXT PRIMARY KEY,
+  provider_id TEXT NOT NULL,
+  entity TEXT NOT NULL,
+  entity_id TEXT NOT NULL,
+  fields_json TEXT NOT NULL,
+  base_json TEXT NOT NULL,
+  remote_json TEXT NOT NULL,
+  created_at TEXT NOT NULL,
+  FOREIGN KEY (provider_id) REFERENCES providers(id)
+);
+
+CREATE TABLE webhooks (
+  id TEXT PRIMARY KEY,
+  workspace_id TEXT NOT NULL,
+  url TEXT NOT NULL,
+  secret TEXT NOT NULL,
+  events_json TEXT NOT NULL,
+  created_at TEXT NOT NULL,
+  FOREIGN KEY (workspace_id) REFERENCES workspaces(id)
+);
+
+CREATE TABLE webhook_deliveries (
+  id TEXT PRIMARY KEY,
+  webhook_id TEXT NOT NULL,
+  event TEXT NOT NULL,
+  payload_json TEXT NOT NULL,
+  attempts INTEGER NOT NULL DEFAULT 0,
+  last_error TEXT NULL,
+  response_status INTEGER NULL,
+  next_attempt_at TEXT NULL,
+  delivered_at TEXT NULL,
+  created_at TEXT NOT NULL,
+  FOREIGN KEY (webhook_id) REFERENCES webhooks(id)
+);
+
+CREATE TABLE task_events (
+  id TEXT PRIMARY KEY,
+  task_id TEXT NOT NULL,
+  workspace_id TEXT NOT NULL,
+  board_id TEXT NULL,
+  kind TEXT NOT NULL,
+  title TEXT NOT NULL,
+  changes_json TEXT NOT NULL,
+  actor TEXT NULL,
+  created_at TEXT NOT NULL
+);
+
+CREATE TABLE operations (
+  id TEXT PRIMARY KEY,
+  namespace TEXT NOT NULL,
+  kind TEXT NOT NULL,
+  entity_id TEXT NOT NULL,
+  title TEXT NOT NULL,
+  before_json TEXT NULL,
+  after_json TEXT NULL,
+  undone_at TEXT NULL,
+  created_at TEXT NOT NULL
+);
+
+CREATE TABLE views (
+  id TEXT PRIMARY KEY,
+  workspace_id TEXT NOT NULL,
+  board_id TEXT NULL,
+  name TEXT NOT NULL,
+  settings_json TEXT NOT NULL,
+  created_at TEXT NOT NULL,
+  updated_at TEXT NOT NULL,
+  FOREIGN KEY (workspace_id) REFERENCES workspaces(id),
+  FOREIGN KEY (board_id) REFERENCES boards(id)
+);
+
+CREATE TABLE labels (
+  id TEXT PRIMARY KEY,
+  workspace_id TEXT NOT NULL,
+  name TEXT NOT NULL,
+  color TEXT NOT NULL DEFAULT '',
+  description TEXT NOT NULL DEFAULT '',
+  created_at TEXT NOT NULL,
+  updated_at TEXT NOT NULL,
+  FOREIGN KEY (workspace_id) REFERENCES workspaces(id)
+);
+
 CREATE INDEX idx_tasks_workspace_id ON tasks(workspace_id);
 CREATE INDEX idx_tasks_column_id ON tasks(column_id);
 CREATE INDEX idx_tasks_updated_at ON tasks(updated_at);
 CREATE INDEX idx_tasks_due_at ON tasks(due_at);
 CREATE INDEX idx_comments_task_created ON comments(task_id, created_at);
 CREATE INDEX idx_columns_board_position ON columns(board_id, position);
+CREATE INDEX idx_sync_queue_provider_created ON sync_queue(provider_id, created_at);
+CREATE UNIQUE INDEX idx_sync_conflicts_entity ON sync_conflicts(entity, entity_id);
+CREATE INDEX idx_webhooks_workspace ON webhooks(workspace_id);
+CREATE INDEX idx_webhook_deliveries_webhook_created ON webhook_deliveries(webhook_id, created_at);
+CREATE INDEX idx_task_events_task_created ON task_events(task_id, created_at);
+CREATE INDEX idx_task_events_workspace_created ON task_events(workspace_id, created_at);
+CREATE INDEX idx_operations_namespace ON operations(namespace);
+CREATE UNIQUE INDEX idx_views_name_unique ON views(workspace_id, COALESCE(board_id, ''), LOWER(TRIM(name)));
+CREATE UNIQUE INDEX idx_labels_name_unique ON labels(workspace_id, LOWER(TRIM(name)));
+
+CREATE VIRTUAL TABLE task_search USING fts5(
+  task_id UNINDEXED,
+  title,
+  description,
+  labels,
+  comments,
+  tokenize = 'unicode61 remove_diacritics 2'
+);
+
+CREATE TRIGGER task_search_task_insert AFTER INSERT ON tasks BEGIN
+  INSERT INTO task_search (task_id, title, description, labels, comments)
+  VALUES (
+    new.id,
+    new.title,
+    new.description_md,
+    new.labels_json,
+    COALESCE((SELECT group_concat(body_md, char(10)) FROM comments WHERE task_id = new.id), '')
+  );
+END;
+
+CREATE TRIGGER task_search_task_update AFTER UPDATE OF title, description_md, labels_json ON tasks BEGIN
+  UPDATE task_search
+  SET title = new.title, description = new.description_md, labels = new.labels_json
+  WHERE task_id = new.id;
+END;
+
+CREATE TRIGGER task_search_task_delete AFTER DELETE ON tasks BEGIN
+  DELETE FROM task_search WHERE task_id = old.id;
+END;
+
+CREATE TRIGGER task_search_comment_insert AFTER INSERT ON comments BEGIN
+  UPDATE task_search
+  SET comments = COALESCE((SELECT group_concat(body_md, char(10)) FROM comments WHERE task_id = new.task_id), '')
+  WHERE task_id = new.task_id;
+END;
+
+CREATE TRIGGER task_search_comment_update AFTER UPDATE OF body_md ON comments BEGIN
+  UPDATE task_search
+  SET comments = COALESCE((SELECT group_concat(body_md, char(10)) FROM comments WHERE task_id = new.task_id), '')
+  WHERE task_id = new.task_id;
+END;
+
+CREATE TRIGGER task_search_comment_delete AFTER DELETE ON comments BEGIN
+  UPDATE task_search
+  SET comments = COALESCE((SELECT group_concat(body_md, char(10)) FROM comments WHERE task_id = old.task_id), '')
+  WHERE task_id = old.task_id;
+END;
@@ -5,8 +5,10 @@ import (
 	"database/sql"
 	"embed"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/pressly/goose/v3"
 	_ "modernc.org/sqlite"
@@ -16,6 +18,15 @@ import (
 
 const DefaultAppName = "kanji"
 
+// BusyTimeout is how long a connection waits for another process's lock
+// before failing with SQLITE_BUSY.
+const BusyTimeout = 5 * time.Second
+
+// connParams configures every pooled connection. WAL lets the TUI read while
+// a CLI command writes, and immediate transactions take the write lock up
+// front so busy_timeout applies instead of failing on a lock upgrade.
+var connParams = fmt.Sprintf("_pragma=busy_timeout(%d)&_pragma=foreign_keys(1)&_pragma=journal_mode(WAL)&_txlock=immediate", BusyTimeout.Milliseconds())
+
 //go:embed migrations/*.sql
 var migrationsFS embed.FS
 
@@ -39,13 +50,13 @@ func NewSQLiteAdapter(dbPath string) (*SQLiteAdapter, error) {
 		return nil, fmt.Errorf("create db directory: %w", err)
 	}
 
-	db, err := sql.Open("sqlite", dbPath)
+	db, err := sql.Open("sqlite", dataSourceName(dbPath))
 	if err != nil {
 		return nil, fmt.Errorf("open sqlite db: %w", err)
 	}
-	if _, err := db.Exec("PRAGMA foreign_keys = ON;"); err != nil {
+	if err := db.Ping(); err != nil {
 		_ = db.Close()
-		return nil, fmt.Errorf("enable foreign keys: %w", err)
+		return nil, fmt.Errorf("configure sqlite db: %w", err)
 	}
 
 	return &SQLiteAdapter{
@@ -54,6 +65,14 @@ func NewSQLiteAdapter(dbPath string) (*SQLiteAdapter, error) {
 	}, nil
 }
 
+// dataSourceName returns dbPath as a file: URI carrying connParams. Escaping
+// the path keeps a '?' or '#' in it from being read as the start of the
+// parameters.
+func dataSourceName(dbPath string) string {
+	path := (&url.URL{Path: filepath.ToSlash(dbPath)}).EscapedPath()
+	return (&url.URL{Scheme: "file", Opaque: path, RawQuery: connParams}).String()
+}
+
 func (a *SQLiteAdapter) Queries() *sqlc.Queries {
 	return a.queries
 }
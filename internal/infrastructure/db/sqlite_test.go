@@ -0,0 +1,46 @@
+package db
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewSQLiteAdapter_PathWithURICharacters(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "what?#100%")
+	path := filepath.Join(dir, "app.db")
+	adapter, err := NewSQLiteAdapter(path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer adapter.Close()
+	if _, err := adapter.Raw().Exec("CREATE TABLE t (id INTEGER)"); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected the database at %s: %v", path, err)
+	}
+}
+
+func TestNewSQLiteAdapter_PragmasHoldOnEveryConnection(t *testing.T) {
+	ctx := context.Background()
+	adapter, err := NewSQLiteAdapter(filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer adapter.Close()
+
+	// Hold several connections at once so the pool has to open new ones.
+	for i := 0; i < 3; i++ {
+		conn, err := adapter.Raw().Conn(ctx)
+		if err != nil {
+			t.Fatalf("conn %d: %v", i, err)
+		}
+		defer conn.Close()
+		var foreignKeys int
+		if err := conn.QueryRowContext(ctx, "PRAGMA foreign_keys").Scan(&foreignKeys); err != nil || foreignKeys != 1 {
+			t.Fatalf("conn %d foreign_keys = %d (%v), want 1", i, foreignKeys, err)
+		}
+	}
+}
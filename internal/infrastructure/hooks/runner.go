@@ -0,0 +1,198 @@
+// Package hooks runs local executable hooks, much like git hooks.
+//
+// A hook is an executable file in the hooks directory named after the hook,
+// such as pre-task-move or task-created. It receives the event as JSON on
+// stdin and its context in KANJI_* environment variables. Files that are
+// missing or not executable are skipped.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+// DefaultTimeout bounds how long a single hook may run.
+const DefaultTimeout = 30 * time.Second
+
+// Runner runs the hooks installed in one directory.
+type Runner struct {
+	dir      string
+	env      []string
+	warnings io.Writer
+	timeout  time.Duration
+}
+
+// NewRunner returns a runner for the hooks in dir. env is added to the
+// environment of every hook. Failures of hooks that run after a change are
+// reported to warnings, which may be nil.
+func NewRunner(dir string, env []string, warnings io.Writer) *Runner {
+	return &Runner{dir: dir, env: env, warnings: warnings, timeout: DefaultTimeout}
+}
+
+// DefaultDir returns the canonical hooks directory.
+func DefaultDir() (string, error) {
+	cfgDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("resolve config dir: %w", err)
+	}
+	return filepath.Join(cfgDir, "kanji", "hooks"), nil
+}
+
+// SetWarnings replaces the writer failures of post hooks are reported to.
+func (r *Runner) SetWarnings(w io.Writer) {
+	r.warnings = w
+}
+
+// Path returns the executable installed for hook, or "" when there is none.
+func (r *Runner) Path(hook string) string {
+	path := filepath.Join(r.dir, hook)
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() || info.Mode().Perm()&0o111 == 0 {
+		return ""
+	}
+	return path
+}
+
+// Run runs the hook for event, if installed. A non-zero exit is returned as
+// an error carrying the hook's output, or its exit status when it printed
+// nothing.
+func (r *Runner) Run(ctx context.Context, event domain.HookEvent) error {
+	path := r.Path(event.Hook)
+	if path == "" {
+		return nil
+	}
+	err := r.run(ctx, path, event)
+	if err != nil && !strings.HasPrefix(event.Hook, "pre-") && r.warnings != nil {
+		fmt.Fprintf(r.warnings, "warning: %s hook failed: %v\n", event.Hook, err)
+	}
+	return err
+}
+
+func (r *Runner) run(ctx context.Context, path string, event domain.HookEvent) error {
+	body, err := json.Marshal(newPayload(event))
+	if err != nil {
+		return fmt.Errorf("encode hook event: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	var output bytes.Buffer
+	cmd := exec.CommandContext(ctx, path)
+	cmd.Stdin = bytes.NewReader(append(body, '\n'))
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	cmd.Env = append(append(os.Environ(), r.env...), eventEnv(event)...)
+	// Do not wait for background children that keep the output open.
+	cmd.WaitDelay = time.Second
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("timed out after %s", r.timeout)
+		}
+		if msg := strings.TrimSpace(output.String()); msg != "" {
+			return errors.New(msg)
+		}
+		return err
+	}
+	return nil
+}
+
+func eventEnv(event domain.HookEvent) []string {
+	env := []string{"KANJI_HOOK=" + event.Hook, "KANJI_WORKSPACE_ID=" + event.WorkspaceID}
+	if t := event.Task; t != nil {
+		env = append(env, "KANJI_TASK_ID="+t.ID)
+		if t.BoardID != nil {
+			env = append(env, "KANJI_BOARD_ID="+*t.BoardID)
+		}
+		if t.ColumnID != nil {
+			env = append(env, "KANJI_COLUMN_ID="+*t.ColumnID)
+		}
+	}
+	if event.Comment != nil {
+		env = append(env, "KANJI_COMMENT_ID="+event.Comment.ID)
+	}
+	return env
+}
+
+// payload is the JSON written to a hook's stdin.
+type payload struct {
+	Hook        string         `json:"hook"`
+	WorkspaceID string         `json:"workspace_id"`
+	Task        *taskJSON      `json:"task,omitempty"`
+	Comment     *commentJSON   `json:"comment,omitempty"`
+	Changes     map[string]any `json:"changes,omitempty"`
+}
+
+type taskJSON struct {
+	ID          string   `json:"id"`
+	WorkspaceID string   `json:"workspace_id"`
+	BoardID     *string  `json:"board_id"`
+	ColumnID    *string  `json:"column_id"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Status      *string  `json:"status"`
+	Priority    int      `json:"priority"`
+	DueAt       *string  `json:"due_at"`
+	Labels      []string `json:"labels"`
+	CreatedAt   string   `json:"created_at"`
+	UpdatedAt   string   `json:"updated_at"`
+}
+
+type commentJSON struct {
+	ID        string  `json:"id"`
+	TaskID    string  `json:"task_id"`
+	Body      string  `json:"body"`
+	Author    *string `json:"author"`
+	CreatedAt string  `json:"created_at"`
+}
+
+func newPayload(event domain.HookEvent) payload {
+	p := payload{Hook: event.Hook, WorkspaceID: event.WorkspaceID, Changes: event.Changes}
+	if t := event.Task; t != nil {
+		var dueAt *string
+		if t.DueAt != nil {
+			formatted := t.DueAt.UTC().Format(time.RFC3339)
+			dueAt = &formatted
+		}
+		labels := t.Labels
+		if labels == nil {
+			labels = []string{}
+		}
+		p.Task = &taskJSON{
+			ID:          t.ID,
+			WorkspaceID: t.WorkspaceID,
+			BoardID:     t.BoardID,
+			ColumnID:    t.ColumnID,
+			Title:       t.Title,
+			Description: t.DescriptionMD,
+			Status:      t.Status,
+			Priority:    t.Priority,
+			DueAt:       dueAt,
+			Labels:      labels,
+			CreatedAt:   t.CreatedAt.UTC().Format(time.RFC3339),
+			UpdatedAt:   t.UpdatedAt.UTC().Format(time.RFC3339),
+		}
+	}
+	if c := event.Comment; c != nil {
+		p.Comment = &commentJSON{
+			ID:        c.ID,
+			TaskID:    c.TaskID,
+			Body:      c.BodyMD,
+			Author:    c.Author,
+			CreatedAt: c.CreatedAt.UTC().Format(time.RFC3339),
+		}
+	}
+	return p
+}
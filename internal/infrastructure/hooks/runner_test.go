@@ -0,0 +1,86 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+func writeHook(t *testing.T, dir, name, script string, mode os.FileMode) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"+script), mode); err != nil {
+		t.Fatalf("write hook: %v", err)
+	}
+}
+
+func TestRunner_PassesEventAndReportsFailures(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks are shell scripts")
+	}
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out")
+	writeHook(t, dir, domain.HookTaskMoved, `cat > "$OUT"; echo "$KANJI_HOOK $KANJI_TASK_ID $KANJI_BOARD_ID $KANJI_DB_PATH" >> "$OUT"`, 0o755)
+	writeHook(t, dir, domain.HookPreTaskMove, "echo 'needs a label' >&2; exit 1", 0o755)
+	writeHook(t, dir, domain.HookTaskDeleted, "exit 3", 0o755)
+	writeHook(t, dir, domain.HookTaskCreated, "exit 1", 0o644)
+
+	var warnings bytes.Buffer
+	runner := NewRunner(dir, []string{"OUT=" + out, "KANJI_DB_PATH=/tmp/k.db"}, &warnings)
+	ctx := context.Background()
+	board := "b1"
+	task := &domain.Task{ID: "t1", WorkspaceID: "w1", BoardID: &board, Title: "Ship", Labels: []string{"ready"}}
+
+	if err := runner.Run(ctx, domain.HookEvent{Hook: domain.HookTaskMoved, WorkspaceID: "w1", Task: task}); err != nil {
+		t.Fatalf("run task-moved: %v", err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read hook output: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 || lines[1] != "task-moved t1 b1 /tmp/k.db" {
+		t.Fatalf("unexpected hook environment: %q", data)
+	}
+	var payload struct {
+		Hook string `json:"hook"`
+		Task struct {
+			Title  string   `json:"title"`
+			Labels []string `json:"labels"`
+		} `json:"task"`
+	}
+	if err := json.Unmarshal([]byte(lines[0]), &payload); err != nil {
+		t.Fatalf("decode stdin payload: %v", err)
+	}
+	if payload.Hook != domain.HookTaskMoved || payload.Task.Title != "Ship" || len(payload.Task.Labels) != 1 {
+		t.Fatalf("unexpected payload: %s", lines[0])
+	}
+
+	err = runner.Run(ctx, domain.HookEvent{Hook: domain.HookPreTaskMove, Task: task})
+	if err == nil || err.Error() != "needs a label" {
+		t.Fatalf("expected the hook's message as error, got %v", err)
+	}
+	if warnings.Len() != 0 {
+		t.Fatalf("pre hook failures must not be reported as warnings: %q", warnings.String())
+	}
+
+	if err := runner.Run(ctx, domain.HookEvent{Hook: domain.HookTaskDeleted, Task: task}); err == nil {
+		t.Fatal("expected task-deleted failure")
+	}
+	if !strings.Contains(warnings.String(), "task-deleted hook failed: exit status 3") {
+		t.Fatalf("expected a warning, got %q", warnings.String())
+	}
+
+	// Not executable, so not run; missing hooks are skipped too.
+	for _, hook := range []string{domain.HookTaskCreated, domain.HookPreTaskDelete} {
+		if err := runner.Run(ctx, domain.HookEvent{Hook: hook, Task: task}); err != nil {
+			t.Fatalf("%s should be skipped, got %v", hook, err)
+		}
+	}
+}
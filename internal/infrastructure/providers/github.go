@@ -0,0 +1,506 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+const (
+	gitHubDefaultAPIURL       = "https://api.github.com"
+	gitHubDefaultClosedColumn = "Done"
+	gitHubPageSize            = 100
+)
+
+// GitHubConfig is the JSON stored in providers.auth_json for GitHub
+// providers.
+type GitHubConfig struct {
+	Token string `json:"token"`
+	// APIURL overrides the REST endpoint, e.g. for GitHub Enterprise.
+	APIURL string `json:"api_url,omitempty"`
+	// ClosedColumn names the column that maps to closed issues. Moving a task
+	// there closes its issue; issues closed on GitHub land there. Defaults to
+	// "Done".
+	ClosedColumn string `json:"closed_column,omitempty"`
+}
+
+// GitHubProvider syncs boards with GitHub repositories through the REST API.
+//
+// A board maps to a repository ("owner/repo", linked with `kanji sync link`),
+// columns map to labels named after the column, tasks map to issues keyed by
+// issue number, and comments map to issue comments keyed by comment ID.
+// Deleting a task closes its issue as "not planned"; repositories and issues
+// are never deleted.
+type GitHubProvider struct {
+	Unsupported
+	name   string
+	config GitHubConfig
+	http   *http.Client
+}
+
+// NewGitHubProvider builds a GitHub client. A nil httpClient uses a client
+// with a 30 second timeout.
+func NewGitHubProvider(name string, config GitHubConfig, httpClient *http.Client) (*GitHubProvider, error) {
+	if strings.TrimSpace(config.Token) == "" {
+		return nil, errors.New("github provider requires a token in auth_json")
+	}
+	if strings.TrimSpace(config.APIURL) == "" {
+		config.APIURL = gitHubDefaultAPIURL
+	}
+	config.APIURL = strings.TrimRight(config.APIURL, "/")
+	if strings.TrimSpace(config.ClosedColumn) == "" {
+		config.ClosedColumn = gitHubDefaultClosedColumn
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &GitHubProvider{name: name, config: config, http: httpClient}, nil
+}
+
+// newGitHubFromProvider builds a GitHub client from a configured provider row.
+func newGitHubFromProvider(provider domain.Provider) (domain.ProviderClient, error) {
+	var config GitHubConfig
+	if provider.AuthJSON != nil {
+		if err := json.Unmarshal([]byte(*provider.AuthJSON), &config); err != nil {
+			return nil, fmt.Errorf("decode github auth: %w", err)
+		}
+	}
+	return NewGitHubProvider(provider.Name, config, nil)
+}
+
+func (g *GitHubProvider) Type() string {
+	return "github"
+}
+
+func (g *GitHubProvider) Name() string {
+	if g.name == "" {
+		return "GitHub"
+	}
+	return g.name
+}
+
+func (g *GitHubProvider) Capabilities() domain.ProviderCapabilities {
+	return domain.ProviderCapabilities{
+		Pull: true,
+		Push: true,
+		Entities: []string{
+			domain.SyncEntityBoard,
+			domain.SyncEntityColumn,
+			domain.SyncEntityTask,
+			domain.SyncEntityComment,
+		},
+		TaskFields: []string{
+			domain.TaskFieldTitle,
+			domain.TaskFieldDescription,
+			domain.TaskFieldColumn,
+			domain.TaskFieldLabels,
+		},
+	}
+}
+
+// Test checks the token by fetching the authenticated user.
+func (g *GitHubProvider) Test(ctx context.Context) error {
+	return g.do(ctx, http.MethodGet, "/user", nil, nil)
+}
+
+type gitHubRepo struct {
+	Name     string `json:"name"`
+	FullName string `json:"full_name"`
+}
+
+type gitHubLabel struct {
+	Name string `json:"name"`
+}
+
+type gitHubUser struct {
+	Login string `json:"login"`
+}
+
+type gitHubIssue struct {
+	Number      int             `json:"number"`
+	Title       string          `json:"title"`
+	Body        *string         `json:"body"`
+	State       string          `json:"state"`
+	StateReason *string         `json:"state_reason"`
+	Labels      []gitHubLabel   `json:"labels"`
+	PullRequest json.RawMessage `json:"pull_request,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+type gitHubComment struct {
+	ID        int64      `json:"id"`
+	Body      string     `json:"body"`
+	User      gitHubUser `json:"user"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// PullBoard fetches the repository a board is linked to.
+func (g *GitHubProvider) PullBoard(ctx context.Context, remoteID string) (domain.Board, error) {
+	var repo gitHubRepo
+	if err := g.do(ctx, http.MethodGet, "/repos/"+remoteID, nil, &repo); err != nil {
+		return domain.Board{}, err
+	}
+	fullName := repo.FullName
+	return domain.Board{RemoteID: &fullName, Name: repo.Name}, nil
+}
+
+// PushBoard only validates the link: boards are created locally and linked to
+// an existing repository, and repositories are never renamed or deleted.
+func (g *GitHubProvider) PushBoard(ctx context.Context, action string, board domain.Board, refs domain.RemoteRefs) (string, error) {
+	if action == domain.SyncActionDelete {
+		return "", nil
+	}
+	if refs.Board == "" {
+		return "", errBoardNotLinked(board.Name)
+	}
+	if _, err := g.PullBoard(ctx, refs.Board); err != nil {
+		return "", err
+	}
+	return refs.Board, nil
+}
+
+// PushColumn keeps a label named after the column in the board's repository.
+func (g *GitHubProvider) PushColumn(ctx context.Context, action string, column domain.Column, refs domain.RemoteRefs) (string, error) {
+	if refs.Board == "" {
+		return "", errBoardNotLinked("")
+	}
+	labels := "/repos/" + refs.Board + "/labels"
+
+	if action == domain.SyncActionDelete {
+		if refs.Column == "" {
+			return "", nil
+		}
+		err := g.do(ctx, http.MethodDelete, labels+"/"+url.PathEscape(refs.Column), nil, nil)
+		if isGitHubStatus(err, http.StatusNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	color := strings.TrimPrefix(column.Color, "#")
+	if refs.Column != "" {
+		err := g.do(ctx, http.MethodPatch, labels+"/"+url.PathEscape(refs.Column), map[string]string{
+			"new_name": column.Name,
+			"color":    color,
+		}, nil)
+		if err == nil {
+			return column.Name, nil
+		}
+		if !isGitHubStatus(err, http.StatusNotFound) {
+			return "", err
+		}
+	}
+
+	err := g.do(ctx, http.MethodPost, labels, map[string]string{
+		"name":  column.Name,
+		"color": color,
+	}, nil)
+	if isGitHubStatus(err, http.StatusUnprocessableEntity) {
+		// The label already exists: adopt it.
+		err = g.do(ctx, http.MethodPatch, labels+"/"+url.PathEscape(column.Name), map[string]string{
+			"color": color,
+		}, nil)
+	}
+	if err != nil {
+		return "", err
+	}
+	return column.Name, nil
+}
+
+// ListTasks returns the repository's issues. Pull requests and issues closed
+// as "not planned" (which is how deleted tasks are closed) are left out;
+// other closed issues report the closed column as their status.
+func (g *GitHubProvider) ListTasks(ctx context.Context, boardRemoteID string) ([]domain.Task, error) {
+	var tasks []domain.Task
+	for page := 1; ; page++ {
+		var issues []gitHubIssue
+		path := fmt.Sprintf("/repos/%s/issues?state=all&per_page=%d&page=%d", boardRemoteID, gitHubPageSize, page)
+		if err := g.do(ctx, http.MethodGet, path, nil, &issues); err != nil {
+			return nil, err
+		}
+		for _, issue := range issues {
+			if len(issue.PullRequest) > 0 {
+				continue
+			}
+			if issue.State == "closed" && issue.StateReason != nil && *issue.StateReason == "not_planned" {
+				continue
+			}
+			tasks = append(tasks, g.issueToTask(issue))
+		}
+		if len(issues) < gitHubPageSize {
+			return tasks, nil
+		}
+	}
+}
+
+func (g *GitHubProvider) PullTask(ctx context.Context, boardRemoteID, remoteID string) (domain.Task, error) {
+	var issue gitHubIssue
+	if err := g.do(ctx, http.MethodGet, "/repos/"+boardRemoteID+"/issues/"+remoteID, nil, &issue); err != nil {
+		return domain.Task{}, err
+	}
+	return g.issueToTask(issue), nil
+}
+
+func (g *GitHubProvider) issueToTask(issue gitHubIssue) domain.Task {
+	remoteID := strconv.Itoa(issue.Number)
+	task := domain.Task{
+		RemoteID:  &remoteID,
+		Title:     issue.Title,
+		Labels:    make([]string, 0, len(issue.Labels)),
+		CreatedAt: issue.CreatedAt,
+		UpdatedAt: issue.UpdatedAt,
+	}
+	if issue.Body != nil {
+		task.DescriptionMD = *issue.Body
+	}
+	for _, label := range issue.Labels {
+		task.Labels = append(task.Labels, label.Name)
+	}
+	if issue.State == "closed" {
+		closed := g.config.ClosedColumn
+		task.Status = &closed
+	}
+	return task
+}
+
+// PushTask creates or edits the task's issue. The issue carries the column
+// label followed by the task labels; moving into the closed column closes it.
+func (g *GitHubProvider) PushTask(ctx context.Context, action string, task domain.Task, refs domain.RemoteRefs) (string, error) {
+	if refs.Board == "" {
+		return "", errBoardNotLinked("")
+	}
+	issues := "/repos/" + refs.Board + "/issues"
+
+	if action == domain.SyncActionDelete {
+		if refs.Task == "" {
+			return "", nil
+		}
+		err := g.do(ctx, http.MethodPatch, issues+"/"+refs.Task, map[string]string{
+			"state":        "closed",
+			"state_reason": "not_planned",
+		}, nil)
+		if isGitHubStatus(err, http.StatusNotFound, http.StatusGone) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	labels := make([]string, 0, len(task.Labels)+1)
+	if refs.Column != "" {
+		labels = append(labels, refs.Column)
+	}
+	for _, label := range task.Labels {
+		if !containsFold(labels, label) {
+			labels = append(labels, label)
+		}
+	}
+	state := "open"
+	if refs.Column != "" && strings.EqualFold(refs.Column, g.config.ClosedColumn) {
+		state = "closed"
+	}
+
+	number := refs.Task
+	if number == "" {
+		var created gitHubIssue
+		if err := g.do(ctx, http.MethodPost, issues, map[string]any{
+			"title":  task.Title,
+			"body":   task.DescriptionMD,
+			"labels": labels,
+		}, &created); err != nil {
+			return "", err
+		}
+		number = strconv.Itoa(created.Number)
+		if state == "open" {
+			return number, nil
+		}
+	}
+
+	if err := g.do(ctx, http.MethodPatch, issues+"/"+number, map[string]any{
+		"title":  task.Title,
+		"body":   task.DescriptionMD,
+		"labels": labels,
+		"state":  state,
+	}, nil); err != nil {
+		return number, err
+	}
+	return number, nil
+}
+
+func (g *GitHubProvider) ListComments(ctx context.Context, boardRemoteID, taskRemoteID string) ([]domain.Comment, error) {
+	var comments []domain.Comment
+	for page := 1; ; page++ {
+		var items []gitHubComment
+		path := fmt.Sprintf("/repos/%s/issues/%s/comments?per_page=%d&page=%d", boardRemoteID, taskRemoteID, gitHubPageSize, page)
+		if err := g.do(ctx, http.MethodGet, path, nil, &items); err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			comments = append(comments, gitHubCommentToDomain(item))
+		}
+		if len(items) < gitHubPageSize {
+			return comments, nil
+		}
+	}
+}
+
+func (g *GitHubProvider) PullComment(ctx context.Context, boardRemoteID, remoteID string) (domain.Comment, error) {
+	var item gitHubComment
+	if err := g.do(ctx, http.MethodGet, "/repos/"+boardRemoteID+"/issues/comments/"+remoteID, nil, &item); err != nil {
+		return domain.Comment{}, err
+	}
+	return gitHubCommentToDomain(item), nil
+}
+
+func gitHubCommentToDomain(item gitHubComment) domain.Comment {
+	remoteID := strconv.FormatInt(item.ID, 10)
+	comment := domain.Comment{
+		RemoteID:  &remoteID,
+		BodyMD:    item.Body,
+		CreatedAt: item.CreatedAt,
+	}
+	if item.User.Login != "" {
+		author := item.User.Login
+		comment.Author = &author
+	}
+	return comment
+}
+
+// PushComment mirrors a comment onto the task's issue.
+func (g *GitHubProvider) PushComment(ctx context.Context, action string, comment domain.Comment, refs domain.RemoteRefs) (string, error) {
+	if refs.Board == "" {
+		return "", errBoardNotLinked("")
+	}
+	comments := "/repos/" + refs.Board + "/issues/comments/"
+
+	switch {
+	case action == domain.SyncActionDelete:
+		if refs.Comment == "" {
+			return "", nil
+		}
+		err := g.do(ctx, http.MethodDelete, comments+refs.Comment, nil, nil)
+		if isGitHubStatus(err, http.StatusNotFound) {
+			return "", nil
+		}
+		return "", err
+	case refs.Comment != "":
+		err := g.do(ctx, http.MethodPatch, comments+refs.Comment, map[string]string{"body": comment.BodyMD}, nil)
+		return refs.Comment, err
+	}
+
+	if refs.Task == "" {
+		return "", errors.New("task has no GitHub issue yet")
+	}
+	var created gitHubComment
+	if err := g.do(ctx, http.MethodPost, "/repos/"+refs.Board+"/issues/"+refs.Task+"/comments", map[string]string{
+		"body": comment.BodyMD,
+	}, &created); err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(created.ID, 10), nil
+}
+
+// gitHubError is a non-2xx REST response.
+type gitHubError struct {
+	Method  string
+	Path    string
+	Status  int
+	Message string
+}
+
+func (e *gitHubError) Error() string {
+	msg := fmt.Sprintf("github: %s %s: %d %s", e.Method, e.Path, e.Status, http.StatusText(e.Status))
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+func isGitHubStatus(err error, statuses ...int) bool {
+	var ghErr *gitHubError
+	if !errors.As(err, &ghErr) {
+		return false
+	}
+	for _, status := range statuses {
+		if ghErr.Status == status {
+			return true
+		}
+	}
+	return false
+}
+
+func errBoardNotLinked(name string) error {
+	if name == "" {
+		return errors.New("board is not linked to a GitHub repository; run: kanji sync link")
+	}
+	return fmt.Errorf("board %q is not linked to a GitHub repository; run: kanji sync link", name)
+}
+
+func (g *GitHubProvider) do(ctx context.Context, method, path string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, g.config.APIURL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+g.config.Token)
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := g.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("github: %s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		_ = json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&apiErr)
+		return &gitHubError{Method: method, Path: stripQuery(path), Status: resp.StatusCode, Message: apiErr.Message}
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("github: decode %s %s: %w", method, path, err)
+	}
+	return nil
+}
+
+func stripQuery(path string) string {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		return path[:i]
+	}
+	return path
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,373 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+// fakeGitHub is an in-memory stand-in for the parts of the GitHub REST API
+// the provider uses.
+type fakeGitHub struct {
+	mu       sync.Mutex
+	token    string
+	labels   map[string]string // name -> color
+	issues   map[int]map[string]any
+	comments map[int64]map[string]any
+	nextID   int64
+}
+
+func newFakeGitHub(t *testing.T) (*fakeGitHub, *GitHubProvider) {
+	t.Helper()
+	gh := &fakeGitHub{
+		token:    "secret",
+		labels:   map[string]string{},
+		issues:   map[int]map[string]any{},
+		comments: map[int64]map[string]any{},
+		nextID:   100,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /user", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]any{"login": "octocat"})
+	})
+	mux.HandleFunc("GET /repos/{owner}/{repo}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("repo") != "app" {
+			writeJSON(w, http.StatusNotFound, map[string]any{"message": "Not Found"})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"name": "app", "full_name": "acme/app"})
+	})
+	mux.HandleFunc("POST /repos/{owner}/{repo}/labels", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if _, ok := gh.labels[body["name"]]; ok {
+			writeJSON(w, http.StatusUnprocessableEntity, map[string]any{"message": "Validation Failed"})
+			return
+		}
+		gh.labels[body["name"]] = body["color"]
+		writeJSON(w, http.StatusCreated, body)
+	})
+	mux.HandleFunc("PATCH /repos/{owner}/{repo}/labels/{name}", func(w http.ResponseWriter, r *http.Request) {
+		name := r.PathValue("name")
+		color, ok := gh.labels[name]
+		if !ok {
+			writeJSON(w, http.StatusNotFound, map[string]any{"message": "Not Found"})
+			return
+		}
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if c, ok := body["color"]; ok {
+			color = c
+		}
+		if newName, ok := body["new_name"]; ok {
+			delete(gh.labels, name)
+			name = newName
+		}
+		gh.labels[name] = color
+		writeJSON(w, http.StatusOK, map[string]any{"name": name})
+	})
+	mux.HandleFunc("DELETE /repos/{owner}/{repo}/labels/{name}", func(w http.ResponseWriter, r *http.Request) {
+		delete(gh.labels, r.PathValue("name"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("GET /repos/{owner}/{repo}/issues", func(w http.ResponseWriter, r *http.Request) {
+		out := []map[string]any{}
+		for n := 1; n <= len(gh.issues); n++ {
+			if issue, ok := gh.issues[n]; ok {
+				out = append(out, issue)
+			}
+		}
+		out = append(out, map[string]any{"number": 999, "title": "A pull request", "state": "open", "pull_request": map[string]any{}})
+		writeJSON(w, http.StatusOK, out)
+	})
+	mux.HandleFunc("POST /repos/{owner}/{repo}/issues", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		n := len(gh.issues) + 1
+		issue := map[string]any{
+			"number":     n,
+			"title":      body["title"],
+			"body":       body["body"],
+			"state":      "open",
+			"labels":     toLabels(body["labels"]),
+			"created_at": time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			"updated_at": time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		}
+		gh.issues[n] = issue
+		writeJSON(w, http.StatusCreated, issue)
+	})
+	mux.HandleFunc("PATCH /repos/{owner}/{repo}/issues/{number}", func(w http.ResponseWriter, r *http.Request) {
+		n, _ := strconv.Atoi(r.PathValue("number"))
+		issue, ok := gh.issues[n]
+		if !ok {
+			writeJSON(w, http.StatusNotFound, map[string]any{"message": "Not Found"})
+			return
+		}
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		for k, v := range body {
+			if k == "labels" {
+				v = toLabels(v)
+			}
+			issue[k] = v
+		}
+		writeJSON(w, http.StatusOK, issue)
+	})
+	mux.HandleFunc("GET /repos/{owner}/{repo}/issues/{number}/comments", func(w http.ResponseWriter, r *http.Request) {
+		n, _ := strconv.Atoi(r.PathValue("number"))
+		out := []map[string]any{}
+		for id := int64(100); id < gh.nextID; id++ {
+			if c, ok := gh.comments[id]; ok && c["issue"] == n {
+				out = append(out, c)
+			}
+		}
+		writeJSON(w, http.StatusOK, out)
+	})
+	mux.HandleFunc("POST /repos/{owner}/{repo}/issues/{number}/comments", func(w http.ResponseWriter, r *http.Request) {
+		n, _ := strconv.Atoi(r.PathValue("number"))
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		id := gh.nextID
+		gh.nextID++
+		c := map[string]any{"id": id, "issue": n, "body": body["body"], "user": map[string]any{"login": "octocat"}}
+		gh.comments[id] = c
+		writeJSON(w, http.StatusCreated, c)
+	})
+	mux.HandleFunc("PATCH /repos/{owner}/{repo}/issues/comments/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id, _ := strconv.ParseInt(r.PathValue("id"), 10, 64)
+		c, ok := gh.comments[id]
+		if !ok {
+			writeJSON(w, http.StatusNotFound, map[string]any{"message": "Not Found"})
+			return
+		}
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		c["body"] = body["body"]
+		writeJSON(w, http.StatusOK, c)
+	})
+	mux.HandleFunc("DELETE /repos/{owner}/{repo}/issues/comments/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id, _ := strconv.ParseInt(r.PathValue("id"), 10, 64)
+		delete(gh.comments, id)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gh.mu.Lock()
+		defer gh.mu.Unlock()
+		if r.Header.Get("Authorization") != "Bearer "+gh.token {
+			writeJSON(w, http.StatusUnauthorized, map[string]any{"message": "Bad credentials"})
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewGitHubProvider("GitHub", GitHubConfig{Token: "secret", APIURL: server.URL}, server.Client())
+	if err != nil {
+		t.Fatalf("new github provider: %v", err)
+	}
+	return gh, client
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func toLabels(v any) []map[string]any {
+	labels := []map[string]any{}
+	items, _ := v.([]any)
+	for _, item := range items {
+		switch l := item.(type) {
+		case string:
+			labels = append(labels, map[string]any{"name": l})
+		case map[string]any:
+			labels = append(labels, l)
+		}
+	}
+	return labels
+}
+
+func TestGitHubProvider_TestChecksToken(t *testing.T) {
+	gh, client := newFakeGitHub(t)
+	ctx := context.Background()
+
+	if err := client.Test(ctx); err != nil {
+		t.Fatalf("test: %v", err)
+	}
+	gh.token = "rotated"
+	err := client.Test(ctx)
+	if err == nil || !strings.Contains(err.Error(), "401") || !strings.Contains(err.Error(), "Bad credentials") {
+		t.Fatalf("expected 401 error, got %v", err)
+	}
+}
+
+func TestGitHubProvider_PushColumnManagesLabels(t *testing.T) {
+	gh, client := newFakeGitHub(t)
+	ctx := context.Background()
+	refs := domain.RemoteRefs{Board: "acme/app"}
+
+	remote, err := client.PushColumn(ctx, domain.SyncActionCreate, domain.Column{Name: "Doing", Color: "#F59E0B"}, refs)
+	if err != nil || remote != "Doing" {
+		t.Fatalf("create column = %q, %v", remote, err)
+	}
+	if gh.labels["Doing"] != "F59E0B" {
+		t.Fatalf("expected label with color, got %v", gh.labels)
+	}
+
+	// Creating an existing label adopts it.
+	if _, err := client.PushColumn(ctx, domain.SyncActionCreate, domain.Column{Name: "Doing", Color: "#000000"}, refs); err != nil {
+		t.Fatalf("adopt existing label: %v", err)
+	}
+
+	refs.Column = "Doing"
+	remote, err = client.PushColumn(ctx, domain.SyncActionUpdate, domain.Column{Name: "In Progress", Color: "#000000"}, refs)
+	if err != nil || remote != "In Progress" {
+		t.Fatalf("rename column = %q, %v", remote, err)
+	}
+	if _, ok := gh.labels["Doing"]; ok {
+		t.Fatalf("old label should be renamed, got %v", gh.labels)
+	}
+
+	refs.Column = "In Progress"
+	if _, err := client.PushColumn(ctx, domain.SyncActionDelete, domain.Column{Name: "In Progress"}, refs); err != nil {
+		t.Fatalf("delete column: %v", err)
+	}
+	if len(gh.labels) != 0 {
+		t.Fatalf("expected no labels, got %v", gh.labels)
+	}
+}
+
+func TestGitHubProvider_TaskRoundTrip(t *testing.T) {
+	gh, client := newFakeGitHub(t)
+	ctx := context.Background()
+	refs := domain.RemoteRefs{Board: "acme/app", Column: "Todo"}
+
+	task := domain.Task{Title: "Ship it", DescriptionMD: "details", Labels: []string{"bug", "todo"}}
+	number, err := client.PushTask(ctx, domain.SyncActionCreate, task, refs)
+	if err != nil || number != "1" {
+		t.Fatalf("create task = %q, %v", number, err)
+	}
+
+	refs.Task = number
+	refs.Column = "Done"
+	task.Title = "Shipped"
+	if _, err := client.PushTask(ctx, domain.SyncActionMove, task, refs); err != nil {
+		t.Fatalf("move task: %v", err)
+	}
+	if gh.issues[1]["state"] != "closed" {
+		t.Fatalf("moving into the closed column should close the issue, got %v", gh.issues[1]["state"])
+	}
+
+	tasks, err := client.ListTasks(ctx, "acme/app")
+	if err != nil {
+		t.Fatalf("list tasks: %v", err)
+	}
+	if len(tasks) != 1 {
+		t.Fatalf("expected pull requests to be skipped, got %d tasks", len(tasks))
+	}
+	got := tasks[0]
+	if *got.RemoteID != "1" || got.Title != "Shipped" || got.DescriptionMD != "details" {
+		t.Fatalf("unexpected pulled task: %+v", got)
+	}
+	if got.Status == nil || *got.Status != "Done" {
+		t.Fatalf("closed issue should report the closed column, got %v", got.Status)
+	}
+	if strings.Join(got.Labels, ",") != "Done,bug,todo" {
+		t.Fatalf("labels = %v, want column label then task labels", got.Labels)
+	}
+
+	if _, err := client.PushTask(ctx, domain.SyncActionDelete, task, refs); err != nil {
+		t.Fatalf("delete task: %v", err)
+	}
+	tasks, err = client.ListTasks(ctx, "acme/app")
+	if err != nil {
+		t.Fatalf("list tasks: %v", err)
+	}
+	if len(tasks) != 0 {
+		t.Fatalf("issues closed as not planned must not be pulled, got %+v", tasks)
+	}
+}
+
+func TestGitHubProvider_Comments(t *testing.T) {
+	_, client := newFakeGitHub(t)
+	ctx := context.Background()
+	refs := domain.RemoteRefs{Board: "acme/app"}
+
+	if _, err := client.PushComment(ctx, domain.SyncActionCreate, domain.Comment{BodyMD: "early"}, refs); err == nil {
+		t.Fatal("expected error for a task without an issue")
+	}
+
+	number, err := client.PushTask(ctx, domain.SyncActionCreate, domain.Task{Title: "Discuss"}, refs)
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	refs.Task = number
+
+	id, err := client.PushComment(ctx, domain.SyncActionCreate, domain.Comment{BodyMD: "first"}, refs)
+	if err != nil || id != "100" {
+		t.Fatalf("create comment = %q, %v", id, err)
+	}
+	refs.Comment = id
+	if _, err := client.PushComment(ctx, domain.SyncActionUpdate, domain.Comment{BodyMD: "edited"}, refs); err != nil {
+		t.Fatalf("update comment: %v", err)
+	}
+
+	comments, err := client.ListComments(ctx, "acme/app", number)
+	if err != nil {
+		t.Fatalf("list comments: %v", err)
+	}
+	if len(comments) != 1 || comments[0].BodyMD != "edited" || *comments[0].Author != "octocat" {
+		t.Fatalf("unexpected comments: %+v", comments)
+	}
+
+	if _, err := client.PushComment(ctx, domain.SyncActionDelete, domain.Comment{}, refs); err != nil {
+		t.Fatalf("delete comment: %v", err)
+	}
+	comments, err = client.ListComments(ctx, "acme/app", number)
+	if err != nil || len(comments) != 0 {
+		t.Fatalf("expected no comments, got %+v, %v", comments, err)
+	}
+}
+
+func TestGitHubProvider_PushBoardRequiresLink(t *testing.T) {
+	_, client := newFakeGitHub(t)
+	ctx := context.Background()
+
+	_, err := client.PushBoard(ctx, domain.SyncActionCreate, domain.Board{Name: "Main"}, domain.RemoteRefs{})
+	if err == nil || !strings.Contains(err.Error(), "kanji sync link") {
+		t.Fatalf("expected link hint, got %v", err)
+	}
+	if _, err := client.PushBoard(ctx, domain.SyncActionUpdate, domain.Board{Name: "Main"}, domain.RemoteRefs{Board: "acme/missing"}); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Fatalf("expected 404 for an unknown repository, got %v", err)
+	}
+	remote, err := client.PushBoard(ctx, domain.SyncActionUpdate, domain.Board{Name: "Main"}, domain.RemoteRefs{Board: "acme/app"})
+	if err != nil || remote != "acme/app" {
+		t.Fatalf("push linked board = %q, %v", remote, err)
+	}
+}
+
+func TestRegistry_GitHubRequiresToken(t *testing.T) {
+	registry := DefaultRegistry()
+	if _, err := registry.Client(domain.Provider{Type: "github", Name: "GH"}); err == nil {
+		t.Fatal("expected missing token error")
+	}
+	auth := `{"token":"abc"}`
+	client, err := registry.Client(domain.Provider{Type: "GitHub", Name: "GH", AuthJSON: &auth})
+	if err != nil {
+		t.Fatalf("build client: %v", err)
+	}
+	if client.Type() != "github" || client.Name() != "GH" {
+		t.Fatalf("unexpected client %s/%s", client.Type(), client.Name())
+	}
+}
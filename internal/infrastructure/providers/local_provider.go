@@ -1,8 +1,18 @@
 package providers
 
-import "github.com/tiagokriok/kanji/internal/domain"
+import (
+	"context"
 
-type LocalProvider struct{}
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+// LocalProvider is the built-in provider for data that lives only in the
+// local database. It has nothing to pull and advertises no capabilities;
+// its changes are not queued for sync, and the sync engine acknowledges any
+// queued before that without pushing them.
+type LocalProvider struct {
+	Unsupported
+}
 
 func NewLocalProvider() domain.ProviderClient {
 	return LocalProvider{}
@@ -15,3 +25,11 @@ func (LocalProvider) Type() string {
 func (LocalProvider) Name() string {
 	return "Local"
 }
+
+func (LocalProvider) Capabilities() domain.ProviderCapabilities {
+	return domain.ProviderCapabilities{}
+}
+
+func (LocalProvider) Test(context.Context) error {
+	return nil
+}
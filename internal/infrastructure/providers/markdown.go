@@ -0,0 +1,612 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+const (
+	markdownDateLayout      = "2006-01-02"
+	markdownDefaultPriority = 3
+)
+
+// MarkdownConfig is the JSON stored in providers.auth_json for markdown
+// providers. It is optional.
+type MarkdownConfig struct {
+	// Root is the directory relative board folders are resolved against,
+	// e.g. a git repository or Obsidian vault.
+	Root string `json:"root,omitempty"`
+}
+
+// MarkdownProvider mirrors boards as folders of markdown files.
+//
+// A board maps to a folder (linked with `kanji sync link`) and every task to
+// one file in it, keyed by its slash-separated path relative to the folder.
+// The YAML front matter carries title, status (the column name), priority,
+// due, labels and updated_at; the body is the task description. Front matter
+// keys kanji does not know are preserved.
+//
+// A push leaves a file alone when it changed after the local edit (its
+// modification time or updated_at is newer) and reports
+// domain.ErrRemoteChanged, so the sync engine merges the file instead.
+type MarkdownProvider struct {
+	Unsupported
+	name   string
+	config MarkdownConfig
+}
+
+// NewMarkdownProvider builds a markdown folder client.
+func NewMarkdownProvider(name string, config MarkdownConfig) *MarkdownProvider {
+	return &MarkdownProvider{name: name, config: config}
+}
+
+// newMarkdownFromProvider builds a markdown client from a configured provider
+// row.
+func newMarkdownFromProvider(provider domain.Provider) (domain.ProviderClient, error) {
+	var config MarkdownConfig
+	if provider.AuthJSON != nil {
+		if err := json.Unmarshal([]byte(*provider.AuthJSON), &config); err != nil {
+			return nil, fmt.Errorf("decode markdown config: %w", err)
+		}
+	}
+	return NewMarkdownProvider(provider.Name, config), nil
+}
+
+func (m *MarkdownProvider) Type() string {
+	return "markdown"
+}
+
+func (m *MarkdownProvider) Name() string {
+	if m.name == "" {
+		return "Markdown"
+	}
+	return m.name
+}
+
+func (m *MarkdownProvider) Capabilities() domain.ProviderCapabilities {
+	return domain.ProviderCapabilities{
+		Pull: true,
+		Push: true,
+		Entities: []string{
+			domain.SyncEntityBoard,
+			domain.SyncEntityTask,
+		},
+		TaskFields: []string{
+			domain.TaskFieldTitle,
+			domain.TaskFieldDescription,
+			domain.TaskFieldColumn,
+			domain.TaskFieldLabels,
+			domain.TaskFieldPriority,
+			domain.TaskFieldDue,
+		},
+	}
+}
+
+// Test checks that the configured root, if any, is a directory.
+func (m *MarkdownProvider) Test(ctx context.Context) error {
+	if m.config.Root == "" {
+		return nil
+	}
+	info, err := os.Stat(m.config.Root)
+	if err != nil {
+		return fmt.Errorf("markdown root: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("markdown root %s is not a directory", m.config.Root)
+	}
+	return nil
+}
+
+// PullBoard checks that the board folder exists.
+func (m *MarkdownProvider) PullBoard(ctx context.Context, remoteID string) (domain.Board, error) {
+	dir, err := m.boardDir(remoteID)
+	if err != nil {
+		return domain.Board{}, err
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return domain.Board{}, err
+	}
+	if !info.IsDir() {
+		return domain.Board{}, fmt.Errorf("%s is not a directory", dir)
+	}
+	return domain.Board{RemoteID: &remoteID, Name: filepath.Base(dir)}, nil
+}
+
+// PushBoard creates the folder of a linked board. Boards are never deleted
+// on disk.
+func (m *MarkdownProvider) PushBoard(ctx context.Context, action string, board domain.Board, refs domain.RemoteRefs) (string, error) {
+	if action == domain.SyncActionDelete {
+		return refs.Board, nil
+	}
+	if refs.Board == "" {
+		return "", errFolderNotLinked(board.Name)
+	}
+	dir, err := m.boardDir(refs.Board)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	return refs.Board, nil
+}
+
+// ListTasks reads every markdown file under the board folder. Hidden
+// directories such as .git and .obsidian are skipped. Files that cannot be
+// read are left out and reported in a *domain.PartialListError returned
+// with the other tasks.
+func (m *MarkdownProvider) ListTasks(ctx context.Context, boardRemoteID string) ([]domain.Task, error) {
+	dir, err := m.boardDir(boardRemoteID)
+	if err != nil {
+		return nil, err
+	}
+	var tasks []domain.Task
+	var skipped []error
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.EqualFold(filepath.Ext(path), ".md") {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		task, err := readMarkdownTask(path, filepath.ToSlash(rel))
+		if err != nil {
+			skipped = append(skipped, err)
+			return ctx.Err()
+		}
+		tasks = append(tasks, task)
+		return ctx.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(skipped) > 0 {
+		return tasks, &domain.PartialListError{Items: skipped}
+	}
+	return tasks, nil
+}
+
+func (m *MarkdownProvider) PullTask(ctx context.Context, boardRemoteID, remoteID string) (domain.Task, error) {
+	path, err := m.taskPath(boardRemoteID, remoteID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	return readMarkdownTask(path, remoteID)
+}
+
+// PushTask writes a task to its file. New tasks get a file named after their
+// title; deleting a task removes its file.
+func (m *MarkdownProvider) PushTask(ctx context.Context, action string, task domain.Task, refs domain.RemoteRefs) (string, error) {
+	if refs.Board == "" {
+		if action == domain.SyncActionDelete {
+			return refs.Task, nil
+		}
+		return "", errFolderNotLinked("")
+	}
+	dir, err := m.boardDir(refs.Board)
+	if err != nil {
+		return "", err
+	}
+
+	if action == domain.SyncActionDelete {
+		if refs.Task == "" {
+			return "", nil
+		}
+		path, err := m.taskPath(refs.Board, refs.Task)
+		if err != nil {
+			return "", err
+		}
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+		return refs.Task, nil
+	}
+
+	remoteID := refs.Task
+	if remoteID == "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return "", err
+		}
+		remoteID = uniqueMarkdownName(dir, task.Title)
+	}
+	path, err := m.taskPath(refs.Board, remoteID)
+	if err != nil {
+		return "", err
+	}
+
+	file := markdownFile{}
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		file, err = parseMarkdown(data)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", remoteID, err)
+		}
+		modified, err := markdownModTime(path, file)
+		if err != nil {
+			return "", err
+		}
+		if modified.After(task.UpdatedAt) {
+			// The file changed after the local edit; keep it for the
+			// engine to merge.
+			return remoteID, fmt.Errorf("%s: %w", remoteID, domain.ErrRemoteChanged)
+		}
+	case !errors.Is(err, fs.ErrNotExist):
+		return "", err
+	}
+
+	if err := file.setTask(task); err != nil {
+		return "", err
+	}
+	out, err := file.render()
+	if err != nil {
+		return "", err
+	}
+	if err := writeFileAtomic(path, out); err != nil {
+		return "", err
+	}
+	if !task.UpdatedAt.IsZero() {
+		if err := os.Chtimes(path, task.UpdatedAt, task.UpdatedAt); err != nil {
+			return remoteID, err
+		}
+	}
+	return remoteID, nil
+}
+
+// boardDir resolves a board remote ID to a folder. Relative folders need a
+// configured root.
+func (m *MarkdownProvider) boardDir(remoteID string) (string, error) {
+	if remoteID == "" {
+		return "", errFolderNotLinked("")
+	}
+	if filepath.IsAbs(remoteID) {
+		return filepath.Clean(remoteID), nil
+	}
+	if m.config.Root == "" {
+		return "", fmt.Errorf("board folder %q is relative; link an absolute path or set root in auth_json", remoteID)
+	}
+	return filepath.Join(m.config.Root, remoteID), nil
+}
+
+// taskPath resolves a task remote ID inside its board folder, refusing paths
+// that escape it.
+func (m *MarkdownProvider) taskPath(boardRemoteID, remoteID string) (string, error) {
+	dir, err := m.boardDir(boardRemoteID)
+	if err != nil {
+		return "", err
+	}
+	rel := filepath.Clean(filepath.FromSlash(remoteID))
+	if remoteID == "" || filepath.IsAbs(rel) || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid task path %q", remoteID)
+	}
+	return filepath.Join(dir, rel), nil
+}
+
+func errFolderNotLinked(name string) error {
+	if name == "" {
+		return errors.New("board is not linked to a folder; run: kanji sync link")
+	}
+	return fmt.Errorf("board %q is not linked to a folder; run: kanji sync link", name)
+}
+
+// markdownFrontMatter is the part of the front matter kanji reads.
+type markdownFrontMatter struct {
+	Title     string   `yaml:"title"`
+	Status    string   `yaml:"status"`
+	Priority  *int     `yaml:"priority"`
+	Due       string   `yaml:"due"`
+	Labels    []string `yaml:"labels"`
+	UpdatedAt string   `yaml:"updated_at"`
+}
+
+// markdownFile is a parsed task file. meta keeps the whole front matter so
+// unknown keys survive a rewrite.
+type markdownFile struct {
+	meta *yaml.Node
+	body string
+}
+
+func readMarkdownTask(path, remoteID string) (domain.Task, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	file, err := parseMarkdown(data)
+	if err != nil {
+		return domain.Task{}, fmt.Errorf("%s: %w", remoteID, err)
+	}
+	var fm markdownFrontMatter
+	if file.meta != nil {
+		if err := file.meta.Decode(&fm); err != nil {
+			return domain.Task{}, fmt.Errorf("%s: front matter: %w", remoteID, err)
+		}
+	}
+	modified, err := markdownModTime(path, file)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	task := domain.Task{
+		RemoteID:      &remoteID,
+		Title:         strings.TrimSpace(fm.Title),
+		DescriptionMD: file.body,
+		Priority:      markdownDefaultPriority,
+		Labels:        fm.Labels,
+		UpdatedAt:     modified,
+	}
+	if task.Title == "" {
+		task.Title = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	if status := strings.TrimSpace(fm.Status); status != "" {
+		task.Status = &status
+	}
+	if fm.Priority != nil {
+		task.Priority = *fm.Priority
+	}
+	if task.Labels == nil {
+		task.Labels = []string{}
+	}
+	if due := strings.TrimSpace(fm.Due); due != "" {
+		dueAt, err := parseMarkdownDue(due)
+		if err != nil {
+			return domain.Task{}, fmt.Errorf("%s: %w", remoteID, err)
+		}
+		task.DueAt = &dueAt
+	}
+	return task, nil
+}
+
+// parseMarkdown splits a file into front matter and body. Files without
+// front matter are all body.
+func parseMarkdown(data []byte) (markdownFile, error) {
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	if !strings.HasPrefix(text, "---\n") {
+		return markdownFile{body: trimMarkdownBody(text)}, nil
+	}
+	rest := text[len("---\n"):]
+	end := -1
+	for offset := 0; offset <= len(rest); {
+		line, _, _ := strings.Cut(rest[offset:], "\n")
+		if line == "---" || line == "..." {
+			end = offset
+			break
+		}
+		next := strings.IndexByte(rest[offset:], '\n')
+		if next < 0 {
+			break
+		}
+		offset += next + 1
+	}
+	if end < 0 {
+		return markdownFile{}, errors.New("front matter is not closed")
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(rest[:end]), &doc); err != nil {
+		return markdownFile{}, fmt.Errorf("front matter: %w", err)
+	}
+	file := markdownFile{}
+	if len(doc.Content) > 0 {
+		if doc.Content[0].Kind != yaml.MappingNode {
+			return markdownFile{}, errors.New("front matter must be a mapping")
+		}
+		file.meta = doc.Content[0]
+	}
+	body := rest[end:]
+	if i := strings.IndexByte(body, '\n'); i >= 0 {
+		body = body[i+1:]
+	} else {
+		body = ""
+	}
+	file.body = trimMarkdownBody(body)
+	return file, nil
+}
+
+func trimMarkdownBody(body string) string {
+	return strings.TrimRight(strings.TrimLeft(body, "\n"), " \t\n")
+}
+
+// setTask stores the task fields in the front matter and body.
+func (f *markdownFile) setTask(task domain.Task) error {
+	if f.meta == nil {
+		f.meta = &yaml.Node{Kind: yaml.MappingNode}
+	}
+	status := ""
+	if task.Status != nil {
+		status = *task.Status
+	}
+	labels := task.Labels
+	if labels == nil {
+		labels = []string{}
+	}
+	fields := []struct {
+		key   string
+		value any
+	}{
+		{"title", task.Title},
+		{"status", status},
+		{"priority", task.Priority},
+		{"due", formatMarkdownDue(task.DueAt)},
+		{"labels", labels},
+		{"updated_at", task.UpdatedAt.UTC().Format(time.RFC3339)},
+	}
+	for _, field := range fields {
+		if s, ok := field.value.(string); ok && s == "" {
+			removeMetaKey(f.meta, field.key)
+			continue
+		}
+		if err := setMetaKey(f.meta, field.key, field.value); err != nil {
+			return err
+		}
+	}
+	f.body = trimMarkdownBody(task.DescriptionMD)
+	return nil
+}
+
+func (f markdownFile) render() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	if f.meta != nil && len(f.meta.Content) > 0 {
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(f.meta); err != nil {
+			return nil, err
+		}
+		if err := enc.Close(); err != nil {
+			return nil, err
+		}
+	}
+	buf.WriteString("---\n")
+	if f.body != "" {
+		buf.WriteString("\n")
+		buf.WriteString(f.body)
+		buf.WriteString("\n")
+	}
+	return buf.Bytes(), nil
+}
+
+func setMetaKey(meta *yaml.Node, key string, value any) error {
+	var node yaml.Node
+	if err := node.Encode(value); err != nil {
+		return err
+	}
+	for i := 0; i+1 < len(meta.Content); i += 2 {
+		if meta.Content[i].Value == key {
+			meta.Content[i+1] = &node
+			return nil
+		}
+	}
+	meta.Content = append(meta.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+		&node,
+	)
+	return nil
+}
+
+func removeMetaKey(meta *yaml.Node, key string) {
+	for i := 0; i+1 < len(meta.Content); i += 2 {
+		if meta.Content[i].Value == key {
+			meta.Content = append(meta.Content[:i], meta.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+// markdownModTime is the later of the file's modification time and its
+// updated_at front matter.
+func markdownModTime(path string, file markdownFile) (time.Time, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	modified := info.ModTime().UTC()
+	if file.meta != nil {
+		var fm markdownFrontMatter
+		if err := file.meta.Decode(&fm); err == nil && fm.UpdatedAt != "" {
+			if t, err := time.Parse(time.RFC3339, fm.UpdatedAt); err == nil && t.After(modified) {
+				modified = t.UTC()
+			}
+		}
+	}
+	return modified, nil
+}
+
+// parseMarkdownDue accepts YYYY-MM-DD (end of day UTC, like the CLI) or
+// RFC3339.
+func parseMarkdownDue(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+	if t, err := time.Parse(markdownDateLayout, value); err == nil {
+		return t.Add(23*time.Hour + 59*time.Minute + 59*time.Second).UTC(), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid due %q; use YYYY-MM-DD or RFC3339", value)
+}
+
+func formatMarkdownDue(due *time.Time) string {
+	if due == nil {
+		return ""
+	}
+	t := due.UTC()
+	if t.Hour() == 23 && t.Minute() == 59 && t.Second() == 59 {
+		return t.Format(markdownDateLayout)
+	}
+	return t.Format(time.RFC3339)
+}
+
+// uniqueMarkdownName returns a file name derived from title that does not
+// exist yet in dir.
+func uniqueMarkdownName(dir, title string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(title) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	slug := strings.TrimRight(b.String(), "-")
+	if slug == "" {
+		slug = "task"
+	}
+	name := slug + ".md"
+	for i := 2; ; i++ {
+		if _, err := os.Stat(filepath.Join(dir, name)); errors.Is(err, fs.ErrNotExist) {
+			return name
+		}
+		name = fmt.Sprintf("%s-%d.md", slug, i)
+	}
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames it
+// into place so readers never see a partial file.
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".kanji-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,185 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+func TestMarkdownProvider_TaskRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	client := NewMarkdownProvider("Notes", MarkdownConfig{})
+	ctx := context.Background()
+	refs := domain.RemoteRefs{Board: dir}
+
+	status := "in progress"
+	due := time.Date(2024, 5, 1, 23, 59, 59, 0, time.UTC)
+	updated := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	task := domain.Task{
+		Title:         "Fix login: again",
+		DescriptionMD: "Steps\n\n- one\n",
+		Status:        &status,
+		Priority:      1,
+		DueAt:         &due,
+		Labels:        []string{"bug"},
+		UpdatedAt:     updated,
+	}
+	remoteID, err := client.PushTask(ctx, domain.SyncActionCreate, task, refs)
+	if err != nil || remoteID != "fix-login-again.md" {
+		t.Fatalf("create task = %q, %v", remoteID, err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, remoteID))
+	if err != nil {
+		t.Fatalf("read file: %v", err)
+	}
+	for _, want := range []string{"title: 'Fix login: again'", "status: in progress", "priority: 1", "due: \"2024-05-01\"", "- bug", "updated_at: \"2024-01-02T03:04:05Z\"", "\n---\n\nSteps\n\n- one\n"} {
+		if !strings.Contains(string(data), want) {
+			t.Fatalf("file missing %q:\n%s", want, data)
+		}
+	}
+
+	tasks, err := client.ListTasks(ctx, dir)
+	if err != nil {
+		t.Fatalf("list tasks: %v", err)
+	}
+	if len(tasks) != 1 {
+		t.Fatalf("expected 1 task, got %d", len(tasks))
+	}
+	got := tasks[0]
+	if *got.RemoteID != remoteID || got.Title != task.Title || got.DescriptionMD != "Steps\n\n- one" {
+		t.Fatalf("unexpected pulled task: %+v", got)
+	}
+	if got.Status == nil || *got.Status != status || got.Priority != 1 || got.DueAt == nil || !got.DueAt.Equal(due) {
+		t.Fatalf("unexpected pulled fields: %+v", got)
+	}
+	if !got.UpdatedAt.Equal(updated) {
+		t.Fatalf("updated_at = %v, want %v", got.UpdatedAt, updated)
+	}
+
+	refs.Task = remoteID
+	if _, err := client.PushTask(ctx, domain.SyncActionDelete, task, refs); err != nil {
+		t.Fatalf("delete task: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, remoteID)); !os.IsNotExist(err) {
+		t.Fatalf("expected file to be removed, got %v", err)
+	}
+}
+
+func TestMarkdownProvider_PreservesUnknownFrontMatter(t *testing.T) {
+	dir := t.TempDir()
+	client := NewMarkdownProvider("Notes", MarkdownConfig{})
+	ctx := context.Background()
+
+	path := filepath.Join(dir, "note.md")
+	if err := os.WriteFile(path, []byte("---\naliases: [n1]\nstatus: todo\ndue: 2024-05-01\n---\nbody\n"), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	old := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+
+	tasks, err := client.ListTasks(ctx, dir)
+	if err != nil || len(tasks) != 1 {
+		t.Fatalf("list tasks = %+v, %v", tasks, err)
+	}
+	if tasks[0].DueAt == nil || tasks[0].DueAt.Format("2006-01-02") != "2024-05-01" {
+		t.Fatalf("expected unquoted due date to parse, got %v", tasks[0].DueAt)
+	}
+	if tasks[0].Title != "note" || tasks[0].Priority != 3 || len(tasks[0].Labels) != 0 {
+		t.Fatalf("expected defaults for missing keys, got %+v", tasks[0])
+	}
+
+	status := "done"
+	task := domain.Task{Title: "Note", DescriptionMD: "new body", Status: &status, Priority: 2, UpdatedAt: old.Add(time.Hour)}
+	if _, err := client.PushTask(ctx, domain.SyncActionUpdate, task, domain.RemoteRefs{Board: dir, Task: "note.md"}); err != nil {
+		t.Fatalf("update task: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), "aliases: [n1]") || !strings.Contains(string(data), "status: done") || !strings.Contains(string(data), "new body") {
+		t.Fatalf("unexpected file:\n%s", data)
+	}
+	if strings.Contains(string(data), "due:") {
+		t.Fatalf("cleared due should be removed:\n%s", data)
+	}
+}
+
+func TestMarkdownProvider_NewerFileWins(t *testing.T) {
+	dir := t.TempDir()
+	client := NewMarkdownProvider("Notes", MarkdownConfig{})
+	ctx := context.Background()
+
+	path := filepath.Join(dir, "task.md")
+	if err := os.WriteFile(path, []byte("---\ntitle: Edited by hand\n---\n"), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	stale := domain.Task{Title: "Local", UpdatedAt: time.Now().Add(-time.Hour)}
+	if _, err := client.PushTask(ctx, domain.SyncActionUpdate, stale, domain.RemoteRefs{Board: dir, Task: "task.md"}); !errors.Is(err, domain.ErrRemoteChanged) {
+		t.Fatalf("update task = %v, want %v", err, domain.ErrRemoteChanged)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), "Edited by hand") {
+		t.Fatalf("newer file was overwritten:\n%s", data)
+	}
+}
+
+func TestMarkdownProvider_ListTasksSkipsUnreadableFiles(t *testing.T) {
+	dir := t.TempDir()
+	client := NewMarkdownProvider("Notes", MarkdownConfig{})
+
+	if err := os.WriteFile(filepath.Join(dir, "good.md"), []byte("---\ntitle: Good\n---\n"), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "bad.md"), []byte("---\ndue: someday\n---\n"), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	tasks, err := client.ListTasks(context.Background(), dir)
+	var partial *domain.PartialListError
+	if !errors.As(err, &partial) || len(partial.Items) != 1 || !strings.Contains(partial.Items[0].Error(), "bad.md") {
+		t.Fatalf("expected bad.md reported on its own, got %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].Title != "Good" {
+		t.Fatalf("tasks = %+v, want the readable file", tasks)
+	}
+}
+
+func TestMarkdownProvider_Paths(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	client := NewMarkdownProvider("Notes", MarkdownConfig{Root: root})
+
+	if _, err := client.PushBoard(ctx, domain.SyncActionCreate, domain.Board{Name: "Main"}, domain.RemoteRefs{}); err == nil || !strings.Contains(err.Error(), "kanji sync link") {
+		t.Fatalf("expected link hint, got %v", err)
+	}
+	if _, err := client.PushBoard(ctx, domain.SyncActionCreate, domain.Board{Name: "Main"}, domain.RemoteRefs{Board: "boards/main"}); err != nil {
+		t.Fatalf("push board: %v", err)
+	}
+	if info, err := os.Stat(filepath.Join(root, "boards", "main")); err != nil || !info.IsDir() {
+		t.Fatalf("expected board folder under root, got %v", err)
+	}
+	if _, err := client.PullTask(ctx, "boards/main", "../escape.md"); err == nil {
+		t.Fatal("expected paths outside the board folder to be rejected")
+	}
+	if _, err := NewMarkdownProvider("Notes", MarkdownConfig{}).ListTasks(ctx, "relative"); err == nil {
+		t.Fatal("expected relative folder without root to fail")
+	}
+
+	if err := os.MkdirAll(filepath.Join(root, "boards", "main", ".obsidian"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	_ = os.WriteFile(filepath.Join(root, "boards", "main", ".obsidian", "x.md"), []byte("hidden"), 0o644)
+	_ = os.WriteFile(filepath.Join(root, "boards", "main", "readme.txt"), []byte("not a task"), 0o644)
+	tasks, err := client.ListTasks(ctx, "boards/main")
+	if err != nil || len(tasks) != 0 {
+		t.Fatalf("expected hidden and non-markdown files to be skipped, got %+v, %v", tasks, err)
+	}
+}
@@ -0,0 +1,65 @@
+package providers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+// Factory builds a client for a configured provider row.
+type Factory func(provider domain.Provider) (domain.ProviderClient, error)
+
+// Registry maps providers.type values to client factories.
+type Registry struct {
+	mu        sync.RWMutex
+	factories map[string]Factory
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{factories: make(map[string]Factory)}
+}
+
+// DefaultRegistry returns a registry with every built-in provider type.
+func DefaultRegistry() *Registry {
+	r := NewRegistry()
+	r.Register("local", func(domain.Provider) (domain.ProviderClient, error) {
+		return NewLocalProvider(), nil
+	})
+	r.Register("github", newGitHubFromProvider)
+	r.Register("markdown", newMarkdownFromProvider)
+	return r
+}
+
+// Register adds or replaces the factory for a provider type.
+func (r *Registry) Register(providerType string, factory Factory) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.factories[strings.ToLower(strings.TrimSpace(providerType))] = factory
+}
+
+// Types returns the registered provider types in alphabetical order.
+func (r *Registry) Types() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	types := make([]string, 0, len(r.factories))
+	for t := range r.factories {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Client builds the client for the given provider using its type.
+func (r *Registry) Client(provider domain.Provider) (domain.ProviderClient, error) {
+	r.mu.RLock()
+	factory, ok := r.factories[strings.ToLower(strings.TrimSpace(provider.Type))]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no client available for provider type %q", provider.Type)
+	}
+	return factory(provider)
+}
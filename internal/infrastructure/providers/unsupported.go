@@ -0,0 +1,72 @@
+package providers
+
+import (
+	"context"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+// Unsupported implements every list, pull and push method of
+// domain.ProviderClient by returning domain.ErrProviderUnsupported. Providers
+// embed it and override only what their capabilities advertise.
+type Unsupported struct{}
+
+func (Unsupported) ListWorkspaces(context.Context) ([]domain.Workspace, error) {
+	return nil, domain.ErrProviderUnsupported
+}
+
+func (Unsupported) PullWorkspace(context.Context, string) (domain.Workspace, error) {
+	return domain.Workspace{}, domain.ErrProviderUnsupported
+}
+
+func (Unsupported) PushWorkspace(context.Context, string, domain.Workspace, domain.RemoteRefs) (string, error) {
+	return "", domain.ErrProviderUnsupported
+}
+
+func (Unsupported) ListBoards(context.Context, string) ([]domain.Board, error) {
+	return nil, domain.ErrProviderUnsupported
+}
+
+func (Unsupported) PullBoard(context.Context, string) (domain.Board, error) {
+	return domain.Board{}, domain.ErrProviderUnsupported
+}
+
+func (Unsupported) PushBoard(context.Context, string, domain.Board, domain.RemoteRefs) (string, error) {
+	return "", domain.ErrProviderUnsupported
+}
+
+func (Unsupported) ListColumns(context.Context, string) ([]domain.Column, error) {
+	return nil, domain.ErrProviderUnsupported
+}
+
+func (Unsupported) PullColumn(context.Context, string, string) (domain.Column, error) {
+	return domain.Column{}, domain.ErrProviderUnsupported
+}
+
+func (Unsupported) PushColumn(context.Context, string, domain.Column, domain.RemoteRefs) (string, error) {
+	return "", domain.ErrProviderUnsupported
+}
+
+func (Unsupported) ListTasks(context.Context, string) ([]domain.Task, error) {
+	return nil, domain.ErrProviderUnsupported
+}
+
+func (Unsupported) PullTask(context.Context, string, string) (domain.Task, error) {
+	return domain.Task{}, domain.ErrProviderUnsupported
+}
+
+func (Unsupported) PushTask(context.Context, string, domain.Task, domain.RemoteRefs) (string, error) {
+	return "", domain.ErrProviderUnsupported
+}
+
+func (Unsupported) ListComments(context.Context, string, string) ([]domain.Comment, error) {
+	return nil, domain.ErrProviderUnsupported
+}
+
+func (Unsupported) PullComment(context.Context, string, string) (domain.Comment, error) {
+	return domain.Comment{}, domain.ErrProviderUnsupported
+}
+
+func (Unsupported) PushComment(context.Context, string, domain.Comment, domain.RemoteRefs) (string, error) {
+	return "", domain.ErrProviderUnsupported
+}
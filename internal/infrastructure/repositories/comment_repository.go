@@ -2,6 +2,9 @@ package repositories
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/tiagokriok/kanji/internal/domain"
@@ -10,28 +13,49 @@ import (
 )
 
 type CommentRepository struct {
-	store store.Store
+	store   store.Store
+	actor   string
+	journal string
 }
 
 func NewCommentRepository(s store.Store) *CommentRepository {
 	return &CommentRepository{store: s}
 }
 
+// SetActor sets who is recorded in task history for new comments that
+// have no author.
+func (r *CommentRepository) SetActor(actor string) {
+	r.actor = actor
+}
+
+// SetJournal journals the changes made through the repository under the
+// namespace so they can be undone. An empty namespace turns journaling off.
+func (r *CommentRepository) SetJournal(namespace string) {
+	r.journal = namespace
+}
+
 func (r *CommentRepository) Create(ctx context.Context, comment domain.Comment) error {
 	return r.store.Write(ctx, "create comment", func(tx store.Tx) error {
 		qtx := tx.Queries()
-		return qtx.CreateComment(ctx, sqlc.CreateCommentParams{
-			ID:         comment.ID,
-			TaskID:     comment.TaskID,
-			ProviderID: comment.ProviderID,
-			RemoteID:   nullString(comment.RemoteID),
-			BodyMd:     comment.BodyMD,
-			Author:     nullString(comment.Author),
-			CreatedAt:  comment.CreatedAt.UTC().Format(time.RFC3339),
-		})
+		if err := createComment(ctx, qtx, comment, r.actor); err != nil {
+			return err
+		}
+		created, err := loadComment(ctx, qtx, comment.ID)
+		if err != nil {
+			return err
+		}
+		return journalComment(ctx, qtx, r.journal, domain.OperationCommentCreate, nil, created)
 	})
 }
 
+func (r *CommentRepository) GetByID(ctx context.Context, commentID string) (domain.Comment, error) {
+	row, err := r.store.Queries().GetComment(ctx, commentID)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	return fromSQLComment(row), nil
+}
+
 func (r *CommentRepository) ListByTask(ctx context.Context, taskID string) ([]domain.Comment, error) {
 	items, err := r.store.Queries().ListComments(ctx, taskID)
 	if err != nil {
@@ -44,17 +68,120 @@ func (r *CommentRepository) ListByTask(ctx context.Context, taskID string) ([]do
 	return result, nil
 }
 
-func (r *CommentRepository) Update(ctx context.Context, commentID string, bodyMD string) error {
+func (r *CommentRepository) Update(ctx context.Context, commentID string, bodyMD string, ifVersion *int) error {
 	return r.store.Write(ctx, "update comment", func(tx store.Tx) error {
-		return tx.Queries().UpdateComment(ctx, sqlc.UpdateCommentParams{
-			ID:     commentID,
-			BodyMd: bodyMD,
-		})
+		qtx := tx.Queries()
+		if err := checkCommentVersion(ctx, qtx, commentID, ifVersion); err != nil {
+			return err
+		}
+		before, err := loadComment(ctx, qtx, commentID)
+		if err != nil {
+			return err
+		}
+		if err := updateComment(ctx, qtx, commentID, bodyMD); err != nil {
+			return err
+		}
+		after, err := loadComment(ctx, qtx, commentID)
+		if err != nil {
+			return err
+		}
+		return journalComment(ctx, qtx, r.journal, domain.OperationCommentUpdate, before, after)
 	})
 }
 
 func (r *CommentRepository) Delete(ctx context.Context, commentID string) error {
 	return r.store.Write(ctx, "delete comment", func(tx store.Tx) error {
-		return tx.Queries().DeleteComment(ctx, commentID)
+		qtx := tx.Queries()
+		before, err := loadComment(ctx, qtx, commentID)
+		if err != nil {
+			return err
+		}
+		if err := deleteComment(ctx, qtx, commentID); err != nil {
+			return err
+		}
+		return journalComment(ctx, qtx, r.journal, domain.OperationCommentDelete, before, nil)
 	})
 }
+
+// loadComment returns the comment, or nil when it does not exist.
+func loadComment(ctx context.Context, qtx *sqlc.Queries, commentID string) (*domain.Comment, error) {
+	row, err := qtx.GetComment(ctx, commentID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load comment: %w", err)
+	}
+	comment := fromSQLComment(row)
+	return &comment, nil
+}
+
+// createComment inserts the comment with its history, sync and webhook
+// entries.
+func createComment(ctx context.Context, qtx *sqlc.Queries, comment domain.Comment, actor string) error {
+	if err := qtx.CreateComment(ctx, sqlc.CreateCommentParams{
+		ID:         comment.ID,
+		TaskID:     comment.TaskID,
+		ProviderID: comment.ProviderID,
+		RemoteID:   nullString(comment.RemoteID),
+		BodyMd:     comment.BodyMD,
+		Author:     nullString(comment.Author),
+		CreatedAt:  comment.CreatedAt.UTC().Format(time.RFC3339),
+	}); err != nil {
+		return err
+	}
+	if err := recordComment(ctx, qtx, comment, actor); err != nil {
+		return err
+	}
+	item, err := commentSyncItem(ctx, qtx, comment.ID, domain.SyncActionCreate)
+	if err != nil {
+		return err
+	}
+	if err := enqueueSync(ctx, qtx, item); err != nil {
+		return err
+	}
+	event, err := commentWebhookEvent(ctx, qtx, comment.ID, domain.WebhookEventCommentCreated)
+	if err != nil {
+		return err
+	}
+	return enqueueWebhooks(ctx, qtx, event)
+}
+
+func updateComment(ctx context.Context, qtx *sqlc.Queries, commentID, bodyMD string) error {
+	if err := qtx.UpdateComment(ctx, sqlc.UpdateCommentParams{
+		ID:     commentID,
+		BodyMd: bodyMD,
+	}); err != nil {
+		return err
+	}
+	item, err := commentSyncItem(ctx, qtx, commentID, domain.SyncActionUpdate)
+	if err != nil {
+		return err
+	}
+	if err := enqueueSync(ctx, qtx, item); err != nil {
+		return err
+	}
+	event, err := commentWebhookEvent(ctx, qtx, commentID, domain.WebhookEventCommentUpdated)
+	if err != nil {
+		return err
+	}
+	return enqueueWebhooks(ctx, qtx, event)
+}
+
+func deleteComment(ctx context.Context, qtx *sqlc.Queries, commentID string) error {
+	item, err := commentSyncItem(ctx, qtx, commentID, domain.SyncActionDelete)
+	if err != nil {
+		return err
+	}
+	event, err := commentWebhookEvent(ctx, qtx, commentID, domain.WebhookEventCommentDeleted)
+	if err != nil {
+		return err
+	}
+	if err := qtx.DeleteComment(ctx, commentID); err != nil {
+		return err
+	}
+	if err := enqueueSync(ctx, qtx, item); err != nil {
+		return err
+	}
+	return enqueueWebhooks(ctx, qtx, event)
+}
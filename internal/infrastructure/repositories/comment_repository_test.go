@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"testing"
 	"time"
@@ -112,6 +113,43 @@ func TestCommentRepository_Create(t *testing.T) {
 	}
 }
 
+func TestCommentRepository_Update_IfVersion(t *testing.T) {
+	adapter := newTestAdapter(t)
+	ctx := context.Background()
+
+	task := seedTask(t, ctx, adapter.Queries())
+	repo := NewCommentRepository(store.New(adapter))
+	comment := domain.Comment{
+		ID:         "cm-version",
+		TaskID:     task.ID,
+		ProviderID: task.ProviderID,
+		BodyMD:     "first",
+		CreatedAt:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	if err := repo.Create(ctx, comment); err != nil {
+		t.Fatalf("create comment: %v", err)
+	}
+
+	one := 1
+	if err := repo.Update(ctx, comment.ID, "second", &one); err != nil {
+		t.Fatalf("update at the current version: %v", err)
+	}
+	if err := repo.Update(ctx, comment.ID, "stale", &one); !errors.Is(err, domain.ErrVersionConflict) {
+		t.Fatalf("expected a version conflict, got %v", err)
+	}
+	if err := repo.Update(ctx, comment.ID, "forced", nil); err != nil {
+		t.Fatalf("update without a version: %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, comment.ID)
+	if err != nil {
+		t.Fatalf("get by id: %v", err)
+	}
+	if got.BodyMD != "forced" || got.Version != 3 {
+		t.Errorf("got body %q at version %d, want forced at 3", got.BodyMD, got.Version)
+	}
+}
+
 func TestCommentRepository_Create_ErrorContext(t *testing.T) {
 	adapter := newTestAdapter(t)
 	ctx := context.Background()
@@ -0,0 +1,232 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+	"github.com/tiagokriok/kanji/internal/infrastructure/db/sqlc"
+	"github.com/tiagokriok/kanji/internal/infrastructure/store"
+)
+
+type LabelRepository struct {
+	store   store.Store
+	actor   string
+	journal string
+}
+
+func NewLabelRepository(s store.Store) *LabelRepository {
+	return &LabelRepository{store: s}
+}
+
+// SetActor sets who is recorded in the history of the tasks relabeled
+// through the repository.
+func (r *LabelRepository) SetActor(actor string) {
+	r.actor = actor
+}
+
+// SetJournal journals the task label rewrites made through the repository
+// under the namespace, so undoing past them reaches earlier changes. An
+// empty namespace turns journaling off.
+func (r *LabelRepository) SetJournal(namespace string) {
+	r.journal = namespace
+}
+
+func (r *LabelRepository) List(ctx context.Context, workspaceID string) ([]domain.Label, error) {
+	rows, err := r.store.Queries().ListLabels(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.Label, 0, len(rows))
+	for _, row := range rows {
+		label := fromSQLLabel(sqlc.Label{
+			ID:          row.ID,
+			WorkspaceID: row.WorkspaceID,
+			Name:        row.Name,
+			Color:       row.Color,
+			Description: row.Description,
+			CreatedAt:   row.CreatedAt,
+			UpdatedAt:   row.UpdatedAt,
+		})
+		label.TaskCount = int(row.TaskCount)
+		result = append(result, label)
+	}
+	return result, nil
+}
+
+func (r *LabelRepository) GetByName(ctx context.Context, workspaceID, name string) (domain.Label, error) {
+	row, err := r.store.Queries().GetLabelByName(ctx, sqlc.GetLabelByNameParams{WorkspaceID: workspaceID, Name: name})
+	if err != nil {
+		return domain.Label{}, err
+	}
+	return fromSQLLabel(row), nil
+}
+
+func (r *LabelRepository) Create(ctx context.Context, label domain.Label) error {
+	return r.store.Write(ctx, "create label", func(tx store.Tx) error {
+		return tx.Queries().CreateLabel(ctx, sqlc.CreateLabelParams{
+			ID:          label.ID,
+			WorkspaceID: label.WorkspaceID,
+			Name:        label.Name,
+			Color:       label.Color,
+			Description: label.Description,
+			CreatedAt:   label.CreatedAt.UTC().Format(time.RFC3339),
+			UpdatedAt:   label.UpdatedAt.UTC().Format(time.RFC3339),
+		})
+	})
+}
+
+func (r *LabelRepository) Update(ctx context.Context, label domain.Label) error {
+	return r.store.Write(ctx, "update label", func(tx store.Tx) error {
+		return updateLabel(ctx, tx.Queries(), label, label.Name)
+	})
+}
+
+func (r *LabelRepository) Rename(ctx context.Context, label domain.Label, newName string) (int, error) {
+	var changed int
+	err := r.store.Write(ctx, "rename label", func(tx store.Tx) error {
+		qtx := tx.Queries()
+		if err := updateLabel(ctx, qtx, label, newName); err != nil {
+			return err
+		}
+		var err error
+		changed, err = r.relabelTasks(ctx, qtx, label.WorkspaceID, map[string]string{labelKey(label.Name): newName})
+		return err
+	})
+	return changed, err
+}
+
+func (r *LabelRepository) Merge(ctx context.Context, sources []domain.Label, target domain.Label) (int, error) {
+	var changed int
+	err := r.store.Write(ctx, "merge labels", func(tx store.Tx) error {
+		qtx := tx.Queries()
+		replace := make(map[string]string, len(sources))
+		for _, source := range sources {
+			if _, err := qtx.DeleteLabel(ctx, source.ID); err != nil {
+				return err
+			}
+			replace[labelKey(source.Name)] = target.Name
+		}
+		var err error
+		changed, err = r.relabelTasks(ctx, qtx, target.WorkspaceID, replace)
+		return err
+	})
+	return changed, err
+}
+
+func (r *LabelRepository) Delete(ctx context.Context, label domain.Label) (int, error) {
+	var changed int
+	err := r.store.Write(ctx, "delete label", func(tx store.Tx) error {
+		qtx := tx.Queries()
+		affected, err := qtx.DeleteLabel(ctx, label.ID)
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return sql.ErrNoRows
+		}
+		changed, err = r.relabelTasks(ctx, qtx, label.WorkspaceID, map[string]string{labelKey(label.Name): ""})
+		return err
+	})
+	return changed, err
+}
+
+func updateLabel(ctx context.Context, qtx *sqlc.Queries, label domain.Label, name string) error {
+	affected, err := qtx.UpdateLabel(ctx, sqlc.UpdateLabelParams{
+		Name:        name,
+		Color:       label.Color,
+		Description: label.Description,
+		UpdatedAt:   time.Now().UTC().Format(time.RFC3339),
+		ID:          label.ID,
+	})
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// relabelTasks rewrites the labels of every task of the workspace: a label
+// whose key is in replace becomes the mapped name, or is dropped when that
+// is empty. Changed tasks get history, journal, sync and webhook entries
+// like any other update.
+func (r *LabelRepository) relabelTasks(ctx context.Context, qtx *sqlc.Queries, workspaceID string, replace map[string]string) (int, error) {
+	items, err := qtx.ListTasks(ctx, sqlc.ListTasksParams{WorkspaceID: workspaceID})
+	if err != nil {
+		return 0, err
+	}
+	changed := 0
+	for _, item := range items {
+		before := fromSQLTask(item)
+		labels := make([]string, 0, len(before.Labels))
+		seen := make(map[string]bool, len(before.Labels))
+		for _, label := range before.Labels {
+			if to, ok := replace[labelKey(label)]; ok {
+				label = to
+			}
+			if label == "" || seen[labelKey(label)] {
+				continue
+			}
+			seen[labelKey(label)] = true
+			labels = append(labels, label)
+		}
+		if slices.Equal(labels, before.Labels) {
+			continue
+		}
+		if err := qtx.UpdateTask(ctx, sqlc.UpdateTaskParams{
+			LabelsJSON: sql.NullString{String: marshalLabels(labels), Valid: true},
+			UpdatedAt:  time.Now().UTC().Format(time.RFC3339),
+			ID:         before.ID,
+		}); err != nil {
+			return 0, err
+		}
+		after, err := loadTask(ctx, qtx, before.ID)
+		if err != nil {
+			return 0, err
+		}
+		if err := recordTaskChanges(ctx, qtx, &before, after, domain.TaskEventUpdated, r.actor); err != nil {
+			return 0, err
+		}
+		if err := journalTask(ctx, qtx, r.journal, domain.OperationTaskUpdate, before.ID, &before, after); err != nil {
+			return 0, err
+		}
+		if err := enqueueTaskChange(ctx, qtx, before.ID, false); err != nil {
+			return 0, err
+		}
+		changed++
+	}
+	return changed, nil
+}
+
+// ensureLabels registers the labels of a task that the workspace does not
+// have yet, so the registry always covers the labels in use.
+func ensureLabels(ctx context.Context, qtx *sqlc.Queries, workspaceID string, labels []string) error {
+	now := time.Now().UTC().Format(time.RFC3339)
+	for _, label := range labels {
+		name := strings.TrimSpace(label)
+		if name == "" {
+			continue
+		}
+		if err := qtx.EnsureLabel(ctx, sqlc.EnsureLabelParams{
+			ID:          uuid.NewString(),
+			WorkspaceID: workspaceID,
+			Name:        name,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func labelKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
@@ -0,0 +1,155 @@
+package repositories
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+	"github.com/tiagokriok/kanji/internal/infrastructure/store"
+)
+
+func TestLabelRepository(t *testing.T) {
+	adapter := newTestAdapter(t)
+	ctx := context.Background()
+	providerID, workspaceID, boardID, columnID := seedProviderWorkspaceBoardColumn(t, ctx, adapter.Queries())
+
+	s := store.New(adapter)
+	tasks := NewTaskRepository(s)
+	labels := NewLabelRepository(s)
+	labels.SetActor("alice")
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for id, taskLabels := range map[string][]string{
+		"t-1": {"bug", "ui"},
+		"t-2": {"Bgu", "defect"},
+		"t-3": {},
+	} {
+		if err := tasks.Create(ctx, domain.Task{
+			ID: id, ProviderID: providerID, WorkspaceID: workspaceID,
+			BoardID: &boardID, ColumnID: &columnID, Title: id,
+			Labels: taskLabels, CreatedAt: now, UpdatedAt: now,
+		}); err != nil {
+			t.Fatalf("create %s: %v", id, err)
+		}
+	}
+	// Labels set by an update are registered too.
+	patch := []string{"ui", "docs"}
+	if err := tasks.Update(ctx, "t-3", domain.TaskPatch{Labels: &patch}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+
+	listed, err := labels.List(ctx, workspaceID)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	counts := map[string]int{}
+	for _, l := range listed {
+		counts[l.Name] = l.TaskCount
+	}
+	want := map[string]int{"Bgu": 1, "bug": 1, "defect": 1, "docs": 1, "ui": 2}
+	if len(counts) != len(want) {
+		t.Fatalf("labels = %v, want %v", counts, want)
+	}
+	for name, n := range want {
+		if counts[name] != n {
+			t.Fatalf("labels = %v, want %v", counts, want)
+		}
+	}
+
+	bgu, err := labels.GetByName(ctx, workspaceID, "BGU")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if changed, err := labels.Rename(ctx, bgu, "typo"); err != nil || changed != 1 {
+		t.Fatalf("Rename = %d, %v, want 1", changed, err)
+	}
+	if got := taskLabels(t, tasks, "t-2"); !slices.Equal(got, []string{"typo", "defect"}) {
+		t.Fatalf("t-2 labels after rename = %v", got)
+	}
+
+	bug, _ := labels.GetByName(ctx, workspaceID, "bug")
+	typo, _ := labels.GetByName(ctx, workspaceID, "typo")
+	defect, _ := labels.GetByName(ctx, workspaceID, "defect")
+	if changed, err := labels.Merge(ctx, []domain.Label{typo, defect}, bug); err != nil || changed != 1 {
+		t.Fatalf("Merge = %d, %v, want 1", changed, err)
+	}
+	if got := taskLabels(t, tasks, "t-2"); !slices.Equal(got, []string{"bug"}) {
+		t.Fatalf("t-2 labels after merge = %v, want [bug]", got)
+	}
+	if _, err := labels.GetByName(ctx, workspaceID, "defect"); err == nil {
+		t.Fatal("merged label should be deleted")
+	}
+
+	ui, _ := labels.GetByName(ctx, workspaceID, "ui")
+	if changed, err := labels.Delete(ctx, ui); err != nil || changed != 2 {
+		t.Fatalf("Delete = %d, %v, want 2", changed, err)
+	}
+	if got := taskLabels(t, tasks, "t-1"); !slices.Equal(got, []string{"bug"}) {
+		t.Fatalf("t-1 labels after delete = %v, want [bug]", got)
+	}
+
+	history, err := NewTaskEventRepository(s).ListByTask(ctx, "t-2")
+	if err != nil {
+		t.Fatalf("history: %v", err)
+	}
+	if len(history) != 3 || history[2].Actor == nil || *history[2].Actor != "alice" {
+		t.Fatalf("t-2 history = %+v, want created plus two relabels by alice", history)
+	}
+}
+
+func taskLabels(t *testing.T, tasks *TaskRepository, id string) []string {
+	t.Helper()
+	task, err := tasks.GetByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("get %s: %v", id, err)
+	}
+	return task.Labels
+}
+
+func TestLabelRepository_RenameKeepsEarlierUndo(t *testing.T) {
+	adapter := newTestAdapter(t)
+	ctx := context.Background()
+	providerID, workspaceID, boardID, columnID := seedProviderWorkspaceBoardColumn(t, ctx, adapter.Queries())
+
+	s := store.New(adapter)
+	tasks := NewTaskRepository(s)
+	tasks.SetJournal("ns")
+	labels := NewLabelRepository(s)
+	labels.SetJournal("ns")
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if err := tasks.Create(ctx, domain.Task{
+		ID: "t-1", ProviderID: providerID, WorkspaceID: workspaceID,
+		BoardID: &boardID, ColumnID: &columnID, Title: "Before",
+		Labels: []string{"bug"}, CreatedAt: now, UpdatedAt: now,
+	}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	title := "After"
+	if err := tasks.Update(ctx, "t-1", domain.TaskPatch{Title: &title}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	bug, err := labels.GetByName(ctx, workspaceID, "bug")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if _, err := labels.Rename(ctx, bug, "defect"); err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+
+	// The rename is undone first, then the title change before it.
+	ops := NewOperationRepository(s)
+	if _, err := ops.Undo(ctx, "ns", ""); err != nil {
+		t.Fatalf("undo rename: %v", err)
+	}
+	if got := taskLabels(t, tasks, "t-1"); !slices.Equal(got, []string{"bug"}) {
+		t.Fatalf("labels after undoing the rename = %v, want [bug]", got)
+	}
+	if _, err := ops.Undo(ctx, "ns", ""); err != nil {
+		t.Fatalf("undo title: %v", err)
+	}
+	task, err := tasks.GetByID(ctx, "t-1")
+	if err != nil || task.Title != "Before" {
+		t.Fatalf("task after undo = %+v, %v", task, err)
+	}
+}
@@ -133,6 +133,7 @@ func fromSQLTask(t sqlc.Task) domain.Task {
 		Position:        t.Position,
 		CreatedAt:       parseRFC3339OrZero(t.CreatedAt),
 		UpdatedAt:       parseRFC3339OrZero(t.UpdatedAt),
+		Version:         int(t.Version),
 	}
 }
 
@@ -153,6 +154,7 @@ func fromSQLComment(c sqlc.Comment) domain.Comment {
 		BodyMD:     c.BodyMd,
 		Author:     author,
 		CreatedAt:  parseRFC3339OrZero(c.CreatedAt),
+		Version:    int(c.Version),
 	}
 }
 
@@ -217,3 +219,181 @@ func fromSQLProvider(p sqlc.Provider) domain.Provider {
 		CreatedAt: parseRFC3339OrZero(p.CreatedAt),
 	}
 }
+
+func fromSQLSyncItem(s sqlc.SyncQueue) domain.SyncItem {
+	var lastError *string
+	if s.LastError.Valid {
+		lastError = &s.LastError.String
+	}
+	return domain.SyncItem{
+		ID:            s.ID,
+		ProviderID:    s.ProviderID,
+		Entity:        s.Entity,
+		EntityID:      s.EntityID,
+		Action:        s.Action,
+		PayloadJSON:   s.PayloadJSON,
+		Attempts:      int(s.Attempts),
+		LastError:     lastError,
+		NextAttemptAt: parseOptionalTime(s.NextAttemptAt),
+		CreatedAt:     parseRFC3339OrZero(s.CreatedAt),
+	}
+}
+
+func fromSQLSyncSnapshot(s sqlc.SyncSnapshot) domain.SyncSnapshot {
+	return domain.SyncSnapshot{
+		ProviderID:  s.ProviderID,
+		Entity:      s.Entity,
+		EntityID:    s.EntityID,
+		PayloadJSON: s.PayloadJSON,
+		SyncedAt:    parseRFC3339OrZero(s.SyncedAt),
+	}
+}
+
+func fromSQLSyncConflict(c sqlc.SyncConflict) domain.SyncConflict {
+	var fields []string
+	if err := json.Unmarshal([]byte(c.FieldsJSON), &fields); err != nil || fields == nil {
+		fields = []string{}
+	}
+	return domain.SyncConflict{
+		ID:         c.ID,
+		ProviderID: c.ProviderID,
+		Entity:     c.Entity,
+		EntityID:   c.EntityID,
+		Fields:     fields,
+		BaseJSON:   c.BaseJSON,
+		RemoteJSON: c.RemoteJSON,
+		CreatedAt:  parseRFC3339OrZero(c.CreatedAt),
+	}
+}
+
+func fromSQLWebhook(w sqlc.Webhook) domain.Webhook {
+	var events []string
+	if err := json.Unmarshal([]byte(w.EventsJSON), &events); err != nil || events == nil {
+		events = []string{}
+	}
+	return domain.Webhook{
+		ID:          w.ID,
+		WorkspaceID: w.WorkspaceID,
+		URL:         w.URL,
+		Secret:      w.Secret,
+		Events:      events,
+		CreatedAt:   parseRFC3339OrZero(w.CreatedAt),
+	}
+}
+
+func fromSQLWebhookDelivery(d sqlc.WebhookDelivery) domain.WebhookDelivery {
+	var lastError *string
+	if d.LastError.Valid {
+		lastError = &d.LastError.String
+	}
+	var status *int
+	if d.ResponseStatus.Valid {
+		v := int(d.ResponseStatus.Int64)
+		status = &v
+	}
+	return domain.WebhookDelivery{
+		ID:             d.ID,
+		WebhookID:      d.WebhookID,
+		Event:          d.Event,
+		PayloadJSON:    d.PayloadJSON,
+		Attempts:       int(d.Attempts),
+		LastError:      lastError,
+		ResponseStatus: status,
+		NextAttemptAt:  parseOptionalTime(d.NextAttemptAt),
+		DeliveredAt:    parseOptionalTime(d.DeliveredAt),
+		CreatedAt:      parseRFC3339OrZero(d.CreatedAt),
+	}
+}
+
+func fromSQLTaskEvent(e sqlc.TaskEvent) domain.TaskEvent {
+	var boardID *string
+	if e.BoardID.Valid {
+		boardID = &e.BoardID.String
+	}
+	var actor *string
+	if e.Actor.Valid {
+		actor = &e.Actor.String
+	}
+	var changes []domain.FieldChange
+	if err := json.Unmarshal([]byte(e.ChangesJSON), &changes); err != nil || changes == nil {
+		changes = []domain.FieldChange{}
+	}
+	return domain.TaskEvent{
+		ID:          e.ID,
+		TaskID:      e.TaskID,
+		WorkspaceID: e.WorkspaceID,
+		BoardID:     boardID,
+		Kind:        e.Kind,
+		Title:       e.Title,
+		Changes:     changes,
+		Actor:       actor,
+		CreatedAt:   parseRFC3339OrZero(e.CreatedAt),
+	}
+}
+
+func fromSQLOperation(o sqlc.Operation) domain.Operation {
+	return domain.Operation{
+		ID:        o.ID,
+		Namespace: o.Namespace,
+		Kind:      o.Kind,
+		EntityID:  o.EntityID,
+		Title:     o.Title,
+		CreatedAt: parseRFC3339OrZero(o.CreatedAt),
+	}
+}
+
+func fromSQLSearchRow(r sqlc.SearchTasksRow) domain.SearchHit {
+	return domain.SearchHit{
+		Task: fromSQLTask(sqlc.Task{
+			ID:              r.ID,
+			ProviderID:      r.ProviderID,
+			WorkspaceID:     r.WorkspaceID,
+			BoardID:         r.BoardID,
+			ColumnID:        r.ColumnID,
+			RemoteID:        r.RemoteID,
+			Title:           r.Title,
+			DescriptionMd:   r.DescriptionMd,
+			Status:          r.Status,
+			Priority:        r.Priority,
+			DueAt:           r.DueAt,
+			EstimateMinutes: r.EstimateMinutes,
+			Assignee:        r.Assignee,
+			LabelsJSON:      r.LabelsJSON,
+			Position:        r.Position,
+			CreatedAt:       r.CreatedAt,
+			UpdatedAt:       r.UpdatedAt,
+			Version:         r.Version,
+		}),
+		Snippet: r.Snippet,
+	}
+}
+
+func fromSQLView(v sqlc.View) domain.View {
+	var boardID *string
+	if v.BoardID.Valid {
+		boardID = &v.BoardID.String
+	}
+	var settings domain.ViewSettings
+	_ = json.Unmarshal([]byte(v.SettingsJSON), &settings)
+	return domain.View{
+		ID:          v.ID,
+		WorkspaceID: v.WorkspaceID,
+		BoardID:     boardID,
+		Name:        v.Name,
+		Settings:    settings,
+		CreatedAt:   parseRFC3339OrZero(v.CreatedAt),
+		UpdatedAt:   parseRFC3339OrZero(v.UpdatedAt),
+	}
+}
+
+func fromSQLLabel(l sqlc.Label) domain.Label {
+	return domain.Label{
+		ID:          l.ID,
+		WorkspaceID: l.WorkspaceID,
+		Name:        l.Name,
+		Color:       l.Color,
+		Description: l.Description,
+		CreatedAt:   parseRFC3339OrZero(l.CreatedAt),
+		UpdatedAt:   parseRFC3339OrZero(l.UpdatedAt),
+	}
+}
@@ -0,0 +1,354 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+	"github.com/tiagokriok/kanji/internal/infrastructure/db/sqlc"
+	"github.com/tiagokriok/kanji/internal/infrastructure/store"
+)
+
+// Journal helpers append operations rows inside the caller's write
+// transaction. A row keeps JSON snapshots of the entity before and after
+// the change: undo writes the before snapshot back, redo the after one. A
+// nil snapshot means the entity did not exist.
+
+// journalLimit is how many operations a namespace keeps.
+const journalLimit = 100
+
+// journalTask journals a task change. Updates and moves that change nothing
+// are skipped.
+func journalTask(ctx context.Context, qtx *sqlc.Queries, namespace, kind, taskID string, before, after *domain.Task) error {
+	if namespace == "" {
+		return nil
+	}
+	if before != nil && after != nil && len(diffTasks(*before, *after)) == 0 && before.Position == after.Position {
+		return nil
+	}
+	title := ""
+	if after != nil {
+		title = after.Title
+	} else if before != nil {
+		title = before.Title
+	}
+	return journalOperation(ctx, qtx, namespace, kind, taskID, title, before, after)
+}
+
+// journalComment journals a comment change under the title of its task.
+func journalComment(ctx context.Context, qtx *sqlc.Queries, namespace, kind string, before, after *domain.Comment) error {
+	if namespace == "" {
+		return nil
+	}
+	comment := after
+	if comment == nil {
+		comment = before
+	}
+	if comment == nil {
+		return nil
+	}
+	title := ""
+	task, err := loadTask(ctx, qtx, comment.TaskID)
+	if err != nil {
+		return err
+	}
+	if task != nil {
+		title = task.Title
+	}
+	return journalOperation(ctx, qtx, namespace, kind, comment.ID, title, before, after)
+}
+
+func journalOperation[T any](ctx context.Context, qtx *sqlc.Queries, namespace, kind, entityID, title string, before, after *T) error {
+	beforeJSON, err := marshalSnapshot(before)
+	if err != nil {
+		return err
+	}
+	afterJSON, err := marshalSnapshot(after)
+	if err != nil {
+		return err
+	}
+	// A new change forks the history: undone operations can no longer be
+	// redone.
+	if err := qtx.DeleteUndoneOperations(ctx, namespace); err != nil {
+		return fmt.Errorf("clear redo journal: %w", err)
+	}
+	if err := qtx.CreateOperation(ctx, sqlc.CreateOperationParams{
+		ID:         uuid.NewString(),
+		Namespace:  namespace,
+		Kind:       kind,
+		EntityID:   entityID,
+		Title:      title,
+		BeforeJSON: beforeJSON,
+		AfterJSON:  afterJSON,
+		CreatedAt:  time.Now().UTC().Format(time.RFC3339),
+	}); err != nil {
+		return fmt.Errorf("journal operation: %w", err)
+	}
+	return qtx.PruneOperations(ctx, sqlc.PruneOperationsParams{Namespace: namespace, Keep: journalLimit})
+}
+
+func marshalSnapshot[T any](v *T) (sql.NullString, error) {
+	if v == nil {
+		return sql.NullString{}, nil
+	}
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return sql.NullString{}, fmt.Errorf("encode operation snapshot: %w", err)
+	}
+	return sql.NullString{String: string(payload), Valid: true}, nil
+}
+
+func unmarshalSnapshot[T any](raw sql.NullString) (*T, error) {
+	if !raw.Valid {
+		return nil, nil
+	}
+	var v T
+	if err := json.Unmarshal([]byte(raw.String), &v); err != nil {
+		return nil, fmt.Errorf("decode operation snapshot: %w", err)
+	}
+	return &v, nil
+}
+
+// OperationRepository undoes and redoes journaled operations. The reverted
+// changes go through the same history, sync and webhook paths as the
+// originals.
+type OperationRepository struct {
+	store store.Store
+	actor string
+}
+
+func NewOperationRepository(s store.Store) *OperationRepository {
+	return &OperationRepository{store: s}
+}
+
+// SetActor sets who is recorded in task history for undone and redone
+// changes.
+func (r *OperationRepository) SetActor(actor string) {
+	r.actor = actor
+}
+
+func (r *OperationRepository) NextUndo(ctx context.Context, namespace string) (domain.OperationChange, error) {
+	return r.next(ctx, namespace, true)
+}
+
+func (r *OperationRepository) NextRedo(ctx context.Context, namespace string) (domain.OperationChange, error) {
+	return r.next(ctx, namespace, false)
+}
+
+func (r *OperationRepository) Undo(ctx context.Context, namespace, operationID string) (domain.Operation, error) {
+	return r.step(ctx, namespace, operationID, true)
+}
+
+func (r *OperationRepository) Redo(ctx context.Context, namespace, operationID string) (domain.Operation, error) {
+	return r.step(ctx, namespace, operationID, false)
+}
+
+// next describes the change the next undo or redo step would make.
+func (r *OperationRepository) next(ctx context.Context, namespace string, undo bool) (domain.OperationChange, error) {
+	row, err := nextOperation(ctx, r.store.Queries(), namespace, undo)
+	if err != nil {
+		return domain.OperationChange{}, err
+	}
+	change := domain.OperationChange{Operation: fromSQLOperation(row)}
+	from, to := stepSnapshots(row, undo)
+	switch row.Kind {
+	case domain.OperationTaskCreate, domain.OperationTaskUpdate, domain.OperationTaskMove, domain.OperationTaskDelete:
+		if change.FromTask, err = unmarshalSnapshot[domain.Task](from); err != nil {
+			return domain.OperationChange{}, err
+		}
+		if change.ToTask, err = unmarshalSnapshot[domain.Task](to); err != nil {
+			return domain.OperationChange{}, err
+		}
+	case domain.OperationCommentCreate, domain.OperationCommentUpdate, domain.OperationCommentDelete:
+		if change.FromComment, err = unmarshalSnapshot[domain.Comment](from); err != nil {
+			return domain.OperationChange{}, err
+		}
+		if change.ToComment, err = unmarshalSnapshot[domain.Comment](to); err != nil {
+			return domain.OperationChange{}, err
+		}
+	}
+	return change, nil
+}
+
+// step undoes or redoes one operation. An operation whose entity changed
+// since is dropped from the journal, so the next step can go on with the
+// operations before it, and the conflict is returned.
+func (r *OperationRepository) step(ctx context.Context, namespace, operationID string, undo bool) (domain.Operation, error) {
+	var (
+		result   domain.Operation
+		conflict error
+	)
+	name := "redo operation"
+	if undo {
+		name = "undo operation"
+	}
+	err := r.store.Write(ctx, name, func(tx store.Tx) error {
+		qtx := tx.Queries()
+		row, err := nextOperation(ctx, qtx, namespace, undo)
+		if err != nil {
+			return err
+		}
+		if operationID != "" && row.ID != operationID {
+			return errors.New("the undo history changed meanwhile; try again")
+		}
+		result = fromSQLOperation(row)
+
+		from, to := stepSnapshots(row, undo)
+		undoneAt := sql.NullString{String: time.Now().UTC().Format(time.RFC3339), Valid: true}
+		if !undo {
+			undoneAt = sql.NullString{}
+		}
+		if err := r.apply(ctx, qtx, row.Kind, row.EntityID, from, to); err != nil {
+			if !errors.Is(err, domain.ErrOperationConflict) {
+				return err
+			}
+			conflict = err
+			return qtx.DeleteOperation(ctx, row.ID)
+		}
+		return qtx.SetOperationUndoneAt(ctx, sqlc.SetOperationUndoneAtParams{UndoneAt: undoneAt, ID: row.ID})
+	})
+	if err != nil {
+		return domain.Operation{}, err
+	}
+	return result, conflict
+}
+
+// nextOperation returns the operation the next undo or redo step applies.
+func nextOperation(ctx context.Context, q *sqlc.Queries, namespace string, undo bool) (sqlc.Operation, error) {
+	var (
+		row sqlc.Operation
+		err error
+	)
+	if undo {
+		row, err = q.GetLastOperation(ctx, namespace)
+	} else {
+		row, err = q.GetFirstUndoneOperation(ctx, namespace)
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		if undo {
+			return sqlc.Operation{}, domain.ErrNothingToUndo
+		}
+		return sqlc.Operation{}, domain.ErrNothingToRedo
+	}
+	return row, err
+}
+
+// stepSnapshots returns the snapshots an undo or redo step goes from and to.
+func stepSnapshots(row sqlc.Operation, undo bool) (from, to sql.NullString) {
+	if undo {
+		return row.AfterJSON, row.BeforeJSON
+	}
+	return row.BeforeJSON, row.AfterJSON
+}
+
+// apply turns the entity from one snapshot into the other. It checks for
+// conflicts before writing anything.
+func (r *OperationRepository) apply(ctx context.Context, qtx *sqlc.Queries, kind, entityID string, from, to sql.NullString) error {
+	switch kind {
+	case domain.OperationTaskCreate, domain.OperationTaskUpdate, domain.OperationTaskMove, domain.OperationTaskDelete:
+		return r.applyTask(ctx, qtx, entityID, kind == domain.OperationTaskMove, from, to)
+	case domain.OperationCommentCreate, domain.OperationCommentUpdate, domain.OperationCommentDelete:
+		return r.applyComment(ctx, qtx, entityID, from, to)
+	}
+	return fmt.Errorf("unknown operation kind %q", kind)
+}
+
+func (r *OperationRepository) applyTask(ctx context.Context, qtx *sqlc.Queries, taskID string, moved bool, from, to sql.NullString) error {
+	fromTask, err := unmarshalSnapshot[domain.Task](from)
+	if err != nil {
+		return err
+	}
+	toTask, err := unmarshalSnapshot[domain.Task](to)
+	if err != nil {
+		return err
+	}
+	current, err := loadTask(ctx, qtx, taskID)
+	if err != nil {
+		return err
+	}
+	if !sameTask(current, fromTask) {
+		return fmt.Errorf("task %s: %w", taskID, domain.ErrOperationConflict)
+	}
+
+	if toTask == nil {
+		comments, err := qtx.ListComments(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		if len(comments) > 0 {
+			return fmt.Errorf("task %s has comments: %w", taskID, domain.ErrOperationConflict)
+		}
+		_, err = deleteTask(ctx, qtx, taskID, r.actor)
+		return err
+	}
+	if toTask.ColumnID != nil {
+		if _, err := qtx.GetColumn(ctx, *toTask.ColumnID); errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("column of task %s was deleted: %w", taskID, domain.ErrOperationConflict)
+		} else if err != nil {
+			return err
+		}
+	}
+	if current == nil {
+		toTask.UpdatedAt = time.Now().UTC()
+		_, err := createTask(ctx, qtx, *toTask, r.actor)
+		return err
+	}
+	return restoreTask(ctx, qtx, current, *toTask, moved, r.actor)
+}
+
+func (r *OperationRepository) applyComment(ctx context.Context, qtx *sqlc.Queries, commentID string, from, to sql.NullString) error {
+	fromComment, err := unmarshalSnapshot[domain.Comment](from)
+	if err != nil {
+		return err
+	}
+	toComment, err := unmarshalSnapshot[domain.Comment](to)
+	if err != nil {
+		return err
+	}
+	current, err := loadComment(ctx, qtx, commentID)
+	if err != nil {
+		return err
+	}
+	if !sameComment(current, fromComment) {
+		return fmt.Errorf("comment %s: %w", commentID, domain.ErrOperationConflict)
+	}
+
+	switch {
+	case toComment == nil:
+		return deleteComment(ctx, qtx, commentID)
+	case current == nil:
+		task, err := loadTask(ctx, qtx, toComment.TaskID)
+		if err != nil {
+			return err
+		}
+		if task == nil {
+			return fmt.Errorf("task of comment %s was deleted: %w", commentID, domain.ErrOperationConflict)
+		}
+		return createComment(ctx, qtx, *toComment, r.actor)
+	default:
+		return updateComment(ctx, qtx, commentID, toComment.BodyMD)
+	}
+}
+
+// sameTask reports whether the task still matches the snapshot in every
+// field tracked by history. Position is ignored so reordering other cards
+// does not block an undo.
+func sameTask(current, snapshot *domain.Task) bool {
+	if current == nil || snapshot == nil {
+		return current == nil && snapshot == nil
+	}
+	return len(diffTasks(*snapshot, *current)) == 0
+}
+
+func sameComment(current, snapshot *domain.Comment) bool {
+	if current == nil || snapshot == nil {
+		return current == nil && snapshot == nil
+	}
+	return current.BodyMD == snapshot.BodyMD
+}
@@ -0,0 +1,168 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+	"github.com/tiagokriok/kanji/internal/infrastructure/db/sqlc"
+	"github.com/tiagokriok/kanji/internal/infrastructure/store"
+)
+
+func TestOperations_UndoRedoTaskChanges(t *testing.T) {
+	adapter := newTestAdapter(t)
+	ctx := context.Background()
+	q := adapter.Queries()
+	providerID, workspaceID, boardID, columnID := seedProviderWorkspaceBoardColumn(t, ctx, q)
+	if err := q.CreateColumn(ctx, sqlc.CreateColumnParams{
+		ID: "c-done", BoardID: boardID, Name: "Done", Color: "#6B7280", Position: 2,
+	}); err != nil {
+		t.Fatalf("create column: %v", err)
+	}
+
+	s := store.New(adapter)
+	tasks := NewTaskRepository(s)
+	tasks.SetJournal("ns")
+	ops := NewOperationRepository(s)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	task := domain.Task{
+		ID: "t-undo", ProviderID: providerID, WorkspaceID: workspaceID,
+		BoardID: &boardID, ColumnID: &columnID, Title: "Draft",
+		Labels: []string{}, CreatedAt: now, UpdatedAt: now,
+	}
+	if err := tasks.Create(ctx, task); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	title := "Final"
+	if err := tasks.Update(ctx, task.ID, domain.TaskPatch{Title: &title}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	done := "c-done"
+	if err := tasks.Move(ctx, domain.MoveTaskInput{TaskID: task.ID, ColumnID: &done, Position: 1, UpdatedAt: now}); err != nil {
+		t.Fatalf("move: %v", err)
+	}
+	if err := tasks.Delete(ctx, task.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	op, err := ops.Undo(ctx, "ns", "")
+	if err != nil || op.Kind != domain.OperationTaskDelete || op.Title != "Final" {
+		t.Fatalf("undo delete = %+v, %v", op, err)
+	}
+	restored, err := tasks.GetByID(ctx, task.ID)
+	if err != nil || *restored.ColumnID != done {
+		t.Fatalf("expected the task back in Done, got %+v (%v)", restored, err)
+	}
+	if op, err = ops.Undo(ctx, "ns", ""); err != nil || op.Kind != domain.OperationTaskMove {
+		t.Fatalf("undo move = %+v, %v", op, err)
+	}
+	if op, err = ops.Undo(ctx, "ns", ""); err != nil || op.Kind != domain.OperationTaskUpdate {
+		t.Fatalf("undo update = %+v, %v", op, err)
+	}
+	got, _ := tasks.GetByID(ctx, task.ID)
+	if got.Title != "Draft" || *got.ColumnID != columnID {
+		t.Fatalf("expected the original task, got %+v", got)
+	}
+
+	if op, err = ops.Redo(ctx, "ns", ""); err != nil || op.Kind != domain.OperationTaskUpdate {
+		t.Fatalf("redo update = %+v, %v", op, err)
+	}
+	got, _ = tasks.GetByID(ctx, task.ID)
+	if got.Title != "Final" {
+		t.Fatalf("expected the redone title, got %q", got.Title)
+	}
+
+	// A new change drops what is left to redo.
+	priority := 1
+	if err := tasks.Update(ctx, task.ID, domain.TaskPatch{Priority: &priority}); err != nil {
+		t.Fatalf("update priority: %v", err)
+	}
+	if _, err := ops.Redo(ctx, "ns", ""); !errors.Is(err, domain.ErrNothingToRedo) {
+		t.Fatalf("expected nothing to redo, got %v", err)
+	}
+	if _, err := ops.Undo(ctx, "other", ""); !errors.Is(err, domain.ErrNothingToUndo) {
+		t.Fatalf("expected another namespace to have nothing to undo, got %v", err)
+	}
+
+	history, err := NewTaskEventRepository(s).ListByTask(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("list history: %v", err)
+	}
+	if last := history[len(history)-1]; last.Kind != domain.TaskEventUpdated {
+		t.Fatalf("expected undo and redo to show in history, got %+v", last)
+	}
+}
+
+func TestOperations_UndoConflictDropsOperation(t *testing.T) {
+	adapter := newTestAdapter(t)
+	ctx := context.Background()
+	q := adapter.Queries()
+	providerID, workspaceID, boardID, columnID := seedProviderWorkspaceBoardColumn(t, ctx, q)
+
+	s := store.New(adapter)
+	journaled := NewTaskRepository(s)
+	journaled.SetJournal("ns")
+	other := NewTaskRepository(s)
+	comments := NewCommentRepository(s)
+	comments.SetJournal("ns")
+	ops := NewOperationRepository(s)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, repo := range []*TaskRepository{other, journaled} {
+		id := "t-conflict"
+		if repo == journaled {
+			id = "t-first"
+		}
+		if err := repo.Create(ctx, domain.Task{
+			ID: id, ProviderID: providerID, WorkspaceID: workspaceID,
+			BoardID: &boardID, ColumnID: &columnID, Title: "Draft",
+			Labels: []string{}, CreatedAt: now, UpdatedAt: now,
+		}); err != nil {
+			t.Fatalf("create %s: %v", id, err)
+		}
+	}
+	title := "Mine"
+	if err := journaled.Update(ctx, "t-conflict", domain.TaskPatch{Title: &title}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if err := comments.Create(ctx, domain.Comment{
+		ID: "cm-1", TaskID: "t-conflict", ProviderID: providerID, BodyMD: "first", CreatedAt: now,
+	}); err != nil {
+		t.Fatalf("comment: %v", err)
+	}
+	if err := comments.Update(ctx, "cm-1", "edited", nil); err != nil {
+		t.Fatalf("edit comment: %v", err)
+	}
+
+	if op, err := ops.Undo(ctx, "ns", ""); err != nil || op.Kind != domain.OperationCommentUpdate {
+		t.Fatalf("undo comment edit = %+v, %v", op, err)
+	}
+	if c, _ := comments.GetByID(ctx, "cm-1"); c.BodyMD != "first" {
+		t.Fatalf("expected the original comment body, got %q", c.BodyMD)
+	}
+	if op, err := ops.Undo(ctx, "ns", ""); err != nil || op.Kind != domain.OperationCommentCreate {
+		t.Fatalf("undo comment = %+v, %v", op, err)
+	}
+
+	// Someone else renames the task; undoing our rename would lose theirs.
+	theirs := "Theirs"
+	if err := other.Update(ctx, "t-conflict", domain.TaskPatch{Title: &theirs}); err != nil {
+		t.Fatalf("other update: %v", err)
+	}
+	if _, err := ops.Undo(ctx, "ns", ""); !errors.Is(err, domain.ErrOperationConflict) {
+		t.Fatalf("expected a conflict, got %v", err)
+	}
+	if got, _ := other.GetByID(ctx, "t-conflict"); got.Title != "Theirs" {
+		t.Fatalf("conflicting undo must not write, got %q", got.Title)
+	}
+	// The conflicting operation is gone and the one before it is next.
+	if op, err := ops.Undo(ctx, "ns", ""); err != nil || op.Kind != domain.OperationTaskCreate || op.EntityID != "t-first" {
+		t.Fatalf("expected to undo the first create next, got %+v (%v)", op, err)
+	}
+	if _, err := other.GetByID(ctx, "t-first"); err == nil {
+		t.Fatalf("expected the created task to be deleted")
+	}
+}
@@ -30,3 +30,35 @@ func queryListColumns(ctx context.Context, q *sqlc.Queries, boardID string) ([]d
 	}
 	return result, nil
 }
+
+// checkTaskVersion returns a *domain.VersionConflictError when the task is
+// no longer at the expected version. A nil expected version skips the check.
+func checkTaskVersion(ctx context.Context, q *sqlc.Queries, taskID string, expected *int) error {
+	if expected == nil {
+		return nil
+	}
+	version, err := q.GetTaskVersion(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	return versionConflict(domain.SyncEntityTask, taskID, *expected, version)
+}
+
+// checkCommentVersion is checkTaskVersion for comments.
+func checkCommentVersion(ctx context.Context, q *sqlc.Queries, commentID string, expected *int) error {
+	if expected == nil {
+		return nil
+	}
+	version, err := q.GetCommentVersion(ctx, commentID)
+	if err != nil {
+		return err
+	}
+	return versionConflict(domain.SyncEntityComment, commentID, *expected, version)
+}
+
+func versionConflict(entity, id string, expected int, actual int64) error {
+	if int64(expected) == actual {
+		return nil
+	}
+	return &domain.VersionConflictError{Entity: entity, ID: id, Expected: expected, Actual: int(actual)}
+}
@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"context"
+	"strings"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+	"github.com/tiagokriok/kanji/internal/infrastructure/db/sqlc"
+	"github.com/tiagokriok/kanji/internal/infrastructure/store"
+)
+
+// SearchRepository queries the task_search full-text index, which triggers
+// keep in step with tasks and comments.
+type SearchRepository struct {
+	store store.Store
+}
+
+func NewSearchRepository(s store.Store) *SearchRepository {
+	return &SearchRepository{store: s}
+}
+
+func (r *SearchRepository) Search(ctx context.Context, filter domain.SearchFilter) ([]domain.SearchHit, error) {
+	query := ftsQuery(filter.Query)
+	if query == "" {
+		return []domain.SearchHit{}, nil
+	}
+	// SQLite treats a negative LIMIT as no limit.
+	limit := int64(filter.Limit)
+	if limit <= 0 {
+		limit = -1
+	}
+	rows, err := r.store.Queries().SearchTasks(ctx, sqlc.SearchTasksParams{
+		MatchStart:  domain.SearchMatchStart,
+		MatchEnd:    domain.SearchMatchEnd,
+		Query:       query,
+		WorkspaceID: filter.WorkspaceID,
+		BoardID:     filter.BoardID,
+		Limit:       limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.SearchHit, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, fromSQLSearchRow(row))
+	}
+	return result, nil
+}
+
+// ftsQuery turns free text into an FTS5 query that matches tasks containing
+// every word, each as a prefix. Words are quoted so FTS5 operators and
+// punctuation in the input are taken literally.
+func ftsQuery(text string) string {
+	words := strings.Fields(text)
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		terms = append(terms, `"`+strings.ReplaceAll(word, `"`, `""`)+`"*`)
+	}
+	return strings.Join(terms, " ")
+}
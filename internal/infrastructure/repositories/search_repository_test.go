@@ -0,0 +1,93 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+	"github.com/tiagokriok/kanji/internal/infrastructure/store"
+)
+
+func TestSearch_IndexFollowsTasksAndComments(t *testing.T) {
+	adapter := newTestAdapter(t)
+	ctx := context.Background()
+	q := adapter.Queries()
+	providerID, workspaceID, boardID, columnID := seedProviderWorkspaceBoardColumn(t, ctx, q)
+
+	s := store.New(adapter)
+	tasks := NewTaskRepository(s)
+	comments := NewCommentRepository(s)
+	search := NewSearchRepository(s)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, task := range []domain.Task{
+		{ID: "t-title", Title: "Fix login redirect", Labels: []string{}},
+		{ID: "t-desc", Title: "Session cleanup", DescriptionMD: "The login cookie expires too early", Labels: []string{}},
+		{ID: "t-label", Title: "Polish", Labels: []string{"frontend"}},
+	} {
+		task.ProviderID, task.WorkspaceID, task.BoardID, task.ColumnID = providerID, workspaceID, &boardID, &columnID
+		task.CreatedAt, task.UpdatedAt = now, now
+		if err := tasks.Create(ctx, task); err != nil {
+			t.Fatalf("create %s: %v", task.ID, err)
+		}
+	}
+	if err := comments.Create(ctx, domain.Comment{
+		ID: "cm-1", TaskID: "t-label", ProviderID: providerID, BodyMD: "Blocked on the login page", CreatedAt: now,
+	}); err != nil {
+		t.Fatalf("comment: %v", err)
+	}
+
+	find := func(query string) []string {
+		t.Helper()
+		hits, err := search.Search(ctx, domain.SearchFilter{WorkspaceID: workspaceID, Query: query})
+		if err != nil {
+			t.Fatalf("search %q: %v", query, err)
+		}
+		ids := make([]string, len(hits))
+		for i, hit := range hits {
+			ids[i] = hit.Task.ID
+		}
+		return ids
+	}
+
+	got := find("login")
+	if len(got) != 3 || got[0] != "t-title" {
+		t.Fatalf("login = %v, want all three with the title match first", got)
+	}
+	if got := find("front"); len(got) != 1 || got[0] != "t-label" {
+		t.Fatalf("prefix search on labels = %v", got)
+	}
+	if got := find(`cookie "expires`); len(got) != 1 || got[0] != "t-desc" {
+		t.Fatalf("quoted input should be taken literally, got %v", got)
+	}
+
+	hits, err := search.Search(ctx, domain.SearchFilter{WorkspaceID: workspaceID, Query: "redirect"})
+	if err != nil || len(hits) != 1 {
+		t.Fatalf("redirect: %v (%v)", hits, err)
+	}
+	if want := "Fix login " + domain.SearchMatchStart + "redirect" + domain.SearchMatchEnd; hits[0].Snippet != want {
+		t.Fatalf("snippet = %q, want %q", hits[0].Snippet, want)
+	}
+
+	title := "Fix logout redirect"
+	if err := tasks.Update(ctx, "t-title", domain.TaskPatch{Title: &title}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if err := comments.Delete(ctx, "cm-1"); err != nil {
+		t.Fatalf("delete comment: %v", err)
+	}
+	if err := tasks.Delete(ctx, "t-desc"); err != nil {
+		t.Fatalf("delete task: %v", err)
+	}
+	if got := find("login"); len(got) != 0 {
+		t.Fatalf("expected the index to follow the changes, got %v", got)
+	}
+	if got := find("logout"); len(got) != 1 {
+		t.Fatalf("expected the renamed task, got %v", got)
+	}
+	other, err := search.Search(ctx, domain.SearchFilter{WorkspaceID: workspaceID, BoardID: "b-other", Query: "logout"})
+	if err != nil || len(other) != 0 {
+		t.Fatalf("expected no hits on another board, got %v (%v)", other, err)
+	}
+}
@@ -32,6 +32,8 @@ func (r *SetupRepository) ListProviders(ctx context.Context) ([]domain.Provider,
 	return result, nil
 }
 
+// CreateProvider does not enqueue a sync entry: providers are the sync
+// targets themselves, not synced entities.
 func (r *SetupRepository) CreateProvider(ctx context.Context, provider domain.Provider) error {
 	return r.store.Write(ctx, "create provider", func(tx store.Tx) error {
 		qtx := tx.Queries()
@@ -45,6 +47,49 @@ func (r *SetupRepository) CreateProvider(ctx context.Context, provider domain.Pr
 	})
 }
 
+// SetProviderAuth replaces the stored credentials of the given providers in
+// one transaction. A nil value clears them.
+func (r *SetupRepository) SetProviderAuth(ctx context.Context, auth map[string]*string) error {
+	return r.store.Write(ctx, "set provider auth", func(tx store.Tx) error {
+		qtx := tx.Queries()
+		for providerID, value := range auth {
+			if err := qtx.UpdateProviderAuth(ctx, sqlc.UpdateProviderAuthParams{
+				AuthJSON: nullString(value),
+				ID:       providerID,
+			}); err != nil {
+				return fmt.Errorf("update provider %s: %w", providerID, err)
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteProvider removes a provider together with its queued sync entries,
+// snapshots and conflicts.
+func (r *SetupRepository) DeleteProvider(ctx context.Context, providerID string) error {
+	providerID = strings.TrimSpace(providerID)
+	if providerID == "" {
+		return fmt.Errorf("provider id is required")
+	}
+
+	return r.store.Write(ctx, "delete provider", func(tx store.Tx) error {
+		qtx := tx.Queries()
+		if err := qtx.DeleteSyncItemsByProvider(ctx, providerID); err != nil {
+			return fmt.Errorf("delete sync items: %w", err)
+		}
+		if err := qtx.DeleteSyncSnapshotsByProvider(ctx, providerID); err != nil {
+			return fmt.Errorf("delete sync snapshots: %w", err)
+		}
+		if err := qtx.DeleteSyncConflictsByProvider(ctx, providerID); err != nil {
+			return fmt.Errorf("delete sync conflicts: %w", err)
+		}
+		if err := qtx.DeleteProvider(ctx, providerID); err != nil {
+			return fmt.Errorf("delete provider: %w", err)
+		}
+		return nil
+	})
+}
+
 func (r *SetupRepository) ListWorkspaces(ctx context.Context) ([]domain.Workspace, error) {
 	items, err := r.store.Queries().ListWorkspaces(ctx)
 	if err != nil {
@@ -60,12 +105,19 @@ func (r *SetupRepository) ListWorkspaces(ctx context.Context) ([]domain.Workspac
 func (r *SetupRepository) CreateWorkspace(ctx context.Context, workspace domain.Workspace) error {
 	return r.store.Write(ctx, "create workspace", func(tx store.Tx) error {
 		qtx := tx.Queries()
-		return qtx.CreateWorkspace(ctx, sqlc.CreateWorkspaceParams{
+		if err := qtx.CreateWorkspace(ctx, sqlc.CreateWorkspaceParams{
 			ID:         workspace.ID,
 			ProviderID: workspace.ProviderID,
 			RemoteID:   nullString(workspace.RemoteID),
 			Name:       workspace.Name,
-		})
+		}); err != nil {
+			return err
+		}
+		item, err := workspaceSyncItem(ctx, qtx, workspace.ID, domain.SyncActionCreate)
+		if err != nil {
+			return err
+		}
+		return enqueueSync(ctx, qtx, item)
 	})
 }
 
@@ -81,10 +133,17 @@ func (r *SetupRepository) RenameWorkspace(ctx context.Context, workspaceID, name
 
 	return r.store.Write(ctx, "rename workspace", func(tx store.Tx) error {
 		qtx := tx.Queries()
-		return qtx.UpdateWorkspaceName(ctx, sqlc.UpdateWorkspaceNameParams{
+		if err := qtx.UpdateWorkspaceName(ctx, sqlc.UpdateWorkspaceNameParams{
 			Name: name,
 			ID:   workspaceID,
-		})
+		}); err != nil {
+			return err
+		}
+		item, err := workspaceSyncItem(ctx, qtx, workspaceID, domain.SyncActionUpdate)
+		if err != nil {
+			return err
+		}
+		return enqueueSync(ctx, qtx, item)
 	})
 }
 
@@ -96,22 +155,41 @@ func (r *SetupRepository) DeleteWorkspace(ctx context.Context, workspaceID strin
 
 	return r.store.Write(ctx, "delete workspace", func(tx store.Tx) error {
 		qtx := tx.Queries()
+		item, err := workspaceSyncItem(ctx, qtx, workspaceID, domain.SyncActionDelete)
+		if err != nil {
+			return err
+		}
 		if err := qtx.DeleteCommentsByWorkspace(ctx, workspaceID); err != nil {
 			return fmt.Errorf("delete comments: %w", err)
 		}
+		if err := qtx.DeleteTaskEventsByWorkspace(ctx, workspaceID); err != nil {
+			return fmt.Errorf("delete task history: %w", err)
+		}
 		if err := qtx.DeleteTasksByWorkspace(ctx, workspaceID); err != nil {
 			return fmt.Errorf("delete tasks: %w", err)
 		}
+		if err := qtx.DeleteViewsByWorkspace(ctx, workspaceID); err != nil {
+			return fmt.Errorf("delete views: %w", err)
+		}
+		if err := qtx.DeleteLabelsByWorkspace(ctx, workspaceID); err != nil {
+			return fmt.Errorf("delete labels: %w", err)
+		}
 		if err := qtx.DeleteColumnsByWorkspace(ctx, workspaceID); err != nil {
 			return fmt.Errorf("delete columns: %w", err)
 		}
 		if err := qtx.DeleteBoardsByWorkspace(ctx, workspaceID); err != nil {
 			return fmt.Errorf("delete boards: %w", err)
 		}
+		if err := qtx.DeleteWebhookDeliveriesByWorkspace(ctx, workspaceID); err != nil {
+			return fmt.Errorf("delete webhook deliveries: %w", err)
+		}
+		if err := qtx.DeleteWebhooksByWorkspace(ctx, workspaceID); err != nil {
+			return fmt.Errorf("delete webhooks: %w", err)
+		}
 		if err := qtx.DeleteWorkspace(ctx, workspaceID); err != nil {
 			return fmt.Errorf("delete workspace: %w", err)
 		}
-		return nil
+		return enqueueSync(ctx, qtx, item)
 	})
 }
 
@@ -121,14 +199,7 @@ func (r *SetupRepository) ListBoards(ctx context.Context, workspaceID string) ([
 
 func (r *SetupRepository) CreateBoard(ctx context.Context, board domain.Board) error {
 	return r.store.Write(ctx, "create board", func(tx store.Tx) error {
-		qtx := tx.Queries()
-		return qtx.CreateBoard(ctx, sqlc.CreateBoardParams{
-			ID:          board.ID,
-			WorkspaceID: board.WorkspaceID,
-			RemoteID:    nullString(board.RemoteID),
-			Name:        board.Name,
-			ViewDefault: board.ViewDefault,
-		})
+		return createBoard(ctx, tx.Queries(), board)
 	})
 }
 
@@ -144,10 +215,17 @@ func (r *SetupRepository) RenameBoard(ctx context.Context, boardID, name string)
 
 	return r.store.Write(ctx, "rename board", func(tx store.Tx) error {
 		qtx := tx.Queries()
-		return qtx.UpdateBoardName(ctx, sqlc.UpdateBoardNameParams{
+		if err := qtx.UpdateBoardName(ctx, sqlc.UpdateBoardNameParams{
 			Name: name,
 			ID:   boardID,
-		})
+		}); err != nil {
+			return err
+		}
+		item, err := boardSyncItem(ctx, qtx, boardID, domain.SyncActionUpdate)
+		if err != nil {
+			return err
+		}
+		return enqueueSync(ctx, qtx, item)
 	})
 }
 
@@ -159,19 +237,26 @@ func (r *SetupRepository) DeleteBoard(ctx context.Context, boardID string) error
 
 	return r.store.Write(ctx, "delete board", func(tx store.Tx) error {
 		qtx := tx.Queries()
+		item, err := boardSyncItem(ctx, qtx, boardID, domain.SyncActionDelete)
+		if err != nil {
+			return err
+		}
 		if err := qtx.DeleteCommentsByBoard(ctx, boardID); err != nil {
 			return fmt.Errorf("delete comments: %w", err)
 		}
 		if err := qtx.DeleteTasksByBoard(ctx, boardID); err != nil {
 			return fmt.Errorf("delete tasks: %w", err)
 		}
+		if err := qtx.DeleteViewsByBoard(ctx, boardID); err != nil {
+			return fmt.Errorf("delete views: %w", err)
+		}
 		if err := qtx.DeleteColumnsByBoard(ctx, boardID); err != nil {
 			return fmt.Errorf("delete columns: %w", err)
 		}
 		if err := qtx.DeleteBoard(ctx, boardID); err != nil {
 			return fmt.Errorf("delete board: %w", err)
 		}
-		return nil
+		return enqueueSync(ctx, qtx, item)
 	})
 }
 
@@ -181,16 +266,7 @@ func (r *SetupRepository) ListColumns(ctx context.Context, boardID string) ([]do
 
 func (r *SetupRepository) CreateColumn(ctx context.Context, column domain.Column) error {
 	return r.store.Write(ctx, "create column", func(tx store.Tx) error {
-		qtx := tx.Queries()
-		return qtx.CreateColumn(ctx, sqlc.CreateColumnParams{
-			ID:       column.ID,
-			BoardID:  column.BoardID,
-			RemoteID: nullString(column.RemoteID),
-			Name:     column.Name,
-			Color:    normalizeHexColor(column.Color),
-			Position: int64(column.Position),
-			WipLimit: nullInt(column.WIPLimit),
-		})
+		return createColumn(ctx, tx.Queries(), column)
 	})
 }
 
@@ -237,7 +313,11 @@ func (r *SetupRepository) UpdateColumn(ctx context.Context, columnID string, nam
 				return fmt.Errorf("update column wip limit: %w", err)
 			}
 		}
-		return nil
+		item, err := columnSyncItem(ctx, qtx, columnID, domain.SyncActionUpdate)
+		if err != nil {
+			return err
+		}
+		return enqueueSync(ctx, qtx, item)
 	})
 }
 
@@ -268,6 +348,13 @@ func (r *SetupRepository) ReorderColumns(ctx context.Context, boardID string, or
 			if affected == 0 {
 				return fmt.Errorf("column %s not found in board", columnID)
 			}
+			item, err := columnSyncItem(ctx, qtx, columnID, domain.SyncActionUpdate)
+			if err != nil {
+				return err
+			}
+			if err := enqueueSync(ctx, qtx, item); err != nil {
+				return err
+			}
 		}
 		return nil
 	})
@@ -280,6 +367,52 @@ func (r *SetupRepository) DeleteColumn(ctx context.Context, columnID string) err
 	}
 
 	return r.store.Write(ctx, "delete column", func(tx store.Tx) error {
-		return tx.Queries().DeleteColumn(ctx, columnID)
+		qtx := tx.Queries()
+		item, err := columnSyncItem(ctx, qtx, columnID, domain.SyncActionDelete)
+		if err != nil {
+			return err
+		}
+		if err := qtx.DeleteColumn(ctx, columnID); err != nil {
+			return err
+		}
+		return enqueueSync(ctx, qtx, item)
 	})
 }
+
+// createBoard inserts a board and queues it for sync.
+func createBoard(ctx context.Context, qtx *sqlc.Queries, board domain.Board) error {
+	if err := qtx.CreateBoard(ctx, sqlc.CreateBoardParams{
+		ID:          board.ID,
+		WorkspaceID: board.WorkspaceID,
+		RemoteID:    nullString(board.RemoteID),
+		Name:        board.Name,
+		ViewDefault: board.ViewDefault,
+	}); err != nil {
+		return err
+	}
+	item, err := boardSyncItem(ctx, qtx, board.ID, domain.SyncActionCreate)
+	if err != nil {
+		return err
+	}
+	return enqueueSync(ctx, qtx, item)
+}
+
+// createColumn inserts a column and queues it for sync.
+func createColumn(ctx context.Context, qtx *sqlc.Queries, column domain.Column) error {
+	if err := qtx.CreateColumn(ctx, sqlc.CreateColumnParams{
+		ID:       column.ID,
+		BoardID:  column.BoardID,
+		RemoteID: nullString(column.RemoteID),
+		Name:     column.Name,
+		Color:    normalizeHexColor(column.Color),
+		Position: int64(column.Position),
+		WipLimit: nullInt(column.WIPLimit),
+	}); err != nil {
+		return err
+	}
+	item, err := columnSyncItem(ctx, qtx, column.ID, domain.SyncActionCreate)
+	if err != nil {
+		return err
+	}
+	return enqueueSync(ctx, qtx, item)
+}
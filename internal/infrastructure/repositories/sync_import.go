@@ -0,0 +1,147 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+	"github.com/tiagokriok/kanji/internal/infrastructure/db/sqlc"
+	"github.com/tiagokriok/kanji/internal/infrastructure/store"
+)
+
+// Imports write state pulled from a provider. The change already exists on
+// the remote side, so unlike regular writes they never enqueue outbox
+// entries. They are recorded in task history with the sync actor.
+
+// ListBoardTasks returns every task of a board.
+func (r *SyncQueueRepository) ListBoardTasks(ctx context.Context, workspaceID, boardID string) ([]domain.Task, error) {
+	items, err := r.store.Queries().ListTasks(ctx, sqlc.ListTasksParams{
+		WorkspaceID: workspaceID,
+		BoardID:     boardID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.Task, 0, len(items))
+	for _, item := range items {
+		result = append(result, fromSQLTask(item))
+	}
+	return result, nil
+}
+
+// ListTaskComments returns the comments of a task, oldest first.
+func (r *SyncQueueRepository) ListTaskComments(ctx context.Context, taskID string) ([]domain.Comment, error) {
+	items, err := r.store.Queries().ListComments(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.Comment, 0, len(items))
+	for _, item := range items {
+		result = append(result, fromSQLComment(item))
+	}
+	return result, nil
+}
+
+// ImportTask inserts a pulled task or overwrites the local copy.
+func (r *SyncQueueRepository) ImportTask(ctx context.Context, task domain.Task, place domain.PlaceFunc) error {
+	return r.store.Write(ctx, "import task", func(tx store.Tx) error {
+		qtx := tx.Queries()
+		before, err := loadTask(ctx, qtx, task.ID)
+		if err != nil {
+			return err
+		}
+		if err := placeImportedTask(ctx, qtx, before, &task, place); err != nil {
+			return err
+		}
+		if err := qtx.UpsertTask(ctx, upsertTaskParams(task)); err != nil {
+			return err
+		}
+		if err := ensureLabels(ctx, qtx, task.WorkspaceID, task.Labels); err != nil {
+			return err
+		}
+		after, err := loadTask(ctx, qtx, task.ID)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return recordTaskEvent(ctx, qtx, after, domain.TaskEventCreated, nil, syncActor)
+		}
+		return recordTaskChanges(ctx, qtx, before, after, domain.TaskEventUpdated, syncActor)
+	})
+}
+
+// placeImportedTask positions a pulled task that is new or changes column
+// with place, and keeps the position of any other.
+func placeImportedTask(ctx context.Context, qtx *sqlc.Queries, before *domain.Task, task *domain.Task, place domain.PlaceFunc) error {
+	if place == nil {
+		return nil
+	}
+	if before != nil && sameColumn(before.ColumnID, task.ColumnID) {
+		task.Position = before.Position
+		return nil
+	}
+	position, err := placeTask(ctx, qtx, task.WorkspaceID, task.BoardID, task.ColumnID, task.ID, place)
+	if err != nil {
+		return err
+	}
+	task.Position = position
+	return nil
+}
+
+func sameColumn(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func upsertTaskParams(task domain.Task) sqlc.UpsertTaskParams {
+	return sqlc.UpsertTaskParams{
+		ID:              task.ID,
+		ProviderID:      task.ProviderID,
+		WorkspaceID:     task.WorkspaceID,
+		BoardID:         nullString(task.BoardID),
+		ColumnID:        nullString(task.ColumnID),
+		RemoteID:        nullString(task.RemoteID),
+		Title:           task.Title,
+		DescriptionMd:   task.DescriptionMD,
+		Status:          nullString(task.Status),
+		Priority:        int64(task.Priority),
+		DueAt:           nullableTimeToString(task.DueAt),
+		EstimateMinutes: nullInt(task.EstimateMinutes),
+		Assignee:        nullString(task.Assignee),
+		LabelsJSON:      marshalLabels(task.Labels),
+		Position:        task.Position,
+		CreatedAt:       task.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:       task.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// ImportComment inserts a pulled comment or overwrites the local copy.
+func (r *SyncQueueRepository) ImportComment(ctx context.Context, comment domain.Comment) error {
+	return r.store.Write(ctx, "import comment", func(tx store.Tx) error {
+		qtx := tx.Queries()
+		_, err := qtx.GetComment(ctx, comment.ID)
+		isNew := errors.Is(err, sql.ErrNoRows)
+		if err != nil && !isNew {
+			return err
+		}
+		if err := qtx.UpsertComment(ctx, sqlc.UpsertCommentParams{
+			ID:         comment.ID,
+			TaskID:     comment.TaskID,
+			ProviderID: comment.ProviderID,
+			RemoteID:   nullString(comment.RemoteID),
+			BodyMd:     comment.BodyMD,
+			Author:     nullString(comment.Author),
+			CreatedAt:  comment.CreatedAt.UTC().Format(time.RFC3339),
+		}); err != nil {
+			return err
+		}
+		if !isNew {
+			return nil
+		}
+		return recordComment(ctx, qtx, comment, syncActor)
+	})
+}
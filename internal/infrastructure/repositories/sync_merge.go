@@ -0,0 +1,153 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+	"github.com/tiagokriok/kanji/internal/infrastructure/db/sqlc"
+	"github.com/tiagokriok/kanji/internal/infrastructure/store"
+)
+
+// GetSnapshot returns the last synced state of an entity, or nil when it has
+// never been synced.
+func (r *SyncQueueRepository) GetSnapshot(ctx context.Context, entity, entityID string) (*domain.SyncSnapshot, error) {
+	row, err := r.store.Queries().GetSyncSnapshot(ctx, sqlc.GetSyncSnapshotParams{Entity: entity, EntityID: entityID})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	snapshot := fromSQLSyncSnapshot(row)
+	return &snapshot, nil
+}
+
+func (r *SyncQueueRepository) SaveSnapshot(ctx context.Context, snapshot domain.SyncSnapshot) error {
+	return r.store.Write(ctx, "save sync snapshot", func(tx store.Tx) error {
+		return tx.Queries().UpsertSyncSnapshot(ctx, upsertSnapshotParams(snapshot))
+	})
+}
+
+// ForgetEntity drops the snapshot and any open conflict of an entity that no
+// longer exists on either side.
+func (r *SyncQueueRepository) ForgetEntity(ctx context.Context, entity, entityID string) error {
+	return r.store.Write(ctx, "forget sync entity", func(tx store.Tx) error {
+		qtx := tx.Queries()
+		if err := qtx.DeleteSyncSnapshot(ctx, sqlc.DeleteSyncSnapshotParams{Entity: entity, EntityID: entityID}); err != nil {
+			return err
+		}
+		return qtx.DeleteSyncConflict(ctx, sqlc.DeleteSyncConflictParams{Entity: entity, EntityID: entityID})
+	})
+}
+
+// ListConflicts returns open conflicts in the order they were detected. An
+// empty providerID lists all of them.
+func (r *SyncQueueRepository) ListConflicts(ctx context.Context, providerID string) ([]domain.SyncConflict, error) {
+	items, err := r.store.Queries().ListSyncConflicts(ctx, strings.TrimSpace(providerID))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.SyncConflict, 0, len(items))
+	for _, item := range items {
+		result = append(result, fromSQLSyncConflict(item))
+	}
+	return result, nil
+}
+
+// SaveConflict records a conflict. A conflict already open for the same
+// entity keeps its ID and detection time and takes the new fields and copies.
+func (r *SyncQueueRepository) SaveConflict(ctx context.Context, conflict domain.SyncConflict) error {
+	fields, err := json.Marshal(conflict.Fields)
+	if err != nil {
+		return err
+	}
+	if conflict.ID == "" {
+		conflict.ID = uuid.NewString()
+	}
+	if conflict.CreatedAt.IsZero() {
+		conflict.CreatedAt = time.Now().UTC()
+	}
+	return r.store.Write(ctx, "save sync conflict", func(tx store.Tx) error {
+		return tx.Queries().UpsertSyncConflict(ctx, sqlc.UpsertSyncConflictParams{
+			ID:         conflict.ID,
+			ProviderID: conflict.ProviderID,
+			Entity:     conflict.Entity,
+			EntityID:   conflict.EntityID,
+			FieldsJSON: string(fields),
+			BaseJSON:   conflict.BaseJSON,
+			RemoteJSON: conflict.RemoteJSON,
+			CreatedAt:  conflict.CreatedAt.UTC().Format(time.RFC3339),
+		})
+	})
+}
+
+func (r *SyncQueueRepository) DeleteConflict(ctx context.Context, entity, entityID string) error {
+	return r.store.Write(ctx, "delete sync conflict", func(tx store.Tx) error {
+		return tx.Queries().DeleteSyncConflict(ctx, sqlc.DeleteSyncConflictParams{Entity: entity, EntityID: entityID})
+	})
+}
+
+// MergeTask stores a merged task and replaces its queued changes with a
+// single update carrying the merged state, so stale snapshots in the queue
+// cannot overwrite the remote changes that were merged in. base becomes the
+// task's snapshot and any open conflict is dropped.
+func (r *SyncQueueRepository) MergeTask(ctx context.Context, task domain.Task, base domain.SyncSnapshot, place domain.PlaceFunc) error {
+	return r.store.Write(ctx, "merge task", func(tx store.Tx) error {
+		qtx := tx.Queries()
+		before, err := loadTask(ctx, qtx, task.ID)
+		if err != nil {
+			return err
+		}
+		if err := placeImportedTask(ctx, qtx, before, &task, place); err != nil {
+			return err
+		}
+		if err := qtx.UpsertTask(ctx, upsertTaskParams(task)); err != nil {
+			return err
+		}
+		if err := ensureLabels(ctx, qtx, task.WorkspaceID, task.Labels); err != nil {
+			return err
+		}
+		after, err := loadTask(ctx, qtx, task.ID)
+		if err != nil {
+			return err
+		}
+		if err := recordTaskChanges(ctx, qtx, before, after, domain.TaskEventUpdated, syncActor); err != nil {
+			return err
+		}
+		if err := qtx.DeleteSyncItemsByEntity(ctx, sqlc.DeleteSyncItemsByEntityParams{Entity: domain.SyncEntityTask, EntityID: task.ID}); err != nil {
+			return err
+		}
+		item, err := taskSyncItem(ctx, qtx, task.ID, domain.SyncActionUpdate)
+		if err != nil {
+			return err
+		}
+		if err := enqueueSync(ctx, qtx, item); err != nil {
+			return err
+		}
+		if err := qtx.UpsertSyncSnapshot(ctx, upsertSnapshotParams(base)); err != nil {
+			return err
+		}
+		return qtx.DeleteSyncConflict(ctx, sqlc.DeleteSyncConflictParams{Entity: domain.SyncEntityTask, EntityID: task.ID})
+	})
+}
+
+func upsertSnapshotParams(snapshot domain.SyncSnapshot) sqlc.UpsertSyncSnapshotParams {
+	syncedAt := snapshot.SyncedAt
+	if syncedAt.IsZero() {
+		syncedAt = time.Now()
+	}
+	return sqlc.UpsertSyncSnapshotParams{
+		Entity:      snapshot.Entity,
+		EntityID:    snapshot.EntityID,
+		ProviderID:  snapshot.ProviderID,
+		PayloadJSON: snapshot.PayloadJSON,
+		SyncedAt:    syncedAt.UTC().Format(time.RFC3339),
+	}
+}
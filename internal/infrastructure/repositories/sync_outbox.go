@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+	"github.com/tiagokriok/kanji/internal/infrastructure/db/sqlc"
+)
+
+// Outbox helpers build sync_queue rows from the current state of an entity.
+// They run inside the caller's write transaction so the queue can never
+// disagree with the data it describes. A nil item means the entity does not
+// exist and nothing needs to be synced. Changes of the local provider are
+// never queued: it has no remote to push them to.
+
+const localProviderType = "local"
+
+func newSyncItem(providerID, entity, entityID, action string, snapshot any) (*sqlc.CreateSyncItemParams, error) {
+	payload, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("encode %s sync payload: %w", entity, err)
+	}
+	return &sqlc.CreateSyncItemParams{
+		ID:          uuid.NewString(),
+		ProviderID:  providerID,
+		Entity:      entity,
+		EntityID:    entityID,
+		Action:      action,
+		PayloadJSON: string(payload),
+		CreatedAt:   time.Now().UTC().Format(time.RFC3339),
+	}, nil
+}
+
+func enqueueSync(ctx context.Context, qtx *sqlc.Queries, item *sqlc.CreateSyncItemParams) error {
+	if item == nil {
+		return nil
+	}
+	providerType, err := qtx.GetProviderType(ctx, item.ProviderID)
+	if err != nil {
+		return fmt.Errorf("load %s provider for sync: %w", item.Entity, err)
+	}
+	if providerType == localProviderType {
+		return nil
+	}
+	if err := qtx.CreateSyncItem(ctx, *item); err != nil {
+		return fmt.Errorf("enqueue %s sync: %w", item.Entity, err)
+	}
+	return nil
+}
+
+func workspaceSyncItem(ctx context.Context, qtx *sqlc.Queries, workspaceID, action string) (*sqlc.CreateSyncItemParams, error) {
+	row, err := qtx.GetWorkspace(ctx, workspaceID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load workspace for sync: %w", err)
+	}
+	workspace := fromSQLWorkspace(row)
+	return newSyncItem(workspace.ProviderID, domain.SyncEntityWorkspace, workspace.ID, action, workspace)
+}
+
+func boardSyncItem(ctx context.Context, qtx *sqlc.Queries, boardID, action string) (*sqlc.CreateSyncItemParams, error) {
+	row, err := qtx.GetBoard(ctx, boardID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load board for sync: %w", err)
+	}
+	workspace, err := qtx.GetWorkspace(ctx, row.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("load board workspace for sync: %w", err)
+	}
+	board := fromSQLBoard(row)
+	return newSyncItem(workspace.ProviderID, domain.SyncEntityBoard, board.ID, action, board)
+}
+
+func columnSyncItem(ctx context.Context, qtx *sqlc.Queries, columnID, action string) (*sqlc.CreateSyncItemParams, error) {
+	row, err := qtx.GetColumn(ctx, columnID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load column for sync: %w", err)
+	}
+	board, err := qtx.GetBoard(ctx, row.BoardID)
+	if err != nil {
+		return nil, fmt.Errorf("load column board for sync: %w", err)
+	}
+	workspace, err := qtx.GetWorkspace(ctx, board.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("load column workspace for sync: %w", err)
+	}
+	column := fromSQLColumn(row)
+	return newSyncItem(workspace.ProviderID, domain.SyncEntityColumn, column.ID, action, column)
+}
+
+func taskSyncItem(ctx context.Context, qtx *sqlc.Queries, taskID, action string) (*sqlc.CreateSyncItemParams, error) {
+	row, err := qtx.GetTask(ctx, taskID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load task for sync: %w", err)
+	}
+	task := fromSQLTask(row)
+	return newSyncItem(task.ProviderID, domain.SyncEntityTask, task.ID, action, task)
+}
+
+func commentSyncItem(ctx context.Context, qtx *sqlc.Queries, commentID, action string) (*sqlc.CreateSyncItemParams, error) {
+	row, err := qtx.GetComment(ctx, commentID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load comment for sync: %w", err)
+	}
+	comment := fromSQLComment(row)
+	return newSyncItem(comment.ProviderID, domain.SyncEntityComment, comment.ID, action, comment)
+}
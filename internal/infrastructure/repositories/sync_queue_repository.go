@@ -0,0 +1,202 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+	"github.com/tiagokriok/kanji/internal/infrastructure/db/sqlc"
+	"github.com/tiagokriok/kanji/internal/infrastructure/store"
+)
+
+type SyncQueueRepository struct {
+	store store.Store
+}
+
+func NewSyncQueueRepository(s store.Store) *SyncQueueRepository {
+	return &SyncQueueRepository{store: s}
+}
+
+// List returns queued items in the order they were recorded. An empty
+// providerID lists the whole queue.
+func (r *SyncQueueRepository) List(ctx context.Context, providerID string) ([]domain.SyncItem, error) {
+	items, err := r.store.Queries().ListSyncItems(ctx, strings.TrimSpace(providerID))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.SyncItem, 0, len(items))
+	for _, item := range items {
+		result = append(result, fromSQLSyncItem(item))
+	}
+	return result, nil
+}
+
+// Complete removes an item once its provider has acknowledged it.
+func (r *SyncQueueRepository) Complete(ctx context.Context, itemID string) error {
+	return r.store.Write(ctx, "complete sync item", func(tx store.Tx) error {
+		_, err := tx.Queries().DeleteSyncItem(ctx, itemID)
+		return err
+	})
+}
+
+func (r *SyncQueueRepository) RecordFailure(ctx context.Context, itemID string, lastError string, nextAttemptAt time.Time) error {
+	return r.store.Write(ctx, "record sync failure", func(tx store.Tx) error {
+		return tx.Queries().RecordSyncFailure(ctx, sqlc.RecordSyncFailureParams{
+			LastError:     sql.NullString{String: lastError, Valid: true},
+			NextAttemptAt: nullableTimeToString(&nextAttemptAt),
+			ID:            itemID,
+		})
+	})
+}
+
+// Retry clears the attempt counter and backoff of a single item so the next
+// run picks it up immediately.
+func (r *SyncQueueRepository) Retry(ctx context.Context, itemID string) (int, error) {
+	itemID = strings.TrimSpace(itemID)
+	if itemID == "" {
+		return 0, fmt.Errorf("sync item id is required")
+	}
+	var affected int64
+	err := r.store.Write(ctx, "retry sync item", func(tx store.Tx) error {
+		var execErr error
+		affected, execErr = tx.Queries().ResetSyncItem(ctx, itemID)
+		return execErr
+	})
+	return int(affected), err
+}
+
+// RetryExhausted resets every item that has reached maxAttempts.
+func (r *SyncQueueRepository) RetryExhausted(ctx context.Context, maxAttempts int) (int, error) {
+	var affected int64
+	err := r.store.Write(ctx, "retry exhausted sync items", func(tx store.Tx) error {
+		var execErr error
+		affected, execErr = tx.Queries().ResetExhaustedSyncItems(ctx, int64(maxAttempts))
+		return execErr
+	})
+	return int(affected), err
+}
+
+// PurgeExhausted drops every item that has reached maxAttempts.
+func (r *SyncQueueRepository) PurgeExhausted(ctx context.Context, maxAttempts int) (int, error) {
+	var affected int64
+	err := r.store.Write(ctx, "purge exhausted sync items", func(tx store.Tx) error {
+		var execErr error
+		affected, execErr = tx.Queries().DeleteExhaustedSyncItems(ctx, int64(maxAttempts))
+		return execErr
+	})
+	return int(affected), err
+}
+
+// PurgeAll empties the queue.
+func (r *SyncQueueRepository) PurgeAll(ctx context.Context) (int, error) {
+	var affected int64
+	err := r.store.Write(ctx, "purge sync queue", func(tx store.Tx) error {
+		var execErr error
+		affected, execErr = tx.Queries().DeleteAllSyncItems(ctx)
+		return execErr
+	})
+	return int(affected), err
+}
+
+// ResolveRemoteRefs looks up the remote IDs of the referenced entities,
+// filling in missing parents from the stored rows. Entities that no longer
+// exist resolve to an empty remote ID.
+func (r *SyncQueueRepository) ResolveRemoteRefs(ctx context.Context, local domain.LocalRefs) (domain.RemoteRefs, error) {
+	q := r.store.Queries()
+	var refs domain.RemoteRefs
+
+	if local.CommentID != "" {
+		row, err := q.GetComment(ctx, local.CommentID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return refs, err
+		}
+		if err == nil {
+			refs.Comment = row.RemoteID.String
+			if local.TaskID == "" {
+				local.TaskID = row.TaskID
+			}
+		}
+	}
+	if local.TaskID != "" {
+		row, err := q.GetTask(ctx, local.TaskID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return refs, err
+		}
+		if err == nil {
+			refs.Task = row.RemoteID.String
+			if local.ColumnID == "" {
+				local.ColumnID = row.ColumnID.String
+			}
+			if local.BoardID == "" {
+				local.BoardID = row.BoardID.String
+			}
+			if local.WorkspaceID == "" {
+				local.WorkspaceID = row.WorkspaceID
+			}
+		}
+	}
+	if local.ColumnID != "" {
+		row, err := q.GetColumn(ctx, local.ColumnID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return refs, err
+		}
+		if err == nil {
+			refs.Column = row.RemoteID.String
+			if local.BoardID == "" {
+				local.BoardID = row.BoardID
+			}
+		}
+	}
+	if local.BoardID != "" {
+		row, err := q.GetBoard(ctx, local.BoardID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return refs, err
+		}
+		if err == nil {
+			refs.Board = row.RemoteID.String
+			if local.WorkspaceID == "" {
+				local.WorkspaceID = row.WorkspaceID
+			}
+		}
+	}
+	if local.WorkspaceID != "" {
+		row, err := q.GetWorkspace(ctx, local.WorkspaceID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return refs, err
+		}
+		if err == nil {
+			refs.Workspace = row.RemoteID.String
+		}
+	}
+	return refs, nil
+}
+
+// SetRemoteID records the provider-side key of an entity without enqueueing
+// a sync entry, since the change originates from the provider itself.
+func (r *SyncQueueRepository) SetRemoteID(ctx context.Context, entity, entityID, remoteID string) error {
+	remote := nullString(&remoteID)
+	if remoteID == "" {
+		remote = sql.NullString{}
+	}
+	return r.store.Write(ctx, "set remote id", func(tx store.Tx) error {
+		qtx := tx.Queries()
+		switch entity {
+		case domain.SyncEntityWorkspace:
+			return qtx.SetWorkspaceRemoteID(ctx, sqlc.SetWorkspaceRemoteIDParams{RemoteID: remote, ID: entityID})
+		case domain.SyncEntityBoard:
+			return qtx.SetBoardRemoteID(ctx, sqlc.SetBoardRemoteIDParams{RemoteID: remote, ID: entityID})
+		case domain.SyncEntityColumn:
+			return qtx.SetColumnRemoteID(ctx, sqlc.SetColumnRemoteIDParams{RemoteID: remote, ID: entityID})
+		case domain.SyncEntityTask:
+			return qtx.SetTaskRemoteID(ctx, sqlc.SetTaskRemoteIDParams{RemoteID: remote, ID: entityID})
+		case domain.SyncEntityComment:
+			return qtx.SetCommentRemoteID(ctx, sqlc.SetCommentRemoteIDParams{RemoteID: remote, ID: entityID})
+		default:
+			return fmt.Errorf("unknown sync entity %q", entity)
+		}
+	})
+}
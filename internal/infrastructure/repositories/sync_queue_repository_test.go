@@ -0,0 +1,388 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+	"github.com/tiagokriok/kanji/internal/infrastructure/db/sqlc"
+	"github.com/tiagokriok/kanji/internal/infrastructure/store"
+)
+
+func TestTaskRepository_WritesEnqueueSyncItems(t *testing.T) {
+	adapter := newTestAdapter(t)
+	ctx := context.Background()
+	providerID, workspaceID, boardID, columnID := seedPushingWorkspaceBoardColumn(t, ctx, adapter.Queries())
+
+	s := store.New(adapter)
+	tasks := NewTaskRepository(s)
+	queue := NewSyncQueueRepository(s)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if err := tasks.Create(ctx, domain.Task{
+		ID:          "t-sync",
+		ProviderID:  providerID,
+		WorkspaceID: workspaceID,
+		BoardID:     &boardID,
+		ColumnID:    &columnID,
+		Title:       "Sync me",
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}); err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	title := "Renamed"
+	if err := tasks.Update(ctx, "t-sync", domain.TaskPatch{Title: &title}); err != nil {
+		t.Fatalf("update task: %v", err)
+	}
+	if err := tasks.Move(ctx, domain.MoveTaskInput{TaskID: "t-sync", ColumnID: &columnID, Position: 2, UpdatedAt: now}); err != nil {
+		t.Fatalf("move task: %v", err)
+	}
+	if err := tasks.Delete(ctx, "t-sync"); err != nil {
+		t.Fatalf("delete task: %v", err)
+	}
+
+	items, err := queue.List(ctx, providerID)
+	if err != nil {
+		t.Fatalf("list queue: %v", err)
+	}
+	var actions []string
+	for _, item := range items {
+		if item.Entity == domain.SyncEntityTask {
+			actions = append(actions, item.Action)
+		}
+	}
+	want := []string{domain.SyncActionCreate, domain.SyncActionUpdate, domain.SyncActionMove, domain.SyncActionDelete}
+	if len(actions) != len(want) {
+		t.Fatalf("task actions = %v, want %v", actions, want)
+	}
+	for i := range want {
+		if actions[i] != want[i] {
+			t.Fatalf("task actions = %v, want %v", actions, want)
+		}
+	}
+
+	var snapshot domain.Task
+	if err := json.Unmarshal([]byte(items[len(items)-1].PayloadJSON), &snapshot); err != nil {
+		t.Fatalf("decode payload: %v", err)
+	}
+	if snapshot.ID != "t-sync" || snapshot.Title != "Renamed" {
+		t.Errorf("delete payload = %+v, want last known task state", snapshot)
+	}
+}
+
+func TestTaskRepository_LocalProviderQueuesNothing(t *testing.T) {
+	adapter := newTestAdapter(t)
+	ctx := context.Background()
+	q := adapter.Queries()
+	providerID := seedProvider(t, ctx, q)
+	if err := q.CreateWorkspace(ctx, sqlc.CreateWorkspaceParams{ID: "w-local", ProviderID: providerID, Name: "Local"}); err != nil {
+		t.Fatalf("create workspace: %v", err)
+	}
+
+	s := store.New(adapter)
+	setup := NewSetupRepository(s)
+	if err := setup.CreateBoard(ctx, domain.Board{ID: "b-local", WorkspaceID: "w-local", Name: "Board", ViewDefault: "kanban"}); err != nil {
+		t.Fatalf("create board: %v", err)
+	}
+	if err := setup.CreateColumn(ctx, domain.Column{ID: "c-local", BoardID: "b-local", Name: "Todo", Position: 1}); err != nil {
+		t.Fatalf("create column: %v", err)
+	}
+	boardID, columnID := "b-local", "c-local"
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tasks := NewTaskRepository(s)
+	if err := tasks.Create(ctx, domain.Task{
+		ID: "t-local", ProviderID: providerID, WorkspaceID: "w-local", BoardID: &boardID, ColumnID: &columnID,
+		Title: "Local only", CreatedAt: now, UpdatedAt: now,
+	}); err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	title := "Still local"
+	if err := tasks.Update(ctx, "t-local", domain.TaskPatch{Title: &title}); err != nil {
+		t.Fatalf("update task: %v", err)
+	}
+	if err := NewCommentRepository(s).Create(ctx, domain.Comment{ID: "cm-local", TaskID: "t-local", ProviderID: providerID, BodyMD: "note", CreatedAt: now}); err != nil {
+		t.Fatalf("create comment: %v", err)
+	}
+
+	items, err := NewSyncQueueRepository(s).List(ctx, "")
+	if err != nil {
+		t.Fatalf("list queue: %v", err)
+	}
+	if len(items) != 0 {
+		t.Fatalf("expected an empty queue for the local provider, got %+v", items)
+	}
+}
+
+func TestTaskRepository_DeleteMissingTaskEnqueuesNothing(t *testing.T) {
+	adapter := newTestAdapter(t)
+	ctx := context.Background()
+	providerID, _, _, _ := seedProviderWorkspaceBoardColumn(t, ctx, adapter.Queries())
+
+	s := store.New(adapter)
+	if err := NewTaskRepository(s).Delete(ctx, "missing"); err != nil {
+		t.Fatalf("delete task: %v", err)
+	}
+	items, err := NewSyncQueueRepository(s).List(ctx, providerID)
+	if err != nil {
+		t.Fatalf("list queue: %v", err)
+	}
+	for _, item := range items {
+		if item.Entity == domain.SyncEntityTask {
+			t.Fatalf("unexpected task sync item: %+v", item)
+		}
+	}
+}
+
+func TestSetupRepository_ColumnWritesEnqueueWithWorkspaceProvider(t *testing.T) {
+	adapter := newTestAdapter(t)
+	ctx := context.Background()
+	providerID, _, boardID, _ := seedPushingWorkspaceBoardColumn(t, ctx, adapter.Queries())
+
+	s := store.New(adapter)
+	setup := NewSetupRepository(s)
+	if err := setup.CreateColumn(ctx, domain.Column{ID: "c-sync", BoardID: boardID, Name: "Review", Position: 2}); err != nil {
+		t.Fatalf("create column: %v", err)
+	}
+
+	items, err := NewSyncQueueRepository(s).List(ctx, providerID)
+	if err != nil {
+		t.Fatalf("list queue: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("len(items) = %d, want 1", len(items))
+	}
+	if items[0].Entity != domain.SyncEntityColumn || items[0].EntityID != "c-sync" || items[0].Action != domain.SyncActionCreate {
+		t.Errorf("unexpected item: %+v", items[0])
+	}
+}
+
+func TestSyncQueueRepository_FailureRetryAndPurge(t *testing.T) {
+	adapter := newTestAdapter(t)
+	ctx := context.Background()
+	providerID, workspaceID, _, _ := seedPushingWorkspaceBoardColumn(t, ctx, adapter.Queries())
+
+	s := store.New(adapter)
+	if err := NewSetupRepository(s).RenameWorkspace(ctx, workspaceID, "Renamed"); err != nil {
+		t.Fatalf("rename workspace: %v", err)
+	}
+	queue := NewSyncQueueRepository(s)
+	items, err := queue.List(ctx, providerID)
+	if err != nil || len(items) != 1 {
+		t.Fatalf("list queue: %v (len %d)", err, len(items))
+	}
+	id := items[0].ID
+
+	next := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		if err := queue.RecordFailure(ctx, id, "boom", next); err != nil {
+			t.Fatalf("record failure: %v", err)
+		}
+	}
+	items, _ = queue.List(ctx, "")
+	if items[0].Attempts != 3 || items[0].LastError == nil || *items[0].LastError != "boom" {
+		t.Fatalf("unexpected item after failures: %+v", items[0])
+	}
+	if items[0].NextAttemptAt == nil || !items[0].NextAttemptAt.Equal(next) {
+		t.Fatalf("NextAttemptAt = %v, want %v", items[0].NextAttemptAt, next)
+	}
+
+	if n, err := queue.PurgeExhausted(ctx, 4); err != nil || n != 0 {
+		t.Fatalf("purge exhausted below limit = %d, %v", n, err)
+	}
+	if n, err := queue.RetryExhausted(ctx, 3); err != nil || n != 1 {
+		t.Fatalf("retry exhausted = %d, %v", n, err)
+	}
+	items, _ = queue.List(ctx, "")
+	if items[0].Attempts != 0 || items[0].LastError != nil || items[0].NextAttemptAt != nil {
+		t.Fatalf("expected reset item, got %+v", items[0])
+	}
+
+	if n, err := queue.PurgeAll(ctx); err != nil || n != 1 {
+		t.Fatalf("purge all = %d, %v", n, err)
+	}
+}
+
+func TestSyncQueueRepository_RemoteRefs(t *testing.T) {
+	adapter := newTestAdapter(t)
+	ctx := context.Background()
+	providerID, workspaceID, boardID, columnID := seedProviderWorkspaceBoardColumn(t, ctx, adapter.Queries())
+
+	s := store.New(adapter)
+	tasks := NewTaskRepository(s)
+	queue := NewSyncQueueRepository(s)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if err := tasks.Create(ctx, domain.Task{
+		ID:          "t-remote",
+		ProviderID:  providerID,
+		WorkspaceID: workspaceID,
+		BoardID:     &boardID,
+		ColumnID:    &columnID,
+		Title:       "Remote",
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}); err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	before, err := queue.List(ctx, providerID)
+	if err != nil {
+		t.Fatalf("list queue: %v", err)
+	}
+
+	for entity, id := range map[string]string{
+		domain.SyncEntityWorkspace: workspaceID,
+		domain.SyncEntityBoard:     boardID,
+		domain.SyncEntityColumn:    columnID,
+		domain.SyncEntityTask:      "t-remote",
+	} {
+		if err := queue.SetRemoteID(ctx, entity, id, "r-"+entity); err != nil {
+			t.Fatalf("set %s remote id: %v", entity, err)
+		}
+	}
+
+	refs, err := queue.ResolveRemoteRefs(ctx, domain.LocalRefs{TaskID: "t-remote"})
+	if err != nil {
+		t.Fatalf("resolve refs: %v", err)
+	}
+	want := domain.RemoteRefs{Workspace: "r-workspace", Board: "r-board", Column: "r-column", Task: "r-task"}
+	if refs != want {
+		t.Fatalf("refs = %+v, want %+v", refs, want)
+	}
+
+	after, err := queue.List(ctx, providerID)
+	if err != nil {
+		t.Fatalf("list queue: %v", err)
+	}
+	if len(after) != len(before) {
+		t.Fatalf("SetRemoteID must not enqueue: before %d, after %d", len(before), len(after))
+	}
+}
+
+func TestSyncQueueRepository_ImportsDoNotEnqueue(t *testing.T) {
+	adapter := newTestAdapter(t)
+	ctx := context.Background()
+	providerID, workspaceID, boardID, columnID := seedProviderWorkspaceBoardColumn(t, ctx, adapter.Queries())
+
+	queue := NewSyncQueueRepository(store.New(adapter))
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	remoteID := "7"
+	task := domain.Task{
+		ID:          "t-pulled",
+		ProviderID:  providerID,
+		WorkspaceID: workspaceID,
+		BoardID:     &boardID,
+		ColumnID:    &columnID,
+		RemoteID:    &remoteID,
+		Title:       "Pulled",
+		Labels:      []string{"bug"},
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := queue.ImportTask(ctx, task, nil); err != nil {
+		t.Fatalf("import task: %v", err)
+	}
+	task.Title = "Pulled again"
+	if err := queue.ImportTask(ctx, task, nil); err != nil {
+		t.Fatalf("reimport task: %v", err)
+	}
+	if err := queue.ImportComment(ctx, domain.Comment{ID: "c-pulled", TaskID: task.ID, ProviderID: providerID, BodyMD: "hi", CreatedAt: now}); err != nil {
+		t.Fatalf("import comment: %v", err)
+	}
+
+	tasks, err := queue.ListBoardTasks(ctx, workspaceID, boardID)
+	if err != nil {
+		t.Fatalf("list board tasks: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].Title != "Pulled again" || len(tasks[0].Labels) != 1 {
+		t.Fatalf("tasks = %+v, want the reimported task", tasks)
+	}
+	comments, err := queue.ListTaskComments(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("list comments: %v", err)
+	}
+	if len(comments) != 1 {
+		t.Fatalf("comments = %+v, want 1", comments)
+	}
+
+	items, err := queue.List(ctx, providerID)
+	if err != nil {
+		t.Fatalf("list queue: %v", err)
+	}
+	if len(items) != 0 {
+		t.Fatalf("imports enqueued %d items", len(items))
+	}
+}
+
+func TestSyncQueueRepository_MergeTaskReplacesQueuedChanges(t *testing.T) {
+	adapter := newTestAdapter(t)
+	ctx := context.Background()
+	providerID, workspaceID, boardID, columnID := seedPushingWorkspaceBoardColumn(t, ctx, adapter.Queries())
+
+	s := store.New(adapter)
+	tasks := NewTaskRepository(s)
+	queue := NewSyncQueueRepository(s)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	task := domain.Task{
+		ID:          "t-merge",
+		ProviderID:  providerID,
+		WorkspaceID: workspaceID,
+		BoardID:     &boardID,
+		ColumnID:    &columnID,
+		Title:       "Local",
+		Priority:    3,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := tasks.Create(ctx, task); err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	if err := queue.SaveConflict(ctx, domain.SyncConflict{ProviderID: providerID, Entity: domain.SyncEntityTask, EntityID: task.ID, Fields: []string{domain.TaskFieldTitle}, BaseJSON: "{}", RemoteJSON: "{}"}); err != nil {
+		t.Fatalf("save conflict: %v", err)
+	}
+	conflicts, err := queue.ListConflicts(ctx, providerID)
+	if err != nil || len(conflicts) != 1 || conflicts[0].Fields[0] != domain.TaskFieldTitle {
+		t.Fatalf("conflicts = %+v, %v", conflicts, err)
+	}
+
+	task.Title = "Merged"
+	base := domain.SyncSnapshot{ProviderID: providerID, Entity: domain.SyncEntityTask, EntityID: task.ID, PayloadJSON: `{"title":"Remote"}`, SyncedAt: now}
+	if err := queue.MergeTask(ctx, task, base, nil); err != nil {
+		t.Fatalf("merge task: %v", err)
+	}
+
+	items, err := queue.List(ctx, providerID)
+	if err != nil {
+		t.Fatalf("list queue: %v", err)
+	}
+	var taskItems []domain.SyncItem
+	for _, item := range items {
+		if item.EntityID == task.ID {
+			taskItems = append(taskItems, item)
+		}
+	}
+	if len(taskItems) != 1 || taskItems[0].Action != domain.SyncActionUpdate {
+		t.Fatalf("expected one queued update, got %+v", taskItems)
+	}
+	var payload domain.Task
+	if err := json.Unmarshal([]byte(taskItems[0].PayloadJSON), &payload); err != nil || payload.Title != "Merged" {
+		t.Fatalf("queued payload = %+v, %v", payload, err)
+	}
+
+	snapshot, err := queue.GetSnapshot(ctx, domain.SyncEntityTask, task.ID)
+	if err != nil || snapshot == nil || snapshot.PayloadJSON != base.PayloadJSON {
+		t.Fatalf("snapshot = %+v, %v", snapshot, err)
+	}
+	if conflicts, _ := queue.ListConflicts(ctx, ""); len(conflicts) != 0 {
+		t.Fatalf("expected conflict to be dropped, got %+v", conflicts)
+	}
+
+	if err := queue.ForgetEntity(ctx, domain.SyncEntityTask, task.ID); err != nil {
+		t.Fatalf("forget entity: %v", err)
+	}
+	if snapshot, err := queue.GetSnapshot(ctx, domain.SyncEntityTask, task.ID); err != nil || snapshot != nil {
+		t.Fatalf("expected snapshot to be forgotten, got %+v, %v", snapshot, err)
+	}
+}
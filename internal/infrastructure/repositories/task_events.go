@@ -0,0 +1,202 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+	"github.com/tiagokriok/kanji/internal/infrastructure/db/sqlc"
+	"github.com/tiagokriok/kanji/internal/infrastructure/store"
+)
+
+// History helpers append task_events rows inside the caller's write
+// transaction, so the history always matches the data. Writers load the task
+// before and after the change and record the fields that differ.
+
+// syncActor is the actor of changes pulled from a provider.
+const syncActor = "sync"
+
+// DefaultActor names who is making changes in this process: $KANJI_ACTOR
+// when set, otherwise the login name of the current OS user. It is empty
+// when neither is known.
+func DefaultActor() string {
+	if actor := strings.TrimSpace(os.Getenv("KANJI_ACTOR")); actor != "" {
+		return actor
+	}
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return ""
+}
+
+// loadTask returns the task, or nil when it does not exist.
+func loadTask(ctx context.Context, qtx *sqlc.Queries, taskID string) (*domain.Task, error) {
+	row, err := qtx.GetTask(ctx, taskID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load task for history: %w", err)
+	}
+	task := fromSQLTask(row)
+	return &task, nil
+}
+
+func recordTaskEvent(ctx context.Context, qtx *sqlc.Queries, task *domain.Task, kind string, changes []domain.FieldChange, actor string) error {
+	if task == nil {
+		return nil
+	}
+	if changes == nil {
+		changes = []domain.FieldChange{}
+	}
+	payload, err := json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("encode task event: %w", err)
+	}
+	var actorValue sql.NullString
+	if actor != "" {
+		actorValue = sql.NullString{String: actor, Valid: true}
+	}
+	if err := qtx.CreateTaskEvent(ctx, sqlc.CreateTaskEventParams{
+		ID:          uuid.NewString(),
+		TaskID:      task.ID,
+		WorkspaceID: task.WorkspaceID,
+		BoardID:     nullString(task.BoardID),
+		Kind:        kind,
+		Title:       task.Title,
+		ChangesJSON: string(payload),
+		Actor:       actorValue,
+		CreatedAt:   time.Now().UTC().Format(time.RFC3339),
+	}); err != nil {
+		return fmt.Errorf("record task event: %w", err)
+	}
+	return nil
+}
+
+// recordTaskChanges records an event of the given kind when after differs
+// from before in any tracked field. Position is not tracked, so reordering
+// a task inside its column leaves no history.
+func recordTaskChanges(ctx context.Context, qtx *sqlc.Queries, before, after *domain.Task, kind, actor string) error {
+	if before == nil || after == nil {
+		return nil
+	}
+	changes := diffTasks(*before, *after)
+	if len(changes) == 0 {
+		return nil
+	}
+	return recordTaskEvent(ctx, qtx, after, kind, changes, actor)
+}
+
+func diffTasks(before, after domain.Task) []domain.FieldChange {
+	var changes []domain.FieldChange
+	add := func(field string, old, new *string) {
+		if (old == nil) != (new == nil) || (old != nil && *old != *new) {
+			changes = append(changes, domain.FieldChange{Field: field, Old: old, New: new})
+		}
+	}
+	add("title", &before.Title, &after.Title)
+	add("description", &before.DescriptionMD, &after.DescriptionMD)
+	add("workspace_id", &before.WorkspaceID, &after.WorkspaceID)
+	add("board_id", before.BoardID, after.BoardID)
+	add("column_id", before.ColumnID, after.ColumnID)
+	add("status", before.Status, after.Status)
+	add("priority", formatInt(&before.Priority), formatInt(&after.Priority))
+	add("due_at", formatTime(before.DueAt), formatTime(after.DueAt))
+	add("estimate_minutes", formatInt(before.EstimateMinutes), formatInt(after.EstimateMinutes))
+	add("assignee", before.Assignee, after.Assignee)
+	if !slices.Equal(before.Labels, after.Labels) {
+		add("labels", formatLabels(before.Labels), formatLabels(after.Labels))
+	}
+	return changes
+}
+
+func formatInt(v *int) *string {
+	if v == nil {
+		return nil
+	}
+	s := strconv.Itoa(*v)
+	return &s
+}
+
+func formatTime(v *time.Time) *string {
+	if v == nil {
+		return nil
+	}
+	s := v.UTC().Format(time.RFC3339)
+	return &s
+}
+
+func formatLabels(labels []string) *string {
+	s := strings.Join(labels, ", ")
+	return &s
+}
+
+// commentChange is the change recorded for a new comment.
+func commentChange(body string) []domain.FieldChange {
+	return []domain.FieldChange{{Field: "comment", New: &body}}
+}
+
+// recordComment records a commented event on the comment's task. The
+// comment author, when set, is the actor.
+func recordComment(ctx context.Context, qtx *sqlc.Queries, comment domain.Comment, actor string) error {
+	task, err := loadTask(ctx, qtx, comment.TaskID)
+	if err != nil {
+		return err
+	}
+	if comment.Author != nil && strings.TrimSpace(*comment.Author) != "" {
+		actor = strings.TrimSpace(*comment.Author)
+	}
+	return recordTaskEvent(ctx, qtx, task, domain.TaskEventCommented, commentChange(comment.BodyMD), actor)
+}
+
+type TaskEventRepository struct {
+	store store.Store
+}
+
+func NewTaskEventRepository(s store.Store) *TaskEventRepository {
+	return &TaskEventRepository{store: s}
+}
+
+func (r *TaskEventRepository) ListByTask(ctx context.Context, taskID string) ([]domain.TaskEvent, error) {
+	rows, err := r.store.Queries().ListTaskEvents(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.TaskEvent, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, fromSQLTaskEvent(row))
+	}
+	return result, nil
+}
+
+func (r *TaskEventRepository) ListActivity(ctx context.Context, filter domain.ActivityFilter) ([]domain.TaskEvent, error) {
+	// SQLite treats a negative LIMIT as no limit.
+	limit := int64(filter.Limit)
+	if limit <= 0 {
+		limit = -1
+	}
+	rows, err := r.store.Queries().ListWorkspaceTaskEvents(ctx, sqlc.ListWorkspaceTaskEventsParams{
+		WorkspaceID: filter.WorkspaceID,
+		BoardID:     filter.BoardID,
+		Limit:       limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.TaskEvent, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, fromSQLTaskEvent(row))
+	}
+	return result, nil
+}
@@ -0,0 +1,144 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+	"github.com/tiagokriok/kanji/internal/infrastructure/db/sqlc"
+	"github.com/tiagokriok/kanji/internal/infrastructure/store"
+)
+
+func TestTaskEvents_RecordedWithChanges(t *testing.T) {
+	adapter := newTestAdapter(t)
+	ctx := context.Background()
+	q := adapter.Queries()
+	providerID, workspaceID, boardID, columnID := seedProviderWorkspaceBoardColumn(t, ctx, q)
+	if err := q.CreateColumn(ctx, sqlc.CreateColumnParams{
+		ID: "c-done", BoardID: boardID, Name: "Done", Color: "#6B7280", Position: 2,
+	}); err != nil {
+		t.Fatalf("create column: %v", err)
+	}
+
+	s := store.New(adapter)
+	tasks := NewTaskRepository(s)
+	tasks.SetActor("alice")
+	comments := NewCommentRepository(s)
+	comments.SetActor("alice")
+	events := NewTaskEventRepository(s)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	task := domain.Task{
+		ID: "t-history", ProviderID: providerID, WorkspaceID: workspaceID,
+		BoardID: &boardID, ColumnID: &columnID, Title: "Draft", Priority: 3,
+		Labels: []string{}, CreatedAt: now, UpdatedAt: now,
+	}
+	if err := tasks.Create(ctx, task); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	title := "Final"
+	labels := []string{"docs"}
+	if err := tasks.Update(ctx, task.ID, domain.TaskPatch{Title: &title, Labels: &labels}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	// Writing the same values again changes nothing worth recording.
+	if err := tasks.Update(ctx, task.ID, domain.TaskPatch{Title: &title}); err != nil {
+		t.Fatalf("no-op update: %v", err)
+	}
+	done := "c-done"
+	if err := tasks.Move(ctx, domain.MoveTaskInput{TaskID: task.ID, ColumnID: &done, Position: 1, UpdatedAt: now}); err != nil {
+		t.Fatalf("move: %v", err)
+	}
+	author := "bob"
+	if err := comments.Create(ctx, domain.Comment{
+		ID: "cm-history", TaskID: task.ID, ProviderID: providerID, BodyMD: "Shipped", Author: &author, CreatedAt: now,
+	}); err != nil {
+		t.Fatalf("comment: %v", err)
+	}
+	if err := comments.Delete(ctx, "cm-history"); err != nil {
+		t.Fatalf("delete comment: %v", err)
+	}
+	if err := tasks.Delete(ctx, task.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	history, err := events.ListByTask(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("list history: %v", err)
+	}
+	kinds := make([]string, len(history))
+	for i, e := range history {
+		kinds[i] = e.Kind
+	}
+	want := []string{domain.TaskEventCreated, domain.TaskEventUpdated, domain.TaskEventMoved, domain.TaskEventCommented, domain.TaskEventDeleted}
+	if len(kinds) != len(want) {
+		t.Fatalf("kinds = %v, want %v", kinds, want)
+	}
+	for i := range want {
+		if kinds[i] != want[i] {
+			t.Fatalf("kinds = %v, want %v", kinds, want)
+		}
+	}
+
+	updated := history[1]
+	if len(updated.Changes) != 2 || updated.Changes[0].Field != "title" || *updated.Changes[0].Old != "Draft" || *updated.Changes[0].New != "Final" {
+		t.Fatalf("unexpected update changes: %+v", updated.Changes)
+	}
+	if updated.Changes[1].Field != "labels" || *updated.Changes[1].New != "docs" {
+		t.Fatalf("unexpected label change: %+v", updated.Changes[1])
+	}
+	moved := history[2]
+	if len(moved.Changes) != 1 || *moved.Changes[0].Old != columnID || *moved.Changes[0].New != done {
+		t.Fatalf("unexpected move changes: %+v", moved.Changes)
+	}
+	if history[0].Actor == nil || *history[0].Actor != "alice" {
+		t.Fatalf("created actor = %v, want alice", history[0].Actor)
+	}
+	if history[3].Actor == nil || *history[3].Actor != "bob" {
+		t.Fatalf("comment actor = %v, want the comment author", history[3].Actor)
+	}
+	if history[4].Title != "Final" || history[4].BoardID == nil || *history[4].BoardID != boardID {
+		t.Fatalf("unexpected delete event: %+v", history[4])
+	}
+}
+
+func TestTaskEvents_ListActivity(t *testing.T) {
+	adapter := newTestAdapter(t)
+	ctx := context.Background()
+	q := adapter.Queries()
+	providerID, workspaceID, boardID, columnID := seedProviderWorkspaceBoardColumn(t, ctx, q)
+
+	s := store.New(adapter)
+	tasks := NewTaskRepository(s)
+	events := NewTaskEventRepository(s)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, id := range []string{"t-a", "t-b", "t-c"} {
+		if err := tasks.Create(ctx, domain.Task{
+			ID: id, ProviderID: providerID, WorkspaceID: workspaceID,
+			BoardID: &boardID, ColumnID: &columnID, Title: id,
+			Labels: []string{}, CreatedAt: now, UpdatedAt: now,
+		}); err != nil {
+			t.Fatalf("create %s: %v", id, err)
+		}
+	}
+
+	latest, err := events.ListActivity(ctx, domain.ActivityFilter{WorkspaceID: workspaceID, Limit: 2})
+	if err != nil {
+		t.Fatalf("list activity: %v", err)
+	}
+	if len(latest) != 2 || latest[0].TaskID != "t-c" || latest[1].TaskID != "t-b" {
+		t.Fatalf("expected the two newest events, got %+v", latest)
+	}
+	if latest[0].Actor != nil {
+		t.Fatalf("expected no actor without SetActor, got %q", *latest[0].Actor)
+	}
+	all, err := events.ListActivity(ctx, domain.ActivityFilter{WorkspaceID: workspaceID, BoardID: boardID})
+	if err != nil || len(all) != 3 {
+		t.Fatalf("expected every event of the board, got %d (%v)", len(all), err)
+	}
+	other, err := events.ListActivity(ctx, domain.ActivityFilter{WorkspaceID: workspaceID, BoardID: "b-other"})
+	if err != nil || len(other) != 0 {
+		t.Fatalf("expected no events for another board, got %d (%v)", len(other), err)
+	}
+}
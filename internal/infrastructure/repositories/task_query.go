@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+// compileTaskQuery compiles a filter expression plan to an SQL condition on
+// the tasks table and the arguments of its placeholders. Every condition is
+// false rather than NULL for tasks without a value, so "not" keeps them.
+func compileTaskQuery(query domain.TaskQuery) (string, []any, error) {
+	switch query.Op {
+	case domain.TaskQueryAnd, domain.TaskQueryOr:
+		joiner := " AND "
+		if query.Op == domain.TaskQueryOr {
+			joiner = " OR "
+		}
+		parts := make([]string, 0, len(query.Operands))
+		var args []any
+		for _, operand := range query.Operands {
+			sql, operandArgs, err := compileTaskQuery(operand)
+			if err != nil {
+				return "", nil, err
+			}
+			parts = append(parts, sql)
+			args = append(args, operandArgs...)
+		}
+		if len(parts) == 0 {
+			return "", nil, fmt.Errorf("empty task query group")
+		}
+		return "(" + strings.Join(parts, joiner) + ")", args, nil
+	case domain.TaskQueryNot:
+		if len(query.Operands) != 1 {
+			return "", nil, fmt.Errorf("task query not takes one operand, got %d", len(query.Operands))
+		}
+		sql, args, err := compileTaskQuery(query.Operands[0])
+		if err != nil {
+			return "", nil, err
+		}
+		return "NOT " + sql, args, nil
+	case domain.TaskQueryCond:
+		return compileTaskCondition(query.Cond)
+	default:
+		return "", nil, fmt.Errorf("unknown task query op %d", query.Op)
+	}
+}
+
+func compileTaskCondition(c domain.TaskCondition) (string, []any, error) {
+	switch {
+	case c.Field == domain.QueryFieldText && c.Op == domain.CompareContains:
+		return "(instr(LOWER(title), LOWER(?)) > 0 OR instr(LOWER(description_md), LOWER(?)) > 0)", []any{c.Text, c.Text}, nil
+	case c.Field == domain.QueryFieldTitle && c.Op == domain.CompareContains:
+		return "(instr(LOWER(title), LOWER(?)) > 0)", []any{c.Text}, nil
+	case c.Field == domain.QueryFieldPriority && isSQLComparison(c.Op):
+		return "(priority " + string(c.Op) + " ?)", []any{c.Number}, nil
+	case c.Field == domain.QueryFieldLabel && c.Op == domain.CompareEqual:
+		return "EXISTS (SELECT 1 FROM json_each(tasks.labels_json) WHERE LOWER(json_each.value) = LOWER(?))", []any{c.Text}, nil
+	case c.Field == domain.QueryFieldLabel && c.Op == domain.CompareUnset:
+		return "(json_array_length(labels_json) = 0)", nil, nil
+	case c.Field == domain.QueryFieldColumn && c.Op == domain.CompareEqual:
+		return "EXISTS (SELECT 1 FROM columns WHERE columns.id = tasks.column_id AND LOWER(columns.name) = LOWER(?))", []any{c.Text}, nil
+	case c.Field == domain.QueryFieldColumn && c.Op == domain.CompareUnset:
+		return "(column_id IS NULL)", nil, nil
+	case c.Field == domain.QueryFieldStatus && c.Op == domain.CompareEqual:
+		return "(LOWER(COALESCE(status, '')) = LOWER(?))", []any{c.Text}, nil
+	case c.Field == domain.QueryFieldStatus && c.Op == domain.CompareUnset:
+		return "(COALESCE(status, '') = '')", nil, nil
+	case c.Field == domain.QueryFieldAssignee && c.Op == domain.CompareEqual:
+		return "(LOWER(COALESCE(assignee, '')) = LOWER(?))", []any{c.Text}, nil
+	case c.Field == domain.QueryFieldAssignee && c.Op == domain.CompareUnset:
+		return "(COALESCE(assignee, '') = '')", nil, nil
+	case c.Field == domain.QueryFieldDue && isSQLComparison(c.Op):
+		return "(due_at IS NOT NULL AND due_at " + string(c.Op) + " ?)", []any{c.Time.UTC().Format(time.RFC3339)}, nil
+	case c.Field == domain.QueryFieldDue && c.Op == domain.CompareUnset:
+		return "(due_at IS NULL)", nil, nil
+	default:
+		return "", nil, fmt.Errorf("unsupported task condition %s %s", c.Field, c.Op)
+	}
+}
+
+// isSQLComparison reports whether op is one of the comparisons written the same
+// in SQL.
+func isSQLComparison(op domain.TaskComparison) bool {
+	switch op {
+	case domain.CompareEqual, domain.CompareLess, domain.CompareLessEqual, domain.CompareGreater, domain.CompareGreaterEqual:
+		return true
+	}
+	return false
+}
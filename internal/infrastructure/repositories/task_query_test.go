@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/tiagokriok/kanji/internal/application"
+	"github.com/tiagokriok/kanji/internal/domain"
+	"github.com/tiagokriok/kanji/internal/infrastructure/db/sqlc"
+	"github.com/tiagokriok/kanji/internal/infrastructure/store"
+)
+
+func TestTaskRepository_ListWithQuery(t *testing.T) {
+	adapter := newTestAdapter(t)
+	ctx := context.Background()
+	q := adapter.Queries()
+	providerID, workspaceID, boardID, todoID := seedProviderWorkspaceBoardColumn(t, ctx, q)
+	if err := q.CreateColumn(ctx, sqlc.CreateColumnParams{
+		ID: "c-done", BoardID: boardID, Name: "Done", Color: "#6B7280", Position: 2,
+	}); err != nil {
+		t.Fatalf("create column: %v", err)
+	}
+	doneID := "c-done"
+
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	in := func(days int) *time.Time {
+		v := now.AddDate(0, 0, days)
+		return &v
+	}
+	alice := "alice"
+	repo := NewTaskRepository(store.New(adapter))
+	for _, task := range []domain.Task{
+		{ID: "t-bug", Title: "Crash on save", Priority: 1, Labels: []string{"Bug"}, DueAt: in(2), ColumnID: &todoID, Assignee: &alice},
+		{ID: "t-done-bug", Title: "Old crash", Priority: 2, Labels: []string{"bug"}, DueAt: in(1), ColumnID: &doneID},
+		{ID: "t-late", Title: "Write docs", DescriptionMD: "Explain the crash reporter", Priority: 4, Labels: []string{}, DueAt: in(30), ColumnID: &todoID},
+		{ID: "t-none", Title: "Backlog idea", Priority: 5, Labels: []string{}},
+	} {
+		task.ProviderID, task.WorkspaceID, task.BoardID = providerID, workspaceID, &boardID
+		task.CreatedAt, task.UpdatedAt = now, now
+		if err := repo.Create(ctx, task); err != nil {
+			t.Fatalf("create %s: %v", task.ID, err)
+		}
+	}
+
+	tests := []struct {
+		filter string
+		want   []string
+	}{
+		{"priority<=high and label:bug and due<7d and not column:Done", []string{"t-bug"}},
+		{"crash", []string{"t-bug", "t-done-bug", "t-late"}},
+		{"title:crash", []string{"t-bug", "t-done-bug"}},
+		{"label:none", []string{"t-late", "t-none"}},
+		{"not label:bug", []string{"t-late", "t-none"}},
+		{"due:none or due>=2024-04-01", []string{"t-late", "t-none"}},
+		{"not due<7d", []string{"t-late", "t-none"}},
+		{"column:none", []string{"t-none"}},
+		{"not column:done", []string{"t-bug", "t-late", "t-none"}},
+		{"assignee:ALICE", []string{"t-bug"}},
+		{"assignee!=alice and priority>medium", []string{"t-late", "t-none"}},
+		{"due:2024-03-11", []string{"t-done-bug"}},
+	}
+	for _, tt := range tests {
+		query, err := application.ParseTaskQuery(tt.filter, now)
+		if err != nil {
+			t.Fatalf("parse %q: %v", tt.filter, err)
+		}
+		tasks, err := repo.List(ctx, domain.TaskFilter{WorkspaceID: workspaceID, Query: &query})
+		if err != nil {
+			t.Fatalf("list %q: %v", tt.filter, err)
+		}
+		got := make([]string, len(tasks))
+		for i, task := range tasks {
+			got[i] = task.ID
+		}
+		sort.Strings(got)
+		if len(got) != len(tt.want) {
+			t.Errorf("%q = %v, want %v", tt.filter, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%q = %v, want %v", tt.filter, got, tt.want)
+				break
+			}
+		}
+	}
+}
@@ -3,6 +3,8 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/tiagokriok/kanji/internal/domain"
@@ -11,60 +13,52 @@ import (
 )
 
 type TaskRepository struct {
-	store store.Store
+	store   store.Store
+	actor   string
+	journal string
 }
 
 func NewTaskRepository(s store.Store) *TaskRepository {
 	return &TaskRepository{store: s}
 }
 
+// SetActor sets who is recorded in the history of the changes made through
+// the repository.
+func (r *TaskRepository) SetActor(actor string) {
+	r.actor = actor
+}
+
+// SetJournal journals the changes made through the repository under the
+// namespace so they can be undone. An empty namespace turns journaling off.
+func (r *TaskRepository) SetJournal(namespace string) {
+	r.journal = namespace
+}
+
 func (r *TaskRepository) Create(ctx context.Context, task domain.Task) error {
-	return r.store.Write(ctx, "create task", func(tx store.Tx) error {
-		qtx := tx.Queries()
-		return qtx.CreateTask(ctx, sqlc.CreateTaskParams{
-			ID:              task.ID,
-			ProviderID:      task.ProviderID,
-			WorkspaceID:     task.WorkspaceID,
-			BoardID:         nullString(task.BoardID),
-			ColumnID:        nullString(task.ColumnID),
-			RemoteID:        nullString(task.RemoteID),
-			Title:           task.Title,
-			DescriptionMd:   task.DescriptionMD,
-			Status:          nullString(task.Status),
-			Priority:        int64(task.Priority),
-			DueAt:           nullableTimeToString(task.DueAt),
-			EstimateMinutes: nullInt(task.EstimateMinutes),
-			Assignee:        nullString(task.Assignee),
-			LabelsJSON:      marshalLabels(task.Labels),
-			Position:        task.Position,
-			CreatedAt:       task.CreatedAt.UTC().Format(time.RFC3339),
-			UpdatedAt:       task.UpdatedAt.UTC().Format(time.RFC3339),
-		})
-	})
+	return r.CreatePlaced(ctx, task, nil)
 }
 
-func (r *TaskRepository) Update(ctx context.Context, taskID string, patch domain.TaskPatch) error {
-	return r.store.Write(ctx, "update task", func(tx store.Tx) error {
+func (r *TaskRepository) CreatePlaced(ctx context.Context, task domain.Task, place domain.PlaceFunc) error {
+	return r.store.Write(ctx, "create task", func(tx store.Tx) error {
 		qtx := tx.Queries()
-		if patch.ClearDueAt {
-			if err := qtx.ClearTaskDueAt(ctx, taskID); err != nil {
+		if place != nil {
+			position, err := placeTask(ctx, qtx, task.WorkspaceID, task.BoardID, task.ColumnID, task.ID, place)
+			if err != nil {
 				return err
 			}
+			task.Position = position
 		}
-		arg := sqlc.UpdateTaskParams{
-			Title:         nullString(patch.Title),
-			DescriptionMd: nullString(patch.DescriptionMD),
-			Status:        nullString(patch.Status),
-			Priority:      nullInt(patch.Priority),
-			DueAt:         nullableTimeToString(patch.DueAt),
-			ColumnID:      nullString(patch.ColumnID),
-			UpdatedAt:     time.Now().UTC().Format(time.RFC3339),
-			ID:            taskID,
-		}
-		if patch.Labels != nil {
-			arg.LabelsJSON = sql.NullString{String: marshalLabels(*patch.Labels), Valid: true}
+		created, err := createTask(ctx, qtx, task, r.actor)
+		if err != nil {
+			return err
 		}
-		return qtx.UpdateTask(ctx, arg)
+		return journalTask(ctx, qtx, r.journal, domain.OperationTaskCreate, task.ID, nil, created)
+	})
+}
+
+func (r *TaskRepository) Update(ctx context.Context, taskID string, patch domain.TaskPatch) error {
+	return r.store.Write(ctx, "update task", func(tx store.Tx) error {
+		return r.applyUpdate(ctx, tx.Queries(), taskID, patch)
 	})
 }
 
@@ -89,7 +83,17 @@ func (r *TaskRepository) List(ctx context.Context, filter domain.TaskFilter) ([]
 		arg.DueSoonBefore = filter.DueSoonBy.UTC().Format(time.RFC3339)
 	}
 
-	items, err := r.store.Queries().ListTasks(ctx, arg)
+	var items []sqlc.Task
+	var err error
+	if filter.Query != nil {
+		where, args, compileErr := compileTaskQuery(*filter.Query)
+		if compileErr != nil {
+			return nil, compileErr
+		}
+		items, err = r.store.Queries().ListTasksWhere(ctx, arg, where, args...)
+	} else {
+		items, err = r.store.Queries().ListTasks(ctx, arg)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -102,20 +106,82 @@ func (r *TaskRepository) List(ctx context.Context, filter domain.TaskFilter) ([]
 
 func (r *TaskRepository) Move(ctx context.Context, input domain.MoveTaskInput) error {
 	return r.store.Write(ctx, "move task", func(tx store.Tx) error {
-		qtx := tx.Queries()
-		return qtx.MoveTask(ctx, sqlc.MoveTaskParams{
-			ColumnID:  nullString(input.ColumnID),
-			Status:    nullString(input.Status),
-			Position:  input.Position,
-			UpdatedAt: input.UpdatedAt.UTC().Format(time.RFC3339),
-			ID:        input.TaskID,
-		})
+		return r.applyMove(ctx, tx.Queries(), input)
 	})
 }
 
 func (r *TaskRepository) Delete(ctx context.Context, id string) error {
 	return r.store.Write(ctx, "delete task", func(tx store.Tx) error {
-		return tx.Queries().DeleteTask(ctx, id)
+		return r.applyDelete(ctx, tx.Queries(), id)
+	})
+}
+
+func (r *TaskRepository) Bulk(ctx context.Context, changes []domain.TaskChange) error {
+	return r.store.Write(ctx, "bulk change tasks", func(tx store.Tx) error {
+		qtx := tx.Queries()
+		for _, change := range changes {
+			var err error
+			switch {
+			case change.Delete:
+				err = r.applyDelete(ctx, qtx, change.TaskID)
+			case change.Move != nil:
+				move := *change.Move
+				move.TaskID = change.TaskID
+				err = r.applyMove(ctx, qtx, move)
+			case change.Patch != nil:
+				err = r.applyUpdate(ctx, qtx, change.TaskID, *change.Patch)
+			}
+			if err != nil {
+				return fmt.Errorf("task %s: %w", change.TaskID, err)
+			}
+		}
+		return nil
+	})
+}
+
+// BuildBoard creates a board with its columns, labels and tasks in one
+// transaction. Labels the workspace already has are left as they are.
+func (r *TaskRepository) BuildBoard(ctx context.Context, build domain.BoardBuild) error {
+	return r.store.Write(ctx, "build board", func(tx store.Tx) error {
+		qtx := tx.Queries()
+		if err := createBoard(ctx, qtx, build.Board); err != nil {
+			return fmt.Errorf("create board: %w", err)
+		}
+		for _, column := range build.Columns {
+			if err := createColumn(ctx, qtx, column); err != nil {
+				return fmt.Errorf("create column %q: %w", column.Name, err)
+			}
+		}
+		for _, label := range build.Labels {
+			_, err := qtx.GetLabelByName(ctx, sqlc.GetLabelByNameParams{WorkspaceID: label.WorkspaceID, Name: label.Name})
+			if err == nil {
+				continue
+			}
+			if !errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
+			if err := qtx.CreateLabel(ctx, sqlc.CreateLabelParams{
+				ID:          label.ID,
+				WorkspaceID: label.WorkspaceID,
+				Name:        label.Name,
+				Color:       label.Color,
+				Description: label.Description,
+				CreatedAt:   label.CreatedAt.UTC().Format(time.RFC3339),
+				UpdatedAt:   label.UpdatedAt.UTC().Format(time.RFC3339),
+			}); err != nil {
+				return fmt.Errorf("create label %q: %w", label.Name, err)
+			}
+		}
+		for _, task := range build.Tasks {
+			created, err := createTask(ctx, qtx, task, r.actor)
+			if err != nil {
+				return fmt.Errorf("create task %q: %w", task.Title, err)
+			}
+			if err := journalTask(ctx, qtx, r.journal, domain.OperationTaskCreate, task.ID, nil, created); err != nil {
+				return err
+			}
+		}
+		return nil
 	})
 }
 
@@ -126,3 +192,376 @@ func (r *TaskRepository) ListColumns(ctx context.Context, boardID string) ([]dom
 func (r *TaskRepository) ListBoards(ctx context.Context, workspaceID string) ([]domain.Board, error) {
 	return queryListBoards(ctx, r.store.Queries(), workspaceID)
 }
+
+// applyUpdate applies a patch with its history, journal, sync and webhook
+// entries.
+func (r *TaskRepository) applyUpdate(ctx context.Context, qtx *sqlc.Queries, taskID string, patch domain.TaskPatch) error {
+	if err := checkTaskVersion(ctx, qtx, taskID, patch.IfVersion); err != nil {
+		return err
+	}
+	before, err := loadTask(ctx, qtx, taskID)
+	if err != nil {
+		return err
+	}
+	if patch.ClearDueAt {
+		if err := qtx.ClearTaskDueAt(ctx, taskID); err != nil {
+			return err
+		}
+	}
+	arg := sqlc.UpdateTaskParams{
+		Title:         nullString(patch.Title),
+		DescriptionMd: nullString(patch.DescriptionMD),
+		Status:        nullString(patch.Status),
+		Priority:      nullInt(patch.Priority),
+		DueAt:         nullableTimeToString(patch.DueAt),
+		ColumnID:      nullString(patch.ColumnID),
+		UpdatedAt:     time.Now().UTC().Format(time.RFC3339),
+		ID:            taskID,
+	}
+	if patch.Labels != nil {
+		arg.LabelsJSON = sql.NullString{String: marshalLabels(*patch.Labels), Valid: true}
+	}
+	if err := qtx.UpdateTask(ctx, arg); err != nil {
+		return err
+	}
+	if patch.Place != nil && patch.ColumnID != nil && before != nil && !sameColumn(before.ColumnID, patch.ColumnID) {
+		position, err := placeTask(ctx, qtx, before.WorkspaceID, before.BoardID, patch.ColumnID, taskID, patch.Place)
+		if err != nil {
+			return err
+		}
+		if err := qtx.UpdateTaskPosition(ctx, sqlc.UpdateTaskPositionParams{Position: position, ID: taskID}); err != nil {
+			return err
+		}
+	}
+	if patch.Labels != nil && before != nil {
+		if err := ensureLabels(ctx, qtx, before.WorkspaceID, *patch.Labels); err != nil {
+			return err
+		}
+	}
+	after, err := loadTask(ctx, qtx, taskID)
+	if err != nil {
+		return err
+	}
+	if err := recordTaskChanges(ctx, qtx, before, after, domain.TaskEventUpdated, r.actor); err != nil {
+		return err
+	}
+	if err := journalTask(ctx, qtx, r.journal, domain.OperationTaskUpdate, taskID, before, after); err != nil {
+		return err
+	}
+	return enqueueTaskChange(ctx, qtx, taskID, false)
+}
+
+// applyMove moves a task with its history, journal, sync and webhook entries.
+func (r *TaskRepository) applyMove(ctx context.Context, qtx *sqlc.Queries, input domain.MoveTaskInput) error {
+	if err := checkTaskVersion(ctx, qtx, input.TaskID, input.IfVersion); err != nil {
+		return err
+	}
+	before, err := loadTask(ctx, qtx, input.TaskID)
+	if err != nil {
+		return err
+	}
+	if input.Place != nil && before != nil {
+		workspaceID, boardID := before.WorkspaceID, before.BoardID
+		if input.WorkspaceID != nil {
+			workspaceID = *input.WorkspaceID
+		}
+		if input.BoardID != nil {
+			boardID = input.BoardID
+		}
+		if input.Position, err = placeTask(ctx, qtx, workspaceID, boardID, input.ColumnID, input.TaskID, input.Place); err != nil {
+			return err
+		}
+	}
+	var gone *sqlc.CreateSyncItemParams
+	if before != nil && (input.BoardID != nil || input.WorkspaceID != nil) {
+		target := *before
+		if input.BoardID != nil {
+			target.BoardID = input.BoardID
+		}
+		if input.WorkspaceID != nil {
+			target.WorkspaceID = *input.WorkspaceID
+		}
+		if gone, err = transferTask(ctx, qtx, *before, target); err != nil {
+			return err
+		}
+	}
+	if err := qtx.MoveTask(ctx, sqlc.MoveTaskParams{
+		ColumnID:  nullString(input.ColumnID),
+		Status:    nullString(input.Status),
+		Position:  input.Position,
+		UpdatedAt: input.UpdatedAt.UTC().Format(time.RFC3339),
+		ID:        input.TaskID,
+	}); err != nil {
+		return err
+	}
+	after, err := loadTask(ctx, qtx, input.TaskID)
+	if err != nil {
+		return err
+	}
+	if err := recordTaskChanges(ctx, qtx, before, after, domain.TaskEventMoved, r.actor); err != nil {
+		return err
+	}
+	if err := journalTask(ctx, qtx, r.journal, domain.OperationTaskMove, input.TaskID, before, after); err != nil {
+		return err
+	}
+	if gone != nil {
+		return enqueueTaskTransfer(ctx, qtx, input.TaskID, gone)
+	}
+	return enqueueTaskChange(ctx, qtx, input.TaskID, true)
+}
+
+// applyDelete deletes a task with its history, journal, sync and webhook
+// entries.
+func (r *TaskRepository) applyDelete(ctx context.Context, qtx *sqlc.Queries, id string) error {
+	deleted, err := deleteTask(ctx, qtx, id, r.actor)
+	if err != nil {
+		return err
+	}
+	return journalTask(ctx, qtx, r.journal, domain.OperationTaskDelete, id, deleted, nil)
+}
+
+// placeTask runs place over the other tasks of the column a task goes to and
+// returns the position it gives the task. A column place renumbers only has
+// its positions rewritten: that is not a change to its tasks, so it leaves
+// their versions, history and sync state alone.
+func placeTask(ctx context.Context, qtx *sqlc.Queries, workspaceID string, boardID, columnID *string, taskID string, place domain.PlaceFunc) (float64, error) {
+	var siblings []domain.Task
+	if columnID != nil && *columnID != "" {
+		arg := sqlc.ListTasksParams{WorkspaceID: workspaceID, ColumnID: *columnID}
+		if boardID != nil {
+			arg.BoardID = *boardID
+		}
+		items, err := qtx.ListTasks(ctx, arg)
+		if err != nil {
+			return 0, err
+		}
+		for _, item := range items {
+			if item.ID != taskID {
+				siblings = append(siblings, fromSQLTask(item))
+			}
+		}
+	}
+	position, renumber, err := place(siblings)
+	if err != nil {
+		return 0, err
+	}
+	for id, p := range renumber {
+		if err := qtx.UpdateTaskPosition(ctx, sqlc.UpdateTaskPositionParams{Position: p, ID: id}); err != nil {
+			return 0, err
+		}
+	}
+	return position, nil
+}
+
+// createTask inserts the task with its history, sync and webhook entries and
+// returns it as stored.
+func createTask(ctx context.Context, qtx *sqlc.Queries, task domain.Task, actor string) (*domain.Task, error) {
+	if err := qtx.CreateTask(ctx, sqlc.CreateTaskParams{
+		ID:              task.ID,
+		ProviderID:      task.ProviderID,
+		WorkspaceID:     task.WorkspaceID,
+		BoardID:         nullString(task.BoardID),
+		ColumnID:        nullString(task.ColumnID),
+		RemoteID:        nullString(task.RemoteID),
+		Title:           task.Title,
+		DescriptionMd:   task.DescriptionMD,
+		Status:          nullString(task.Status),
+		Priority:        int64(task.Priority),
+		DueAt:           nullableTimeToString(task.DueAt),
+		EstimateMinutes: nullInt(task.EstimateMinutes),
+		Assignee:        nullString(task.Assignee),
+		LabelsJSON:      marshalLabels(task.Labels),
+		Position:        task.Position,
+		CreatedAt:       task.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:       task.UpdatedAt.UTC().Format(time.RFC3339),
+	}); err != nil {
+		return nil, err
+	}
+	if err := ensureLabels(ctx, qtx, task.WorkspaceID, task.Labels); err != nil {
+		return nil, err
+	}
+	created, err := loadTask(ctx, qtx, task.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := recordTaskEvent(ctx, qtx, created, domain.TaskEventCreated, nil, actor); err != nil {
+		return nil, err
+	}
+	item, err := taskSyncItem(ctx, qtx, task.ID, domain.SyncActionCreate)
+	if err != nil {
+		return nil, err
+	}
+	if err := enqueueSync(ctx, qtx, item); err != nil {
+		return nil, err
+	}
+	event, err := taskWebhookEvent(ctx, qtx, task.ID, domain.WebhookEventTaskCreated)
+	if err != nil {
+		return nil, err
+	}
+	return created, enqueueWebhooks(ctx, qtx, event)
+}
+
+// restoreTask writes every field of target over the current task, as undo
+// and redo do, and records the change like an update or a move.
+func restoreTask(ctx context.Context, qtx *sqlc.Queries, current *domain.Task, target domain.Task, moved bool, actor string) error {
+	var gone *sqlc.CreateSyncItemParams
+	if current.WorkspaceID != target.WorkspaceID {
+		var err error
+		if gone, err = transferTask(ctx, qtx, *current, target); err != nil {
+			return err
+		}
+	}
+	if err := qtx.RestoreTask(ctx, sqlc.RestoreTaskParams{
+		BoardID:         nullString(target.BoardID),
+		ColumnID:        nullString(target.ColumnID),
+		Title:           target.Title,
+		DescriptionMd:   target.DescriptionMD,
+		Status:          nullString(target.Status),
+		Priority:        int64(target.Priority),
+		DueAt:           nullableTimeToString(target.DueAt),
+		EstimateMinutes: nullInt(target.EstimateMinutes),
+		Assignee:        nullString(target.Assignee),
+		LabelsJSON:      marshalLabels(target.Labels),
+		Position:        target.Position,
+		UpdatedAt:       time.Now().UTC().Format(time.RFC3339),
+		ID:              target.ID,
+	}); err != nil {
+		return err
+	}
+	if err := ensureLabels(ctx, qtx, target.WorkspaceID, target.Labels); err != nil {
+		return err
+	}
+	after, err := loadTask(ctx, qtx, target.ID)
+	if err != nil {
+		return err
+	}
+	kind := domain.TaskEventUpdated
+	if moved {
+		kind = domain.TaskEventMoved
+	}
+	if err := recordTaskChanges(ctx, qtx, current, after, kind, actor); err != nil {
+		return err
+	}
+	if gone != nil {
+		return enqueueTaskTransfer(ctx, qtx, target.ID, gone)
+	}
+	return enqueueTaskChange(ctx, qtx, target.ID, moved)
+}
+
+// transferTask puts a task on the board and in the workspace of target. A
+// task leaving its workspace leaves its provider too: the task and its
+// comments take the provider of the new workspace and drop their remote
+// IDs. The returned item tells the old provider to delete the task; it is
+// nil when the task stays in its workspace.
+func transferTask(ctx context.Context, qtx *sqlc.Queries, current, target domain.Task) (*sqlc.CreateSyncItemParams, error) {
+	providerID, remoteID := current.ProviderID, current.RemoteID
+	var gone *sqlc.CreateSyncItemParams
+	if target.WorkspaceID != current.WorkspaceID {
+		workspace, err := qtx.GetWorkspace(ctx, target.WorkspaceID)
+		if err != nil {
+			return nil, fmt.Errorf("load destination workspace: %w", err)
+		}
+		if gone, err = taskSyncItem(ctx, qtx, current.ID, domain.SyncActionDelete); err != nil {
+			return nil, err
+		}
+		providerID, remoteID = workspace.ProviderID, nil
+		if err := qtx.TransferTaskComments(ctx, sqlc.TransferTaskCommentsParams{ProviderID: providerID, TaskID: current.ID}); err != nil {
+			return nil, err
+		}
+		if err := ensureLabels(ctx, qtx, target.WorkspaceID, target.Labels); err != nil {
+			return nil, err
+		}
+	}
+	if err := qtx.TransferTask(ctx, sqlc.TransferTaskParams{
+		ProviderID:  providerID,
+		WorkspaceID: target.WorkspaceID,
+		BoardID:     nullString(target.BoardID),
+		RemoteID:    nullString(remoteID),
+		ID:          current.ID,
+	}); err != nil {
+		return nil, err
+	}
+	return gone, nil
+}
+
+// enqueueTaskTransfer queues the sync items and webhooks of a task that
+// moved to another workspace: the old provider deletes it and the new one
+// creates it with its comments. Webhooks see a move.
+func enqueueTaskTransfer(ctx context.Context, qtx *sqlc.Queries, taskID string, gone *sqlc.CreateSyncItemParams) error {
+	if err := enqueueSync(ctx, qtx, gone); err != nil {
+		return err
+	}
+	item, err := taskSyncItem(ctx, qtx, taskID, domain.SyncActionCreate)
+	if err != nil {
+		return err
+	}
+	if err := enqueueSync(ctx, qtx, item); err != nil {
+		return err
+	}
+	comments, err := qtx.ListComments(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	for _, comment := range comments {
+		item, err := commentSyncItem(ctx, qtx, comment.ID, domain.SyncActionCreate)
+		if err != nil {
+			return err
+		}
+		if err := enqueueSync(ctx, qtx, item); err != nil {
+			return err
+		}
+	}
+	event, err := taskWebhookEvent(ctx, qtx, taskID, domain.WebhookEventTaskMoved)
+	if err != nil {
+		return err
+	}
+	return enqueueWebhooks(ctx, qtx, event)
+}
+
+// enqueueTaskChange queues the sync item and webhooks of an updated or
+// moved task.
+func enqueueTaskChange(ctx context.Context, qtx *sqlc.Queries, taskID string, moved bool) error {
+	action, hook := domain.SyncActionUpdate, domain.WebhookEventTaskUpdated
+	if moved {
+		action, hook = domain.SyncActionMove, domain.WebhookEventTaskMoved
+	}
+	item, err := taskSyncItem(ctx, qtx, taskID, action)
+	if err != nil {
+		return err
+	}
+	if err := enqueueSync(ctx, qtx, item); err != nil {
+		return err
+	}
+	event, err := taskWebhookEvent(ctx, qtx, taskID, hook)
+	if err != nil {
+		return err
+	}
+	return enqueueWebhooks(ctx, qtx, event)
+}
+
+// deleteTask removes the task with its history, sync and webhook entries and
+// returns it as it was.
+func deleteTask(ctx context.Context, qtx *sqlc.Queries, id, actor string) (*domain.Task, error) {
+	item, err := taskSyncItem(ctx, qtx, id, domain.SyncActionDelete)
+	if err != nil {
+		return nil, err
+	}
+	event, err := taskWebhookEvent(ctx, qtx, id, domain.WebhookEventTaskDeleted)
+	if err != nil {
+		return nil, err
+	}
+	task, err := loadTask(ctx, qtx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := qtx.DeleteTask(ctx, id); err != nil {
+		return nil, err
+	}
+	if err := recordTaskEvent(ctx, qtx, task, domain.TaskEventDeleted, nil, actor); err != nil {
+		return nil, err
+	}
+	if err := enqueueSync(ctx, qtx, item); err != nil {
+		return nil, err
+	}
+	return task, enqueueWebhooks(ctx, qtx, event)
+}
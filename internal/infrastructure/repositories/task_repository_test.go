@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"testing"
 	"time"
@@ -12,6 +13,18 @@ import (
 )
 
 func seedProviderWorkspaceBoardColumn(t *testing.T, ctx context.Context, q *sqlc.Queries) (providerID, workspaceID, boardID, columnID string) {
+	t.Helper()
+	return seedWorkspaceBoardColumn(t, ctx, q, "local")
+}
+
+// seedPushingWorkspaceBoardColumn is seedProviderWorkspaceBoardColumn with a
+// provider that pushes, so writes are queued for sync.
+func seedPushingWorkspaceBoardColumn(t *testing.T, ctx context.Context, q *sqlc.Queries) (providerID, workspaceID, boardID, columnID string) {
+	t.Helper()
+	return seedWorkspaceBoardColumn(t, ctx, q, "markdown")
+}
+
+func seedWorkspaceBoardColumn(t *testing.T, ctx context.Context, q *sqlc.Queries, providerType string) (providerID, workspaceID, boardID, columnID string) {
 	t.Helper()
 	providerID = "p-task"
 	workspaceID = "w-task"
@@ -20,7 +33,7 @@ func seedProviderWorkspaceBoardColumn(t *testing.T, ctx context.Context, q *sqlc
 
 	if err := q.CreateProvider(ctx, sqlc.CreateProviderParams{
 		ID:        providerID,
-		Type:      "local",
+		Type:      providerType,
 		Name:      "Test Provider",
 		CreatedAt: "2024-01-01T00:00:00Z",
 	}); err != nil {
@@ -202,6 +215,191 @@ func TestTaskRepository_Move(t *testing.T) {
 	}
 }
 
+func TestTaskRepository_Move_ToAnotherWorkspace(t *testing.T) {
+	adapter := newTestAdapter(t)
+	ctx := context.Background()
+	q := adapter.Queries()
+	providerID, workspaceID, boardID, columnID := seedPushingWorkspaceBoardColumn(t, ctx, q)
+	if err := q.CreateProvider(ctx, sqlc.CreateProviderParams{
+		ID: "p-other", Type: "markdown", Name: "Other Provider", CreatedAt: "2024-01-01T00:00:00Z",
+	}); err != nil {
+		t.Fatalf("create provider: %v", err)
+	}
+	if err := q.CreateWorkspace(ctx, sqlc.CreateWorkspaceParams{ID: "w-other", ProviderID: "p-other", Name: "Other"}); err != nil {
+		t.Fatalf("create workspace: %v", err)
+	}
+	if err := q.CreateBoard(ctx, sqlc.CreateBoardParams{ID: "b-other", WorkspaceID: "w-other", Name: "Other", ViewDefault: "kanban"}); err != nil {
+		t.Fatalf("create board: %v", err)
+	}
+	if err := q.CreateColumn(ctx, sqlc.CreateColumnParams{ID: "c-other", BoardID: "b-other", Name: "To Do", Color: "#6B7280", Position: 1}); err != nil {
+		t.Fatalf("create column: %v", err)
+	}
+
+	s := store.New(adapter)
+	repo := NewTaskRepository(s)
+	repo.SetJournal("ns")
+	remoteID := "gh-1"
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	task := domain.Task{
+		ID: "t-transfer", ProviderID: providerID, WorkspaceID: workspaceID,
+		BoardID: &boardID, ColumnID: &columnID, RemoteID: &remoteID, Title: "Transfer",
+		Labels: []string{"bug"}, CreatedAt: now, UpdatedAt: now,
+	}
+	if err := repo.Create(ctx, task); err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	if err := NewCommentRepository(s).Create(ctx, domain.Comment{
+		ID: "cm-transfer", TaskID: task.ID, ProviderID: providerID, BodyMD: "note", CreatedAt: now,
+	}); err != nil {
+		t.Fatalf("create comment: %v", err)
+	}
+	if err := q.DeleteSyncItemsByProvider(ctx, providerID); err != nil {
+		t.Fatalf("clear sync queue: %v", err)
+	}
+
+	board, workspace, column := "b-other", "w-other", "c-other"
+	if err := repo.Move(ctx, domain.MoveTaskInput{
+		TaskID: task.ID, ColumnID: &column, BoardID: &board, WorkspaceID: &workspace,
+		Position: 1, UpdatedAt: now,
+	}); err != nil {
+		t.Fatalf("move task: %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("get task: %v", err)
+	}
+	if got.ProviderID != "p-other" || got.WorkspaceID != workspace || *got.BoardID != board || got.RemoteID != nil {
+		t.Fatalf("expected the task in the other workspace without a remote ID, got %+v", got)
+	}
+	comments, err := q.ListComments(ctx, task.ID)
+	if err != nil || len(comments) != 1 || comments[0].ProviderID != "p-other" {
+		t.Fatalf("expected the comment to follow the task, got %+v (%v)", comments, err)
+	}
+	labels, err := q.ListLabels(ctx, workspace)
+	if err != nil || len(labels) != 1 || labels[0].Name != "bug" {
+		t.Fatalf("expected the label registered in the other workspace, got %+v (%v)", labels, err)
+	}
+
+	old, err := q.ListSyncItems(ctx, providerID)
+	if err != nil || len(old) != 1 || old[0].Action != domain.SyncActionDelete {
+		t.Fatalf("expected a delete for the old provider, got %+v (%v)", old, err)
+	}
+	created, err := q.ListSyncItems(ctx, "p-other")
+	if err != nil || len(created) != 2 {
+		t.Fatalf("expected the task and its comment created on the new provider, got %+v (%v)", created, err)
+	}
+	for _, item := range created {
+		if item.Action != domain.SyncActionCreate {
+			t.Errorf("action = %q, want create", item.Action)
+		}
+	}
+
+	if _, err := NewOperationRepository(s).Undo(ctx, "ns", ""); err != nil {
+		t.Fatalf("undo: %v", err)
+	}
+	got, _ = repo.GetByID(ctx, task.ID)
+	if got.ProviderID != providerID || got.WorkspaceID != workspaceID || *got.BoardID != boardID || *got.ColumnID != columnID {
+		t.Fatalf("expected the task back in its workspace, got %+v", got)
+	}
+}
+
+func TestTaskRepository_PlacesInTransaction(t *testing.T) {
+	adapter := newTestAdapter(t)
+	ctx := context.Background()
+	providerID, workspaceID, boardID, columnID := seedProviderWorkspaceBoardColumn(t, ctx, adapter.Queries())
+
+	s := store.New(adapter)
+	repo := NewTaskRepository(s)
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newTask := func(id string) domain.Task {
+		return domain.Task{
+			ID: id, ProviderID: providerID, WorkspaceID: workspaceID,
+			BoardID: &boardID, ColumnID: &columnID, Title: id,
+			Position: 1.7e18, CreatedAt: created, UpdatedAt: created,
+		}
+	}
+	for _, id := range []string{"t-1", "t-2"} {
+		if err := repo.Create(ctx, newTask(id)); err != nil {
+			t.Fatalf("create %s: %v", id, err)
+		}
+	}
+
+	var seen []string
+	if err := repo.CreatePlaced(ctx, newTask("t-3"), func(siblings []domain.Task) (float64, map[string]float64, error) {
+		for _, sibling := range siblings {
+			seen = append(seen, sibling.ID)
+		}
+		return 3072, map[string]float64{"t-1": 2048, "t-2": 1024}, nil
+	}); err != nil {
+		t.Fatalf("create placed: %v", err)
+	}
+	if len(seen) != 2 {
+		t.Fatalf("siblings = %v, want t-1 and t-2", seen)
+	}
+	got, err := repo.GetByID(ctx, "t-3")
+	if err != nil || got.Position != 3072 {
+		t.Fatalf("t-3 = %+v, %v; want position 3072", got, err)
+	}
+	// Renumbering is not a change to the other tasks.
+	got, err = repo.GetByID(ctx, "t-1")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if got.Position != 2048 || got.Version != 1 || !got.UpdatedAt.Equal(created) {
+		t.Fatalf("t-1 = position %v, version %d, updated %v; want 2048, 1, unchanged", got.Position, got.Version, got.UpdatedAt)
+	}
+	history, err := NewTaskEventRepository(s).ListByTask(ctx, "t-2")
+	if err != nil {
+		t.Fatalf("history: %v", err)
+	}
+	if len(history) != 1 {
+		t.Fatalf("t-2 history = %+v, want only the creation", history)
+	}
+
+	// An update placing a task only does so when it changes column.
+	if err := adapter.Queries().CreateColumn(ctx, sqlc.CreateColumnParams{
+		ID: "c-done", BoardID: boardID, Name: "Done", Color: "#10B981", Position: 2,
+	}); err != nil {
+		t.Fatalf("create column: %v", err)
+	}
+	bottom := func([]domain.Task) (float64, map[string]float64, error) { return 4096, nil, nil }
+	if err := repo.Update(ctx, "t-2", domain.TaskPatch{ColumnID: &columnID, Place: bottom}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if got, _ := repo.GetByID(ctx, "t-2"); got.Position != 1024 {
+		t.Fatalf("t-2 position = %v, want it kept in its column", got.Position)
+	}
+	done := "c-done"
+	if err := repo.Update(ctx, "t-2", domain.TaskPatch{ColumnID: &done, Place: bottom}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if got, _ := repo.GetByID(ctx, "t-2"); got.Position != 4096 || *got.ColumnID != done {
+		t.Fatalf("t-2 = %+v, want it placed in c-done", got)
+	}
+
+	// A change failing later in the transaction undoes the renumbering too.
+	stale := 99
+	err = repo.Bulk(ctx, []domain.TaskChange{
+		{TaskID: "t-3", Move: &domain.MoveTaskInput{
+			ColumnID:  &columnID,
+			UpdatedAt: created,
+			Place: func([]domain.Task) (float64, map[string]float64, error) {
+				return 512, map[string]float64{"t-1": 1}, nil
+			},
+		}},
+		{TaskID: "t-1", Patch: &domain.TaskPatch{IfVersion: &stale}},
+	})
+	if err == nil {
+		t.Fatal("expected the stale change to fail")
+	}
+	for id, want := range map[string]float64{"t-1": 2048, "t-3": 3072} {
+		if got, _ := repo.GetByID(ctx, id); got.Position != want {
+			t.Fatalf("%s position = %v after a failed bulk change, want %v", id, got.Position, want)
+		}
+	}
+}
+
 func TestTaskRepository_Update(t *testing.T) {
 	adapter := newTestAdapter(t)
 	ctx := context.Background()
@@ -259,6 +457,57 @@ func TestTaskRepository_Update(t *testing.T) {
 	}
 }
 
+func TestTaskRepository_IfVersion(t *testing.T) {
+	adapter := newTestAdapter(t)
+	ctx := context.Background()
+	q := adapter.Queries()
+	providerID, workspaceID, boardID, columnID := seedProviderWorkspaceBoardColumn(t, ctx, q)
+
+	repo := NewTaskRepository(store.New(adapter))
+	task := domain.Task{
+		ID:          "t-version",
+		ProviderID:  providerID,
+		WorkspaceID: workspaceID,
+		BoardID:     &boardID,
+		ColumnID:    &columnID,
+		Title:       "Version Test",
+		Labels:      []string{},
+		CreatedAt:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	if err := repo.Create(ctx, task); err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+
+	title := "Edited"
+	one := 1
+	if err := repo.Update(ctx, task.ID, domain.TaskPatch{Title: &title, IfVersion: &one}); err != nil {
+		t.Fatalf("update at the current version: %v", err)
+	}
+	// A second writer still holding version 1 must not overwrite the edit.
+	err := repo.Update(ctx, task.ID, domain.TaskPatch{Title: &title, IfVersion: &one})
+	var conflict *domain.VersionConflictError
+	if !errors.As(err, &conflict) || conflict.Actual != 2 || conflict.Expected != 1 {
+		t.Fatalf("expected a version conflict at version 2, got %v", err)
+	}
+	err = repo.Move(ctx, domain.MoveTaskInput{TaskID: task.ID, ColumnID: &columnID, UpdatedAt: time.Now(), IfVersion: &one})
+	if !errors.Is(err, domain.ErrVersionConflict) {
+		t.Fatalf("expected the move to conflict, got %v", err)
+	}
+
+	two := 2
+	if err := repo.Move(ctx, domain.MoveTaskInput{TaskID: task.ID, ColumnID: &columnID, UpdatedAt: time.Now(), IfVersion: &two}); err != nil {
+		t.Fatalf("move at the current version: %v", err)
+	}
+	got, err := repo.GetByID(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("get by id: %v", err)
+	}
+	if got.Version != 3 {
+		t.Errorf("Version = %d, want 3", got.Version)
+	}
+}
+
 func TestTaskRepository_Create_ErrorContext(t *testing.T) {
 	adapter := newTestAdapter(t)
 	ctx := context.Background()
@@ -380,6 +629,67 @@ func TestTaskRepository_Delete(t *testing.T) {
 	}
 }
 
+func TestTaskRepository_Bulk(t *testing.T) {
+	adapter := newTestAdapter(t)
+	ctx := context.Background()
+	providerID, workspaceID, boardID, columnID := seedProviderWorkspaceBoardColumn(t, ctx, adapter.Queries())
+
+	s := store.New(adapter)
+	repo := NewTaskRepository(s)
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, id := range []string{"t-1", "t-2", "t-3"} {
+		if err := repo.Create(ctx, domain.Task{
+			ID: id, ProviderID: providerID, WorkspaceID: workspaceID,
+			BoardID: &boardID, ColumnID: &columnID, Title: id,
+			Position: 1, CreatedAt: created, UpdatedAt: created,
+		}); err != nil {
+			t.Fatalf("create %s: %v", id, err)
+		}
+	}
+
+	priority := 3
+	stale := 7
+	err := repo.Bulk(ctx, []domain.TaskChange{
+		{TaskID: "t-1", Patch: &domain.TaskPatch{Priority: &priority}},
+		{TaskID: "t-2", Delete: true},
+		{TaskID: "t-3", Patch: &domain.TaskPatch{Priority: &priority, IfVersion: &stale}},
+	})
+	if !errors.Is(err, domain.ErrVersionConflict) {
+		t.Fatalf("expected a version conflict, got %v", err)
+	}
+	// The failing change rolls back the ones before it.
+	if got, err := repo.GetByID(ctx, "t-1"); err != nil || got.Priority != 0 {
+		t.Fatalf("t-1 = %+v, %v; want priority 0", got, err)
+	}
+	if _, err := repo.GetByID(ctx, "t-2"); err != nil {
+		t.Fatalf("t-2 should still exist: %v", err)
+	}
+
+	if err := repo.Bulk(ctx, []domain.TaskChange{
+		{TaskID: "t-1", Patch: &domain.TaskPatch{Priority: &priority}},
+		{TaskID: "t-2", Delete: true},
+		{TaskID: "t-3", Move: &domain.MoveTaskInput{ColumnID: &columnID, Position: 2048, UpdatedAt: created}},
+	}); err != nil {
+		t.Fatalf("bulk: %v", err)
+	}
+	if got, _ := repo.GetByID(ctx, "t-1"); got.Priority != 3 {
+		t.Fatalf("t-1 priority = %d, want 3", got.Priority)
+	}
+	if _, err := repo.GetByID(ctx, "t-2"); err == nil {
+		t.Fatal("t-2 should be deleted")
+	}
+	if got, _ := repo.GetByID(ctx, "t-3"); got.Position != 2048 {
+		t.Fatalf("t-3 position = %v, want 2048", got.Position)
+	}
+	history, err := NewTaskEventRepository(s).ListByTask(ctx, "t-1")
+	if err != nil {
+		t.Fatalf("history: %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("t-1 history = %+v, want created and updated", history)
+	}
+}
+
 func TestTaskRepository_List(t *testing.T) {
 	adapter := newTestAdapter(t)
 	ctx := context.Background()
@@ -689,3 +999,47 @@ func TestTaskRepository_Move_ErrorContext(t *testing.T) {
 		t.Errorf("ColumnID = %v, want %q", got.ColumnID, columnID)
 	}
 }
+
+func TestTaskRepository_BuildBoardIsAtomic(t *testing.T) {
+	adapter := newTestAdapter(t)
+	ctx := context.Background()
+	providerID, workspaceID, _, _ := seedProviderWorkspaceBoardColumn(t, ctx, adapter.Queries())
+
+	repo := NewTaskRepository(store.New(adapter))
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	boardID, columnID := "b-new", "c-new"
+	build := domain.BoardBuild{
+		Board:   domain.Board{ID: boardID, WorkspaceID: workspaceID, Name: "New", ViewDefault: "list"},
+		Columns: []domain.Column{{ID: columnID, BoardID: boardID, Name: "Todo", Color: "#60A5FA", Position: 1}},
+		Labels:  []domain.Label{{ID: "l-new", WorkspaceID: workspaceID, Name: "blocker", Color: "#F87171", CreatedAt: created, UpdatedAt: created}},
+	}
+	for _, id := range []string{"t-1", "t-1"} {
+		build.Tasks = append(build.Tasks, domain.Task{
+			ID: id, ProviderID: providerID, WorkspaceID: workspaceID,
+			BoardID: &boardID, ColumnID: &columnID, Title: id,
+			CreatedAt: created, UpdatedAt: created,
+		})
+	}
+
+	// The second task reuses the first one's ID, so the build fails and
+	// nothing of it stays.
+	if err := repo.BuildBoard(ctx, build); err == nil {
+		t.Fatal("expected the build to fail")
+	}
+	boards, err := adapter.Queries().ListBoards(ctx, workspaceID)
+	if err != nil || len(boards) != 1 {
+		t.Fatalf("boards = %d (%v), want only the seeded one", len(boards), err)
+	}
+	if _, err := adapter.Queries().GetLabelByName(ctx, sqlc.GetLabelByNameParams{WorkspaceID: workspaceID, Name: "blocker"}); err == nil {
+		t.Fatal("label created by a failed build")
+	}
+
+	build.Tasks = build.Tasks[:1]
+	if err := repo.BuildBoard(ctx, build); err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	task, err := repo.GetByID(ctx, "t-1")
+	if err != nil || task.BoardID == nil || *task.BoardID != boardID {
+		t.Fatalf("task = %+v (%v), want it on the new board", task, err)
+	}
+}
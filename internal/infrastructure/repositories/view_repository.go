@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+	"github.com/tiagokriok/kanji/internal/infrastructure/db/sqlc"
+	"github.com/tiagokriok/kanji/internal/infrastructure/store"
+)
+
+type ViewRepository struct {
+	store store.Store
+}
+
+func NewViewRepository(s store.Store) *ViewRepository {
+	return &ViewRepository{store: s}
+}
+
+func (r *ViewRepository) Create(ctx context.Context, view domain.View) error {
+	settings, err := json.Marshal(view.Settings)
+	if err != nil {
+		return fmt.Errorf("encode view settings: %w", err)
+	}
+	return r.store.Write(ctx, "create view", func(tx store.Tx) error {
+		return tx.Queries().CreateView(ctx, sqlc.CreateViewParams{
+			ID:           view.ID,
+			WorkspaceID:  view.WorkspaceID,
+			BoardID:      nullString(view.BoardID),
+			Name:         view.Name,
+			SettingsJSON: string(settings),
+			CreatedAt:    view.CreatedAt.UTC().Format(time.RFC3339),
+			UpdatedAt:    view.UpdatedAt.UTC().Format(time.RFC3339),
+		})
+	})
+}
+
+func (r *ViewRepository) Update(ctx context.Context, view domain.View) error {
+	settings, err := json.Marshal(view.Settings)
+	if err != nil {
+		return fmt.Errorf("encode view settings: %w", err)
+	}
+	return r.store.Write(ctx, "update view", func(tx store.Tx) error {
+		affected, err := tx.Queries().UpdateView(ctx, sqlc.UpdateViewParams{
+			SettingsJSON: string(settings),
+			UpdatedAt:    view.UpdatedAt.UTC().Format(time.RFC3339),
+			ID:           view.ID,
+		})
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return sql.ErrNoRows
+		}
+		return nil
+	})
+}
+
+func (r *ViewRepository) Get(ctx context.Context, id string) (domain.View, error) {
+	row, err := r.store.Queries().GetView(ctx, strings.TrimSpace(id))
+	if err != nil {
+		return domain.View{}, err
+	}
+	return fromSQLView(row), nil
+}
+
+func (r *ViewRepository) List(ctx context.Context, workspaceID, boardID string) ([]domain.View, error) {
+	rows, err := r.store.Queries().ListViews(ctx, sqlc.ListViewsParams{
+		WorkspaceID: strings.TrimSpace(workspaceID),
+		BoardID:     strings.TrimSpace(boardID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.View, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, fromSQLView(row))
+	}
+	return result, nil
+}
+
+func (r *ViewRepository) Delete(ctx context.Context, id string) (int, error) {
+	var affected int64
+	err := r.store.Write(ctx, "delete view", func(tx store.Tx) error {
+		var execErr error
+		affected, execErr = tx.Queries().DeleteView(ctx, id)
+		return execErr
+	})
+	return int(affected), err
+}
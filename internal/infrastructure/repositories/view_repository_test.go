@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+	"github.com/tiagokriok/kanji/internal/infrastructure/db/sqlc"
+	"github.com/tiagokriok/kanji/internal/infrastructure/store"
+)
+
+func TestViewRepository(t *testing.T) {
+	adapter := newTestAdapter(t)
+	ctx := context.Background()
+	q := adapter.Queries()
+	_, workspaceID, boardID, _ := seedProviderWorkspaceBoardColumn(t, ctx, q)
+	if err := q.CreateBoard(ctx, sqlc.CreateBoardParams{
+		ID:          "b-other",
+		WorkspaceID: workspaceID,
+		Name:        "Other Board",
+		ViewDefault: "kanban",
+	}); err != nil {
+		t.Fatalf("create board: %v", err)
+	}
+
+	s := store.New(adapter)
+	repo := NewViewRepository(s)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	priority := 1
+	otherBoardID := "b-other"
+	for _, view := range []domain.View{
+		{ID: "v-all", Name: "Urgent", Settings: domain.ViewSettings{Priority: &priority, Sort: domain.ViewSortDue}},
+		{ID: "v-board", BoardID: &boardID, Name: "Review", Settings: domain.ViewSettings{Column: "Review", Layout: domain.ViewLayoutKanban}},
+		{ID: "v-other", BoardID: &otherBoardID, Name: "Backlog"},
+	} {
+		view.WorkspaceID = workspaceID
+		view.CreatedAt, view.UpdatedAt = now, now
+		if err := repo.Create(ctx, view); err != nil {
+			t.Fatalf("create %s: %v", view.ID, err)
+		}
+	}
+
+	duplicate := domain.View{ID: "v-dup", WorkspaceID: workspaceID, BoardID: &boardID, Name: " review ", CreatedAt: now, UpdatedAt: now}
+	if err := repo.Create(ctx, duplicate); err == nil {
+		t.Fatal("expected a duplicate name on the same board to fail")
+	}
+
+	all, err := repo.List(ctx, workspaceID, "")
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if got := viewIDs(all); len(got) != 3 || got[0] != "v-other" || got[1] != "v-board" || got[2] != "v-all" {
+		t.Fatalf("List(workspace) = %v, want [v-other v-board v-all]", got)
+	}
+	forBoard, err := repo.List(ctx, workspaceID, boardID)
+	if err != nil {
+		t.Fatalf("list board: %v", err)
+	}
+	if got := viewIDs(forBoard); len(got) != 2 || got[0] != "v-board" || got[1] != "v-all" {
+		t.Fatalf("List(board) = %v, want [v-board v-all]", got)
+	}
+
+	got, err := repo.Get(ctx, "v-all")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if got.BoardID != nil || got.Settings.Priority == nil || *got.Settings.Priority != 1 || got.Settings.Sort != domain.ViewSortDue {
+		t.Fatalf("Get = %+v", got)
+	}
+
+	got.Settings = domain.ViewSettings{Layout: domain.ViewLayoutList}
+	got.UpdatedAt = now.Add(time.Hour)
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if got, _ = repo.Get(ctx, "v-all"); got.Settings.Priority != nil || got.Settings.Layout != domain.ViewLayoutList || !got.UpdatedAt.Equal(now.Add(time.Hour)) {
+		t.Fatalf("after update = %+v", got)
+	}
+
+	if err := NewSetupRepository(s).DeleteBoard(ctx, "b-other"); err != nil {
+		t.Fatalf("delete board: %v", err)
+	}
+	if n, err := repo.Delete(ctx, "v-other"); err != nil || n != 0 {
+		t.Fatalf("views of a deleted board should be gone, Delete = %d, %v", n, err)
+	}
+	if n, err := repo.Delete(ctx, "v-board"); err != nil || n != 1 {
+		t.Fatalf("Delete = %d, %v, want 1", n, err)
+	}
+}
+
+func viewIDs(views []domain.View) []string {
+	ids := make([]string, len(views))
+	for i, v := range views {
+		ids[i] = v.ID
+	}
+	return ids
+}
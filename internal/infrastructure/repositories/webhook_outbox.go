@@ -0,0 +1,153 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/tiagokriok/kanji/internal/infrastructure/db/sqlc"
+)
+
+// Webhook helpers queue one delivery per subscribed webhook inside the
+// caller's write transaction, next to the sync outbox entry. A nil event
+// means the entity does not exist and nothing is delivered.
+
+type webhookEvent struct {
+	workspaceID string
+	name        string
+	data        any
+}
+
+// webhookPayload is the body POSTed to webhook URLs.
+type webhookPayload struct {
+	ID          string `json:"id"`
+	Event       string `json:"event"`
+	WorkspaceID string `json:"workspace_id"`
+	CreatedAt   string `json:"created_at"`
+	Data        any    `json:"data"`
+}
+
+type webhookTask struct {
+	ID          string   `json:"id"`
+	WorkspaceID string   `json:"workspace_id"`
+	BoardID     *string  `json:"board_id"`
+	ColumnID    *string  `json:"column_id"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Status      *string  `json:"status"`
+	Priority    int      `json:"priority"`
+	DueAt       *string  `json:"due_at"`
+	Labels      []string `json:"labels"`
+	CreatedAt   string   `json:"created_at"`
+	UpdatedAt   string   `json:"updated_at"`
+}
+
+type webhookComment struct {
+	ID        string  `json:"id"`
+	TaskID    string  `json:"task_id"`
+	Body      string  `json:"body"`
+	Author    *string `json:"author"`
+	CreatedAt string  `json:"created_at"`
+}
+
+func taskWebhookEvent(ctx context.Context, qtx *sqlc.Queries, taskID, event string) (*webhookEvent, error) {
+	row, err := qtx.GetTask(ctx, taskID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load task for webhooks: %w", err)
+	}
+	task := fromSQLTask(row)
+	var dueAt *string
+	if task.DueAt != nil {
+		formatted := task.DueAt.UTC().Format(time.RFC3339)
+		dueAt = &formatted
+	}
+	return &webhookEvent{
+		workspaceID: task.WorkspaceID,
+		name:        event,
+		data: webhookTask{
+			ID:          task.ID,
+			WorkspaceID: task.WorkspaceID,
+			BoardID:     task.BoardID,
+			ColumnID:    task.ColumnID,
+			Title:       task.Title,
+			Description: task.DescriptionMD,
+			Status:      task.Status,
+			Priority:    task.Priority,
+			DueAt:       dueAt,
+			Labels:      task.Labels,
+			CreatedAt:   task.CreatedAt.UTC().Format(time.RFC3339),
+			UpdatedAt:   task.UpdatedAt.UTC().Format(time.RFC3339),
+		},
+	}, nil
+}
+
+func commentWebhookEvent(ctx context.Context, qtx *sqlc.Queries, commentID, event string) (*webhookEvent, error) {
+	row, err := qtx.GetComment(ctx, commentID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load comment for webhooks: %w", err)
+	}
+	task, err := qtx.GetTask(ctx, row.TaskID)
+	if err != nil {
+		return nil, fmt.Errorf("load comment task for webhooks: %w", err)
+	}
+	comment := fromSQLComment(row)
+	return &webhookEvent{
+		workspaceID: task.WorkspaceID,
+		name:        event,
+		data: webhookComment{
+			ID:        comment.ID,
+			TaskID:    comment.TaskID,
+			Body:      comment.BodyMD,
+			Author:    comment.Author,
+			CreatedAt: comment.CreatedAt.UTC().Format(time.RFC3339),
+		},
+	}, nil
+}
+
+func enqueueWebhooks(ctx context.Context, qtx *sqlc.Queries, event *webhookEvent) error {
+	if event == nil {
+		return nil
+	}
+	rows, err := qtx.ListWebhooks(ctx, event.workspaceID)
+	if err != nil {
+		return fmt.Errorf("load webhooks: %w", err)
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	for _, row := range rows {
+		if !fromSQLWebhook(row).Subscribes(event.name) {
+			continue
+		}
+		payload := webhookPayload{
+			ID:          uuid.NewString(),
+			Event:       event.name,
+			WorkspaceID: event.workspaceID,
+			CreatedAt:   now,
+			Data:        event.data,
+		}
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("encode %s payload: %w", event.name, err)
+		}
+		if err := qtx.CreateWebhookDelivery(ctx, sqlc.CreateWebhookDeliveryParams{
+			ID:          payload.ID,
+			WebhookID:   row.ID,
+			Event:       event.name,
+			PayloadJSON: string(encoded),
+			CreatedAt:   now,
+		}); err != nil {
+			return fmt.Errorf("enqueue %s delivery: %w", event.name, err)
+		}
+	}
+	return nil
+}
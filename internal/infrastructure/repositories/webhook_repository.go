@@ -0,0 +1,144 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+	"github.com/tiagokriok/kanji/internal/infrastructure/db/sqlc"
+	"github.com/tiagokriok/kanji/internal/infrastructure/store"
+)
+
+type WebhookRepository struct {
+	store store.Store
+}
+
+func NewWebhookRepository(s store.Store) *WebhookRepository {
+	return &WebhookRepository{store: s}
+}
+
+func (r *WebhookRepository) Create(ctx context.Context, webhook domain.Webhook) error {
+	events, err := json.Marshal(webhook.Events)
+	if err != nil {
+		return fmt.Errorf("encode webhook events: %w", err)
+	}
+	return r.store.Write(ctx, "create webhook", func(tx store.Tx) error {
+		return tx.Queries().CreateWebhook(ctx, sqlc.CreateWebhookParams{
+			ID:          webhook.ID,
+			WorkspaceID: webhook.WorkspaceID,
+			URL:         webhook.URL,
+			Secret:      webhook.Secret,
+			EventsJSON:  string(events),
+			CreatedAt:   webhook.CreatedAt.UTC().Format(time.RFC3339),
+		})
+	})
+}
+
+func (r *WebhookRepository) Get(ctx context.Context, id string) (domain.Webhook, error) {
+	row, err := r.store.Queries().GetWebhook(ctx, strings.TrimSpace(id))
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+	return fromSQLWebhook(row), nil
+}
+
+func (r *WebhookRepository) List(ctx context.Context, workspaceID string) ([]domain.Webhook, error) {
+	rows, err := r.store.Queries().ListWebhooks(ctx, strings.TrimSpace(workspaceID))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.Webhook, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, fromSQLWebhook(row))
+	}
+	return result, nil
+}
+
+func (r *WebhookRepository) SetSecrets(ctx context.Context, secrets map[string]string) error {
+	return r.store.Write(ctx, "set webhook secrets", func(tx store.Tx) error {
+		qtx := tx.Queries()
+		for id, secret := range secrets {
+			if err := qtx.UpdateWebhookSecret(ctx, sqlc.UpdateWebhookSecretParams{Secret: secret, ID: id}); err != nil {
+				return fmt.Errorf("update webhook %s: %w", id, err)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *WebhookRepository) Delete(ctx context.Context, id string) (int, error) {
+	var affected int64
+	err := r.store.Write(ctx, "delete webhook", func(tx store.Tx) error {
+		qtx := tx.Queries()
+		if err := qtx.DeleteWebhookDeliveriesByWebhook(ctx, id); err != nil {
+			return fmt.Errorf("delete webhook deliveries: %w", err)
+		}
+		var execErr error
+		affected, execErr = qtx.DeleteWebhook(ctx, id)
+		return execErr
+	})
+	return int(affected), err
+}
+
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, webhookID string) ([]domain.WebhookDelivery, error) {
+	rows, err := r.store.Queries().ListWebhookDeliveries(ctx, strings.TrimSpace(webhookID))
+	if err != nil {
+		return nil, err
+	}
+	return fromSQLWebhookDeliveries(rows), nil
+}
+
+func (r *WebhookRepository) ListUndelivered(ctx context.Context) ([]domain.WebhookDelivery, error) {
+	rows, err := r.store.Queries().ListUndeliveredWebhookDeliveries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return fromSQLWebhookDeliveries(rows), nil
+}
+
+func (r *WebhookRepository) MarkDelivered(ctx context.Context, id string, status int, at time.Time) error {
+	return r.store.Write(ctx, "mark webhook delivered", func(tx store.Tx) error {
+		return tx.Queries().MarkWebhookDelivered(ctx, sqlc.MarkWebhookDeliveredParams{
+			ResponseStatus: sql.NullInt64{Int64: int64(status), Valid: true},
+			DeliveredAt:    nullableTimeToString(&at),
+			ID:             id,
+		})
+	})
+}
+
+func (r *WebhookRepository) RecordFailure(ctx context.Context, id string, status *int, lastError string, nextAttemptAt time.Time) error {
+	return r.store.Write(ctx, "record webhook failure", func(tx store.Tx) error {
+		return tx.Queries().RecordWebhookFailure(ctx, sqlc.RecordWebhookFailureParams{
+			ResponseStatus: nullInt(status),
+			LastError:      sql.NullString{String: lastError, Valid: true},
+			NextAttemptAt:  nullableTimeToString(&nextAttemptAt),
+			ID:             id,
+		})
+	})
+}
+
+func (r *WebhookRepository) Retry(ctx context.Context, id string) (int, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return 0, fmt.Errorf("delivery id is required")
+	}
+	var affected int64
+	err := r.store.Write(ctx, "retry webhook delivery", func(tx store.Tx) error {
+		var execErr error
+		affected, execErr = tx.Queries().ResetWebhookDelivery(ctx, id)
+		return execErr
+	})
+	return int(affected), err
+}
+
+func fromSQLWebhookDeliveries(rows []sqlc.WebhookDelivery) []domain.WebhookDelivery {
+	result := make([]domain.WebhookDelivery, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, fromSQLWebhookDelivery(row))
+	}
+	return result
+}
@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+	"github.com/tiagokriok/kanji/internal/infrastructure/store"
+)
+
+func TestTaskRepository_WritesEnqueueWebhookDeliveries(t *testing.T) {
+	adapter := newTestAdapter(t)
+	ctx := context.Background()
+	providerID, workspaceID, boardID, columnID := seedProviderWorkspaceBoardColumn(t, ctx, adapter.Queries())
+
+	s := store.New(adapter)
+	tasks := NewTaskRepository(s)
+	comments := NewCommentRepository(s)
+	webhooks := NewWebhookRepository(s)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, w := range []domain.Webhook{
+		{ID: "wh-tasks", WorkspaceID: workspaceID, URL: "http://a", Secret: "s", Events: []string{domain.WebhookEventTaskCreated, domain.WebhookEventTaskMoved}, CreatedAt: now},
+		{ID: "wh-comments", WorkspaceID: workspaceID, URL: "http://b", Secret: "s", Events: []string{domain.WebhookEventCommentCreated}, CreatedAt: now},
+	} {
+		if err := webhooks.Create(ctx, w); err != nil {
+			t.Fatalf("create webhook: %v", err)
+		}
+	}
+
+	if err := tasks.Create(ctx, domain.Task{
+		ID: "t-hook", ProviderID: providerID, WorkspaceID: workspaceID,
+		BoardID: &boardID, ColumnID: &columnID, Title: "Hook me", CreatedAt: now, UpdatedAt: now,
+	}); err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	title := "Renamed"
+	if err := tasks.Update(ctx, "t-hook", domain.TaskPatch{Title: &title}); err != nil {
+		t.Fatalf("update task: %v", err)
+	}
+	if err := tasks.Move(ctx, domain.MoveTaskInput{TaskID: "t-hook", ColumnID: &columnID, Position: 2, UpdatedAt: now}); err != nil {
+		t.Fatalf("move task: %v", err)
+	}
+	if err := comments.Create(ctx, domain.Comment{ID: "c-hook", TaskID: "t-hook", ProviderID: providerID, BodyMD: "hi", CreatedAt: now}); err != nil {
+		t.Fatalf("create comment: %v", err)
+	}
+
+	taskDeliveries, err := webhooks.ListDeliveries(ctx, "wh-tasks")
+	if err != nil {
+		t.Fatalf("list deliveries: %v", err)
+	}
+	if len(taskDeliveries) != 2 || taskDeliveries[0].Event != domain.WebhookEventTaskMoved || taskDeliveries[1].Event != domain.WebhookEventTaskCreated {
+		t.Fatalf("unexpected task deliveries (newest first): %+v", taskDeliveries)
+	}
+	var payload struct {
+		ID    string `json:"id"`
+		Event string `json:"event"`
+		Data  struct {
+			Title string `json:"title"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(taskDeliveries[0].PayloadJSON), &payload); err != nil {
+		t.Fatalf("decode payload: %v", err)
+	}
+	if payload.ID != taskDeliveries[0].ID || payload.Event != domain.WebhookEventTaskMoved || payload.Data.Title != "Renamed" {
+		t.Fatalf("unexpected payload: %+v", payload)
+	}
+
+	commentDeliveries, err := webhooks.ListDeliveries(ctx, "wh-comments")
+	if err != nil {
+		t.Fatalf("list deliveries: %v", err)
+	}
+	if len(commentDeliveries) != 1 || commentDeliveries[0].Event != domain.WebhookEventCommentCreated {
+		t.Fatalf("unexpected comment deliveries: %+v", commentDeliveries)
+	}
+
+	if affected, err := webhooks.Delete(ctx, "wh-tasks"); err != nil || affected != 1 {
+		t.Fatalf("delete webhook = %d, %v", affected, err)
+	}
+	remaining, err := webhooks.ListDeliveries(ctx, "")
+	if err != nil || len(remaining) != 1 {
+		t.Fatalf("deliveries after delete = %+v, %v", remaining, err)
+	}
+}
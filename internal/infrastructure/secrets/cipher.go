@@ -0,0 +1,353 @@
+// Package secrets encrypts provider credentials at rest.
+//
+// Encrypted values are JSON envelopes, so providers.auth_json keeps holding
+// valid JSON. The key is either derived from a passphrase (KANJI_PASSPHRASE)
+// or read from a random key file under the config dir, created on first use.
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// PassphraseEnv names the environment variable holding the passphrase the
+// credential key is derived from. When unset, the key file is used.
+const PassphraseEnv = "KANJI_PASSPHRASE"
+
+// NewPassphraseEnv names the environment variable holding the passphrase to
+// rotate to.
+const NewPassphraseEnv = "KANJI_NEW_PASSPHRASE"
+
+const (
+	envelopeVersion  = 1
+	kdfKeyFile       = "keyfile"
+	kdfPassphrase    = "pbkdf2-sha256"
+	pbkdf2Iterations = 600_000
+	// Envelopes are not authenticated before the key is derived, so their
+	// iteration count is held to a range that is neither weak nor a way to
+	// stall every read.
+	minPBKDF2Iterations = 100_000
+	maxPBKDF2Iterations = 10_000_000
+	keySize             = 32
+	saltSize            = 16
+)
+
+// envelope is the stored form of an encrypted credential.
+type envelope struct {
+	Version    int    `json:"kanji_secret"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iter,omitempty"`
+	Salt       string `json:"salt,omitempty"`
+	Nonce      string `json:"nonce"`
+	Data       string `json:"data"`
+}
+
+// Cipher encrypts and decrypts credentials. New values are encrypted with
+// the passphrase when one is set, otherwise with the key file.
+type Cipher struct {
+	keyPath    string
+	passphrase string
+
+	mu sync.Mutex
+	// derived holds the keys derived from the passphrase, by iterations and
+	// salt.
+	derived map[string][]byte
+}
+
+// NewCipher returns a cipher using the key file at keyPath and, when not
+// empty, the given passphrase.
+func NewCipher(keyPath, passphrase string) *Cipher {
+	return &Cipher{keyPath: keyPath, passphrase: passphrase}
+}
+
+// FromEnv returns a cipher using the default key file and the passphrase
+// from KANJI_PASSPHRASE, if set.
+func FromEnv() (*Cipher, error) {
+	path, err := DefaultKeyPath()
+	if err != nil {
+		return nil, err
+	}
+	return NewCipher(path, os.Getenv(PassphraseEnv)), nil
+}
+
+// DefaultKeyPath returns the canonical credential key file path.
+func DefaultKeyPath() (string, error) {
+	cfgDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("resolve config dir: %w", err)
+	}
+	return filepath.Join(cfgDir, "kanji", "credentials.key"), nil
+}
+
+// UsesPassphrase reports whether new values are encrypted with a passphrase.
+func (c *Cipher) UsesPassphrase() bool {
+	return c.passphrase != ""
+}
+
+// IsEncrypted reports whether a stored value is an encrypted envelope.
+func (c *Cipher) IsEncrypted(stored string) bool {
+	_, ok := parseEnvelope(stored)
+	return ok
+}
+
+// Encrypt seals plaintext into an envelope.
+func (c *Cipher) Encrypt(plaintext string) (string, error) {
+	env := envelope{Version: envelopeVersion}
+	var key []byte
+	if c.passphrase != "" {
+		salt := make([]byte, saltSize)
+		if _, err := rand.Read(salt); err != nil {
+			return "", fmt.Errorf("generate salt: %w", err)
+		}
+		derived, err := c.deriveKey(salt, pbkdf2Iterations)
+		if err != nil {
+			return "", fmt.Errorf("derive key: %w", err)
+		}
+		key = derived
+		env.KDF = kdfPassphrase
+		env.Iterations = pbkdf2Iterations
+		env.Salt = base64.StdEncoding.EncodeToString(salt)
+	} else {
+		loaded, err := c.loadOrCreateKey()
+		if err != nil {
+			return "", err
+		}
+		key = loaded
+		env.KDF = kdfKeyFile
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("generate nonce: %w", err)
+	}
+	env.Nonce = base64.StdEncoding.EncodeToString(nonce)
+	env.Data = base64.StdEncoding.EncodeToString(aead.Seal(nil, nonce, []byte(plaintext), nil))
+
+	data, err := json.Marshal(env)
+	if err != nil {
+		return "", fmt.Errorf("encode credentials: %w", err)
+	}
+	return string(data), nil
+}
+
+// Decrypt opens an envelope. Values that are not encrypted are returned
+// unchanged so credentials stored before encryption keep working.
+func (c *Cipher) Decrypt(stored string) (string, error) {
+	env, ok := parseEnvelope(stored)
+	if !ok {
+		return stored, nil
+	}
+
+	var key []byte
+	switch env.KDF {
+	case kdfPassphrase:
+		if c.passphrase == "" {
+			return "", fmt.Errorf("credentials are passphrase-encrypted: set %s", PassphraseEnv)
+		}
+		if env.Iterations < minPBKDF2Iterations || env.Iterations > maxPBKDF2Iterations {
+			return "", fmt.Errorf("credentials use %d key derivation iterations; want %d to %d",
+				env.Iterations, minPBKDF2Iterations, maxPBKDF2Iterations)
+		}
+		salt, err := base64.StdEncoding.DecodeString(env.Salt)
+		if err != nil {
+			return "", fmt.Errorf("decode salt: %w", err)
+		}
+		derived, err := c.deriveKey(salt, env.Iterations)
+		if err != nil {
+			return "", fmt.Errorf("derive key: %w", err)
+		}
+		key = derived
+	case kdfKeyFile:
+		loaded, err := c.loadKey()
+		if err != nil {
+			return "", err
+		}
+		key = loaded
+	default:
+		return "", fmt.Errorf("unsupported credential kdf %q", env.KDF)
+	}
+
+	nonce, err := base64.StdEncoding.DecodeString(env.Nonce)
+	if err != nil {
+		return "", fmt.Errorf("decode nonce: %w", err)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(env.Data)
+	if err != nil {
+		return "", fmt.Errorf("decode credentials: %w", err)
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+	if len(nonce) != aead.NonceSize() {
+		return "", errors.New("decrypt credentials: invalid nonce")
+	}
+	plaintext, err := aead.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		if env.KDF == kdfPassphrase {
+			return "", errors.New("decrypt credentials: wrong passphrase")
+		}
+		return "", fmt.Errorf("decrypt credentials: key file %s does not match", c.keyPath)
+	}
+	return string(plaintext), nil
+}
+
+// Rotation is a key change in progress. Next encrypts with the new key;
+// Commit makes it the current key once every credential was re-encrypted.
+type Rotation struct {
+	Next    *Cipher
+	staged  string
+	keyPath string
+}
+
+// Rotate prepares a new key. With a passphrase the new key is derived from
+// it; otherwise a new key file is generated next to the current one and only
+// replaces it on Commit.
+func (c *Cipher) Rotate(newPassphrase string) (*Rotation, error) {
+	if newPassphrase != "" {
+		return &Rotation{Next: NewCipher(c.keyPath, newPassphrase)}, nil
+	}
+	staged := c.keyPath + ".new"
+	// A staged key left by an interrupted rotation never encrypted anything
+	// that was kept.
+	if err := os.Remove(staged); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("remove staged key file: %w", err)
+	}
+	if err := writeKeyFile(staged); err != nil {
+		return nil, err
+	}
+	return &Rotation{Next: NewCipher(staged, ""), staged: staged, keyPath: c.keyPath}, nil
+}
+
+// Commit replaces the current key file with the staged one.
+func (r *Rotation) Commit() error {
+	if r.staged == "" {
+		return nil
+	}
+	if err := os.Rename(r.staged, r.keyPath); err != nil {
+		return fmt.Errorf("install new key file: %w", err)
+	}
+	return nil
+}
+
+// Abort discards the staged key file.
+func (r *Rotation) Abort() {
+	if r.staged != "" {
+		_ = os.Remove(r.staged)
+	}
+}
+
+func parseEnvelope(stored string) (envelope, bool) {
+	trimmed := strings.TrimSpace(stored)
+	if !strings.HasPrefix(trimmed, "{") || !strings.Contains(trimmed, `"kanji_secret"`) {
+		return envelope{}, false
+	}
+	var env envelope
+	if err := json.Unmarshal([]byte(trimmed), &env); err != nil || env.Version == 0 {
+		return envelope{}, false
+	}
+	return env, true
+}
+
+// deriveKey derives a key from the passphrase. PBKDF2 is slow on purpose,
+// so the keys are kept for the life of the cipher: values sealed with the
+// same salt, like a secret decrypted on every webhook delivery, derive their
+// key once.
+func (c *Cipher) deriveKey(salt []byte, iterations int) ([]byte, error) {
+	id := fmt.Sprintf("%d:%x", iterations, salt)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if key, ok := c.derived[id]; ok {
+		return key, nil
+	}
+	key, err := pbkdf2.Key(sha256.New, c.passphrase, salt, iterations, keySize)
+	if err != nil {
+		return nil, err
+	}
+	if c.derived == nil {
+		c.derived = make(map[string][]byte)
+	}
+	c.derived[id] = key
+	return key, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("init cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("init cipher: %w", err)
+	}
+	return aead, nil
+}
+
+func (c *Cipher) loadKey() ([]byte, error) {
+	data, err := os.ReadFile(c.keyPath)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("credential key file %s not found", c.keyPath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read key file: %w", err)
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil || len(key) != keySize {
+		return nil, fmt.Errorf("key file %s is not a valid key", c.keyPath)
+	}
+	return key, nil
+}
+
+// loadOrCreateKey loads the key file, creating it first if it does not
+// exist. When another process creates it at the same time, both use the one
+// created first.
+func (c *Cipher) loadOrCreateKey() ([]byte, error) {
+	if err := writeKeyFile(c.keyPath); err != nil && !errors.Is(err, fs.ErrExist) {
+		return nil, err
+	}
+	return c.loadKey()
+}
+
+// writeKeyFile stores a new random key readable only by the current user. It
+// never replaces a file: when path exists it fails with an error matching
+// fs.ErrExist.
+func writeKeyFile(path string) error {
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return fmt.Errorf("generate key: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("create key dir: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return fmt.Errorf("create key file: %w", err)
+	}
+	encoded := base64.StdEncoding.EncodeToString(key) + "\n"
+	if _, err := f.WriteString(encoded); err != nil {
+		_ = f.Close()
+		_ = os.Remove(path)
+		return fmt.Errorf("write key file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(path)
+		return fmt.Errorf("write key file: %w", err)
+	}
+	return nil
+}
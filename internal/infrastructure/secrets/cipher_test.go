@@ -0,0 +1,180 @@
+package secrets
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCipher_KeyFileRoundTrip(t *testing.T) {
+	keyPath := filepath.Join(t.TempDir(), "kanji", "credentials.key")
+	c := NewCipher(keyPath, "")
+
+	stored, err := c.Encrypt(`{"token":"hunter2"}`)
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+	if strings.Contains(stored, "hunter2") || !c.IsEncrypted(stored) {
+		t.Fatalf("expected an encrypted envelope, got %s", stored)
+	}
+	info, err := os.Stat(keyPath)
+	if err != nil {
+		t.Fatalf("expected key file to be created: %v", err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Fatalf("key file mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	plaintext, err := c.Decrypt(stored)
+	if err != nil || plaintext != `{"token":"hunter2"}` {
+		t.Fatalf("decrypt = %q, %v", plaintext, err)
+	}
+
+	other := NewCipher(filepath.Join(t.TempDir(), "other.key"), "")
+	if _, err := other.Encrypt("x"); err != nil {
+		t.Fatalf("encrypt with other key: %v", err)
+	}
+	if _, err := other.Decrypt(stored); err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Fatalf("expected key mismatch, got %v", err)
+	}
+}
+
+func TestCipher_KeyFileCreatedOnce(t *testing.T) {
+	keyPath := filepath.Join(t.TempDir(), "kanji", "credentials.key")
+	first := NewCipher(keyPath, "")
+	stored, err := first.Encrypt("token")
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+	created, err := os.ReadFile(keyPath)
+	if err != nil {
+		t.Fatalf("read key file: %v", err)
+	}
+
+	// A cipher that finds the key file already there uses it.
+	second := NewCipher(keyPath, "")
+	if _, err := second.Encrypt("other"); err != nil {
+		t.Fatalf("encrypt with existing key: %v", err)
+	}
+	if plaintext, err := second.Decrypt(stored); err != nil || plaintext != "token" {
+		t.Fatalf("decrypt = %q, %v", plaintext, err)
+	}
+	if err := writeKeyFile(keyPath); !errors.Is(err, fs.ErrExist) {
+		t.Fatalf("expected fs.ErrExist for an existing key file, got %v", err)
+	}
+	if kept, _ := os.ReadFile(keyPath); string(kept) != string(created) {
+		t.Fatal("key file was replaced")
+	}
+}
+
+func TestCipher_Passphrase(t *testing.T) {
+	keyPath := filepath.Join(t.TempDir(), "credentials.key")
+	c := NewCipher(keyPath, "correct horse")
+
+	stored, err := c.Encrypt("token")
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+	if _, err := os.Stat(keyPath); !os.IsNotExist(err) {
+		t.Fatalf("passphrase mode must not create a key file, got %v", err)
+	}
+	if plaintext, err := c.Decrypt(stored); err != nil || plaintext != "token" {
+		t.Fatalf("decrypt = %q, %v", plaintext, err)
+	}
+	if _, err := NewCipher(keyPath, "wrong").Decrypt(stored); err == nil || !strings.Contains(err.Error(), "wrong passphrase") {
+		t.Fatalf("expected wrong passphrase error, got %v", err)
+	}
+	if _, err := NewCipher(keyPath, "").Decrypt(stored); err == nil || !strings.Contains(err.Error(), PassphraseEnv) {
+		t.Fatalf("expected hint to set %s, got %v", PassphraseEnv, err)
+	}
+}
+
+func TestCipher_PassphraseKeyDerivedOnce(t *testing.T) {
+	stored, err := NewCipher("", "correct horse").Encrypt("token")
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+	c := NewCipher("", "correct horse")
+	for i := 0; i < 3; i++ {
+		if plaintext, err := c.Decrypt(stored); err != nil || plaintext != "token" {
+			t.Fatalf("decrypt %d = %q, %v", i, plaintext, err)
+		}
+	}
+	if len(c.derived) != 1 {
+		t.Fatalf("derived %d keys, want 1", len(c.derived))
+	}
+}
+
+func TestCipher_RejectsIterationsOutOfRange(t *testing.T) {
+	c := NewCipher("", "correct horse")
+	stored, err := c.Encrypt("token")
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+	for _, iter := range []string{"1", "2000000000"} {
+		tampered := strings.Replace(stored, `"iter":600000`, `"iter":`+iter, 1)
+		if tampered == stored {
+			t.Fatalf("envelope has no iteration count: %s", stored)
+		}
+		if _, err := c.Decrypt(tampered); err == nil || !strings.Contains(err.Error(), "iterations") {
+			t.Fatalf("expected an iteration count error for %s, got %v", iter, err)
+		}
+	}
+	// Only the key Encrypt derived.
+	if len(c.derived) != 1 {
+		t.Fatalf("derived %d keys, want only the one Encrypt derived", len(c.derived))
+	}
+}
+
+func TestCipher_PlaintextPassesThrough(t *testing.T) {
+	c := NewCipher(filepath.Join(t.TempDir(), "credentials.key"), "")
+	for _, stored := range []string{`{"token":"abc"}`, `{"root":"/notes"}`} {
+		if c.IsEncrypted(stored) {
+			t.Fatalf("%s reported as encrypted", stored)
+		}
+		if plaintext, err := c.Decrypt(stored); err != nil || plaintext != stored {
+			t.Fatalf("decrypt(%s) = %q, %v", stored, plaintext, err)
+		}
+	}
+}
+
+func TestCipher_RotateKeyFile(t *testing.T) {
+	keyPath := filepath.Join(t.TempDir(), "credentials.key")
+	c := NewCipher(keyPath, "")
+	old, err := c.Encrypt("token")
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+
+	// A staged key left by an interrupted rotation is replaced.
+	if err := os.WriteFile(keyPath+".new", []byte("stale\n"), 0o600); err != nil {
+		t.Fatalf("write stale staged key: %v", err)
+	}
+	rotation, err := c.Rotate("")
+	if err != nil {
+		t.Fatalf("rotate: %v", err)
+	}
+	rotated, err := rotation.Next.Encrypt("token")
+	if err != nil {
+		t.Fatalf("encrypt with new key: %v", err)
+	}
+	if _, err := c.Decrypt(old); err != nil {
+		t.Fatalf("current key must keep working until commit: %v", err)
+	}
+	if err := rotation.Commit(); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+
+	if plaintext, err := c.Decrypt(rotated); err != nil || plaintext != "token" {
+		t.Fatalf("decrypt after commit = %q, %v", plaintext, err)
+	}
+	if _, err := c.Decrypt(old); err == nil {
+		t.Fatal("expected values encrypted with the old key to fail")
+	}
+	if _, err := os.Stat(keyPath + ".new"); !os.IsNotExist(err) {
+		t.Fatalf("staged key file should be gone, got %v", err)
+	}
+}
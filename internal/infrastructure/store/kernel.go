@@ -4,13 +4,15 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sync/atomic"
 
 	"github.com/tiagokriok/kanji/internal/infrastructure/db"
 	"github.com/tiagokriok/kanji/internal/infrastructure/db/sqlc"
 )
 
 type kernel struct {
-	adapter db.Adapter
+	adapter   db.Adapter
+	committed atomic.Bool
 }
 
 // New creates a Store backed by the given adapter.
@@ -40,9 +42,14 @@ func (k *kernel) InTx(ctx context.Context, fn func(Tx) error) error {
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("commit tx: %w", err)
 	}
+	k.committed.Store(true)
 	return nil
 }
 
+func (k *kernel) Committed() bool {
+	return k.committed.Load()
+}
+
 func (k *kernel) Write(ctx context.Context, op string, fn func(Tx) error) error {
 	if err := k.InTx(ctx, fn); err != nil {
 		return fmt.Errorf("%s: %w", op, err)
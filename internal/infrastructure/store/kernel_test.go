@@ -234,3 +234,25 @@ func TestKernel_Write_RollbacksOnError(t *testing.T) {
 		t.Fatal("expected provider p-write-rollback to be rolled back")
 	}
 }
+
+func TestKernel_Committed(t *testing.T) {
+	adapter := newTestAdapter(t)
+	s := New(adapter)
+
+	ctx := context.Background()
+	if s.Committed() {
+		t.Fatal("expected no commit on a new store")
+	}
+	_ = s.Write(ctx, "fail", func(tx Tx) error {
+		return errors.New("intentional failure")
+	})
+	if s.Committed() {
+		t.Fatal("a rolled back write must not count as committed")
+	}
+	if err := s.Write(ctx, "noop", func(tx Tx) error { return nil }); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !s.Committed() {
+		t.Fatal("expected the write to count as committed")
+	}
+}
@@ -11,6 +11,9 @@ type Store interface {
 	InTx(ctx context.Context, fn func(Tx) error) error
 	Write(ctx context.Context, op string, fn func(Tx) error) error
 	Queries() *sqlc.Queries
+	// Committed reports whether a transaction was committed through the
+	// store since it was created.
+	Committed() bool
 }
 
 // Tx exposes the sqlc query interface inside a transaction.
@@ -0,0 +1,111 @@
+// Package templates keeps board templates as YAML files.
+//
+// A template is a file named after the template in the templates
+// directory, such as scrum.yaml. Users may write or edit these files by
+// hand; a file without a name takes it from the file name.
+package templates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+const fileExt = ".yaml"
+
+// Store reads and writes the templates of one directory.
+type Store struct {
+	dir string
+}
+
+// NewStore returns a store for the templates in dir. The directory is
+// created on the first save.
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// DefaultDir returns the canonical templates directory.
+func DefaultDir() (string, error) {
+	cfgDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("resolve config dir: %w", err)
+	}
+	return filepath.Join(cfgDir, "kanji", "templates"), nil
+}
+
+// List returns the templates of the directory by name. A missing directory
+// holds no templates.
+func (s *Store) List(ctx context.Context) ([]domain.BoardTemplate, error) {
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var out []domain.BoardTemplate
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != fileExt {
+			continue
+		}
+		template, err := s.read(filepath.Join(s.dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, template)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return strings.ToLower(out[i].Name) < strings.ToLower(out[j].Name)
+	})
+	return out, nil
+}
+
+// Get returns the template with the given name.
+func (s *Store) Get(ctx context.Context, name string) (domain.BoardTemplate, error) {
+	return s.read(s.path(name))
+}
+
+// Save writes the template, replacing the file of a template with the same
+// name.
+func (s *Store) Save(ctx context.Context, template domain.BoardTemplate) error {
+	data, err := yaml.Marshal(template)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("create templates dir: %w", err)
+	}
+	return os.WriteFile(s.path(template.Name), data, 0o644)
+}
+
+// Delete removes the template file.
+func (s *Store) Delete(ctx context.Context, name string) error {
+	return os.Remove(s.path(name))
+}
+
+func (s *Store) path(name string) string {
+	return filepath.Join(s.dir, strings.ToLower(strings.TrimSpace(name))+fileExt)
+}
+
+func (s *Store) read(path string) (domain.BoardTemplate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return domain.BoardTemplate{}, err
+	}
+	var template domain.BoardTemplate
+	if err := yaml.Unmarshal(data, &template); err != nil {
+		return domain.BoardTemplate{}, fmt.Errorf("template %s: %w", filepath.Base(path), err)
+	}
+	if strings.TrimSpace(template.Name) == "" {
+		template.Name = strings.TrimSuffix(filepath.Base(path), fileExt)
+	}
+	return template, nil
+}
@@ -0,0 +1,67 @@
+package templates
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+func TestStore_SaveGetListDelete(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "templates")
+	store := NewStore(dir)
+	ctx := context.Background()
+
+	list, err := store.List(ctx)
+	if err != nil || len(list) != 0 {
+		t.Fatalf("List() on a missing dir = %v, %v; want no templates", list, err)
+	}
+
+	limit := 3
+	priority := 2
+	template := domain.BoardTemplate{
+		Name:    "Release",
+		Columns: []domain.TemplateColumn{{Name: "Ready", Color: "#60A5FA"}, {Name: "Testing", WIPLimit: &limit}},
+		Labels:  []domain.TemplateLabel{{Name: "blocker", Color: "#F87171"}},
+		Tasks:   []domain.TemplateTask{{Title: "Tag the release", Column: "Ready", Priority: &priority}},
+	}
+	if err := store.Save(ctx, template); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "release.yaml")); err != nil {
+		t.Fatalf("expected release.yaml: %v", err)
+	}
+
+	got, err := store.Get(ctx, "RELEASE")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Name != "Release" || len(got.Columns) != 2 || got.Columns[1].WIPLimit == nil || *got.Columns[1].WIPLimit != 3 {
+		t.Fatalf("unexpected template: %+v", got)
+	}
+	if len(got.Tasks) != 1 || got.Tasks[0].Priority == nil || *got.Tasks[0].Priority != 2 {
+		t.Fatalf("unexpected tasks: %+v", got.Tasks)
+	}
+
+	// A hand-written file without a name is named after the file.
+	if err := os.WriteFile(filepath.Join(dir, "ops.yaml"), []byte("columns:\n  - name: Inbox\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	list, err = store.List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(list) != 2 || list[0].Name != "ops" || list[1].Name != "Release" {
+		t.Fatalf("unexpected list: %+v", list)
+	}
+
+	if err := store.Delete(ctx, "release"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := store.Get(ctx, "release"); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("Get() after delete error = %v, want os.ErrNotExist", err)
+	}
+}
@@ -0,0 +1,82 @@
+// Package webhooks POSTs signed webhook payloads.
+//
+// Every request carries the event name, the delivery ID and an HMAC-SHA256
+// signature of the raw body computed with the webhook's secret, so receivers
+// can verify the payload came from kanji.
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+// Request headers set on every delivery.
+const (
+	HeaderEvent     = "X-Kanji-Event"
+	HeaderDelivery  = "X-Kanji-Delivery"
+	HeaderSignature = "X-Kanji-Signature"
+)
+
+// Sender delivers webhook payloads over HTTP.
+type Sender struct {
+	http *http.Client
+}
+
+// NewSender returns a sender using httpClient, or a client with a 10s
+// timeout when nil.
+func NewSender(httpClient *http.Client) *Sender {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Sender{http: httpClient}
+}
+
+// Sign returns the signature header value for body: "sha256=" followed by
+// the hex HMAC-SHA256 of body keyed with secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is a valid signature of body.
+func Verify(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
+
+// Send POSTs the delivery payload to the webhook URL. It returns the
+// response status, or 0 when no response was received. Any status outside
+// 2xx is an error.
+func (s *Sender) Send(ctx context.Context, webhook domain.Webhook, secret string, delivery domain.WebhookDelivery) (int, error) {
+	body := []byte(delivery.PayloadJSON)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "kanji-webhooks")
+	req.Header.Set(HeaderEvent, delivery.Event)
+	req.Header.Set(HeaderDelivery, delivery.ID)
+	req.Header.Set(HeaderSignature, Sign(secret, body))
+
+	resp, err := s.http.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("%s responded %s", webhook.URL, resp.Status)
+	}
+	return resp.StatusCode, nil
+}
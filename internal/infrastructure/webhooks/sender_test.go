@@ -0,0 +1,99 @@
+package webhooks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+func testDelivery() domain.WebhookDelivery {
+	return domain.WebhookDelivery{ID: "d-1", Event: domain.WebhookEventTaskCreated, PayloadJSON: `{"event":"task.created"}`}
+}
+
+func TestSignAndVerify(t *testing.T) {
+	body := []byte(`{"event":"task.created"}`)
+	signature := Sign("s3cret", body)
+	if !strings.HasPrefix(signature, "sha256=") || len(signature) != len("sha256=")+64 {
+		t.Fatalf("signature = %q, want sha256= and a hex HMAC-SHA256", signature)
+	}
+	if !Verify("s3cret", body, signature) {
+		t.Fatal("expected the signature to verify")
+	}
+	if Verify("other", body, signature) {
+		t.Fatal("a signature verified with the wrong secret")
+	}
+	if Verify("s3cret", []byte(`{"event":"task.deleted"}`), signature) {
+		t.Fatal("a signature verified for another body")
+	}
+}
+
+func TestSender_SendSignsRequest(t *testing.T) {
+	var got *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	status, err := NewSender(nil).Send(context.Background(), domain.Webhook{URL: server.URL}, "s3cret", testDelivery())
+	if err != nil || status != http.StatusNoContent {
+		t.Fatalf("send = %d, %v", status, err)
+	}
+	if got.Method != http.MethodPost || got.Header.Get("Content-Type") != "application/json" {
+		t.Fatalf("request = %s %s", got.Method, got.Header.Get("Content-Type"))
+	}
+	if got.Header.Get(HeaderEvent) != domain.WebhookEventTaskCreated || got.Header.Get(HeaderDelivery) != "d-1" {
+		t.Fatalf("event = %q, delivery = %q", got.Header.Get(HeaderEvent), got.Header.Get(HeaderDelivery))
+	}
+	if string(body) != testDelivery().PayloadJSON {
+		t.Fatalf("body = %s", body)
+	}
+	if !Verify("s3cret", body, got.Header.Get(HeaderSignature)) {
+		t.Fatalf("signature %q does not verify", got.Header.Get(HeaderSignature))
+	}
+}
+
+func TestSender_SendNon2xxIsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	status, err := NewSender(nil).Send(context.Background(), domain.Webhook{URL: server.URL}, "s3cret", testDelivery())
+	if err == nil || !strings.Contains(err.Error(), "500") {
+		t.Fatalf("expected a 500 error, got %v", err)
+	}
+	if status != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", status)
+	}
+}
+
+func TestSender_SendTimesOut(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	sender := NewSender(&http.Client{Timeout: 50 * time.Millisecond})
+	start := time.Now()
+	status, err := sender.Send(context.Background(), domain.Webhook{URL: server.URL}, "s3cret", testDelivery())
+	if err == nil {
+		t.Fatal("expected a timeout error")
+	}
+	if status != 0 {
+		t.Fatalf("status = %d, want 0 without a response", status)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("send took %v, want it cut off by the client timeout", elapsed)
+	}
+}
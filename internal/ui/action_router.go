@@ -38,9 +38,16 @@ func (m Model) executeAction(action string) (tea.Model, tea.Cmd) {
 		return m, nil
 	case "search":
 		return m, m.startSearch()
+	case "filter_expression":
+		return m, m.startFilterExpression()
 	case "open_filters":
 		m.openFilterPanel()
 		return m, nil
+	case "open_saved_views":
+		if err := m.openSavedViewPanel(); err != nil {
+			m.statusLine = err.Error()
+		}
+		return m, nil
 	case "open_workspaces":
 		m.openContextPanel(contextWorkspace)
 		return m, textinput.Blink
@@ -68,10 +75,11 @@ func (m Model) executeAction(action string) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 	case "clear_search":
-		if strings.TrimSpace(m.titleFilter) == "" {
+		if strings.TrimSpace(m.titleFilter) == "" && strings.TrimSpace(m.filterExpr) == "" {
 			return m, nil
 		}
 		m.titleFilter = ""
+		m.filterExpr = ""
 		m.statusLine = ""
 		return m, m.loadTasksCmd()
 	case "new_task":
@@ -158,13 +166,55 @@ func (m Model) executeAction(action string) (tea.Model, tea.Cmd) {
 			}
 		}
 		return m, nil
+	case "reorder_task_up", "reorder_task_down":
+		task, ok := m.currentTask()
+		if !ok {
+			return m, nil
+		}
+		delta := 1
+		if action == "reorder_task_up" {
+			delta = -1
+		}
+		// The list only shows the new order when sorted manually.
+		if m.viewMode == viewList && m.sortMode != sortByManual {
+			m.sortMode = sortByManual
+		}
+		return m, m.reorderTaskCmd(task, delta)
 	case "open_move":
 		return m, m.openTaskViewer()
 	case "move_task":
+		if len(m.marked) > 0 {
+			return m, m.startBulkInput(inputBulkMove)
+		}
 		if task, ok := m.currentTask(); ok {
 			return m, m.moveToNextColumnCmd(task)
 		}
 		return m, nil
+	case "toggle_mark":
+		m.toggleMark()
+		return m, nil
+	case "mark_range":
+		m.markRange()
+		return m, nil
+	case "clear_marks":
+		m.clearMarks()
+		return m, nil
+	case "set_priority":
+		return m, m.startBulkInput(inputBulkPriority)
+	case "edit_labels":
+		return m, m.startBulkInput(inputBulkLabels)
+	case "set_due_date":
+		return m, m.startBulkInput(inputBulkDueDate)
+	case "move_to_board", "copy_task":
+		if err := m.openTaskDestination(action == "copy_task"); err != nil {
+			m.statusLine = err.Error()
+			return m, nil
+		}
+		return m, textinput.Blink
+	case "undo":
+		return m, m.undoCmd(false)
+	case "redo":
+		return m, m.undoCmd(true)
 	default:
 		return m, nil
 	}
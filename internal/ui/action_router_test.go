@@ -69,6 +69,17 @@ func TestExecuteAction_ClearSearch(t *testing.T) {
 	}
 }
 
+func TestExecuteAction_ClearSearchClearsFilterExpression(t *testing.T) {
+	m := Model{filterExpr: "label:bug"}
+	updated, cmd := m.executeAction("clear_search")
+	if um := updated.(Model); um.filterExpr != "" {
+		t.Errorf("filterExpr = %q, want empty", um.filterExpr)
+	}
+	if cmd == nil {
+		t.Error("expected non-nil cmd")
+	}
+}
+
 func TestExecuteAction_ClearSearchEmpty(t *testing.T) {
 	m := Model{titleFilter: ""}
 	updated, cmd := m.executeAction("clear_search")
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"fmt"
 	"sort"
 
 	"github.com/charmbracelet/bubbles/key"
@@ -24,9 +25,14 @@ type inputMode int
 const (
 	inputNone inputMode = iota
 	inputSearch
+	inputFilterExpression
 	inputAddComment
 	inputEditDescription
 	inputTaskForm
+	inputBulkMove
+	inputBulkPriority
+	inputBulkLabels
+	inputBulkDueDate
 )
 
 type dueFilterMode int
@@ -46,6 +52,7 @@ const (
 	sortByTitle
 	sortByUpdated
 	sortByCreated
+	sortByManual
 )
 
 var taskPriorityOptions = []taskPriorityOption{
@@ -58,8 +65,18 @@ var taskPriorityOptions = []taskPriorityOption{
 }
 
 type tasksLoadedMsg struct {
-	tasks []domain.Task
-	err   error
+	tasks     []domain.Task
+	conflicts map[string]bool
+	// snippets holds the search match snippets of the tasks by ID.
+	snippets map[string]string
+	err      error
+	// labelColors maps lowercased label names to their colors.
+	labelColors map[string]string
+	// columnCounts counts the tasks of each column by ID, filtered out or
+	// not.
+	columnCounts map[string]int
+	// selectTaskID keeps this task selected if it is still listed.
+	selectTaskID string
 }
 
 type commentsLoadedMsg struct {
@@ -67,11 +84,23 @@ type commentsLoadedMsg struct {
 	err      error
 }
 
+type historyLoadedMsg struct {
+	events []domain.TaskEvent
+	err    error
+}
+
 type opResultMsg struct {
 	status   string
 	err      error
 	taskID   string
 	columnID string
+	// overwrite, when set, repeats a save that hit a version conflict
+	// without the version check.
+	overwrite tea.Cmd
+	// toast keeps status on the status line once the operation succeeded.
+	toast bool
+	// clearMarks drops the task selection once the operation succeeded.
+	clearMarks bool
 }
 
 type descriptionEditedMsg struct {
@@ -82,10 +111,20 @@ type descriptionEditedMsg struct {
 type Model struct {
 	overlayState
 
-	taskService    *application.TaskService
-	taskFlow       *application.TaskFlow
-	commentService *application.CommentService
-	contextService *application.ContextService
+	taskService     *application.TaskService
+	taskFlow        *application.TaskFlow
+	commentService  *application.CommentService
+	contextService  *application.ContextService
+	syncEngine      *application.SyncEngine
+	historyService  *application.HistoryService
+	searchService   *application.SearchService
+	undoService     *application.UndoService
+	viewService     *application.ViewService
+	labelService    *application.LabelService
+	copyService     *application.TaskCopyService
+	templateService *application.TemplateService
+	undoNamespace   string
+	changes         ChangeWatcher
 
 	dateFormat userDateFormat
 
@@ -98,8 +137,17 @@ type Model struct {
 	workspaces    []domain.Workspace
 	boards        []domain.Board
 
-	tasks    []domain.Task
-	comments []domain.Comment
+	tasks     []domain.Task
+	comments  []domain.Comment
+	history   []domain.TaskEvent
+	conflicts map[string]bool
+	// searchSnippets holds the match snippets of the current search.
+	searchSnippets map[string]string
+	// labelColors maps lowercased label names to their colors.
+	labelColors map[string]string
+	// columnCounts counts the tasks of each column by ID, including those
+	// the active filters hide, for the WIP limits in the kanban headers.
+	columnCounts map[string]int
 
 	selected       int
 	activeColumn   int
@@ -108,6 +156,7 @@ type Model struct {
 	filterIndex    int
 	priorityFilter int
 	titleFilter    string
+	filterExpr     string // e.g. "label:bug and due<7d", on top of the other filters
 	dueFilter      dueFilterMode
 	sortMode       taskSortMode
 
@@ -120,12 +169,24 @@ type Model struct {
 	keyFilter             textinput.Model
 	contextFilter         textinput.Model
 	contextEditInput      textinput.Model
+	viewNameInput         textinput.Model
+	savedViews            []domain.View
 	state                 persistedUIState
 	editingDescTask       string
+	editingDescVersion    int
 	pendingKanbanTaskID   string
 	pendingKanbanColumnID string
 
 	confirmingDelete bool
+	// marked holds the IDs of the tasks selected for a bulk action, and
+	// markAnchor the task V selects a range from.
+	marked     map[string]bool
+	markAnchor string
+	// pendingOverwrite is offered after a save conflicted with a change made
+	// elsewhere.
+	pendingOverwrite tea.Cmd
+	// staleData is set when an external change could not be shown yet.
+	staleData bool
 
 	statusLine string
 	err        error
@@ -136,7 +197,7 @@ type Model struct {
 	keys keyMap
 }
 
-func NewModel(taskService *application.TaskService, taskFlow *application.TaskFlow, commentService *application.CommentService, contextService *application.ContextService, setup application.BootstrapResult) Model {
+func NewModel(taskService *application.TaskService, taskFlow *application.TaskFlow, commentService *application.CommentService, contextService *application.ContextService, syncEngine *application.SyncEngine, setup application.BootstrapResult) Model {
 	ti := textinput.New()
 	ti.Placeholder = "Type..."
 	ti.CharLimit = 512
@@ -162,6 +223,11 @@ func NewModel(taskService *application.TaskService, taskFlow *application.TaskFl
 	ce.Prompt = "> "
 	ce.CharLimit = 256
 
+	vn := textinput.New()
+	vn.Placeholder = "View name..."
+	vn.Prompt = "> "
+	vn.CharLimit = 128
+
 	cols := make([]domain.Column, 0, len(setup.Columns))
 	cols = append(cols, setup.Columns...)
 	sort.Slice(cols, func(i, j int) bool {
@@ -175,6 +241,7 @@ func NewModel(taskService *application.TaskService, taskFlow *application.TaskFl
 		taskFlow:         taskFlow,
 		commentService:   commentService,
 		contextService:   contextService,
+		syncEngine:       syncEngine,
 		dateFormat:       detectUserDateFormat(),
 		providerID:       setup.Provider.ID,
 		workspaceID:      setup.Workspace.ID,
@@ -193,6 +260,7 @@ func NewModel(taskService *application.TaskService, taskFlow *application.TaskFl
 		keyFilter:        kf,
 		contextFilter:    cf,
 		contextEditInput: ce,
+		viewNameInput:    vn,
 		state:            state,
 		keys:             newKeyMap(),
 	}
@@ -201,11 +269,60 @@ func NewModel(taskService *application.TaskService, taskFlow *application.TaskFl
 	return model
 }
 
+// SetChangeWatcher makes the model reload when the database changes
+// outside of it, such as from a CLI command in another terminal.
+func (m *Model) SetChangeWatcher(w ChangeWatcher) {
+	m.changes = w
+}
+
+// SetHistoryService enables the Activity tab of the task viewer.
+func (m *Model) SetHistoryService(s *application.HistoryService) {
+	m.historyService = s
+}
+
+// SetSearchService makes / search titles, descriptions, labels and
+// comments through the full-text index instead of matching titles only.
+func (m *Model) SetSearchService(s *application.SearchService) {
+	m.searchService = s
+}
+
+// SetUndoService enables undo and redo of the changes journaled for the
+// namespace.
+func (m *Model) SetUndoService(s *application.UndoService, namespace string) {
+	m.undoService = s
+	m.undoNamespace = namespace
+}
+
+// SetViewService enables the saved view picker.
+func (m *Model) SetViewService(s *application.ViewService) {
+	m.viewService = s
+}
+
+// SetLabelService colors the label chips of task cards with the colors of
+// the workspace labels.
+func (m *Model) SetLabelService(s *application.LabelService) {
+	m.labelService = s
+}
+
+// SetTaskCopyService enables copying tasks from the destination picker.
+func (m *Model) SetTaskCopyService(s *application.TaskCopyService) {
+	m.copyService = s
+}
+
+// SetTemplateService offers board templates in the board create form.
+func (m *Model) SetTemplateService(s *application.TemplateService) {
+	m.templateService = s
+}
+
 func (m Model) Init() tea.Cmd {
-	return m.loadTasksCmd()
+	return tea.Batch(m.loadTasksCmd(), m.pollChangesCmd())
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// Polls keep running whichever overlay is open.
+	if msg, ok := msg.(changePollMsg); ok {
+		return m.handleChangePoll(msg)
+	}
 	if model, cmd, ok := m.dispatchOverlayUpdate(msg); ok {
 		return model, cmd
 	}
@@ -216,6 +333,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if model, cmd, ok := m.handleDeleteConfirmKey(msg); ok {
 			return model, cmd
 		}
+		if model, cmd, ok := m.handleOverwriteConfirmKey(msg); ok {
+			return model, cmd
+		}
 		switch {
 		case key.Matches(msg, m.keys.Quit):
 			return m.executeAction("quit")
@@ -224,6 +344,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, textinput.Blink
 		case key.Matches(msg, m.keys.ShowFilters):
 			return m.executeAction("open_filters")
+		case key.Matches(msg, m.keys.SavedViews):
+			return m.executeAction("open_saved_views")
 		case key.Matches(msg, m.keys.OpenWorkspace):
 			return m.executeAction("open_workspaces")
 		case key.Matches(msg, m.keys.OpenBoardPanel):
@@ -238,6 +360,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.executeAction("toggle_details")
 		case key.Matches(msg, m.keys.Search):
 			return m.executeAction("search")
+		case key.Matches(msg, m.keys.FilterExpression):
+			return m.executeAction("filter_expression")
 		case key.Matches(msg, m.keys.ClearSearch):
 			return m.executeAction("clear_search")
 		case key.Matches(msg, m.keys.NewTask):
@@ -254,6 +378,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.executeAction("cycle_due_filter")
 		case key.Matches(msg, m.keys.CycleSort):
 			return m.executeAction("cycle_sort")
+		case key.Matches(msg, m.keys.ReorderTaskUp):
+			return m.executeAction("reorder_task_up")
+		case key.Matches(msg, m.keys.ReorderTaskDown):
+			return m.executeAction("reorder_task_down")
 		case key.Matches(msg, m.keys.Up):
 			return m.executeAction("move_up")
 		case key.Matches(msg, m.keys.Down):
@@ -273,13 +401,36 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, m.keys.MoveTaskLeft):
 			return m.executeAction("move_task_left")
 		case key.Matches(msg, m.keys.DeleteTask):
-			if m.viewMode == viewKanban {
+			if len(m.marked) > 0 {
+				m.confirmingDelete = true
+				m.statusLine = fmt.Sprintf("delete %s? y/n", pluralTasks(len(m.marked)))
+			} else if m.viewMode == viewKanban {
 				if _, ok := m.currentTask(); ok {
 					m.confirmingDelete = true
 					m.statusLine = "delete task? y/n"
 				}
 			}
 			return m, nil
+		case key.Matches(msg, m.keys.ToggleMark):
+			return m.executeAction("toggle_mark")
+		case key.Matches(msg, m.keys.MarkRange):
+			return m.executeAction("mark_range")
+		case key.Matches(msg, m.keys.SetPriority):
+			return m.executeAction("set_priority")
+		case key.Matches(msg, m.keys.EditLabels):
+			return m.executeAction("edit_labels")
+		case key.Matches(msg, m.keys.SetDueDate):
+			return m.executeAction("set_due_date")
+		case key.Matches(msg, m.keys.MoveToBoard):
+			return m.executeAction("move_to_board")
+		case key.Matches(msg, m.keys.CopyTask):
+			return m.executeAction("copy_task")
+		case key.Matches(msg, m.keys.Cancel):
+			return m.executeAction("clear_marks")
+		case key.Matches(msg, m.keys.Undo):
+			return m.executeAction("undo")
+		case key.Matches(msg, m.keys.Redo):
+			return m.executeAction("redo")
 		}
 	}
 
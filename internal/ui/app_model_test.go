@@ -227,6 +227,68 @@ func TestHandleDeleteConfirmKey_OtherKeyCancels(t *testing.T) {
 	}
 }
 
+// --- handleOverwriteConfirmKey tests ---
+
+func TestHandleOverwriteConfirmKey_OverwriteRunsSave(t *testing.T) {
+	m := newTestModelWithWindowSize(80, 24)
+	saved := false
+	m.pendingOverwrite = func() tea.Msg {
+		saved = true
+		return nil
+	}
+	m.statusLine = "task changed elsewhere: o overwrite, r reload"
+	updated, cmd, ok := m.handleOverwriteConfirmKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	if !ok {
+		t.Fatal("expected true while the prompt is active")
+	}
+	if updated.pendingOverwrite != nil || updated.statusLine != "" {
+		t.Error("expected the prompt to be cleared")
+	}
+	if cmd == nil {
+		t.Fatal("expected the overwrite cmd")
+	}
+	cmd()
+	if !saved {
+		t.Error("expected the overwrite cmd to run the save")
+	}
+}
+
+func TestHandleOverwriteConfirmKey_ReloadKeyReloads(t *testing.T) {
+	m := newTestModelForLoad(&fakeTaskRepoForLoad{}, &fakeCommentRepoForLoad{})
+	m.pendingOverwrite = func() tea.Msg {
+		t.Error("overwrite must not run")
+		return nil
+	}
+	updated, cmd, ok := m.handleOverwriteConfirmKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	if !ok || updated.pendingOverwrite != nil {
+		t.Fatal("expected the prompt to be answered")
+	}
+	if _, isLoad := cmd().(tasksLoadedMsg); !isLoad {
+		t.Error("expected a task reload")
+	}
+}
+
+func TestHandleOverwriteConfirmKey_UnrelatedKeyKeepsEdit(t *testing.T) {
+	m := newTestModelWithWindowSize(80, 24)
+	m.pendingOverwrite = func() tea.Msg {
+		t.Error("overwrite must not run")
+		return nil
+	}
+	m.statusLine = "task changed elsewhere: o overwrite, r reload"
+	for _, msg := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune{'j'}},
+		{Type: tea.KeyEnter},
+	} {
+		updated, cmd, ok := m.handleOverwriteConfirmKey(msg)
+		if !ok || cmd != nil {
+			t.Fatalf("%s: expected the key to be swallowed", msg)
+		}
+		if updated.pendingOverwrite == nil || updated.statusLine == "" {
+			t.Fatalf("%s: expected the prompt and the pending edit to stay", msg)
+		}
+	}
+}
+
 // --- renderBaseView tests ---
 
 func TestRenderBaseView_ListMode(t *testing.T) {
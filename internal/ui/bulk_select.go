@@ -0,0 +1,301 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/tiagokriok/kanji/internal/application"
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+// markedPrefix marks the title of a selected task.
+const markedPrefix = "✓ "
+
+// visibleTasks returns the listed tasks in the order they are shown: the list
+// order, or the kanban columns left to right.
+func (m Model) visibleTasks() []domain.Task {
+	if m.viewMode != viewKanban {
+		return m.tasks
+	}
+	tasks := make([]domain.Task, 0, len(m.tasks))
+	for _, col := range m.columns {
+		tasks = append(tasks, m.tasksForColumn(col.ID)...)
+	}
+	return tasks
+}
+
+// toggleMark adds the current task to the selection or takes it out, and
+// makes it the anchor of the next range.
+func (m *Model) toggleMark() {
+	task, ok := m.currentTask()
+	if !ok {
+		return
+	}
+	if m.marked == nil {
+		m.marked = map[string]bool{}
+	}
+	if m.marked[task.ID] {
+		delete(m.marked, task.ID)
+	} else {
+		m.marked[task.ID] = true
+	}
+	m.markAnchor = task.ID
+}
+
+// markRange selects every visible task between the anchor and the current
+// task. Without an anchor it selects the current task.
+func (m *Model) markRange() {
+	task, ok := m.currentTask()
+	if !ok {
+		return
+	}
+	if m.marked == nil {
+		m.marked = map[string]bool{}
+	}
+	tasks := m.visibleTasks()
+	from, to := -1, -1
+	for i, t := range tasks {
+		if t.ID == m.markAnchor {
+			from = i
+		}
+		if t.ID == task.ID {
+			to = i
+		}
+	}
+	if from < 0 {
+		from = to
+	}
+	if from > to {
+		from, to = to, from
+	}
+	for _, t := range tasks[from : to+1] {
+		m.marked[t.ID] = true
+	}
+	m.markAnchor = task.ID
+}
+
+func (m *Model) clearMarks() {
+	m.marked = nil
+	m.markAnchor = ""
+}
+
+// pruneMarks drops selected tasks that are no longer listed.
+func (m *Model) pruneMarks() {
+	if len(m.marked) == 0 {
+		return
+	}
+	listed := make(map[string]bool, len(m.tasks))
+	for _, task := range m.tasks {
+		listed[task.ID] = true
+	}
+	for id := range m.marked {
+		if !listed[id] {
+			delete(m.marked, id)
+		}
+	}
+	if !listed[m.markAnchor] {
+		m.markAnchor = ""
+	}
+}
+
+// bulkTargets returns the tasks a bulk action applies to: the selected
+// tasks in visible order, or the current task when nothing is selected.
+func (m Model) bulkTargets() []domain.Task {
+	if len(m.marked) == 0 {
+		if task, ok := m.currentTask(); ok {
+			return []domain.Task{task}
+		}
+		return nil
+	}
+	targets := make([]domain.Task, 0, len(m.marked))
+	for _, task := range m.visibleTasks() {
+		if m.marked[task.ID] {
+			targets = append(targets, task)
+		}
+	}
+	return targets
+}
+
+// markedHint returns the footer note of the selection, or "".
+func (m Model) markedHint() string {
+	if len(m.marked) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d selected  m:move  p:priority  #:labels  D:due  ctrl+d:delete  esc:clear", len(m.marked))
+}
+
+// startBulkInput opens the prompt of a bulk action for the bulk targets.
+func (m *Model) startBulkInput(mode inputMode) tea.Cmd {
+	count := len(m.bulkTargets())
+	if count == 0 {
+		return nil
+	}
+	subject := pluralTasks(count)
+	m.inputMode = mode
+	m.textInput.SetValue("")
+	switch mode {
+	case inputBulkMove:
+		m.textInput.Placeholder = "Column name or number"
+		m.statusLine = fmt.Sprintf("Move %s to column", subject)
+	case inputBulkPriority:
+		m.textInput.Placeholder = "critical, urgent, high, medium, low, none or 0-5"
+		m.statusLine = fmt.Sprintf("Priority of %s", subject)
+	case inputBulkLabels:
+		m.textInput.Placeholder = "+bug -ui"
+		m.statusLine = fmt.Sprintf("Labels of %s: +name adds, -name removes", subject)
+	case inputBulkDueDate:
+		m.textInput.Placeholder = m.dueDatePlaceholder()
+		m.statusLine = fmt.Sprintf("Due date of %s (empty clears)", subject)
+	}
+	m.textInput.Focus()
+	return textinput.Blink
+}
+
+// confirmBulkInput runs the bulk action of the open prompt. Input that does
+// not parse keeps the prompt open with the error on the status line.
+func (m *Model) confirmBulkInput() tea.Cmd {
+	value := strings.TrimSpace(m.textInput.Value())
+	targets := m.bulkTargets()
+	ids := make([]string, len(targets))
+	for i, task := range targets {
+		ids[i] = task.ID
+	}
+
+	var cmd tea.Cmd
+	switch m.inputMode {
+	case inputBulkMove:
+		column, err := m.parseColumnInput(value)
+		if err != nil {
+			m.statusLine = err.Error()
+			return nil
+		}
+		cmd = m.bulkMoveCmd(ids, column)
+	case inputBulkPriority:
+		priority, err := parsePriorityInput(value)
+		if err != nil {
+			m.statusLine = err.Error()
+			return nil
+		}
+		cmd = m.bulkUpdateCmd(ids, application.UpdateTaskInput{Priority: &priority}, "priority set")
+	case inputBulkLabels:
+		add, remove, err := parseLabelEdits(value)
+		if err != nil {
+			m.statusLine = err.Error()
+			return nil
+		}
+		cmd = m.bulkLabelCmd(ids, add, remove)
+	case inputBulkDueDate:
+		dueAt, err := m.parseDueDateInput(value)
+		if err != nil {
+			m.statusLine = err.Error()
+			return nil
+		}
+		input := application.UpdateTaskInput{DueAt: dueAt, ClearDueAt: dueAt == nil}
+		cmd = m.bulkUpdateCmd(ids, input, "due date set")
+	}
+	m.cancelInput()
+	return cmd
+}
+
+// parseColumnInput finds a column of the board by name or 1-based number.
+func (m Model) parseColumnInput(value string) (domain.Column, error) {
+	if n, err := strconv.Atoi(value); err == nil && n >= 1 && n <= len(m.columns) {
+		return m.columns[n-1], nil
+	}
+	for _, col := range m.columns {
+		if strings.EqualFold(col.Name, value) {
+			return col, nil
+		}
+	}
+	return domain.Column{}, fmt.Errorf("no column %q on this board", value)
+}
+
+// parsePriorityInput accepts a priority label or its number.
+func parsePriorityInput(value string) (int, error) {
+	for _, option := range taskPriorityOptions {
+		if strings.EqualFold(option.Label, value) || strconv.Itoa(option.Value) == value {
+			return option.Value, nil
+		}
+	}
+	return 0, errors.New("priority must be critical, urgent, high, medium, low, none or 0-5")
+}
+
+// parseLabelEdits splits "+bug -ui docs" into labels to add and to remove. A
+// label without a sign is added.
+func parseLabelEdits(value string) ([]string, []string, error) {
+	var add, remove []string
+	for _, field := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' }) {
+		switch {
+		case strings.HasPrefix(field, "-"):
+			if label := strings.TrimPrefix(field, "-"); label != "" {
+				remove = append(remove, label)
+			}
+		default:
+			if label := strings.TrimPrefix(field, "+"); label != "" {
+				add = append(add, label)
+			}
+		}
+	}
+	if len(add) == 0 && len(remove) == 0 {
+		return nil, nil, errors.New("enter labels to add (+name) or remove (-name)")
+	}
+	return add, remove, nil
+}
+
+func pluralTasks(n int) string {
+	if n == 1 {
+		return "1 task"
+	}
+	return fmt.Sprintf("%d tasks", n)
+}
+
+func (m Model) bulkMoveCmd(ids []string, column domain.Column) tea.Cmd {
+	flow := m.taskFlow
+	return bulkCmd(fmt.Sprintf("moved to %s", column.Name), func(ctx context.Context) ([]application.BulkTaskResult, error) {
+		return flow.BulkMoveTasks(ctx, ids, column.ID, strings.ToLower(column.Name), application.BulkOptions{})
+	})
+}
+
+func (m Model) bulkUpdateCmd(ids []string, input application.UpdateTaskInput, status string) tea.Cmd {
+	service := m.taskService
+	return bulkCmd(status, func(ctx context.Context) ([]application.BulkTaskResult, error) {
+		return service.BulkUpdateTasks(ctx, ids, input, application.BulkOptions{})
+	})
+}
+
+func (m Model) bulkLabelCmd(ids, add, remove []string) tea.Cmd {
+	service := m.taskService
+	return bulkCmd("labels changed", func(ctx context.Context) ([]application.BulkTaskResult, error) {
+		return service.BulkLabelTasks(ctx, ids, add, remove, application.BulkOptions{})
+	})
+}
+
+func (m Model) bulkDeleteCmd(ids []string) tea.Cmd {
+	service := m.taskService
+	return bulkCmd("deleted", func(ctx context.Context) ([]application.BulkTaskResult, error) {
+		return service.BulkDeleteTasks(ctx, ids, application.BulkOptions{})
+	})
+}
+
+// bulkCmd runs a bulk action and reports how many tasks it changed.
+func bulkCmd(done string, run func(ctx context.Context) ([]application.BulkTaskResult, error)) tea.Cmd {
+	return func() tea.Msg {
+		results, err := run(context.Background())
+		if err != nil {
+			return opResultMsg{err: err}
+		}
+		changed := 0
+		for _, result := range results {
+			if result.Changed {
+				changed++
+			}
+		}
+		return opResultMsg{status: pluralTasks(changed) + " " + done, toast: true, clearMarks: true}
+	}
+}
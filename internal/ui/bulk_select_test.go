@@ -0,0 +1,149 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+// bulkTaskRepo serves a fixed set of tasks and records bulk changes.
+type bulkTaskRepo struct {
+	fakeTaskRepoForCommands
+	tasks    []domain.Task
+	lastBulk []domain.TaskChange
+}
+
+func (r *bulkTaskRepo) GetByID(_ context.Context, id string) (domain.Task, error) {
+	for _, task := range r.tasks {
+		if task.ID == id {
+			return task, nil
+		}
+	}
+	return domain.Task{}, nil
+}
+
+func (r *bulkTaskRepo) Bulk(_ context.Context, changes []domain.TaskChange) error {
+	r.lastBulk = changes
+	return nil
+}
+
+func newBulkTestModel() (Model, *bulkTaskRepo) {
+	todo, doing := "col-1", "col-2"
+	repo := &bulkTaskRepo{tasks: []domain.Task{
+		{ID: "a", Title: "A", ColumnID: &todo, Position: 1},
+		{ID: "b", Title: "B", ColumnID: &todo, Position: 2},
+		{ID: "c", Title: "C", ColumnID: &doing, Position: 1},
+		{ID: "d", Title: "D", ColumnID: &doing, Position: 2},
+	}}
+	model := newTestModelWithServices(repo, &fakeCommentRepoForCommands{})
+	model.columns = []domain.Column{{ID: todo, Name: "Todo"}, {ID: doing, Name: "Doing"}}
+	model.tasks = repo.tasks
+	model.textInput = textinput.New()
+	return model, repo
+}
+
+func pressKeys(t *testing.T, model Model, keys ...tea.KeyMsg) Model {
+	t.Helper()
+	for _, k := range keys {
+		next, _ := model.Update(k)
+		model = next.(Model)
+	}
+	return model
+}
+
+func runeKey(r rune) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+}
+
+func TestSpaceAndVSelectARange(t *testing.T) {
+	model, _ := newBulkTestModel()
+	model.width, model.height = 160, 40
+
+	down := tea.KeyMsg{Type: tea.KeyDown}
+	model = pressKeys(t, model, tea.KeyMsg{Type: tea.KeySpace}, down, down, runeKey('V'))
+	if len(model.marked) != 3 || !model.marked["a"] || !model.marked["c"] || model.marked["d"] {
+		t.Fatalf("marked = %v, want a, b and c", model.marked)
+	}
+	if footer := model.renderListFooter(160); !strings.Contains(footer, "3 selected") {
+		t.Fatalf("footer should show the selection count:\n%s", footer)
+	}
+
+	// Space takes a task out again; esc drops the whole selection.
+	model = pressKeys(t, model, tea.KeyMsg{Type: tea.KeySpace})
+	if len(model.marked) != 2 || model.marked["c"] {
+		t.Fatalf("marked = %v after toggling c off", model.marked)
+	}
+	model = pressKeys(t, model, tea.KeyMsg{Type: tea.KeyEsc})
+	if len(model.marked) != 0 {
+		t.Fatalf("marked = %v after esc", model.marked)
+	}
+}
+
+func TestBulkPriorityAppliesToTheSelection(t *testing.T) {
+	model, repo := newBulkTestModel()
+	model.viewMode = viewKanban
+	model.marked = map[string]bool{"a": true, "c": true}
+
+	model = pressKeys(t, model, runeKey('p'))
+	if model.inputMode != inputBulkPriority || !strings.Contains(model.statusLine, "2 tasks") {
+		t.Fatalf("input mode = %v, status = %q", model.inputMode, model.statusLine)
+	}
+	model.textInput.SetValue("urgnet")
+	model = pressKeys(t, model, tea.KeyMsg{Type: tea.KeyEnter})
+	if model.inputMode != inputBulkPriority {
+		t.Fatal("an invalid priority should keep the prompt open")
+	}
+
+	model.textInput.SetValue("high")
+	next, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model = next.(Model)
+	if cmd == nil {
+		t.Fatal("expected a bulk update command")
+	}
+	msg := cmd().(opResultMsg)
+	if msg.err != nil || msg.status != "2 tasks priority set" {
+		t.Fatalf("result = %+v", msg)
+	}
+	if len(repo.lastBulk) != 2 || repo.lastBulk[0].TaskID != "a" || *repo.lastBulk[1].Patch.Priority != 2 {
+		t.Fatalf("changes = %+v", repo.lastBulk)
+	}
+	next, _ = model.Update(msg)
+	if marked := next.(Model).marked; len(marked) != 0 {
+		t.Fatalf("selection should be cleared after the change, got %v", marked)
+	}
+}
+
+func TestBulkDeleteAsksOnce(t *testing.T) {
+	model, repo := newBulkTestModel()
+	model.marked = map[string]bool{"b": true, "d": true}
+
+	model = pressKeys(t, model, tea.KeyMsg{Type: tea.KeyCtrlD})
+	if !model.confirmingDelete || model.statusLine != "delete 2 tasks? y/n" {
+		t.Fatalf("confirming = %v, status = %q", model.confirmingDelete, model.statusLine)
+	}
+	_, cmd := model.Update(runeKey('y'))
+	if cmd == nil {
+		t.Fatal("expected a bulk delete command")
+	}
+	if msg := cmd().(opResultMsg); msg.err != nil {
+		t.Fatalf("delete: %v", msg.err)
+	}
+	if len(repo.lastBulk) != 2 || !repo.lastBulk[0].Delete || repo.lastBulk[0].TaskID != "b" || repo.lastBulk[1].TaskID != "d" {
+		t.Fatalf("changes = %+v", repo.lastBulk)
+	}
+}
+
+func TestParseLabelEdits(t *testing.T) {
+	add, remove, err := parseLabelEdits("+bug -ui, docs")
+	if err != nil || strings.Join(add, ",") != "bug,docs" || strings.Join(remove, ",") != "ui" {
+		t.Fatalf("parseLabelEdits = %v, %v, %v", add, remove, err)
+	}
+	if _, _, err := parseLabelEdits(" + - "); err == nil {
+		t.Fatal("expected an error without labels")
+	}
+}
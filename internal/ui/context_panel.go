@@ -20,6 +20,9 @@ type contextMode int
 const (
 	contextWorkspace contextMode = iota
 	contextBoard
+	// contextDestination picks the board and column a task is moved or
+	// copied to.
+	contextDestination
 )
 
 type contextEditMode int
@@ -52,6 +55,16 @@ type boardCreateForm struct {
 	focus     int
 	boardName textinput.Model
 	columns   []boardColumnFormRow
+	templates []domain.BoardTemplate
+	template  int // index into templates; -1 for custom columns
+}
+
+func defaultBoardColumnRows() []boardColumnFormRow {
+	return []boardColumnFormRow{
+		newBoardColumnRow(1, "Todo", "#60A5FA"),
+		newBoardColumnRow(2, "Doing", "#F59E0B"),
+		newBoardColumnRow(3, "Done", "#22C55E"),
+	}
 }
 
 type boardColumnsOrderForm struct {
@@ -218,6 +231,43 @@ func (f *boardCreateForm) cycleFocusedColor(delta int) bool {
 	return true
 }
 
+// selectedTemplate returns the template the form starts from, if any.
+func (f *boardCreateForm) selectedTemplate() (domain.BoardTemplate, bool) {
+	if f.template < 0 || f.template >= len(f.templates) {
+		return domain.BoardTemplate{}, false
+	}
+	return f.templates[f.template], true
+}
+
+func (f *boardCreateForm) templateName() string {
+	if template, ok := f.selectedTemplate(); ok {
+		return template.Name
+	}
+	return "custom"
+}
+
+// cycleTemplate moves to the next or previous template, with custom columns
+// between the last and the first, and fills the column rows from it.
+func (f *boardCreateForm) cycleTemplate(delta int) bool {
+	if len(f.templates) == 0 {
+		return false
+	}
+	options := len(f.templates) + 1
+	f.template = (f.template+1+delta+options)%options - 1
+
+	template, ok := f.selectedTemplate()
+	if !ok {
+		f.columns = defaultBoardColumnRows()
+	} else {
+		f.columns = make([]boardColumnFormRow, 0, len(template.Columns))
+		for i, column := range template.Columns {
+			f.columns = append(f.columns, newBoardColumnRow(i+1, column.Name, column.Color))
+		}
+	}
+	f.setFocus(f.focus)
+	return true
+}
+
 func (f *boardCreateForm) addColumn() {
 	index := len(f.columns) + 1
 	f.columns = append(f.columns, newBoardColumnRow(index, "", ""))
@@ -254,6 +304,9 @@ func (m *Model) closeContextPanel() {
 
 func (m Model) contextItems() []string {
 	query := strings.ToLower(strings.TrimSpace(m.contextFilter.Value()))
+	if m.contextMode == contextDestination {
+		return m.destinationItems(query)
+	}
 	items := make([]string, 0)
 	if m.contextMode == contextWorkspace {
 		for _, ws := range m.workspaces {
@@ -295,6 +348,9 @@ func (m *Model) selectedContextID() string {
 }
 
 func (m Model) contextTitle() string {
+	if m.contextMode == contextDestination {
+		return m.destinationTitle()
+	}
 	if m.contextMode == contextWorkspace {
 		return "Workspaces"
 	}
@@ -302,6 +358,9 @@ func (m Model) contextTitle() string {
 }
 
 func (m Model) contextNameByID(id string) string {
+	if m.contextMode == contextDestination {
+		return m.destinationNameByID(id)
+	}
 	if m.contextMode == contextWorkspace {
 		return workspaceName(m.workspaces, id)
 	}
@@ -322,11 +381,15 @@ func (m *Model) beginContextCreate() {
 func (m *Model) startBoardCreateForm() {
 	form := &boardCreateForm{
 		boardName: newTaskFormInput("Board name", "", 128),
-		columns: []boardColumnFormRow{
-			newBoardColumnRow(1, "Todo", "#60A5FA"),
-			newBoardColumnRow(2, "Doing", "#F59E0B"),
-			newBoardColumnRow(3, "Done", "#22C55E"),
-		},
+		columns:   defaultBoardColumnRows(),
+		template:  -1,
+	}
+	if m.templateService != nil {
+		templates, err := m.templateService.ListTemplates(context.Background())
+		if err != nil {
+			m.statusLine = err.Error()
+		}
+		form.templates = templates
 	}
 	form.setFocus(0)
 	m.boardForm = form
@@ -432,7 +495,13 @@ func (m *Model) submitBoardCreateForm() error {
 	}
 
 	ctx := context.Background()
-	board, err := m.contextService.CreateBoardWithColumns(ctx, m.workspaceID, boardName, columns)
+	var board domain.Board
+	var err error
+	if template, ok := m.boardForm.selectedTemplate(); ok && m.templateService != nil {
+		board, err = m.templateService.CreateBoardFromTemplate(ctx, m.workspaceID, boardName, templateWithColumns(template, columns))
+	} else {
+		board, err = m.contextService.CreateBoardWithColumns(ctx, m.workspaceID, boardName, columns)
+	}
 	if err != nil {
 		return err
 	}
@@ -449,6 +518,33 @@ func (m *Model) submitBoardCreateForm() error {
 	return nil
 }
 
+// templateWithColumns returns the template with the columns edited in the
+// board form. Columns keep the WIP limit of the template column of the same
+// name, and starter tasks of removed columns go to the first column.
+func templateWithColumns(template domain.BoardTemplate, columns []application.CreateBoardColumnInput) domain.BoardTemplate {
+	limits := make(map[string]*int, len(template.Columns))
+	for _, column := range template.Columns {
+		limits[strings.ToLower(strings.TrimSpace(column.Name))] = column.WIPLimit
+	}
+	names := make(map[string]bool, len(columns))
+	template.Columns = make([]domain.TemplateColumn, len(columns))
+	for i, column := range columns {
+		key := strings.ToLower(column.Name)
+		names[key] = true
+		template.Columns[i] = domain.TemplateColumn{Name: column.Name, Color: column.Color, WIPLimit: limits[key]}
+	}
+
+	tasks := make([]domain.TemplateTask, len(template.Tasks))
+	for i, task := range template.Tasks {
+		if !names[strings.ToLower(strings.TrimSpace(task.Column))] {
+			task.Column = ""
+		}
+		tasks[i] = task
+	}
+	template.Tasks = tasks
+	return template
+}
+
 func (m *Model) beginContextRename() {
 	id := m.selectedContextID()
 	if id == "" {
@@ -544,6 +640,10 @@ func (m Model) updateContextPanel(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 		return m, nil
 	case tea.KeyMsg:
+		if m.destination != nil {
+			return m.updateTaskDestination(msg)
+		}
+
 		if m.boardOrder != nil {
 			switch {
 			case key.Matches(msg, m.keys.Cancel):
@@ -588,6 +688,11 @@ func (m Model) updateContextPanel(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case "ctrl+n":
 				m.boardForm.addColumn()
 				return m, textinput.Blink
+			case "ctrl+t":
+				if m.boardForm.cycleTemplate(1) {
+					return m, textinput.Blink
+				}
+				return m, nil
 			case "ctrl+d":
 				if m.boardForm.removeFocusedColumn() {
 					return m, textinput.Blink
@@ -754,6 +859,9 @@ func (m Model) renderContextPanel(base string) string {
 	if m.contextMode == contextBoard {
 		helpText = "Type to filter | Enter: switch | n:create (name + columns + colors) | r:rename | o:reorder columns | Esc:close"
 	}
+	if m.contextMode == contextDestination {
+		helpText = m.destinationHelp()
+	}
 
 	lines := []string{
 		lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("151")).Render(m.contextTitle()),
@@ -881,8 +989,16 @@ func (m Model) renderBoardCreateContextPanel(contentWidth, contentHeight int) st
 		lipgloss.NewStyle().Foreground(lipgloss.Color("246")).Render("Board Name"),
 		m.boardForm.boardName.View(),
 		"",
-		lipgloss.NewStyle().Foreground(lipgloss.Color("246")).Render(fmt.Sprintf("Columns (%d)", len(m.boardForm.columns))),
 	}
+	if len(m.boardForm.templates) > 0 {
+		headerLines = append(headerLines,
+			lipgloss.NewStyle().Foreground(lipgloss.Color("246")).Render("Template: "+m.boardForm.templateName()+" (Ctrl+T)"),
+			"",
+		)
+	}
+	headerLines = append(headerLines,
+		lipgloss.NewStyle().Foreground(lipgloss.Color("246")).Render(fmt.Sprintf("Columns (%d)", len(m.boardForm.columns))),
+	)
 
 	footerLine := lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Render("You can type HEX directly or use \u2190/\u2192 on color fields. Add as many columns as needed.")
 
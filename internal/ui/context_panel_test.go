@@ -25,6 +25,10 @@ func (r *mockSetupRepo) ListProviders(ctx context.Context) ([]domain.Provider, e
 	return nil, r.err
 }
 func (r *mockSetupRepo) CreateProvider(ctx context.Context, p domain.Provider) error { return r.err }
+func (r *mockSetupRepo) SetProviderAuth(ctx context.Context, auth map[string]*string) error {
+	return r.err
+}
+func (r *mockSetupRepo) DeleteProvider(ctx context.Context, id string) error { return r.err }
 func (r *mockSetupRepo) ListWorkspaces(ctx context.Context) ([]domain.Workspace, error) {
 	return r.workspaces, r.err
 }
@@ -422,6 +426,65 @@ func TestStartBoardCreateForm(t *testing.T) {
 	}
 }
 
+func TestBoardCreateForm_CycleTemplate(t *testing.T) {
+	limit := 2
+	m := Model{}
+	m.startBoardCreateForm()
+	form := m.boardForm
+	if form.cycleTemplate(1) {
+		t.Fatal("cycleTemplate() without templates = true, want false")
+	}
+	form.templates = []domain.BoardTemplate{{
+		Name:    "release",
+		Columns: []domain.TemplateColumn{{Name: "Ready", Color: "#60A5FA"}, {Name: "Testing", WIPLimit: &limit}},
+	}}
+
+	if !form.cycleTemplate(1) || form.templateName() != "release" {
+		t.Fatalf("templateName() = %q, want release", form.templateName())
+	}
+	if len(form.columns) != 2 || form.columns[1].name.Value() != "Testing" {
+		t.Fatalf("columns not filled from template: %d", len(form.columns))
+	}
+	if form.columns[1].color.Value() == "" {
+		t.Error("expected a palette color for a column without one")
+	}
+
+	form.cycleTemplate(1)
+	if form.templateName() != "custom" || len(form.columns) != 3 {
+		t.Errorf("templateName() = %q with %d columns, want custom with 3", form.templateName(), len(form.columns))
+	}
+	form.cycleTemplate(-1)
+	if form.templateName() != "release" {
+		t.Errorf("templateName() = %q after cycling back, want release", form.templateName())
+	}
+}
+
+func TestTemplateWithColumns(t *testing.T) {
+	limit := 2
+	template := domain.BoardTemplate{
+		Name:    "release",
+		Columns: []domain.TemplateColumn{{Name: "Ready"}, {Name: "Testing", WIPLimit: &limit}},
+		Tasks:   []domain.TemplateTask{{Title: "Smoke test", Column: "Testing"}, {Title: "Tag", Column: "Ready"}},
+	}
+	got := templateWithColumns(template, []application.CreateBoardColumnInput{
+		{Name: "testing", Color: "#F59E0B"},
+		{Name: "Shipped", Color: "#22C55E"},
+	})
+
+	if len(got.Columns) != 2 || got.Columns[0].WIPLimit == nil || *got.Columns[0].WIPLimit != 2 {
+		t.Fatalf("WIP limit not kept by column name: %+v", got.Columns)
+	}
+	if got.Columns[1].WIPLimit != nil {
+		t.Errorf("new column has WIP limit %d, want none", *got.Columns[1].WIPLimit)
+	}
+	if got.Tasks[0].Column != "Testing" || got.Tasks[1].Column != "" {
+		t.Errorf("task columns = %q, %q; want Testing and none", got.Tasks[0].Column, got.Tasks[1].Column)
+	}
+	if template.Tasks[1].Column != "Ready" {
+		t.Error("templateWithColumns changed the tasks of its argument")
+	}
+}
+
 func TestCloseBoardCreateForm(t *testing.T) {
 	m := Model{
 		overlayState:  overlayState{boardForm: &boardCreateForm{}},
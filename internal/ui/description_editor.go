@@ -15,6 +15,7 @@ import (
 // and returns a command that opens the user's preferred editor.
 func (m *Model) startExternalDescriptionEdit(task domain.Task) tea.Cmd {
 	m.editingDescTask = task.ID
+	m.editingDescVersion = task.Version
 	m.statusLine = ""
 	return openDescriptionEditorCmd(task.DescriptionMD)
 }
@@ -115,5 +116,5 @@ func (m Model) handleExternalDescriptionEdited(msg descriptionEditedMsg) (tea.Mo
 		m.statusLine = fmt.Sprintf("editor error: %v", msg.err)
 		return m, nil
 	}
-	return m, m.updateTaskDescriptionCmd(taskID, msg.content)
+	return m, m.updateTaskDescriptionCmd(taskID, m.editingDescVersion, msg.content)
 }
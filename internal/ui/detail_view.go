@@ -43,7 +43,15 @@ func (m Model) renderDetailView(width, height int) string {
 	metaLine := strings.Join(meta, " | ")
 
 	descTitle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("221")).Render("Description")
+	// The snippet of a search match takes three lines below the description.
+	snippet := strings.TrimSpace(m.searchSnippets[task.ID])
 	maxDescLines := max(2, contentHeight-5)
+	if snippet != "" {
+		maxDescLines = max(2, contentHeight-8)
+	}
+	if len(task.Labels) > 0 {
+		maxDescLines = max(2, maxDescLines-1)
+	}
 	maxDescWidth := max(12, contentWidth-4)
 	descPreview := previewMarkdown(task.DescriptionMD, maxDescLines, maxDescWidth)
 	desc := renderMarkdownMinimal(descPreview)
@@ -51,7 +59,15 @@ func (m Model) renderDetailView(width, height int) string {
 		desc = lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render("(empty)")
 	}
 
-	content := []string{header, metaLine, "", descTitle, desc}
+	content := []string{header, metaLine}
+	if len(task.Labels) > 0 {
+		content = append(content, m.labelChips(task.Labels, contentWidth-2))
+	}
+	content = append(content, "", descTitle, desc)
+	if snippet != "" {
+		matchTitle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("221")).Render("Match")
+		content = append(content, "", matchTitle, renderSnippet(snippet, lipgloss.NewStyle().Foreground(lipgloss.Color("252"))))
+	}
 	joined := strings.Join(content, "\n")
 	return panelStyle.Render(joined)
 }
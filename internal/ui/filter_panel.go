@@ -68,6 +68,8 @@ func (m Model) sortModeLabel() string {
 		return "Updated"
 	case sortByCreated:
 		return "Created"
+	case sortByManual:
+		return "Manual"
 	default:
 		return "Priority"
 	}
@@ -93,7 +95,7 @@ func (m *Model) cycleDueFilter() {
 
 func (m *Model) cycleSortMode() {
 	next := int(m.sortMode) + 1
-	if next > int(sortByCreated) {
+	if next > int(sortByManual) {
 		next = int(sortByPriority)
 	}
 	m.sortMode = taskSortMode(next)
@@ -161,7 +163,7 @@ func (m *Model) adjustFilterSelection(delta int) (bool, error) {
 		m.priorityFilter = next - 1
 		changed = true
 	case 5: // sort
-		total := int(sortByCreated) + 1
+		total := int(sortByManual) + 1
 		next := (int(m.sortMode) + delta + total) % total
 		m.sortMode = taskSortMode(next)
 		changed = true
@@ -124,6 +124,13 @@ func TestSortModeLabel_Created(t *testing.T) {
 	}
 }
 
+func TestSortModeLabel_Manual(t *testing.T) {
+	m := Model{sortMode: sortByManual}
+	if got := m.sortModeLabel(); got != "Manual" {
+		t.Errorf("sortModeLabel() = %q, want %q", got, "Manual")
+	}
+}
+
 // --- filter state mutator tests ---
 
 func TestSetStatusFilterByIndex_Valid(t *testing.T) {
@@ -189,6 +196,10 @@ func TestCycleSortMode(t *testing.T) {
 		t.Errorf("sortMode = %v, want sortByCreated", m.sortMode)
 	}
 	m.cycleSortMode()
+	if m.sortMode != sortByManual {
+		t.Errorf("sortMode = %v, want sortByManual", m.sortMode)
+	}
+	m.cycleSortMode()
 	if m.sortMode != sortByPriority {
 		t.Errorf("sortMode = %v, want sortByPriority", m.sortMode)
 	}
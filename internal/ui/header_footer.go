@@ -21,7 +21,11 @@ func (m Model) renderHeader(width int) string {
 	metaStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("246"))
 
 	left := headerStyle.Render(fmt.Sprintf("%s / %s", m.workspaceName, m.boardName))
-	right := metaStyle.Render(fmt.Sprintf("view:%s  sort:%s  filter:%s  search:%q", viewLabel, strings.ToLower(m.sortModeLabel()), strings.Join(filterParts, ","), m.titleFilter))
+	meta := fmt.Sprintf("view:%s  sort:%s  filter:%s  search:%q", viewLabel, strings.ToLower(m.sortModeLabel()), strings.Join(filterParts, ","), m.titleFilter)
+	if strings.TrimSpace(m.filterExpr) != "" {
+		meta += fmt.Sprintf("  expr:%q", m.filterExpr)
+	}
+	right := metaStyle.Render(meta)
 	if width > 20 {
 		return lipgloss.JoinHorizontal(lipgloss.Top,
 			lipgloss.NewStyle().Width(width/2).Render(left),
@@ -34,15 +38,18 @@ func (m Model) renderHeader(width int) string {
 func (m Model) renderFooter() string {
 	inputLine := ""
 	switch m.inputMode {
-	case inputSearch, inputAddComment:
+	case inputSearch, inputFilterExpression, inputAddComment, inputBulkMove, inputBulkPriority, inputBulkLabels, inputBulkDueDate:
 		inputLine = lipgloss.NewStyle().Foreground(lipgloss.Color("221")).Render(m.textInput.View())
 	case inputEditDescription:
 		inputLine = lipgloss.NewStyle().Foreground(lipgloss.Color("221")).Render(m.textArea.View())
 	}
 
 	shortcuts := "?:help  n:new  /:search  enter:open  w:workspaces  b:boards  f:filters  q:quit"
-	if strings.TrimSpace(m.titleFilter) != "" {
-		shortcuts += " x:clear-search"
+	if hint := m.clearSearchHint(); hint != "" {
+		shortcuts += " " + hint
+	}
+	if hint := m.markedHint(); hint != "" {
+		shortcuts = hint + "  " + shortcuts
 	}
 	lines := make([]string, 0, 3)
 	if strings.TrimSpace(m.statusLine) != "" {
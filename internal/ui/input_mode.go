@@ -3,11 +3,13 @@ package ui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/tiagokriok/kanji/internal/application"
 	"github.com/tiagokriok/kanji/internal/domain"
 )
 
@@ -21,6 +23,33 @@ func (m *Model) startSearch() tea.Cmd {
 	return textinput.Blink
 }
 
+// startFilterExpression enters filter-expression input mode, restoring the
+// current expression.
+func (m *Model) startFilterExpression() tea.Cmd {
+	m.inputMode = inputFilterExpression
+	m.textInput.SetValue(m.filterExpr)
+	m.textInput.Placeholder = "priority<=high and label:bug and due<7d"
+	m.textInput.Focus()
+	m.statusLine = "Filter expression (see kanji help filters; empty clears)"
+	return textinput.Blink
+}
+
+// confirmFilterExpression stores a valid expression and triggers a reload.
+// An invalid expression keeps the input open with the error on the status
+// line.
+func (m *Model) confirmFilterExpression() tea.Cmd {
+	value := strings.TrimSpace(m.textInput.Value())
+	if value != "" {
+		if _, err := application.ParseTaskQuery(value, time.Now()); err != nil {
+			m.statusLine = err.Error()
+			return nil
+		}
+	}
+	m.cancelInput()
+	m.filterExpr = value
+	return m.loadTasksCmd()
+}
+
 // cancelInput resets any active non-task-form input mode and blurs widgets.
 func (m *Model) cancelInput() {
 	m.inputMode = inputNone
@@ -98,7 +127,7 @@ func (m *Model) submitEditDescription() tea.Cmd {
 	previousStatus := m.statusLine
 	m.cancelInput()
 	m.statusLine = previousStatus
-	return m.updateTaskDescriptionCmd(task.ID, description)
+	return m.updateTaskDescriptionCmd(task.ID, task.Version, description)
 }
 
 // handleDescriptionEditedMsg processes a descriptionEditedMsg while in input mode.
@@ -146,7 +175,7 @@ func (m Model) saveEditDescription() (tea.Model, tea.Cmd) {
 	description := m.textArea.Value()
 	m.inputMode = inputNone
 	m.textArea.Blur()
-	return m, m.updateTaskDescriptionCmd(task.ID, description)
+	return m, m.updateTaskDescriptionCmd(task.ID, task.Version, description)
 }
 
 // confirmInputMode handles the confirm key for any active non-edit-description input mode.
@@ -155,8 +184,12 @@ func (m Model) confirmInputMode() (tea.Model, tea.Cmd, bool) {
 	switch m.inputMode {
 	case inputSearch:
 		return m, m.confirmSearch(), true
+	case inputFilterExpression:
+		return m, m.confirmFilterExpression(), true
 	case inputAddComment:
 		return m, m.confirmAddComment(), true
+	case inputBulkMove, inputBulkPriority, inputBulkLabels, inputBulkDueDate:
+		return m, m.confirmBulkInput(), true
 	}
 	return m, nil, false
 }
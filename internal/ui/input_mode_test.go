@@ -59,6 +59,29 @@ func TestConfirmSearch(t *testing.T) {
 	}
 }
 
+func TestConfirmFilterExpression(t *testing.T) {
+	m := Model{filterExpr: "label:bug", overlayState: overlayState{inputMode: inputFilterExpression}}
+	m.textInput = textinput.New()
+	m.textInput.SetValue("label<bug")
+	if cmd := m.confirmFilterExpression(); cmd != nil {
+		t.Error("expected nil cmd for an invalid expression")
+	}
+	if m.inputMode != inputFilterExpression || m.filterExpr != "label:bug" {
+		t.Errorf("invalid expression should keep the input open, got mode %v expr %q", m.inputMode, m.filterExpr)
+	}
+	if m.statusLine != "label only supports :, = and != at position 7" {
+		t.Errorf("statusLine = %q", m.statusLine)
+	}
+
+	m.textInput.SetValue("  due<7d  ")
+	if cmd := m.confirmFilterExpression(); cmd == nil {
+		t.Error("expected non-nil cmd")
+	}
+	if m.inputMode != inputNone || m.filterExpr != "due<7d" {
+		t.Errorf("got mode %v expr %q, want inputNone and due<7d", m.inputMode, m.filterExpr)
+	}
+}
+
 func TestCancelInput(t *testing.T) {
 	m := Model{statusLine: "something", overlayState: overlayState{inputMode: inputSearch}}
 	m.textInput = textinput.New()
@@ -127,7 +127,10 @@ type kanbanMoveRepo struct {
 	lastMove *domain.MoveTaskInput
 }
 
-func (r *kanbanMoveRepo) Create(context.Context, domain.Task) error              { return nil }
+func (r *kanbanMoveRepo) Create(context.Context, domain.Task) error { return nil }
+func (r *kanbanMoveRepo) CreatePlaced(context.Context, domain.Task, domain.PlaceFunc) error {
+	return nil
+}
 func (r *kanbanMoveRepo) Update(context.Context, string, domain.TaskPatch) error { return nil }
 func (r *kanbanMoveRepo) GetByID(context.Context, string) (domain.Task, error) {
 	return domain.Task{}, nil
@@ -139,8 +142,153 @@ func (r *kanbanMoveRepo) Move(_ context.Context, input domain.MoveTaskInput) err
 	r.lastMove = &input
 	return nil
 }
-func (r *kanbanMoveRepo) Delete(context.Context, string) error { return nil }
+func (r *kanbanMoveRepo) Delete(context.Context, string) error            { return nil }
+func (r *kanbanMoveRepo) Bulk(context.Context, []domain.TaskChange) error { return nil }
 func (r *kanbanMoveRepo) ListColumns(context.Context, string) ([]domain.Column, error) {
 	return nil, nil
 }
 func (r *kanbanMoveRepo) ListBoards(context.Context, string) ([]domain.Board, error) { return nil, nil }
+
+func TestShiftUpReordersTaskWithinItsColumn(t *testing.T) {
+	columnID := "col-1"
+	status := "todo"
+	tasks := []domain.Task{
+		{ID: "task-1", Title: "Task 1", ColumnID: &columnID, Status: &status, Position: 1024},
+		{ID: "task-2", Title: "Task 2", ColumnID: &columnID, Status: &status, Position: 2048},
+	}
+	repo := &reorderKanbanRepo{wipKanbanRepo: wipKanbanRepo{tasks: tasks}}
+	model, _ := kanbanMoveTestModel(&repo.kanbanMoveRepo)
+	model.taskFlow = application.NewTaskFlow(repo)
+	model.tasks = tasks
+	model.kanbanRow = 1
+
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyShiftUp})
+	if cmd == nil {
+		t.Fatal("expected shift+up to produce a reorder command")
+	}
+	if msg := cmd().(opResultMsg); msg.err != nil {
+		t.Fatalf("reorder: %v", msg.err)
+	}
+	if repo.lastMove == nil || repo.lastMove.TaskID != "task-2" || repo.lastMove.Position >= 1024 {
+		t.Fatalf("expected task-2 to move above task-1, got %#v", repo.lastMove)
+	}
+	if repo.lastMove.ColumnID == nil || *repo.lastMove.ColumnID != columnID {
+		t.Fatalf("expected task-2 to stay in %q, got %#v", columnID, repo.lastMove.ColumnID)
+	}
+
+	// The first card cannot move further up.
+	model.kanbanRow = 0
+	if _, cmd := model.Update(tea.KeyMsg{Type: tea.KeyShiftUp}); cmd != nil {
+		t.Fatal("expected no reorder for the top card")
+	}
+}
+
+func TestShiftDownInListSwitchesToManualSort(t *testing.T) {
+	repo := &kanbanMoveRepo{}
+	model, _ := kanbanMoveTestModel(repo)
+	model.viewMode = viewList
+
+	next, _ := model.Update(tea.KeyMsg{Type: tea.KeyShiftDown})
+	if got := next.(Model).sortMode; got != sortByManual {
+		t.Fatalf("expected manual sort, got %v", got)
+	}
+}
+
+func TestKanbanColumnTitleShowsWIPCount(t *testing.T) {
+	limit := 2
+	col := domain.Column{Name: "Doing", WIPLimit: &limit}
+
+	if got := kanbanColumnTitle(domain.Column{Name: "Todo"}, 4); got != "Todo" {
+		t.Fatalf("expected plain name without limit, got %q", got)
+	}
+	if got := kanbanColumnTitle(col, 1); got != "Doing 1/2" {
+		t.Fatalf("expected %q, got %q", "Doing 1/2", got)
+	}
+	if got := kanbanColumnTitle(col, 2); got != "Doing 2/2 (full)" {
+		t.Fatalf("expected %q, got %q", "Doing 2/2 (full)", got)
+	}
+}
+
+func TestShiftRightIntoFullColumnReportsWIPLimit(t *testing.T) {
+	limit := 1
+	firstColumnID := "col-1"
+	secondColumnID := "col-2"
+	boardID := "board-1"
+	repo := &wipKanbanRepo{
+		columns: []domain.Column{
+			{ID: firstColumnID, Name: "Todo"},
+			{ID: secondColumnID, Name: "Doing", WIPLimit: &limit},
+		},
+		tasks: []domain.Task{
+			{ID: "task-1", BoardID: &boardID, ColumnID: &firstColumnID},
+			{ID: "task-2", BoardID: &boardID, ColumnID: &secondColumnID},
+		},
+	}
+	model := Model{
+		taskFlow: application.NewTaskFlow(repo),
+		columns:  repo.columns,
+		tasks:    repo.tasks,
+		viewMode: viewKanban,
+		keys:     newKeyMap(),
+	}
+
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyShiftRight})
+	if cmd == nil {
+		t.Fatal("expected shift+right to produce a move command")
+	}
+	msg, ok := cmd().(opResultMsg)
+	if !ok {
+		t.Fatalf("expected opResultMsg, got %T", msg)
+	}
+	if msg.err == nil {
+		t.Fatal("expected WIP limit error")
+	}
+	next, _ := model.Update(msg)
+	if got := next.(Model).statusLine; got != msg.err.Error() {
+		t.Fatalf("expected status line %q, got %q", msg.err.Error(), got)
+	}
+	if repo.moved {
+		t.Fatal("expected move to be refused")
+	}
+}
+
+type wipKanbanRepo struct {
+	kanbanMoveRepo
+	columns []domain.Column
+	tasks   []domain.Task
+	moved   bool
+}
+
+func (r *wipKanbanRepo) GetByID(_ context.Context, id string) (domain.Task, error) {
+	for _, t := range r.tasks {
+		if t.ID == id {
+			return t, nil
+		}
+	}
+	return domain.Task{}, nil
+}
+func (r *wipKanbanRepo) List(_ context.Context, filter domain.TaskFilter) ([]domain.Task, error) {
+	var out []domain.Task
+	for _, t := range r.tasks {
+		if t.ColumnID != nil && *t.ColumnID == filter.ColumnID {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+func (r *wipKanbanRepo) ListColumns(context.Context, string) ([]domain.Column, error) {
+	return r.columns, nil
+}
+func (r *wipKanbanRepo) Move(context.Context, domain.MoveTaskInput) error {
+	r.moved = true
+	return nil
+}
+
+type reorderKanbanRepo struct {
+	wipKanbanRepo
+}
+
+func (r *reorderKanbanRepo) Move(_ context.Context, input domain.MoveTaskInput) error {
+	r.lastMove = &input
+	return nil
+}
@@ -5,6 +5,9 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/tiagokriok/kanji/internal/application"
+	"github.com/tiagokriok/kanji/internal/domain"
 )
 
 func (m Model) renderKanbanView(width, height int) string {
@@ -17,6 +20,7 @@ func (m Model) renderKanbanView(width, height int) string {
 	panelContentWidth := boxContentWidth(columnOuterWidth, 0, true)
 	cardContentWidth := boxContentWidth(panelContentWidth, 1, true)
 	cards := make([]string, 0, len(m.columns))
+	terms := searchTerms(m.titleFilter)
 	for ci, col := range m.columns {
 		headerStyle := lipgloss.NewStyle().
 			Bold(true).
@@ -25,8 +29,8 @@ func (m Model) renderKanbanView(width, height int) string {
 			Width(max(1, panelContentWidth-2)).
 			Padding(0, 1)
 
-		header := headerStyle.Render(fmt.Sprintf("%s", col.Name))
 		colTasks := m.tasksForColumn(col.ID)
+		header := headerStyle.Render(kanbanColumnTitle(col, m.columnTaskCount(col.ID, len(colTasks))))
 		cardRows := make([]string, 0, max(1, len(colTasks)))
 		if len(colTasks) == 0 {
 			cardRows = append(cardRows, lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Padding(0, 1).Render("(empty)"))
@@ -39,15 +43,25 @@ func (m Model) renderKanbanView(width, height int) string {
 			if p > 0 {
 				prefix = lipgloss.NewStyle().Foreground(priorityColor(p)).Render("●") + " "
 			}
-
 			titleMax := max(4, cardContentWidth-4)
+			if m.conflicts[task.ID] {
+				prefix = lipgloss.NewStyle().Foreground(lipgloss.Color("203")).Render("!") + " " + prefix
+				titleMax = max(4, titleMax-2)
+			}
+			if m.marked[task.ID] {
+				prefix = markedPrefix + prefix
+				titleMax = max(4, titleMax-2)
+			}
 			title := task.Title
 			if len([]rune(title)) > titleMax {
 				title = string([]rune(title)[:titleMax-3]) + "..."
 			}
-			titleLine := prefix + title
+			titleLine := prefix + highlightSearch(title, terms, lipgloss.NewStyle())
 
 			content := titleLine
+			if len(task.Labels) > 0 {
+				content += "\n" + m.labelChips(task.Labels, max(4, cardContentWidth-2))
+			}
 			if task.DueAt != nil {
 				dueText, dueColor := m.dueDisplay(*task.DueAt)
 				meta := lipgloss.NewStyle().Foreground(dueColor).Render("  due: " + dueText)
@@ -55,6 +69,9 @@ func (m Model) renderKanbanView(width, height int) string {
 			}
 
 			borderColor := lipgloss.Color("238")
+			if m.marked[task.ID] {
+				borderColor = lipgloss.Color("114")
+			}
 			if isActive {
 				borderColor = lipgloss.Color("62")
 			}
@@ -79,6 +96,29 @@ func (m Model) renderKanbanView(width, height int) string {
 	return lipgloss.JoinHorizontal(lipgloss.Top, cards...)
 }
 
+// columnTaskCount returns how many tasks a column holds, including those the
+// active filters hide, falling back to shown when the counts are not loaded.
+func (m Model) columnTaskCount(columnID string, shown int) int {
+	if m.columnCounts == nil {
+		return shown
+	}
+	return m.columnCounts[columnID]
+}
+
+// kanbanColumnTitle renders the column name, followed by count/limit when the
+// column has a WIP limit. Full columns are marked so moves into them are
+// expected to be refused.
+func kanbanColumnTitle(col domain.Column, count int) string {
+	if col.WIPLimit == nil || *col.WIPLimit <= 0 {
+		return col.Name
+	}
+	title := fmt.Sprintf("%s %d/%d", col.Name, count, *col.WIPLimit)
+	if application.WIPLimitReached(col, count) {
+		title += " (full)"
+	}
+	return title
+}
+
 func renderKanbanColumnContent(header string, rows []string, maxHeight, activeRow int) string {
 	if maxHeight < 1 {
 		return ""
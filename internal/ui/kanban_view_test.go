@@ -2,6 +2,7 @@ package ui
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -57,3 +58,16 @@ func kanbanTestModel(width, height int) Model {
 		height:   height,
 	}
 }
+
+func TestKanbanViewMarksConflictedTasks(t *testing.T) {
+	model := kanbanTestModel(80, 14)
+	model.conflicts = map[string]bool{"task-01": true}
+
+	rendered := model.View()
+	if !strings.Contains(rendered, "! Task 01") {
+		t.Fatalf("expected conflicted task to be marked\n%s", rendered)
+	}
+	if strings.Contains(rendered, "! Task 02") {
+		t.Fatalf("expected only conflicted tasks to be marked\n%s", rendered)
+	}
+}
@@ -22,6 +22,8 @@ func (m Model) keybindEntries() []keybindEntry {
 		{ID: "add_comment", Key: "c", Label: "Add comment"},
 		{ID: "search", Key: "/", Label: "Search"},
 		{ID: "open_filters", Key: "f", Label: "Open filter/sort panel"},
+		{ID: "filter_expression", Key: "F", Label: "Filter by expression"},
+		{ID: "open_saved_views", Key: "v", Label: "Open saved views"},
 		{ID: "open_workspaces", Key: "w", Label: "Open workspace switcher"},
 		{ID: "open_board_panel", Key: "b", Label: "Open board manager"},
 		{ID: "prev_board", Key: "[", Label: "Previous board"},
@@ -39,10 +41,21 @@ func (m Model) keybindEntries() []keybindEntry {
 		{ID: "move_right", Key: "→", Label: "Move selection right (kanban)"},
 		{ID: "move_task_left", Key: "Shift+←", Label: "Move card to left column (kanban)"},
 		{ID: "move_task_right", Key: "Shift+→", Label: "Move card to right column (kanban)"},
+		{ID: "reorder_task_up", Key: "Shift+↑", Label: "Move card up within its column"},
+		{ID: "reorder_task_down", Key: "Shift+↓", Label: "Move card down within its column"},
+		{ID: "toggle_mark", Key: "Space", Label: "Select task for bulk actions"},
+		{ID: "mark_range", Key: "V", Label: "Select range up to task"},
+		{ID: "set_priority", Key: "p", Label: "Set priority of selected tasks"},
+		{ID: "edit_labels", Key: "#", Label: "Add/remove labels of selected tasks"},
+		{ID: "set_due_date", Key: "D", Label: "Set due date of selected tasks"},
+		{ID: "move_to_board", Key: "M", Label: "Move task to another board"},
+		{ID: "copy_task", Key: "C", Label: "Copy task, optionally to another board"},
+		{ID: "undo", Key: "u", Label: "Undo last change"},
+		{ID: "redo", Key: "Ctrl+R", Label: "Redo undone change"},
 		{ID: "quit", Key: "q", Label: "Quit"},
 	}
-	if strings.TrimSpace(m.titleFilter) != "" {
-		entries = append(entries, keybindEntry{ID: "clear_search", Key: "x", Label: "Clear search"})
+	if strings.TrimSpace(m.titleFilter) != "" || strings.TrimSpace(m.filterExpr) != "" {
+		entries = append(entries, keybindEntry{ID: "clear_search", Key: "x", Label: "Clear search and filter"})
 	}
 	return entries
 }
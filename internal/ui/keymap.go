@@ -16,7 +16,9 @@ type keyMap struct {
 	AddComment          key.Binding
 	Search              key.Binding
 	ClearSearch         key.Binding
+	FilterExpression    key.Binding
 	ShowFilters         key.Binding
+	SavedViews          key.Binding
 	OpenWorkspace       key.Binding
 	OpenBoardPanel      key.Binding
 	PrevBoard           key.Binding
@@ -27,7 +29,18 @@ type keyMap struct {
 	MoveTaskLeft        key.Binding
 	KanbanMoveTaskLeft  key.Binding
 	KanbanMoveTaskRight key.Binding
+	ReorderTaskUp       key.Binding
+	ReorderTaskDown     key.Binding
 	DeleteTask          key.Binding
+	ToggleMark          key.Binding
+	MarkRange           key.Binding
+	SetPriority         key.Binding
+	EditLabels          key.Binding
+	SetDueDate          key.Binding
+	MoveToBoard         key.Binding
+	CopyTask            key.Binding
+	Undo                key.Binding
+	Redo                key.Binding
 	CycleStatus         key.Binding
 	ToggleDueSoon       key.Binding
 	CycleSort           key.Binding
@@ -49,8 +62,10 @@ func newKeyMap() keyMap {
 		EditDescription:     key.NewBinding(key.WithKeys("E"), key.WithHelp("E", "edit description")),
 		AddComment:          key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "add comment")),
 		Search:              key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "search")),
-		ClearSearch:         key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "clear search")),
+		ClearSearch:         key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "clear search and filter")),
+		FilterExpression:    key.NewBinding(key.WithKeys("F"), key.WithHelp("F", "filter expression")),
 		ShowFilters:         key.NewBinding(key.WithKeys("f"), key.WithHelp("f", "filters")),
+		SavedViews:          key.NewBinding(key.WithKeys("v"), key.WithHelp("v", "saved views")),
 		OpenWorkspace:       key.NewBinding(key.WithKeys("w"), key.WithHelp("w", "workspaces")),
 		OpenBoardPanel:      key.NewBinding(key.WithKeys("b"), key.WithHelp("b", "board manager")),
 		PrevBoard:           key.NewBinding(key.WithKeys("["), key.WithHelp("[", "prev board")),
@@ -61,7 +76,18 @@ func newKeyMap() keyMap {
 		MoveTaskLeft:        key.NewBinding(key.WithKeys("H"), key.WithHelp("H", "move left")),
 		KanbanMoveTaskLeft:  key.NewBinding(key.WithKeys("shift+left"), key.WithHelp("shift+←", "move card left")),
 		KanbanMoveTaskRight: key.NewBinding(key.WithKeys("shift+right"), key.WithHelp("shift+→", "move card right")),
+		ReorderTaskUp:       key.NewBinding(key.WithKeys("shift+up"), key.WithHelp("shift+↑", "move card up")),
+		ReorderTaskDown:     key.NewBinding(key.WithKeys("shift+down"), key.WithHelp("shift+↓", "move card down")),
 		DeleteTask:          key.NewBinding(key.WithKeys("ctrl+d"), key.WithHelp("ctrl+d", "delete")),
+		ToggleMark:          key.NewBinding(key.WithKeys(" "), key.WithHelp("space", "select")),
+		MarkRange:           key.NewBinding(key.WithKeys("V"), key.WithHelp("V", "select range")),
+		SetPriority:         key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "set priority")),
+		EditLabels:          key.NewBinding(key.WithKeys("#"), key.WithHelp("#", "add/remove labels")),
+		SetDueDate:          key.NewBinding(key.WithKeys("D"), key.WithHelp("D", "set due date")),
+		MoveToBoard:         key.NewBinding(key.WithKeys("M"), key.WithHelp("M", "move to board")),
+		CopyTask:            key.NewBinding(key.WithKeys("C"), key.WithHelp("C", "copy task")),
+		Undo:                key.NewBinding(key.WithKeys("u"), key.WithHelp("u", "undo")),
+		Redo:                key.NewBinding(key.WithKeys("ctrl+r"), key.WithHelp("ctrl+r", "redo")),
 		CycleStatus:         key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "cycle column filter")),
 		ToggleDueSoon:       key.NewBinding(key.WithKeys("z"), key.WithHelp("z", "cycle due filter")),
 		CycleSort:           key.NewBinding(key.WithKeys("o"), key.WithHelp("o", "cycle sort")),
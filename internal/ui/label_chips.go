@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// labelChips renders labels as chips in the colors of the workspace labels,
+// gray for labels without one. Chips that do not fit in width are counted in
+// a trailing "+N".
+func (m Model) labelChips(labels []string, width int) string {
+	chips := make([]string, 0, len(labels))
+	used := 0
+	for i, label := range labels {
+		color := m.labelColors[strings.ToLower(strings.TrimSpace(label))]
+		chip := lipgloss.NewStyle().
+			Foreground(contrastingTextColorFromHexOrDefault(color, "252")).
+			Background(colorFromHexOrDefault(color, "238")).
+			Render(" " + label + " ")
+		chipWidth := lipgloss.Width(chip)
+		if len(chips) > 0 {
+			chipWidth++
+		}
+		// Keep room for the "+N" of the labels after this one.
+		reserve := 0
+		if i < len(labels)-1 {
+			reserve = len(fmt.Sprintf(" +%d", len(labels)-i-1))
+		}
+		if used+chipWidth+reserve > width {
+			more := lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Render(fmt.Sprintf("+%d", len(labels)-i))
+			chips = append(chips, more)
+			break
+		}
+		chips = append(chips, chip)
+		used += chipWidth
+	}
+	return strings.Join(chips, " ")
+}
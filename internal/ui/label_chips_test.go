@@ -0,0 +1,33 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestLabelChips_FitWidth(t *testing.T) {
+	m := Model{labelColors: map[string]string{"bug": "#EF4444"}}
+
+	all := ansi.Strip(m.labelChips([]string{"Bug", "ui"}, 40))
+	if all != " Bug   ui " {
+		t.Fatalf("labelChips() = %q, want both chips", all)
+	}
+
+	chips := m.labelChips([]string{"bug", "frontend", "docs"}, 12)
+	if got := ansi.Strip(chips); got != " bug  +2" {
+		t.Fatalf("labelChips() = %q, want the first chip and +2", got)
+	}
+	if lipgloss.Width(chips) > 12 {
+		t.Fatalf("labelChips() is %d wide, want at most 12", lipgloss.Width(chips))
+	}
+}
+
+func TestLabelChips_NothingFits(t *testing.T) {
+	m := Model{}
+	if got := ansi.Strip(m.labelChips([]string{"documentation"}, 6)); !strings.HasPrefix(got, "+1") {
+		t.Fatalf("labelChips() = %q, want +1", got)
+	}
+}
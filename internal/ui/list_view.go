@@ -8,6 +8,11 @@ import (
 	liptable "github.com/charmbracelet/lipgloss/table"
 )
 
+var (
+	listRowStyle         = lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
+	listSelectedRowStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("230")).Background(lipgloss.Color("62"))
+)
+
 func (m Model) renderListScreen() string {
 	containerWidth := max(40, m.width-2)
 
@@ -80,6 +85,9 @@ func (m Model) renderListFilterBar(width int) string {
 	if strings.TrimSpace(m.titleFilter) != "" {
 		content = fmt.Sprintf("%s | Search: %s", content, m.titleFilter)
 	}
+	if strings.TrimSpace(m.filterExpr) != "" {
+		content = fmt.Sprintf("%s | Expr: %s", content, m.filterExpr)
+	}
 	panel := lipgloss.NewStyle().
 		Width(contentWidth).
 		Padding(0, 1).
@@ -238,15 +246,27 @@ func (m Model) renderListView(width, height int) string {
 	fixedTailWidth := statusCellWidth + dueCellWidth + priCellWidth
 	const tableGutterReserve = 4
 	taskContentWidth := max(8, tableWidth-fixedTailWidth-tableGutterReserve)
+	terms := searchTerms(m.titleFilter)
 	rows := make([][]string, 0, len(m.tasks))
-	for _, task := range m.tasks {
+	for i, task := range m.tasks {
 		status := m.statusLabelForTask(task)
 		due := "-"
 		if task.DueAt != nil {
 			due, _ = m.dueDisplay(*task.DueAt)
 		}
+		title := task.Title
+		if m.conflicts[task.ID] {
+			title = "! " + title
+		}
+		if m.marked[task.ID] {
+			title = markedPrefix + title
+		}
+		titleStyle := listRowStyle
+		if i == m.selected {
+			titleStyle = listSelectedRowStyle
+		}
 		rows = append(rows, []string{
-			truncate(task.Title, taskContentWidth),
+			highlightSearch(truncate(title, taskContentWidth), terms, titleStyle),
 			truncate(status, statusContentWidth),
 			truncate(due, dueContentWidth),
 			fmt.Sprintf("p%d", task.Priority),
@@ -270,11 +290,11 @@ func (m Model) renderListView(width, height int) string {
 		Wrap(false).
 		Offset(offset).
 		StyleFunc(func(row, col int) lipgloss.Style {
-			style := lipgloss.NewStyle().Padding(0, 1).Foreground(lipgloss.Color("252"))
+			style := listRowStyle.Padding(0, 1)
 			if row == liptable.HeaderRow {
 				style = lipgloss.NewStyle().Padding(0, 1).Bold(true).Foreground(lipgloss.Color("245"))
 			} else if row == selectedTableRow {
-				style = lipgloss.NewStyle().Padding(0, 1).Foreground(lipgloss.Color("230")).Background(lipgloss.Color("62"))
+				style = listSelectedRowStyle.Padding(0, 1)
 			}
 
 			if row >= 0 {
@@ -325,6 +345,18 @@ func (m Model) renderListView(width, height int) string {
 	return withBottomCounter(panel, counterText)
 }
 
+// clearSearchHint returns the footer hint of the clear key while a search or
+// filter expression is active.
+func (m Model) clearSearchHint() string {
+	switch {
+	case strings.TrimSpace(m.titleFilter) != "":
+		return "x:clear-search"
+	case strings.TrimSpace(m.filterExpr) != "":
+		return "x:clear-filter"
+	}
+	return ""
+}
+
 func withBottomCounter(panel, counter string) string {
 	if strings.TrimSpace(counter) == "" {
 		return panel
@@ -367,8 +399,11 @@ func withBottomCounter(panel, counter string) string {
 
 func (m Model) renderListFooter(width int) string {
 	shortcuts := "?:help  n:new  /:search  enter:open  w:workspaces  b:boards  f:filters  q:quit"
-	if strings.TrimSpace(m.titleFilter) != "" {
-		shortcuts += "  x:clear-search"
+	if hint := m.clearSearchHint(); hint != "" {
+		shortcuts += "  " + hint
+	}
+	if hint := m.markedHint(); hint != "" {
+		shortcuts = hint + "  " + shortcuts
 	}
 	helpLine := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("244")).
@@ -393,7 +428,7 @@ func (m Model) renderListFooter(width int) string {
 func (m Model) renderInlineInput(width int) string {
 	contentWidth := boxContentWidth(width, 1, true)
 	switch m.inputMode {
-	case inputSearch, inputAddComment, inputTaskForm:
+	case inputSearch, inputFilterExpression, inputAddComment, inputTaskForm, inputBulkMove, inputBulkPriority, inputBulkLabels, inputBulkDueDate:
 		return lipgloss.NewStyle().
 			Width(contentWidth).
 			Padding(0, 1).
@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// changePollInterval is how often the TUI checks for external changes.
+const changePollInterval = time.Second
+
+// ChangeWatcher reports whether the database changed since it was last asked.
+type ChangeWatcher interface {
+	Changed(ctx context.Context) (bool, error)
+}
+
+type changePollMsg struct {
+	changed bool
+	err     error
+}
+
+// pollChangesCmd schedules the next change check. It returns nil when the
+// model has no watcher.
+func (m Model) pollChangesCmd() tea.Cmd {
+	if m.changes == nil {
+		return nil
+	}
+	watcher := m.changes
+	return tea.Tick(changePollInterval, func(time.Time) tea.Msg {
+		changed, err := watcher.Changed(context.Background())
+		return changePollMsg{changed: changed, err: err}
+	})
+}
+
+// handleChangePoll reloads contexts, tasks and comments after an external
+// change and schedules the next poll. While an input, the keybind panel, the
+// context panel or the saved view picker is open the reload waits, so it
+// cannot disturb them.
+// Failed polls are retried silently on the next tick.
+func (m Model) handleChangePoll(msg changePollMsg) (tea.Model, tea.Cmd) {
+	next := m.pollChangesCmd()
+	if msg.err != nil || (!msg.changed && !m.staleData) {
+		return m, next
+	}
+	switch m.activeOverlay() {
+	case overlayInput, overlayKeybinds, overlayContexts, overlaySavedViews:
+		m.staleData = true
+		return m, next
+	}
+	m.staleData = false
+
+	if err := m.refreshContexts(); err != nil {
+		m.err = err
+		m.statusLine = err.Error()
+		return m, next
+	}
+	cmds := []tea.Cmd{next, m.reloadTasksCmd()}
+	if m.activeOverlay() == overlayTaskView {
+		cmds = append(cmds, m.loadCommentsCmd(m.viewTaskID))
+		if m.viewActivity {
+			cmds = append(cmds, m.loadHistoryCmd(m.viewTaskID))
+		}
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// reloadTasksCmd is loadTasksCmd keeping the current task selected.
+func (m Model) reloadTasksCmd() tea.Cmd {
+	load := m.loadTasksCmd()
+	task, ok := m.currentTask()
+	if !ok {
+		return load
+	}
+	return func() tea.Msg {
+		msg := load().(tasksLoadedMsg)
+		msg.selectTaskID = task.ID
+		return msg
+	}
+}
+
+// refreshContexts reloads workspaces, boards and columns. It keeps the
+// current workspace and board unless they were deleted.
+func (m *Model) refreshContexts() error {
+	if m.contextService == nil {
+		return nil
+	}
+	ctx := context.Background()
+	workspaces, err := m.contextService.ListWorkspaces(ctx)
+	if err != nil {
+		return err
+	}
+	m.workspaces = workspaces
+	if !containsWorkspace(workspaces, m.workspaceID) {
+		return m.reloadContextsFromStorage()
+	}
+	m.workspaceName = workspaceName(workspaces, m.workspaceID)
+
+	boards, err := m.contextService.ListBoards(ctx, m.workspaceID)
+	if err != nil {
+		return err
+	}
+	m.boards = boards
+	if !containsBoard(boards, m.boardID) {
+		return m.switchWorkspace(m.workspaceID)
+	}
+	m.boardName = boardName(boards, m.boardID)
+
+	columns, err := m.contextService.ListColumns(ctx, m.boardID)
+	if err != nil {
+		return err
+	}
+	sort.Slice(columns, func(i, j int) bool {
+		return columns[i].Position < columns[j].Position
+	})
+	m.columns = columns
+	return nil
+}
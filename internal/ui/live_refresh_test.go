@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+func TestReloadTasksCmd_KeepsSelectedTask(t *testing.T) {
+	repo := &fakeTaskRepoForLoad{listTasks: []domain.Task{
+		{ID: "t1", Title: "One", Priority: 3},
+		{ID: "t2", Title: "Two", Priority: 3},
+		{ID: "t0", Title: "Created elsewhere", Priority: 0},
+	}}
+	m := newTestModelForLoad(repo, &fakeCommentRepoForLoad{})
+	m.priorityFilter = -1
+	m.tasks = []domain.Task{{ID: "t1", Title: "One"}, {ID: "t2", Title: "Two"}}
+	m.selected = 1
+
+	msg := m.reloadTasksCmd()().(tasksLoadedMsg)
+	if msg.selectTaskID != "t2" {
+		t.Fatalf("selectTaskID = %q, want t2", msg.selectTaskID)
+	}
+	updated, _ := m.handleTasksLoaded(msg, true, false)
+	if task, ok := updated.currentTask(); !ok || task.ID != "t2" {
+		t.Fatalf("selection moved to %+v, want t2", task)
+	}
+}
+
+func TestSelectTask_Kanban(t *testing.T) {
+	s := selectionState{
+		viewMode: viewKanban,
+		columns:  []domain.Column{{ID: "c1"}, {ID: "c2"}},
+		tasks: []domain.Task{
+			{ID: "a", ColumnID: strPtr("c1")},
+			{ID: "b", ColumnID: strPtr("c2"), Position: 1},
+			{ID: "c", ColumnID: strPtr("c2"), Position: 2},
+		},
+	}
+	if !s.selectTask("c") || s.activeColumn != 1 || s.kanbanRow != 1 {
+		t.Fatalf("selectTask(c): column %d row %d", s.activeColumn, s.kanbanRow)
+	}
+	if s.selectTask("gone") || s.activeColumn != 1 || s.kanbanRow != 1 {
+		t.Fatal("selecting a missing task must leave the selection alone")
+	}
+}
+
+func TestHandleChangePoll_WaitsForInputToClose(t *testing.T) {
+	m := newTestModelForLoad(&fakeTaskRepoForLoad{}, &fakeCommentRepoForLoad{})
+	m.inputMode = inputAddComment
+
+	model, cmd := m.handleChangePoll(changePollMsg{changed: true})
+	m = model.(Model)
+	if !m.staleData || cmd != nil {
+		t.Fatalf("expected the reload to wait: stale=%v cmd=%v", m.staleData, cmd != nil)
+	}
+
+	m.inputMode = inputNone
+	model, cmd = m.handleChangePoll(changePollMsg{})
+	m = model.(Model)
+	if m.staleData || cmd == nil {
+		t.Fatalf("expected the pending reload to run: stale=%v cmd=%v", m.staleData, cmd != nil)
+	}
+
+	if _, cmd := m.handleChangePoll(changePollMsg{}); cmd != nil {
+		t.Fatal("expected no reload without changes")
+	}
+}
@@ -44,6 +44,13 @@ func (m *Model) restorePendingKanbanSelection() bool {
 	return ok
 }
 
+func (m *Model) selectTask(taskID string) bool {
+	s := m.toSelectionState()
+	ok := s.selectTask(taskID)
+	m.applySelectionState(s)
+	return ok
+}
+
 func (m *Model) ensureKanbanRow() {
 	s := m.toSelectionState()
 	s.ensureKanbanRow()
@@ -8,6 +8,7 @@ const (
 	overlayKeybinds
 	overlayFilters
 	overlayContexts
+	overlaySavedViews
 	overlayTaskView
 	overlayInput
 )
@@ -29,9 +30,19 @@ type overlayState struct {
 	contextEditMode contextEditMode
 	boardForm       *boardCreateForm
 	boardOrder      *boardColumnsOrderForm
+	destination     *taskDestination
+
+	// showSavedViews opens the saved view picker; savingView prompts for
+	// the name to save the current settings under.
+	showSavedViews    bool
+	savedViewSelected int
+	savingView        bool
 
 	viewTaskID     string
 	viewDescScroll int
+	// viewActivity shows the task's activity instead of its comments in
+	// the right pane of the task viewer.
+	viewActivity   bool
 	returnTaskView bool
 	returnTaskID   string
 }
@@ -50,6 +61,9 @@ func (o *overlayState) activeOverlay() overlayKind {
 	if o.showContexts {
 		return overlayContexts
 	}
+	if o.showSavedViews {
+		return overlaySavedViews
+	}
 	if o.inputMode != inputNone {
 		return overlayInput
 	}
@@ -82,6 +96,7 @@ func (o *overlayState) openContexts(mode contextMode) {
 	o.contextEditMode = contextEditNone
 	o.boardForm = nil
 	o.boardOrder = nil
+	o.destination = nil
 }
 
 func (o *overlayState) closeContexts() {
@@ -89,18 +104,32 @@ func (o *overlayState) closeContexts() {
 	o.contextEditMode = contextEditNone
 	o.boardForm = nil
 	o.boardOrder = nil
+	o.destination = nil
+}
+
+func (o *overlayState) openSavedViews() {
+	o.showSavedViews = true
+	o.savedViewSelected = 0
+	o.savingView = false
+}
+
+func (o *overlayState) closeSavedViews() {
+	o.showSavedViews = false
+	o.savingView = false
 }
 
 func (o *overlayState) openTaskView(taskID string) {
 	o.showTaskView = true
 	o.viewTaskID = taskID
 	o.viewDescScroll = 0
+	o.viewActivity = false
 }
 
 func (o *overlayState) closeTaskView() {
 	o.showTaskView = false
 	o.viewTaskID = ""
 	o.viewDescScroll = 0
+	o.viewActivity = false
 }
 
 func (o *overlayState) setTaskViewerReturn(taskID string) {
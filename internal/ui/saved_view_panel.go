@@ -0,0 +1,263 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/tiagokriok/kanji/internal/application"
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+// openSavedViewPanel loads the views of the current board and the
+// workspace-wide views, and opens the picker.
+func (m *Model) openSavedViewPanel() error {
+	if m.viewService == nil {
+		return errors.New("saved views are not available")
+	}
+	views, err := m.viewService.ListViews(context.Background(), m.workspaceID, m.boardID)
+	if err != nil {
+		return err
+	}
+	m.savedViews = views
+	m.overlayState.openSavedViews()
+	return nil
+}
+
+func (m *Model) closeSavedViewPanel() {
+	m.overlayState.closeSavedViews()
+	m.viewNameInput.Blur()
+}
+
+func (m *Model) clampSavedViewSelection() {
+	if m.savedViewSelected >= len(m.savedViews) {
+		m.savedViewSelected = len(m.savedViews) - 1
+	}
+	if m.savedViewSelected < 0 {
+		m.savedViewSelected = 0
+	}
+}
+
+// currentViewSettings captures the filters, sort order and layout on screen.
+func (m Model) currentViewSettings() domain.ViewSettings {
+	settings := domain.ViewSettings{
+		Due:    dueFilterValues[m.dueFilter],
+		Sort:   sortModeValues[m.sortMode],
+		Layout: domain.ViewLayoutList,
+		Search: strings.TrimSpace(m.titleFilter),
+		Filter: strings.TrimSpace(m.filterExpr),
+	}
+	if m.viewMode == viewKanban {
+		settings.Layout = domain.ViewLayoutKanban
+	}
+	if m.columnFilter != "" {
+		settings.Column = m.columnName(m.columnFilter)
+	}
+	if m.priorityFilter >= 0 {
+		priority := m.priorityFilter
+		settings.Priority = &priority
+	}
+	return settings
+}
+
+// applySavedView restores every setting of a view; settings the view leaves
+// empty are reset. A column the current board does not have leaves the
+// column filter off.
+func (m *Model) applySavedView(view domain.View) {
+	settings := view.Settings
+	m.setStatusFilterByIndex(-1)
+	for i, col := range m.columns {
+		if settings.Column != "" && strings.EqualFold(col.Name, settings.Column) {
+			m.setStatusFilterByIndex(i)
+			break
+		}
+	}
+	m.priorityFilter = -1
+	if settings.Priority != nil {
+		m.priorityFilter = *settings.Priority
+	}
+	m.dueFilter = dueFilterAny
+	for mode, value := range dueFilterValues {
+		if value == settings.Due {
+			m.dueFilter = mode
+		}
+	}
+	m.sortMode = sortByPriority
+	for mode, value := range sortModeValues {
+		if value == settings.Sort {
+			m.sortMode = mode
+		}
+	}
+	m.titleFilter = settings.Search
+	m.filterExpr = settings.Filter
+	switch settings.Layout {
+	case domain.ViewLayoutList:
+		m.viewMode = viewList
+	case domain.ViewLayoutKanban:
+		m.viewMode = viewKanban
+	}
+	m.ensureSelection()
+
+	m.statusLine = "View: " + view.Name
+	if settings.Column != "" && m.columnFilter == "" {
+		m.statusLine += fmt.Sprintf(" (no %q column on this board)", settings.Column)
+	}
+}
+
+// saveCurrentView saves the settings on screen as a view of the current
+// board under the name typed in the picker.
+func (m *Model) saveCurrentView() error {
+	view, err := m.viewService.SaveView(context.Background(), application.SaveViewInput{
+		WorkspaceID: m.workspaceID,
+		BoardID:     m.boardID,
+		Name:        m.viewNameInput.Value(),
+		Settings:    m.currentViewSettings(),
+	})
+	if err != nil {
+		return err
+	}
+	views, err := m.viewService.ListViews(context.Background(), m.workspaceID, m.boardID)
+	if err != nil {
+		return err
+	}
+	m.savedViews = views
+	for i, v := range views {
+		if v.ID == view.ID {
+			m.savedViewSelected = i
+		}
+	}
+	m.savingView = false
+	m.viewNameInput.Blur()
+	m.statusLine = "Saved view " + view.Name
+	return nil
+}
+
+func (m Model) updateSavedViewPanel(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tasksLoadedMsg:
+		return m.handleTasksLoaded(msg, false, true)
+	case commentsLoadedMsg:
+		return m.handleCommentsLoaded(msg)
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+	case tea.KeyMsg:
+		if m.savingView {
+			switch {
+			case key.Matches(msg, m.keys.Cancel):
+				m.savingView = false
+				m.viewNameInput.Blur()
+				return m, nil
+			case key.Matches(msg, m.keys.Confirm):
+				if err := m.saveCurrentView(); err != nil {
+					m.statusLine = err.Error()
+				}
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.viewNameInput, cmd = m.viewNameInput.Update(msg)
+			return m, cmd
+		}
+
+		switch {
+		case key.Matches(msg, m.keys.Cancel), key.Matches(msg, m.keys.SavedViews):
+			m.closeSavedViewPanel()
+			return m, nil
+		case key.Matches(msg, m.keys.Up):
+			m.savedViewSelected--
+			m.clampSavedViewSelection()
+			return m, nil
+		case key.Matches(msg, m.keys.Down):
+			m.savedViewSelected++
+			m.clampSavedViewSelection()
+			return m, nil
+		case key.Matches(msg, m.keys.Confirm):
+			if len(m.savedViews) == 0 {
+				return m, nil
+			}
+			m.clampSavedViewSelection()
+			m.applySavedView(m.savedViews[m.savedViewSelected])
+			m.closeSavedViewPanel()
+			return m, m.loadTasksCmd()
+		}
+		if msg.String() == "n" {
+			m.savingView = true
+			m.viewNameInput.SetValue("")
+			m.viewNameInput.Focus()
+			return m, textinput.Blink
+		}
+	}
+	return m, nil
+}
+
+func (m Model) renderSavedViewPanel(base string) string {
+	_ = base
+	panelWidth := m.width * 2 / 3
+	if panelWidth < 56 {
+		panelWidth = 56
+	}
+	if panelWidth > m.width-2 {
+		panelWidth = max(20, m.width-2)
+	}
+	panelHeight := m.height * 3 / 4
+	if panelHeight < 12 {
+		panelHeight = 12
+	}
+	if panelHeight > m.height-2 {
+		panelHeight = max(8, m.height-2)
+	}
+
+	contentWidth := boxContentWidth(panelWidth, 1, true)
+	contentHeight := boxContentHeight(panelHeight, true)
+	listHeight := max(1, contentHeight-6)
+
+	lines := []string{
+		lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("151")).Render("Saved Views"),
+		lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Render("↑/↓ select | Enter: apply | n: save current filters | Esc: close"),
+		"",
+	}
+
+	if len(m.savedViews) == 0 {
+		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("245")).Render("(no views yet: press n to save the current filters)"))
+	} else {
+		offset := 0
+		if m.savedViewSelected >= listHeight {
+			offset = m.savedViewSelected - listHeight + 1
+		}
+		end := min(len(m.savedViews), offset+listHeight)
+		for i := offset; i < end; i++ {
+			view := m.savedViews[i]
+			scope := "board"
+			if view.BoardID == nil {
+				scope = "all boards"
+			}
+			text := truncate(fmt.Sprintf("%s (%s) %s", view.Name, scope, view.Settings.Summary()), max(1, contentWidth-2))
+			line := lipgloss.NewStyle().Foreground(lipgloss.Color("252")).Render(text)
+			if i == m.savedViewSelected {
+				line = lipgloss.NewStyle().Foreground(lipgloss.Color("230")).Background(lipgloss.Color("62")).Render(text)
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	if m.savingView {
+		lines = append(lines, "", lipgloss.NewStyle().Foreground(lipgloss.Color("221")).Render("Save as: "+m.viewNameInput.View()))
+	}
+
+	panel := lipgloss.NewStyle().
+		Width(contentWidth).
+		Height(contentHeight).
+		Padding(0, 1).
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("151")).
+		Render(strings.Join(lines, "\n"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, panel)
+}
@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+func TestSavedView_RoundTrip(t *testing.T) {
+	columns := []domain.Column{{ID: "c1", Name: "Todo"}, {ID: "c2", Name: "Doing"}}
+	m := Model{
+		columns:        columns,
+		filterIndex:    1,
+		columnFilter:   "c2",
+		priorityFilter: 2,
+		dueFilter:      dueFilterOverdue,
+		sortMode:       sortByTitle,
+		viewMode:       viewKanban,
+		titleFilter:    " login ",
+		filterExpr:     "label:bug",
+	}
+	settings := m.currentViewSettings()
+	if settings.Column != "Doing" || settings.Priority == nil || *settings.Priority != 2 ||
+		settings.Due != domain.ViewDueOverdue || settings.Sort != domain.ViewSortTitle ||
+		settings.Layout != domain.ViewLayoutKanban || settings.Search != "login" || settings.Filter != "label:bug" {
+		t.Fatalf("currentViewSettings() = %+v", settings)
+	}
+
+	restored := Model{columns: columns, filterIndex: -1, priorityFilter: -1}
+	restored.applySavedView(domain.View{Name: "Bugs", Settings: settings})
+	if restored.columnFilter != "c2" || restored.filterIndex != 1 || restored.priorityFilter != 2 ||
+		restored.dueFilter != dueFilterOverdue || restored.sortMode != sortByTitle || restored.viewMode != viewKanban ||
+		restored.titleFilter != "login" || restored.filterExpr != "label:bug" {
+		t.Fatalf("applySavedView restored %+v", restored)
+	}
+	if restored.statusLine != "View: Bugs" {
+		t.Fatalf("statusLine = %q", restored.statusLine)
+	}
+}
+
+func TestSavedView_ApplyResetsUnsetSettings(t *testing.T) {
+	m := Model{
+		columns:        []domain.Column{{ID: "c1", Name: "Todo"}},
+		filterIndex:    0,
+		columnFilter:   "c1",
+		priorityFilter: 1,
+		dueFilter:      dueFilterSoon,
+		sortMode:       sortByDueDate,
+		titleFilter:    "login",
+		filterExpr:     "label:bug",
+	}
+	m.applySavedView(domain.View{Name: "Review", Settings: domain.ViewSettings{Column: "In Review"}})
+	if m.columnFilter != "" || m.filterIndex != -1 || m.priorityFilter != -1 || m.dueFilter != dueFilterAny ||
+		m.sortMode != sortByPriority || m.titleFilter != "" || m.filterExpr != "" {
+		t.Fatalf("applySavedView left settings on: %+v", m)
+	}
+	if !strings.Contains(m.statusLine, `no "In Review" column`) {
+		t.Fatalf("statusLine = %q", m.statusLine)
+	}
+}
@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+var searchMatchStyle = lipgloss.NewStyle().Bold(true).Underline(true).Foreground(lipgloss.Color("220"))
+
+// searchTerms splits search text into lowercase words the way the full-text
+// index does, on anything that is not a letter or digit.
+func searchTerms(query string) []string {
+	return strings.FieldsFunc(strings.ToLower(query), isNotWordRune)
+}
+
+func isNotWordRune(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// highlightSearch renders text in base, highlighting every word that starts
+// with one of the search terms. Text without matches is returned unstyled so
+// callers can keep styling it as before.
+func highlightSearch(text string, terms []string, base lipgloss.Style) string {
+	if len(terms) == 0 {
+		return text
+	}
+	runes := []rune(text)
+	var b strings.Builder
+	matched := false
+	plainStart := 0
+	for i := 0; i < len(runes); {
+		if isNotWordRune(runes[i]) {
+			i++
+			continue
+		}
+		end := i
+		for end < len(runes) && !isNotWordRune(runes[end]) {
+			end++
+		}
+		if matchesSearchTerm(string(runes[i:end]), terms) {
+			if plainStart < i {
+				b.WriteString(base.Render(string(runes[plainStart:i])))
+			}
+			b.WriteString(searchMatchStyle.Inherit(base).Render(string(runes[i:end])))
+			plainStart = end
+			matched = true
+		}
+		i = end
+	}
+	if !matched {
+		return text
+	}
+	if plainStart < len(runes) {
+		b.WriteString(base.Render(string(runes[plainStart:])))
+	}
+	return b.String()
+}
+
+func matchesSearchTerm(word string, terms []string) bool {
+	word = strings.ToLower(word)
+	for _, term := range terms {
+		if strings.HasPrefix(word, term) {
+			return true
+		}
+	}
+	return false
+}
+
+// renderSnippet renders a search snippet on one line in base, highlighting
+// the words the index marked as matches.
+func renderSnippet(snippet string, base lipgloss.Style) string {
+	snippet = strings.Join(strings.Fields(snippet), " ")
+	var b strings.Builder
+	for {
+		start := strings.Index(snippet, domain.SearchMatchStart)
+		if start < 0 {
+			break
+		}
+		rest := snippet[start+len(domain.SearchMatchStart):]
+		end := strings.Index(rest, domain.SearchMatchEnd)
+		if end < 0 {
+			break
+		}
+		if start > 0 {
+			b.WriteString(base.Render(snippet[:start]))
+		}
+		b.WriteString(searchMatchStyle.Inherit(base).Render(rest[:end]))
+		snippet = rest[end+len(domain.SearchMatchEnd):]
+	}
+	snippet = strings.NewReplacer(domain.SearchMatchStart, "", domain.SearchMatchEnd, "").Replace(snippet)
+	if snippet != "" {
+		b.WriteString(base.Render(snippet))
+	}
+	return b.String()
+}
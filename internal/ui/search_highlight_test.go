@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestHighlightSearch(t *testing.T) {
+	base := lipgloss.NewStyle()
+	if got := highlightSearch("Fix login redirect", nil, base); got != "Fix login redirect" {
+		t.Errorf("without terms = %q", got)
+	}
+	if got := highlightSearch("Fix login redirect", searchTerms("vendor"), base); got != "Fix login redirect" {
+		t.Errorf("without matches = %q", got)
+	}
+	got := highlightSearch("Fix Login-redirect", searchTerms(`"log" red`), base)
+	if plain := ansi.Strip(got); plain != "Fix Login-redirect" {
+		t.Errorf("highlighting changed the text: %q", plain)
+	}
+	if !strings.Contains(got, searchMatchStyle.Inherit(base).Render("Login")) || !strings.Contains(got, searchMatchStyle.Inherit(base).Render("redirect")) {
+		t.Errorf("expected Login and redirect highlighted, got %q", got)
+	}
+}
+
+func TestRenderSnippet(t *testing.T) {
+	base := lipgloss.NewStyle()
+	got := renderSnippet("Waiting on\nthe \x02vendor\x03 contract", base)
+	if plain := ansi.Strip(got); plain != "Waiting on the vendor contract" {
+		t.Errorf("snippet = %q", plain)
+	}
+	if !strings.Contains(got, searchMatchStyle.Inherit(base).Render("vendor")) {
+		t.Errorf("expected vendor highlighted, got %q", got)
+	}
+}
@@ -100,6 +100,30 @@ func (s *selectionState) restorePendingKanbanSelection() bool {
 	return false
 }
 
+// selectTask selects the task with the given ID in either view.
+// Returns false, leaving the selection alone, when the task is not listed.
+func (s *selectionState) selectTask(taskID string) bool {
+	if s.viewMode == viewKanban {
+		for i, col := range s.columns {
+			for row, task := range s.tasksForColumn(col.ID) {
+				if task.ID == taskID {
+					s.activeColumn = i
+					s.kanbanRow = row
+					return true
+				}
+			}
+		}
+		return false
+	}
+	for i, task := range s.tasks {
+		if task.ID == taskID {
+			s.selected = i
+			return true
+		}
+	}
+	return false
+}
+
 // ensureKanbanRow validates and adjusts the kanban row index for the current column.
 // Ensures kanbanRow is within bounds of tasks in the active column.
 func (s *selectionState) ensureKanbanRow() {
@@ -2,6 +2,7 @@ package ui
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -10,9 +11,20 @@ import (
 	"github.com/tiagokriok/kanji/internal/domain"
 )
 
+// currentProviderID returns the provider of the active workspace, falling
+// back to the default provider.
+func (m Model) currentProviderID() string {
+	for _, ws := range m.workspaces {
+		if ws.ID == m.workspaceID && ws.ProviderID != "" {
+			return ws.ProviderID
+		}
+	}
+	return m.providerID
+}
+
 func (m Model) createTaskWithDetailsCmd(title, description string, priority int, dueAt *time.Time, boardID, columnID, status *string) tea.Cmd {
 	service := m.taskService
-	providerID := m.providerID
+	providerID := m.currentProviderID()
 	workspaceID := m.workspaceID
 
 	return func() tea.Msg {
@@ -35,38 +47,44 @@ func (m Model) createTaskWithDetailsCmd(title, description string, priority int,
 	}
 }
 
-func (m Model) updateTaskWithDetailsCmd(taskID string, title, description *string, priority *int, dueAt *time.Time, columnID, status *string) tea.Cmd {
-	service := m.taskService
-	return func() tea.Msg {
-		err := service.UpdateTask(context.Background(), taskID, application.UpdateTaskInput{
-			Title:         title,
-			DescriptionMD: description,
-			Priority:      priority,
-			DueAt:         dueAt,
-			ColumnID:      columnID,
-			Status:        status,
-		})
-		if err != nil {
-			return opResultMsg{err: err}
-		}
-		return opResultMsg{status: "task updated"}
-	}
+func (m Model) updateTaskWithDetailsCmd(taskID string, version int, title, description *string, priority *int, dueAt *time.Time, columnID, status *string) tea.Cmd {
+	return saveTaskCmd(m.taskService, taskID, version, application.UpdateTaskInput{
+		Title:         title,
+		DescriptionMD: description,
+		Priority:      priority,
+		DueAt:         dueAt,
+		ColumnID:      columnID,
+		Status:        status,
+	}, "task updated")
 }
 
-func (m Model) updateTaskDescriptionCmd(taskID, description string) tea.Cmd {
-	service := m.taskService
+func (m Model) updateTaskDescriptionCmd(taskID string, version int, description string) tea.Cmd {
+	return saveTaskCmd(m.taskService, taskID, version, application.UpdateTaskInput{DescriptionMD: &description}, "description updated")
+}
+
+// saveTaskCmd applies input to a task that was last seen at version; 0 skips
+// the version check. When the task changed in the meantime, the result
+// carries an overwrite command that saves without the check.
+func saveTaskCmd(service *application.TaskService, taskID string, version int, input application.UpdateTaskInput, status string) tea.Cmd {
+	input.IfVersion = nil
+	if version > 0 {
+		input.IfVersion = &version
+	}
 	return func() tea.Msg {
-		err := service.UpdateTask(context.Background(), taskID, application.UpdateTaskInput{DescriptionMD: &description})
+		err := service.UpdateTask(context.Background(), taskID, input)
+		if errors.Is(err, domain.ErrVersionConflict) {
+			return opResultMsg{err: err, overwrite: saveTaskCmd(service, taskID, 0, input, status)}
+		}
 		if err != nil {
 			return opResultMsg{err: err}
 		}
-		return opResultMsg{status: "description updated"}
+		return opResultMsg{status: status}
 	}
 }
 
 func (m Model) addCommentCmd(taskID, body string) tea.Cmd {
 	service := m.commentService
-	providerID := m.providerID
+	providerID := m.currentProviderID()
 	return func() tea.Msg {
 		_, err := service.AddComment(context.Background(), application.AddCommentInput{
 			TaskID:     taskID,
@@ -108,6 +126,37 @@ func (m Model) moveToPrevColumnCmd(task domain.Task) tea.Cmd {
 	}
 }
 
+// reorderTaskCmd moves a task one place up (delta -1) or down (delta 1) among
+// the listed tasks of its column.
+func (m Model) reorderTaskCmd(task domain.Task, delta int) tea.Cmd {
+	if task.ColumnID == nil {
+		return nil
+	}
+	columnID := *task.ColumnID
+	tasks := m.tasksForColumn(columnID)
+	next := -1
+	for i, t := range tasks {
+		if t.ID == task.ID {
+			next = i + delta
+			break
+		}
+	}
+	if next < 0 || next >= len(tasks) {
+		return nil
+	}
+	placement := application.Placement{Before: tasks[next].ID}
+	if delta > 0 {
+		placement = application.Placement{After: tasks[next].ID}
+	}
+	flow := m.taskFlow
+	return func() tea.Msg {
+		if err := flow.ReorderTask(context.Background(), task.ID, application.MoveOptions{Placement: placement}); err != nil {
+			return opResultMsg{err: err}
+		}
+		return opResultMsg{status: "task reordered", taskID: task.ID, columnID: columnID}
+	}
+}
+
 func (m Model) deleteTaskCmd(id string) tea.Cmd {
 	service := m.taskService
 	return func() tea.Msg {
@@ -118,3 +167,51 @@ func (m Model) deleteTaskCmd(id string) tea.Cmd {
 		return opResultMsg{status: "task deleted"}
 	}
 }
+
+// transferTaskCmd moves a task to a column of another board; where names
+// the destination on the status line.
+func (m Model) transferTaskCmd(task domain.Task, dest application.TaskDestination, where string) tea.Cmd {
+	flow := m.taskFlow
+	return func() tea.Msg {
+		if _, err := flow.TransferTask(context.Background(), task.ID, dest, application.MoveOptions{}); err != nil {
+			return opResultMsg{err: err}
+		}
+		return opResultMsg{status: "task moved to " + where, toast: true}
+	}
+}
+
+// copyTaskCmd copies a task, and with withComments its comments, to a
+// column of any board.
+func (m Model) copyTaskCmd(task domain.Task, dest application.TaskDestination, withComments bool, where string) tea.Cmd {
+	service := m.copyService
+	return func() tea.Msg {
+		_, err := service.CopyTask(context.Background(), task.ID, application.CopyTaskInput{
+			Destination:  dest,
+			WithComments: withComments,
+		})
+		if err != nil {
+			return opResultMsg{err: err}
+		}
+		return opResultMsg{status: "task copied to " + where, toast: true}
+	}
+}
+
+// undoCmd undoes, or with redo set redoes, the latest change journaled for
+// the namespace and reports it on the status line.
+func (m Model) undoCmd(redo bool) tea.Cmd {
+	service, namespace := m.undoService, m.undoNamespace
+	if service == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		run, verb := service.Undo, "undid"
+		if redo {
+			run, verb = service.Redo, "redid"
+		}
+		ops, err := run(context.Background(), namespace, 1)
+		if err != nil {
+			return opResultMsg{err: err}
+		}
+		return opResultMsg{status: verb + " " + ops[0].Describe(), toast: true}
+	}
+}
@@ -29,6 +29,9 @@ func (r *fakeTaskRepoForCommands) Create(ctx context.Context, task domain.Task)
 	r.lastCreated = task
 	return r.createErr
 }
+func (r *fakeTaskRepoForCommands) CreatePlaced(ctx context.Context, task domain.Task, place domain.PlaceFunc) error {
+	return r.Create(ctx, task)
+}
 func (r *fakeTaskRepoForCommands) Update(ctx context.Context, taskID string, patch domain.TaskPatch) error {
 	r.lastUpdateID = taskID
 	r.lastUpdate = patch
@@ -48,6 +51,9 @@ func (r *fakeTaskRepoForCommands) Delete(ctx context.Context, id string) error {
 	r.lastDeletedID = id
 	return r.deleteErr
 }
+func (r *fakeTaskRepoForCommands) Bulk(ctx context.Context, changes []domain.TaskChange) error {
+	return nil
+}
 func (r *fakeTaskRepoForCommands) ListColumns(ctx context.Context, boardID string) ([]domain.Column, error) {
 	return nil, nil
 }
@@ -64,10 +70,13 @@ func (r *fakeCommentRepoForCommands) Create(ctx context.Context, comment domain.
 	r.lastCreated = comment
 	return r.createErr
 }
+func (r *fakeCommentRepoForCommands) GetByID(ctx context.Context, commentID string) (domain.Comment, error) {
+	return domain.Comment{}, nil
+}
 func (r *fakeCommentRepoForCommands) ListByTask(ctx context.Context, taskID string) ([]domain.Comment, error) {
 	return nil, nil
 }
-func (r *fakeCommentRepoForCommands) Update(ctx context.Context, commentID string, bodyMD string) error {
+func (r *fakeCommentRepoForCommands) Update(ctx context.Context, commentID string, bodyMD string, ifVersion *int) error {
 	return nil
 }
 func (r *fakeCommentRepoForCommands) Delete(ctx context.Context, commentID string) error {
@@ -178,7 +187,7 @@ func TestUpdateTaskWithDetailsCmd_Success(t *testing.T) {
 	repo := &fakeTaskRepoForCommands{}
 	m := newTestModelWithServices(repo, &fakeCommentRepoForCommands{})
 
-	cmd := m.updateTaskWithDetailsCmd("task-1", strPtr("new title"), strPtr("new desc"), intPtr(2), nil, strPtr("col-2"), strPtr("doing"))
+	cmd := m.updateTaskWithDetailsCmd("task-1", 0, strPtr("new title"), strPtr("new desc"), intPtr(2), nil, strPtr("col-2"), strPtr("doing"))
 	assertOpResultStatus(t, cmd, "task updated")
 	if repo.lastUpdateID != "task-1" {
 		t.Errorf("taskID = %q, want task-1", repo.lastUpdateID)
@@ -204,17 +213,36 @@ func TestUpdateTaskWithDetailsCmd_Error(t *testing.T) {
 	repo := &fakeTaskRepoForCommands{updateErr: errors.New("update failed")}
 	m := newTestModelWithServices(repo, &fakeCommentRepoForCommands{})
 
-	cmd := m.updateTaskWithDetailsCmd("task-1", strPtr("new title"), nil, nil, nil, nil, nil)
+	cmd := m.updateTaskWithDetailsCmd("task-1", 0, strPtr("new title"), nil, nil, nil, nil, nil)
 	assertOpResultError(t, cmd, "update failed")
 }
 
+func TestUpdateTaskWithDetailsCmd_VersionConflictOffersOverwrite(t *testing.T) {
+	repo := &fakeTaskRepoForCommands{updateErr: &domain.VersionConflictError{Entity: "task", ID: "task-1", Expected: 3, Actual: 4}}
+	m := newTestModelWithServices(repo, &fakeCommentRepoForCommands{})
+
+	result := m.updateTaskWithDetailsCmd("task-1", 3, strPtr("new title"), nil, nil, nil, nil, nil)().(opResultMsg)
+	if repo.lastUpdate.IfVersion == nil || *repo.lastUpdate.IfVersion != 3 {
+		t.Fatalf("IfVersion = %v, want 3", repo.lastUpdate.IfVersion)
+	}
+	if !errors.Is(result.err, domain.ErrVersionConflict) || result.overwrite == nil {
+		t.Fatalf("expected a conflict with an overwrite command, got %+v", result)
+	}
+
+	repo.updateErr = nil
+	assertOpResultStatus(t, result.overwrite, "task updated")
+	if repo.lastUpdate.IfVersion != nil {
+		t.Errorf("overwrite kept IfVersion = %d", *repo.lastUpdate.IfVersion)
+	}
+}
+
 // --- updateTaskDescriptionCmd ---
 
 func TestUpdateTaskDescriptionCmd_Success(t *testing.T) {
 	repo := &fakeTaskRepoForCommands{}
 	m := newTestModelWithServices(repo, &fakeCommentRepoForCommands{})
 
-	cmd := m.updateTaskDescriptionCmd("task-1", "new description")
+	cmd := m.updateTaskDescriptionCmd("task-1", 0, "new description")
 	assertOpResultStatus(t, cmd, "description updated")
 	if repo.lastUpdateID != "task-1" {
 		t.Errorf("taskID = %q, want task-1", repo.lastUpdateID)
@@ -228,7 +256,7 @@ func TestUpdateTaskDescriptionCmd_Error(t *testing.T) {
 	repo := &fakeTaskRepoForCommands{updateErr: errors.New("update failed")}
 	m := newTestModelWithServices(repo, &fakeCommentRepoForCommands{})
 
-	cmd := m.updateTaskDescriptionCmd("task-1", "new description")
+	cmd := m.updateTaskDescriptionCmd("task-1", 0, "new description")
 	assertOpResultError(t, cmd, "update failed")
 }
 
@@ -363,3 +391,59 @@ func TestDeleteTaskCmd_Error(t *testing.T) {
 	cmd := m.deleteTaskCmd("task-1")
 	assertOpResultError(t, cmd, "delete failed")
 }
+
+// --- undoCmd ---
+
+type fakeOperationRepo struct {
+	undone    []string
+	namespace string
+}
+
+func (r *fakeOperationRepo) NextUndo(ctx context.Context, namespace string) (domain.OperationChange, error) {
+	return domain.OperationChange{}, domain.ErrNothingToUndo
+}
+
+func (r *fakeOperationRepo) NextRedo(ctx context.Context, namespace string) (domain.OperationChange, error) {
+	return domain.OperationChange{}, domain.ErrNothingToRedo
+}
+
+func (r *fakeOperationRepo) Undo(ctx context.Context, namespace, operationID string) (domain.Operation, error) {
+	r.namespace = namespace
+	if len(r.undone) > 0 {
+		return domain.Operation{}, domain.ErrNothingToUndo
+	}
+	r.undone = append(r.undone, "op-1")
+	return domain.Operation{ID: "op-1", Kind: domain.OperationTaskDelete, Title: "Ship it"}, nil
+}
+
+func (r *fakeOperationRepo) Redo(ctx context.Context, namespace, operationID string) (domain.Operation, error) {
+	return domain.Operation{}, domain.ErrNothingToRedo
+}
+
+func TestUndoKey_ShowsToast(t *testing.T) {
+	repo := &fakeOperationRepo{}
+	m := newTestModelWithServices(&fakeTaskRepoForCommands{}, &fakeCommentRepoForCommands{})
+	m.SetUndoService(application.NewUndoService(repo), "ns-1")
+
+	model, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	if cmd == nil {
+		t.Fatal("expected an undo command")
+	}
+	model, _ = model.(Model).Update(cmd())
+	if got := model.(Model).statusLine; got != `undid deletion of "Ship it"` {
+		t.Errorf("statusLine = %q", got)
+	}
+	if repo.namespace != "ns-1" {
+		t.Errorf("namespace = %q, want ns-1", repo.namespace)
+	}
+
+	_, cmd = model.(Model).Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	assertOpResultError(t, cmd, "nothing to redo")
+}
+
+func TestUndoCmd_WithoutServiceIsNil(t *testing.T) {
+	m := newTestModelWithServices(&fakeTaskRepoForCommands{}, &fakeCommentRepoForCommands{})
+	if cmd := m.undoCmd(false); cmd != nil {
+		t.Error("expected nil cmd without an undo service")
+	}
+}
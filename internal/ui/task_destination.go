@@ -0,0 +1,243 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/tiagokriok/kanji/internal/application"
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+// taskDestination is the state of the context panel while it picks where to
+// move or copy a task: a board of any workspace first, then one of its
+// columns.
+type taskDestination struct {
+	task         domain.Task
+	copying      bool
+	withComments bool
+	boards       []destinationBoard
+	// board is the picked board; columns are listed once it is set.
+	board   *destinationBoard
+	columns []domain.Column
+}
+
+type destinationBoard struct {
+	workspaceID string
+	label       string // "Workspace / Board"
+	board       domain.Board
+}
+
+// openTaskDestination opens the destination picker for the current task.
+// Moving lists the boards other than the current one, since m, H and L
+// already move tasks within it; copying lists every board.
+func (m *Model) openTaskDestination(copying bool) error {
+	if m.contextService == nil || (copying && m.copyService == nil) {
+		return nil
+	}
+	task, ok := m.currentTask()
+	if !ok {
+		return nil
+	}
+
+	ctx := context.Background()
+	boards := make([]destinationBoard, 0)
+	for _, ws := range m.workspaces {
+		list, err := m.contextService.ListBoards(ctx, ws.ID)
+		if err != nil {
+			return err
+		}
+		for _, b := range list {
+			if !copying && b.ID == m.boardID {
+				continue
+			}
+			boards = append(boards, destinationBoard{workspaceID: ws.ID, label: ws.Name + " / " + b.Name, board: b})
+		}
+	}
+	if len(boards) == 0 {
+		return fmt.Errorf("no other board to move the task to")
+	}
+
+	m.openContextPanel(contextDestination)
+	m.destination = &taskDestination{task: task, copying: copying, boards: boards}
+	return nil
+}
+
+func (m Model) destinationItems(query string) []string {
+	d := m.destination
+	items := make([]string, 0)
+	if d == nil {
+		return items
+	}
+	if d.board == nil {
+		for _, b := range d.boards {
+			if query == "" || strings.Contains(strings.ToLower(b.label), query) {
+				items = append(items, b.board.ID)
+			}
+		}
+		return items
+	}
+	for _, c := range d.columns {
+		if query == "" || strings.Contains(strings.ToLower(c.Name), query) {
+			items = append(items, c.ID)
+		}
+	}
+	return items
+}
+
+func (m Model) destinationNameByID(id string) string {
+	d := m.destination
+	if d == nil {
+		return ""
+	}
+	if d.board == nil {
+		for _, b := range d.boards {
+			if b.board.ID == id {
+				return b.label
+			}
+		}
+		return ""
+	}
+	for _, c := range d.columns {
+		if c.ID == id {
+			return c.Name
+		}
+	}
+	return ""
+}
+
+func (m Model) destinationTitle() string {
+	d := m.destination
+	if d == nil {
+		return ""
+	}
+	verb := "Move"
+	if d.copying {
+		verb = "Copy"
+	}
+	if d.board == nil {
+		return fmt.Sprintf("%s %q to board", verb, d.task.Title)
+	}
+	return fmt.Sprintf("%s %q to a column of %s", verb, d.task.Title, d.board.label)
+}
+
+func (m Model) destinationHelp() string {
+	d := m.destination
+	if d == nil || d.board == nil {
+		return "Type to filter | Enter: pick board | Esc:close"
+	}
+	if !d.copying {
+		return "Type to filter | Enter: move here | Esc:back"
+	}
+	comments := "off"
+	if d.withComments {
+		comments = "on"
+	}
+	return "Type to filter | Enter: copy here | Tab: with comments (" + comments + ") | Esc:back"
+}
+
+// pickDestinationBoard lists the columns of the picked board and selects
+// the one named like the task's current column.
+func (m *Model) pickDestinationBoard(id string) error {
+	d := m.destination
+	var picked *destinationBoard
+	for i := range d.boards {
+		if d.boards[i].board.ID == id {
+			picked = &d.boards[i]
+			break
+		}
+	}
+	if picked == nil {
+		return fmt.Errorf("no board selected")
+	}
+
+	columns, err := m.contextService.ListColumns(context.Background(), id)
+	if err != nil {
+		return err
+	}
+	if len(columns) == 0 {
+		return fmt.Errorf("%s has no columns", picked.label)
+	}
+	sort.Slice(columns, func(i, j int) bool {
+		return columns[i].Position < columns[j].Position
+	})
+
+	d.board = picked
+	d.columns = columns
+	m.contextFilter.SetValue("")
+	m.contextSelected = 0
+
+	current := ""
+	if d.task.ColumnID != nil {
+		for _, c := range m.columns {
+			if c.ID == *d.task.ColumnID {
+				current = strings.TrimSpace(c.Name)
+				break
+			}
+		}
+	}
+	for i, c := range columns {
+		if current != "" && strings.EqualFold(strings.TrimSpace(c.Name), current) {
+			m.contextSelected = i
+			break
+		}
+	}
+	return nil
+}
+
+func (m Model) updateTaskDestination(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	d := m.destination
+	switch {
+	case key.Matches(msg, m.keys.Cancel):
+		if d.board == nil {
+			m.closeContextPanel()
+			return m, nil
+		}
+		d.board = nil
+		d.columns = nil
+		m.contextFilter.SetValue("")
+		m.contextSelected = 0
+		return m, nil
+	case key.Matches(msg, m.keys.Up):
+		m.contextSelected--
+		m.clampContextSelection()
+		return m, nil
+	case key.Matches(msg, m.keys.Down):
+		m.contextSelected++
+		m.clampContextSelection()
+		return m, nil
+	case msg.String() == "tab":
+		if d.copying {
+			d.withComments = !d.withComments
+		}
+		return m, nil
+	case key.Matches(msg, m.keys.Confirm):
+		id := m.selectedContextID()
+		if id == "" {
+			return m, nil
+		}
+		if d.board == nil {
+			if err := m.pickDestinationBoard(id); err != nil {
+				m.statusLine = err.Error()
+			}
+			return m, textinput.Blink
+		}
+		dest := application.TaskDestination{WorkspaceID: d.board.workspaceID, BoardID: d.board.board.ID, ColumnID: id}
+		where := d.board.label + " / " + m.destinationNameByID(id)
+		m.closeContextPanel()
+		if d.copying {
+			return m, m.copyTaskCmd(d.task, dest, d.withComments, where)
+		}
+		return m, m.transferTaskCmd(d.task, dest, where)
+	}
+
+	var cmd tea.Cmd
+	m.contextFilter, cmd = m.contextFilter.Update(msg)
+	m.clampContextSelection()
+	return m, cmd
+}
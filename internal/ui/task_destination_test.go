@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+func newDestinationTestModel() Model {
+	repo := &mockSetupRepo{
+		boards: []domain.Board{{ID: "b1", Name: "Main"}, {ID: "b2", Name: "Release"}},
+		columns: []domain.Column{
+			{ID: "r-done", Name: "Done", Position: 3},
+			{ID: "r-todo", Name: "Todo", Position: 1},
+			{ID: "r-doing", Name: "Doing", Position: 2},
+		},
+	}
+	model := newMockModelWithContextService(repo)
+	doing := "col-2"
+	model.workspaceID = "ws1"
+	model.boardID = "b1"
+	model.workspaces = []domain.Workspace{{ID: "ws1", Name: "Work"}}
+	model.columns = []domain.Column{{ID: "col-1", Name: "Todo"}, {ID: doing, Name: "Doing"}}
+	model.tasks = []domain.Task{{ID: "t1", Title: "Ship it", ColumnID: &doing}}
+	return model
+}
+
+func TestOpenTaskDestination_MoveSkipsCurrentBoard(t *testing.T) {
+	m := newDestinationTestModel()
+	if err := m.openTaskDestination(false); err != nil {
+		t.Fatalf("openTaskDestination() error = %v", err)
+	}
+	if !m.showContexts || m.contextMode != contextDestination {
+		t.Fatalf("expected destination picker to be open")
+	}
+	items := m.contextItems()
+	if len(items) != 1 || items[0] != "b2" {
+		t.Errorf("items = %v, want [b2]", items)
+	}
+	if got := m.contextNameByID("b2"); got != "Work / Release" {
+		t.Errorf("contextNameByID() = %q, want %q", got, "Work / Release")
+	}
+}
+
+func TestOpenTaskDestination_CopyNeedsService(t *testing.T) {
+	m := newDestinationTestModel()
+	if err := m.openTaskDestination(true); err != nil {
+		t.Fatalf("openTaskDestination() error = %v", err)
+	}
+	if m.showContexts {
+		t.Error("expected picker to stay closed without a copy service")
+	}
+}
+
+func TestTaskDestination_PickBoardSelectsMatchingColumn(t *testing.T) {
+	m := newDestinationTestModel()
+	if err := m.openTaskDestination(false); err != nil {
+		t.Fatalf("openTaskDestination() error = %v", err)
+	}
+	next, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = next.(Model)
+
+	if m.destination == nil || m.destination.board == nil {
+		t.Fatalf("expected the column step")
+	}
+	items := m.contextItems()
+	want := []string{"r-todo", "r-doing", "r-done"}
+	if len(items) != len(want) {
+		t.Fatalf("items = %v, want %v", items, want)
+	}
+	for i := range want {
+		if items[i] != want[i] {
+			t.Fatalf("items = %v, want %v", items, want)
+		}
+	}
+	if got := m.selectedContextID(); got != "r-doing" {
+		t.Errorf("selectedContextID() = %q, want r-doing", got)
+	}
+
+	// Esc goes back to the boards, and again closes the panel.
+	next, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = next.(Model)
+	if m.destination == nil || m.destination.board != nil {
+		t.Fatalf("expected the board step after esc")
+	}
+	next, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = next.(Model)
+	if m.showContexts || m.destination != nil {
+		t.Error("expected the picker to be closed")
+	}
+}
+
+func TestTaskDestination_ConfirmColumnReturnsCmd(t *testing.T) {
+	m := newDestinationTestModel()
+	if err := m.openTaskDestination(false); err != nil {
+		t.Fatalf("openTaskDestination() error = %v", err)
+	}
+	next, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = next.(Model)
+	next, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = next.(Model)
+	if cmd == nil {
+		t.Error("expected a move command")
+	}
+	if m.showContexts {
+		t.Error("expected the picker to close")
+	}
+}
@@ -278,6 +278,22 @@ func TestSortTasks_ByCreated(t *testing.T) {
 	}
 }
 
+func TestSortTasks_Manual(t *testing.T) {
+	now := time.Now().UTC()
+	tasks := []domain.Task{
+		{ID: "t1", Position: 2048, CreatedAt: now},
+		{ID: "t2", Position: 1024, CreatedAt: now},
+		{ID: "t3", Position: 2048, CreatedAt: now.Add(-time.Hour)},
+	}
+	fs := taskFilterState{sortMode: sortByManual}
+	fs.sortTasks(tasks)
+	want := []string{"t2", "t3", "t1"}
+	got := ids(tasks)
+	if !sliceEq(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
 func TestSortTasks_IsStable(t *testing.T) {
 	now := time.Now().UTC()
 	tasks := []domain.Task{
@@ -31,8 +31,9 @@ const (
 type taskForm struct {
 	mode taskFormMode
 
-	taskID string
-	focus  int
+	taskID  string
+	version int
+	focus   int
 
 	title       textinput.Model
 	description textinput.Model
@@ -178,6 +179,7 @@ func (m *Model) startEditTaskForm(task domain.Task) {
 	form := &taskForm{
 		mode:            taskFormEdit,
 		taskID:          task.ID,
+		version:         task.Version,
 		title:           newTaskFormInput("Title", task.Title, 512),
 		description:     newTaskFormInput("Description", summarizeDescription(task.DescriptionMD), 2048),
 		dueDate:         newTaskFormInput(m.dueDatePlaceholder(), due, 32),
@@ -400,6 +402,7 @@ func (m Model) submitTaskFormCmd() (tea.Cmd, error) {
 
 	return m.updateTaskWithDetailsCmd(
 		m.taskForm.taskID,
+		m.taskForm.version,
 		&title,
 		&description,
 		&priority,
@@ -13,9 +13,19 @@ func (m Model) handleOpResult(msg opResultMsg) (Model, tea.Cmd) {
 		m.err = msg.err
 		m.statusLine = msg.err.Error()
 		m.clearTaskViewerReturn()
+		if msg.overwrite != nil {
+			m.pendingOverwrite = msg.overwrite
+			m.statusLine = "task changed elsewhere: o overwrite, r reload"
+		}
 		return m, nil
 	}
 	m.statusLine = ""
+	if msg.clearMarks {
+		m.clearMarks()
+	}
+	if msg.toast {
+		m.statusLine = msg.status
+	}
 	if m.viewMode == viewKanban && strings.TrimSpace(msg.taskID) != "" && strings.TrimSpace(msg.columnID) != "" {
 		m.pendingKanbanTaskID = msg.taskID
 		m.pendingKanbanColumnID = msg.columnID
@@ -27,5 +37,8 @@ func (m Model) handleOpResult(msg opResultMsg) (Model, tea.Cmd) {
 		commentsCmd := m.openTaskViewerByID(taskID)
 		return m, tea.Batch(m.loadTasksCmd(), commentsCmd)
 	}
+	if m.viewMode == viewList && strings.TrimSpace(msg.taskID) != "" {
+		return m, m.reloadTasksCmd()
+	}
 	return m, m.loadTasksCmd()
 }
@@ -2,26 +2,93 @@ package ui
 
 import (
 	"context"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/tiagokriok/kanji/internal/application"
+	"github.com/tiagokriok/kanji/internal/domain"
 )
 
 // loadTasksCmd returns a command that loads tasks for the current workspace and board
-// using the active filter state. The result is delivered as a tasksLoadedMsg.
+// using the active filter state, along with the IDs of tasks holding a sync conflict.
+// With a search service, the search text runs as a full-text search and the
+// match snippets come along. With a label service, so do the label colors.
+// The result is delivered as a tasksLoadedMsg.
 func (m Model) loadTasksCmd() tea.Cmd {
 	filters := application.ListTaskFilters{
 		WorkspaceID: m.workspaceID,
 		BoardID:     m.boardID,
 		TitleQuery:  m.titleFilter,
 		ColumnID:    m.columnFilter,
+		Filter:      m.filterExpr,
 	}
 	flow := m.taskFlow
+	search := m.searchService
+	engine := m.syncEngine
+	labels := m.labelService
 	return func() tea.Msg {
-		tasks, err := flow.ListTasks(context.Background(), filters)
-		return tasksLoadedMsg{tasks: tasks, err: err}
+		var msg tasksLoadedMsg
+		if search != nil && strings.TrimSpace(filters.TitleQuery) != "" {
+			msg.tasks, msg.snippets, msg.err = searchTasks(search, flow, filters)
+		} else {
+			msg.tasks, msg.err = flow.ListTasks(context.Background(), filters)
+		}
+		if msg.err == nil && labels != nil {
+			msg.labelColors, msg.err = labels.LabelColors(context.Background(), filters.WorkspaceID)
+		}
+		if msg.err == nil && filters.BoardID != "" {
+			msg.columnCounts, msg.err = flow.CountTasksByColumn(context.Background(), filters.WorkspaceID, filters.BoardID)
+		}
+		if msg.err != nil || engine == nil {
+			return msg
+		}
+		msg.conflicts, msg.err = engine.ConflictedTaskIDs(context.Background())
+		return msg
+	}
+}
+
+// searchTasks runs the search text of filters against the full-text index
+// and returns the matching tasks with their snippets keyed by task ID. A
+// filter expression narrows the hits to the tasks flow lists for it.
+func searchTasks(search *application.SearchService, flow *application.TaskFlow, filters application.ListTaskFilters) ([]domain.Task, map[string]string, error) {
+	hits, err := search.Search(context.Background(), domain.SearchFilter{
+		WorkspaceID: filters.WorkspaceID,
+		BoardID:     filters.BoardID,
+		Query:       filters.TitleQuery,
+	})
+	if err != nil {
+		return nil, nil, err
 	}
+	var allowed map[string]bool
+	if strings.TrimSpace(filters.Filter) != "" {
+		listed, err := flow.ListTasks(context.Background(), application.ListTaskFilters{
+			WorkspaceID: filters.WorkspaceID,
+			BoardID:     filters.BoardID,
+			ColumnID:    filters.ColumnID,
+			Filter:      filters.Filter,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		allowed = make(map[string]bool, len(listed))
+		for _, task := range listed {
+			allowed[task.ID] = true
+		}
+	}
+	tasks := make([]domain.Task, 0, len(hits))
+	snippets := make(map[string]string, len(hits))
+	for _, hit := range hits {
+		if filters.ColumnID != "" && (hit.Task.ColumnID == nil || *hit.Task.ColumnID != filters.ColumnID) {
+			continue
+		}
+		if allowed != nil && !allowed[hit.Task.ID] {
+			continue
+		}
+		tasks = append(tasks, hit.Task)
+		snippets[hit.Task.ID] = hit.Snippet
+	}
+	return tasks, snippets, nil
 }
 
 // loadCommentsCmd returns a command that loads comments for the given task ID.
@@ -34,9 +101,22 @@ func (m Model) loadCommentsCmd(taskID string) tea.Cmd {
 	}
 }
 
+// loadHistoryCmd returns a command that loads the history of the given task
+// ID. The result is delivered as a historyLoadedMsg.
+func (m Model) loadHistoryCmd(taskID string) tea.Cmd {
+	service := m.historyService
+	if service == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		events, err := service.TaskHistory(context.Background(), taskID)
+		return historyLoadedMsg{events: events, err: err}
+	}
+}
+
 // handleTasksLoaded processes a tasksLoadedMsg, updating tasks with active filters and sort.
-// When restoreKanban is true, it attempts to restore pending kanban selection before
-// falling back to ensureSelection. When refreshDetails is true, it loads comments for
+// When restoreKanban is true, it attempts to restore pending kanban selection, then
+// msg.selectTaskID, before falling back to ensureSelection. When refreshDetails is true, it loads comments for
 // the current task if details are visible, or clears cached comments otherwise.
 func (m Model) handleTasksLoaded(msg tasksLoadedMsg, restoreKanban, refreshDetails bool) (Model, tea.Cmd) {
 	if msg.err != nil {
@@ -44,13 +124,17 @@ func (m Model) handleTasksLoaded(msg tasksLoadedMsg, restoreKanban, refreshDetai
 		m.statusLine = msg.err.Error()
 		return m, nil
 	}
+	m.conflicts = msg.conflicts
+	m.searchSnippets = msg.snippets
+	m.labelColors = msg.labelColors
+	m.columnCounts = msg.columnCounts
 	m.tasks = m.applyActiveFilters(msg.tasks)
 	m.sortTasks(m.tasks)
-	if restoreKanban {
-		if !m.restorePendingKanbanSelection() {
-			m.ensureSelection()
-		}
-	} else {
+	m.pruneMarks()
+	switch {
+	case restoreKanban && m.restorePendingKanbanSelection():
+	case msg.selectTaskID != "" && m.selectTask(msg.selectTaskID):
+	default:
 		m.ensureSelection()
 	}
 	if refreshDetails {
@@ -92,3 +176,15 @@ func (m Model) handleCommentsLoaded(msg commentsLoadedMsg) (Model, tea.Cmd) {
 	m.comments = msg.comments
 	return m, nil
 }
+
+// handleHistoryLoaded processes a historyLoadedMsg, updating the cached
+// task history.
+func (m Model) handleHistoryLoaded(msg historyLoadedMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		m.err = msg.err
+		m.statusLine = msg.err.Error()
+		return m, nil
+	}
+	m.history = msg.events
+	return m, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/tiagokriok/kanji/internal/application"
 	"github.com/tiagokriok/kanji/internal/domain"
 )
 
@@ -61,6 +62,46 @@ func TestLoadTasksCmd_Success(t *testing.T) {
 	}
 }
 
+// columnFilterRepo honours the column filter of List.
+type columnFilterRepo struct {
+	fakeTaskRepoForCommands
+	tasks []domain.Task
+}
+
+func (r *columnFilterRepo) List(ctx context.Context, filter domain.TaskFilter) ([]domain.Task, error) {
+	var out []domain.Task
+	for _, task := range r.tasks {
+		if filter.ColumnID == "" || (task.ColumnID != nil && *task.ColumnID == filter.ColumnID) {
+			out = append(out, task)
+		}
+	}
+	return out, nil
+}
+
+func TestLoadTasksCmd_CountsColumnsUnfiltered(t *testing.T) {
+	todo, doing := "col-1", "col-2"
+	repo := &columnFilterRepo{tasks: []domain.Task{
+		{ID: "t1", ColumnID: &todo},
+		{ID: "t2", ColumnID: &doing},
+		{ID: "t3", ColumnID: &doing},
+	}}
+	m := newTestModelForLoad(repo, &fakeCommentRepoForLoad{})
+	m.columnFilter = todo
+
+	loaded := m.loadTasksCmd()().(tasksLoadedMsg)
+	if loaded.err != nil || len(loaded.tasks) != 1 {
+		t.Fatalf("tasks = %v, err %v; want only t1", loaded.tasks, loaded.err)
+	}
+	m, _ = m.handleTasksLoaded(loaded, false, false)
+	// The hidden tasks still count towards the WIP limit of their column.
+	if got := m.columnTaskCount(doing, len(m.tasksForColumn(doing))); got != 2 {
+		t.Fatalf("count of %s = %d, want 2", doing, got)
+	}
+	if got := m.columnTaskCount(todo, len(m.tasksForColumn(todo))); got != 1 {
+		t.Fatalf("count of %s = %d, want 1", todo, got)
+	}
+}
+
 func TestLoadTasksCmd_Error(t *testing.T) {
 	repo := &fakeTaskRepoForLoad{listErr: errTest("list failed")}
 	m := newTestModelForLoad(repo, &fakeCommentRepoForLoad{})
@@ -300,3 +341,46 @@ func TestRefreshDetails_HideDetails(t *testing.T) {
 		t.Error("expected comments to be cleared when showDetails is false")
 	}
 }
+
+type fakeSearchRepo struct {
+	hits   []domain.SearchHit
+	filter domain.SearchFilter
+}
+
+func (r *fakeSearchRepo) Search(ctx context.Context, filter domain.SearchFilter) ([]domain.SearchHit, error) {
+	r.filter = filter
+	return r.hits, nil
+}
+
+func TestLoadTasksCmd_SearchServiceRunsFullTextSearch(t *testing.T) {
+	done, doing := "col-done", "col-doing"
+	search := &fakeSearchRepo{hits: []domain.SearchHit{
+		{Task: domain.Task{ID: "t1", Title: "Task 1", ColumnID: &done}, Snippet: "the \x02vendor\x03 contract"},
+		{Task: domain.Task{ID: "t2", Title: "Task 2", ColumnID: &doing}, Snippet: "\x02vendor\x03"},
+	}}
+	repo := &fakeTaskRepoForLoad{listTasks: []domain.Task{{ID: "t3", Title: "Listed"}}}
+	m := newTestModelForLoad(repo, &fakeCommentRepoForLoad{})
+	m.SetSearchService(application.NewSearchService(search))
+	m.titleFilter = "vendor"
+	m.columnFilter = done
+
+	loaded, ok := m.loadTasksCmd()().(tasksLoadedMsg)
+	if !ok || loaded.err != nil {
+		t.Fatalf("unexpected result %#v", loaded)
+	}
+	if search.filter.Query != "vendor" || search.filter.BoardID != "board-1" {
+		t.Errorf("search filter = %+v", search.filter)
+	}
+	if len(loaded.tasks) != 1 || loaded.tasks[0].ID != "t1" {
+		t.Errorf("tasks = %v, want only t1 from the filtered column", loaded.tasks)
+	}
+	if loaded.snippets["t1"] != "the \x02vendor\x03 contract" {
+		t.Errorf("snippets = %v", loaded.snippets)
+	}
+
+	m.titleFilter = ""
+	loaded = m.loadTasksCmd()().(tasksLoadedMsg)
+	if len(loaded.tasks) != 1 || loaded.tasks[0].ID != "t3" || loaded.snippets != nil {
+		t.Errorf("without search text tasks should be listed, got %v", loaded.tasks)
+	}
+}
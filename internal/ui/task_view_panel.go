@@ -145,10 +145,18 @@ func (m Model) renderTaskViewerLeftLines(task domain.Task, width, height, scroll
 		Bold(true).
 		Render(m.statusLabelForTask(task))
 
+	hint := "j/k or ↑/↓ scroll description | e edit | c comment | Enter/Esc close"
+	if m.historyService != nil {
+		pane := "activity"
+		if m.viewActivity {
+			pane = "comments"
+		}
+		hint = fmt.Sprintf("j/k or ↑/↓ scroll description | e edit | c comment | Tab %s | Enter/Esc close", pane)
+	}
 	lines := []string{
 		titleStyle.Render(truncate(task.Title, max(1, width))),
 		metaStyle.Render(fmt.Sprintf("%s | %s | %s", dueValue, priorityValue, statusValue)),
-		hintStyle.Render("j/k or ↑/↓ scroll description | e edit | c comment | Enter/Esc close"),
+		hintStyle.Render(hint),
 	}
 
 	descLines := renderViewerMarkdownLines(task.DescriptionMD, width)
@@ -168,6 +176,9 @@ func (m Model) renderTaskViewerLeftLines(task domain.Task, width, height, scroll
 }
 
 func (m Model) renderTaskViewerRightLines(width, height int) []string {
+	if m.viewActivity {
+		return m.renderTaskViewerActivityLines(width, height)
+	}
 	headerStyle := lipgloss.NewStyle().Width(width).Foreground(lipgloss.Color("231")).Bold(true).Align(lipgloss.Center)
 	commentMetaStyle := lipgloss.NewStyle().Width(width).Foreground(lipgloss.Color("245"))
 	commentBodyStyle := lipgloss.NewStyle().Width(width).Foreground(lipgloss.Color("252"))
@@ -218,6 +229,82 @@ func (m Model) renderTaskViewerRightLines(width, height int) []string {
 	return trimViewerLines(lines, width, height)
 }
 
+// renderTaskViewerActivityLines lists the task history, newest first.
+func (m Model) renderTaskViewerActivityLines(width, height int) []string {
+	headerStyle := lipgloss.NewStyle().Width(width).Foreground(lipgloss.Color("231")).Bold(true).Align(lipgloss.Center)
+	metaStyle := lipgloss.NewStyle().Width(width).Foreground(lipgloss.Color("245"))
+	changeStyle := lipgloss.NewStyle().Width(width).Foreground(lipgloss.Color("252"))
+	emptyStyle := lipgloss.NewStyle().Width(width).Foreground(lipgloss.Color("245"))
+
+	lines := []string{
+		headerStyle.Render("Activity"),
+		emptyStyle.Render(""),
+	}
+
+	if m.history == nil {
+		lines = append(lines, emptyStyle.Render("(loading...)"))
+		return trimViewerLines(lines, width, height)
+	}
+	if len(m.history) == 0 {
+		lines = append(lines, emptyStyle.Render("(none)"))
+		return trimViewerLines(lines, width, height)
+	}
+
+	for i := len(m.history) - 1; i >= 0; i-- {
+		event := m.history[i]
+		actor := "unknown"
+		if event.Actor != nil && strings.TrimSpace(*event.Actor) != "" {
+			actor = strings.TrimSpace(*event.Actor)
+		}
+		meta := fmt.Sprintf("%s %s  %s", actor, event.Kind, m.formatCommentDateTime(event.CreatedAt))
+		lines = append(lines, metaStyle.Render(ansi.Truncate(meta, max(1, width), "")))
+		for _, change := range event.Changes {
+			text := m.describeFieldChange(change)
+			lines = append(lines, changeStyle.Render("  "+ansi.Truncate(text, max(1, width-2), "…")))
+			if len(lines) >= height {
+				return lines[:height]
+			}
+		}
+		lines = append(lines, emptyStyle.Render(""))
+		if len(lines) >= height {
+			return lines[:height]
+		}
+	}
+
+	return trimViewerLines(lines, width, height)
+}
+
+// describeFieldChange renders one change on a line, showing column names
+// instead of IDs for columns of the current board.
+func (m Model) describeFieldChange(change domain.FieldChange) string {
+	value := func(v *string) string {
+		if v == nil || *v == "" {
+			return "-"
+		}
+		if change.Field == "column_id" {
+			for _, column := range m.columns {
+				if column.ID == *v {
+					return column.Name
+				}
+			}
+		}
+		return strings.ReplaceAll(*v, "\n", " ")
+	}
+	field := change.Field
+	switch field {
+	case "description":
+		return "description changed"
+	case "comment":
+		return value(change.New)
+	case "column_id":
+		field = "column"
+	}
+	if change.Old == nil {
+		return fmt.Sprintf("%s: %s", field, value(change.New))
+	}
+	return fmt.Sprintf("%s: %s → %s", field, value(change.Old), value(change.New))
+}
+
 func trimViewerLines(lines []string, width, height int) []string {
 	if len(lines) > height {
 		return lines[:height]
@@ -66,6 +66,24 @@ func (m *Model) openTaskViewerByID(taskID string) tea.Cmd {
 	return m.loadCommentsCmd(taskID)
 }
 
+// toggleTaskViewerActivity switches the right pane of the task viewer
+// between comments and activity, loading the history when it is shown.
+func (m *Model) toggleTaskViewerActivity() tea.Cmd {
+	if m.historyService == nil {
+		return nil
+	}
+	m.viewActivity = !m.viewActivity
+	if !m.viewActivity {
+		return nil
+	}
+	task, ok := m.viewerTask()
+	if !ok {
+		return nil
+	}
+	m.history = nil
+	return m.loadHistoryCmd(task.ID)
+}
+
 func (m *Model) closeTaskViewer() {
 	m.overlayState.closeTaskView()
 }
@@ -99,6 +117,8 @@ func (m Model) updateTaskViewer(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleTasksLoaded(msg, false, false)
 	case commentsLoadedMsg:
 		return m.handleCommentsLoaded(msg)
+	case historyLoadedMsg:
+		return m.handleHistoryLoaded(msg)
 	case tea.KeyMsg:
 		switch {
 		case key.Matches(msg, m.keys.Cancel), key.Matches(msg, m.keys.Confirm), key.Matches(msg, m.keys.OpenDetails):
@@ -123,6 +143,8 @@ func (m Model) updateTaskViewer(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.setTaskViewerReturn(task.ID)
 			m.closeTaskViewer()
 			return m, m.startAddComment()
+		case key.Matches(msg, m.keys.ToggleView):
+			return m, m.toggleTaskViewerActivity()
 		case key.Matches(msg, m.keys.Up):
 			m.viewDescScroll = scrollUp(m.viewDescScroll)
 			return m, nil
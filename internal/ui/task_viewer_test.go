@@ -1,10 +1,13 @@
 package ui
 
 import (
+	"context"
+	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/tiagokriok/kanji/internal/application"
 	"github.com/tiagokriok/kanji/internal/domain"
 )
 
@@ -567,3 +570,62 @@ type errTest string
 func (e errTest) Error() string {
 	return string(e)
 }
+
+type fakeTaskEventRepo struct {
+	events []domain.TaskEvent
+}
+
+func (f *fakeTaskEventRepo) ListByTask(_ context.Context, _ string) ([]domain.TaskEvent, error) {
+	return f.events, nil
+}
+
+func (f *fakeTaskEventRepo) ListActivity(_ context.Context, _ domain.ActivityFilter) ([]domain.TaskEvent, error) {
+	return f.events, nil
+}
+
+func TestUpdateTaskViewer_TabShowsActivity(t *testing.T) {
+	todo, done := "c1", "c2"
+	actor := "alice"
+	repo := &fakeTaskEventRepo{events: []domain.TaskEvent{
+		{Kind: domain.TaskEventCreated, Actor: &actor},
+		{Kind: domain.TaskEventMoved, Actor: &actor, Changes: []domain.FieldChange{{Field: "column_id", Old: &todo, New: &done}}},
+	}}
+	m := Model{
+		keys:         newKeyMap(),
+		overlayState: overlayState{showTaskView: true, viewTaskID: "t1"},
+		tasks:        []domain.Task{{ID: "t1", Title: "Task"}},
+		columns:      []domain.Column{{ID: "c1", Name: "Todo"}, {ID: "c2", Name: "Done"}},
+		width:        120,
+		height:       40,
+	}
+
+	newM, cmd := m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if newM.(Model).viewActivity || cmd != nil {
+		t.Fatal("expected Tab to do nothing without a history service")
+	}
+
+	m.SetHistoryService(application.NewHistoryService(repo))
+	newM, cmd = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m = newM.(Model)
+	if !m.viewActivity || cmd == nil {
+		t.Fatalf("expected the activity tab to load: viewActivity=%v cmd=%v", m.viewActivity, cmd != nil)
+	}
+	newM, _ = m.Update(cmd())
+	m = newM.(Model)
+	if len(m.history) != 2 {
+		t.Fatalf("history = %d events, want 2", len(m.history))
+	}
+
+	rendered := strings.Join(m.renderTaskViewerRightLines(60, 20), "\n")
+	if !strings.Contains(rendered, "column: Todo → Done") || !strings.Contains(rendered, "alice moved") {
+		t.Fatalf("activity pane is missing the move:\n%s", rendered)
+	}
+	if strings.Index(rendered, "alice moved") > strings.Index(rendered, "alice created") {
+		t.Fatal("expected the newest event first")
+	}
+
+	newM, cmd = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if newM.(Model).viewActivity || cmd != nil {
+		t.Fatal("expected Tab to switch back to comments")
+	}
+}
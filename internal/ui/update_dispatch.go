@@ -20,6 +20,9 @@ func (m Model) dispatchOverlayUpdate(msg tea.Msg) (tea.Model, tea.Cmd, bool) {
 	case overlayContexts:
 		model, cmd := m.updateContextPanel(msg)
 		return model, cmd, true
+	case overlaySavedViews:
+		model, cmd := m.updateSavedViewPanel(msg)
+		return model, cmd, true
 	case overlayInput:
 		model, cmd := m.updateInputMode(msg)
 		return model, cmd, true
@@ -63,6 +66,15 @@ func (m Model) handleDeleteConfirmKey(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
 	if !m.confirmingDelete {
 		return m, nil, false
 	}
+	if msg.String() == "y" && len(m.marked) > 0 {
+		ids := make([]string, 0, len(m.marked))
+		for _, task := range m.bulkTargets() {
+			ids = append(ids, task.ID)
+		}
+		m.confirmingDelete = false
+		m.statusLine = ""
+		return m, m.bulkDeleteCmd(ids), true
+	}
 	if msg.String() == "y" {
 		if task, ok := m.currentTask(); ok {
 			m.confirmingDelete = false
@@ -74,3 +86,25 @@ func (m Model) handleDeleteConfirmKey(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
 	m.statusLine = ""
 	return m, nil, true
 }
+
+// handleOverwriteConfirmKey answers the prompt shown after a save conflicted
+// with a change made elsewhere: "o" saves anyway, "r" or esc drops the edit
+// and reloads. Other keys leave the prompt open, except ctrl+c, which still
+// quits. It returns (model, cmd, true) when the prompt took the key.
+func (m Model) handleOverwriteConfirmKey(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	if m.pendingOverwrite == nil || msg.Type == tea.KeyCtrlC {
+		return m, nil, false
+	}
+	switch {
+	case msg.String() == "o":
+		overwrite := m.pendingOverwrite
+		m.pendingOverwrite = nil
+		m.statusLine = ""
+		return m, overwrite, true
+	case msg.String() == "r" || msg.Type == tea.KeyEsc:
+		m.pendingOverwrite = nil
+		m.statusLine = ""
+		return m, m.reloadTasksCmd(), true
+	}
+	return m, nil, true
+}
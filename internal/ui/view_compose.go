@@ -54,6 +54,9 @@ func (m Model) wrapOverlays(base string) string {
 	if m.showContexts {
 		return m.renderContextPanel(base)
 	}
+	if m.showSavedViews {
+		return m.renderSavedViewPanel(base)
+	}
 	base = m.renderTaskFormOverlay(base)
 	if m.showTaskView {
 		return m.renderTaskViewerPanel(base)
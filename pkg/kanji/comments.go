@@ -0,0 +1,81 @@
+package kanji
+
+import (
+	"context"
+	"strings"
+
+	"github.com/tiagokriok/kanji/internal/application"
+)
+
+// ListComments returns the comments of a task, oldest first.
+func (c *Client) ListComments(ctx context.Context, taskID string) ([]Comment, error) {
+	if _, err := c.GetTask(ctx, taskID); err != nil {
+		return nil, err
+	}
+	comments, err := c.comments.ListComments(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Comment, len(comments))
+	for i, comment := range comments {
+		out[i] = newComment(comment)
+	}
+	return out, nil
+}
+
+// GetComment returns a comment of a task.
+func (c *Client) GetComment(ctx context.Context, taskID, id string) (Comment, error) {
+	comments, err := c.ListComments(ctx, taskID)
+	if err != nil {
+		return Comment{}, err
+	}
+	for _, comment := range comments {
+		if comment.ID == id {
+			return comment, nil
+		}
+	}
+	return Comment{}, notFound("comment", id)
+}
+
+// AddComment adds a markdown comment to a task. author may be empty.
+func (c *Client) AddComment(ctx context.Context, taskID, body, author string) (Comment, error) {
+	if strings.TrimSpace(body) == "" {
+		return Comment{}, invalid("comment body is required")
+	}
+	task, err := c.tasks.GetTask(ctx, taskID)
+	if err != nil {
+		return Comment{}, taskError(taskID, err)
+	}
+	input := application.AddCommentInput{
+		TaskID:     task.ID,
+		ProviderID: task.ProviderID,
+		BodyMD:     body,
+	}
+	if author != "" {
+		input.Author = &author
+	}
+	comment, err := c.comments.AddComment(ctx, input)
+	if err != nil {
+		return Comment{}, err
+	}
+	return newComment(comment), nil
+}
+
+// UpdateComment replaces the body of a comment.
+func (c *Client) UpdateComment(ctx context.Context, taskID, id, body string) error {
+	if strings.TrimSpace(body) == "" {
+		return invalid("comment body is required")
+	}
+	if _, err := c.GetComment(ctx, taskID, id); err != nil {
+		return err
+	}
+	return c.comments.UpdateComment(ctx, id, body, nil)
+}
+
+// DeleteComment deletes a comment of a task.
+func (c *Client) DeleteComment(ctx context.Context, taskID, id string) error {
+	if _, err := c.GetComment(ctx, taskID, id); err != nil {
+		return err
+	}
+	return c.comments.DeleteComment(ctx, id)
+}
@@ -0,0 +1,46 @@
+package kanji
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/tiagokriok/kanji/internal/application"
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+var (
+	// ErrNotFound reports an unknown workspace, board, column, task or comment.
+	ErrNotFound = errors.New("not found")
+	// ErrInvalid reports an argument the operation cannot accept.
+	ErrInvalid = errors.New("invalid argument")
+	// ErrWIPLimitExceeded reports a column at its WIP limit; retry with Force.
+	ErrWIPLimitExceeded = application.ErrWIPLimitExceeded
+	// ErrHookRejected reports a change vetoed by a local pre-* hook.
+	ErrHookRejected = application.ErrHookRejected
+	// ErrVersionConflict reports a task changed since the version a
+	// TaskPatch.IfVersion or MoveTaskOptions.IfVersion expected.
+	ErrVersionConflict = domain.ErrVersionConflict
+)
+
+func notFound(kind, id string) error {
+	return fmt.Errorf("%s %q: %w", kind, id, ErrNotFound)
+}
+
+// invalidError is an ErrInvalid with its own message.
+type invalidError string
+
+func (e invalidError) Error() string        { return string(e) }
+func (e invalidError) Is(target error) bool { return target == ErrInvalid }
+
+func invalid(msg string) error {
+	return invalidError(msg)
+}
+
+// taskError turns a missing row into ErrNotFound.
+func taskError(id string, err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return notFound("task", id)
+	}
+	return err
+}
@@ -0,0 +1,37 @@
+package kanji_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/tiagokriok/kanji/pkg/kanji"
+)
+
+func Example() {
+	dir, err := os.MkdirTemp("", "kanji-example")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ctx := context.Background()
+	client, err := kanji.Open(ctx, filepath.Join(dir, "kanji.db"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer client.Close()
+
+	_, board, err := client.Bootstrap(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	task, err := client.CreateTask(ctx, kanji.CreateTaskInput{BoardID: board.ID, Title: "Ship the SDK"})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(task.Title, task.Status)
+	// Output: Ship the SDK todo
+}
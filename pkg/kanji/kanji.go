@@ -0,0 +1,80 @@
+// Package kanji embeds kanji in other Go programs.
+//
+// A Client opens a kanji database, migrating it to the current schema, and
+// offers typed operations on workspaces, boards, columns, tasks and comments.
+// Changes go through the same application services as the kanji CLI, so WIP
+// limits, local hooks and webhooks apply to them too.
+//
+// The package follows semantic versioning: exported identifiers are only
+// added, never changed or removed, within a major version. Types returned by
+// the Client are plain values owned by the caller.
+package kanji
+
+import (
+	"context"
+
+	"github.com/tiagokriok/kanji/internal/app"
+	"github.com/tiagokriok/kanji/internal/application"
+	"github.com/tiagokriok/kanji/internal/infrastructure/db"
+)
+
+// Client is an open kanji database. It is safe for concurrent use.
+type Client struct {
+	app             *app.App
+	bootstrap       *application.BootstrapService
+	context         *application.ContextService
+	tasks           *application.TaskService
+	flow            *application.TaskFlow
+	comments        *application.CommentService
+	workspaceDelete *application.WorkspaceDeleteService
+	boardDelete     *application.BoardDeleteService
+	columnDelete    *application.ColumnDeleteService
+}
+
+// DefaultPath returns the database path the kanji CLI uses by default.
+func DefaultPath() (string, error) {
+	return db.DefaultDBPath(db.DefaultAppName)
+}
+
+// Open opens the database at path, creating it if needed, and runs pending
+// migrations. An empty path opens DefaultPath.
+func Open(ctx context.Context, path string) (*Client, error) {
+	if path == "" {
+		var err error
+		if path, err = DefaultPath(); err != nil {
+			return nil, err
+		}
+	}
+	a, err := app.Open(ctx, app.Options{DBPath: path})
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		app:             a,
+		bootstrap:       a.BootstrapService,
+		context:         a.ContextService,
+		tasks:           a.TaskService,
+		flow:            a.TaskFlow,
+		comments:        a.CommentService,
+		workspaceDelete: a.WorkspaceDeleteService,
+		boardDelete:     a.BoardDeleteService,
+		columnDelete:    a.ColumnDeleteService,
+	}, nil
+}
+
+// Close sends queued webhook deliveries, then closes the database.
+// Deliveries that fail stay queued for a later run.
+func (c *Client) Close() error {
+	return c.app.Close()
+}
+
+// Bootstrap ensures the default provider, workspace, board and columns
+// exist, like `kanji data bootstrap`, and returns the default workspace and
+// board.
+func (c *Client) Bootstrap(ctx context.Context) (Workspace, Board, error) {
+	setup, err := c.bootstrap.EnsureDefaultSetup(ctx)
+	if err != nil {
+		return Workspace{}, Board{}, err
+	}
+	return newWorkspace(setup.Workspace), newBoard(setup.Board), nil
+}
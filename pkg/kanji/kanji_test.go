@@ -0,0 +1,203 @@
+package kanji_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/tiagokriok/kanji/pkg/kanji"
+)
+
+// The Client API is covered by the compatibility promise: changing any of
+// these signatures breaks the build of this test.
+var _ interface {
+	Close() error
+	Bootstrap(context.Context) (kanji.Workspace, kanji.Board, error)
+	ListWorkspaces(context.Context) ([]kanji.Workspace, error)
+	GetWorkspace(context.Context, string) (kanji.Workspace, error)
+	CreateWorkspace(context.Context, string) (kanji.Workspace, error)
+	RenameWorkspace(context.Context, string, string) error
+	DeleteWorkspace(context.Context, string) error
+	ListBoards(context.Context, string) ([]kanji.Board, error)
+	GetBoard(context.Context, string) (kanji.Board, error)
+	CreateBoard(context.Context, string, string) (kanji.Board, error)
+	RenameBoard(context.Context, string, string) error
+	DeleteBoard(context.Context, string) error
+	ListColumns(context.Context, string) ([]kanji.Column, error)
+	GetColumn(context.Context, string) (kanji.Column, error)
+	CreateColumn(context.Context, string, kanji.CreateColumnInput) (kanji.Column, error)
+	UpdateColumn(context.Context, string, kanji.ColumnPatch) error
+	DeleteColumn(context.Context, string, string) error
+	ListTasks(context.Context, kanji.TaskFilter) ([]kanji.Task, error)
+	GetTask(context.Context, string) (kanji.Task, error)
+	CreateTask(context.Context, kanji.CreateTaskInput) (kanji.Task, error)
+	UpdateTask(context.Context, string, kanji.TaskPatch) error
+	MoveTask(context.Context, string, string, kanji.MoveTaskOptions) error
+	DeleteTask(context.Context, string) error
+	ListComments(context.Context, string) ([]kanji.Comment, error)
+	GetComment(context.Context, string, string) (kanji.Comment, error)
+	AddComment(context.Context, string, string, string) (kanji.Comment, error)
+	UpdateComment(context.Context, string, string, string) error
+	DeleteComment(context.Context, string, string) error
+} = (*kanji.Client)(nil)
+
+func openTestClient(t *testing.T) *kanji.Client {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(dir, "config"))
+	client, err := kanji.Open(context.Background(), filepath.Join(dir, "kanji.db"))
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+func TestClient_TaskLifecycle(t *testing.T) {
+	client := openTestClient(t)
+	ctx := context.Background()
+
+	ws, board, err := client.Bootstrap(ctx)
+	if err != nil {
+		t.Fatalf("bootstrap: %v", err)
+	}
+	columns, err := client.ListColumns(ctx, board.ID)
+	if err != nil || len(columns) < 2 {
+		t.Fatalf("list columns: %v %+v", err, columns)
+	}
+	done := columns[len(columns)-1]
+
+	high := kanji.PriorityHigh
+	task, err := client.CreateTask(ctx, kanji.CreateTaskInput{
+		BoardID:  board.ID,
+		Title:    "Embed kanji",
+		Priority: &high,
+		Labels:   []string{"sdk"},
+	})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	if task.ColumnID != columns[0].ID || task.Priority != kanji.PriorityHigh || task.WorkspaceID != ws.ID {
+		t.Fatalf("unexpected task: %+v", task)
+	}
+
+	if err := client.MoveTask(ctx, task.ID, done.ID, kanji.MoveTaskOptions{}); err != nil {
+		t.Fatalf("move task: %v", err)
+	}
+	title := "Embed kanji everywhere"
+	if err := client.UpdateTask(ctx, task.ID, kanji.TaskPatch{Title: &title}); err != nil {
+		t.Fatalf("update task: %v", err)
+	}
+	comment, err := client.AddComment(ctx, task.ID, "works", "sdk")
+	if err != nil {
+		t.Fatalf("add comment: %v", err)
+	}
+
+	got, err := client.GetTask(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("get task: %v", err)
+	}
+	if got.Title != title || got.ColumnID != done.ID || len(got.Labels) != 1 {
+		t.Fatalf("unexpected task after changes: %+v", got)
+	}
+	tasks, err := client.ListTasks(ctx, kanji.TaskFilter{WorkspaceID: ws.ID, ColumnID: done.ID})
+	if err != nil || len(tasks) != 1 || tasks[0].ID != task.ID {
+		t.Fatalf("list tasks: %v %+v", err, tasks)
+	}
+	tasks, err = client.ListTasks(ctx, kanji.TaskFilter{WorkspaceID: ws.ID, Filter: "label:sdk and priority<=high"})
+	if err != nil || len(tasks) != 1 || tasks[0].ID != task.ID {
+		t.Fatalf("list tasks by filter: %v %+v", err, tasks)
+	}
+
+	if err := client.DeleteComment(ctx, task.ID, comment.ID); err != nil {
+		t.Fatalf("delete comment: %v", err)
+	}
+	if err := client.DeleteTask(ctx, task.ID); err != nil {
+		t.Fatalf("delete task: %v", err)
+	}
+	if _, err := client.GetTask(ctx, task.ID); !errors.Is(err, kanji.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestClient_Errors(t *testing.T) {
+	client := openTestClient(t)
+	ctx := context.Background()
+	_, board, err := client.Bootstrap(ctx)
+	if err != nil {
+		t.Fatalf("bootstrap: %v", err)
+	}
+
+	if _, err := client.CreateTask(ctx, kanji.CreateTaskInput{BoardID: board.ID}); !errors.Is(err, kanji.ErrInvalid) {
+		t.Fatalf("expected ErrInvalid for a missing title, got %v", err)
+	}
+	if _, err := client.ListTasks(ctx, kanji.TaskFilter{WorkspaceID: board.WorkspaceID, Filter: "label<bug"}); !errors.Is(err, kanji.ErrInvalid) {
+		t.Fatalf("expected ErrInvalid for a bad filter, got %v", err)
+	}
+	if _, err := client.ListBoards(ctx, "missing"); !errors.Is(err, kanji.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	columns, err := client.ListColumns(ctx, board.ID)
+	if err != nil {
+		t.Fatalf("list columns: %v", err)
+	}
+	limit := 1
+	if err := client.UpdateColumn(ctx, columns[0].ID, kanji.ColumnPatch{WIPLimit: &limit}); err != nil {
+		t.Fatalf("set wip limit: %v", err)
+	}
+	input := kanji.CreateTaskInput{BoardID: board.ID, Title: "One too many"}
+	if _, err := client.CreateTask(ctx, input); err != nil {
+		t.Fatalf("first task: %v", err)
+	}
+	if _, err := client.CreateTask(ctx, input); !errors.Is(err, kanji.ErrWIPLimitExceeded) {
+		t.Fatalf("expected ErrWIPLimitExceeded, got %v", err)
+	}
+	input.Force = true
+	if _, err := client.CreateTask(ctx, input); err != nil {
+		t.Fatalf("forced task: %v", err)
+	}
+
+	if err := client.DeleteColumn(ctx, columns[0].ID, ""); !errors.Is(err, kanji.ErrInvalid) {
+		t.Fatalf("expected ErrInvalid for a non-empty column, got %v", err)
+	}
+	if err := client.DeleteColumn(ctx, columns[0].ID, columns[1].ID); err != nil {
+		t.Fatalf("delete column: %v", err)
+	}
+}
+
+func TestClient_MoveTaskIfVersion(t *testing.T) {
+	client := openTestClient(t)
+	ctx := context.Background()
+	_, board, err := client.Bootstrap(ctx)
+	if err != nil {
+		t.Fatalf("bootstrap: %v", err)
+	}
+	columns, err := client.ListColumns(ctx, board.ID)
+	if err != nil || len(columns) < 2 {
+		t.Fatalf("list columns: %v %+v", err, columns)
+	}
+	task, err := client.CreateTask(ctx, kanji.CreateTaskInput{BoardID: board.ID, Title: "Versioned"})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+
+	stale := task.Version
+	title := "Versioned again"
+	if err := client.UpdateTask(ctx, task.ID, kanji.TaskPatch{Title: &title}); err != nil {
+		t.Fatalf("update task: %v", err)
+	}
+	err = client.MoveTask(ctx, task.ID, columns[1].ID, kanji.MoveTaskOptions{IfVersion: &stale})
+	if !errors.Is(err, kanji.ErrVersionConflict) {
+		t.Fatalf("expected ErrVersionConflict for a stale move, got %v", err)
+	}
+	got, err := client.GetTask(ctx, task.ID)
+	if err != nil || got.ColumnID != task.ColumnID {
+		t.Fatalf("stale move must leave the task in place: %v %+v", err, got)
+	}
+
+	if err := client.MoveTask(ctx, task.ID, columns[1].ID, kanji.MoveTaskOptions{IfVersion: &got.Version}); err != nil {
+		t.Fatalf("move at the current version: %v", err)
+	}
+}
@@ -0,0 +1,170 @@
+package kanji
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/tiagokriok/kanji/internal/application"
+)
+
+// ListTasks returns the tasks of a workspace, narrowed by filter.
+// filter.WorkspaceID is required.
+func (c *Client) ListTasks(ctx context.Context, filter TaskFilter) ([]Task, error) {
+	if _, err := c.GetWorkspace(ctx, filter.WorkspaceID); err != nil {
+		return nil, err
+	}
+	if filter.DueWithin < 0 {
+		return nil, invalid("DueWithin must not be negative")
+	}
+	tasks, err := c.flow.ListTasks(ctx, application.ListTaskFilters{
+		WorkspaceID: filter.WorkspaceID,
+		BoardID:     filter.BoardID,
+		TitleQuery:  filter.Query,
+		ColumnID:    filter.ColumnID,
+		DueSoonDays: filter.DueWithin,
+		Filter:      filter.Filter,
+	})
+	if errors.Is(err, application.ErrInvalidTaskQuery) {
+		return nil, invalid("Filter: " + err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Task, len(tasks))
+	for i, t := range tasks {
+		out[i] = newTask(t)
+	}
+	return out, nil
+}
+
+// GetTask returns the task with the given ID.
+func (c *Client) GetTask(ctx context.Context, id string) (Task, error) {
+	task, err := c.tasks.GetTask(ctx, id)
+	if err != nil {
+		return Task{}, taskError(id, err)
+	}
+	return newTask(task), nil
+}
+
+// CreateTask creates a task on a board. It fails with ErrWIPLimitExceeded
+// when the column is full, unless input.Force is set.
+func (c *Client) CreateTask(ctx context.Context, input CreateTaskInput) (Task, error) {
+	if strings.TrimSpace(input.Title) == "" {
+		return Task{}, invalid("title is required")
+	}
+	board, err := c.GetBoard(ctx, input.BoardID)
+	if err != nil {
+		return Task{}, err
+	}
+	ws, err := c.GetWorkspace(ctx, board.WorkspaceID)
+	if err != nil {
+		return Task{}, err
+	}
+	columns, err := c.context.ListColumns(ctx, board.ID)
+	if err != nil {
+		return Task{}, err
+	}
+	var column *Column
+	for _, col := range columns {
+		if input.ColumnID == "" || col.ID == input.ColumnID {
+			found := newColumn(col)
+			column = &found
+			break
+		}
+	}
+	if column == nil {
+		if input.ColumnID == "" {
+			return Task{}, invalid("board has no columns")
+		}
+		return Task{}, notFound("column", input.ColumnID)
+	}
+
+	priority := PriorityMedium
+	if input.Priority != nil {
+		priority = *input.Priority
+	}
+	if err := validPriority(priority); err != nil {
+		return Task{}, err
+	}
+	status := strings.ToLower(column.Name)
+	task, err := c.tasks.CreateTask(ctx, application.CreateTaskInput{
+		ProviderID:    ws.ProviderID,
+		WorkspaceID:   ws.ID,
+		BoardID:       &board.ID,
+		ColumnID:      &column.ID,
+		Title:         input.Title,
+		DescriptionMD: input.Description,
+		Status:        &status,
+		Priority:      int(priority),
+		DueAt:         input.DueAt,
+		Labels:        input.Labels,
+		Force:         input.Force,
+	})
+	if err != nil {
+		return Task{}, err
+	}
+	return newTask(task), nil
+}
+
+// UpdateTask applies patch to a task.
+func (c *Client) UpdateTask(ctx context.Context, id string, patch TaskPatch) error {
+	if _, err := c.GetTask(ctx, id); err != nil {
+		return err
+	}
+	if patch.Title != nil && strings.TrimSpace(*patch.Title) == "" {
+		return invalid("title cannot be empty")
+	}
+	input := application.UpdateTaskInput{
+		Title:         patch.Title,
+		DescriptionMD: patch.Description,
+		DueAt:         patch.DueAt,
+		ClearDueAt:    patch.ClearDueAt,
+		Labels:        patch.Labels,
+		IfVersion:     patch.IfVersion,
+	}
+	if patch.Priority != nil {
+		if err := validPriority(*patch.Priority); err != nil {
+			return err
+		}
+		priority := int(*patch.Priority)
+		input.Priority = &priority
+	}
+	return c.tasks.UpdateTask(ctx, id, input)
+}
+
+// MoveTask moves a task to another column of its board. It fails with
+// ErrWIPLimitExceeded when the column is full, unless opts.Force is set.
+func (c *Client) MoveTask(ctx context.Context, id, columnID string, opts MoveTaskOptions) error {
+	task, err := c.GetTask(ctx, id)
+	if err != nil {
+		return err
+	}
+	column, board, err := c.column(ctx, columnID)
+	if err != nil {
+		return err
+	}
+	if task.BoardID != board.ID {
+		return invalid("column must be a column of the task's board")
+	}
+	status := strings.ToLower(column.Name)
+	return c.flow.MoveTaskWith(ctx, task.ID, &column.ID, &status, 0, application.MoveOptions{
+		Force:     opts.Force,
+		IfVersion: opts.IfVersion,
+	})
+}
+
+// DeleteTask deletes a task.
+func (c *Client) DeleteTask(ctx context.Context, id string) error {
+	if _, err := c.GetTask(ctx, id); err != nil {
+		return err
+	}
+	return c.tasks.DeleteTask(ctx, id)
+}
+
+func validPriority(p Priority) error {
+	if p < PriorityCritical || p > PriorityNone {
+		return invalid("priority must be between 0 and 5")
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package kanji
+
+import (
+	"time"
+
+	"github.com/tiagokriok/kanji/internal/domain"
+)
+
+// Priority orders tasks from PriorityCritical (most urgent) to PriorityNone.
+type Priority int
+
+const (
+	PriorityCritical Priority = 0
+	PriorityUrgent   Priority = 1
+	PriorityHigh     Priority = 2
+	PriorityMedium   Priority = 3
+	PriorityLow      Priority = 4
+	PriorityNone     Priority = 5
+)
+
+// Workspace groups boards. Every workspace belongs to a provider.
+type Workspace struct {
+	ID         string
+	ProviderID string
+	Name       string
+}
+
+// Board holds an ordered set of columns.
+type Board struct {
+	ID          string
+	WorkspaceID string
+	Name        string
+}
+
+// Column is a stage of a board. A nil WIPLimit means no limit.
+type Column struct {
+	ID       string
+	BoardID  string
+	Name     string
+	Color    string
+	Position int
+	WIPLimit *int
+}
+
+// Task is a card on a board.
+type Task struct {
+	ID          string
+	WorkspaceID string
+	BoardID     string
+	ColumnID    string
+	Title       string
+	Description string
+	Status      string
+	Priority    Priority
+	DueAt       *time.Time
+	Labels      []string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	// Version goes up with every change; see TaskPatch.IfVersion.
+	Version int
+}
+
+// Comment is a markdown note on a task. Author is empty when unknown.
+type Comment struct {
+	ID        string
+	TaskID    string
+	Body      string
+	Author    string
+	CreatedAt time.Time
+	Version   int
+}
+
+// TaskFilter narrows ListTasks. Zero fields do not filter.
+type TaskFilter struct {
+	WorkspaceID string
+	BoardID     string
+	ColumnID    string
+	// Query matches task titles.
+	Query string
+	// DueWithin keeps tasks due within that many days.
+	DueWithin int
+	// Filter is a filter expression such as
+	// "priority<=high and label:bug and due<7d", in the syntax of
+	// `kanji task list --filter`.
+	Filter string
+}
+
+// CreateTaskInput describes a new task.
+type CreateTaskInput struct {
+	BoardID string
+	// ColumnID defaults to the first column of the board.
+	ColumnID    string
+	Title       string
+	Description string
+	// Priority defaults to PriorityMedium.
+	Priority *Priority
+	DueAt    *time.Time
+	Labels   []string
+	// Force creates the task even if the column is at its WIP limit.
+	Force bool
+}
+
+// TaskPatch lists the task fields to change. Nil fields are left alone.
+type TaskPatch struct {
+	Title       *string
+	Description *string
+	Priority    *Priority
+	DueAt       *time.Time
+	ClearDueAt  bool
+	// Labels replaces all labels; an empty slice removes them.
+	Labels *[]string
+	// IfVersion makes the update fail with ErrVersionConflict unless the
+	// task is still at that version.
+	IfVersion *int
+}
+
+// MoveTaskOptions adjusts a MoveTask. The zero value is a plain move.
+type MoveTaskOptions struct {
+	// Force moves the task even if the column is at its WIP limit.
+	Force bool
+	// IfVersion makes the move fail with ErrVersionConflict unless the task
+	// is still at that version.
+	IfVersion *int
+}
+
+// CreateColumnInput describes a new column.
+type CreateColumnInput struct {
+	Name string
+	// Color is a #RRGGBB value; empty picks the next default color.
+	Color    string
+	WIPLimit *int
+}
+
+// ColumnPatch lists the column fields to change. Nil fields are left alone.
+type ColumnPatch struct {
+	Name          *string
+	Color         *string
+	WIPLimit      *int
+	ClearWIPLimit bool
+}
+
+func newWorkspace(ws domain.Workspace) Workspace {
+	return Workspace{ID: ws.ID, ProviderID: ws.ProviderID, Name: ws.Name}
+}
+
+func newBoard(b domain.Board) Board {
+	return Board{ID: b.ID, WorkspaceID: b.WorkspaceID, Name: b.Name}
+}
+
+func newColumn(c domain.Column) Column {
+	return Column{
+		ID:       c.ID,
+		BoardID:  c.BoardID,
+		Name:     c.Name,
+		Color:    c.Color,
+		Position: c.Position,
+		WIPLimit: c.WIPLimit,
+	}
+}
+
+func newTask(t domain.Task) Task {
+	task := Task{
+		ID:          t.ID,
+		WorkspaceID: t.WorkspaceID,
+		Title:       t.Title,
+		Description: t.DescriptionMD,
+		Priority:    Priority(t.Priority),
+		DueAt:       t.DueAt,
+		Labels:      append([]string{}, t.Labels...),
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+		Version:     t.Version,
+	}
+	if t.BoardID != nil {
+		task.BoardID = *t.BoardID
+	}
+	if t.ColumnID != nil {
+		task.ColumnID = *t.ColumnID
+	}
+	if t.Status != nil {
+		task.Status = *t.Status
+	}
+	return task
+}
+
+func newComment(c domain.Comment) Comment {
+	comment := Comment{ID: c.ID, TaskID: c.TaskID, Body: c.BodyMD, CreatedAt: c.CreatedAt, Version: c.Version}
+	if c.Author != nil {
+		comment.Author = *c.Author
+	}
+	return comment
+}
@@ -0,0 +1,250 @@
+package kanji
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ListWorkspaces returns all workspaces.
+func (c *Client) ListWorkspaces(ctx context.Context) ([]Workspace, error) {
+	workspaces, err := c.context.ListWorkspaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Workspace, len(workspaces))
+	for i, ws := range workspaces {
+		out[i] = newWorkspace(ws)
+	}
+	return out, nil
+}
+
+// GetWorkspace returns the workspace with the given ID.
+func (c *Client) GetWorkspace(ctx context.Context, id string) (Workspace, error) {
+	workspaces, err := c.ListWorkspaces(ctx)
+	if err != nil {
+		return Workspace{}, err
+	}
+	for _, ws := range workspaces {
+		if ws.ID == id {
+			return ws, nil
+		}
+	}
+	return Workspace{}, notFound("workspace", id)
+}
+
+// CreateWorkspace creates a workspace on the default provider, together with
+// a "Main" board with the default columns.
+func (c *Client) CreateWorkspace(ctx context.Context, name string) (Workspace, error) {
+	if strings.TrimSpace(name) == "" {
+		return Workspace{}, invalid("workspace name is required")
+	}
+	setup, err := c.bootstrap.EnsureDefaultSetup(ctx)
+	if err != nil {
+		return Workspace{}, err
+	}
+	ws, _, err := c.context.CreateWorkspace(ctx, setup.Provider.ID, strings.TrimSpace(name))
+	if err != nil {
+		return Workspace{}, err
+	}
+	return newWorkspace(ws), nil
+}
+
+// RenameWorkspace renames a workspace.
+func (c *Client) RenameWorkspace(ctx context.Context, id, name string) error {
+	if strings.TrimSpace(name) == "" {
+		return invalid("workspace name is required")
+	}
+	if _, err := c.GetWorkspace(ctx, id); err != nil {
+		return err
+	}
+	return c.context.RenameWorkspace(ctx, id, strings.TrimSpace(name))
+}
+
+// DeleteWorkspace deletes a workspace with all of its boards, columns, tasks
+// and comments.
+func (c *Client) DeleteWorkspace(ctx context.Context, id string) error {
+	if _, err := c.GetWorkspace(ctx, id); err != nil {
+		return err
+	}
+	return c.workspaceDelete.Delete(ctx, id)
+}
+
+// ListBoards returns the boards of a workspace.
+func (c *Client) ListBoards(ctx context.Context, workspaceID string) ([]Board, error) {
+	if _, err := c.GetWorkspace(ctx, workspaceID); err != nil {
+		return nil, err
+	}
+	boards, err := c.context.ListBoards(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Board, len(boards))
+	for i, b := range boards {
+		out[i] = newBoard(b)
+	}
+	return out, nil
+}
+
+// GetBoard returns the board with the given ID.
+func (c *Client) GetBoard(ctx context.Context, id string) (Board, error) {
+	workspaces, err := c.context.ListWorkspaces(ctx)
+	if err != nil {
+		return Board{}, err
+	}
+	for _, ws := range workspaces {
+		boards, err := c.context.ListBoards(ctx, ws.ID)
+		if err != nil {
+			return Board{}, err
+		}
+		for _, b := range boards {
+			if b.ID == id {
+				return newBoard(b), nil
+			}
+		}
+	}
+	return Board{}, notFound("board", id)
+}
+
+// CreateBoard creates a board with the default columns.
+func (c *Client) CreateBoard(ctx context.Context, workspaceID, name string) (Board, error) {
+	if _, err := c.GetWorkspace(ctx, workspaceID); err != nil {
+		return Board{}, err
+	}
+	if strings.TrimSpace(name) == "" {
+		return Board{}, invalid("board name is required")
+	}
+	b, err := c.context.CreateBoard(ctx, workspaceID, name)
+	if err != nil {
+		return Board{}, err
+	}
+	return newBoard(b), nil
+}
+
+// RenameBoard renames a board.
+func (c *Client) RenameBoard(ctx context.Context, id, name string) error {
+	if strings.TrimSpace(name) == "" {
+		return invalid("board name is required")
+	}
+	if _, err := c.GetBoard(ctx, id); err != nil {
+		return err
+	}
+	return c.context.RenameBoard(ctx, id, strings.TrimSpace(name))
+}
+
+// DeleteBoard deletes a board with its columns, tasks and comments.
+func (c *Client) DeleteBoard(ctx context.Context, id string) error {
+	if _, err := c.GetBoard(ctx, id); err != nil {
+		return err
+	}
+	return c.boardDelete.DeleteBoard(ctx, id)
+}
+
+// ListColumns returns the columns of a board in board order.
+func (c *Client) ListColumns(ctx context.Context, boardID string) ([]Column, error) {
+	if _, err := c.GetBoard(ctx, boardID); err != nil {
+		return nil, err
+	}
+	columns, err := c.context.ListColumns(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Column, len(columns))
+	for i, col := range columns {
+		out[i] = newColumn(col)
+	}
+	return out, nil
+}
+
+// GetColumn returns the column with the given ID.
+func (c *Client) GetColumn(ctx context.Context, id string) (Column, error) {
+	column, _, err := c.column(ctx, id)
+	return column, err
+}
+
+// column finds a column by ID together with its board.
+func (c *Client) column(ctx context.Context, id string) (Column, Board, error) {
+	workspaces, err := c.context.ListWorkspaces(ctx)
+	if err != nil {
+		return Column{}, Board{}, err
+	}
+	for _, ws := range workspaces {
+		boards, err := c.context.ListBoards(ctx, ws.ID)
+		if err != nil {
+			return Column{}, Board{}, err
+		}
+		for _, b := range boards {
+			columns, err := c.context.ListColumns(ctx, b.ID)
+			if err != nil {
+				return Column{}, Board{}, err
+			}
+			for _, col := range columns {
+				if col.ID == id {
+					return newColumn(col), newBoard(b), nil
+				}
+			}
+		}
+	}
+	return Column{}, Board{}, notFound("column", id)
+}
+
+// CreateColumn appends a column to a board.
+func (c *Client) CreateColumn(ctx context.Context, boardID string, input CreateColumnInput) (Column, error) {
+	if _, err := c.GetBoard(ctx, boardID); err != nil {
+		return Column{}, err
+	}
+	if strings.TrimSpace(input.Name) == "" {
+		return Column{}, invalid("column name is required")
+	}
+	col, err := c.context.CreateColumn(ctx, boardID, input.Name, input.Color, input.WIPLimit)
+	if err != nil {
+		return Column{}, err
+	}
+	return newColumn(col), nil
+}
+
+// UpdateColumn changes the name, color or WIP limit of a column.
+func (c *Client) UpdateColumn(ctx context.Context, id string, patch ColumnPatch) error {
+	if _, err := c.GetColumn(ctx, id); err != nil {
+		return err
+	}
+	if patch.Name != nil && strings.TrimSpace(*patch.Name) == "" {
+		return invalid("column name cannot be empty")
+	}
+	if patch.WIPLimit != nil && patch.ClearWIPLimit {
+		return invalid("WIPLimit and ClearWIPLimit are mutually exclusive")
+	}
+	return c.context.UpdateColumn(ctx, id, patch.Name, patch.Color, patch.WIPLimit, patch.ClearWIPLimit)
+}
+
+// DeleteColumn deletes a column. A column that still has tasks can only be
+// deleted by moving them to moveTasksTo, another column of the same board.
+func (c *Client) DeleteColumn(ctx context.Context, id, moveTasksTo string) error {
+	column, board, err := c.column(ctx, id)
+	if err != nil {
+		return err
+	}
+	count, err := c.columnDelete.ColumnTaskCount(ctx, board.WorkspaceID, column.ID)
+	if err != nil {
+		return err
+	}
+	if count > 0 && moveTasksTo == "" {
+		return invalid(fmt.Sprintf("column has %d tasks: set moveTasksTo to reassign them", count))
+	}
+	if moveTasksTo != "" {
+		if moveTasksTo == column.ID {
+			return invalid("cannot move tasks to the column being deleted")
+		}
+		dest, destBoard, err := c.column(ctx, moveTasksTo)
+		if err != nil {
+			return err
+		}
+		if destBoard.ID != board.ID {
+			return invalid("moveTasksTo must be a column of the same board")
+		}
+		if err := c.columnDelete.ReassignTasks(ctx, board.WorkspaceID, column.ID, dest.ID, strings.ToLower(dest.Name)); err != nil {
+			return err
+		}
+	}
+	return c.columnDelete.DeleteColumn(ctx, column.ID)
+}